@@ -97,6 +97,9 @@ type Routes struct {
 
 	DataRetention *mux.Router // 'api/v4/data_retention'
 
+	EscalationPolicies *mux.Router // 'api/v4/escalation_policies'
+	EscalationPolicy   *mux.Router // 'api/v4/escalation_policies/{policy_id:[A-Za-z0-9]+}'
+
 	Brand *mux.Router // 'api/v4/brand'
 
 	System *mux.Router // 'api/v4/system'
@@ -245,6 +248,8 @@ func Init(srv *app.Server) (*API, error) {
 	api.BaseRoutes.Elasticsearch = api.BaseRoutes.APIRoot.PathPrefix("/elasticsearch").Subrouter()
 	api.BaseRoutes.Bleve = api.BaseRoutes.APIRoot.PathPrefix("/bleve").Subrouter()
 	api.BaseRoutes.DataRetention = api.BaseRoutes.APIRoot.PathPrefix("/data_retention").Subrouter()
+	api.BaseRoutes.EscalationPolicies = api.BaseRoutes.APIRoot.PathPrefix("/escalation_policies").Subrouter()
+	api.BaseRoutes.EscalationPolicy = api.BaseRoutes.EscalationPolicies.PathPrefix("/{policy_id:[A-Za-z0-9]+}").Subrouter()
 
 	api.BaseRoutes.Emojis = api.BaseRoutes.APIRoot.PathPrefix("/emoji").Subrouter()
 	api.BaseRoutes.Emoji = api.BaseRoutes.APIRoot.PathPrefix("/emoji/{emoji_id:[A-Za-z0-9]+}").Subrouter()
@@ -307,6 +312,7 @@ func Init(srv *app.Server) (*API, error) {
 	api.InitElasticsearch()
 	api.InitBleve()
 	api.InitDataRetention()
+	api.InitEscalationPolicy()
 	api.InitBrand()
 	api.InitJob()
 	api.InitCommand()
@@ -64,6 +64,10 @@ func (api *API) InitChannel() {
 	api.BaseRoutes.Channel.Handle("/member_counts_by_group", api.APISessionRequired(channelMemberCountsByGroup)).Methods(http.MethodGet)
 	api.BaseRoutes.Channel.Handle("/common_teams", api.APISessionRequired(getGroupMessageMembersCommonTeams)).Methods(http.MethodGet)
 	api.BaseRoutes.Channel.Handle("/convert_to_channel", api.APISessionRequired(convertGroupMessageToChannel)).Methods(http.MethodPost)
+	api.BaseRoutes.Channel.Handle("/access_policy", api.APISessionRequired(getChannelAccessPolicy)).Methods(http.MethodGet)
+	api.BaseRoutes.Channel.Handle("/access_policy", api.APISessionRequired(updateChannelAccessPolicy)).Methods(http.MethodPut)
+	api.BaseRoutes.Channel.Handle("/access_policy", api.APISessionRequired(deleteChannelAccessPolicy)).Methods(http.MethodDelete)
+	api.BaseRoutes.Channel.Handle("/access_policy/preview", api.APISessionRequired(previewChannelAccessPolicy)).Methods(http.MethodPost)
 
 	api.BaseRoutes.ChannelForUser.Handle("/unread", api.APISessionRequired(getChannelUnread)).Methods(http.MethodGet)
 
@@ -1569,7 +1573,7 @@ func getChannelMembersForTeamForUser(c *Context, w http.ResponseWriter, r *http.
 		return
 	}
 
-	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageSystem) {
+	if !c.AppContext.Session().CanActAsUser(c.Params.UserId) && !c.App.SessionHasPermissionToTeam(*c.AppContext.Session(), c.Params.TeamId, model.PermissionManageSystem) {
 		c.SetPermissionError(model.PermissionManageSystem)
 		return
 	}
@@ -1992,7 +1996,7 @@ func removeChannelMember(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if c.Params.UserId != c.AppContext.Session().UserId {
+	if !c.AppContext.Session().CanActAsUserInChannel(c.Params.UserId, channel.Id, channel.TeamId) {
 		if channel.Type == model.ChannelTypeOpen && !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), channel.Id, model.PermissionManagePublicChannelMembers) {
 			c.SetPermissionError(model.PermissionManagePublicChannelMembers)
 			return
@@ -0,0 +1,91 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestChannelAccessPolicy(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreatePrivateChannel()
+	expression := `user.email_domain == "example.com"`
+
+	t.Run("only admins can manage access policies", func(t *testing.T) {
+		_, resp, err := th.Client.UpdateChannelAccessPolicy(context.Background(), channel.Id, &model.ChannelAccessPolicy{Expression: expression})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetChannelAccessPolicy(context.Background(), channel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = th.Client.DeleteChannelAccessPolicy(context.Background(), channel.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid expressions are refused", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.UpdateChannelAccessPolicy(context.Background(), channel.Id, &model.ChannelAccessPolicy{Expression: `user.email_domain ==`})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("channels without a policy", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.GetChannelAccessPolicy(context.Background(), channel.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("the preview doesn't save the policy", func(t *testing.T) {
+		_, resp, err := th.Client.PreviewChannelAccessPolicy(context.Background(), channel.Id, &model.ChannelAccessPolicy{Expression: expression})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		changes, _, err := th.SystemAdminClient.PreviewChannelAccessPolicy(context.Background(), channel.Id, &model.ChannelAccessPolicy{Expression: expression})
+		require.NoError(t, err)
+		for _, change := range changes {
+			assert.Equal(t, channel.Id, change.TargetId)
+			assert.Equal(t, model.MembershipChangeActionRemove, change.Action)
+		}
+
+		_, resp, err = th.SystemAdminClient.GetChannelAccessPolicy(context.Background(), channel.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	policy, _, err := th.SystemAdminClient.UpdateChannelAccessPolicy(context.Background(), channel.Id, &model.ChannelAccessPolicy{Expression: expression})
+	require.NoError(t, err)
+	assert.Equal(t, channel.Id, policy.ChannelId)
+	assert.Equal(t, expression, policy.Expression)
+
+	policy, _, err = th.SystemAdminClient.GetChannelAccessPolicy(context.Background(), channel.Id)
+	require.NoError(t, err)
+	assert.Equal(t, expression, policy.Expression)
+
+	t.Run("users not satisfying the policy can't be added", func(t *testing.T) {
+		user := th.CreateUser()
+		_, _, err := th.SystemAdminClient.AddTeamMember(context.Background(), th.BasicTeam.Id, user.Id)
+		require.NoError(t, err)
+
+		_, resp, err := th.SystemAdminClient.AddChannelMember(context.Background(), channel.Id, user.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	_, err = th.SystemAdminClient.DeleteChannelAccessPolicy(context.Background(), channel.Id)
+	require.NoError(t, err)
+
+	_, resp, err := th.SystemAdminClient.GetChannelAccessPolicy(context.Background(), channel.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+}
@@ -0,0 +1,275 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+func (api *API) InitEscalationPolicy() {
+	api.BaseRoutes.EscalationPolicies.Handle("", api.APISessionRequired(createEscalationPolicy)).Methods(http.MethodPost)
+	api.BaseRoutes.EscalationPolicies.Handle("", api.APISessionRequired(getEscalationPolicies)).Methods(http.MethodGet)
+	api.BaseRoutes.EscalationPolicy.Handle("", api.APISessionRequired(getEscalationPolicy)).Methods(http.MethodGet)
+	api.BaseRoutes.EscalationPolicy.Handle("", api.APISessionRequired(updateEscalationPolicy)).Methods(http.MethodPut)
+	api.BaseRoutes.EscalationPolicy.Handle("", api.APISessionRequired(deleteEscalationPolicy)).Methods(http.MethodDelete)
+}
+
+// checkEscalationPolicyPermission verifies that the session can manage the
+// policies of the given channel, or the server wide policies when channelID
+// is empty.
+func checkEscalationPolicyPermission(c *Context, channelID string) {
+	if channelID == "" {
+		if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+			c.SetPermissionError(model.PermissionManageSystem)
+		}
+		return
+	}
+
+	channel, appErr := c.App.GetChannel(c.AppContext, channelID)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	switch channel.Type {
+	case model.ChannelTypeOpen:
+		if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), channel.Id, model.PermissionManagePublicChannelProperties) {
+			c.SetPermissionError(model.PermissionManagePublicChannelProperties)
+		}
+	case model.ChannelTypePrivate:
+		if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), channel.Id, model.PermissionManagePrivateChannelProperties) {
+			c.SetPermissionError(model.PermissionManagePrivateChannelProperties)
+		}
+	default:
+		c.Err = model.NewAppError("checkEscalationPolicyPermission", "api.escalation_policy.channel_type.app_error", nil, "", http.StatusBadRequest)
+	}
+}
+
+// checkEscalationStepTargets verifies that the session can post to the
+// channels paged by the steps of the policy, and could message the users and
+// mention the groups they page.
+func checkEscalationStepTargets(c *Context, policy *model.EscalationPolicy) {
+	session := c.AppContext.Session()
+	for _, step := range policy.Steps {
+		if step == nil {
+			continue
+		}
+
+		if step.ChannelId != "" && !c.App.SessionHasPermissionToChannel(c.AppContext, *session, step.ChannelId, model.PermissionCreatePost) {
+			c.SetPermissionError(model.PermissionCreatePost)
+			return
+		}
+
+		if len(step.UserIds) > 0 && !c.App.SessionHasPermissionTo(*session, model.PermissionCreateDirectChannel) {
+			c.SetPermissionError(model.PermissionCreateDirectChannel)
+			return
+		}
+		for _, userID := range step.UserIds {
+			canSee, appErr := c.App.UserCanSeeOtherUser(c.AppContext, session.UserId, userID)
+			if appErr != nil {
+				c.Err = appErr
+				return
+			}
+			if !canSee {
+				c.SetPermissionError(model.PermissionViewMembers)
+				return
+			}
+		}
+
+		if len(step.GroupIds) == 0 {
+			continue
+		}
+		restrictions, appErr := c.App.GetViewUsersRestrictions(c.AppContext, session.UserId)
+		if appErr != nil {
+			c.Err = appErr
+			return
+		}
+		for _, groupID := range step.GroupIds {
+			group, appErr := c.App.GetGroup(groupID, nil, restrictions)
+			if appErr != nil {
+				c.Err = appErr
+				return
+			}
+			// Groups which can't be mentioned can only be paged by those managing groups.
+			if !group.AllowReference && !c.App.SessionHasPermissionToGroup(*session, group.Id, model.PermissionSysconsoleReadUserManagementGroups) {
+				c.SetPermissionError(model.PermissionSysconsoleReadUserManagementGroups)
+				return
+			}
+		}
+	}
+}
+
+func createEscalationPolicy(c *Context, w http.ResponseWriter, r *http.Request) {
+	var policy model.EscalationPolicy
+	if jsonErr := json.NewDecoder(r.Body).Decode(&policy); jsonErr != nil {
+		c.SetInvalidParamWithErr("escalation_policy", jsonErr)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createEscalationPolicy", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameterAuditable(auditRec, "escalation_policy", &policy)
+
+	checkEscalationPolicyPermission(c, policy.ChannelId)
+	if c.Err != nil {
+		return
+	}
+	checkEscalationStepTargets(c, &policy)
+	if c.Err != nil {
+		return
+	}
+
+	policy.CreatorId = c.AppContext.Session().UserId
+	newPolicy, appErr := c.App.CreateEscalationPolicy(&policy)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(newPolicy)
+	auditRec.AddEventObjectType("escalation_policy")
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(newPolicy); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getEscalationPolicies(c *Context, w http.ResponseWriter, r *http.Request) {
+	if c.Params.ChannelId != "" {
+		c.RequireChannelId()
+		if c.Err != nil {
+			return
+		}
+		if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), c.Params.ChannelId, model.PermissionReadChannelContent) {
+			c.SetPermissionError(model.PermissionReadChannelContent)
+			return
+		}
+	} else if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	policies, appErr := c.App.GetEscalationPoliciesForChannel(c.Params.ChannelId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(policies); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getEscalationPolicy(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePolicyId()
+	if c.Err != nil {
+		return
+	}
+
+	policy, appErr := c.App.GetEscalationPolicy(c.Params.PolicyId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if policy.ChannelId != "" {
+		if !c.App.SessionHasPermissionToChannel(c.AppContext, *c.AppContext.Session(), policy.ChannelId, model.PermissionReadChannelContent) {
+			c.SetPermissionError(model.PermissionReadChannelContent)
+			return
+		}
+	} else if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(policy); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateEscalationPolicy(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePolicyId()
+	if c.Err != nil {
+		return
+	}
+
+	var policy model.EscalationPolicy
+	if jsonErr := json.NewDecoder(r.Body).Decode(&policy); jsonErr != nil {
+		c.SetInvalidParamWithErr("escalation_policy", jsonErr)
+		return
+	}
+	policy.Id = c.Params.PolicyId
+
+	auditRec := c.MakeAuditRecord("updateEscalationPolicy", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameterAuditable(auditRec, "escalation_policy", &policy)
+
+	oldPolicy, appErr := c.App.GetEscalationPolicy(c.Params.PolicyId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddEventPriorState(oldPolicy)
+
+	checkEscalationPolicyPermission(c, oldPolicy.ChannelId)
+	if c.Err != nil {
+		return
+	}
+	checkEscalationStepTargets(c, &policy)
+	if c.Err != nil {
+		return
+	}
+
+	updatedPolicy, appErr := c.App.UpdateEscalationPolicy(&policy)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	auditRec.AddEventResultState(updatedPolicy)
+	auditRec.AddEventObjectType("escalation_policy")
+
+	if err := json.NewEncoder(w).Encode(updatedPolicy); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func deleteEscalationPolicy(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePolicyId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("deleteEscalationPolicy", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "policy_id", c.Params.PolicyId)
+
+	policy, appErr := c.App.GetEscalationPolicy(c.Params.PolicyId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	auditRec.AddEventPriorState(policy)
+
+	checkEscalationPolicyPermission(c, policy.ChannelId)
+	if c.Err != nil {
+		return
+	}
+
+	if appErr := c.App.DeleteEscalationPolicy(policy.Id); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
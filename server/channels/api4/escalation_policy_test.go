@@ -0,0 +1,131 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package api4
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestEscalationPolicies(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newPolicy := func(channelID string) *model.EscalationPolicy {
+		return &model.EscalationPolicy{
+			ChannelId: channelID,
+			Priority:  model.PostPriorityUrgent,
+			Steps: []*model.EscalationStep{
+				{AfterCount: 1, UserIds: []string{th.BasicUser2.Id}},
+			},
+		}
+	}
+
+	t.Run("channel policies", func(t *testing.T) {
+		policy, resp, err := th.Client.CreateEscalationPolicy(context.Background(), newPolicy(th.BasicChannel.Id))
+		require.NoError(t, err)
+		CheckCreatedStatus(t, resp)
+		assert.Equal(t, th.BasicUser.Id, policy.CreatorId)
+
+		_, resp, err = th.Client.CreateEscalationPolicy(context.Background(), newPolicy(th.BasicChannel.Id))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		policies, _, err := th.Client.GetEscalationPolicies(context.Background(), th.BasicChannel.Id)
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		assert.Equal(t, policy.Id, policies[0].Id)
+
+		policy.Steps = append(policy.Steps, &model.EscalationStep{AfterCount: 2, ChannelId: th.BasicChannel2.Id})
+		updated, _, err := th.Client.UpdateEscalationPolicy(context.Background(), policy)
+		require.NoError(t, err)
+		assert.Len(t, updated.Steps, 2)
+
+		got, _, err := th.Client.GetEscalationPolicy(context.Background(), policy.Id)
+		require.NoError(t, err)
+		assert.Equal(t, updated.Steps, got.Steps)
+
+		_, err = th.Client.DeleteEscalationPolicy(context.Background(), policy.Id)
+		require.NoError(t, err)
+
+		_, resp, err = th.Client.GetEscalationPolicy(context.Background(), policy.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("invalid policy", func(t *testing.T) {
+		policy := newPolicy(th.BasicChannel.Id)
+		policy.Steps = nil
+		_, resp, err := th.Client.CreateEscalationPolicy(context.Background(), policy)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("requires permission to manage the channel", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PermissionManagePublicChannelProperties.Id, model.ChannelUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionManagePublicChannelProperties.Id, model.ChannelUserRoleId)
+
+		_, resp, err := th.Client.CreateEscalationPolicy(context.Background(), newPolicy(th.BasicChannel.Id))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("requires permission to post in step channels", func(t *testing.T) {
+		policy := newPolicy(th.BasicChannel.Id)
+		policy.Steps[0].ChannelId = th.CreateChannelWithClient(th.SystemAdminClient, model.ChannelTypePrivate).Id
+		_, resp, err := th.Client.CreateEscalationPolicy(context.Background(), policy)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("requires being able to see step users", func(t *testing.T) {
+		th.RemovePermissionFromRole(model.PermissionViewMembers.Id, model.SystemUserRoleId)
+		defer th.AddPermissionToRole(model.PermissionViewMembers.Id, model.SystemUserRoleId)
+
+		policy := newPolicy(th.BasicChannel.Id)
+		policy.Steps[0].UserIds = []string{th.CreateUser().Id}
+		_, resp, err := th.Client.CreateEscalationPolicy(context.Background(), policy)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("requires step groups to allow mentions", func(t *testing.T) {
+		policy := newPolicy(th.BasicChannel.Id)
+		policy.Steps[0].UserIds = nil
+		policy.Steps[0].GroupIds = []string{th.CreateGroup().Id}
+		_, resp, err := th.Client.CreateEscalationPolicy(context.Background(), policy)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("global policies", func(t *testing.T) {
+		_, resp, err := th.Client.CreateEscalationPolicy(context.Background(), newPolicy(""))
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.Client.GetEscalationPolicies(context.Background(), "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		policy, _, err := th.SystemAdminClient.CreateEscalationPolicy(context.Background(), newPolicy(""))
+		require.NoError(t, err)
+
+		policies, _, err := th.SystemAdminClient.GetEscalationPolicies(context.Background(), "")
+		require.NoError(t, err)
+		require.Len(t, policies, 1)
+		assert.Equal(t, policy.Id, policies[0].Id)
+
+		resp, err = th.Client.DeleteEscalationPolicy(context.Background(), policy.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = th.SystemAdminClient.DeleteEscalationPolicy(context.Background(), policy.Id)
+		require.NoError(t, err)
+	})
+}
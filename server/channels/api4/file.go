@@ -508,9 +508,15 @@ func getFile(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	defer fileReader.Close()
 
+	content, size, err := c.App.ApplyFileWillBeDownloadedHook(c.AppContext, info, c.AppContext.Session().UserId, model.FileDownloadTypeFile, fileReader, info.Size)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
 	auditRec.Success()
 
-	web.WriteFileResponse(info.Name, info.MimeType, info.Size, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
+	web.WriteFileResponse(info.Name, info.MimeType, size, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, content, forceDownload, w, r)
 }
 
 func getFileThumbnail(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -556,7 +562,13 @@ func getFileThumbnail(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	defer fileReader.Close()
 
-	web.WriteFileResponse(info.Name, ThumbnailImageType, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
+	content, size, err := c.App.ApplyFileWillBeDownloadedHook(c.AppContext, info, c.AppContext.Session().UserId, model.FileDownloadTypeThumbnail, fileReader, 0)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	web.WriteFileResponse(info.Name, ThumbnailImageType, size, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, content, forceDownload, w, r)
 }
 
 func getFileLink(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -656,7 +668,13 @@ func getFilePreview(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	defer fileReader.Close()
 
-	web.WriteFileResponse(info.Name, PreviewImageType, 0, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, forceDownload, w, r)
+	content, size, err := c.App.ApplyFileWillBeDownloadedHook(c.AppContext, info, c.AppContext.Session().UserId, model.FileDownloadTypePreview, fileReader, 0)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	web.WriteFileResponse(info.Name, PreviewImageType, size, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, content, forceDownload, w, r)
 }
 
 func getFileInfo(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -734,7 +752,13 @@ func getPublicFile(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 	defer fileReader.Close()
 
-	web.WriteFileResponse(info.Name, info.MimeType, info.Size, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, fileReader, false, w, r)
+	content, size, err := c.App.ApplyFileWillBeDownloadedHook(c.AppContext, info, "", model.FileDownloadTypePublic, fileReader, info.Size)
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	web.WriteFileResponse(info.Name, info.MimeType, size, time.Unix(0, info.UpdateAt*int64(1000*1000)), *c.App.Config().ServiceSettings.WebserverMode, content, false, w, r)
 }
 
 func searchFilesInTeam(c *Context, w http.ResponseWriter, r *http.Request) {
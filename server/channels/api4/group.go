@@ -28,10 +28,22 @@ func (api *API) InitGroup() {
 	// POST /api/v4/groups
 	api.BaseRoutes.Groups.Handle("", api.APISessionRequired(createGroup)).Methods(http.MethodPost)
 
+	// GET /api/v4/groups/attribute_rules/preview
+	api.BaseRoutes.Groups.Handle("/attribute_rules/preview",
+		api.APISessionRequired(previewAttributeGroupSync)).Methods(http.MethodGet)
+
 	// GET /api/v4/groups/:group_id
 	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}",
 		api.APISessionRequired(getGroup)).Methods(http.MethodGet)
 
+	// GET /api/v4/groups/:group_id/attribute_rule
+	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}/attribute_rule",
+		api.APISessionRequired(getGroupAttributeRule)).Methods(http.MethodGet)
+
+	// PUT /api/v4/groups/:group_id/attribute_rule
+	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}/attribute_rule",
+		api.APISessionRequired(updateGroupAttributeRule)).Methods(http.MethodPut)
+
 	// PUT /api/v4/groups/:group_id/patch
 	api.BaseRoutes.Groups.Handle("/{group_id:[A-Za-z0-9]+}/patch",
 		api.APISessionRequired(patchGroup)).Methods(http.MethodPut)
@@ -129,7 +141,7 @@ func getGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if group.Source == model.GroupSourceLdap {
+	if group.Source == model.GroupSourceLdap || group.Source == model.GroupSourceOIDC || group.Source == model.GroupSourceScim || group.Source == model.GroupSourceAttribute {
 		if !c.App.SessionHasPermissionToGroup(*c.AppContext.Session(), c.Params.GroupId, model.PermissionSysconsoleReadUserManagementGroups) {
 			c.SetPermissionError(model.PermissionSysconsoleReadUserManagementGroups)
 			return
@@ -165,6 +177,11 @@ func createGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if group.Source == model.GroupSourceAttribute {
+		createAttributeGroup(c, w, group)
+		return
+	}
+
 	if group.Source != model.GroupSourceCustom {
 		c.Err = model.NewAppError("createGroup", "app.group.crud_permission", nil, "", http.StatusBadRequest)
 		return
@@ -215,6 +232,43 @@ func createGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+// createAttributeGroup creates a group whose members are the users matching its attribute rule.
+func createAttributeGroup(c *Context, w http.ResponseWriter, group *model.GroupWithUserIds) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteUserManagementGroups) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteUserManagementGroups)
+		return
+	}
+
+	// The members of attribute groups are only set by their attribute rule.
+	if group.GetRemoteId() != "" || len(group.UserIds) > 0 {
+		c.Err = model.NewAppError("createGroup", "api.group.create_attribute_group.invalid.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameterAuditable(auditRec, "group", group)
+
+	newGroup, appErr := c.App.CreateGroup(&group.Group)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.AddEventResultState(newGroup)
+	auditRec.AddEventObjectType("group")
+	js, err := json.Marshal(newGroup)
+	if err != nil {
+		c.Err = model.NewAppError("createGroup", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	auditRec.Success()
+	w.WriteHeader(http.StatusCreated)
+	if _, err := w.Write(js); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func patchGroup(c *Context, w http.ResponseWriter, r *http.Request) {
 	permissionErr := requireLicense(c)
 	if permissionErr != nil {
@@ -655,7 +709,7 @@ func verifyLinkUnlinkPermission(c *Context, syncableType model.GroupSyncableType
 		return appErr
 	}
 
-	if group.Source != model.GroupSourceLdap {
+	if group.Source != model.GroupSourceLdap && group.Source != model.GroupSourceScim && group.Source != model.GroupSourceAttribute {
 		return model.NewAppError("Api4.linkGroupSyncable", "app.group.crud_permission", nil, "", http.StatusBadRequest)
 	}
 
@@ -808,7 +862,7 @@ func getGroupsByUserId(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+	if !c.AppContext.Session().CanActAsUser(c.Params.UserId) && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
 		c.SetPermissionError(model.PermissionManageSystem)
 		return
 	}
@@ -1436,7 +1490,7 @@ func hasPermissionToReadGroupMembers(c *web.Context, groupID string) *model.AppE
 		return lcErr
 	}
 
-	if group.Source == model.GroupSourceLdap && !group.AllowReference {
+	if (group.Source == model.GroupSourceLdap || group.Source == model.GroupSourceOIDC || group.Source == model.GroupSourceScim || group.Source == model.GroupSourceAttribute) && !group.AllowReference {
 		if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementGroups) {
 			return model.MakePermissionError(c.AppContext.Session(), []*model.Permission{model.PermissionSysconsoleReadUserManagementGroups})
 		}
@@ -1471,3 +1525,106 @@ func licensedAndConfiguredForGroupBySource(app app.AppIface, source model.GroupS
 
 	return nil
 }
+
+func getGroupAttributeRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	permissionErr := requireLicense(c)
+	if permissionErr != nil {
+		c.Err = permissionErr
+		return
+	}
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementGroups) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementGroups)
+		return
+	}
+
+	rule, appErr := c.App.GetGroupAttributeRule(c.Params.GroupId)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func updateGroupAttributeRule(c *Context, w http.ResponseWriter, r *http.Request) {
+	permissionErr := requireLicense(c)
+	if permissionErr != nil {
+		c.Err = permissionErr
+		return
+	}
+	c.RequireGroupId()
+	if c.Err != nil {
+		return
+	}
+
+	var rule *model.GroupAttributeRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil || rule == nil {
+		c.SetInvalidParamWithErr("attribute_rule", err)
+		return
+	}
+	rule.GroupId = c.Params.GroupId
+
+	auditRec := c.MakeAuditRecord("updateGroupAttributeRule", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "group_id", c.Params.GroupId)
+	audit.AddEventParameter(auditRec, "attribute", rule.Attribute)
+	audit.AddEventParameter(auditRec, "value", rule.Value)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWriteUserManagementGroups) {
+		c.SetPermissionError(model.PermissionSysconsoleWriteUserManagementGroups)
+		return
+	}
+
+	rule, appErr := c.App.SaveGroupAttributeRule(c.AppContext, rule)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+
+	if err := json.NewEncoder(w).Encode(rule); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+// previewAttributeGroupSync returns the memberships the next synchronization of the attribute
+// groups would change, optionally for a single group.
+func previewAttributeGroupSync(c *Context, w http.ResponseWriter, r *http.Request) {
+	permissionErr := requireLicense(c)
+	if permissionErr != nil {
+		c.Err = permissionErr
+		return
+	}
+
+	groupID := r.URL.Query().Get("group_id")
+	if groupID != "" && !model.IsValidId(groupID) {
+		c.SetInvalidURLParam("group_id")
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementGroups) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementGroups)
+		return
+	}
+
+	changes, appErr := c.App.PreviewAttributeGroupSync(c.AppContext, groupID)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+	if changes == nil {
+		changes = []*model.MembershipChange{}
+	}
+
+	if err := json.NewEncoder(w).Encode(changes); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
@@ -2110,3 +2110,97 @@ func TestDeleteMembersFromGroup(t *testing.T) {
 	require.Error(t, deleteErr)
 	CheckBadRequestStatus(t, response)
 }
+
+func TestAttributeGroups(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.Srv().SetLicense(model.NewTestLicense("ldap"))
+
+	g := &model.Group{
+		DisplayName: "Engineering",
+		Name:        model.NewPointer("engineering" + model.NewId()),
+		Source:      model.GroupSourceAttribute,
+	}
+
+	t.Run("only admins can create attribute groups", func(t *testing.T) {
+		_, resp, err := th.Client.CreateGroup(context.Background(), g)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("attribute groups can't have a remote id", func(t *testing.T) {
+		withRemoteID := *g
+		withRemoteID.RemoteId = model.NewPointer("engineering")
+		_, resp, err := th.SystemAdminClient.CreateGroup(context.Background(), &withRemoteID)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	group, resp, err := th.SystemAdminClient.CreateGroup(context.Background(), g)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, model.GroupSourceAttribute, group.Source)
+
+	_, resp, err = th.SystemAdminClient.GetGroupAttributeRule(context.Background(), group.Id)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	rule := &model.GroupAttributeRule{Attribute: "department", Value: "Engineering"}
+
+	t.Run("only admins can set attribute rules", func(t *testing.T) {
+		_, resp, err := th.Client.UpdateGroupAttributeRule(context.Background(), group.Id, rule)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("invalid rules are rejected", func(t *testing.T) {
+		_, resp, err := th.SystemAdminClient.UpdateGroupAttributeRule(context.Background(), group.Id, &model.GroupAttributeRule{Attribute: "department"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("rules can only be set on attribute groups", func(t *testing.T) {
+		ldapGroup := th.CreateGroup()
+		_, resp, err := th.SystemAdminClient.UpdateGroupAttributeRule(context.Background(), ldapGroup.Id, rule)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	savedRule, _, err := th.SystemAdminClient.UpdateGroupAttributeRule(context.Background(), group.Id, rule)
+	require.NoError(t, err)
+	assert.Equal(t, group.Id, savedRule.GroupId)
+	assert.Equal(t, "department", savedRule.Attribute)
+
+	fetchedRule, _, err := th.SystemAdminClient.GetGroupAttributeRule(context.Background(), group.Id)
+	require.NoError(t, err)
+	assert.Equal(t, savedRule, fetchedRule)
+
+	t.Run("the preview lists the memberships to change", func(t *testing.T) {
+		// The rule is saved without synchronizing the group, like rules users logged in before.
+		salesGroup, _, err := th.SystemAdminClient.CreateGroup(context.Background(), &model.Group{
+			DisplayName: "Sales",
+			Name:        model.NewPointer("sales" + model.NewId()),
+			Source:      model.GroupSourceAttribute,
+		})
+		require.NoError(t, err)
+		_, err = th.App.Srv().Store().AttributeGroup().SaveRule(&model.GroupAttributeRule{GroupId: salesGroup.Id, Attribute: "department", Value: "Sales"})
+		require.NoError(t, err)
+		err = th.App.Srv().Store().AttributeGroup().SetUserAttributes(th.BasicUser2.Id, map[string][]string{"department": {"Sales"}})
+		require.NoError(t, err)
+
+		_, resp, err := th.Client.PreviewAttributeGroupSync(context.Background(), "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = th.SystemAdminClient.PreviewAttributeGroupSync(context.Background(), "invalid")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		changes, _, err := th.SystemAdminClient.PreviewAttributeGroupSync(context.Background(), salesGroup.Id)
+		require.NoError(t, err)
+		assert.Equal(t, []*model.MembershipChange{
+			{UserId: th.BasicUser2.Id, Type: model.MembershipChangeTypeGroup, TargetId: salesGroup.Id, Action: model.MembershipChangeActionAdd},
+		}, changes)
+	})
+}
@@ -45,6 +45,14 @@ func createOAuthApp(c *Context, w http.ResponseWriter, r *http.Request) {
 		oauthApp.IsTrusted = false
 	}
 
+	// Only those managing the bot can let an app act as it with the client credentials grant.
+	if oauthApp.BotUserId != "" {
+		if err := c.App.SessionHasPermissionToManageBot(c.AppContext, *c.AppContext.Session(), oauthApp.BotUserId); err != nil {
+			c.Err = err
+			return
+		}
+	}
+
 	oauthApp.CreatorId = c.AppContext.Session().UserId
 
 	rapp, err := c.App.CreateOAuthApp(&oauthApp)
@@ -109,6 +117,13 @@ func updateOAuthApp(c *Context, w http.ResponseWriter, r *http.Request) {
 		oauthApp.IsTrusted = oldOAuthApp.IsTrusted
 	}
 
+	if oauthApp.BotUserId != "" && oauthApp.BotUserId != oldOAuthApp.BotUserId {
+		if err = c.App.SessionHasPermissionToManageBot(c.AppContext, *c.AppContext.Session(), oauthApp.BotUserId); err != nil {
+			c.Err = err
+			return
+		}
+	}
+
 	updatedOAuthApp, err := c.App.UpdateOAuthApp(oldOAuthApp, &oauthApp)
 	if err != nil {
 		c.Err = err
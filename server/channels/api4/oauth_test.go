@@ -77,6 +77,46 @@ func TestCreateOAuthApp(t *testing.T) {
 	CheckNotImplementedStatus(t, resp)
 }
 
+func TestCreateOAuthAppWithBot(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	defaultRolePermissions := th.SaveDefaultRolePermissions()
+	defer th.RestoreDefaultRolePermissions(defaultRolePermissions)
+	th.AddPermissionToRole(model.PermissionManageOAuth.Id, model.SystemUserRoleId)
+	th.AddPermissionToRole(model.PermissionManageBots.Id, model.SystemUserRoleId)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	ownBot, appErr := th.App.CreateBot(th.Context, &model.Bot{Username: GenerateTestUsername(), OwnerId: th.BasicUser.Id})
+	require.Nil(t, appErr)
+	otherBot, appErr := th.App.CreateBot(th.Context, &model.Bot{Username: GenerateTestUsername(), OwnerId: th.BasicUser2.Id})
+	require.Nil(t, appErr)
+
+	oapp := &model.OAuthApp{Name: GenerateTestAppName(), Homepage: "https://nowhere.com", CallbackUrls: []string{"https://nowhere.com"}, BotUserId: otherBot.UserId}
+
+	// Users can't see the bots of others without the permission to read them.
+	_, resp, err := th.Client.CreateOAuthApp(context.Background(), oapp)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	oapp.BotUserId = ownBot.UserId
+	rapp, resp, err := th.Client.CreateOAuthApp(context.Background(), oapp)
+	require.NoError(t, err)
+	CheckCreatedStatus(t, resp)
+	assert.Equal(t, ownBot.UserId, rapp.BotUserId)
+
+	rapp.BotUserId = otherBot.UserId
+	_, resp, err = th.Client.UpdateOAuthApp(context.Background(), rapp)
+	require.Error(t, err)
+	CheckNotFoundStatus(t, resp)
+
+	rapp.BotUserId = otherBot.UserId
+	rapp, _, err = th.SystemAdminClient.UpdateOAuthApp(context.Background(), rapp)
+	require.NoError(t, err)
+	assert.Equal(t, otherBot.UserId, rapp.BotUserId)
+}
+
 func TestUpdateOAuthApp(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -34,11 +34,16 @@ func (api *API) InitPlugin() {
 	api.BaseRoutes.Plugins.Handle("/statuses", api.APISessionRequired(getPluginStatuses)).Methods(http.MethodGet)
 	api.BaseRoutes.Plugin.Handle("/enable", api.APISessionRequired(enablePlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugin.Handle("/disable", api.APISessionRequired(disablePlugin)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugin.Handle("/capabilities/approve", api.APISessionRequired(approvePluginCapabilities)).Methods(http.MethodPost)
 
 	api.BaseRoutes.Plugins.Handle("/webapp", api.APIHandler(getWebappPlugins)).Methods(http.MethodGet)
 
 	api.BaseRoutes.Plugins.Handle("/marketplace", api.APISessionRequired(getMarketplacePlugins)).Methods(http.MethodGet)
 
+	api.BaseRoutes.Plugins.Handle("/marketplace/catalog", api.APISessionRequired(importMarketplaceCatalog, handlerParamFileAPI)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugins.Handle("/marketplace/catalog", api.APISessionRequired(getMarketplaceCatalog)).Methods(http.MethodGet)
+	api.BaseRoutes.Plugins.Handle("/marketplace/catalog/{plugin_id:[A-Za-z0-9\\_\\-\\.]+}", api.APISessionRequired(removeMarketplaceCatalogPlugin)).Methods(http.MethodDelete)
+
 	api.BaseRoutes.Plugins.Handle("/marketplace/first_admin_visit", api.APIHandler(setFirstAdminVisitMarketplaceStatus)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugins.Handle("/marketplace/first_admin_visit", api.APISessionRequired(getFirstAdminVisitMarketplaceStatus)).Methods(http.MethodGet)
 }
@@ -321,6 +326,119 @@ func getMarketplacePlugins(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func importMarketplaceCatalog(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().PluginSettings.Enable {
+		c.Err = model.NewAppError("importMarketplaceCatalog", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("importMarketplaceCatalog", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWritePlugins) {
+		c.SetPermissionError(model.PermissionSysconsoleWritePlugins)
+		return
+	}
+
+	if err := r.ParseMultipartForm(MaxPluginMemory); err != nil {
+		if err.Error() == "http: request body too large" {
+			c.Err = model.NewAppError("importMarketplaceCatalog", "api.plugin.upload.file_too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	m := r.MultipartForm
+
+	catalogArray, ok := m.File["catalog"]
+	if !ok || len(catalogArray) == 0 {
+		c.Err = model.NewAppError("importMarketplaceCatalog", "api.plugin.marketplace_catalog.no_file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+	audit.AddEventParameter(auditRec, "filename", catalogArray[0].Filename)
+
+	file, err := catalogArray[0].Open()
+	if err != nil {
+		c.Err = model.NewAppError("importMarketplaceCatalog", "api.plugin.upload.file.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+	defer file.Close()
+
+	replace := len(m.Value["replace"]) > 0 && m.Value["replace"][0] == "true"
+	audit.AddEventParameter(auditRec, "replace", replace)
+
+	plugins, appErr := c.App.ImportMarketplaceCatalog(file, replace)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	for _, plugin := range plugins {
+		auditRec.AddMeta(plugin.Manifest.Id, plugin.Manifest.Version)
+	}
+
+	w.WriteHeader(http.StatusCreated)
+	if err := json.NewEncoder(w).Encode(plugins); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getMarketplaceCatalog(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().PluginSettings.Enable {
+		c.Err = model.NewAppError("getMarketplaceCatalog", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadPlugins) {
+		c.SetPermissionError(model.PermissionSysconsoleReadPlugins)
+		return
+	}
+
+	plugins, appErr := c.App.GetMarketplaceCatalog()
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(plugins); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func removeMarketplaceCatalogPlugin(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePluginId()
+	if c.Err != nil {
+		return
+	}
+
+	if !*c.App.Config().PluginSettings.Enable {
+		c.Err = model.NewAppError("removeMarketplaceCatalogPlugin", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("removeMarketplaceCatalogPlugin", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "plugin_id", c.Params.PluginId)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWritePlugins) {
+		c.SetPermissionError(model.PermissionSysconsoleWritePlugins)
+		return
+	}
+
+	version := r.URL.Query().Get("version")
+	audit.AddEventParameter(auditRec, "version", version)
+
+	if appErr := c.App.RemoveMarketplaceCatalogPlugin(c.Params.PluginId, version); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	ReturnStatusOK(w)
+}
+
 func enablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePluginId()
 	if c.Err != nil {
@@ -350,6 +468,45 @@ func enablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 	ReturnStatusOK(w)
 }
 
+func approvePluginCapabilities(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequirePluginId()
+	if c.Err != nil {
+		return
+	}
+
+	if !*c.App.Config().PluginSettings.Enable {
+		c.Err = model.NewAppError("approvePluginCapabilities", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+		return
+	}
+
+	capabilities, err := model.SortedArrayFromJSON(r.Body)
+	if err != nil {
+		c.Err = model.NewAppError("approvePluginCapabilities", model.PayloadParseError, nil, "", http.StatusBadRequest).Wrap(err)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("approvePluginCapabilities", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "plugin_id", c.Params.PluginId)
+	audit.AddEventParameter(auditRec, "capabilities", capabilities)
+
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleWritePlugins) {
+		c.SetPermissionError(model.PermissionSysconsoleWritePlugins)
+		return
+	}
+
+	info, appErr := c.App.ApprovePluginCapabilities(c.Params.PluginId, capabilities)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	if err := json.NewEncoder(w).Encode(info); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func disablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePluginId()
 	if c.Err != nil {
@@ -370,7 +527,10 @@ func disablePlugin(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if err := c.App.DisablePlugin(c.Params.PluginId); err != nil {
+	force, _ := strconv.ParseBool(r.URL.Query().Get("force"))
+	audit.AddEventParameter(auditRec, "force", force)
+
+	if err := c.App.DisablePlugin(c.Params.PluginId, force); err != nil {
 		c.Err = err
 		return
 	}
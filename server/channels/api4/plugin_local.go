@@ -17,8 +17,12 @@ func (api *API) InitPluginLocal() {
 	api.BaseRoutes.Plugin.Handle("", api.APILocal(removePlugin)).Methods(http.MethodDelete)
 	api.BaseRoutes.Plugin.Handle("/enable", api.APILocal(enablePlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugin.Handle("/disable", api.APILocal(disablePlugin)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugin.Handle("/capabilities/approve", api.APILocal(approvePluginCapabilities)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugins.Handle("/marketplace", api.APILocal(installMarketplacePlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugins.Handle("/marketplace", api.APILocal(getMarketplacePlugins)).Methods(http.MethodGet)
+	api.BaseRoutes.Plugins.Handle("/marketplace/catalog", api.APILocal(importMarketplaceCatalog, handlerParamFileAPI)).Methods(http.MethodPost)
+	api.BaseRoutes.Plugins.Handle("/marketplace/catalog", api.APILocal(getMarketplaceCatalog)).Methods(http.MethodGet)
+	api.BaseRoutes.Plugins.Handle("/marketplace/catalog/{plugin_id:[A-Za-z0-9\\_\\-\\.]+}", api.APILocal(removeMarketplaceCatalogPlugin)).Methods(http.MethodDelete)
 	api.BaseRoutes.Plugins.Handle("/reattach", api.APILocal(reattachPlugin)).Methods(http.MethodPost)
 	api.BaseRoutes.Plugin.Handle("/detach", api.APILocal(detachPlugin)).Methods(http.MethodPost)
 }
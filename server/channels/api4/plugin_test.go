@@ -480,6 +480,49 @@ func TestDisableOnRemove(t *testing.T) {
 	}
 }
 
+func TestApprovePluginCapabilities(t *testing.T) {
+	path, _ := fileutils.FindDir("tests")
+	tarData, err := os.ReadFile(filepath.Join(path, "testplugin.tar.gz"))
+	require.NoError(t, err)
+
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.Enable = true
+		*cfg.PluginSettings.EnableUploads = true
+	})
+
+	manifest, _, err := th.SystemAdminClient.UploadPlugin(context.Background(), bytes.NewReader(tarData))
+	require.NoError(t, err)
+
+	t.Run("regular user", func(t *testing.T) {
+		_, resp, err := th.Client.ApprovePluginCapabilities(context.Background(), manifest.Id, []string{model.PluginCapabilityReadPosts})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		_, resp, err := client.ApprovePluginCapabilities(context.Background(), manifest.Id, []string{"read_everything"})
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+
+		_, resp, err = client.ApprovePluginCapabilities(context.Background(), "missing", []string{model.PluginCapabilityReadPosts})
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+
+		info, _, err := client.ApprovePluginCapabilities(context.Background(), manifest.Id, []string{model.PluginCapabilityReadPosts})
+		require.NoError(t, err)
+		require.Equal(t, manifest.Id, info.Id)
+		require.Equal(t, []string{model.PluginCapabilityReadPosts}, info.ApprovedCapabilities)
+
+		pluginsResp, _, err := client.GetPlugins(context.Background())
+		require.NoError(t, err)
+		require.Len(t, pluginsResp.Inactive, 1)
+		require.Equal(t, []string{model.PluginCapabilityReadPosts}, pluginsResp.Inactive[0].ApprovedCapabilities)
+	})
+}
+
 func TestGetMarketplacePlugins(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
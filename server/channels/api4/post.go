@@ -746,6 +746,12 @@ func searchPostsInTeam(c *Context, w http.ResponseWriter, r *http.Request) {
 }
 
 func searchPostsInAllTeams(c *Context, w http.ResponseWriter, r *http.Request) {
+	// Tokens restricted to some teams or channels can only search the teams they're restricted to.
+	if c.AppContext.Session().GetUserAccessTokenScopes().RestrictsResources() {
+		c.SetPermissionError(model.PermissionViewTeam)
+		return
+	}
+
 	searchPosts(c, w, r, "")
 }
 
@@ -803,6 +809,11 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request, teamId stri
 		return
 	}
 
+	if err = filterPostSearchResultsByScopes(c, results); err != nil {
+		c.Err = err
+		return
+	}
+
 	clientPostList := c.App.PreparePostListForClient(c.AppContext, results.PostList)
 	clientPostList, err = c.App.SanitizePostListMetadataForUser(c.AppContext, clientPostList, c.AppContext.Session().UserId)
 	if err != nil {
@@ -818,6 +829,41 @@ func searchPosts(c *Context, w http.ResponseWriter, r *http.Request, teamId stri
 	}
 }
 
+// filterPostSearchResultsByScopes removes the posts of the channels which the scopes of the
+// session don't cover, such as direct messages found by a token restricted to a team.
+func filterPostSearchResultsByScopes(c *Context, results *model.PostSearchResults) *model.AppError {
+	scopes := c.AppContext.Session().GetUserAccessTokenScopes()
+	if !scopes.RestrictsResources() || len(results.Posts) == 0 {
+		return nil
+	}
+
+	channelIDs := make([]string, 0, len(results.Posts))
+	for _, post := range results.Posts {
+		channelIDs = append(channelIDs, post.ChannelId)
+	}
+	channels, err := c.App.GetChannels(c.AppContext, channelIDs)
+	if err != nil {
+		return err
+	}
+	allowed := make(map[string]bool, len(channels))
+	for _, channel := range channels {
+		allowed[channel.Id] = scopes.AllowsChannel(channel.Id, channel.TeamId)
+	}
+
+	order := results.Order[:0]
+	for _, postID := range results.Order {
+		if post, ok := results.Posts[postID]; ok && allowed[post.ChannelId] {
+			order = append(order, postID)
+			continue
+		}
+		delete(results.Posts, postID)
+		delete(results.Matches, postID)
+	}
+	results.Order = order
+
+	return nil
+}
+
 func updatePost(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequirePostId()
 	if c.Err != nil {
@@ -965,7 +1011,7 @@ func setPostUnread(c *Context, w http.ResponseWriter, r *http.Request) {
 	props := model.MapBoolFromJSON(r.Body)
 	collapsedThreadsSupported := props["collapsed_threads_supported"]
 
-	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
 		c.SetPermissionError(model.PermissionEditOtherUsers)
 		return
 	}
@@ -990,7 +1036,7 @@ func setPostReminder(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if c.AppContext.Session().UserId != c.Params.UserId && !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
 		c.SetPermissionError(model.PermissionEditOtherUsers)
 		return
 	}
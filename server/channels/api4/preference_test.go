@@ -282,6 +282,27 @@ func TestUpdatePreferences(t *testing.T) {
 	require.Error(t, err)
 	CheckForbiddenStatus(t, resp)
 
+	t.Run("calendar preferences are managed by the server", func(t *testing.T) {
+		for _, name := range []string{model.PreferenceNameCalendarAppliedStatus, model.PreferenceNameCalendarFeed} {
+			preferences := model.Preferences{
+				{
+					UserId:   user1.Id,
+					Category: model.PreferenceCategoryCalendar,
+					Name:     name,
+					Value:    model.PreferenceCalendarFeedFile,
+				},
+			}
+
+			resp, err := client.UpdatePreferences(context.Background(), user1.Id, preferences)
+			require.Error(t, err)
+			CheckForbiddenStatus(t, resp)
+
+			resp, err = client.DeletePreferences(context.Background(), user1.Id, preferences)
+			require.Error(t, err)
+			CheckForbiddenStatus(t, resp)
+		}
+	})
+
 	_, err = client.Logout(context.Background())
 	require.NoError(t, err)
 	resp, err = client.UpdatePreferences(context.Background(), user1.Id, preferences1)
@@ -5,10 +5,12 @@ package api4
 
 import (
 	"encoding/json"
+	"io"
 	"net/http"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/app"
 )
 
 func (api *API) InitStatus() {
@@ -17,6 +19,9 @@ func (api *API) InitStatus() {
 	api.BaseRoutes.User.Handle("/status", api.APISessionRequired(updateUserStatus)).Methods(http.MethodPut)
 	api.BaseRoutes.User.Handle("/status/custom", api.APISessionRequired(updateUserCustomStatus)).Methods(http.MethodPut)
 	api.BaseRoutes.User.Handle("/status/custom", api.APISessionRequired(removeUserCustomStatus)).Methods(http.MethodDelete)
+	api.BaseRoutes.User.Handle("/status/calendar", api.APISessionRequired(setUserCalendarFeed)).Methods(http.MethodPut)
+	api.BaseRoutes.User.Handle("/status/calendar/file", api.APISessionRequired(uploadUserCalendar)).Methods(http.MethodPost)
+	api.BaseRoutes.User.Handle("/status/calendar", api.APISessionRequired(removeUserCalendar)).Methods(http.MethodDelete)
 
 	// Both these handlers are for removing the recent custom status but the one with the POST method should be preferred
 	// as DELETE method doesn't support request body in the mobile app.
@@ -219,3 +224,77 @@ func removeUserRecentCustomStatus(c *Context, w http.ResponseWriter, r *http.Req
 
 	ReturnStatusOK(w)
 }
+
+func setUserCalendarFeed(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	props := model.MapFromJSON(r.Body)
+	feedURL := props["url"]
+	if feedURL == "" {
+		c.SetInvalidParam("url")
+		return
+	}
+
+	if err := c.App.SetCalendarFeed(c.AppContext, c.Params.UserId, feedURL); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func uploadUserCalendar(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	data, err := io.ReadAll(io.LimitReader(r.Body, app.MaxCalendarSize+1))
+	if err != nil {
+		c.Err = model.NewAppError("uploadUserCalendar", "api.status.calendar.read.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		return
+	}
+	if len(data) == 0 {
+		c.SetInvalidParam("file")
+		return
+	}
+
+	if appErr := c.App.UploadCalendarFile(c.AppContext, c.Params.UserId, data); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	ReturnStatusOK(w)
+}
+
+func removeUserCalendar(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if err := c.App.DeleteCalendar(c.AppContext, c.Params.UserId); err != nil {
+		c.Err = err
+		return
+	}
+
+	ReturnStatusOK(w)
+}
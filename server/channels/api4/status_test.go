@@ -420,3 +420,67 @@ func TestRemoveUserCustomStatus(t *testing.T) {
 		assert.Nil(t, customStatus)
 	})
 }
+
+func TestUserCalendar(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+	client := th.Client
+
+	calendar := []byte(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:Meeting",
+		"DTSTART:" + time.Now().Add(-time.Hour).UTC().Format("20060102T150405Z"),
+		"DTEND:" + time.Now().Add(time.Hour).UTC().Format("20060102T150405Z"),
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n"))
+
+	t.Run("disabled", func(t *testing.T) {
+		resp, err := client.UploadUserCalendar(context.Background(), th.BasicUser.Id, calendar)
+		require.Error(t, err)
+		CheckNotImplementedStatus(t, resp)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCalendarStatusSync = true })
+
+	t.Run("upload sets the status", func(t *testing.T) {
+		_, err := client.UploadUserCalendar(context.Background(), th.BasicUser.Id, calendar)
+		require.NoError(t, err)
+
+		status, _, err := client.GetUserStatus(context.Background(), th.BasicUser.Id, "")
+		require.NoError(t, err)
+		assert.Equal(t, model.StatusDnd, status.Status)
+
+		_, err = client.RemoveUserCalendar(context.Background(), th.BasicUser.Id)
+		require.NoError(t, err)
+
+		status, _, err = client.GetUserStatus(context.Background(), th.BasicUser.Id, "")
+		require.NoError(t, err)
+		assert.NotEqual(t, model.StatusDnd, status.Status)
+	})
+
+	t.Run("invalid calendar", func(t *testing.T) {
+		resp, err := client.UploadUserCalendar(context.Background(), th.BasicUser.Id, []byte("not a calendar"))
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("invalid url", func(t *testing.T) {
+		resp, err := client.SetUserCalendarFeed(context.Background(), th.BasicUser.Id, "ftp://example.com/calendar.ics")
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+
+	t.Run("no calendar to remove", func(t *testing.T) {
+		resp, err := client.RemoveUserCalendar(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		resp, err := client.UploadUserCalendar(context.Background(), th.BasicUser2.Id, calendar)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+}
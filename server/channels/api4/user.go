@@ -60,6 +60,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/auth", api.APISessionRequiredTrustRequester(updateUserAuth)).Methods(http.MethodPut)
 
 	api.BaseRoutes.User.Handle("/mfa", api.APISessionRequiredMfa(updateUserMfa)).Methods(http.MethodPut)
+	api.BaseRoutes.User.Handle("/unlock", api.APISessionRequired(unlockUser)).Methods(http.MethodPost)
 	api.BaseRoutes.User.Handle("/mfa/generate", api.APISessionRequiredMfa(generateMfaSecret)).Methods(http.MethodPost)
 
 	api.BaseRoutes.Users.Handle("/login", api.APIHandler(login)).Methods(http.MethodPost)
@@ -76,6 +77,8 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsForUser)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/sessions/revoke/all", api.APISessionRequired(revokeAllSessionsAllUsers)).Methods(http.MethodPost)
 	api.BaseRoutes.Users.Handle("/sessions/device", api.APISessionRequired(handleDeviceProps)).Methods(http.MethodPut)
+	api.BaseRoutes.User.Handle("/sessions/activity", api.APISessionRequired(getSessionActivities)).Methods(http.MethodGet)
+	api.BaseRoutes.Users.Handle("/sessions/activity/anomalies", api.APISessionRequired(getSessionAnomalies)).Methods(http.MethodGet)
 	api.BaseRoutes.User.Handle("/audits", api.APISessionRequired(getUserAudits)).Methods(http.MethodGet)
 
 	api.BaseRoutes.User.Handle("/tokens", api.APISessionRequired(createUserAccessToken)).Methods(http.MethodPost)
@@ -96,6 +99,7 @@ func (api *API) InitUser() {
 	api.BaseRoutes.User.Handle("/channel_members", api.APISessionRequired(getChannelMembersForUser)).Methods(http.MethodGet)
 
 	api.BaseRoutes.Users.Handle("/invalid_emails", api.APISessionRequired(getUsersWithInvalidEmails)).Methods(http.MethodGet)
+	api.BaseRoutes.Users.Handle("/expired_passwords", api.APISessionRequired(getUsersWithExpiredPasswords)).Methods(http.MethodGet)
 
 	api.BaseRoutes.UserThreads.Handle("", api.APISessionRequired(getThreadsForUser)).Methods(http.MethodGet)
 	api.BaseRoutes.UserThreads.Handle("/read", api.APISessionRequired(updateReadStateAllThreadsByUser)).Methods(http.MethodPut)
@@ -1630,6 +1634,33 @@ func updateUserAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func unlockUser(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("unlockUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	audit.AddEventParameter(auditRec, "user_id", c.Params.UserId)
+
+	// Locked users can't log in, so only the users managing them can unlock them.
+	if c.Params.UserId == c.AppContext.Session().UserId || !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	if appErr := c.App.UnlockUser(c.AppContext, c.Params.UserId); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("")
+
+	ReturnStatusOK(w)
+}
+
 func updateUserMfa(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -1752,7 +1783,7 @@ func updatePassword(c *Context, w http.ResponseWriter, r *http.Request) {
 			err = model.NewAppError("updatePassword", "api.user.update_password.context.app_error", nil, "", http.StatusForbidden)
 		}
 	} else {
-		if c.Params.UserId == c.AppContext.Session().UserId {
+		if c.AppContext.Session().CanActAsUser(c.Params.UserId) {
 			currentPassword := props["current_password"]
 			if currentPassword == "" {
 				c.SetInvalidParam("current_password")
@@ -2143,6 +2174,45 @@ func getSessions(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func getSessionActivities(c *Context, w http.ResponseWriter, r *http.Request) {
+	c.RequireUserId()
+	if c.Err != nil {
+		return
+	}
+
+	if !c.App.SessionHasPermissionToUser(*c.AppContext.Session(), c.Params.UserId) {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		return
+	}
+
+	activities, appErr := c.App.GetSessionActivities(c.Params.UserId, c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(activities); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
+func getSessionAnomalies(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+		c.SetPermissionError(model.PermissionManageSystem)
+		return
+	}
+
+	activities, appErr := c.App.GetSessionAnomalies(c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(activities); err != nil {
+		c.Logger.Warn("Error while writing response", mlog.Err(err))
+	}
+}
+
 func revokeSession(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireUserId()
 	if c.Err != nil {
@@ -2523,8 +2593,27 @@ func createUserAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A user access token can't be used to create a token with more access than its own.
+	if session := c.AppContext.Session(); session.IsUserAccessToken() {
+		sessionToken, err := c.App.GetUserAccessToken(session.Props[model.SessionPropUserAccessTokenId], true)
+		if err != nil {
+			c.Err = err
+			return
+		}
+		if len(sessionToken.Scopes) > 0 {
+			c.Err = model.NewAppError("createUserAccessToken", "api.user.create_user_access_token.scoped_session.app_error", nil, "", http.StatusForbidden)
+			return
+		}
+		if sessionToken.ExpiresAt > 0 && (accessToken.ExpiresAt == 0 || accessToken.ExpiresAt > sessionToken.ExpiresAt) {
+			c.Err = model.NewAppError("createUserAccessToken", "api.user.create_user_access_token.expires_at.app_error", nil, "", http.StatusForbidden)
+			return
+		}
+	}
+
 	accessToken.UserId = c.Params.UserId
 	accessToken.Token = ""
+	audit.AddEventParameter(auditRec, "expires_at", accessToken.ExpiresAt)
+	audit.AddEventParameter(auditRec, "scopes", []string(accessToken.Scopes))
 
 	token, err := c.App.CreateUserAccessToken(c.AppContext, &accessToken)
 	if err != nil {
@@ -2951,7 +3040,7 @@ func publishUserTyping(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if c.Params.UserId != c.AppContext.Session().UserId && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+	if !c.AppContext.Session().CanActAsUser(c.Params.UserId) && !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
 		c.SetPermissionError(model.PermissionManageSystem)
 		return
 	}
@@ -3055,7 +3144,7 @@ func getUploadsForUser(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	if c.Params.UserId != c.AppContext.Session().UserId {
+	if !c.AppContext.Session().CanActAsUser(c.Params.UserId) {
 		c.Err = model.NewAppError("getUploadsForUser", "api.user.get_uploads_for_user.forbidden.app_error", nil, "", http.StatusForbidden)
 		return
 	}
@@ -3520,3 +3609,21 @@ func getUsersWithInvalidEmails(c *Context, w http.ResponseWriter, r *http.Reques
 		c.Logger.Warn("Error writing response", mlog.Err(err))
 	}
 }
+
+func getUsersWithExpiredPasswords(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionSysconsoleReadUserManagementUsers) {
+		c.SetPermissionError(model.PermissionSysconsoleReadUserManagementUsers)
+		return
+	}
+
+	users, appErr := c.App.GetUsersWithExpiredPasswords(c.Params.Page, c.Params.PerPage)
+	if appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	err := json.NewEncoder(w).Encode(users)
+	if err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
@@ -31,6 +31,7 @@ import (
 	"github.com/mattermost/mattermost/server/v8/platform/shared/mail"
 
 	_ "github.com/mattermost/mattermost/server/v8/channels/app/oauthproviders/gitlab"
+	_ "github.com/mattermost/mattermost/server/v8/channels/app/oauthproviders/openid"
 )
 
 func TestCreateUser(t *testing.T) {
@@ -3798,6 +3799,34 @@ func TestGetSessions(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestGetSessionActivity(t *testing.T) {
+	th := SetupConfig(t, func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableSessionActivityLog = true
+	}).InitBasic()
+	defer th.TearDown()
+
+	require.Eventually(t, func() bool {
+		activities, _, err := th.Client.GetSessionActivity(context.Background(), th.BasicUser.Id, 0, 10)
+		require.NoError(t, err)
+		return len(activities) > 0
+	}, 5*time.Second, 100*time.Millisecond)
+
+	_, resp, err := th.Client.GetSessionActivity(context.Background(), th.BasicUser2.Id, 0, 10)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	_, _, err = th.SystemAdminClient.GetSessionActivity(context.Background(), th.BasicUser.Id, 0, 10)
+	require.NoError(t, err)
+
+	_, resp, err = th.Client.GetSessionAnomalies(context.Background(), 0, 10)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	anomalies, _, err := th.SystemAdminClient.GetSessionAnomalies(context.Background(), 0, 10)
+	require.NoError(t, err)
+	assert.Empty(t, anomalies)
+}
+
 func TestRevokeSessions(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -5902,6 +5931,134 @@ func TestEnableUserAccessToken(t *testing.T) {
 	})
 }
 
+func TestUserAccessTokenExpiryAndScopes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	_, appErr := th.App.UpdateUserRoles(th.Context, th.BasicUser.Id, model.SystemUserRoleId+" "+model.SystemUserAccessTokenRoleId, false)
+	require.Nil(t, appErr)
+
+	expiresAt := model.GetMillis() + 60*60*1000
+	token, _, err := th.Client.CreateUserAccessTokenWithOptions(context.Background(), th.BasicUser.Id, &model.UserAccessToken{
+		Description: "expiring token",
+		ExpiresAt:   expiresAt,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, expiresAt, token.ExpiresAt)
+
+	scopedToken, _, err := th.Client.CreateUserAccessTokenWithOptions(context.Background(), th.BasicUser.Id, &model.UserAccessToken{
+		Description: "scoped token",
+		Scopes:      model.StringArray{"permission:" + model.PermissionCreatePost.Id, "channel:" + th.BasicChannel.Id},
+	})
+	require.NoError(t, err)
+
+	_, resp, err := th.Client.CreateUserAccessTokenWithOptions(context.Background(), th.BasicUser.Id, &model.UserAccessToken{
+		Description: "invalid scope",
+		Scopes:      model.StringArray{"permission:unknown"},
+	})
+	require.Error(t, err)
+	CheckBadRequestStatus(t, resp)
+
+	t.Run("expiring token can't create a longer lived token", func(t *testing.T) {
+		client := th.CreateClient()
+		client.AuthToken = token.Token
+
+		_, resp, err := client.CreateUserAccessToken(context.Background(), th.BasicUser.Id, "unlimited token")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, _, err = client.CreateUserAccessTokenWithOptions(context.Background(), th.BasicUser.Id, &model.UserAccessToken{
+			Description: "shorter lived token",
+			ExpiresAt:   expiresAt - 1000,
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("scoped token is restricted to its scopes", func(t *testing.T) {
+		client := th.CreateClient()
+		client.AuthToken = scopedToken.Token
+
+		_, _, err := client.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel.Id, Message: "scoped"})
+		require.NoError(t, err)
+
+		_, resp, err := client.CreatePost(context.Background(), &model.Post{ChannelId: th.BasicChannel2.Id, Message: "scoped"})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.CreateUserAccessToken(context.Background(), th.BasicUser.Id, "unscoped token")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("scoped token can't act on its user's account", func(t *testing.T) {
+		client := th.CreateClient()
+		client.AuthToken = scopedToken.Token
+
+		_, resp, err := client.GetFlaggedPostsForUser(context.Background(), th.BasicUser.Id, 0, 10)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.GetTeamsForUser(context.Background(), th.BasicUser.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.PatchUser(context.Background(), th.BasicUser.Id, &model.UserPatch{Nickname: model.NewPointer("scoped")})
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("team scoped token is restricted to its team", func(t *testing.T) {
+		teamToken, _, err := th.Client.CreateUserAccessTokenWithOptions(context.Background(), th.BasicUser.Id, &model.UserAccessToken{
+			Description: "team scoped token",
+			Scopes:      model.StringArray{"team:" + th.BasicTeam.Id},
+		})
+		require.NoError(t, err)
+		client := th.CreateClient()
+		client.AuthToken = teamToken.Token
+
+		terms := "teamscoped" + model.NewId()[:8]
+		th.CreateMessagePostWithClient(th.Client, th.BasicChannel, terms)
+		dm := th.CreateDmChannel(th.BasicUser2)
+		th.CreateMessagePostWithClient(th.Client, dm, terms)
+
+		posts, _, err := client.SearchPosts(context.Background(), th.BasicTeam.Id, terms, false)
+		require.NoError(t, err)
+		require.Len(t, posts.Order, 1, "direct messages are outside of the team")
+		assert.Equal(t, th.BasicChannel.Id, posts.Posts[posts.Order[0]].ChannelId)
+
+		_, resp, err := client.SearchPosts(context.Background(), "", terms, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		otherTeam := th.CreateTeam()
+		th.LinkUserToTeam(th.BasicUser, otherTeam)
+		_, resp, err = client.SearchPosts(context.Background(), otherTeam.Id, terms, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = client.SetPostUnread(context.Background(), th.BasicUser.Id, th.BasicPost.Id, false)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.GetChannelMembersForUser(context.Background(), th.BasicUser.Id, th.BasicTeam.Id, "")
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, resp, err = client.GetUploadsForUser(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		resp, err = client.RemoveTeamMember(context.Background(), otherTeam.Id, th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+
+		_, err = client.RemoveTeamMember(context.Background(), th.BasicTeam.Id, th.BasicUser.Id)
+		require.NoError(t, err)
+	})
+}
+
 func TestUserAccessTokenInactiveUser(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -6230,6 +6387,49 @@ func TestLoginLockout(t *testing.T) {
 	CheckErrorID(t, err, "api.user.check_user_login_attempts.too_many.app_error")
 }
 
+func TestUnlockUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaximumLoginAttempts = 1 })
+
+	lockUser := func(t *testing.T) {
+		t.Helper()
+		err := th.App.Srv().Store().User().UpdateFailedPasswordAttempts(th.BasicUser2.Id, 1)
+		require.NoError(t, err)
+		th.App.InvalidateCacheForUser(th.BasicUser2.Id)
+	}
+
+	t.Run("users can't unlock other users", func(t *testing.T) {
+		lockUser(t)
+		resp, err := th.Client.UnlockUser(context.Background(), th.BasicUser2.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	t.Run("users can't unlock themselves", func(t *testing.T) {
+		resp, err := th.Client.UnlockUser(context.Background(), th.BasicUser.Id)
+		require.Error(t, err)
+		CheckForbiddenStatus(t, resp)
+	})
+
+	th.TestForSystemAdminAndLocal(t, func(t *testing.T, client *model.Client4) {
+		lockUser(t)
+
+		_, err := client.UnlockUser(context.Background(), th.BasicUser2.Id)
+		require.NoError(t, err)
+
+		_, _, err = th.CreateClient().Login(context.Background(), th.BasicUser2.Email, th.BasicUser2.Password)
+		require.NoError(t, err)
+	})
+
+	t.Run("unknown users can't be unlocked", func(t *testing.T) {
+		resp, err := th.SystemAdminClient.UnlockUser(context.Background(), model.NewId())
+		require.Error(t, err)
+		CheckNotFoundStatus(t, resp)
+	})
+}
+
 func TestDemoteUserToGuest(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -8212,6 +8412,21 @@ func TestSetProfileImageWithProviderAttributes(t *testing.T) {
 	})
 }
 
+func TestGetUsersWithExpiredPasswords(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.MaximumAgeDays = 30 })
+
+	_, resp, err := th.Client.GetUsersWithExpiredPasswords(context.Background(), 0, 50)
+	require.Error(t, err)
+	CheckForbiddenStatus(t, resp)
+
+	users, _, err := th.SystemAdminClient.GetUsersWithExpiredPasswords(context.Background(), 0, 50)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
 func TestGetUsersWithInvalidEmails(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -5,6 +5,8 @@ package api4
 
 import (
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/gorilla/websocket"
 
@@ -18,11 +20,13 @@ const (
 	connectionIDParam   = "connection_id"
 	sequenceNumberParam = "sequence_number"
 	postedAckParam      = "posted_ack"
+	lastEventIDHeader   = "Last-Event-ID"
 )
 
 func (api *API) InitWebSocket() {
 	// Optionally supports a trailing slash
 	api.BaseRoutes.APIRoot.Handle("/{websocket:websocket(?:\\/)?}", api.APIHandlerTrustRequester(connectWebSocket)).Methods(http.MethodGet)
+	api.BaseRoutes.APIRoot.Handle("/events", api.APISessionRequired(connectEventStream)).Methods(http.MethodGet)
 }
 
 func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
@@ -88,3 +92,69 @@ func connectWebSocket(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	wc.Pump()
 }
+
+// connectEventStream serves the same events as the websocket through
+// Server-Sent Events, for clients sitting behind proxies which do not
+// allow websocket upgrades.
+func connectEventStream(c *Context, w http.ResponseWriter, r *http.Request) {
+	connectionID := r.URL.Query().Get(connectionIDParam)
+	sequenceNumber := r.URL.Query().Get(sequenceNumberParam)
+	if connectionID == "" {
+		// Browsers resume an event stream by sending back the id of the
+		// last event they received, which is "<connection_id>:<sequence>".
+		connectionID, sequenceNumber = parseLastEventID(r.Header.Get(lastEventIDHeader))
+	}
+
+	cfg := &platform.WebConnConfig{
+		Session:       *c.AppContext.Session(),
+		TFunc:         c.AppContext.T,
+		Locale:        "",
+		Active:        true,
+		RemoteAddress: c.AppContext.IPAddress(),
+		XForwardedFor: c.AppContext.XForwardedFor(),
+		OriginClient:  string(web.GetOriginClient(r)),
+		ConnectionID:  connectionID,
+	}
+
+	if cfg.ConnectionID == "" {
+		cfg.ConnectionID = model.NewId()
+	} else {
+		var err error
+		cfg, err = c.App.Srv().Platform().PopulateWebConnConfig(c.AppContext.Session(), cfg, sequenceNumber)
+		if err != nil {
+			c.Err = model.NewAppError("connectEventStream", "api.event_stream.connect.invalid_resume.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+			return
+		}
+	}
+
+	es, err := platform.NewEventStream(w, r)
+	if err != nil {
+		c.Err = model.NewAppError("connectEventStream", "api.event_stream.connect.not_supported.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		return
+	}
+	cfg.EventStream = es
+
+	wc := c.App.Srv().Platform().NewWebConn(cfg, c.App, c.App.Srv().Channels())
+	if err := c.App.Srv().Platform().HubRegister(wc); err != nil {
+		// The response headers have already been sent,
+		// so all we can do is to end the stream.
+		c.Logger.Error("Error while registering event stream to hub", mlog.String("id", cfg.ConnectionID), mlog.Err(err))
+		return
+	}
+
+	wc.Pump()
+}
+
+// parseLastEventID splits the value of the Last-Event-ID header into a
+// connection id and the sequence number of the next expected event.
+func parseLastEventID(id string) (string, string) {
+	connectionID, seq, ok := strings.Cut(id, ":")
+	if !ok {
+		return "", ""
+	}
+	lastSeq, err := strconv.ParseInt(seq, 10, 64)
+	if err != nil || lastSeq < 0 {
+		return "", ""
+	}
+	return connectionID, strconv.FormatInt(lastSeq+1, 10)
+}
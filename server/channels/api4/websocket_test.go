@@ -470,3 +470,112 @@ func TestWebSocketUpgrade(t *testing.T) {
 	require.NoError(t, th.TestLogger.Flush())
 	testlib.AssertLog(t, buffer, mlog.LvlDebug.Name, "URL Blocked because of CORS. Url: ")
 }
+
+func TestEventStream(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("requires a session", func(t *testing.T) {
+		client := th.CreateClient()
+		_, resp, err := client.ConnectEventStream(context.Background(), "", 0)
+		require.Error(t, err)
+		CheckUnauthorizedStatus(t, resp)
+	})
+
+	t.Run("receives events and resumes", func(t *testing.T) {
+		stream, _, err := th.Client.ConnectEventStream(context.Background(), "", 0)
+		require.NoError(t, err)
+
+		hello := <-stream.EventChannel
+		require.Equal(t, model.WebsocketEventHello, hello.EventType())
+		connID := stream.ConnectionID()
+		require.NotEmpty(t, connID)
+
+		evt := model.NewWebSocketEvent(model.WebsocketEventTyping, "", th.BasicChannel.Id, "", nil, "")
+		evt.Add("user_id", "somerandomid")
+		th.App.Publish(evt)
+
+		received := <-stream.EventChannel
+		require.Equal(t, model.WebsocketEventTyping, received.EventType())
+		require.Equal(t, int64(1), received.GetSequence())
+		stream.Close()
+
+		// Events published while disconnected are delivered on resume.
+		require.Eventually(t, func() bool {
+			return th.App.Srv().Platform().WebConnCountForUser(th.BasicUser.Id) == 0
+		}, 5*time.Second, 100*time.Millisecond)
+		th.App.Publish(evt)
+
+		resumed, _, err := th.Client.ConnectEventStream(context.Background(), connID, stream.NextSequence())
+		require.NoError(t, err)
+		defer resumed.Close()
+
+		received = <-resumed.EventChannel
+		require.Equal(t, model.WebsocketEventTyping, received.EventType())
+		require.Equal(t, int64(2), received.GetSequence())
+		require.Equal(t, connID, resumed.ConnectionID())
+	})
+
+	t.Run("invalid resume", func(t *testing.T) {
+		_, resp, err := th.Client.ConnectEventStream(context.Background(), "invalid", 0)
+		require.Error(t, err)
+		CheckBadRequestStatus(t, resp)
+	})
+}
+
+func TestWebSocketSubscribe(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	wsClient, err := th.CreateWebSocketClient()
+	require.NoError(t, err)
+	defer wsClient.Close()
+	wsClient.Listen()
+
+	resp := <-wsClient.ResponseChannel
+	require.Equal(t, model.StatusOk, resp.Status)
+
+	wsClient.Subscribe([]model.WebsocketEventType{model.WebsocketEventPosted}, []string{th.BasicChannel.Id})
+	resp = <-wsClient.ResponseChannel
+	require.Equal(t, model.StatusOk, resp.Status)
+
+	typing := model.NewWebSocketEvent(model.WebsocketEventTyping, "", th.BasicChannel.Id, "", nil, "")
+	th.App.Publish(typing)
+	th.CreatePostWithClient(th.Client, th.BasicChannel2)
+	post := th.CreatePost()
+
+	timeout := time.After(5 * time.Second)
+	for {
+		select {
+		case evt := <-wsClient.EventChannel:
+			if evt.EventType() == model.WebsocketEventHello {
+				continue
+			}
+			require.Equal(t, model.WebsocketEventPosted, evt.EventType())
+			require.Equal(t, th.BasicChannel.Id, evt.GetBroadcast().ChannelId)
+			require.Contains(t, evt.GetData()["post"], post.Id)
+			return
+		case <-timeout:
+			require.Fail(t, "did not receive subscribed event")
+			return
+		}
+	}
+}
+
+func TestWebSocketSubscribeInvalid(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	wsClient, err := th.CreateWebSocketClient()
+	require.NoError(t, err)
+	defer wsClient.Close()
+	wsClient.Listen()
+
+	resp := <-wsClient.ResponseChannel
+	require.Equal(t, model.StatusOk, resp.Status)
+
+	wsClient.Subscribe(nil, []string{"invalid"})
+	resp = <-wsClient.ResponseChannel
+	require.Equal(t, model.StatusFail, resp.Status)
+	require.Equal(t, "api.web_socket_router.bad_subscription.app_error", resp.Error.Id)
+}
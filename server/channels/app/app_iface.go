@@ -54,6 +54,21 @@ type AppIface interface {
 	AddPublicKey(name string, key io.Reader) *model.AppError
 	// AddUserToChannel adds a user to a given channel.
 	AddUserToChannel(c request.CTX, user *model.User, channel *model.Channel, skipTeamMemberIntegrityCheck bool) (*model.ChannelMember, *model.AppError)
+	// ApplyFileWillBeDownloadedHook lets plugins reject the download of a file, or replace the content
+	// sent to the user. It returns the content to send and its size, which is the given file unless
+	// a plugin replaced it. The caller remains responsible for closing the given file.
+	ApplyFileWillBeDownloadedHook(c request.CTX, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.ReadSeeker, size int64) (io.ReadSeeker, int64, *model.AppError)
+	// ApprovePluginCapabilities records the capabilities an administrator approved for an installed
+	// plugin, replacing any earlier approval. Enabled plugins are activated once all of their
+	// declared capabilities are approved.
+	ApprovePluginCapabilities(id string, capabilities []string) (*model.PluginInfo, *model.AppError)
+	// AuthorizeOAuthDevice approves or denies the pending authorization request of the device
+	// that was given the user code.
+	AuthorizeOAuthDevice(c request.CTX, userID, userCode string, approve bool) *model.AppError
+	// BatchPluginKeys applies the given operations to the key-value pairs of a plugin in a single
+	// transaction. It returns false without applying any of them if the condition of an atomic
+	// operation doesn't hold.
+	BatchPluginKeys(pluginID string, operations []*model.PluginKVBatchOperation) (bool, *model.AppError)
 	// Caller must close the first return value
 	ExportFileReader(path string) (filestore.ReadCloseSeeker, *model.AppError)
 	// Caller must close the first return value
@@ -102,6 +117,15 @@ type AppIface interface {
 	// CreateGuest creates a guest and sets several fields of the returned User struct to
 	// their zero values.
 	CreateGuest(c request.CTX, user *model.User) (*model.User, *model.AppError)
+	// CreateOAuthDeviceAuthorization starts the device flow of RFC 8628 for the given client,
+	// returning the device code it polls the token endpoint with and the code its user enters
+	// to approve it.
+	CreateOAuthDeviceAuthorization(clientId, secret, scope string) (*model.OAuthDeviceAuthorizationResponse, *model.AppError)
+	// CreateScimGroup provisions a group along with its members.
+	CreateScimGroup(rctx request.CTX, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError)
+	// CreateScimUser provisions a user. Users logging in with a password get a random one unless
+	// the identity provider sets it, and reset it to log in.
+	CreateScimUser(rctx request.CTX, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError)
 	// CreateUser creates a user and sets several fields of the returned User struct to
 	// their zero values.
 	CreateUser(c request.CTX, user *model.User) (*model.User, *model.AppError)
@@ -118,6 +142,11 @@ type AppIface interface {
 	//
 	//	['town-square', 'game-of-thrones', 'wow']
 	DefaultChannelNames(c request.CTX) []string
+	// DeleteCalendar stops driving the user's status from their calendar, reverting
+	// any status currently set on their behalf.
+	DeleteCalendar(rctx request.CTX, userID string) *model.AppError
+	// DeleteChannelAccessPolicy removes the access policy of a channel.
+	DeleteChannelAccessPolicy(channelID string) *model.AppError
 	// DeleteChannelScheme deletes a channels scheme and sets its SchemeId to nil.
 	DeleteChannelScheme(c request.CTX, channel *model.Channel) (*model.Channel, *model.AppError)
 	// DeleteGroupConstrainedMemberships deletes team and channel memberships of users who aren't members of the allowed
@@ -127,20 +156,30 @@ type AppIface interface {
 	DeletePersistentNotification(c request.CTX, post *model.Post) *model.AppError
 	// DeletePublicKey will delete plugin public key from the config.
 	DeletePublicKey(name string) *model.AppError
+	// DeleteScimGroup deletes a group, removing its members from the teams and channels constrained
+	// to it.
+	DeleteScimGroup(rctx request.CTX, groupID string) *model.AppError
+	// DeleteScimUser deactivates a user, whose content is kept.
+	DeleteScimUser(rctx request.CTX, userID string) *model.AppError
 	// DemoteUserToGuest Convert user's roles and all his membership's roles from
 	// regular user roles to guest roles.
 	DemoteUserToGuest(c request.CTX, user *model.User) *model.AppError
 	// DetachPlugin allows the server to bind to an existing plugin instance launched elsewhere.
 	DetachPlugin(pluginId string) *model.AppError
 	// DisablePlugin will set the config for an installed plugin to disabled, triggering deactivation if active.
-	// Notifies cluster peers through config change.
-	DisablePlugin(id string) *model.AppError
+	// Notifies cluster peers through config change. Plugins other enabled plugins depend on are only
+	// disabled when forced to.
+	DisablePlugin(id string, force bool) *model.AppError
 	// DoPermissionsMigrations execute all the permissions migrations need by the current version.
 	DoPermissionsMigrations() error
 	// EnablePlugin will set the config for an installed plugin to enabled, triggering asynchronous
 	// activation if inactive anywhere in the cluster.
 	// Notifies cluster peers through config change.
 	EnablePlugin(id string) *model.AppError
+	// EnforceChannelAccessPolicies removes from the channels having an access policy the members who
+	// don't satisfy it. SAML and OpenID Connect members whose attributes were never captured are
+	// kept until they log in again, unless the policy is decided without their attributes.
+	EnforceChannelAccessPolicies(rctx request.CTX) *model.AppError
 	// EnsureBot provides similar functionality with the plugin-api BotService. It doesn't accept
 	// any ensureBotOptions hence it is not required for now.
 	EnsureBot(rctx request.CTX, pluginID string, bot *model.Bot) (string, error)
@@ -171,6 +210,8 @@ type AppIface interface {
 	GetBot(rctx request.CTX, botUserId string, includeDeleted bool) (*model.Bot, *model.AppError)
 	// GetBots returns the requested page of bots.
 	GetBots(rctx request.CTX, options *model.BotGetOptions) (model.BotList, *model.AppError)
+	// GetChannelAccessPolicy returns the access policy of a channel.
+	GetChannelAccessPolicy(channelID string) (*model.ChannelAccessPolicy, *model.AppError)
 	// GetChannelGroupUsers returns the users who are associated to the channel via GroupChannels and GroupMembers.
 	GetChannelGroupUsers(channelID string) ([]*model.User, *model.AppError)
 	// GetChannelModerationsForChannel Gets a channels ChannelModerations from either the higherScoped roles or from the channel scheme roles.
@@ -185,10 +226,15 @@ type AppIface interface {
 	// GetEnvironmentConfig returns a map of configuration keys whose values have been overridden by an environment variable.
 	// If filter is not nil and returns false for a struct field, that field will be omitted.
 	GetEnvironmentConfig(filter func(reflect.StructField) bool) map[string]any
+	// GetEscalationPoliciesForChannel returns the policies configured for the
+	// given channel, or the server wide policies when channelID is empty.
+	GetEscalationPoliciesForChannel(channelID string) ([]*model.EscalationPolicy, *model.AppError)
 	// GetFileInfosForPost also returns firstInaccessibleFileTime based on cloud plan's limit.
 	GetFileInfosForPost(rctx request.CTX, postID string, fromMaster bool, includeDeleted bool) ([]*model.FileInfo, int64, *model.AppError)
 	// GetFilteredUsersStats is used to get a count of users based on the set of filters supported by UserCountOptions.
 	GetFilteredUsersStats(options *model.UserCountOptions) (*model.UsersStats, *model.AppError)
+	// GetGroupAttributeRule returns the attribute rule of an attribute group.
+	GetGroupAttributeRule(groupID string) (*model.GroupAttributeRule, *model.AppError)
 	// GetGroupsByTeam returns the paged list and the total count of group associated to the given team.
 	GetGroupsByTeam(teamID string, opts model.GroupSearchOpts) ([]*model.GroupWithSchemeAdmin, int, *model.AppError)
 	// GetKnownUsers returns the list of user ids of users with any direct
@@ -204,9 +250,30 @@ type AppIface interface {
 	GetLastAccessiblePostTime() (int64, *model.AppError)
 	// GetLdapGroup retrieves a single LDAP group by the given LDAP group id.
 	GetLdapGroup(rctx request.CTX, ldapGroupID string) (*model.Group, *model.AppError)
+	// GetMarketplaceCatalog returns every plugin of the offline marketplace catalog, including
+	// every version of each.
+	GetMarketplaceCatalog() ([]*model.BaseMarketplacePlugin, *model.AppError)
 	// GetMarketplacePlugins returns a list of plugins from the marketplace-server,
 	// and plugins that are installed locally.
 	GetMarketplacePlugins(rctx request.CTX, filter *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.AppError)
+	// GetOAuthAccessTokenForClientCredentials issues a token for the bot the app is bound to.
+	GetOAuthAccessTokenForClientCredentials(c request.CTX, clientId, secret, scope string) (*model.AccessResponse, *model.AppError)
+	// GetOAuthAccessTokenForDeviceFlow issues a token to a device once its user approved its
+	// authorization request. The errors returned while the request is pending are named after the
+	// error codes of RFC 8628.
+	GetOAuthAccessTokenForDeviceFlow(c request.CTX, clientId, secret, deviceCode string) (*model.AccessResponse, *model.AppError)
+	// GetOIDCJSONWebKeySet returns the public keys ID tokens are signed with, including the keys
+	// retired recently.
+	GetOIDCJSONWebKeySet() (*model.JSONWebKeySet, *model.AppError)
+	// GetOIDCProviderMetadata returns the discovery document of the OpenID Connect provider, whose
+	// issuer is the site URL.
+	GetOIDCProviderMetadata() (*model.OIDCProviderMetadata, *model.AppError)
+	// GetOIDCUserInfo returns the claims about the user of an OAuth session allowed by the scopes
+	// granted to its app.
+	GetOIDCUserInfo(session *model.Session) (*model.OIDCUserInfo, *model.AppError)
+	// GetPluginKeys returns the values of the given keys of a plugin, omitting the keys which
+	// don't exist.
+	GetPluginKeys(pluginID string, keys []string) (map[string][]byte, *model.AppError)
 	// GetPluginStatus returns the status for a plugin installed on this server.
 	GetPluginStatus(id string) (*model.PluginStatus, *model.AppError)
 	// GetPluginStatuses returns the status for plugins installed on this server.
@@ -232,6 +299,22 @@ type AppIface interface {
 	GetSanitizedConfig() *model.Config
 	// GetSchemeRolesForChannel Checks if a channel or its team has an override scheme for channel roles and returns the scheme roles or default channel roles.
 	GetSchemeRolesForChannel(c request.CTX, channelID string) (guestRoleName string, userRoleName string, adminRoleName string, err *model.AppError)
+	// GetScimBaseURL returns the URL the SCIM resources are served under.
+	GetScimBaseURL() string
+	// GetScimGroup returns a group provisioned through SCIM. Its members are left out unless
+	// withMembers is true, since identity providers exclude them when they only check the group.
+	GetScimGroup(groupID string, withMembers bool) (*model.ScimGroup, *model.AppError)
+	// GetScimGroups returns a page of the groups provisioned through SCIM matching the filter,
+	// starting at the 1-based startIndex.
+	GetScimGroups(filter string, startIndex, count int, withMembers bool) (*model.ScimListResponse, *model.AppError)
+	// GetScimUsers returns a page of users, starting at the 1-based startIndex. Users can only be
+	// filtered by an equality with their userName, emails or externalId, possibly along with other
+	// expressions, since the other attributes aren't indexed.
+	GetScimUsers(filter string, startIndex, count int) (*model.ScimListResponse, *model.AppError)
+	// GetSessionActivities returns the activity of the sessions of a user, most recent first.
+	GetSessionActivities(userID string, page, perPage int) ([]*model.SessionActivity, *model.AppError)
+	// GetSessionAnomalies returns the anomalous session activity of all users, most recent first.
+	GetSessionAnomalies(page, perPage int) ([]*model.SessionActivity, *model.AppError)
 	// GetSessionLengthInMillis returns the session length, in milliseconds,
 	// based on the type of session (Mobile, SSO, Web/LDAP).
 	GetSessionLengthInMillis(session *model.Session) int64
@@ -247,8 +330,16 @@ type AppIface interface {
 	GetTotalUsersStats(viewRestrictions *model.ViewUsersRestrictions) (*model.UsersStats, *model.AppError)
 	// GetUserStatusesByIds used by apiV4
 	GetUserStatusesByIds(userIDs []string) ([]*model.Status, *model.AppError)
+	// GetUsersWithExpiredPasswords returns the users who have to reset their password the next
+	// time they log in.
+	GetUsersWithExpiredPasswords(page int, perPage int) ([]*model.User, *model.AppError)
 	// HasRemote returns whether a given channelID is present in the channel remotes or not.
 	HasRemote(channelID string, remoteID string) (bool, error)
+	// ImportMarketplaceCatalog adds the plugins of the given catalog bundle to the offline
+	// marketplace catalog, replacing the versions already listed. If replace is true, the plugins
+	// previously listed are removed from the catalog. The bundle is rejected as a whole if any of its
+	// plugins isn't signed by a trusted key.
+	ImportMarketplaceCatalog(bundle io.ReadSeeker, replace bool) ([]*model.BaseMarketplacePlugin, *model.AppError)
 	// InstallPlugin unpacks and installs a plugin but does not enable or activate it unless the the
 	// plugin was already enabled.
 	InstallPlugin(pluginFile io.ReadSeeker, replace bool) (*model.Manifest, *model.AppError)
@@ -271,6 +362,10 @@ type AppIface interface {
 	MoveChannel(c request.CTX, team *model.Team, channel *model.Channel, user *model.User) *model.AppError
 	// NotifySessionsExpired is called periodically from the job server to notify any mobile sessions that have expired.
 	NotifySessionsExpired() error
+	// NotifyUserAccessTokensExpiring is called periodically from the job server to notify the owners
+	// of user access tokens expiring soon, once per token. The owner of the token of a bot is the
+	// owner of the bot.
+	NotifyUserAccessTokensExpiring(rctx request.CTX) error
 	// OnSharedChannelsAttachmentSyncMsg is called by the Shared Channels service for a registered plugin when a file attachment
 	// needs to be synchronized.
 	OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error
@@ -290,6 +385,10 @@ type AppIface interface {
 	PatchBot(rctx request.CTX, botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
 	// PatchChannelModerationsForChannel Updates a channels scheme roles based on a given ChannelModerationPatch, if the permissions match the higher scoped role the scheme is deleted.
 	PatchChannelModerationsForChannel(c request.CTX, channel *model.Channel, channelModerationsPatch []*model.ChannelModerationPatch) ([]*model.ChannelModeration, *model.AppError)
+	// PatchScimGroup applies the operations of the patch to a group.
+	PatchScimGroup(rctx request.CTX, groupID string, patch *model.ScimPatchRequest) (*model.ScimGroup, *model.AppError)
+	// PatchScimUser applies the operations of the patch to a user.
+	PatchScimUser(rctx request.CTX, userID string, patch *model.ScimPatchRequest) (*model.ScimUser, *model.AppError)
 	// Perform an HTTP POST request to an integration's action endpoint.
 	// Caller must consume and close returned http.Response as necessary.
 	// For internal requests, requests are routed directly to a plugin ServerHTTP hook
@@ -299,27 +398,54 @@ type AppIface interface {
 	// PopulateWebConnConfig checks if the connection id already exists in the hub,
 	// and if so, accordingly populates the other fields of the webconn.
 	PopulateWebConnConfig(s *model.Session, cfg *platform.WebConnConfig, seqVal string) (*platform.WebConnConfig, error)
+	// PreviewAttributeGroupSync returns the group, team and channel memberships synchronizing the
+	// attribute groups would add and remove, without changing them. Only the given group is
+	// synchronized when its id is given.
+	PreviewAttributeGroupSync(rctx request.CTX, groupID string) ([]*model.MembershipChange, *model.AppError)
+	// PreviewChannelAccessPolicy returns the members of the channel saving the access policy would
+	// remove, without saving it or removing them.
+	PreviewChannelAccessPolicy(rctx request.CTX, policy *model.ChannelAccessPolicy) ([]*model.MembershipChange, *model.AppError)
 	// PromoteGuestToUser Convert user's roles and all his membership's roles from
 	// guest roles to regular user roles.
 	PromoteGuestToUser(c request.CTX, user *model.User, requestorId string) *model.AppError
 	// ReattachPlugin allows the server to bind to an existing plugin instance launched elsewhere.
 	ReattachPlugin(manifest *model.Manifest, pluginReattachConfig *model.PluginReattachConfig) *model.AppError
+	// RecordSessionActivity records that the session is used from the given IP address. It's
+	// throttled so that the activity is updated at most once per SessionActivityTimeout.
+	RecordSessionActivity(c request.CTX, session *model.Session, ipAddress, userAgent string)
+	// RemoveMarketplaceCatalogPlugin removes the given version of a plugin from the offline
+	// marketplace catalog, or every version of it if none is given.
+	RemoveMarketplaceCatalogPlugin(pluginID, version string) *model.AppError
 	// Removes a listener function by the unique ID returned when AddConfigListener was called
 	RemoveConfigListener(id string)
 	// RenameChannel is used to rename the channel Name and the DisplayName fields
 	RenameChannel(c request.CTX, channel *model.Channel, newChannelName string, newDisplayName string) (*model.Channel, *model.AppError)
 	// RenameTeam is used to rename the team Name and the DisplayName fields
 	RenameTeam(team *model.Team, newTeamName string, newDisplayName string) (*model.Team, *model.AppError)
+	// ReplaceScimGroup replaces the attributes and the members of a group.
+	ReplaceScimGroup(rctx request.CTX, groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError)
+	// ReplaceScimUser replaces the attributes of a user with the ones of the SCIM user.
+	ReplaceScimUser(rctx request.CTX, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError)
 	// ResolvePersistentNotification stops the persistent notifications, if a loggedInUserID(except the post owner) reacts, reply or ack on the post.
 	// Post-owner can only delete the original post to stop the notifications.
 	ResolvePersistentNotification(c request.CTX, post *model.Post, loggedInUserID string) *model.AppError
 	// RevokeSessionsFromAllUsers will go through all the sessions active
 	// in the server and revoke them
 	RevokeSessionsFromAllUsers() *model.AppError
+	// RotateOIDCSigningKeys is called periodically from the job server to replace the signing key
+	// of ID tokens once it has been used for long enough, and to stop publishing the keys retired
+	// long enough ago.
+	RotateOIDCSigningKeys(rctx request.CTX) error
 	// SanitizedConfig sanitizes a given configuration for a system admin without any secrets.
 	SanitizedConfig(cfg *model.Config)
+	// SaveChannelAccessPolicy sets the access policy of a public or private channel, and removes the
+	// members who don't satisfy it in the background.
+	SaveChannelAccessPolicy(rctx request.CTX, policy *model.ChannelAccessPolicy) (*model.ChannelAccessPolicy, *model.AppError)
 	// SaveConfig replaces the active configuration, optionally notifying cluster peers.
 	SaveConfig(newCfg *model.Config, sendConfigChangeClusterMessage bool) (*model.Config, *model.Config, *model.AppError)
+	// SaveGroupAttributeRule sets the attribute rule of an attribute group, and synchronizes the
+	// members of the group with it in the background.
+	SaveGroupAttributeRule(rctx request.CTX, rule *model.GroupAttributeRule) (*model.GroupAttributeRule, *model.AppError)
 	// SearchAllChannels returns a list of channels, the total count of the results of the search (if the paginate search option is true), and an error.
 	SearchAllChannels(c request.CTX, term string, opts model.ChannelSearchOpts) (model.ChannelListWithTeamData, int64, *model.AppError)
 	// SearchAllTeams returns a team list and the total count of the results
@@ -334,6 +460,9 @@ type AppIface interface {
 	SessionHasPermissionToTeams(c request.CTX, session model.Session, teamIDs []string, permission *model.Permission) bool
 	// SessionIsRegistered determines if a specific session has been registered
 	SessionIsRegistered(session model.Session) bool
+	// SetCalendarFeed subscribes the user's status to the ICS feed at the given URL.
+	// webcal:// URLs are fetched over https.
+	SetCalendarFeed(rctx request.CTX, userID, feedURL string) *model.AppError
 	// SetSessionExpireInHours sets the session's expiry the specified number of hours
 	// relative to either the session creation date or the current time, depending
 	// on the `ExtendSessionOnActivity` config setting.
@@ -345,6 +474,13 @@ type AppIface interface {
 	// status to away if needed. Used by the WS to set status to away if an 'online' device disconnects
 	// while an 'away' device is still connected
 	SetStatusLastActivityAt(userID string, activityAt int64)
+	// SyncAttributeGroups synchronizes the members of all attribute groups with their rules, and
+	// the members of the teams and channels the groups are linked to. Users are matched against
+	// the attributes kept from their last login.
+	SyncAttributeGroups(rctx request.CTX) *model.AppError
+	// SyncCalendarStatuses updates the status of every user with a calendar
+	// according to their current events. It is run periodically by a job.
+	SyncCalendarStatuses() error
 	// SyncLdap starts an LDAP sync job.
 	// If includeRemovedMembers is true, then members who left or were removed from a team/channel will
 	// be re-added; otherwise, they will not be re-added.
@@ -355,12 +491,22 @@ type AppIface interface {
 	// SyncRolesAndMembership updates the SchemeAdmin status and membership of all of the members of the given
 	// syncable.
 	SyncRolesAndMembership(rctx request.CTX, syncableID string, syncableType model.GroupSyncableType, includeRemovedMembers bool)
+	// SyncSamlUserAttributeGroups synchronizes the attribute groups of a user logged in with the
+	// given SAML response. The attributes are only read when the response matches the user, by the
+	// id attribute or else the email attribute of the SAML settings. The attributes of encrypted
+	// assertions can't be read, so users logging in with them keep their attribute groups.
+	SyncSamlUserAttributeGroups(rctx request.CTX, user *model.User, encodedXML string) *model.AppError
 	// SyncSharedChannel forces a shared channel to send any changed content to all remote clusters.
 	SyncSharedChannel(channelID string) error
 	// SyncSyncableRoles updates the SchemeAdmin field value of the given syncable's members based on the configuration of
 	// the member's group memberships and the configuration of those groups to the syncable. This method should only
 	// be invoked on group-synced (aka group-constrained) syncables.
 	SyncSyncableRoles(rctx request.CTX, syncableID string, syncableType model.GroupSyncableType) *model.AppError
+	// SyncUserAttributeGroups keeps the attributes of a user logging in with SAML or OpenID Connect,
+	// makes the user a member of exactly the attribute groups whose rules match them, and adds the
+	// user to the teams and channels of the groups. Only the attributes matched by rules or referred
+	// to by channel access policies are kept.
+	SyncUserAttributeGroups(rctx request.CTX, userID string, attributes map[string][]string) *model.AppError
 	// TeamMembersMinusGroupMembers returns the set of users on the given team minus the set of users in the given
 	// groups.
 	//
@@ -381,6 +527,8 @@ type AppIface interface {
 	CreateZipFileAndAddFiles(fileBackend filestore.FileBackend, fileDatas []model.FileData, zipFileName, directory string) error
 	// This to be used for places we check the users password when they are already logged in
 	DoubleCheckPassword(rctx request.CTX, user *model.User, password string) *model.AppError
+	// UnlockUser unlocks an account locked after too many failed logins.
+	UnlockUser(rctx request.CTX, userID string) *model.AppError
 	// UpdateBotActive marks a bot as active or inactive, along with its corresponding user.
 	UpdateBotActive(rctx request.CTX, botUserId string, active bool) (*model.Bot, *model.AppError)
 	// UpdateBotOwner changes a bot's owner to the given value.
@@ -392,6 +540,9 @@ type AppIface interface {
 	// UpdateDNDStatusOfUsers is a recurring task which is started when server starts
 	// which unsets dnd status of users if needed and saves and broadcasts it
 	UpdateDNDStatusOfUsers()
+	// UpdateEscalationPolicy replaces the steps and quiet hours of a policy.
+	// The channel and priority a policy applies to can't be changed.
+	UpdateEscalationPolicy(policy *model.EscalationPolicy) (*model.EscalationPolicy, *model.AppError)
 	// UpdateProductNotices is called periodically from a scheduled worker to fetch new notices and update the cache
 	UpdateProductNotices() *model.AppError
 	// UpdateSharedChannelCursor updates the cursor for the specified channelID and remoteID.
@@ -407,6 +558,8 @@ type AppIface interface {
 	UpdateViewedProductNoticesForNewUser(userID string)
 	// UpdateWebConnUserActivity sets the LastUserActivityAt of the hub for the given session.
 	UpdateWebConnUserActivity(session model.Session, activityAt int64)
+	// UploadCalendarFile stores the given calendar and uses it to drive the user's status.
+	UploadCalendarFile(rctx request.CTX, userID string, data []byte) *model.AppError
 	// UploadFile uploads a single file in form of a completely constructed byte array for a channel.
 	UploadFile(c request.CTX, data []byte, channelID string, filename string) (*model.FileInfo, *model.AppError)
 	// UploadFileX uploads a single file as specified in t. It applies the upload
@@ -519,6 +672,7 @@ type AppIface interface {
 	CreateCommand(cmd *model.Command) (*model.Command, *model.AppError)
 	CreateCommandWebhook(commandID string, args *model.CommandArgs) (*model.CommandWebhook, *model.AppError)
 	CreateEmoji(c request.CTX, sessionUserId string, emoji *model.Emoji, multiPartImageData *multipart.Form) (*model.Emoji, *model.AppError)
+	CreateEscalationPolicy(policy *model.EscalationPolicy) (*model.EscalationPolicy, *model.AppError)
 	CreateGroup(group *model.Group) (*model.Group, *model.AppError)
 	CreateGroupChannel(c request.CTX, userIDs []string, creatorId string) (*model.Channel, *model.AppError)
 	CreateGroupWithUserIds(group *model.GroupWithUserIds) (*model.Group, *model.AppError)
@@ -566,6 +720,7 @@ type AppIface interface {
 	DeleteDraft(rctx request.CTX, draft *model.Draft, connectionID string) *model.AppError
 	DeleteEmoji(c request.CTX, emoji *model.Emoji) *model.AppError
 	DeleteEphemeralPost(rctx request.CTX, userID, postID string)
+	DeleteEscalationPolicy(id string) *model.AppError
 	DeleteExport(name string) *model.AppError
 	DeleteGroup(groupID string) (*model.Group, *model.AppError)
 	DeleteGroupMember(groupID string, userID string) (*model.GroupMember, *model.AppError)
@@ -689,6 +844,7 @@ type AppIface interface {
 	GetEmojiByName(c request.CTX, emojiName string) (*model.Emoji, *model.AppError)
 	GetEmojiImage(c request.CTX, emojiId string) ([]byte, string, *model.AppError)
 	GetEmojiList(c request.CTX, page, perPage int, sort string) ([]*model.Emoji, *model.AppError)
+	GetEscalationPolicy(id string) (*model.EscalationPolicy, *model.AppError)
 	GetFile(rctx request.CTX, fileID string) ([]byte, *model.AppError)
 	GetFileInfo(rctx request.CTX, fileID string) (*model.FileInfo, *model.AppError)
 	GetFileInfos(rctx request.CTX, page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError)
@@ -738,7 +894,7 @@ type AppIface interface {
 	GetNextPostIdFromPostList(postList *model.PostList, collapsedThreads bool) string
 	GetNotificationNameFormat(user *model.User) string
 	GetNumberOfChannelsOnTeam(c request.CTX, teamID string) (int, *model.AppError)
-	GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType, redirectURI, code, secret, refreshToken string) (*model.AccessResponse, *model.AppError)
+	GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType, redirectURI, code, secret, refreshToken, codeVerifier string) (*model.AccessResponse, *model.AppError)
 	GetOAuthAccessTokenForImplicitFlow(c request.CTX, userID string, authRequest *model.AuthorizeRequest) (*model.Session, *model.AppError)
 	GetOAuthApp(appID string) (*model.OAuthApp, *model.AppError)
 	GetOAuthApps(page, perPage int) ([]*model.OAuthApp, *model.AppError)
@@ -809,6 +965,7 @@ type AppIface interface {
 	GetSchemeRolesForTeam(teamID string) (string, string, string, *model.AppError)
 	GetSchemes(scope string, offset int, limit int) ([]*model.Scheme, *model.AppError)
 	GetSchemesPage(scope string, page int, perPage int) ([]*model.Scheme, *model.AppError)
+	GetScimUser(userID string) (*model.ScimUser, *model.AppError)
 	GetServerLimits() (*model.ServerLimits, *model.AppError)
 	GetSession(token string) (*model.Session, *model.AppError)
 	GetSessionById(c request.CTX, sessionID string) (*model.Session, *model.AppError)
@@ -954,6 +1111,7 @@ type AppIface interface {
 	ListExports() ([]string, *model.AppError)
 	ListImports() ([]string, *model.AppError)
 	ListPluginKeys(pluginID string, page, perPage int) ([]string, *model.AppError)
+	ListPluginKeysWithPrefix(pluginID, prefix string, page, perPage int) ([]string, *model.AppError)
 	ListTeamCommands(teamID string) ([]*model.Command, *model.AppError)
 	Log() *mlog.Logger
 	LoginByOAuth(c request.CTX, service string, userData io.Reader, teamID string, tokenUser *model.User) (*model.User, *model.AppError)
@@ -0,0 +1,499 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"unicode/utf8"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// GetGroupAttributeRule returns the attribute rule of an attribute group.
+func (a *App) GetGroupAttributeRule(groupID string) (*model.GroupAttributeRule, *model.AppError) {
+	rule, err := a.Srv().Store().AttributeGroup().GetRule(groupID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetGroupAttributeRule", "app.attribute_group.get_rule.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("GetGroupAttributeRule", "app.attribute_group.get_rule.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return rule, nil
+}
+
+// SaveGroupAttributeRule sets the attribute rule of an attribute group, and synchronizes the
+// members of the group with it in the background.
+func (a *App) SaveGroupAttributeRule(rctx request.CTX, rule *model.GroupAttributeRule) (*model.GroupAttributeRule, *model.AppError) {
+	group, appErr := a.GetGroup(rule.GroupId, nil, nil)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if group.Source != model.GroupSourceAttribute {
+		return nil, model.NewAppError("SaveGroupAttributeRule", "app.attribute_group.not_attribute_group.app_error", nil, "group_id="+group.Id, http.StatusBadRequest)
+	}
+
+	savedRule, err := a.Srv().Store().AttributeGroup().SaveRule(rule)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("SaveGroupAttributeRule", "app.attribute_group.save_rule.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.Srv().Go(func() {
+		if appErr := a.syncAttributeGroups(rctx, []*model.GroupAttributeRule{savedRule}); appErr != nil {
+			rctx.Logger().Warn("Failed to synchronize the members of the attribute group", mlog.String("group_id", savedRule.GroupId), mlog.Err(appErr))
+		}
+	})
+
+	return savedRule, nil
+}
+
+func (a *App) getGroupAttributeRules() ([]*model.GroupAttributeRule, *model.AppError) {
+	rules, err := a.Srv().Store().AttributeGroup().GetRules()
+	if err != nil {
+		return nil, model.NewAppError("getGroupAttributeRules", "app.attribute_group.get_rules.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return rules, nil
+}
+
+// attributeRuleNames returns the names of the attributes the rules are matched against.
+func attributeRuleNames(rules []*model.GroupAttributeRule) []string {
+	seen := make(map[string]bool, len(rules))
+	names := make([]string, 0, len(rules))
+	for _, rule := range rules {
+		if !seen[rule.Attribute] {
+			seen[rule.Attribute] = true
+			names = append(names, rule.Attribute)
+		}
+	}
+
+	return names
+}
+
+// keptUserAttributeNames returns the names of the attributes kept for users: those the rules are
+// matched against, and those the channel access policies refer to.
+func (a *App) keptUserAttributeNames(rules []*model.GroupAttributeRule) ([]string, *model.AppError) {
+	names := attributeRuleNames(rules)
+
+	policyNames, appErr := a.channelAccessPolicyAttributeNames()
+	if appErr != nil {
+		return nil, appErr
+	}
+	for _, name := range policyNames {
+		if !slices.Contains(names, name) {
+			names = append(names, name)
+		}
+	}
+
+	return names, nil
+}
+
+// filterUserAttributes keeps only the attributes with the given names, and the values short
+// enough to match a rule.
+func filterUserAttributes(names []string, attributes map[string][]string) map[string][]string {
+	filtered := make(map[string][]string)
+	for _, name := range names {
+		for _, value := range attributes[name] {
+			if value == "" || utf8.RuneCountInString(value) > model.GroupAttributeRuleValueMaxLength {
+				continue
+			}
+			if len(filtered[name]) == model.UserAttributesMaxValues {
+				break
+			}
+			filtered[name] = append(filtered[name], value)
+		}
+	}
+
+	return filtered
+}
+
+// SyncUserAttributeGroups keeps the attributes of a user logging in with SAML or OpenID Connect,
+// makes the user a member of exactly the attribute groups whose rules match them, and adds the
+// user to the teams and channels of the groups. Only the attributes matched by rules or referred
+// to by channel access policies are kept.
+func (a *App) SyncUserAttributeGroups(rctx request.CTX, userID string, attributes map[string][]string) *model.AppError {
+	rules, appErr := a.getGroupAttributeRules()
+	if appErr != nil {
+		return appErr
+	}
+	names, appErr := a.keptUserAttributeNames(rules)
+	if appErr != nil {
+		return appErr
+	}
+	if len(names) == 0 {
+		return nil
+	}
+
+	attributes = filterUserAttributes(names, attributes)
+	if err := a.Srv().Store().AttributeGroup().SetUserAttributes(userID, attributes); err != nil {
+		return model.NewAppError("SyncUserAttributeGroups", "app.attribute_group.set_user_attributes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	currentGroups, appErr := a.GetGroupsByUserId(userID)
+	if appErr != nil {
+		return appErr
+	}
+	isMember := make(map[string]bool, len(currentGroups))
+	for _, group := range currentGroups {
+		if group.Source == model.GroupSourceAttribute {
+			isMember[group.Id] = true
+		}
+	}
+
+	since := model.GetMillis()
+	var added, removed bool
+	for _, rule := range rules {
+		switch matches := rule.Matches(attributes); {
+		case matches && !isMember[rule.GroupId]:
+			if _, appErr := a.UpsertGroupMember(rule.GroupId, userID); appErr != nil {
+				return appErr
+			}
+			added = true
+		case !matches && isMember[rule.GroupId]:
+			if _, appErr := a.DeleteGroupMember(rule.GroupId, userID); appErr != nil {
+				return appErr
+			}
+			removed = true
+		}
+	}
+
+	// The user is added to the teams and channels of the groups before the login completes.
+	if added {
+		if err := a.CreateDefaultMemberships(rctx, model.CreateDefaultMembershipParams{Since: since, ScopedUserID: &userID}); err != nil {
+			rctx.Logger().Warn("Failed to add the user to the teams and channels of attribute groups", mlog.String("user_id", userID), mlog.Err(err))
+		}
+	}
+	if removed {
+		a.Srv().Go(func() {
+			if err := a.DeleteGroupConstrainedMemberships(rctx); err != nil {
+				rctx.Logger().Warn("Failed to remove the former members of attribute groups from their teams and channels", mlog.Err(err))
+			}
+		})
+	}
+
+	return nil
+}
+
+// attributeGroupMemberChanges returns the users to add to and remove from each attribute group
+// for its members to be the users whose kept attributes match its rule.
+func (a *App) attributeGroupMemberChanges(rules []*model.GroupAttributeRule) (map[string][]string, map[string][]string, *model.AppError) {
+	toAdd := make(map[string][]string)
+	toRemove := make(map[string][]string)
+
+	for _, rule := range rules {
+		userIDs, err := a.Srv().Store().AttributeGroup().GetUserIDsByAttribute(rule.Attribute, rule.Value)
+		if err != nil {
+			return nil, nil, model.NewAppError("attributeGroupMemberChanges", "app.attribute_group.get_users.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		members, appErr := a.GetGroupMemberUsers(rule.GroupId)
+		if appErr != nil {
+			return nil, nil, appErr
+		}
+
+		matches := make(map[string]bool, len(userIDs))
+		for _, userID := range userIDs {
+			matches[userID] = true
+		}
+		isMember := make(map[string]bool, len(members))
+		for _, member := range members {
+			isMember[member.Id] = true
+			if !matches[member.Id] {
+				toRemove[rule.GroupId] = append(toRemove[rule.GroupId], member.Id)
+			}
+		}
+		for _, userID := range userIDs {
+			if !isMember[userID] {
+				toAdd[rule.GroupId] = append(toAdd[rule.GroupId], userID)
+			}
+		}
+	}
+
+	return toAdd, toRemove, nil
+}
+
+// SyncAttributeGroups synchronizes the members of all attribute groups with their rules, and
+// the members of the teams and channels the groups are linked to. Users are matched against
+// the attributes kept from their last login.
+func (a *App) SyncAttributeGroups(rctx request.CTX) *model.AppError {
+	rules, appErr := a.getGroupAttributeRules()
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.syncAttributeGroups(rctx, rules)
+}
+
+func (a *App) syncAttributeGroups(rctx request.CTX, rules []*model.GroupAttributeRule) *model.AppError {
+	toAdd, toRemove, appErr := a.attributeGroupMemberChanges(rules)
+	if appErr != nil {
+		return appErr
+	}
+
+	since := model.GetMillis()
+	for groupID, userIDs := range toAdd {
+		if _, appErr := a.UpsertGroupMembers(groupID, userIDs); appErr != nil {
+			return appErr
+		}
+	}
+	for groupID, userIDs := range toRemove {
+		if _, appErr := a.DeleteGroupMembers(groupID, userIDs); appErr != nil {
+			return appErr
+		}
+	}
+
+	if len(toAdd) > 0 {
+		if err := a.CreateDefaultMemberships(rctx, model.CreateDefaultMembershipParams{Since: since}); err != nil {
+			return model.NewAppError("SyncAttributeGroups", "app.attribute_group.sync_memberships.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+	if len(toRemove) > 0 {
+		if err := a.DeleteGroupConstrainedMemberships(rctx); err != nil {
+			return model.NewAppError("SyncAttributeGroups", "app.attribute_group.sync_memberships.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// PreviewAttributeGroupSync returns the group, team and channel memberships synchronizing the
+// attribute groups would add and remove, without changing them. Only the given group is
+// synchronized when its id is given.
+func (a *App) PreviewAttributeGroupSync(rctx request.CTX, groupID string) ([]*model.MembershipChange, *model.AppError) {
+	var rules []*model.GroupAttributeRule
+	if groupID != "" {
+		rule, appErr := a.GetGroupAttributeRule(groupID)
+		if appErr != nil {
+			return nil, appErr
+		}
+		rules = []*model.GroupAttributeRule{rule}
+	} else {
+		var appErr *model.AppError
+		if rules, appErr = a.getGroupAttributeRules(); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	toAdd, toRemove, appErr := a.attributeGroupMemberChanges(rules)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	preview := &membershipChangePreview{
+		app:           a,
+		rctx:          rctx,
+		seen:          map[model.MembershipChange]bool{},
+		addedGroups:   map[string][]string{},
+		removedGroups: map[string][]string{},
+	}
+	for groupID, userIDs := range toAdd {
+		for _, userID := range userIDs {
+			preview.addedGroups[userID] = append(preview.addedGroups[userID], groupID)
+		}
+	}
+	for groupID, userIDs := range toRemove {
+		for _, userID := range userIDs {
+			preview.removedGroups[userID] = append(preview.removedGroups[userID], groupID)
+		}
+	}
+
+	for _, rule := range rules {
+		for _, userID := range toAdd[rule.GroupId] {
+			if appErr := preview.addGroupMember(rule.GroupId, userID); appErr != nil {
+				return nil, appErr
+			}
+		}
+	}
+	for _, rule := range rules {
+		for _, userID := range toRemove[rule.GroupId] {
+			if appErr := preview.removeGroupMember(rule.GroupId, userID); appErr != nil {
+				return nil, appErr
+			}
+		}
+	}
+
+	return preview.changes, nil
+}
+
+// membershipChangePreview collects the membership changes of PreviewAttributeGroupSync.
+type membershipChangePreview struct {
+	app     *App
+	rctx    request.CTX
+	changes []*model.MembershipChange
+	seen    map[model.MembershipChange]bool
+
+	// The attribute groups added to and removed from each user, by user id.
+	addedGroups   map[string][]string
+	removedGroups map[string][]string
+}
+
+func (p *membershipChangePreview) add(change model.MembershipChange) {
+	if p.seen[change] {
+		return
+	}
+	p.seen[change] = true
+	p.changes = append(p.changes, &change)
+}
+
+func (p *membershipChangePreview) isTeamMember(teamID, userID string) (bool, *model.AppError) {
+	if _, appErr := p.app.GetTeamMember(p.rctx, teamID, userID); appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, appErr
+	}
+	return true, nil
+}
+
+func (p *membershipChangePreview) isChannelMember(channelID, userID string) (bool, *model.AppError) {
+	if _, appErr := p.app.GetChannelMember(p.rctx, channelID, userID); appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return false, nil
+		}
+		return false, appErr
+	}
+	return true, nil
+}
+
+// addGroupMember records adding the user to the group, and to the teams and channels the group
+// adds its members to.
+func (p *membershipChangePreview) addGroupMember(groupID, userID string) *model.AppError {
+	p.add(model.MembershipChange{UserId: userID, Type: model.MembershipChangeTypeGroup, TargetId: groupID, Action: model.MembershipChangeActionAdd})
+
+	teamSyncables, appErr := p.app.GetGroupSyncables(groupID, model.GroupSyncableTypeTeam)
+	if appErr != nil {
+		return appErr
+	}
+	channelSyncables, appErr := p.app.GetGroupSyncables(groupID, model.GroupSyncableTypeChannel)
+	if appErr != nil {
+		return appErr
+	}
+
+	addToTeam := func(teamID string) *model.AppError {
+		isMember, appErr := p.isTeamMember(teamID, userID)
+		if appErr != nil {
+			return appErr
+		}
+		if !isMember {
+			p.add(model.MembershipChange{UserId: userID, Type: model.MembershipChangeTypeTeam, TargetId: teamID, Action: model.MembershipChangeActionAdd})
+		}
+		return nil
+	}
+
+	for _, syncable := range teamSyncables {
+		if !syncable.AutoAdd {
+			continue
+		}
+		if appErr := addToTeam(syncable.SyncableId); appErr != nil {
+			return appErr
+		}
+	}
+
+	for _, syncable := range channelSyncables {
+		if !syncable.AutoAdd {
+			continue
+		}
+		channel, appErr := p.app.GetChannel(p.rctx, syncable.SyncableId)
+		if appErr != nil {
+			return appErr
+		}
+		// Users are added to the team of a channel before the channel.
+		if appErr := addToTeam(channel.TeamId); appErr != nil {
+			return appErr
+		}
+		isMember, appErr := p.isChannelMember(channel.Id, userID)
+		if appErr != nil {
+			return appErr
+		}
+		if !isMember {
+			p.add(model.MembershipChange{UserId: userID, Type: model.MembershipChangeTypeChannel, TargetId: channel.Id, Action: model.MembershipChangeActionAdd})
+		}
+	}
+
+	return nil
+}
+
+// removeGroupMember records removing the user from the group, and from the teams and channels
+// constrained to the group that none of the other groups of the user is linked to.
+func (p *membershipChangePreview) removeGroupMember(groupID, userID string) *model.AppError {
+	p.add(model.MembershipChange{UserId: userID, Type: model.MembershipChangeTypeGroup, TargetId: groupID, Action: model.MembershipChangeActionRemove})
+
+	// The groups of the user once the attribute groups are synchronized.
+	currentGroups, appErr := p.app.GetGroupsByUserId(userID)
+	if appErr != nil {
+		return appErr
+	}
+	groupIDs := make(map[string]bool, len(currentGroups))
+	for _, group := range currentGroups {
+		groupIDs[group.Id] = true
+	}
+	for _, id := range p.addedGroups[userID] {
+		groupIDs[id] = true
+	}
+	for _, id := range p.removedGroups[userID] {
+		delete(groupIDs, id)
+	}
+
+	isLinkedToOtherGroup := func(syncableID string, syncableType model.GroupSyncableType) bool {
+		for id := range groupIDs {
+			if _, appErr := p.app.GetGroupSyncable(id, syncableID, syncableType); appErr == nil {
+				return true
+			}
+		}
+		return false
+	}
+
+	teamSyncables, appErr := p.app.GetGroupSyncables(groupID, model.GroupSyncableTypeTeam)
+	if appErr != nil {
+		return appErr
+	}
+	for _, syncable := range teamSyncables {
+		team, appErr := p.app.GetTeam(syncable.SyncableId)
+		if appErr != nil {
+			return appErr
+		}
+		if !team.IsGroupConstrained() || isLinkedToOtherGroup(team.Id, model.GroupSyncableTypeTeam) {
+			continue
+		}
+		isMember, appErr := p.isTeamMember(team.Id, userID)
+		if appErr != nil {
+			return appErr
+		}
+		if isMember {
+			p.add(model.MembershipChange{UserId: userID, Type: model.MembershipChangeTypeTeam, TargetId: team.Id, Action: model.MembershipChangeActionRemove})
+		}
+	}
+
+	channelSyncables, appErr := p.app.GetGroupSyncables(groupID, model.GroupSyncableTypeChannel)
+	if appErr != nil {
+		return appErr
+	}
+	for _, syncable := range channelSyncables {
+		channel, appErr := p.app.GetChannel(p.rctx, syncable.SyncableId)
+		if appErr != nil {
+			return appErr
+		}
+		if !channel.IsGroupConstrained() || isLinkedToOtherGroup(channel.Id, model.GroupSyncableTypeChannel) {
+			continue
+		}
+		isMember, appErr := p.isChannelMember(channel.Id, userID)
+		if appErr != nil {
+			return appErr
+		}
+		if isMember {
+			p.add(model.MembershipChange{UserId: userID, Type: model.MembershipChangeTypeChannel, TargetId: channel.Id, Action: model.MembershipChangeActionRemove})
+		}
+	}
+
+	return nil
+}
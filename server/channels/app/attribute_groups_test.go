@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestFilterUserAttributes(t *testing.T) {
+	rules := []*model.GroupAttributeRule{
+		{Attribute: "department", Value: "Engineering"},
+		{Attribute: "department", Value: "Sales"},
+		{Attribute: "location", Value: "Paris"},
+	}
+
+	filtered := filterUserAttributes(attributeRuleNames(rules), map[string][]string{
+		"department": {"Engineering", "", strings.Repeat("a", model.GroupAttributeRuleValueMaxLength+1)},
+		"location":   {"Paris"},
+		"salary":     {"secret"},
+	})
+	assert.Equal(t, map[string][]string{
+		"department": {"Engineering"},
+		"location":   {"Paris"},
+	}, filtered)
+}
+
+func createAttributeGroup(t *testing.T, th *TestHelper, attribute, value string) *model.Group {
+	t.Helper()
+
+	group, appErr := th.App.CreateGroup(&model.Group{
+		Name:        model.NewPointer("attribute-" + model.NewId()),
+		DisplayName: attribute + "=" + value,
+		Source:      model.GroupSourceAttribute,
+	})
+	require.Nil(t, appErr)
+
+	_, err := th.App.Srv().Store().AttributeGroup().SaveRule(&model.GroupAttributeRule{GroupId: group.Id, Attribute: attribute, Value: value})
+	require.NoError(t, err)
+
+	return group
+}
+
+func TestSaveGroupAttributeRule(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	group := th.CreateGroup()
+	_, appErr := th.App.SaveGroupAttributeRule(th.Context, &model.GroupAttributeRule{GroupId: group.Id, Attribute: "department", Value: "Engineering"})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.attribute_group.not_attribute_group.app_error", appErr.Id)
+
+	_, appErr = th.App.GetGroupAttributeRule(group.Id)
+	require.NotNil(t, appErr)
+	assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+}
+
+func TestSyncUserAttributeGroups(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	group := createAttributeGroup(t, th, "department", "Engineering")
+	team := th.CreateTeam()
+	_, appErr := th.App.UpsertGroupSyncable(model.NewGroupTeam(group.Id, team.Id, true))
+	require.Nil(t, appErr)
+
+	user := th.CreateUser()
+
+	isGroupMember := func(t *testing.T) bool {
+		t.Helper()
+		groups, appErr := th.App.GetGroupsByUserId(user.Id)
+		require.Nil(t, appErr)
+		for _, g := range groups {
+			if g.Id == group.Id {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("users matching the rule join the group and its teams", func(t *testing.T) {
+		appErr := th.App.SyncUserAttributeGroups(th.Context, user.Id, map[string][]string{
+			"department": {"Engineering"},
+			"salary":     {"secret"},
+		})
+		require.Nil(t, appErr)
+		assert.True(t, isGroupMember(t))
+
+		_, appErr = th.App.GetTeamMember(th.Context, team.Id, user.Id)
+		require.Nil(t, appErr)
+
+		attributes, err := th.App.Srv().Store().AttributeGroup().GetUserAttributes(user.Id)
+		require.NoError(t, err)
+		assert.Equal(t, map[string][]string{"department": {"Engineering"}}, attributes)
+	})
+
+	t.Run("users not matching the rule anymore leave the group", func(t *testing.T) {
+		appErr := th.App.SyncUserAttributeGroups(th.Context, user.Id, map[string][]string{"department": {"Sales"}})
+		require.Nil(t, appErr)
+		assert.False(t, isGroupMember(t))
+	})
+}
+
+func TestSyncAttributeGroups(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	group := createAttributeGroup(t, th, "department", "Engineering")
+	team := th.CreateTeam()
+	_, appErr := th.App.UpsertGroupSyncable(model.NewGroupTeam(group.Id, team.Id, true))
+	require.Nil(t, appErr)
+
+	// The user logged in before the rule was matched against their attributes.
+	user := th.CreateUser()
+	err := th.App.Srv().Store().AttributeGroup().SetUserAttributes(user.Id, map[string][]string{"department": {"Engineering"}})
+	require.NoError(t, err)
+
+	t.Run("the preview doesn't change memberships", func(t *testing.T) {
+		changes, appErr := th.App.PreviewAttributeGroupSync(th.Context, "")
+		require.Nil(t, appErr)
+		assert.ElementsMatch(t, []*model.MembershipChange{
+			{UserId: user.Id, Type: model.MembershipChangeTypeGroup, TargetId: group.Id, Action: model.MembershipChangeActionAdd},
+			{UserId: user.Id, Type: model.MembershipChangeTypeTeam, TargetId: team.Id, Action: model.MembershipChangeActionAdd},
+		}, changes)
+
+		_, appErr = th.App.GetTeamMember(th.Context, team.Id, user.Id)
+		require.NotNil(t, appErr)
+	})
+
+	t.Run("the synchronization applies the preview", func(t *testing.T) {
+		require.Nil(t, th.App.SyncAttributeGroups(th.Context))
+
+		_, appErr := th.App.GetTeamMember(th.Context, team.Id, user.Id)
+		require.Nil(t, appErr)
+
+		changes, appErr := th.App.PreviewAttributeGroupSync(th.Context, group.Id)
+		require.Nil(t, appErr)
+		assert.Empty(t, changes)
+	})
+
+	t.Run("users leave the teams constrained to the group", func(t *testing.T) {
+		team.GroupConstrained = model.NewPointer(true)
+		_, appErr := th.App.UpdateTeam(team)
+		require.Nil(t, appErr)
+
+		err := th.App.Srv().Store().AttributeGroup().SetUserAttributes(user.Id, map[string][]string{"department": {"Sales"}})
+		require.NoError(t, err)
+
+		changes, appErr := th.App.PreviewAttributeGroupSync(th.Context, group.Id)
+		require.Nil(t, appErr)
+		assert.ElementsMatch(t, []*model.MembershipChange{
+			{UserId: user.Id, Type: model.MembershipChangeTypeGroup, TargetId: group.Id, Action: model.MembershipChangeActionRemove},
+			{UserId: user.Id, Type: model.MembershipChangeTypeTeam, TargetId: team.Id, Action: model.MembershipChangeActionRemove},
+		}, changes)
+	})
+}
@@ -46,7 +46,7 @@ func (tl TokenLocation) String() string {
 }
 
 func (a *App) IsPasswordValid(rctx request.CTX, password string) *model.AppError {
-	if err := users.IsPasswordValidWithSettings(password, &a.Config().PasswordSettings); err != nil {
+	if err := a.ch.srv.userService.IsPasswordValid(password); err != nil {
 		var invErr *users.ErrInvalidPassword
 		switch {
 		case errors.As(err, &invErr):
@@ -79,9 +79,13 @@ func (a *App) CheckPasswordAndAllCriteria(rctx request.CTX, userID string, passw
 		return err
 	}
 
+	if err := a.checkLoginBackoff(rctx, user); err != nil {
+		return err
+	}
+
 	if err := users.CheckUserPassword(user, password); err != nil {
-		if passErr := a.Srv().Store().User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
-			return model.NewAppError("CheckPasswordAndAllCriteria", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(passErr)
+		if passErr := a.recordFailedLogin(rctx, user); passErr != nil {
+			return passErr
 		}
 
 		var invErr *users.ErrInvalidPassword
@@ -97,34 +101,57 @@ func (a *App) CheckPasswordAndAllCriteria(rctx request.CTX, userID string, passw
 		// If the mfaToken is not set, we assume the client used this as a pre-flight request to query the server
 		// about the MFA state of the user in question
 		if mfaToken != "" {
-			if passErr := a.Srv().Store().User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
-				return model.NewAppError("CheckPasswordAndAllCriteria", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(passErr)
+			if passErr := a.recordFailedLogin(rctx, user); passErr != nil {
+				return passErr
 			}
 		}
 
 		return err
 	}
 
-	if passErr := a.Srv().Store().User().UpdateFailedPasswordAttempts(user.Id, 0); passErr != nil {
-		return model.NewAppError("CheckPasswordAndAllCriteria", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(passErr)
+	if passErr := a.resetFailedLogins(rctx, user); passErr != nil {
+		return passErr
 	}
 
 	if err := a.CheckUserPostflightAuthenticationCriteria(rctx, user); err != nil {
 		return err
 	}
 
+	if expiredBefore, ok := a.getPasswordExpiredBefore(); ok && user.LastPasswordUpdate < expiredBefore {
+		// The user proved who they are, so send them the link to reset the password right away,
+		// unless a link sent earlier can still be used.
+		a.Srv().Go(func() {
+			if pending, err := a.hasPendingPasswordRecoveryToken(user.Id); err != nil {
+				rctx.Logger().Warn("Failed to check the password recovery tokens for an expired password", mlog.String("user_id", user.Id), mlog.Err(err))
+				return
+			} else if pending {
+				return
+			}
+
+			if _, err := a.SendPasswordReset(rctx, user.Email, a.GetSiteURL()); err != nil {
+				rctx.Logger().Warn("Failed to send the password reset email for an expired password", mlog.String("user_id", user.Id), mlog.Err(err))
+			}
+		})
+
+		return model.NewAppError("CheckPasswordAndAllCriteria", "api.user.login.password_expired.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
+	}
+
 	return nil
 }
 
 // This to be used for places we check the users password when they are already logged in
 func (a *App) DoubleCheckPassword(rctx request.CTX, user *model.User, password string) *model.AppError {
-	if err := checkUserLoginAttempts(user, *a.Config().ServiceSettings.MaximumLoginAttempts); err != nil {
+	if err := a.checkUserLoginAttempts(rctx, user); err != nil {
+		return err
+	}
+
+	if err := a.checkLoginBackoff(rctx, user); err != nil {
 		return err
 	}
 
 	if err := users.CheckUserPassword(user, password); err != nil {
-		if passErr := a.Srv().Store().User().UpdateFailedPasswordAttempts(user.Id, user.FailedAttempts+1); passErr != nil {
-			return model.NewAppError("DoubleCheckPassword", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(passErr)
+		if passErr := a.recordFailedLogin(rctx, user); passErr != nil {
+			return passErr
 		}
 
 		a.InvalidateCacheForUser(user.Id)
@@ -138,8 +165,8 @@ func (a *App) DoubleCheckPassword(rctx request.CTX, user *model.User, password s
 		}
 	}
 
-	if passErr := a.Srv().Store().User().UpdateFailedPasswordAttempts(user.Id, 0); passErr != nil {
-		return model.NewAppError("DoubleCheckPassword", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(passErr)
+	if passErr := a.resetFailedLogins(rctx, user); passErr != nil {
+		return passErr
 	}
 
 	a.InvalidateCacheForUser(user.Id)
@@ -147,23 +174,34 @@ func (a *App) DoubleCheckPassword(rctx request.CTX, user *model.User, password s
 	return nil
 }
 
-func (a *App) checkLdapUserPasswordAndAllCriteria(rctx request.CTX, ldapId *string, password string, mfaToken string) (*model.User, *model.AppError) {
+// checkLdapUserPasswordAndAllCriteria logs the user in with the LDAP server. The failed attempts
+// of LDAP users are counted by the LDAP service, so only the failures delaying logins are
+// recorded here.
+func (a *App) checkLdapUserPasswordAndAllCriteria(rctx request.CTX, user *model.User, password string, mfaToken string) (*model.User, *model.AppError) {
+	ldapId := user.AuthData
 	if a.Ldap() == nil || ldapId == nil {
 		err := model.NewAppError("doLdapAuthentication", "api.user.login_ldap.not_available.app_error", nil, "", http.StatusNotImplemented)
 		return nil, err
 	}
 
+	if err := a.checkLoginBackoff(rctx, user); err != nil {
+		return nil, err
+	}
+
 	ldapUser, err := a.Ldap().DoLogin(rctx, *ldapId, password)
 	if err != nil {
 		// Log a info to make it easier to admin to spot that a user tried to log in with a legitimate user name.
 		if err.Id == "ent.ldap.do_login.invalid_password.app_error" {
 			rctx.Logger().LogM(mlog.MlvlLDAPInfo, "A user tried to sign in, which matched an LDAP account, but the password was incorrect.", mlog.String("ldap_id", *ldapId))
+			a.recordLoginBackoffFailure(rctx, user.Id, model.GetMillis())
 		}
 
 		err.StatusCode = http.StatusUnauthorized
 		return nil, err
 	}
 
+	a.clearLoginBackoffFailures(rctx, user.Id)
+
 	if err := a.CheckUserMfa(rctx, ldapUser, mfaToken); err != nil {
 		return nil, err
 	}
@@ -197,7 +235,7 @@ func (a *App) CheckUserPreflightAuthenticationCriteria(rctx request.CTX, user *m
 		return err
 	}
 
-	if err := checkUserLoginAttempts(user, *a.Config().ServiceSettings.MaximumLoginAttempts); err != nil {
+	if err := a.checkUserLoginAttempts(rctx, user); err != nil {
 		return err
 	}
 
@@ -233,14 +271,6 @@ func (a *App) CheckUserMfa(rctx request.CTX, user *model.User, token string) *mo
 	return nil
 }
 
-func checkUserLoginAttempts(user *model.User, max int) *model.AppError {
-	if user.FailedAttempts >= max {
-		return model.NewAppError("checkUserLoginAttempts", "api.user.check_user_login_attempts.too_many.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
-	}
-
-	return nil
-}
-
 func checkUserNotDisabled(user *model.User) *model.AppError {
 	if user.DeleteAt > 0 {
 		return model.NewAppError("Login", "api.user.login.inactive.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
@@ -265,9 +295,11 @@ func (a *App) authenticateUser(rctx request.CTX, user *model.User, password, mfa
 			return user, err
 		}
 
-		ldapUser, err := a.checkLdapUserPasswordAndAllCriteria(rctx, user.AuthData, password, mfaToken)
+		ldapUser, err := a.checkLdapUserPasswordAndAllCriteria(rctx, user, password, mfaToken)
 		if err != nil {
-			err.StatusCode = http.StatusUnauthorized
+			if err.StatusCode != http.StatusTooManyRequests {
+				err.StatusCode = http.StatusUnauthorized
+			}
 			return user, err
 		}
 
@@ -289,7 +321,9 @@ func (a *App) authenticateUser(rctx request.CTX, user *model.User, password, mfa
 			rctx.Logger().LogM(mlog.MlvlLDAPInfo, "A user tried to sign in, which matched a Mattermost account, but the password was incorrect.", mlog.String("username", user.Username))
 		}
 
-		err.StatusCode = http.StatusUnauthorized
+		if err.StatusCode != http.StatusTooManyRequests {
+			err.StatusCode = http.StatusUnauthorized
+		}
 		return user, err
 	}
 
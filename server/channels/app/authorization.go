@@ -18,6 +18,9 @@ func (a *App) SessionHasPermissionTo(session model.Session, permission *model.Pe
 	if session.IsUnrestricted() {
 		return true
 	}
+	if !session.GetUserAccessTokenScopes().AllowsSystemPermission(permission) {
+		return false
+	}
 	return a.RolesGrantPermission(session.GetUserRoles(), permission.Id)
 }
 
@@ -38,6 +41,17 @@ func (a *App) SessionHasPermissionToTeam(session model.Session, teamID string, p
 		return true
 	}
 
+	scopes := session.GetUserAccessTokenScopes()
+	if !scopes.AllowsPermission(permission.Id) || !scopes.AllowsTeam(teamID) {
+		return false
+	}
+
+	return a.sessionRolesGrantTeamPermission(session, teamID, permission)
+}
+
+// sessionRolesGrantTeamPermission returns true if the team or system roles of the session grant
+// the permission, regardless of the scopes of the session.
+func (a *App) sessionRolesGrantTeamPermission(session model.Session, teamID string, permission *model.Permission) bool {
 	teamMember := session.GetTeamByTeamId(teamID)
 	if teamMember != nil {
 		if a.RolesGrantPermission(teamMember.GetRoles(), permission.Id) {
@@ -54,14 +68,19 @@ func (a *App) SessionHasPermissionToTeams(c request.CTX, session model.Session,
 		return true
 	}
 
+	scopes := session.GetUserAccessTokenScopes()
+	if !scopes.AllowsPermission(permission.Id) {
+		return false
+	}
 	for _, teamID := range teamIDs {
-		if teamID == "" {
+		if teamID == "" || !scopes.AllowsTeam(teamID) {
 			return false
 		}
 	}
 
-	// Check session permission, if it allows access, no need to check teams.
-	if a.SessionHasPermissionTo(session, permission) {
+	// Check session permission, if it allows access, no need to check teams. The scopes of the
+	// session were checked for each team already.
+	if session.IsUnrestricted() || a.RolesGrantPermission(session.GetUserRoles(), permission.Id) {
 		return true
 	}
 	for _, teamID := range teamIDs {
@@ -82,6 +101,10 @@ func (a *App) SessionHasPermissionToChannel(c request.CTX, session model.Session
 		return false
 	}
 
+	if !a.sessionScopesAllowChannel(c, session, channelID, permission) {
+		return false
+	}
+
 	ids, err := a.Srv().Store().Channel().GetAllChannelMembersForUser(c, session.UserId, true, true)
 	var channelRoles []string
 	if err == nil {
@@ -103,12 +126,31 @@ func (a *App) SessionHasPermissionToChannel(c request.CTX, session model.Session
 	}
 
 	if appErr == nil && channel.TeamId != "" {
-		return a.SessionHasPermissionToTeam(session, channel.TeamId, permission)
+		return a.sessionRolesGrantTeamPermission(session, channel.TeamId, permission)
 	}
 
 	return a.SessionHasPermissionTo(session, permission)
 }
 
+// sessionScopesAllowChannel returns true if the scopes of the session, if any, allow the
+// permission on the channel.
+func (a *App) sessionScopesAllowChannel(c request.CTX, session model.Session, channelID string, permission *model.Permission) bool {
+	scopes := session.GetUserAccessTokenScopes()
+	if !scopes.AllowsPermission(permission.Id) {
+		return false
+	}
+	if !scopes.RestrictsResources() {
+		return true
+	}
+
+	channel, appErr := a.GetChannel(c, channelID)
+	if appErr != nil {
+		return false
+	}
+
+	return scopes.AllowsChannel(channel.Id, channel.TeamId)
+}
+
 // SessionHasPermissionToChannels returns true only if user has access to all channels.
 func (a *App) SessionHasPermissionToChannels(c request.CTX, session model.Session, channelIDs []string, permission *model.Permission) bool {
 	if len(channelIDs) == 0 {
@@ -116,7 +158,7 @@ func (a *App) SessionHasPermissionToChannels(c request.CTX, session model.Sessio
 	}
 
 	for _, channelID := range channelIDs {
-		if channelID == "" {
+		if channelID == "" || !a.sessionScopesAllowChannel(c, session, channelID, permission) {
 			return false
 		}
 	}
@@ -152,6 +194,11 @@ func (a *App) SessionHasPermissionToChannels(c request.CTX, session model.Sessio
 }
 
 func (a *App) SessionHasPermissionToGroup(session model.Session, groupID string, permission *model.Permission) bool {
+	// Groups are neither teams nor channels, which a token restricted by scopes could be restricted to.
+	if scopes := session.GetUserAccessTokenScopes(); !scopes.AllowsPermission(permission.Id) || scopes.RestrictsResources() {
+		return false
+	}
+
 	groupMember, err := a.Srv().Store().Group().GetMember(groupID, session.UserId)
 	// don't reject immediately on ErrNoRows error because there's further authz logic below for non-groupmembers
 	if err != nil && !errors.Is(err, sql.ErrNoRows) {
@@ -175,6 +222,17 @@ func (a *App) SessionHasPermissionToChannelByPost(session model.Session, postID
 		return false
 	}
 
+	scopes := session.GetUserAccessTokenScopes()
+	if !scopes.AllowsPermission(permission.Id) {
+		return false
+	}
+	if scopes.RestrictsResources() {
+		channel, err := a.Srv().Store().Channel().GetForPost(postID)
+		if err != nil || !scopes.AllowsChannel(channel.Id, channel.TeamId) {
+			return false
+		}
+	}
+
 	if channelMember, err := a.Srv().Store().Channel().GetMemberForPost(postID, session.UserId, *a.Config().TeamSettings.ExperimentalViewArchivedChannels); err == nil {
 		if a.RolesGrantPermission(channelMember.GetRoles(), permission.Id) {
 			return true
@@ -183,7 +241,7 @@ func (a *App) SessionHasPermissionToChannelByPost(session model.Session, postID
 
 	if channel, err := a.Srv().Store().Channel().GetForPost(postID); err == nil {
 		if channel.TeamId != "" {
-			return a.SessionHasPermissionToTeam(session, channel.TeamId, permission)
+			return a.sessionRolesGrantTeamPermission(session, channel.TeamId, permission)
 		}
 	}
 
@@ -206,7 +264,7 @@ func (a *App) SessionHasPermissionToUser(session model.Session, userID string) b
 		return true
 	}
 
-	if session.UserId == userID {
+	if session.CanActAsUser(userID) {
 		return true
 	}
 
@@ -376,6 +434,14 @@ func (a *App) SessionHasPermissionToReadChannel(c request.CTX, session model.Ses
 		return true
 	}
 
+	scopes := session.GetUserAccessTokenScopes()
+	if !scopes.AllowsChannel(channel.Id, channel.TeamId) {
+		return false
+	}
+	if !scopes.AllowsPermission(model.PermissionReadChannelContent.Id) && !scopes.AllowsPermission(model.PermissionReadPublicChannel.Id) {
+		return false
+	}
+
 	return a.HasPermissionToReadChannel(c, session.UserId, channel)
 }
 
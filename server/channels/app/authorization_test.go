@@ -172,6 +172,99 @@ func TestSessionHasPermissionToChannel(t *testing.T) {
 	})
 }
 
+func TestSessionHasPermissionWithUserAccessTokenScopes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	newSession := func(scopes ...string) model.Session {
+		session := model.Session{
+			UserId:      th.BasicUser.Id,
+			Roles:       th.BasicUser.GetRawRoles(),
+			TeamMembers: []*model.TeamMember{{TeamId: th.BasicTeam.Id, UserId: th.BasicUser.Id, SchemeUser: true}},
+		}
+		session.AddProp(model.SessionPropType, model.SessionTypeUserAccessToken)
+		session.AddProp(model.SessionPropUserAccessTokenScopes, strings.Join(scopes, " "))
+		return session
+	}
+
+	t.Run("permission scopes", func(t *testing.T) {
+		session := newSession("permission:" + model.PermissionCreatePost.Id)
+		assert.True(t, th.App.SessionHasPermissionToChannel(th.Context, session, th.BasicChannel.Id, model.PermissionCreatePost))
+		assert.False(t, th.App.SessionHasPermissionToChannel(th.Context, session, th.BasicChannel.Id, model.PermissionAddReaction))
+		assert.False(t, th.App.SessionHasPermissionToTeam(session, th.BasicTeam.Id, model.PermissionListTeamChannels))
+		assert.False(t, th.App.SessionHasPermissionTo(session, model.PermissionCreateTeam))
+	})
+
+	t.Run("team scopes", func(t *testing.T) {
+		session := newSession("team:" + th.BasicTeam.Id)
+		assert.True(t, th.App.SessionHasPermissionToTeam(session, th.BasicTeam.Id, model.PermissionListTeamChannels))
+		assert.True(t, th.App.SessionHasPermissionToChannel(th.Context, session, th.BasicChannel.Id, model.PermissionCreatePost))
+		assert.False(t, th.App.SessionHasPermissionToTeam(session, model.NewId(), model.PermissionListTeamChannels))
+	})
+
+	t.Run("team scopes deny system permissions", func(t *testing.T) {
+		session := newSession("team:" + th.BasicTeam.Id)
+		session.UserId = th.SystemAdminUser.Id
+		session.Roles = th.SystemAdminUser.GetRawRoles()
+		assert.False(t, th.App.SessionHasPermissionTo(session, model.PermissionManageSystem))
+		assert.True(t, th.App.SessionHasPermissionToTeam(session, th.BasicTeam.Id, model.PermissionManageTeam))
+		assert.False(t, th.App.SessionHasPermissionToUser(session, th.BasicUser.Id))
+	})
+
+	t.Run("scoped tokens can't act as their user", func(t *testing.T) {
+		assert.True(t, th.App.SessionHasPermissionToUser(newSession(), th.BasicUser.Id))
+		assert.False(t, th.App.SessionHasPermissionToUser(newSession("permission:"+model.PermissionCreatePost.Id), th.BasicUser.Id))
+		assert.False(t, th.App.SessionHasPermissionToUser(newSession("channel:"+th.BasicChannel.Id), th.BasicUser.Id))
+	})
+
+	t.Run("channel scopes", func(t *testing.T) {
+		otherChannel := th.CreateChannel(th.Context, th.BasicTeam)
+		session := newSession("channel:" + th.BasicChannel.Id)
+		assert.True(t, th.App.SessionHasPermissionToChannel(th.Context, session, th.BasicChannel.Id, model.PermissionCreatePost))
+		assert.False(t, th.App.SessionHasPermissionToChannel(th.Context, session, otherChannel.Id, model.PermissionCreatePost))
+		assert.False(t, th.App.SessionHasPermissionToTeam(session, th.BasicTeam.Id, model.PermissionListTeamChannels))
+		assert.True(t, th.App.SessionHasPermissionToReadChannel(th.Context, session, th.BasicChannel))
+		assert.False(t, th.App.SessionHasPermissionToReadChannel(th.Context, session, otherChannel))
+	})
+}
+
+func TestCreateSessionForUserAccessTokenWithExpiryAndScopes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableUserAccessTokens = true })
+
+	t.Run("expiring scoped token", func(t *testing.T) {
+		expiresAt := model.GetMillis() + 60*60*1000
+		token, appErr := th.App.CreateUserAccessToken(th.Context, &model.UserAccessToken{
+			UserId:      th.BasicUser.Id,
+			Description: "scoped",
+			ExpiresAt:   expiresAt,
+			Scopes:      model.StringArray{"channel:" + th.BasicChannel.Id},
+		})
+		require.Nil(t, appErr)
+
+		session, appErr := th.App.GetSession(token.Token)
+		require.Nil(t, appErr)
+		assert.Equal(t, expiresAt, session.ExpiresAt)
+		assert.Equal(t, "channel:"+th.BasicChannel.Id, session.Props[model.SessionPropUserAccessTokenScopes])
+
+		token, appErr = th.App.GetUserAccessToken(token.Id, true)
+		require.Nil(t, appErr)
+		assert.NotZero(t, token.LastUsedAt)
+	})
+
+	t.Run("expired token", func(t *testing.T) {
+		_, appErr := th.App.CreateUserAccessToken(th.Context, &model.UserAccessToken{
+			UserId:      th.BasicUser.Id,
+			Description: "expired",
+			ExpiresAt:   model.GetMillis() - 1000,
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.user_access_token.expires_at.app_error", appErr.Id)
+	})
+}
+
 func TestSessionHasPermissionToChannels(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,414 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/ics"
+)
+
+const (
+	// MaxCalendarSize is the largest calendar accepted, either uploaded or fetched from a feed.
+	MaxCalendarSize      = 5 * 1024 * 1024
+	calendarFetchTimeout = 30 * time.Second
+	// calendarSyncConcurrency bounds the number of calendars synced at once.
+	calendarSyncConcurrency = 8
+	// calendarLookahead bounds how far back-to-back meetings are merged
+	// into a single do not disturb period.
+	calendarLookahead = 24 * time.Hour
+
+	calendarAppliedAutoResponder = "auto_responder"
+)
+
+var outOfOfficeSummaryRegexp = regexp.MustCompile(`(?i)\b(out of (the )?office|ooo)\b`)
+
+func calendarFilePath(userID string) string {
+	return "users/" + userID + "/calendar.ics"
+}
+
+// SetCalendarFeed subscribes the user's status to the ICS feed at the given URL.
+// webcal:// URLs are fetched over https.
+func (a *App) SetCalendarFeed(rctx request.CTX, userID, feedURL string) *model.AppError {
+	if appErr := a.checkCalendarStatusSyncEnabled(); appErr != nil {
+		return appErr
+	}
+
+	if strings.HasPrefix(strings.ToLower(feedURL), "webcal://") {
+		feedURL = "https://" + feedURL[len("webcal://"):]
+	}
+	if !model.IsValidHTTPURL(feedURL) {
+		return model.NewAppError("SetCalendarFeed", "app.calendar_status.invalid_url.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	data, err := a.fetchCalendar(feedURL)
+	if err != nil {
+		return model.NewAppError("SetCalendarFeed", "app.calendar_status.fetch.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return a.saveCalendar(rctx, userID, feedURL, data)
+}
+
+// UploadCalendarFile stores the given calendar and uses it to drive the user's status.
+func (a *App) UploadCalendarFile(rctx request.CTX, userID string, data []byte) *model.AppError {
+	if appErr := a.checkCalendarStatusSyncEnabled(); appErr != nil {
+		return appErr
+	}
+
+	if len(data) > MaxCalendarSize {
+		return model.NewAppError("UploadCalendarFile", "app.calendar_status.too_large.app_error", nil, "", http.StatusRequestEntityTooLarge)
+	}
+
+	return a.saveCalendar(rctx, userID, model.PreferenceCalendarFeedFile, data)
+}
+
+// DeleteCalendar stops driving the user's status from their calendar, reverting
+// any status currently set on their behalf.
+func (a *App) DeleteCalendar(rctx request.CTX, userID string) *model.AppError {
+	feed, err := a.Srv().Store().Preference().Get(userID, model.PreferenceCategoryCalendar, model.PreferenceNameCalendarFeed)
+	if err != nil {
+		return model.NewAppError("DeleteCalendar", "app.calendar_status.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+	}
+
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if appErr := a.applyCalendarStatus(rctx, user, "", time.Time{}); appErr != nil {
+		return appErr
+	}
+
+	if feed.Value == model.PreferenceCalendarFeedFile {
+		if appErr := a.RemoveFile(calendarFilePath(userID)); appErr != nil {
+			rctx.Logger().Warn("Failed to remove calendar file", mlog.String("user_id", userID), mlog.Err(appErr))
+		}
+	}
+
+	return a.deletePreferences(rctx, userID, model.Preferences{*feed})
+}
+
+// SyncCalendarStatuses updates the status of every active user with a calendar
+// according to their current events. It is run periodically by a job.
+func (a *App) SyncCalendarStatuses() error {
+	feeds, err := a.Srv().Store().Preference().GetCategoryAndName(model.PreferenceCategoryCalendar, model.PreferenceNameCalendarFeed)
+	if err != nil {
+		return errors.Wrap(err, "failed to get calendar feeds")
+	}
+
+	rctx := request.EmptyContext(a.Log())
+	now := time.Now()
+
+	// sema is a counting semaphore bounding the number of calendars fetched at once, so
+	// that a few slow feeds don't hold up the sync of everyone else.
+	sema := make(chan struct{}, calendarSyncConcurrency)
+	var wg sync.WaitGroup
+	for _, feed := range feeds {
+		sema <- struct{}{}
+		wg.Add(1)
+		go func(feed model.Preference) {
+			defer func() {
+				<-sema
+				wg.Done()
+			}()
+			a.syncCalendarFeed(rctx, feed, now)
+		}(feed)
+	}
+	wg.Wait()
+
+	return nil
+}
+
+func (a *App) syncCalendarFeed(rctx request.CTX, feed model.Preference, now time.Time) {
+	user, appErr := a.GetUser(feed.UserId)
+	if appErr != nil {
+		rctx.Logger().Warn("Failed to get user for calendar sync", mlog.String("user_id", feed.UserId), mlog.Err(appErr))
+		return
+	}
+	if user.DeleteAt != 0 {
+		return
+	}
+
+	var data []byte
+	if feed.Value == model.PreferenceCalendarFeedFile {
+		data, appErr = a.ReadFile(calendarFilePath(user.Id))
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to read calendar file", mlog.String("user_id", user.Id), mlog.Err(appErr))
+			return
+		}
+	} else {
+		var err error
+		data, err = a.fetchCalendar(feed.Value)
+		if err != nil {
+			rctx.Logger().Warn("Failed to fetch calendar feed", mlog.String("user_id", user.Id), mlog.Err(err))
+			return
+		}
+	}
+
+	if appErr := a.syncCalendarStatus(rctx, user, data, now); appErr != nil {
+		rctx.Logger().Warn("Failed to sync calendar status", mlog.String("user_id", user.Id), mlog.Err(appErr))
+	}
+}
+
+func (a *App) checkCalendarStatusSyncEnabled() *model.AppError {
+	if !*a.Config().ServiceSettings.EnableCalendarStatusSync || !*a.Config().ServiceSettings.EnableUserStatuses {
+		return model.NewAppError("checkCalendarStatusSyncEnabled", "app.calendar_status.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+func (a *App) fetchCalendar(feedURL string) ([]byte, error) {
+	client := a.HTTPService().MakeClient(false)
+	client.Timeout = calendarFetchTimeout
+
+	resp, err := client.Get(feedURL)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, MaxCalendarSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxCalendarSize {
+		return nil, errors.New("calendar is too large")
+	}
+	return data, nil
+}
+
+func (a *App) saveCalendar(rctx request.CTX, userID, feed string, data []byte) *model.AppError {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if _, err := ics.Parse(bytes.NewReader(data), user.GetTimezoneLocation()); err != nil {
+		return model.NewAppError("saveCalendar", "app.calendar_status.invalid_calendar.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	if feed == model.PreferenceCalendarFeedFile {
+		if _, appErr := a.WriteFile(bytes.NewReader(data), calendarFilePath(userID)); appErr != nil {
+			return appErr
+		}
+	} else if previous, err := a.Srv().Store().Preference().Get(userID, model.PreferenceCategoryCalendar, model.PreferenceNameCalendarFeed); err == nil && previous.Value == model.PreferenceCalendarFeedFile {
+		if appErr := a.RemoveFile(calendarFilePath(userID)); appErr != nil {
+			rctx.Logger().Warn("Failed to remove calendar file", mlog.String("user_id", userID), mlog.Err(appErr))
+		}
+	}
+
+	if appErr := a.updatePreferences(rctx, userID, model.Preferences{{
+		UserId:   userID,
+		Category: model.PreferenceCategoryCalendar,
+		Name:     model.PreferenceNameCalendarFeed,
+		Value:    feed,
+	}}); appErr != nil {
+		return appErr
+	}
+
+	return a.syncCalendarStatus(rctx, user, data, time.Now())
+}
+
+func (a *App) syncCalendarStatus(rctx request.CTX, user *model.User, data []byte, now time.Time) *model.AppError {
+	events, err := ics.Parse(bytes.NewReader(data), user.GetTimezoneLocation())
+	if err != nil {
+		return model.NewAppError("syncCalendarStatus", "app.calendar_status.invalid_calendar.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	status, until := calendarStatusAt(events, now)
+	return a.applyCalendarStatus(rctx, user, status, until)
+}
+
+// calendarStatusAt returns the status the calendar calls for at the given
+// time, and until when: out of office during all day "Out of office" events,
+// do not disturb during meetings, or no status at all.
+func calendarStatusAt(events []*ics.Event, now time.Time) (string, time.Time) {
+	var busy []ics.Occurrence
+	var outOfOfficeUntil time.Time
+	for _, event := range events {
+		if event.Cancelled {
+			continue
+		}
+
+		if isOutOfOfficeEvent(event) {
+			for _, occurrence := range event.Occurrences(now, now.Add(time.Second)) {
+				if occurrence.End.After(outOfOfficeUntil) {
+					outOfOfficeUntil = occurrence.End
+				}
+			}
+			continue
+		}
+
+		if event.AllDay || event.Transparent {
+			continue
+		}
+		busy = append(busy, event.Occurrences(now, now.Add(calendarLookahead))...)
+	}
+
+	if !outOfOfficeUntil.IsZero() {
+		return model.StatusOutOfOffice, outOfOfficeUntil
+	}
+
+	var until time.Time
+	for _, occurrence := range busy {
+		if !occurrence.Start.After(now) && occurrence.End.After(until) {
+			until = occurrence.End
+		}
+	}
+	if until.IsZero() {
+		return "", time.Time{}
+	}
+
+	// Back to back and overlapping meetings are merged so that the
+	// status does not flip between them.
+	for extended := true; extended; {
+		extended = false
+		for _, occurrence := range busy {
+			if !occurrence.Start.After(until) && occurrence.End.After(until) {
+				until = occurrence.End
+				extended = true
+			}
+		}
+	}
+	return model.StatusDnd, until
+}
+
+func isOutOfOfficeEvent(event *ics.Event) bool {
+	return event.OutOfOffice || (event.AllDay && outOfOfficeSummaryRegexp.MatchString(event.Summary))
+}
+
+// applyCalendarStatus sets the user's status as requested by their calendar,
+// or reverts the status previously set by it when wanted is empty. Statuses
+// the user set themselves are left untouched.
+func (a *App) applyCalendarStatus(rctx request.CTX, user *model.User, wanted string, until time.Time) *model.AppError {
+	applied, detail := "", ""
+	if pref, err := a.Srv().Store().Preference().Get(user.Id, model.PreferenceCategoryCalendar, model.PreferenceNameCalendarAppliedStatus); err == nil {
+		applied, detail, _ = strings.Cut(pref.Value, ":")
+	}
+
+	status, appErr := a.GetStatus(user.Id)
+	if appErr != nil {
+		status = &model.Status{UserId: user.Id, Status: model.StatusOffline}
+	}
+	setByUser := status.Manual && (status.Status == model.StatusDnd || status.Status == model.StatusOutOfOffice)
+
+	if applied == model.StatusOutOfOffice && wanted != model.StatusOutOfOffice {
+		if appErr := a.revertCalendarOutOfOffice(rctx, user, status, detail); appErr != nil {
+			return appErr
+		}
+		applied, setByUser = "", false
+	}
+
+	switch wanted {
+	case model.StatusOutOfOffice:
+		if applied == model.StatusOutOfOffice || (setByUser && applied == "") {
+			return nil
+		}
+
+		detail = ""
+		if user.NotifyProps[model.AutoResponderMessageNotifyProp] != "" && user.NotifyProps[model.AutoResponderActiveNotifyProp] != "true" {
+			oldNotifyProps := user.NotifyProps
+			patch := &model.UserPatch{NotifyProps: model.CopyStringMap(user.NotifyProps)}
+			patch.NotifyProps[model.AutoResponderActiveNotifyProp] = "true"
+			updatedUser, appErr := a.PatchUser(rctx, user.Id, patch, true)
+			if appErr != nil {
+				return appErr
+			}
+			a.SetAutoResponderStatus(rctx, updatedUser, oldNotifyProps)
+			detail = calendarAppliedAutoResponder
+		} else {
+			a.SetStatusOutOfOffice(user.Id)
+		}
+		return a.saveCalendarAppliedStatus(user.Id, model.StatusOutOfOffice, detail)
+
+	case model.StatusDnd:
+		if applied == model.StatusDnd {
+			if status.Status == model.StatusDnd {
+				if status.DNDEndTime < until.Unix() {
+					status.DNDEndTime = until.Unix()
+					a.SaveAndBroadcastStatus(status)
+				}
+				return a.saveCalendarAppliedStatus(user.Id, model.StatusDnd, strconv.FormatInt(status.DNDEndTime, 10))
+			}
+
+			// The user changed their status during the meeting.
+			if appliedUntil, _ := strconv.ParseInt(detail, 10, 64); appliedUntil > time.Now().Unix() {
+				return nil
+			}
+		} else if setByUser {
+			return nil
+		}
+
+		a.SetStatusDoNotDisturbTimed(user.Id, until.Unix())
+		return a.saveCalendarAppliedStatus(user.Id, model.StatusDnd, strconv.FormatInt(until.Unix(), 10))
+	}
+
+	if applied == model.StatusDnd && status.Status == model.StatusDnd && status.DNDEndTime > time.Now().Unix() {
+		// The meeting was cancelled or shortened.
+		status.Status = status.PrevStatus
+		status.PrevStatus = model.StatusDnd
+		status.DNDEndTime = 0
+		status.Manual = false
+		a.SaveAndBroadcastStatus(status)
+	}
+
+	if applied == "" {
+		return nil
+	}
+	if err := a.Srv().Store().Preference().Delete(user.Id, model.PreferenceCategoryCalendar, model.PreferenceNameCalendarAppliedStatus); err != nil {
+		return model.NewAppError("applyCalendarStatus", "app.preference.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (a *App) revertCalendarOutOfOffice(rctx request.CTX, user *model.User, status *model.Status, detail string) *model.AppError {
+	if detail == calendarAppliedAutoResponder {
+		if appErr := a.DisableAutoResponder(rctx, user.Id, true); appErr != nil {
+			return appErr
+		}
+	}
+
+	// The user may have come back early and changed their status already.
+	if status.Status == model.StatusOutOfOffice {
+		a.SetStatusOnline(user.Id, false)
+	}
+
+	if err := a.Srv().Store().Preference().Delete(user.Id, model.PreferenceCategoryCalendar, model.PreferenceNameCalendarAppliedStatus); err != nil {
+		return model.NewAppError("revertCalendarOutOfOffice", "app.preference.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
+
+func (a *App) saveCalendarAppliedStatus(userID, status, detail string) *model.AppError {
+	value := status
+	if detail != "" {
+		value += ":" + detail
+	}
+
+	if err := a.Srv().Store().Preference().Save(model.Preferences{{
+		UserId:   userID,
+		Category: model.PreferenceCategoryCalendar,
+		Name:     model.PreferenceNameCalendarAppliedStatus,
+		Value:    value,
+	}}); err != nil {
+		return model.NewAppError("saveCalendarAppliedStatus", "app.preference.save.updating.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return nil
+}
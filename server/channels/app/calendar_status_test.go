@@ -0,0 +1,154 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/platform/shared/ics"
+)
+
+func TestCalendarStatusAt(t *testing.T) {
+	events, err := ics.Parse(strings.NewReader(strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"BEGIN:VEVENT",
+		"SUMMARY:Standup",
+		"DTSTART:20240102T090000Z",
+		"DTEND:20240102T093000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Planning",
+		"DTSTART:20240102T093000Z",
+		"DTEND:20240102T100000Z",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Focus time",
+		"DTSTART:20240102T110000Z",
+		"DTEND:20240102T120000Z",
+		"TRANSP:TRANSPARENT",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Cancelled",
+		"DTSTART:20240102T130000Z",
+		"DTEND:20240102T140000Z",
+		"STATUS:CANCELLED",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Company holiday",
+		"DTSTART;VALUE=DATE:20240103",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:OOO - vacation",
+		"DTSTART;VALUE=DATE:20240104",
+		"DTEND;VALUE=DATE:20240106",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"SUMMARY:Meeting during vacation",
+		"DTSTART:20240104T090000Z",
+		"DTEND:20240104T100000Z",
+		"END:VEVENT",
+		"END:VCALENDAR",
+	}, "\r\n")), time.UTC)
+	require.NoError(t, err)
+
+	at := func(day, hour, minute int) time.Time {
+		return time.Date(2024, 1, day, hour, minute, 0, 0, time.UTC)
+	}
+
+	for name, tc := range map[string]struct {
+		now            time.Time
+		expectedStatus string
+		expectedUntil  time.Time
+	}{
+		"before any event":          {at(2, 8, 0), "", time.Time{}},
+		"back to back meetings":     {at(2, 9, 10), model.StatusDnd, at(2, 10, 0)},
+		"second meeting":            {at(2, 9, 45), model.StatusDnd, at(2, 10, 0)},
+		"transparent event":         {at(2, 11, 30), "", time.Time{}},
+		"cancelled event":           {at(2, 13, 30), "", time.Time{}},
+		"all day event":             {at(3, 12, 0), "", time.Time{}},
+		"out of office":             {at(4, 12, 0), model.StatusOutOfOffice, at(6, 0, 0)},
+		"meeting during vacation":   {at(4, 9, 30), model.StatusOutOfOffice, at(6, 0, 0)},
+		"after the last event ends": {at(6, 0, 0), "", time.Time{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			status, until := calendarStatusAt(events, tc.now)
+			assert.Equal(t, tc.expectedStatus, status)
+			assert.True(t, tc.expectedUntil.Equal(until), "expected %v, got %v", tc.expectedUntil, until)
+		})
+	}
+}
+
+func TestApplyCalendarStatus(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableCalendarStatusSync = true })
+
+	t.Run("do not disturb during meetings", func(t *testing.T) {
+		th.App.SetStatusOnline(th.BasicUser.Id, false)
+		until := time.Now().Add(time.Hour).Truncate(time.Second)
+
+		appErr := th.App.applyCalendarStatus(th.Context, th.BasicUser, model.StatusDnd, until)
+		require.Nil(t, appErr)
+		status, appErr := th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusDnd, status.Status)
+		assert.Equal(t, until.Unix(), status.DNDEndTime)
+
+		// Meetings which are extended move the end time.
+		appErr = th.App.applyCalendarStatus(th.Context, th.BasicUser, model.StatusDnd, until.Add(time.Hour))
+		require.Nil(t, appErr)
+		status, appErr = th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, until.Add(time.Hour).Unix(), status.DNDEndTime)
+
+		appErr = th.App.applyCalendarStatus(th.Context, th.BasicUser, "", time.Time{})
+		require.Nil(t, appErr)
+		status, appErr = th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusOnline, status.Status)
+	})
+
+	t.Run("status set by the user is kept", func(t *testing.T) {
+		th.App.SetStatusDoNotDisturb(th.BasicUser.Id)
+
+		appErr := th.App.applyCalendarStatus(th.Context, th.BasicUser, model.StatusOutOfOffice, time.Now().Add(time.Hour))
+		require.Nil(t, appErr)
+		status, appErr := th.App.GetStatus(th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusDnd, status.Status)
+	})
+
+	t.Run("out of office enables the auto responder", func(t *testing.T) {
+		th.App.SetStatusOnline(th.BasicUser2.Id, false)
+		patch := &model.UserPatch{NotifyProps: model.CopyStringMap(th.BasicUser2.NotifyProps)}
+		patch.NotifyProps[model.AutoResponderMessageNotifyProp] = "Away until Monday"
+		user, appErr := th.App.PatchUser(th.Context, th.BasicUser2.Id, patch, true)
+		require.Nil(t, appErr)
+
+		appErr = th.App.applyCalendarStatus(th.Context, user, model.StatusOutOfOffice, time.Now().Add(24*time.Hour))
+		require.Nil(t, appErr)
+		user, appErr = th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "true", user.NotifyProps[model.AutoResponderActiveNotifyProp])
+		status, appErr := th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusOutOfOffice, status.Status)
+
+		appErr = th.App.applyCalendarStatus(th.Context, user, "", time.Time{})
+		require.Nil(t, appErr)
+		user, appErr = th.App.GetUser(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, "false", user.NotifyProps[model.AutoResponderActiveNotifyProp])
+		status, appErr = th.App.GetStatus(user.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, model.StatusOnline, status.Status)
+	})
+}
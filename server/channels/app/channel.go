@@ -83,6 +83,15 @@ func (a *App) JoinDefaultChannels(c request.CTX, teamID string, user *model.User
 			continue
 		}
 
+		// Users are added to the default channels whose access policy they satisfy.
+		if appErr := a.checkChannelAccessPolicy(c, user, channel); appErr != nil {
+			if appErr.StatusCode != http.StatusForbidden {
+				return appErr
+			}
+			c.Logger().Debug("Not adding the user to the default channel denied by its access policy", mlog.String("user_id", user.Id), mlog.String("channel_id", channel.Id))
+			continue
+		}
+
 		cm := &model.ChannelMember{
 			ChannelId:   channel.Id,
 			UserId:      user.Id,
@@ -1559,6 +1568,10 @@ func (a *App) addUserToChannel(c request.CTX, user *model.User, channel *model.C
 		}
 	}
 
+	if appErr := a.checkChannelAccessPolicy(c, user, channel); appErr != nil {
+		return nil, appErr
+	}
+
 	newMember := &model.ChannelMember{
 		ChannelId:   channel.Id,
 		UserId:      user.Id,
@@ -3153,6 +3166,10 @@ func (a *App) PermanentDeleteChannel(c request.CTX, channel *model.Channel) *mod
 		return model.NewAppError("PermanentDeleteChannel", "app.post_persistent_notification.delete_by_channel.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().ChannelAccessPolicy().Delete(channel.Id); err != nil {
+		return model.NewAppError("PermanentDeleteChannel", "app.channel_access_policy.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	deleteAt := model.GetMillis()
 
 	if nErr := a.Srv().Store().Channel().PermanentDelete(c, channel.Id); nErr != nil {
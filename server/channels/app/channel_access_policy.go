@@ -0,0 +1,331 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+const channelAccessPoliciesPerPage = 100
+
+// GetChannelAccessPolicy returns the access policy of a channel.
+func (a *App) GetChannelAccessPolicy(channelID string) (*model.ChannelAccessPolicy, *model.AppError) {
+	policy, err := a.Srv().Store().ChannelAccessPolicy().Get(channelID)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, model.NewAppError("GetChannelAccessPolicy", "app.channel_access_policy.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		}
+		return nil, model.NewAppError("GetChannelAccessPolicy", "app.channel_access_policy.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return policy, nil
+}
+
+// getChannelForAccessPolicy returns the channel of an access policy, refusing the channels that
+// can't have one.
+func (a *App) getChannelForAccessPolicy(rctx request.CTX, channelID string) (*model.Channel, *model.AppError) {
+	channel, appErr := a.GetChannel(rctx, channelID)
+	if appErr != nil {
+		return nil, appErr
+	}
+	if channel.Type != model.ChannelTypeOpen && channel.Type != model.ChannelTypePrivate {
+		return nil, model.NewAppError("getChannelForAccessPolicy", "app.channel_access_policy.channel_type.app_error", nil, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+	// Everyone on the team is a member of the default channel.
+	if channel.Name == model.DefaultChannelName {
+		return nil, model.NewAppError("getChannelForAccessPolicy", "app.channel_access_policy.default_channel.app_error", map[string]any{"Channel": model.DefaultChannelName}, "channel_id="+channel.Id, http.StatusBadRequest)
+	}
+
+	return channel, nil
+}
+
+// SaveChannelAccessPolicy sets the access policy of a public or private channel, and removes the
+// members who don't satisfy it in the background.
+func (a *App) SaveChannelAccessPolicy(rctx request.CTX, policy *model.ChannelAccessPolicy) (*model.ChannelAccessPolicy, *model.AppError) {
+	if _, appErr := a.getChannelForAccessPolicy(rctx, policy.ChannelId); appErr != nil {
+		return nil, appErr
+	}
+
+	savedPolicy, err := a.Srv().Store().ChannelAccessPolicy().Save(policy)
+	if err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return nil, appErr
+		}
+		return nil, model.NewAppError("SaveChannelAccessPolicy", "app.channel_access_policy.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.Srv().Go(func() {
+		if appErr := a.enforceChannelAccessPolicy(rctx, savedPolicy); appErr != nil {
+			rctx.Logger().Warn("Failed to remove the channel members not satisfying its access policy", mlog.String("channel_id", savedPolicy.ChannelId), mlog.Err(appErr))
+		}
+	})
+
+	return savedPolicy, nil
+}
+
+// PreviewChannelAccessPolicy returns the members of the channel saving the access policy would
+// remove, without saving it or removing them.
+func (a *App) PreviewChannelAccessPolicy(rctx request.CTX, policy *model.ChannelAccessPolicy) ([]*model.MembershipChange, *model.AppError) {
+	channel, appErr := a.getChannelForAccessPolicy(rctx, policy.ChannelId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	policy.PreSave()
+	if appErr := policy.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	userIDs, appErr := a.channelAccessPolicyMembersToRemove(rctx, channel, policy)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	changes := make([]*model.MembershipChange, 0, len(userIDs))
+	for _, userID := range userIDs {
+		changes = append(changes, &model.MembershipChange{UserId: userID, Type: model.MembershipChangeTypeChannel, TargetId: channel.Id, Action: model.MembershipChangeActionRemove})
+	}
+
+	return changes, nil
+}
+
+// DeleteChannelAccessPolicy removes the access policy of a channel.
+func (a *App) DeleteChannelAccessPolicy(channelID string) *model.AppError {
+	if _, appErr := a.GetChannelAccessPolicy(channelID); appErr != nil {
+		return appErr
+	}
+
+	if err := a.Srv().Store().ChannelAccessPolicy().Delete(channelID); err != nil {
+		return model.NewAppError("DeleteChannelAccessPolicy", "app.channel_access_policy.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// getAllChannelAccessPolicies returns the access policies of the channels which aren't archived.
+func (a *App) getAllChannelAccessPolicies() ([]*model.ChannelAccessPolicy, *model.AppError) {
+	var policies []*model.ChannelAccessPolicy
+	for offset := 0; ; offset += channelAccessPoliciesPerPage {
+		page, err := a.Srv().Store().ChannelAccessPolicy().GetAll(offset, channelAccessPoliciesPerPage)
+		if err != nil {
+			return nil, model.NewAppError("getAllChannelAccessPolicies", "app.channel_access_policy.get_all.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		policies = append(policies, page...)
+		if len(page) < channelAccessPoliciesPerPage {
+			return policies, nil
+		}
+	}
+}
+
+// channelAccessPolicyAttributeNames returns the names of the SAML or OpenID Connect attributes the
+// channel access policies refer to.
+func (a *App) channelAccessPolicyAttributeNames() ([]string, *model.AppError) {
+	policies, appErr := a.getAllChannelAccessPolicies()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var names []string
+	for _, policy := range policies {
+		expression, err := model.ParseAccessPolicyExpression(policy.Expression)
+		if err != nil {
+			continue
+		}
+		for _, name := range expression.AttributeNames() {
+			if !slices.Contains(names, name) {
+				names = append(names, name)
+			}
+		}
+	}
+
+	return names, nil
+}
+
+// userAttributesMayBeUncaptured returns whether the user logs in with a service the SAML or
+// OpenID Connect attributes of users are captured from, and so whether missing attributes may
+// only mean that the user didn't log in since they are captured.
+func userAttributesMayBeUncaptured(user *model.User) bool {
+	return user.IsSAMLUser() || user.AuthService == model.ServiceOpenid
+}
+
+// evaluateChannelAccessPolicy returns whether the user may be a member of a channel with the
+// access policy of the given expression. SAML and OpenID Connect users whose attributes were
+// never captured are allowed, and reported as such, unless the expression is decided without
+// their attributes. Bots aren't subject to access policies.
+func (a *App) evaluateChannelAccessPolicy(expression *model.AccessPolicyExpression, user *model.User) (allowed bool, uncaptured bool, appErr *model.AppError) {
+	if user.IsBot {
+		return true, false, nil
+	}
+
+	attributes, err := a.Srv().Store().AttributeGroup().GetUserAttributes(user.Id)
+	if err != nil {
+		return false, false, model.NewAppError("evaluateChannelAccessPolicy", "app.attribute_group.get_user_attributes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if len(attributes) == 0 && len(expression.AttributeNames()) > 0 && userAttributesMayBeUncaptured(user) {
+		if satisfied, decided := expression.EvaluateWithoutAttributes(user); decided {
+			return satisfied, false, nil
+		}
+		return true, true, nil
+	}
+
+	return expression.Evaluate(user, attributes), false, nil
+}
+
+// auditUncapturedChannelAccessPolicy records that the access policy of the channel was skipped
+// for a user whose attributes were never captured.
+func (a *App) auditUncapturedChannelAccessPolicy(rctx request.CTX, channelID, userID string, policy *model.ChannelAccessPolicy) {
+	auditRec := a.MakeAuditRecord(rctx, "skipChannelAccessPolicy", audit.Success)
+	auditRec.AddMeta("channel_id", channelID)
+	auditRec.AddMeta("user_id", userID)
+	auditRec.AddMeta("expression", policy.Expression)
+	auditRec.AddMeta("reason", "attributes_not_captured")
+	a.LogAuditRec(rctx, auditRec, nil)
+}
+
+// checkChannelAccessPolicy refuses to add the user to a channel whose access policy the user
+// doesn't satisfy. Users are allowed or refused like the enforcement of the policy keeps or
+// removes members.
+func (a *App) checkChannelAccessPolicy(rctx request.CTX, user *model.User, channel *model.Channel) *model.AppError {
+	policy, err := a.Srv().Store().ChannelAccessPolicy().Get(channel.Id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil
+		}
+		return model.NewAppError("checkChannelAccessPolicy", "app.channel_access_policy.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	denied := model.NewAppError("checkChannelAccessPolicy", "api.channel.access_policy.denied.app_error", map[string]any{"Username": user.Username}, "user_id="+user.Id+", channel_id="+channel.Id, http.StatusForbidden)
+
+	// Policies failing to parse allow no one.
+	expression, err := model.ParseAccessPolicyExpression(policy.Expression)
+	if err != nil {
+		return denied.Wrap(err)
+	}
+
+	allowed, uncaptured, appErr := a.evaluateChannelAccessPolicy(expression, user)
+	if appErr != nil {
+		return appErr
+	}
+	if uncaptured {
+		a.auditUncapturedChannelAccessPolicy(rctx, channel.Id, user.Id, policy)
+	}
+	if !allowed {
+		return denied
+	}
+
+	return nil
+}
+
+// EnforceChannelAccessPolicies removes from the channels having an access policy the members who
+// don't satisfy it. SAML and OpenID Connect members whose attributes were never captured are
+// kept until they log in again, unless the policy is decided without their attributes.
+func (a *App) EnforceChannelAccessPolicies(rctx request.CTX) *model.AppError {
+	policies, appErr := a.getAllChannelAccessPolicies()
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, policy := range policies {
+		if appErr := a.enforceChannelAccessPolicy(rctx, policy); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
+
+func (a *App) enforceChannelAccessPolicy(rctx request.CTX, policy *model.ChannelAccessPolicy) *model.AppError {
+	channel, appErr := a.GetChannel(rctx, policy.ChannelId)
+	if appErr != nil {
+		return appErr
+	}
+
+	toRemove, appErr := a.channelAccessPolicyMembersToRemove(rctx, channel, policy)
+	if appErr != nil {
+		return appErr
+	}
+
+	for _, userID := range toRemove {
+		auditRec := a.MakeAuditRecord(rctx, "removeChannelMemberByAccessPolicy", audit.Fail)
+		auditRec.AddMeta("channel_id", channel.Id)
+		auditRec.AddMeta("user_id", userID)
+		auditRec.AddMeta("expression", policy.Expression)
+
+		if appErr := a.RemoveUserFromChannel(rctx, userID, "", channel); appErr != nil {
+			a.LogAuditRec(rctx, auditRec, appErr)
+			rctx.Logger().Warn("Failed to remove the channel member not satisfying its access policy", mlog.String("channel_id", channel.Id), mlog.String("user_id", userID), mlog.Err(appErr))
+			continue
+		}
+
+		auditRec.Success()
+		a.LogAuditRec(rctx, auditRec, nil)
+		rctx.Logger().Info("Removed the channel member not satisfying its access policy", mlog.String("channel_id", channel.Id), mlog.String("user_id", userID))
+	}
+
+	return nil
+}
+
+// channelAccessPolicyMembersToRemove returns the members of the channel who don't satisfy the
+// access policy. SAML and OpenID Connect members whose attributes were never captured are kept,
+// since they can't be told apart from members not satisfying it, unless the policy is decided
+// without their attributes. The members kept this way are recorded in the audit log.
+func (a *App) channelAccessPolicyMembersToRemove(rctx request.CTX, channel *model.Channel, policy *model.ChannelAccessPolicy) ([]string, *model.AppError) {
+	expression, err := model.ParseAccessPolicyExpression(policy.Expression)
+	if err != nil {
+		return nil, model.NewAppError("channelAccessPolicyMembersToRemove", "model.channel_access_policy.expression.app_error", map[string]any{"Error": err.Error()}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	// Members are removed once all of them were checked, not to skip any while paging.
+	var toRemove []string
+	for page := 0; ; page++ {
+		members, appErr := a.GetChannelMembersPage(rctx, channel.Id, page, channelAccessPoliciesPerPage)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if len(members) == 0 {
+			break
+		}
+
+		userIDs := make([]string, 0, len(members))
+		for _, member := range members {
+			userIDs = append(userIDs, member.UserId)
+		}
+		// Profiles are read from the store not to have their email addresses sanitized.
+		users, err := a.Srv().Store().User().GetProfileByIds(rctx.Context(), userIDs, &store.UserGetByIdsOpts{}, false)
+		if err != nil {
+			return nil, model.NewAppError("channelAccessPolicyMembersToRemove", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, user := range users {
+			allowed, uncaptured, appErr := a.evaluateChannelAccessPolicy(expression, user)
+			if appErr != nil {
+				return nil, appErr
+			}
+			if uncaptured {
+				a.auditUncapturedChannelAccessPolicy(rctx, channel.Id, user.Id, policy)
+			}
+			if !allowed {
+				toRemove = append(toRemove, user.Id)
+			}
+		}
+
+		if len(members) < channelAccessPoliciesPerPage {
+			break
+		}
+	}
+
+	return toRemove, nil
+}
@@ -0,0 +1,225 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSaveChannelAccessPolicy(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	expression := `user.attributes.department == "Engineering"`
+
+	t.Run("direct messages can't have an access policy", func(t *testing.T) {
+		channel := th.CreateDmChannel(th.BasicUser2)
+		_, appErr := th.App.SaveChannelAccessPolicy(th.Context, &model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: expression})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel_access_policy.channel_type.app_error", appErr.Id)
+	})
+
+	t.Run("the default channel can't have an access policy", func(t *testing.T) {
+		channel, appErr := th.App.GetChannelByName(th.Context, model.DefaultChannelName, th.BasicTeam.Id, false)
+		require.Nil(t, appErr)
+		_, appErr = th.App.SaveChannelAccessPolicy(th.Context, &model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: expression})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.channel_access_policy.default_channel.app_error", appErr.Id)
+	})
+
+	t.Run("invalid expressions are refused", func(t *testing.T) {
+		_, appErr := th.App.SaveChannelAccessPolicy(th.Context, &model.ChannelAccessPolicy{ChannelId: th.BasicChannel.Id, Expression: `user.department == "Engineering"`})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "model.channel_access_policy.expression.app_error", appErr.Id)
+	})
+
+	t.Run("policies are saved and deleted", func(t *testing.T) {
+		channel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+		policy, appErr := th.App.SaveChannelAccessPolicy(th.Context, &model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: expression})
+		require.Nil(t, appErr)
+		assert.Equal(t, expression, policy.Expression)
+
+		policy, appErr = th.App.GetChannelAccessPolicy(channel.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, expression, policy.Expression)
+
+		require.Nil(t, th.App.DeleteChannelAccessPolicy(channel.Id))
+		_, appErr = th.App.GetChannelAccessPolicy(channel.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func TestAddUserToChannelWithAccessPolicy(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+	_, err := th.App.Srv().Store().ChannelAccessPolicy().Save(&model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: `user.attributes.department == "Engineering"`})
+	require.NoError(t, err)
+
+	t.Run("users not satisfying the policy can't be added", func(t *testing.T) {
+		_, appErr := th.App.AddUserToChannel(th.Context, th.BasicUser2, channel, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.access_policy.denied.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("users satisfying the policy are added", func(t *testing.T) {
+		require.NoError(t, th.App.Srv().Store().AttributeGroup().SetUserAttributes(th.BasicUser2.Id, map[string][]string{"department": {"Engineering"}}))
+		_, appErr := th.App.AddUserToChannel(th.Context, th.BasicUser2, channel, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("users are added like the enforcement of the policy keeps members", func(t *testing.T) {
+		withoutAttributes := th.CreateUser()
+		th.LinkUserToTeam(withoutAttributes, th.BasicTeam)
+		_, appErr := th.App.AddUserToChannel(th.Context, withoutAttributes, channel, false)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.channel.access_policy.denied.app_error", appErr.Id)
+
+		uncaptured := createSamlUser(t, th, "Engineer")
+		th.LinkUserToTeam(uncaptured, th.BasicTeam)
+		_, appErr = th.App.AddUserToChannel(th.Context, uncaptured, channel, false)
+		require.Nil(t, appErr)
+	})
+
+	t.Run("bots aren't subject to the policy", func(t *testing.T) {
+		bot := th.CreateBot()
+		botUser, appErr := th.App.GetUser(bot.UserId)
+		require.Nil(t, appErr)
+		_, appErr = th.App.AddUserToChannel(th.Context, botUser, channel, true)
+		require.Nil(t, appErr)
+	})
+}
+
+// createSamlUser creates a user logging in with SAML, whose attributes are captured at login.
+func createSamlUser(t *testing.T, th *TestHelper, position string) *model.User {
+	t.Helper()
+
+	user := th.CreateUser()
+	user.Position = position
+	_, err := th.App.Srv().Store().User().Update(th.Context, user, true)
+	require.NoError(t, err)
+	_, err = th.App.Srv().Store().User().UpdateAuthData(user.Id, model.UserAuthServiceSaml, model.NewPointer(model.NewId()), user.Email, false)
+	require.NoError(t, err)
+
+	user, appErr := th.App.GetUser(user.Id)
+	require.Nil(t, appErr)
+	return user
+}
+
+func TestEnforceChannelAccessPolicies(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+	allowed := th.CreateUser()
+	denied := th.CreateUser()
+	withoutAttributes := th.CreateUser()
+	uncaptured := createSamlUser(t, th, "Engineer")
+	uncapturedContractor := createSamlUser(t, th, "Contractor")
+	for _, user := range []*model.User{allowed, denied, withoutAttributes, uncaptured, uncapturedContractor} {
+		th.LinkUserToTeam(user, th.BasicTeam)
+		th.AddUserToChannel(user, channel)
+	}
+	require.NoError(t, th.App.Srv().Store().AttributeGroup().SetUserAttributes(allowed.Id, map[string][]string{"department": {"Engineering"}}))
+	require.NoError(t, th.App.Srv().Store().AttributeGroup().SetUserAttributes(denied.Id, map[string][]string{"department": {"Sales"}}))
+
+	policy := &model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: `user.position != "Contractor" && user.attributes.department == "Engineering"`}
+
+	t.Run("the preview doesn't remove members", func(t *testing.T) {
+		changes, appErr := th.App.PreviewChannelAccessPolicy(th.Context, policy)
+		require.Nil(t, appErr)
+		var removed []string
+		for _, change := range changes {
+			assert.Equal(t, model.MembershipChangeActionRemove, change.Action)
+			removed = append(removed, change.UserId)
+		}
+		assert.ElementsMatch(t, []string{denied.Id, withoutAttributes.Id, uncapturedContractor.Id}, removed)
+
+		_, appErr = th.App.GetChannelAccessPolicy(channel.Id)
+		require.NotNil(t, appErr)
+		_, appErr = th.App.GetChannelMember(th.Context, channel.Id, denied.Id)
+		require.Nil(t, appErr)
+	})
+
+	// The policy is saved through the store, not to be enforced in the background.
+	_, err := th.App.Srv().Store().ChannelAccessPolicy().Save(policy)
+	require.NoError(t, err)
+
+	require.Nil(t, th.App.EnforceChannelAccessPolicies(th.Context))
+
+	_, appErr := th.App.GetChannelMember(th.Context, channel.Id, allowed.Id)
+	require.Nil(t, appErr)
+
+	// Only SAML and OpenID Connect members whose attributes were never captured can't be told
+	// apart from those not satisfying the policy, unless a clause not about attributes fails.
+	_, appErr = th.App.GetChannelMember(th.Context, channel.Id, uncaptured.Id)
+	require.Nil(t, appErr)
+
+	for _, user := range []*model.User{denied, withoutAttributes, uncapturedContractor} {
+		_, appErr = th.App.GetChannelMember(th.Context, channel.Id, user.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	}
+}
+
+func TestJoinDefaultChannelsWithAccessPolicy(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	offTopic, appErr := th.App.GetChannelByName(th.Context, "off-topic", th.BasicTeam.Id, false)
+	require.Nil(t, appErr)
+	_, err := th.App.Srv().Store().ChannelAccessPolicy().Save(&model.ChannelAccessPolicy{ChannelId: offTopic.Id, Expression: `user.attributes.department == "Engineering"`})
+	require.NoError(t, err)
+
+	denied := th.CreateUser()
+	allowed := th.CreateUser()
+	require.NoError(t, th.App.Srv().Store().AttributeGroup().SetUserAttributes(allowed.Id, map[string][]string{"department": {"Engineering"}}))
+
+	for _, user := range []*model.User{denied, allowed} {
+		_, _, appErr = th.App.AddUserToTeam(th.Context, th.BasicTeam.Id, user.Id, "")
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetChannelMember(th.Context, offTopic.Id, user.Id)
+		if user == denied {
+			require.NotNil(t, appErr)
+			assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+		} else {
+			require.Nil(t, appErr)
+		}
+
+		// The default channel can't have an access policy, so everyone joins it.
+		townSquare, appErr := th.App.GetChannelByName(th.Context, model.DefaultChannelName, th.BasicTeam.Id, false)
+		require.Nil(t, appErr)
+		_, appErr = th.App.GetChannelMember(th.Context, townSquare.Id, user.Id)
+		require.Nil(t, appErr)
+	}
+}
+
+func TestSyncUserAttributeGroupsKeepsAccessPolicyAttributes(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	channel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+	_, err := th.App.Srv().Store().ChannelAccessPolicy().Save(&model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: `user.attributes.clearance in ["secret", "top-secret"]`})
+	require.NoError(t, err)
+
+	appErr := th.App.SyncUserAttributeGroups(th.Context, th.BasicUser.Id, map[string][]string{
+		"clearance": {"secret"},
+		"salary":    {"secret"},
+	})
+	require.Nil(t, appErr)
+
+	attributes, err := th.App.Srv().Store().AttributeGroup().GetUserAttributes(th.BasicUser.Id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{"clearance": {"secret"}}, attributes)
+}
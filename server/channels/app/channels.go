@@ -17,6 +17,8 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/app/imaging"
 	"github.com/mattermost/mattermost/server/v8/config"
 	"github.com/mattermost/mattermost/server/v8/einterfaces"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+	"github.com/mattermost/mattermost/server/v8/platform/services/geoip"
 	"github.com/mattermost/mattermost/server/v8/platform/services/imageproxy"
 	"github.com/mattermost/mattermost/server/v8/platform/shared/filestore"
 )
@@ -44,6 +46,8 @@ type Channels struct {
 	pluginsEnvironment            *plugin.Environment
 	pluginConfigListenerID        string
 	pluginClusterLeaderListenerID string
+	pluginHotReloader             *pluginHotReloader
+	marketplaceCatalogLock        sync.Mutex
 
 	imageProxy *imageproxy.ImageProxy
 
@@ -80,6 +84,10 @@ type Channels struct {
 	scheduledPostMut  sync.Mutex
 	scheduledPostTask *model.ScheduledTask
 	loginAttemptsMut  sync.Mutex
+
+	// sessionActivityCache throttles the recording of the activity of sessions.
+	sessionActivityCache cache.Cache
+	geoIPLoader          geoip.Loader
 }
 
 func NewChannels(s *Server) (*Channels, error) {
@@ -92,6 +100,16 @@ func NewChannels(s *Server) (*Channels, error) {
 		cfgSvc:          s.Platform(),
 	}
 
+	// The cache is shared between the nodes of a cluster when the cache is backed by Redis, so
+	// that every node doesn't record the same activity.
+	var err error
+	if ch.sessionActivityCache, err = s.platform.CacheProvider().NewCache(&cache.CacheOptions{
+		Name: "session_activity",
+		Size: sessionActivityCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "unable to create session activity cache")
+	}
+
 	// We are passing a partially filled Channels struct so that the enterprise
 	// methods can have access to app methods.
 	// Otherwise, passing server would mean it has to call s.Channels(),
@@ -225,6 +243,14 @@ func (ch *Channels) RunMultiHook(hookRunnerFunc func(hooks plugin.Hooks, manifes
 	}
 }
 
+// IsHookImplemented returns whether any active plugin implements the given hookId.
+func (ch *Channels) IsHookImplemented(hookId int) bool {
+	if env := ch.GetPluginsEnvironment(); env != nil {
+		return env.IsHookImplemented(hookId)
+	}
+	return false
+}
+
 func (ch *Channels) HooksForPlugin(id string) (plugin.Hooks, error) {
 	env := ch.GetPluginsEnvironment()
 	if env == nil {
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -334,6 +335,70 @@ func (es *Service) SendUserAccessTokenAddedEmail(email, locale, siteURL string)
 	return nil
 }
 
+func (es *Service) SendUserAccessTokenExpiringEmail(email, locale, siteURL, description string, expiresAt int64) error {
+	T := i18n.GetUserTranslations(locale)
+
+	subject := T("api.templates.user_access_token_expiring_subject",
+		map[string]any{"SiteName": es.config().TeamSettings.SiteName})
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.user_access_token_expiring_body.title")
+	data.Props["Info"] = T("api.templates.user_access_token_expiring_body.info",
+		map[string]any{
+			"Description": description,
+			"ExpiresAt":   time.UnixMilli(expiresAt).UTC().Format(time.RFC1123),
+			"SiteName":    es.config().TeamSettings.SiteName,
+		})
+	data.Props["Warning"] = T("api.templates.email_warning")
+
+	body, err := es.templatesContainer.RenderToString("password_change_body", data)
+	if err != nil {
+		return err
+	}
+
+	if err := es.sendMail(email, subject, body, "UserAccessTokenExpiringEmail"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (es *Service) SendNewSignInEmail(email, locale, siteURL, device, ipAddress, region string, signedInAt int64) error {
+	T := i18n.GetUserTranslations(locale)
+
+	subject := T("api.templates.new_sign_in_subject",
+		map[string]any{"SiteName": es.config().TeamSettings.SiteName})
+
+	location := ipAddress
+	if region != "" {
+		location = fmt.Sprintf("%s (%s)", ipAddress, region)
+	}
+
+	data := es.NewEmailTemplateData(locale)
+	data.Props["SiteURL"] = siteURL
+	data.Props["Title"] = T("api.templates.new_sign_in_body.title")
+	data.Props["Info"] = T("api.templates.new_sign_in_body.info",
+		map[string]any{
+			"Device":     device,
+			"Location":   location,
+			"SignedInAt": time.UnixMilli(signedInAt).UTC().Format(time.RFC1123),
+			"SiteName":   es.config().TeamSettings.SiteName,
+		})
+	data.Props["Warning"] = T("api.templates.email_warning")
+
+	body, err := es.templatesContainer.RenderToString("password_change_body", data)
+	if err != nil {
+		return err
+	}
+
+	if err := es.sendMail(email, subject, body, "NewSignInEmail"); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (es *Service) SendPasswordResetEmail(email string, token *model.Token, locale, siteURL string) (bool, error) {
 	T := i18n.GetUserTranslations(locale)
 
@@ -392,6 +392,24 @@ func (_m *ServiceInterface) SendMfaChangeEmail(_a0 string, activated bool, local
 	return r0
 }
 
+// SendNewSignInEmail provides a mock function with given fields: _a0, locale, siteURL, device, ipAddress, region, signedInAt
+func (_m *ServiceInterface) SendNewSignInEmail(_a0 string, locale string, siteURL string, device string, ipAddress string, region string, signedInAt int64) error {
+	ret := _m.Called(_a0, locale, siteURL, device, ipAddress, region, signedInAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendNewSignInEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string, string, string, int64) error); ok {
+		r0 = rf(_a0, locale, siteURL, device, ipAddress, region, signedInAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendNotificationMail provides a mock function with given fields: to, subject, htmlBody
 func (_m *ServiceInterface) SendNotificationMail(to string, subject string, htmlBody string) error {
 	ret := _m.Called(to, subject, htmlBody)
@@ -510,6 +528,24 @@ func (_m *ServiceInterface) SendUserAccessTokenAddedEmail(_a0 string, locale str
 	return r0
 }
 
+// SendUserAccessTokenExpiringEmail provides a mock function with given fields: _a0, locale, siteURL, description, expiresAt
+func (_m *ServiceInterface) SendUserAccessTokenExpiringEmail(_a0 string, locale string, siteURL string, description string, expiresAt int64) error {
+	ret := _m.Called(_a0, locale, siteURL, description, expiresAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SendUserAccessTokenExpiringEmail")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string, string, int64) error); ok {
+		r0 = rf(_a0, locale, siteURL, description, expiresAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // SendVerifyEmail provides a mock function with given fields: userEmail, locale, siteURL, token, redirect
 func (_m *ServiceInterface) SendVerifyEmail(userEmail string, locale string, siteURL string, token string, redirect string) error {
 	ret := _m.Called(userEmail, locale, siteURL, token, redirect)
@@ -0,0 +1,349 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func (a *App) CreateEscalationPolicy(policy *model.EscalationPolicy) (*model.EscalationPolicy, *model.AppError) {
+	if appErr := a.checkEscalationPolicySteps(policy); appErr != nil {
+		return nil, appErr
+	}
+
+	policy.Id = ""
+	saved, err := a.Srv().Store().EscalationPolicy().Save(policy)
+	if err != nil {
+		var appErr *model.AppError
+		var cErr *store.ErrConflict
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &cErr):
+			return nil, model.NewAppError("CreateEscalationPolicy", "app.escalation_policy.save.exists.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		default:
+			return nil, model.NewAppError("CreateEscalationPolicy", "app.escalation_policy.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return saved, nil
+}
+
+func (a *App) GetEscalationPolicy(id string) (*model.EscalationPolicy, *model.AppError) {
+	policy, err := a.Srv().Store().EscalationPolicy().Get(id)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("GetEscalationPolicy", "app.escalation_policy.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("GetEscalationPolicy", "app.escalation_policy.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return policy, nil
+}
+
+// GetEscalationPoliciesForChannel returns the policies configured for the
+// given channel, or the server wide policies when channelID is empty.
+func (a *App) GetEscalationPoliciesForChannel(channelID string) ([]*model.EscalationPolicy, *model.AppError) {
+	policies, err := a.Srv().Store().EscalationPolicy().GetForChannels([]string{channelID})
+	if err != nil {
+		return nil, model.NewAppError("GetEscalationPoliciesForChannel", "app.escalation_policy.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return policies, nil
+}
+
+// UpdateEscalationPolicy replaces the steps and quiet hours of a policy.
+// The channel and priority a policy applies to can't be changed.
+func (a *App) UpdateEscalationPolicy(policy *model.EscalationPolicy) (*model.EscalationPolicy, *model.AppError) {
+	oldPolicy, appErr := a.GetEscalationPolicy(policy.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.checkEscalationPolicySteps(policy); appErr != nil {
+		return nil, appErr
+	}
+
+	oldPolicy.Steps = policy.Steps
+	oldPolicy.QuietHours = policy.QuietHours
+
+	updated, err := a.Srv().Store().EscalationPolicy().Update(oldPolicy)
+	if err != nil {
+		var appErr *model.AppError
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &appErr):
+			return nil, appErr
+		case errors.As(err, &nfErr):
+			return nil, model.NewAppError("UpdateEscalationPolicy", "app.escalation_policy.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return nil, model.NewAppError("UpdateEscalationPolicy", "app.escalation_policy.update.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return updated, nil
+}
+
+func (a *App) DeleteEscalationPolicy(id string) *model.AppError {
+	if err := a.Srv().Store().EscalationPolicy().Delete(id); err != nil {
+		var nfErr *store.ErrNotFound
+		switch {
+		case errors.As(err, &nfErr):
+			return model.NewAppError("DeleteEscalationPolicy", "app.escalation_policy.get.not_found.app_error", nil, "", http.StatusNotFound).Wrap(err)
+		default:
+			return model.NewAppError("DeleteEscalationPolicy", "app.escalation_policy.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return nil
+}
+
+// checkEscalationPolicySteps rejects missing steps, and steps that could never
+// run because persistent notifications stop before reaching their count.
+func (a *App) checkEscalationPolicySteps(policy *model.EscalationPolicy) *model.AppError {
+	maxCount := *a.Config().ServiceSettings.PersistentNotificationMaxCount
+	for _, step := range policy.Steps {
+		if step == nil {
+			return model.NewAppError("checkEscalationPolicySteps", "model.escalation_policy.is_valid.step_targets.app_error", nil, "", http.StatusBadRequest)
+		}
+		if int(step.AfterCount) > maxCount {
+			return model.NewAppError("checkEscalationPolicySteps", "app.escalation_policy.after_count.app_error", map[string]any{"MaxCount": maxCount}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// resolveEscalationPolicy returns the policy that applies to the post, the
+// most specific one first: the channel and priority, then the channel, then
+// the server wide ones.
+func resolveEscalationPolicy(policies []*model.EscalationPolicy, channelID, priority string) *model.EscalationPolicy {
+	for _, key := range [][2]string{{channelID, priority}, {channelID, ""}, {"", priority}, {"", ""}} {
+		for _, policy := range policies {
+			if policy.ChannelId == key[0] && policy.Priority == key[1] {
+				return policy
+			}
+		}
+	}
+
+	return nil
+}
+
+// escalatePersistentNotifications runs the escalation steps which are due for
+// the given notifications, counting the send which just happened when sent is
+// true. Only posts in team channels are escalated, so that direct and group
+// messages aren't shared with others. It returns the ids of the posts whose
+// due steps were deferred by quiet hours.
+func (a *App) escalatePersistentNotifications(rctx request.CTX, notifications []*model.PostPersistentNotifications, posts []*model.Post, sent bool) ([]string, error) {
+	channelsMap, teamsMap, err := a.channelTeamMapsForPosts(posts)
+	if err != nil {
+		return nil, err
+	}
+
+	channelIDs := []string{""}
+	for id, channel := range channelsMap {
+		if !channel.IsGroupOrDirect() {
+			channelIDs = append(channelIDs, id)
+		}
+	}
+
+	policies, err := a.Srv().Store().EscalationPolicy().GetForChannels(channelIDs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get escalation policies")
+	}
+	if len(policies) == 0 {
+		return nil, nil
+	}
+
+	postsMap := make(map[string]*model.Post, len(posts))
+	for _, post := range posts {
+		postsMap[post.Id] = post
+	}
+
+	now := time.Now()
+	var systemBot *model.Bot
+	var deferredPostIDs []string
+	for _, notification := range notifications {
+		post := postsMap[notification.PostId]
+		if post == nil {
+			continue
+		}
+		channel := channelsMap[post.ChannelId]
+		if channel == nil || channel.IsGroupOrDirect() {
+			continue
+		}
+
+		var priority string
+		if p := post.GetPriority(); p != nil && p.Priority != nil {
+			priority = *p.Priority
+		}
+
+		policy := resolveEscalationPolicy(policies, channel.Id, priority)
+		if policy == nil {
+			continue
+		}
+
+		sentCount := notification.SentCount
+		if sent {
+			sentCount++
+		}
+		steps := policy.StepsDue(notification.EscalationLevel, sentCount)
+		if len(steps) == 0 {
+			continue
+		}
+
+		// Steps due during quiet hours run once they're over.
+		if policy.QuietHours != nil && policy.QuietHours.Contains(now) {
+			deferredPostIDs = append(deferredPostIDs, post.Id)
+			continue
+		}
+
+		if systemBot == nil {
+			bot, appErr := a.GetSystemBot(rctx)
+			if appErr != nil {
+				return nil, errors.Wrap(appErr, "failed to get system bot")
+			}
+			systemBot = bot
+		}
+
+		for i, step := range steps {
+			level := int(notification.EscalationLevel) + i + 1
+			if err := a.runEscalationStep(rctx, policy, step, level, post, channel, teamsMap[channel.TeamId], systemBot); err != nil {
+				rctx.Logger().Warn("Failed to run escalation step", mlog.String("post_id", post.Id), mlog.String("policy_id", policy.Id), mlog.Int("step", level), mlog.Err(err))
+			}
+		}
+
+		if err := a.Srv().Store().PostPersistentNotification().UpdateEscalationLevel(post.Id, notification.EscalationLevel+int16(len(steps))); err != nil {
+			return nil, errors.Wrapf(err, "failed to update escalation level for post %s", post.Id)
+		}
+	}
+
+	return deferredPostIDs, nil
+}
+
+// runEscalationStep pages the users and channel of an escalation step, and
+// records who was paged in the audit log.
+func (a *App) runEscalationStep(rctx request.CTX, policy *model.EscalationPolicy, step *model.EscalationStep, level int, post *model.Post, channel *model.Channel, team *model.Team, systemBot *model.Bot) error {
+	auditRec := a.MakeAuditRecord(rctx, "escalatePersistentNotification", audit.Fail)
+	defer a.LogAuditRec(rctx, auditRec, nil)
+	auditRec.AddMeta("post_id", post.Id)
+	auditRec.AddMeta("channel_id", channel.Id)
+	auditRec.AddMeta("policy_id", policy.Id)
+	auditRec.AddMeta("step", level)
+
+	users, err := a.escalationStepUsers(step, post.UserId)
+	if err != nil {
+		return err
+	}
+
+	permalink := a.GetSiteURL() + "/" + team.Name + "/pl/" + post.Id
+	pagedUserIDs := make([]string, 0, len(users))
+	for _, user := range users {
+		dm, appErr := a.GetOrCreateDirectChannel(rctx, systemBot.UserId, user.Id)
+		if appErr != nil {
+			rctx.Logger().Warn("Failed to get direct channel for escalation", mlog.String("user_id", user.Id), mlog.Err(appErr))
+			continue
+		}
+
+		// Users who can't read the channel of the post are paged without
+		// revealing the channel or linking to the post.
+		T := i18n.GetUserTranslations(user.Locale)
+		message := T("app.escalation_policy.page_user.generic_message")
+		if a.HasPermissionToReadChannel(rctx, user.Id, channel) {
+			message = T("app.escalation_policy.page_user.message", map[string]any{"ChannelName": channel.DisplayName, "Link": permalink})
+		}
+		dmPost := &model.Post{
+			ChannelId: dm.Id,
+			UserId:    systemBot.UserId,
+			Message:   message,
+		}
+		if _, appErr := a.CreatePost(rctx, dmPost, dm, model.CreatePostFlags{SetOnline: false}); appErr != nil {
+			rctx.Logger().Warn("Failed to page user for escalation", mlog.String("user_id", user.Id), mlog.Err(appErr))
+			continue
+		}
+		pagedUserIDs = append(pagedUserIDs, user.Id)
+	}
+	auditRec.AddMeta("paged_user_ids", pagedUserIDs)
+
+	if step.ChannelId != "" {
+		stepChannel, appErr := a.GetChannel(rctx, step.ChannelId)
+		if appErr != nil {
+			return appErr
+		}
+
+		message := i18n.T("app.escalation_policy.page_channel.generic_message")
+		if escalationChannelVisibleFrom(channel, stepChannel) {
+			message = i18n.T("app.escalation_policy.page_channel.message", map[string]any{"ChannelName": channel.DisplayName, "Link": permalink})
+		}
+		channelPost := &model.Post{
+			ChannelId: stepChannel.Id,
+			UserId:    systemBot.UserId,
+			Message:   message,
+		}
+		if _, appErr := a.CreatePost(rctx, channelPost, stepChannel, model.CreatePostFlags{SetOnline: false}); appErr != nil {
+			return appErr
+		}
+		auditRec.AddMeta("paged_channel_id", stepChannel.Id)
+	}
+
+	auditRec.Success()
+	return nil
+}
+
+// escalationChannelVisibleFrom reports whether the members of the paged channel may all see the
+// channel of the escalated post: either it's the same channel, or a public channel of the same
+// team.
+func escalationChannelVisibleFrom(channel, pagedChannel *model.Channel) bool {
+	if channel.Id == pagedChannel.Id {
+		return true
+	}
+	return channel.Type == model.ChannelTypeOpen && channel.DeleteAt == 0 && channel.TeamId == pagedChannel.TeamId
+}
+
+// escalationStepUsers returns the active users and group members targeted
+// by a step, leaving out the author of the post.
+func (a *App) escalationStepUsers(step *model.EscalationStep, authorID string) ([]*model.User, error) {
+	var users []*model.User
+	if len(step.UserIds) > 0 {
+		profiles, err := a.Srv().Store().User().GetProfileByIds(context.Background(), step.UserIds, nil, true)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get escalation step users")
+		}
+		users = append(users, profiles...)
+	}
+
+	for _, groupID := range step.GroupIds {
+		members, err := a.Srv().Store().Group().GetMemberUsers(groupID)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get members of group %s", groupID)
+		}
+		users = append(users, members...)
+	}
+
+	seen := make(model.StringSet, len(users))
+	result := make([]*model.User, 0, len(users))
+	for _, user := range users {
+		if user.Id == authorID || user.DeleteAt != 0 || user.IsBot || seen.Has(user.Id) {
+			continue
+		}
+		seen.Add(user.Id)
+		result = append(result, user)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,167 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestResolveEscalationPolicy(t *testing.T) {
+	channelID := model.NewId()
+	channelUrgent := &model.EscalationPolicy{Id: "1", ChannelId: channelID, Priority: model.PostPriorityUrgent}
+	channelAny := &model.EscalationPolicy{Id: "2", ChannelId: channelID}
+	globalUrgent := &model.EscalationPolicy{Id: "3", Priority: model.PostPriorityUrgent}
+	globalAny := &model.EscalationPolicy{Id: "4"}
+	policies := []*model.EscalationPolicy{globalAny, globalUrgent, channelAny, channelUrgent}
+
+	assert.Equal(t, channelUrgent, resolveEscalationPolicy(policies, channelID, model.PostPriorityUrgent))
+	assert.Equal(t, channelAny, resolveEscalationPolicy(policies, channelID, ""))
+	assert.Equal(t, globalUrgent, resolveEscalationPolicy(policies, model.NewId(), model.PostPriorityUrgent))
+	assert.Equal(t, globalAny, resolveEscalationPolicy(policies, model.NewId(), ""))
+	assert.Nil(t, resolveEscalationPolicy([]*model.EscalationPolicy{channelAny}, model.NewId(), ""))
+}
+
+func TestEscalatePersistentNotifications(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.PersistentNotificationMaxCount = 6
+	})
+	pagedChannel := th.CreateChannel(th.Context, th.BasicTeam)
+
+	post, appErr := th.App.CreatePost(th.Context, &model.Post{
+		UserId:    th.BasicUser.Id,
+		ChannelId: th.BasicChannel.Id,
+		Message:   "urgent @" + th.BasicUser2.Username,
+		Metadata: &model.PostMetadata{
+			Priority: &model.PostPriority{
+				Priority:                model.NewPointer(model.PostPriorityUrgent),
+				PersistentNotifications: model.NewPointer(true),
+			},
+		},
+	}, th.BasicChannel, model.CreatePostFlags{})
+	require.Nil(t, appErr)
+
+	policy, appErr := th.App.CreateEscalationPolicy(&model.EscalationPolicy{
+		ChannelId: th.BasicChannel.Id,
+		CreatorId: th.BasicUser.Id,
+		Steps: []*model.EscalationStep{
+			{AfterCount: 2, UserIds: []string{th.BasicUser2.Id, th.BasicUser.Id}},
+			{AfterCount: 3, ChannelId: pagedChannel.Id},
+		},
+	})
+	require.Nil(t, appErr)
+
+	bot, appErr := th.App.GetSystemBot(th.Context)
+	require.Nil(t, appErr)
+
+	botPostsIn := func(channelID string) int {
+		posts, err := th.App.Srv().Store().Post().GetPostsSince(model.GetPostsSinceOptions{ChannelId: channelID, Time: post.CreateAt - 1}, true, map[string]bool{})
+		require.NoError(t, err)
+		count := 0
+		for _, p := range posts.Posts {
+			if p.UserId == bot.UserId {
+				count++
+			}
+		}
+		return count
+	}
+
+	t.Run("no step is due", func(t *testing.T) {
+		notification := &model.PostPersistentNotifications{PostId: post.Id, SentCount: 0}
+		deferred, err := th.App.escalatePersistentNotifications(th.Context, []*model.PostPersistentNotifications{notification}, []*model.Post{post}, true)
+		require.NoError(t, err)
+		assert.Empty(t, deferred)
+		assert.Zero(t, botPostsIn(pagedChannel.Id))
+	})
+
+	t.Run("quiet hours hold back steps", func(t *testing.T) {
+		now := time.Now().UTC()
+		policy.QuietHours = &model.EscalationQuietHours{
+			Start:    now.Add(-time.Hour).Format("15:04"),
+			End:      now.Add(time.Hour).Format("15:04"),
+			Timezone: "UTC",
+		}
+		_, appErr := th.App.UpdateEscalationPolicy(policy)
+		require.Nil(t, appErr)
+		defer func() {
+			policy.QuietHours = nil
+			_, appErr := th.App.UpdateEscalationPolicy(policy)
+			require.Nil(t, appErr)
+		}()
+
+		notification := &model.PostPersistentNotifications{PostId: post.Id, SentCount: 2}
+		deferred, err := th.App.escalatePersistentNotifications(th.Context, []*model.PostPersistentNotifications{notification}, []*model.Post{post}, true)
+		require.NoError(t, err)
+		assert.Equal(t, []string{post.Id}, deferred)
+		assert.Zero(t, botPostsIn(pagedChannel.Id))
+	})
+
+	t.Run("due steps page users and channels", func(t *testing.T) {
+		notification := &model.PostPersistentNotifications{PostId: post.Id, SentCount: 2}
+		deferred, err := th.App.escalatePersistentNotifications(th.Context, []*model.PostPersistentNotifications{notification}, []*model.Post{post}, true)
+		require.NoError(t, err)
+		assert.Empty(t, deferred)
+		assert.Equal(t, 1, botPostsIn(pagedChannel.Id))
+
+		dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, bot.UserId, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, botPostsIn(dm.Id))
+
+		// The author of the post isn't paged.
+		dm, appErr = th.App.GetOrCreateDirectChannel(th.Context, bot.UserId, th.BasicUser.Id)
+		require.Nil(t, appErr)
+		assert.Zero(t, botPostsIn(dm.Id))
+	})
+
+	t.Run("private channel details are only sent to its members", func(t *testing.T) {
+		privateChannel := th.CreatePrivateChannel(th.Context, th.BasicTeam)
+		privatePost, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: privateChannel.Id,
+			Message:   "urgent",
+		}, privateChannel, model.CreatePostFlags{})
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.CreateEscalationPolicy(&model.EscalationPolicy{
+			ChannelId: privateChannel.Id,
+			CreatorId: th.BasicUser.Id,
+			Steps:     []*model.EscalationStep{{AfterCount: 1, UserIds: []string{th.BasicUser2.Id}, ChannelId: pagedChannel.Id}},
+		})
+		require.Nil(t, appErr)
+
+		notification := &model.PostPersistentNotifications{PostId: privatePost.Id, SentCount: 1}
+		_, err := th.App.escalatePersistentNotifications(th.Context, []*model.PostPersistentNotifications{notification}, []*model.Post{privatePost}, true)
+		require.NoError(t, err)
+
+		dm, appErr := th.App.GetOrCreateDirectChannel(th.Context, bot.UserId, th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		for _, channelID := range []string{dm.Id, pagedChannel.Id} {
+			posts, err := th.App.Srv().Store().Post().GetPostsSince(model.GetPostsSinceOptions{ChannelId: channelID, Time: privatePost.CreateAt - 1}, true, map[string]bool{})
+			require.NoError(t, err)
+			require.NotEmpty(t, posts.Posts)
+			for _, p := range posts.Posts {
+				assert.NotContains(t, p.Message, privateChannel.DisplayName)
+				assert.NotContains(t, p.Message, privatePost.Id)
+			}
+		}
+	})
+
+	t.Run("steps after the max count are rejected", func(t *testing.T) {
+		_, appErr := th.App.CreateEscalationPolicy(&model.EscalationPolicy{
+			ChannelId: pagedChannel.Id,
+			CreatorId: th.BasicUser.Id,
+			Steps:     []*model.EscalationStep{{AfterCount: 7, ChannelId: th.BasicChannel.Id}},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.escalation_policy.after_count.app_error", appErr.Id)
+	})
+}
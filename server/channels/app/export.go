@@ -183,6 +183,13 @@ func (a *App) BulkExport(ctx request.CTX, writer io.Writer, outPath string, job
 		return err
 	}
 
+	if opts.IncludePluginKeyValues {
+		ctx.Logger().Info("Bulk export: exporting plugin key values")
+		if err = a.exportAllPluginKVs(ctx, job, writer); err != nil {
+			return err
+		}
+	}
+
 	if opts.IncludeAttachments {
 		ctx.Logger().Info("Bulk export: exporting file attachments")
 		if err = a.exportAttachments(ctx, attachments, outPath, zipWr); err != nil {
@@ -1126,6 +1133,35 @@ func (a *App) exportAllDirectPosts(ctx request.CTX, job *model.Job, writer io.Wr
 	return attachments, nil
 }
 
+func (a *App) exportAllPluginKVs(ctx request.CTX, job *model.Job, writer io.Writer) *model.AppError {
+	afterPluginID := ""
+	afterKey := ""
+	cnt := 0
+	for {
+		kvs, err := a.Srv().Store().Plugin().GetAllForExport(afterPluginID, afterKey, 1000)
+		if err != nil {
+			return model.NewAppError("exportAllPluginKVs", "app.plugin_store.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		if len(kvs) == 0 {
+			break
+		}
+		cnt += len(kvs)
+		updateJobProgress(ctx.Logger(), a.Srv().Store(), job, "plugin_kvs_exported", cnt)
+
+		for _, kv := range kvs {
+			afterPluginID = kv.PluginId
+			afterKey = kv.Key
+
+			if err := a.exportWriteLine(writer, importLineFromPluginKV(kv)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (a *App) exportFile(outPath, filePath string, zipWr *zip.Writer) *model.AppError {
 	var wr io.Writer
 	var err error
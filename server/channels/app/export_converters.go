@@ -329,6 +329,22 @@ func importLineFromEmoji(emoji *model.Emoji, filePath string) *imports.LineImpor
 	}
 }
 
+func importLineFromPluginKV(kv *model.PluginKeyValue) *imports.LineImportData {
+	data := &imports.PluginKVImportData{
+		PluginId: &kv.PluginId,
+		Key:      &kv.Key,
+		Value:    kv.Value,
+	}
+	if kv.ExpireAt != 0 {
+		data.ExpireAt = &kv.ExpireAt
+	}
+
+	return &imports.LineImportData{
+		Type:     "plugin_kv",
+		PluginKV: data,
+	}
+}
+
 func importRoleDataFromRole(role *model.Role) *imports.RoleImportData {
 	return &imports.RoleImportData{
 		Name:          &role.Name,
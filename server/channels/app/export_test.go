@@ -1386,3 +1386,43 @@ func TestExportSchemes(t *testing.T) {
 		require.Equal(t, customTeamGuestRole.BuiltIn, importedTeamGuestRole.BuiltIn)
 	})
 }
+
+func TestExportPluginKVs(t *testing.T) {
+	th1 := Setup(t)
+	defer th1.TearDown()
+
+	pluginID := "com.example.plugin"
+	appErr := th1.App.SetPluginKey(pluginID, "key", []byte("value"))
+	require.Nil(t, appErr)
+	appErr = th1.App.SetPluginKeyWithExpiry(pluginID, "expiring", []byte("expiring value"), 3600)
+	require.Nil(t, appErr)
+
+	t.Run("excluded by default", func(t *testing.T) {
+		var b bytes.Buffer
+		appErr := th1.App.BulkExport(th1.Context, &b, "", nil, model.BulkExportOpts{})
+		require.Nil(t, appErr)
+		assert.NotContains(t, b.String(), `"type":"plugin_kv"`)
+	})
+
+	t.Run("included", func(t *testing.T) {
+		var b bytes.Buffer
+		appErr := th1.App.BulkExport(th1.Context, &b, "", nil, model.BulkExportOpts{
+			IncludePluginKeyValues: true,
+		})
+		require.Nil(t, appErr)
+
+		th2 := Setup(t)
+		defer th2.TearDown()
+		appErr, i := th2.App.BulkImport(th2.Context, &b, nil, false, 1)
+		require.Nil(t, appErr)
+		require.Equal(t, 0, i)
+
+		values, appErr := th2.App.GetPluginKeys(pluginID, []string{"key", "expiring"})
+		require.Nil(t, appErr)
+		assert.Equal(t, map[string][]byte{"key": []byte("value"), "expiring": []byte("expiring value")}, values)
+
+		kv, err := th2.App.Srv().Store().Plugin().Get(pluginID, "expiring")
+		require.NoError(t, err)
+		assert.NotZero(t, kv.ExpireAt)
+	})
+}
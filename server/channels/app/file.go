@@ -142,6 +142,42 @@ func (a *App) ExportFileReader(path string) (filestore.ReadCloseSeeker, *model.A
 	return a.Srv().exportFileReader(path)
 }
 
+// ApplyFileWillBeDownloadedHook lets plugins reject the download of a file, or replace the content
+// sent to the user. It returns the content to send and its size, which is the given file unless
+// a plugin replaced it. The caller remains responsible for closing the given file.
+func (a *App) ApplyFileWillBeDownloadedHook(c request.CTX, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.ReadSeeker, size int64) (io.ReadSeeker, int64, *model.AppError) {
+	content := file
+	var rejectionError *model.AppError
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, manifest *model.Manifest) bool {
+		if _, err := content.Seek(0, io.SeekStart); err != nil {
+			rejectionError = model.NewAppError("ApplyFileWillBeDownloadedHook", "app.file.download.read_file.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			return false
+		}
+
+		var replacement bytes.Buffer
+		if rejectionReason := hooks.FileWillBeDownloaded(pluginContext, info, userID, downloadType, content, &replacement); rejectionReason != "" {
+			rejectionError = model.NewAppError("ApplyFileWillBeDownloadedHook", "app.file.download.rejected_by_plugin.app_error", map[string]any{"Reason": rejectionReason}, "plugin_id="+manifest.Id, http.StatusForbidden)
+			return false
+		}
+		if replacement.Len() != 0 {
+			content = bytes.NewReader(replacement.Bytes())
+			size = int64(replacement.Len())
+		}
+
+		return true
+	}, plugin.FileWillBeDownloadedID)
+	if rejectionError != nil {
+		return nil, 0, rejectionError
+	}
+
+	if _, err := content.Seek(0, io.SeekStart); err != nil {
+		return nil, 0, model.NewAppError("ApplyFileWillBeDownloadedHook", "app.file.download.read_file.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return content, size, nil
+}
+
 func (a *App) FileExists(path string) (bool, *model.AppError) {
 	return a.Srv().fileExists(path)
 }
@@ -1454,7 +1490,19 @@ func (a *App) SearchFilesInTeamForUser(c request.CTX, terms string, userId strin
 		}
 	}
 
-	return fileInfoSearchResults, a.filterInaccessibleFiles(fileInfoSearchResults, filterFileOptions{assumeSortedCreatedAt: true})
+	if appErr := a.filterInaccessibleFiles(fileInfoSearchResults, filterFileOptions{assumeSortedCreatedAt: true}); appErr != nil {
+		return nil, appErr
+	}
+
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		if _, files := hooks.SearchResultsWillBeReturned(pluginContext, userId, nil, fileInfoSearchResults); files != nil {
+			fileInfoSearchResults = files
+		}
+		return true
+	}, plugin.SearchResultsWillBeReturnedID)
+
+	return fileInfoSearchResults, nil
 }
 
 func (a *App) ExtractContentFromFileInfo(rctx request.CTX, fileInfo *model.FileInfo) error {
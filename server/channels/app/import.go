@@ -364,6 +364,11 @@ func (a *App) importLine(c request.CTX, line imports.LineImportData, dryRun bool
 			return model.NewAppError("BulkImport", "app.import.import_line.null_emoji.error", nil, "", http.StatusBadRequest)
 		}
 		return a.importEmoji(c, line.Emoji, dryRun)
+	case line.Type == "plugin_kv":
+		if line.PluginKV == nil {
+			return model.NewAppError("BulkImport", "app.import.import_line.null_plugin_kv.error", nil, "", http.StatusBadRequest)
+		}
+		return a.importPluginKV(c, line.PluginKV, dryRun)
 	default:
 		return model.NewAppError("BulkImport", "app.import.import_line.unknown_line_type.error", map[string]any{"Type": line.Type}, "", http.StatusBadRequest)
 	}
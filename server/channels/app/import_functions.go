@@ -1177,6 +1177,15 @@ func (a *App) importUserChannels(rctx request.CTX, user *model.User, team *model
 			// town-square membership was in the import and added by the importer (skip the added by the importer)
 			continue
 		}
+		if _, ok = existingMembershipsByChannelId[channel.Id]; !ok {
+			if appErr := a.checkChannelAccessPolicy(rctx, user, channel); appErr != nil {
+				if appErr.StatusCode != http.StatusForbidden {
+					return appErr
+				}
+				rctx.Logger().Warn("Skipping the channel membership denied by the access policy of the channel", mlog.String("user_id", user.Id), mlog.String("channel_id", channel.Id))
+				continue
+			}
+		}
 
 		isGuestByChannelId[channel.Id] = false
 		isUserByChannelId[channel.Id] = true
@@ -2620,3 +2629,45 @@ func (a *App) extractThreadMembers(line *imports.LineImportWorkerData, users map
 
 	return threadMemberships, 0, nil
 }
+
+func (a *App) importPluginKV(rctx request.CTX, data *imports.PluginKVImportData, dryRun bool) *model.AppError {
+	var fields []mlog.Field
+	if data != nil && data.PluginId != nil && data.Key != nil {
+		fields = append(fields, mlog.String("plugin_id", *data.PluginId), mlog.String("key", *data.Key))
+	}
+	rctx.Logger().Info("Validating plugin key value", fields...)
+
+	if err := imports.ValidatePluginKVImportData(data); err != nil {
+		return err
+	}
+
+	// If this is a Dry Run, do not continue any further.
+	if dryRun {
+		return nil
+	}
+
+	kv := &model.PluginKeyValue{
+		PluginId: *data.PluginId,
+		Key:      *data.Key,
+		Value:    data.Value,
+	}
+	if data.ExpireAt != nil {
+		if *data.ExpireAt <= model.GetMillis() {
+			rctx.Logger().Info("Skipping expired plugin key value", fields...)
+			return nil
+		}
+		kv.ExpireAt = *data.ExpireAt
+	}
+
+	rctx.Logger().Info("Importing plugin key value", fields...)
+
+	if _, err := a.Srv().Store().Plugin().SaveOrUpdate(kv); err != nil {
+		var appErr *model.AppError
+		if errors.As(err, &appErr) {
+			return appErr
+		}
+		return model.NewAppError("importPluginKV", "app.plugin_store.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
@@ -24,6 +24,7 @@ type LineImportData struct {
 	DirectChannel *DirectChannelImportData `json:"direct_channel,omitempty"`
 	DirectPost    *DirectPostImportData    `json:"direct_post,omitempty"`
 	Emoji         *EmojiImportData         `json:"emoji,omitempty"`
+	PluginKV      *PluginKVImportData      `json:"plugin_kv,omitempty"`
 	Version       *int                     `json:"version,omitempty"`
 	Info          *VersionInfoImportData   `json:"info,omitempty"`
 }
@@ -174,6 +175,13 @@ type EmojiImportData struct {
 	Data  *zip.File `json:"-"`
 }
 
+type PluginKVImportData struct {
+	PluginId *string `json:"plugin_id"`
+	Key      *string `json:"key"`
+	Value    []byte  `json:"value"`
+	ExpireAt *int64  `json:"expire_at,omitempty"`
+}
+
 type ReactionImportData struct {
 	User      *string `json:"user"`
 	CreateAt  *int64  `json:"create_at"`
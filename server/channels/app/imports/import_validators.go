@@ -661,6 +661,34 @@ func ValidateEmojiImportData(data *EmojiImportData) *model.AppError {
 	return nil
 }
 
+func ValidatePluginKVImportData(data *PluginKVImportData) *model.AppError {
+	if data == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_plugin_kv_import_data.empty.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.PluginId == nil || *data.PluginId == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_plugin_kv_import_data.plugin_id_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Key == nil || *data.Key == "" {
+		return model.NewAppError("BulkImport", "app.import.validate_plugin_kv_import_data.key_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	if data.Value == nil {
+		return model.NewAppError("BulkImport", "app.import.validate_plugin_kv_import_data.value_missing.error", nil, "", http.StatusBadRequest)
+	}
+
+	kv := &model.PluginKeyValue{
+		PluginId: *data.PluginId,
+		Key:      *data.Key,
+	}
+	if err := kv.IsValid(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func isValidTrueOrFalseString(value string) bool {
 	return value == "true" || value == "false"
 }
@@ -1462,6 +1462,44 @@ func TestImportValidateEmojiImportData(t *testing.T) {
 	}
 }
 
+func TestImportValidatePluginKVImportData(t *testing.T) {
+	var testCases = []struct {
+		testName    string
+		pluginID    *string
+		key         *string
+		value       []byte
+		expectError bool
+	}{
+		{"success", model.NewPointer("com.example.plugin"), model.NewPointer("key"), []byte("value"), false},
+		{"empty value", model.NewPointer("com.example.plugin"), model.NewPointer("key"), []byte{}, false},
+		{"nil plugin id", nil, model.NewPointer("key"), []byte("value"), true},
+		{"empty plugin id", model.NewPointer(""), model.NewPointer("key"), []byte("value"), true},
+		{"nil key", model.NewPointer("com.example.plugin"), nil, []byte("value"), true},
+		{"empty key", model.NewPointer("com.example.plugin"), model.NewPointer(""), []byte("value"), true},
+		{"key too long", model.NewPointer("com.example.plugin"), model.NewPointer(strings.Repeat("k", model.KeyValueKeyMaxRunes+1)), []byte("value"), true},
+		{"nil value", model.NewPointer("com.example.plugin"), model.NewPointer("key"), nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.testName, func(t *testing.T) {
+			data := PluginKVImportData{
+				PluginId: tc.pluginID,
+				Key:      tc.key,
+				Value:    tc.value,
+			}
+
+			err := ValidatePluginKVImportData(&data)
+			if tc.expectError {
+				assert.NotNil(t, err)
+			} else {
+				assert.Nil(t, err)
+			}
+		})
+	}
+
+	assert.NotNil(t, ValidatePluginKVImportData(nil))
+}
+
 func checkError(t *testing.T, err *model.AppError) {
 	require.NotNil(t, err, "Should have returned an error.")
 }
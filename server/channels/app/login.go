@@ -221,6 +221,8 @@ func (a *App) DoLogin(c request.CTX, w http.ResponseWriter, r *http.Request, use
 
 	c = c.WithSession(session)
 
+	a.recordSessionActivityAsync(c, session, utils.GetIPAddress(r, a.Config().ServiceSettings.TrustedProxyIPHeader), r.UserAgent(), true)
+
 	if a.Srv().License() != nil && *a.Srv().License().Features.LDAP && a.Ldap() != nil {
 		userVal := *user
 		sessionVal := *session
@@ -0,0 +1,212 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+	"github.com/mattermost/mattermost/server/v8/platform/services/cache"
+)
+
+const loginFailuresCacheSize = 10000
+
+// loginFailures counts the consecutive failed logins of an account or an IP address.
+type loginFailures struct {
+	Count         int
+	LastFailureAt int64
+}
+
+func loginFailuresUserKey(userID string) string {
+	return "user:" + userID
+}
+
+func loginFailuresIPKey(ipAddress string) string {
+	return "ip:" + ipAddress
+}
+
+// loginBackoffDelay returns the delay after the given number of consecutive failed logins,
+// doubling from the base delay up to the maximum one.
+func loginBackoffDelay(failures int, baseSeconds int, maxSeconds int) time.Duration {
+	if failures <= 0 || baseSeconds <= 0 {
+		return 0
+	}
+
+	delay := time.Duration(baseSeconds) * time.Second
+	maxDelay := time.Duration(maxSeconds) * time.Second
+	for i := 1; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxDelay)
+}
+
+func (a *App) getLoginFailures(rctx request.CTX, key string) (*loginFailures, bool) {
+	var failures loginFailures
+	if err := a.Srv().loginFailuresCache.Get(key, &failures); err != nil {
+		if !errors.Is(err, cache.ErrKeyNotFound) {
+			rctx.Logger().Warn("Failed to get the login failures", mlog.String("key", key), mlog.Err(err))
+		}
+		return nil, false
+	}
+
+	return &failures, true
+}
+
+// checkLoginBackoff refuses the login while the account or the IP address it comes from has to
+// wait after its last failed logins.
+func (a *App) checkLoginBackoff(rctx request.CTX, user *model.User) *model.AppError {
+	baseSeconds := *a.Config().ServiceSettings.LoginBackoffBaseSeconds
+	if baseSeconds == 0 {
+		return nil
+	}
+	maxSeconds := *a.Config().ServiceSettings.LoginBackoffMaxSeconds
+
+	keys := []string{loginFailuresUserKey(user.Id)}
+	if rctx.IPAddress() != "" {
+		keys = append(keys, loginFailuresIPKey(rctx.IPAddress()))
+	}
+
+	now := model.GetMillis()
+	for _, key := range keys {
+		failures, ok := a.getLoginFailures(rctx, key)
+		if !ok {
+			continue
+		}
+
+		retryAt := failures.LastFailureAt + loginBackoffDelay(failures.Count, baseSeconds, maxSeconds).Milliseconds()
+		if now < retryAt {
+			seconds := (retryAt - now + 999) / 1000
+			return model.NewAppError("checkLoginBackoff", "api.user.login.backoff.app_error", map[string]any{"Seconds": seconds}, "user_id="+user.Id, http.StatusTooManyRequests)
+		}
+	}
+
+	return nil
+}
+
+// recordFailedLogin counts a failed login of the user, and locks the account once it reaches
+// the maximum number of attempts. The time of the failure is stored with the count, since the
+// failures kept in the cache to delay logins can be evicted or be missing on other nodes.
+func (a *App) recordFailedLogin(rctx request.CTX, user *model.User) *model.AppError {
+	attempts := user.FailedAttempts + 1
+	now := model.GetMillis()
+	if err := a.Srv().Store().User().RecordFailedPasswordAttempt(user.Id, attempts, now); err != nil {
+		return model.NewAppError("recordFailedLogin", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.recordLoginBackoffFailure(rctx, user.Id, now)
+
+	cfg := a.Config().ServiceSettings
+	if attempts == *cfg.MaximumLoginAttempts {
+		auditRec := a.MakeAuditRecord(rctx, "lockUserAccount", audit.Success)
+		auditRec.AddMeta("user_id", user.Id)
+		auditRec.AddMeta("failed_attempts", attempts)
+		auditRec.AddMeta("ip_address", rctx.IPAddress())
+		a.LogAuditRec(rctx, auditRec, nil)
+
+		rctx.Logger().Warn("Account locked after too many failed logins", mlog.String("user_id", user.Id), mlog.Int("failed_attempts", attempts))
+	}
+
+	return nil
+}
+
+// recordLoginBackoffFailure counts a failed login of the user, and of the IP address it comes
+// from, to delay the next logins.
+func (a *App) recordLoginBackoffFailure(rctx request.CTX, userID string, now int64) {
+	cfg := a.Config().ServiceSettings
+	if *cfg.LoginBackoffBaseSeconds == 0 && *cfg.LoginLockoutWindowMinutes == 0 {
+		return
+	}
+
+	// Keep the failures for as long as they delay logins or keep the account locked.
+	ttl := max(time.Duration(*cfg.LoginBackoffMaxSeconds)*time.Second, time.Duration(*cfg.LoginLockoutWindowMinutes)*time.Minute)
+
+	keys := []string{loginFailuresUserKey(userID)}
+	if rctx.IPAddress() != "" {
+		keys = append(keys, loginFailuresIPKey(rctx.IPAddress()))
+	}
+
+	for _, key := range keys {
+		failures, ok := a.getLoginFailures(rctx, key)
+		if !ok {
+			failures = &loginFailures{}
+		}
+		failures.Count++
+		failures.LastFailureAt = now
+
+		if err := a.Srv().loginFailuresCache.SetWithExpiry(key, failures, ttl); err != nil {
+			rctx.Logger().Warn("Failed to record the login failure", mlog.String("key", key), mlog.Err(err))
+		}
+	}
+}
+
+// clearLoginBackoffFailures stops delaying the logins of the user.
+func (a *App) clearLoginBackoffFailures(rctx request.CTX, userID string) {
+	if err := a.Srv().loginFailuresCache.Remove(loginFailuresUserKey(userID)); err != nil {
+		rctx.Logger().Warn("Failed to clear the login failures", mlog.String("user_id", userID), mlog.Err(err))
+	}
+}
+
+// resetFailedLogins clears the failed logins of the user after a successful one.
+func (a *App) resetFailedLogins(rctx request.CTX, user *model.User) *model.AppError {
+	if err := a.Srv().Store().User().UpdateFailedPasswordAttempts(user.Id, 0); err != nil {
+		return model.NewAppError("resetFailedLogins", "app.user.update_failed_pwd_attempts.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	a.clearLoginBackoffFailures(rctx, user.Id)
+
+	return nil
+}
+
+// checkUserLoginAttempts refuses the login of accounts locked after too many failed logins,
+// unless no login failed during the lockout window. Accounts locked before the time of the last
+// failure was stored stay locked until unlocked by an admin.
+func (a *App) checkUserLoginAttempts(rctx request.CTX, user *model.User) *model.AppError {
+	if user.FailedAttempts < *a.Config().ServiceSettings.MaximumLoginAttempts {
+		return nil
+	}
+
+	windowMinutes := *a.Config().ServiceSettings.LoginLockoutWindowMinutes
+	if windowMinutes > 0 {
+		if user.LastFailedAttemptAt > 0 && model.GetMillis()-user.LastFailedAttemptAt >= (time.Duration(windowMinutes)*time.Minute).Milliseconds() {
+			if appErr := a.unlockUser(rctx, user); appErr != nil {
+				return appErr
+			}
+
+			auditRec := a.MakeAuditRecord(rctx, "unlockUserAccount", audit.Success)
+			auditRec.AddMeta("user_id", user.Id)
+			auditRec.AddMeta("reason", "lockout_window")
+			a.LogAuditRec(rctx, auditRec, nil)
+
+			return nil
+		}
+	}
+
+	return model.NewAppError("checkUserLoginAttempts", "api.user.check_user_login_attempts.too_many.app_error", nil, "user_id="+user.Id, http.StatusUnauthorized)
+}
+
+// UnlockUser unlocks an account locked after too many failed logins.
+func (a *App) UnlockUser(rctx request.CTX, userID string) *model.AppError {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	return a.unlockUser(rctx, user)
+}
+
+func (a *App) unlockUser(rctx request.CTX, user *model.User) *model.AppError {
+	if appErr := a.resetFailedLogins(rctx, user); appErr != nil {
+		return appErr
+	}
+	user.FailedAttempts = 0
+	a.InvalidateCacheForUser(user.Id)
+
+	return nil
+}
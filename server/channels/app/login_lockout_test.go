@@ -0,0 +1,129 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestLoginBackoffDelay(t *testing.T) {
+	assert.Equal(t, time.Duration(0), loginBackoffDelay(0, 1, 300))
+	assert.Equal(t, time.Duration(0), loginBackoffDelay(3, 0, 300))
+	assert.Equal(t, time.Second, loginBackoffDelay(1, 1, 300))
+	assert.Equal(t, 2*time.Second, loginBackoffDelay(2, 1, 300))
+	assert.Equal(t, 8*time.Second, loginBackoffDelay(4, 1, 300))
+	assert.Equal(t, 300*time.Second, loginBackoffDelay(20, 1, 300))
+	assert.Equal(t, 300*time.Second, loginBackoffDelay(1000, 1, 300))
+}
+
+func TestLoginBackoff(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.LoginBackoffBaseSeconds = 60
+		*cfg.ServiceSettings.MaximumLoginAttempts = 10
+	})
+
+	rctx := th.Context.WithIPAddress("10.0.0.1")
+	user, appErr := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	require.Nil(t, th.App.checkLoginBackoff(rctx, user))
+	require.Nil(t, th.App.recordFailedLogin(rctx, user))
+
+	appErr = th.App.checkLoginBackoff(rctx, user)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "api.user.login.backoff.app_error", appErr.Id)
+	assert.Equal(t, http.StatusTooManyRequests, appErr.StatusCode)
+
+	t.Run("the source IP address is delayed for other users", func(t *testing.T) {
+		appErr := th.App.checkLoginBackoff(rctx, th.BasicUser2)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.login.backoff.app_error", appErr.Id)
+
+		require.Nil(t, th.App.checkLoginBackoff(th.Context.WithIPAddress("10.0.0.2"), th.BasicUser2))
+	})
+
+	t.Run("double checking the password is delayed", func(t *testing.T) {
+		appErr := th.App.DoubleCheckPassword(rctx, user, "Password1")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.user.login.backoff.app_error", appErr.Id)
+	})
+
+	t.Run("a successful login clears the account delay", func(t *testing.T) {
+		require.Nil(t, th.App.resetFailedLogins(th.Context, user))
+		require.Nil(t, th.App.checkLoginBackoff(th.Context.WithIPAddress("10.0.0.2"), user))
+	})
+}
+
+func TestLoginLockoutWindow(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.MaximumLoginAttempts = 1
+		*cfg.ServiceSettings.LoginLockoutWindowMinutes = 15
+	})
+
+	user, appErr := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	require.Nil(t, th.App.recordFailedLogin(th.Context, user))
+	th.App.InvalidateCacheForUser(user.Id)
+
+	user, appErr = th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	appErr = th.App.checkUserLoginAttempts(th.Context, user)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "api.user.check_user_login_attempts.too_many.app_error", appErr.Id)
+
+	assert.NotZero(t, user.LastFailedAttemptAt)
+
+	// The account stays locked when the cached failures are evicted or kept by another node.
+	require.NoError(t, th.App.Srv().loginFailuresCache.Remove(loginFailuresUserKey(user.Id)))
+	appErr = th.App.checkUserLoginAttempts(th.Context, user)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "api.user.check_user_login_attempts.too_many.app_error", appErr.Id)
+
+	// Move the last failure out of the lockout window.
+	err := th.App.Srv().Store().User().RecordFailedPasswordAttempt(user.Id, 1, model.GetMillis()-(16*time.Minute).Milliseconds())
+	require.NoError(t, err)
+	th.App.InvalidateCacheForUser(user.Id)
+
+	user, appErr = th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	require.Nil(t, th.App.checkUserLoginAttempts(th.Context, user))
+
+	user, appErr = th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.Zero(t, user.FailedAttempts)
+}
+
+func TestUnlockUser(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.MaximumLoginAttempts = 1 })
+
+	err := th.App.Srv().Store().User().UpdateFailedPasswordAttempts(th.BasicUser.Id, 1)
+	require.NoError(t, err)
+	th.App.InvalidateCacheForUser(th.BasicUser.Id)
+
+	require.Nil(t, th.App.UnlockUser(th.Context, th.BasicUser.Id))
+
+	user, appErr := th.App.GetUser(th.BasicUser.Id)
+	require.Nil(t, appErr)
+	assert.Zero(t, user.FailedAttempts)
+	require.Nil(t, th.App.checkUserLoginAttempts(th.Context, user))
+
+	appErr = th.App.UnlockUser(th.Context, model.NewId())
+	require.NotNil(t, appErr)
+}
@@ -163,7 +163,7 @@ func (a *App) GetOAuthImplicitRedirect(c request.CTX, userID string, authRequest
 }
 
 func (a *App) GetOAuthCodeRedirect(userID string, authRequest *model.AuthorizeRequest) (string, *model.AppError) {
-	authData := &model.AuthData{UserId: userID, ClientId: authRequest.ClientId, CreateAt: model.GetMillis(), RedirectUri: authRequest.RedirectURI, State: authRequest.State, Scope: authRequest.Scope}
+	authData := &model.AuthData{UserId: userID, ClientId: authRequest.ClientId, CreateAt: model.GetMillis(), RedirectUri: authRequest.RedirectURI, State: authRequest.State, Scope: authRequest.Scope, CodeChallenge: authRequest.CodeChallenge, CodeChallengeMethod: authRequest.CodeChallengeMethod, Nonce: authRequest.Nonce}
 	authData.Code = model.NewId() + model.NewId()
 
 	// parse authRequest.RedirectURI to handle query parameters see: https://mattermost.atlassian.net/browse/MM-46216
@@ -189,10 +189,6 @@ func (a *App) AllowOAuthAppAccessToUser(c request.CTX, userID string, authReques
 		return "", model.NewAppError("AllowOAuthAppAccessToUser", "api.oauth.allow_oauth.turn_off.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	if authRequest.Scope == "" {
-		authRequest.Scope = model.DefaultScope
-	}
-
 	oauthApp, nErr := a.Srv().Store().OAuth().GetApp(authRequest.ClientId)
 	if nErr != nil {
 		var nfErr *store.ErrNotFound
@@ -208,6 +204,17 @@ func (a *App) AllowOAuthAppAccessToUser(c request.CTX, userID string, authReques
 		return "", model.NewAppError("AllowOAuthAppAccessToUser", "api.oauth.allow_oauth.redirect_callback.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	scope, ok := oauthApp.GetGrantedScope(authRequest.Scope)
+	if !ok {
+		return authRequest.RedirectURI + "?error=invalid_scope&state=" + authRequest.State, nil
+	}
+	authRequest.Scope = scope
+
+	// Public clients can't keep a secret, so their codes must be bound to a code challenge.
+	if oauthApp.IsPublic && authRequest.ResponseType == model.AuthCodeResponseType && authRequest.CodeChallenge == "" {
+		return authRequest.RedirectURI + "?error=invalid_request&state=" + authRequest.State, nil
+	}
+
 	var redirectURI string
 	var err *model.AppError
 	switch authRequest.ResponseType {
@@ -255,7 +262,7 @@ func (a *App) GetOAuthAccessTokenForImplicitFlow(c request.CTX, userID string, a
 		return nil, err
 	}
 
-	session, err := a.newSession(c, oauthApp, user)
+	session, err := a.newSession(c, oauthApp, user, authRequest.Scope)
 	if err != nil {
 		return nil, err
 	}
@@ -269,23 +276,21 @@ func (a *App) GetOAuthAccessTokenForImplicitFlow(c request.CTX, userID string, a
 	return session, nil
 }
 
-func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType, redirectURI, code, secret, refreshToken string) (*model.AccessResponse, *model.AppError) {
+func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType, redirectURI, code, secret, refreshToken, codeVerifier string) (*model.AccessResponse, *model.AppError) {
 	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
 		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.disabled.app_error", nil, "", http.StatusNotImplemented)
 	}
 
-	oauthApp, nErr := a.Srv().Store().OAuth().GetApp(clientId)
-	if nErr != nil {
-		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.credentials.app_error", nil, "", http.StatusNotFound)
-	}
-
-	if oauthApp.ClientSecret != secret {
-		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.credentials.app_error", nil, "", http.StatusForbidden)
+	oauthApp, appErr := a.authenticateOAuthClient(clientId, secret)
+	if appErr != nil {
+		return nil, appErr
 	}
 
+	var nErr error
 	var accessData *model.AccessData
 	var accessRsp *model.AccessResponse
 	var user *model.User
+	var scope, nonce string
 	if grantType == model.AccessTokenGrantType {
 		var authData *model.AuthData
 		authData, nErr = a.Srv().Store().OAuth().GetAuthData(code)
@@ -300,10 +305,18 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType,
 			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.expired_code.app_error", nil, "", http.StatusForbidden)
 		}
 
+		if authData.ClientId != clientId {
+			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.expired_code.app_error", nil, "", http.StatusBadRequest)
+		}
+
 		if authData.RedirectUri != redirectURI {
 			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.redirect_uri.app_error", nil, "", http.StatusBadRequest)
 		}
 
+		if (oauthApp.IsPublic && authData.CodeChallenge == "") || !authData.VerifyCodeVerifier(codeVerifier) {
+			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.code_verifier.app_error", nil, "", http.StatusBadRequest)
+		}
+
 		user, nErr = a.Srv().Store().User().Get(context.Background(), authData.UserId)
 		if nErr != nil {
 			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.internal_user.app_error", nil, "", http.StatusNotFound)
@@ -338,7 +351,7 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType,
 		} else {
 			var session *model.Session
 			// Create a new session and return new access token
-			session, err := a.newSession(c, oauthApp, user)
+			session, err := a.newSession(c, oauthApp, user, authData.Scope)
 			if err != nil {
 				return nil, err
 			}
@@ -360,6 +373,9 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType,
 		if nErr = a.Srv().Store().OAuth().RemoveAuthData(authData.Code); nErr != nil {
 			c.Logger().Warn("unable to remove auth data", mlog.Err(nErr))
 		}
+
+		scope = authData.Scope
+		nonce = authData.Nonce
 	} else {
 		// When grantType is refresh_token
 		accessData, nErr = a.Srv().Store().OAuth().GetAccessDataByRefreshToken(refreshToken)
@@ -367,7 +383,11 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType,
 			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.refresh_token.app_error", nil, "", http.StatusNotFound)
 		}
 
-		user, nErr := a.Srv().Store().User().Get(context.Background(), accessData.UserId)
+		if accessData.ClientId != clientId {
+			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.refresh_token.app_error", nil, "", http.StatusNotFound)
+		}
+
+		user, nErr = a.Srv().Store().User().Get(context.Background(), accessData.UserId)
 		if nErr != nil {
 			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.internal_user.app_error", nil, "", http.StatusNotFound)
 		}
@@ -377,12 +397,115 @@ func (a *App) GetOAuthAccessTokenForCodeFlow(c request.CTX, clientId, grantType,
 			return nil, err
 		}
 		accessRsp = access
+		scope = accessData.Scope
+	}
+
+	idToken, appErr := a.newOIDCIdToken(oauthApp, user, scope, nonce)
+	if appErr != nil {
+		return nil, appErr
 	}
+	accessRsp.IdToken = idToken
 
 	return accessRsp, nil
 }
 
-func (a *App) newSession(c request.CTX, app *model.OAuthApp, user *model.User) (*model.Session, *model.AppError) {
+// GetOAuthAccessTokenForClientCredentials issues a token for the bot the app is bound to.
+func (a *App) GetOAuthAccessTokenForClientCredentials(c request.CTX, clientId, secret, scope string) (*model.AccessResponse, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	oauthApp, appErr := a.authenticateOAuthClient(clientId, secret)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if oauthApp.IsPublic || oauthApp.BotUserId == "" {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.unauthorized_client.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	grantedScope, ok := oauthApp.GetGrantedScope(scope)
+	if !ok {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.invalid_scope.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	user, err := a.Srv().Store().User().Get(context.Background(), oauthApp.BotUserId)
+	if err != nil {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.internal_user.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if !user.IsBot || user.DeleteAt != 0 {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.unauthorized_client.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// Clients can authenticate again at any time, so no refresh token is issued.
+	return a.issueOAuthAccessToken(c, oauthApp, user, grantedScope, model.ClientCredentialsGrantType, false)
+}
+
+// issueOAuthAccessToken creates a new session for the user of the app and returns its token. The
+// session replaces the previous one of the user, except for client credentials, since several
+// instances of a client may hold tokens of the app's bot at the same time.
+func (a *App) issueOAuthAccessToken(c request.CTX, app *model.OAuthApp, user *model.User, scope, grantType string, withRefreshToken bool) (*model.AccessResponse, *model.AppError) {
+	if grantType != model.ClientCredentialsGrantType {
+		previous, err := a.Srv().Store().OAuth().GetPreviousAccessData(user.Id, app.Id)
+		if err != nil {
+			return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.internal.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+
+		if previous != nil {
+			if appErr := a.RevokeAccessToken(c, previous.Token); appErr != nil {
+				return nil, appErr
+			}
+		}
+	}
+
+	session, appErr := a.newSession(c, app, user, scope)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	accessData := &model.AccessData{ClientId: app.Id, UserId: user.Id, Token: session.Token, ExpiresAt: session.ExpiresAt, Scope: scope, GrantType: grantType}
+	if withRefreshToken {
+		accessData.RefreshToken = model.NewId()
+	}
+
+	if _, err := a.Srv().Store().OAuth().SaveAccessData(accessData); err != nil {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.internal_saving.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &model.AccessResponse{
+		AccessToken:      session.Token,
+		TokenType:        model.AccessTokenType,
+		RefreshToken:     accessData.RefreshToken,
+		ExpiresInSeconds: int32(*a.Config().ServiceSettings.SessionLengthSSOInHours * 60 * 60),
+		Scope:            scope,
+	}, nil
+}
+
+// authenticateOAuthClient returns the app of the given client, checking its secret unless it's
+// a public client.
+func (a *App) authenticateOAuthClient(clientId, secret string) (*model.OAuthApp, *model.AppError) {
+	oauthApp, err := a.Srv().Store().OAuth().GetApp(clientId)
+	if err != nil {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.credentials.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if oauthApp.IsPublic {
+		return oauthApp, nil
+	}
+
+	if secret == "" {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.bad_client_secret.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if oauthApp.ClientSecret != secret {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.credentials.app_error", nil, "", http.StatusForbidden)
+	}
+
+	return oauthApp, nil
+}
+
+func (a *App) newSession(c request.CTX, app *model.OAuthApp, user *model.User, scope string) (*model.Session, *model.AppError) {
 	if err := a.limitNumberOfSessions(c, user.Id); err != nil {
 		return nil, model.NewAppError("newSession", "api.oauth.get_access_token.internal_session.app_error", nil,
 			"", http.StatusInternalServerError).Wrap(err)
@@ -397,6 +520,13 @@ func (a *App) newSession(c request.CTX, app *model.OAuthApp, user *model.User) (
 	session.AddProp(model.SessionPropMattermostAppID, app.MattermostAppID)
 	session.AddProp(model.SessionPropOs, "OAuth2")
 	session.AddProp(model.SessionPropBrowser, "OAuth2")
+	if user.IsBot {
+		session.AddProp(model.SessionPropIsBot, model.SessionPropIsBotValue)
+	}
+	// The scopes restricting the session are enforced the same way as those of user access tokens.
+	if scopes := strings.Fields(scope); len(model.ParseUserAccessTokenScopes(scopes)) > 0 {
+		session.AddProp(model.SessionPropUserAccessTokenScopes, strings.Join(scopes, " "))
+	}
 
 	session, err := a.Srv().Store().Session().Save(c, session)
 	if err != nil {
@@ -414,7 +544,7 @@ func (a *App) newSessionUpdateToken(c request.CTX, app *model.OAuthApp, accessDa
 		c.Logger().Warn("error removing access data token from session", mlog.Err(err))
 	}
 
-	session, err := a.newSession(c, app, user)
+	session, err := a.newSession(c, app, user, accessData.Scope)
 	if err != nil {
 		return nil, err
 	}
@@ -571,20 +701,43 @@ func (a *App) RevokeAccessToken(c request.CTX, token string) *model.AppError {
 func (a *App) CompleteOAuth(c request.CTX, service string, body io.ReadCloser, teamID string, props map[string]string, tokenUser *model.User) (*model.User, *model.AppError) {
 	defer body.Close()
 
+	// The user data is read again to synchronize the groups of the user.
+	buf := bytes.Buffer{}
+	if _, err := buf.ReadFrom(body); err != nil {
+		return nil, model.NewAppError("CompleteOAuth", "api.user.login_by_oauth.parse.app_error",
+			map[string]any{"Service": service}, "", http.StatusBadRequest).Wrap(err)
+	}
+	userData := bytes.NewReader(buf.Bytes())
+
+	var user *model.User
+	var appErr *model.AppError
 	action := props["action"]
 
 	switch action {
 	case model.OAuthActionSignup:
-		return a.CreateOAuthUser(c, service, body, teamID, tokenUser)
+		user, appErr = a.CreateOAuthUser(c, service, userData, teamID, tokenUser)
 	case model.OAuthActionLogin:
-		return a.LoginByOAuth(c, service, body, teamID, tokenUser)
+		user, appErr = a.LoginByOAuth(c, service, userData, teamID, tokenUser)
 	case model.OAuthActionEmailToSSO:
-		return a.CompleteSwitchWithOAuth(c, service, body, props["email"], tokenUser)
+		user, appErr = a.CompleteSwitchWithOAuth(c, service, userData, props["email"], tokenUser)
 	case model.OAuthActionSSOToEmail:
-		return a.LoginByOAuth(c, service, body, teamID, tokenUser)
+		user, appErr = a.LoginByOAuth(c, service, userData, teamID, tokenUser)
 	default:
-		return a.LoginByOAuth(c, service, body, teamID, tokenUser)
+		user, appErr = a.LoginByOAuth(c, service, userData, teamID, tokenUser)
 	}
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// Failing to synchronize the groups doesn't prevent logging in.
+	if appErr := a.syncOAuthUserGroups(c, service, buf.Bytes(), user, tokenUser); appErr != nil {
+		c.Logger().Warn("Failed to synchronize the groups of the OAuth user", mlog.String("service", service), mlog.String("user_id", user.Id), mlog.Err(appErr))
+	}
+	if appErr := a.syncOAuthUserAttributeGroups(c, service, buf.Bytes(), user); appErr != nil {
+		c.Logger().Warn("Failed to synchronize the attribute groups of the OAuth user", mlog.String("service", service), mlog.String("user_id", user.Id), mlog.Err(appErr))
+	}
+
+	return user, nil
 }
 
 func (a *App) getSSOProvider(service string) (einterfaces.OAuthProvider, *model.AppError) {
@@ -601,9 +754,28 @@ func (a *App) getSSOProvider(service string) (einterfaces.OAuthProvider, *model.
 		return nil, model.NewAppError("getSSOProvider", "api.user.login_by_oauth.not_available.app_error",
 			map[string]any{"Service": strings.Title(service)}, "", http.StatusNotImplemented)
 	}
+	if httpClientProvider, ok := provider.(einterfaces.OAuthHTTPClientProvider); ok {
+		provider = httpClientProvider.WithHTTPClient(a.HTTPService().MakeClient(true))
+	}
 	return provider, nil
 }
 
+// getOAuthUserFromJSON reads the user from the user data of the service, with the claims
+// configured for the service when the provider maps them.
+func (a *App) getOAuthUserFromJSON(c request.CTX, provider einterfaces.OAuthProvider, service string, userData io.Reader, tokenUser *model.User) (*model.User, error) {
+	claimsProvider, ok := provider.(einterfaces.OAuthClaimsProvider)
+	if !ok {
+		return provider.GetUserFromJSON(c, userData, tokenUser)
+	}
+
+	settings, err := claimsProvider.GetSSOSettings(c, a.Config(), service)
+	if err != nil {
+		return nil, err
+	}
+
+	return claimsProvider.GetUserFromJSONWithSettings(c, settings, userData, tokenUser)
+}
+
 func (a *App) LoginByOAuth(c request.CTX, service string, userData io.Reader, teamID string, tokenUser *model.User) (*model.User, *model.AppError) {
 	provider, e := a.getSSOProvider(service)
 	if e != nil {
@@ -616,7 +788,7 @@ func (a *App) LoginByOAuth(c request.CTX, service string, userData io.Reader, te
 			map[string]any{"Service": service}, "", http.StatusBadRequest)
 	}
 
-	authUser, err1 := provider.GetUserFromJSON(c, bytes.NewReader(buf.Bytes()), tokenUser)
+	authUser, err1 := a.getOAuthUserFromJSON(c, provider, service, bytes.NewReader(buf.Bytes()), tokenUser)
 	if err1 != nil {
 		return nil, model.NewAppError("LoginByOAuth", "api.user.login_by_oauth.parse.app_error",
 			map[string]any{"Service": service}, "", http.StatusBadRequest).Wrap(err1)
@@ -667,7 +839,7 @@ func (a *App) CompleteSwitchWithOAuth(c request.CTX, service string, userData io
 		return nil, model.NewAppError("CompleteSwitchWithOAuth", "api.user.complete_switch_with_oauth.blank_email.app_error", nil, "", http.StatusBadRequest)
 	}
 
-	ssoUser, err1 := provider.GetUserFromJSON(c, userData, tokenUser)
+	ssoUser, err1 := a.getOAuthUserFromJSON(c, provider, service, userData, tokenUser)
 	if err1 != nil {
 		return nil, model.NewAppError("CompleteSwitchWithOAuth", "api.user.complete_switch_with_oauth.parse.app_error",
 			map[string]any{"Service": service}, "", http.StatusBadRequest).Wrap(err1)
@@ -903,7 +1075,11 @@ func (a *App) AuthorizeOAuthUser(c request.CTX, w http.ResponseWriter, r *http.R
 
 	var userFromToken *model.User
 	if ar.IdToken != "" {
-		userFromToken, err = provider.GetUserFromIdToken(c, ar.IdToken)
+		if claimsProvider, ok := provider.(einterfaces.OAuthClaimsProvider); ok {
+			userFromToken, err = claimsProvider.GetUserFromIdTokenWithSettings(c, sso, ar.IdToken)
+		} else {
+			userFromToken, err = provider.GetUserFromIdToken(c, ar.IdToken)
+		}
 		if err != nil {
 			return nil, "", stateProps, nil, model.NewAppError("AuthorizeOAuthUser", "api.user.authorize_oauth_user.token_failed.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 		}
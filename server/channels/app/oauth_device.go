@@ -0,0 +1,226 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// CreateOAuthDeviceAuthorization starts the device flow of RFC 8628 for the given client,
+// returning the device code it polls the token endpoint with and the code its user enters
+// to approve it.
+func (a *App) CreateOAuthDeviceAuthorization(clientId, secret, scope string) (*model.OAuthDeviceAuthorizationResponse, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "api.oauth.get_access_token.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	oauthApp, appErr := a.authenticateOAuthClient(clientId, secret)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	grantedScope, ok := oauthApp.GetGrantedScope(scope)
+	if !ok {
+		return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "api.oauth.get_access_token.invalid_scope.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	authorization := &model.OAuthDeviceAuthorization{
+		ClientId:  oauthApp.Id,
+		Scope:     grantedScope,
+		Status:    model.OAuthDeviceAuthorizationStatusPending,
+		ExpiresAt: model.GetMillis() + model.OAuthDeviceCodeExpireTime*1000,
+		Interval:  model.OAuthDevicePollInterval,
+	}
+
+	deviceToken := model.NewToken(model.TokenTypeOAuthDeviceCode, string(model.ToJSON(authorization)))
+	if err := a.Srv().Store().Token().Save(deviceToken); err != nil {
+		return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "app.recover.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	userCode := model.NewOAuthUserCode()
+	userCodeToken := &model.Token{
+		Token:    model.OAuthUserCodeTokenKey(userCode),
+		CreateAt: deviceToken.CreateAt,
+		Type:     model.TokenTypeOAuthUserCode,
+		Extra:    deviceToken.Token,
+	}
+	if err := a.Srv().Store().Token().Save(userCodeToken); err != nil {
+		return nil, model.NewAppError("CreateOAuthDeviceAuthorization", "app.recover.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	verificationURI := a.GetSiteURL() + "/oauth/device"
+
+	return &model.OAuthDeviceAuthorizationResponse{
+		DeviceCode:              deviceToken.Token,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(userCode),
+		ExpiresInSeconds:        model.OAuthDeviceCodeExpireTime,
+		Interval:                authorization.Interval,
+	}, nil
+}
+
+// AuthorizeOAuthDevice approves or denies the pending authorization request of the device
+// that was given the user code.
+func (a *App) AuthorizeOAuthDevice(c request.CTX, userID, userCode string, approve bool) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return model.NewAppError("AuthorizeOAuthDevice", "api.oauth.allow_oauth.turn_off.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	userCodeToken, err := a.Srv().Store().Token().GetByToken(model.OAuthUserCodeTokenKey(userCode))
+	if err != nil || userCodeToken.Type != model.TokenTypeOAuthUserCode {
+		return model.NewAppError("AuthorizeOAuthDevice", "api.oauth.authorize_device.user_code.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// A user code can only be used once.
+	if err = a.Srv().Store().Token().Delete(userCodeToken.Token); err != nil {
+		return model.NewAppError("AuthorizeOAuthDevice", "api.oauth.authorize_device.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	deviceToken, authorization, appErr := a.getOAuthDeviceAuthorization(userCodeToken.Extra)
+	if appErr != nil || authorization.Status != model.OAuthDeviceAuthorizationStatusPending || authorization.IsExpired() {
+		return model.NewAppError("AuthorizeOAuthDevice", "api.oauth.authorize_device.user_code.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	authorization.Status = model.OAuthDeviceAuthorizationStatusDenied
+	if approve {
+		authorization.Status = model.OAuthDeviceAuthorizationStatusApproved
+		authorization.UserId = userID
+
+		// This saves the OAuth2 app as authorized, like the authorization code flow does.
+		authorizedApp := model.Preference{
+			UserId:   userID,
+			Category: model.PreferenceCategoryAuthorizedOAuthApp,
+			Name:     authorization.ClientId,
+			Value:    authorization.Scope,
+		}
+		if err = a.Srv().Store().Preference().Save(model.Preferences{authorizedApp}); err != nil {
+			return model.NewAppError("AuthorizeOAuthDevice", "api.oauth.authorize_device.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return a.updateOAuthDeviceAuthorization(deviceToken, authorization)
+}
+
+// GetOAuthAccessTokenForDeviceFlow issues a token to a device once its user approved its
+// authorization request. The errors returned while the request is pending are named after the
+// error codes of RFC 8628.
+func (a *App) GetOAuthAccessTokenForDeviceFlow(c request.CTX, clientId, secret, deviceCode string) (*model.AccessResponse, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	oauthApp, appErr := a.authenticateOAuthClient(clientId, secret)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	deviceToken, authorization, appErr := a.getOAuthDeviceAuthorization(deviceCode)
+	if appErr != nil || authorization.ClientId != oauthApp.Id {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.device_code.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if authorization.IsExpired() {
+		a.removeOAuthDeviceAuthorization(c, deviceToken)
+		return nil, newOAuthDeviceError(model.OAuthErrorExpiredToken)
+	}
+
+	switch authorization.Status {
+	case model.OAuthDeviceAuthorizationStatusDenied:
+		a.removeOAuthDeviceAuthorization(c, deviceToken)
+		return nil, newOAuthDeviceError(model.OAuthErrorAccessDenied)
+	case model.OAuthDeviceAuthorizationStatusPending:
+		now := model.GetMillis()
+		slowDown := now-authorization.LastPolledAt < int64(authorization.Interval)*1000
+		if slowDown {
+			// RFC 8628 asks clients polling too often to increase their interval.
+			authorization.Interval += model.OAuthDevicePollInterval
+		}
+		authorization.LastPolledAt = now
+		if appErr = a.updateOAuthDeviceAuthorization(deviceToken, authorization); appErr != nil {
+			if appErr.StatusCode == http.StatusConflict {
+				// Another request polled at the same time.
+				return nil, newOAuthDeviceError(model.OAuthErrorSlowDown)
+			}
+			return nil, appErr
+		}
+
+		if slowDown {
+			return nil, newOAuthDeviceError(model.OAuthErrorSlowDown)
+		}
+		return nil, newOAuthDeviceError(model.OAuthErrorAuthorizationPending)
+	}
+
+	// A device code can only be exchanged once.
+	a.removeOAuthDeviceAuthorization(c, deviceToken)
+
+	user, err := a.Srv().Store().User().Get(context.Background(), authorization.UserId)
+	if err != nil {
+		return nil, model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token.internal_user.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if user.DeleteAt != 0 {
+		return nil, newOAuthDeviceError(model.OAuthErrorAccessDenied)
+	}
+
+	accessRsp, appErr := a.issueOAuthAccessToken(c, oauthApp, user, authorization.Scope, model.DeviceCodeGrantType, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if accessRsp.IdToken, appErr = a.newOIDCIdToken(oauthApp, user, authorization.Scope, ""); appErr != nil {
+		return nil, appErr
+	}
+
+	return accessRsp, nil
+}
+
+func newOAuthDeviceError(code string) *model.AppError {
+	return model.NewAppError("GetOAuthAccessToken", "api.oauth.get_access_token."+code+".app_error", nil, "", http.StatusBadRequest)
+}
+
+func (a *App) getOAuthDeviceAuthorization(deviceCode string) (*model.Token, *model.OAuthDeviceAuthorization, *model.AppError) {
+	token, err := a.Srv().Store().Token().GetByToken(deviceCode)
+	if err != nil || token.Type != model.TokenTypeOAuthDeviceCode {
+		return nil, nil, model.NewAppError("getOAuthDeviceAuthorization", "api.oauth.get_access_token.device_code.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	var authorization *model.OAuthDeviceAuthorization
+	if err = json.Unmarshal([]byte(token.Extra), &authorization); err != nil || authorization == nil {
+		return nil, nil, model.NewAppError("getOAuthDeviceAuthorization", "api.oauth.get_access_token.device_code.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return token, authorization, nil
+}
+
+// updateOAuthDeviceAuthorization saves the changes to the authorization request, as long as no
+// other request changed it since it was read.
+func (a *App) updateOAuthDeviceAuthorization(token *model.Token, authorization *model.OAuthDeviceAuthorization) *model.AppError {
+	extra := string(model.ToJSON(authorization))
+	if err := a.Srv().Store().Token().UpdateExtra(token.Token, token.Extra, extra); err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return model.NewAppError("updateOAuthDeviceAuthorization", "api.oauth.authorize_device.conflict.app_error", nil, "", http.StatusConflict)
+		}
+		return model.NewAppError("updateOAuthDeviceAuthorization", "api.oauth.authorize_device.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	token.Extra = extra
+	return nil
+}
+
+func (a *App) removeOAuthDeviceAuthorization(c request.CTX, token *model.Token) {
+	if err := a.Srv().Store().Token().Delete(token.Token); err != nil {
+		c.Logger().Warn("unable to remove oauth device authorization", mlog.Err(err))
+	}
+}
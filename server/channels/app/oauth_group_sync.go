@@ -0,0 +1,130 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+// syncOAuthUserGroups makes the user a member of exactly the groups of the groups claim of the
+// service logged in with, when the service is configured with one. The groups are created the
+// first time a user is a member of them, and are never deleted.
+func (a *App) syncOAuthUserGroups(c request.CTX, service string, userData []byte, user, tokenUser *model.User) *model.AppError {
+	provider, appErr := a.getSSOProvider(service)
+	if appErr != nil {
+		return appErr
+	}
+
+	claimsProvider, ok := provider.(einterfaces.OAuthClaimsProvider)
+	if !ok {
+		return nil
+	}
+
+	settings, err := claimsProvider.GetSSOSettings(c, a.Config(), service)
+	if err != nil {
+		return model.NewAppError("syncOAuthUserGroups", "app.oauth.sync_groups.get_groups.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	remoteIDs, enabled, err := claimsProvider.GetUserGroups(c, settings, bytes.NewReader(userData), tokenUser)
+	if err != nil {
+		return model.NewAppError("syncOAuthUserGroups", "app.oauth.sync_groups.get_groups.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if !enabled {
+		return nil
+	}
+
+	return a.syncOIDCGroupMemberships(c, user.Id, remoteIDs)
+}
+
+// syncOAuthUserAttributeGroups synchronizes the attribute groups of the user with the claims of
+// the service logged in with, when the service maps claims.
+func (a *App) syncOAuthUserAttributeGroups(c request.CTX, service string, userData []byte, user *model.User) *model.AppError {
+	provider, appErr := a.getSSOProvider(service)
+	if appErr != nil {
+		return appErr
+	}
+
+	claimsProvider, ok := provider.(einterfaces.OAuthClaimsProvider)
+	if !ok {
+		return nil
+	}
+
+	settings, err := claimsProvider.GetSSOSettings(c, a.Config(), service)
+	if err != nil {
+		return model.NewAppError("syncOAuthUserAttributeGroups", "app.oauth.sync_attribute_groups.get_attributes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	attributes, err := claimsProvider.GetUserAttributes(c, settings, bytes.NewReader(userData))
+	if err != nil {
+		return model.NewAppError("syncOAuthUserAttributeGroups", "app.oauth.sync_attribute_groups.get_attributes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return a.SyncUserAttributeGroups(c, user.Id, attributes)
+}
+
+func (a *App) syncOIDCGroupMemberships(c request.CTX, userID string, remoteIDs []string) *model.AppError {
+	currentGroups, appErr := a.GetGroupsByUserId(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	isMember := make(map[string]bool, len(currentGroups))
+	for _, group := range currentGroups {
+		isMember[group.Id] = true
+	}
+
+	keep := make(map[string]bool, len(remoteIDs))
+	for _, remoteID := range remoteIDs {
+		if len(remoteID) > model.GroupRemoteIDMaxLength {
+			c.Logger().Warn("Skipping OpenID Connect group with a too long name", mlog.String("remote_id", remoteID))
+			continue
+		}
+
+		group, appErr := a.GetGroupByRemoteID(remoteID, model.GroupSourceOIDC)
+		if appErr != nil {
+			if appErr.StatusCode != http.StatusNotFound {
+				return appErr
+			}
+
+			group, appErr = a.CreateGroup(&model.Group{
+				DisplayName: remoteID,
+				RemoteId:    model.NewPointer(remoteID),
+				Source:      model.GroupSourceOIDC,
+			})
+			if appErr != nil {
+				return appErr
+			}
+		}
+
+		// Groups deleted by an admin aren't synchronized anymore.
+		if group.DeleteAt != 0 {
+			continue
+		}
+
+		keep[group.Id] = true
+		if isMember[group.Id] {
+			continue
+		}
+		if _, appErr = a.UpsertGroupMember(group.Id, userID); appErr != nil {
+			return appErr
+		}
+	}
+
+	for _, group := range currentGroups {
+		if group.Source != model.GroupSourceOIDC || keep[group.Id] {
+			continue
+		}
+		if _, appErr := a.DeleteGroupMember(group.Id, userID); appErr != nil {
+			return appErr
+		}
+	}
+
+	return nil
+}
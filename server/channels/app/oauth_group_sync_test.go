@@ -0,0 +1,63 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestSyncOIDCGroupMemberships(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	ldapGroup := th.CreateGroup()
+	_, appErr := th.App.UpsertGroupMember(ldapGroup.Id, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	oidcGroupNames := func(t *testing.T) []string {
+		t.Helper()
+		groups, appErr := th.App.GetGroupsByUserId(th.BasicUser.Id)
+		require.Nil(t, appErr)
+
+		var names []string
+		for _, group := range groups {
+			if group.Source == model.GroupSourceOIDC {
+				names = append(names, group.GetRemoteId())
+			}
+		}
+		return names
+	}
+
+	remoteID := "engineering-" + model.NewId()
+	otherRemoteID := "admins-" + model.NewId()
+
+	t.Run("creates the groups", func(t *testing.T) {
+		require.Nil(t, th.App.syncOIDCGroupMemberships(th.Context, th.BasicUser.Id, []string{remoteID, otherRemoteID}))
+		assert.ElementsMatch(t, []string{remoteID, otherRemoteID}, oidcGroupNames(t))
+
+		group, appErr := th.App.GetGroupByRemoteID(remoteID, model.GroupSourceOIDC)
+		require.Nil(t, appErr)
+		assert.Equal(t, remoteID, group.DisplayName)
+	})
+
+	t.Run("removes the user from the groups left", func(t *testing.T) {
+		require.Nil(t, th.App.syncOIDCGroupMemberships(th.Context, th.BasicUser.Id, []string{remoteID}))
+		assert.Equal(t, []string{remoteID}, oidcGroupNames(t))
+
+		// Other groups are left alone.
+		members, _, appErr := th.App.GetGroupMemberUsersPage(ldapGroup.Id, 0, 10, nil)
+		require.Nil(t, appErr)
+		require.Len(t, members, 1)
+	})
+
+	t.Run("no groups", func(t *testing.T) {
+		require.Nil(t, th.App.syncOIDCGroupMemberships(th.Context, th.BasicUser.Id, []string{}))
+		assert.Empty(t, oidcGroupNames(t))
+	})
+}
@@ -12,6 +12,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -680,9 +681,230 @@ func TestDeactivatedUserOAuthApp(t *testing.T) {
 	_, appErr := th.App.UpdateActive(th.Context, th.BasicUser, false)
 	require.Nil(t, appErr)
 
-	resp, accErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oapp.Id, model.AccessTokenGrantType, oapp.CallbackUrls[0], code, oapp.ClientSecret, "")
+	resp, accErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oapp.Id, model.AccessTokenGrantType, oapp.CallbackUrls[0], code, oapp.ClientSecret, "", "")
 	assert.Nil(t, resp)
 	require.NotNil(t, accErr, "Should not get access token")
 	require.Equal(t, http.StatusBadRequest, accErr.StatusCode)
 	assert.Equal(t, "api.oauth.get_access_token.expired_code.app_error", accErr.Id)
 }
+
+func TestOAuthPKCE(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oapp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "fakeoauthapp" + model.NewRandomString(10),
+		CreatorId:    th.BasicUser2.Id,
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://nowhere.com"},
+		IsPublic:     true,
+	})
+	require.Nil(t, appErr)
+
+	// The example of RFC 7636 appendix B.
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	getCode := func(t *testing.T, challenge string) string {
+		t.Helper()
+		authRequest := &model.AuthorizeRequest{
+			ResponseType:        model.AuthCodeResponseType,
+			ClientId:            oapp.Id,
+			RedirectURI:         oapp.CallbackUrls[0],
+			State:               "123",
+			CodeChallenge:       challenge,
+			CodeChallengeMethod: model.PKCECodeChallengeMethodS256,
+		}
+		redirectURL, appErr := th.App.AllowOAuthAppAccessToUser(th.Context, th.BasicUser.Id, authRequest)
+		require.Nil(t, appErr)
+		uri, err := url.Parse(redirectURL)
+		require.NoError(t, err)
+		return uri.Query().Get("code")
+	}
+
+	t.Run("public clients must use PKCE", func(t *testing.T) {
+		redirectURL, appErr := th.App.AllowOAuthAppAccessToUser(th.Context, th.BasicUser.Id, &model.AuthorizeRequest{
+			ResponseType: model.AuthCodeResponseType,
+			ClientId:     oapp.Id,
+			RedirectURI:  oapp.CallbackUrls[0],
+			State:        "123",
+		})
+		require.Nil(t, appErr)
+		assert.Contains(t, redirectURL, "error=invalid_request")
+	})
+
+	t.Run("invalid code verifier", func(t *testing.T) {
+		code := getCode(t, challenge)
+		require.NotEmpty(t, code)
+
+		_, appErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oapp.Id, model.AccessTokenGrantType, oapp.CallbackUrls[0], code, "", "", challenge)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.code_verifier.app_error", appErr.Id)
+	})
+
+	t.Run("valid code verifier without secret", func(t *testing.T) {
+		code := getCode(t, challenge)
+		require.NotEmpty(t, code)
+
+		rsp, appErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oapp.Id, model.AccessTokenGrantType, oapp.CallbackUrls[0], code, "", "", verifier)
+		require.Nil(t, appErr)
+		assert.NotEmpty(t, rsp.AccessToken)
+		assert.NotEmpty(t, rsp.RefreshToken)
+	})
+}
+
+func TestGetOAuthAccessTokenForClientCredentials(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	bot, appErr := th.App.CreateBot(th.Context, &model.Bot{
+		Username: "bot" + model.NewRandomString(10),
+		OwnerId:  th.BasicUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	oapp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "fakeoauthapp" + model.NewRandomString(10),
+		CreatorId:    th.BasicUser.Id,
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://nowhere.com"},
+		BotUserId:    bot.UserId,
+		Scopes:       model.StringArray{"channel:" + th.BasicChannel.Id},
+	})
+	require.Nil(t, appErr)
+
+	t.Run("invalid secret", func(t *testing.T) {
+		_, appErr := th.App.GetOAuthAccessTokenForClientCredentials(th.Context, oapp.Id, "junk", "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.credentials.app_error", appErr.Id)
+	})
+
+	t.Run("scope not allowed", func(t *testing.T) {
+		_, appErr := th.App.GetOAuthAccessTokenForClientCredentials(th.Context, oapp.Id, oapp.ClientSecret, "channel:"+model.NewId())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.invalid_scope.app_error", appErr.Id)
+	})
+
+	t.Run("issues a scoped bot session", func(t *testing.T) {
+		rsp, appErr := th.App.GetOAuthAccessTokenForClientCredentials(th.Context, oapp.Id, oapp.ClientSecret, "")
+		require.Nil(t, appErr)
+		assert.Empty(t, rsp.RefreshToken)
+		assert.Equal(t, "channel:"+th.BasicChannel.Id, rsp.Scope)
+
+		session, appErr := th.App.GetSession(rsp.AccessToken)
+		require.Nil(t, appErr)
+		assert.Equal(t, bot.UserId, session.UserId)
+		assert.True(t, session.IsBotUser())
+		assert.True(t, session.GetUserAccessTokenScopes().AllowsChannel(th.BasicChannel.Id, ""))
+		assert.False(t, session.GetUserAccessTokenScopes().AllowsChannel(model.NewId(), ""))
+
+		// Authenticating again keeps the previous session, which other instances of the client may use.
+		rsp2, appErr := th.App.GetOAuthAccessTokenForClientCredentials(th.Context, oapp.Id, oapp.ClientSecret, "")
+		require.Nil(t, appErr)
+		assert.NotEqual(t, rsp.AccessToken, rsp2.AccessToken)
+		_, appErr = th.App.GetSession(rsp.AccessToken)
+		assert.Nil(t, appErr)
+	})
+
+	t.Run("app without bot", func(t *testing.T) {
+		otherApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+			Name:         "fakeoauthapp" + model.NewRandomString(10),
+			CreatorId:    th.BasicUser.Id,
+			Homepage:     "https://nowhere.com",
+			CallbackUrls: []string{"https://nowhere.com"},
+		})
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetOAuthAccessTokenForClientCredentials(th.Context, otherApp.Id, otherApp.ClientSecret, "")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.unauthorized_client.app_error", appErr.Id)
+	})
+}
+
+func TestOAuthDeviceFlow(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oapp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "fakeoauthapp" + model.NewRandomString(10),
+		CreatorId:    th.BasicUser2.Id,
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://nowhere.com"},
+		IsPublic:     true,
+	})
+	require.Nil(t, appErr)
+
+	t.Run("approved", func(t *testing.T) {
+		deviceRsp, appErr := th.App.CreateOAuthDeviceAuthorization(oapp.Id, "", "")
+		require.Nil(t, appErr)
+		assert.Equal(t, th.App.GetSiteURL()+"/oauth/device", deviceRsp.VerificationURI)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceFlow(th.Context, oapp.Id, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.authorization_pending.app_error", appErr.Id)
+
+		// Polling again right away is too often.
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceFlow(th.Context, oapp.Id, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.slow_down.app_error", appErr.Id)
+
+		appErr = th.App.AuthorizeOAuthDevice(th.Context, th.BasicUser.Id, strings.ToLower(deviceRsp.UserCode), true)
+		require.Nil(t, appErr)
+
+		// The user code can only be used once.
+		appErr = th.App.AuthorizeOAuthDevice(th.Context, th.BasicUser.Id, deviceRsp.UserCode, true)
+		require.NotNil(t, appErr)
+
+		rsp, appErr := th.App.GetOAuthAccessTokenForDeviceFlow(th.Context, oapp.Id, "", deviceRsp.DeviceCode)
+		require.Nil(t, appErr)
+		assert.NotEmpty(t, rsp.RefreshToken)
+
+		session, appErr := th.App.GetSession(rsp.AccessToken)
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicUser.Id, session.UserId)
+
+		// The device code can only be exchanged once.
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceFlow(th.Context, oapp.Id, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.device_code.app_error", appErr.Id)
+	})
+
+	t.Run("denied", func(t *testing.T) {
+		deviceRsp, appErr := th.App.CreateOAuthDeviceAuthorization(oapp.Id, "", "")
+		require.Nil(t, appErr)
+
+		appErr = th.App.AuthorizeOAuthDevice(th.Context, th.BasicUser.Id, deviceRsp.UserCode, false)
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceFlow(th.Context, oapp.Id, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.access_denied.app_error", appErr.Id)
+	})
+
+	t.Run("other client", func(t *testing.T) {
+		deviceRsp, appErr := th.App.CreateOAuthDeviceAuthorization(oapp.Id, "", "")
+		require.Nil(t, appErr)
+
+		otherApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+			Name:         "fakeoauthapp" + model.NewRandomString(10),
+			CreatorId:    th.BasicUser2.Id,
+			Homepage:     "https://nowhere.com",
+			CallbackUrls: []string{"https://nowhere.com"},
+			IsPublic:     true,
+		})
+		require.Nil(t, appErr)
+
+		_, appErr = th.App.GetOAuthAccessTokenForDeviceFlow(th.Context, otherApp.Id, "", deviceRsp.DeviceCode)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.get_access_token.device_code.app_error", appErr.Id)
+	})
+}
@@ -0,0 +1,504 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthopenid
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/einterfaces"
+)
+
+const (
+	// metadataCacheTime is how long the discovery document of an identity provider is cached.
+	metadataCacheTime = time.Hour
+	// keySetRefreshInterval limits how often the keys of an identity provider are fetched again
+	// when an ID token is signed with an unknown key.
+	keySetRefreshInterval = time.Minute
+	// idTokenLeeway is the clock skew tolerated when validating the times of ID tokens.
+	idTokenLeeway = time.Minute
+
+	// idTokenGroupsProp holds the groups read from the ID token on the user returned by
+	// GetUserFromIdToken, which is never saved.
+	idTokenGroupsProp = "openid_id_token_groups"
+)
+
+// OpenIDProvider logs users in with any OpenID Connect identity provider. The endpoints of the
+// identity provider are read from its discovery document, and the fields of users from the
+// claims configured in the settings of the service.
+type OpenIDProvider struct {
+	client *http.Client
+	cache  *openIDCache
+}
+
+// openIDCache is shared by the copies of the provider bound to an HTTP client.
+type openIDCache struct {
+	mutex    sync.Mutex
+	metadata map[string]*cachedMetadata
+	keySets  map[string]*cachedKeySet
+}
+
+type cachedMetadata struct {
+	metadata  *model.OIDCProviderMetadata
+	fetchedAt time.Time
+}
+
+type cachedKeySet struct {
+	keySet    *model.JSONWebKeySet
+	fetchedAt time.Time
+}
+
+func init() {
+	// The app binds the provider to its HTTP client with WithHTTPClient before using it.
+	einterfaces.RegisterOAuthProvider(model.ServiceOpenid, NewOpenIDProvider(nil))
+}
+
+func NewOpenIDProvider(client *http.Client) *OpenIDProvider {
+	return &OpenIDProvider{
+		client: client,
+		cache: &openIDCache{
+			metadata: make(map[string]*cachedMetadata),
+			keySets:  make(map[string]*cachedKeySet),
+		},
+	}
+}
+
+// WithHTTPClient returns a copy of the provider making its requests with the given client,
+// which shares the cached discovery documents and keys of the provider.
+func (p *OpenIDProvider) WithHTTPClient(client *http.Client) einterfaces.OAuthProvider {
+	return &OpenIDProvider{
+		client: client,
+		cache:  p.cache,
+	}
+}
+
+func (p *OpenIDProvider) GetSSOSettings(_ request.CTX, config *model.Config, service string) (*model.SSOSettings, error) {
+	settings := config.GetSSOService(service)
+	if settings == nil {
+		return nil, fmt.Errorf("unsupported service %q", service)
+	}
+
+	if settings.DiscoveryEndpoint == nil || *settings.DiscoveryEndpoint == "" {
+		return settings, nil
+	}
+
+	metadata, err := p.getMetadata(*settings.DiscoveryEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	// The endpoints of the discovery document take precedence over the configured ones.
+	discovered := *settings
+	if metadata.AuthorizationEndpoint != "" {
+		discovered.AuthEndpoint = model.NewPointer(metadata.AuthorizationEndpoint)
+	}
+	if metadata.TokenEndpoint != "" {
+		discovered.TokenEndpoint = model.NewPointer(metadata.TokenEndpoint)
+	}
+	if metadata.UserinfoEndpoint != "" {
+		discovered.UserAPIEndpoint = model.NewPointer(metadata.UserinfoEndpoint)
+	}
+
+	return &discovered, nil
+}
+
+func (p *OpenIDProvider) GetUserFromJSON(c request.CTX, data io.Reader, tokenUser *model.User) (*model.User, error) {
+	return p.GetUserFromJSONWithSettings(c, &model.SSOSettings{}, data, tokenUser)
+}
+
+// GetUserFromJSONWithSettings reads the user from the claims of the user info endpoint, completed
+// with the claims of the ID token when there is one.
+func (p *OpenIDProvider) GetUserFromJSONWithSettings(c request.CTX, settings *model.SSOSettings, data io.Reader, tokenUser *model.User) (*model.User, error) {
+	claims, err := claimsFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	user := userFromClaims(settings, claims)
+
+	if tokenUser != nil {
+		if tokenUser.AuthData != nil && *tokenUser.AuthData != "" && *user.AuthData != "" && *tokenUser.AuthData != *user.AuthData {
+			return nil, errors.New("the subject of the user info doesn't match the one of the id token")
+		}
+		if *user.AuthData == "" && tokenUser.AuthData != nil {
+			user.AuthData = model.NewPointer(*tokenUser.AuthData)
+		}
+		if user.Email == "" {
+			user.Email = tokenUser.Email
+		}
+		if user.Username == "" {
+			user.Username = tokenUser.Username
+		}
+		if user.FirstName == "" {
+			user.FirstName = tokenUser.FirstName
+		}
+		if user.LastName == "" {
+			user.LastName = tokenUser.LastName
+		}
+	}
+
+	if *user.AuthData == "" {
+		return nil, errors.New("user auth data should not be empty")
+	}
+
+	if user.Email == "" {
+		return nil, errors.New("user e-mail should not be empty")
+	}
+	user.Email = strings.ToLower(user.Email)
+
+	if user.Username == "" {
+		user.Username = strings.Split(user.Email, "@")[0]
+	}
+	user.Username = model.CleanUsername(c.Logger(), user.Username)
+
+	return user, nil
+}
+
+func (p *OpenIDProvider) GetUserFromIdToken(_ request.CTX, idToken string) (*model.User, error) {
+	return nil, nil
+}
+
+// GetUserFromIdTokenWithSettings validates the ID token against the keys of the identity provider
+// and reads the user from its claims. ID tokens are ignored without a discovery endpoint, since
+// neither the issuer nor the keys are known then.
+func (p *OpenIDProvider) GetUserFromIdTokenWithSettings(_ request.CTX, settings *model.SSOSettings, idToken string) (*model.User, error) {
+	if settings.DiscoveryEndpoint == nil || *settings.DiscoveryEndpoint == "" {
+		return nil, nil
+	}
+
+	metadata, err := p.getMetadata(*settings.DiscoveryEndpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	if metadata.JWKSURI == "" {
+		return nil, errors.New("the discovery document has no jwks_uri")
+	}
+
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (any, error) {
+		keyId, _ := token.Header["kid"].(string)
+		return p.getKey(metadata.JWKSURI, keyId)
+	},
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}),
+		jwt.WithIssuer(metadata.Issuer),
+		jwt.WithAudience(model.SafeDereference(settings.Id)),
+		jwt.WithExpirationRequired(),
+		jwt.WithLeeway(idTokenLeeway),
+		jwt.WithJSONNumber(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("invalid id token: %w", err)
+	}
+
+	user := userFromClaims(settings, claims)
+	if groups, ok := readGroups(settings, claims); ok {
+		groupsJSON, err := json.Marshal(groups)
+		if err != nil {
+			return nil, err
+		}
+		user.SetProp(idTokenGroupsProp, string(groupsJSON))
+	}
+
+	return user, nil
+}
+
+// GetUserGroups reads the groups of the user from the groups claim of the user info, or else
+// of the ID token.
+func (p *OpenIDProvider) GetUserGroups(_ request.CTX, settings *model.SSOSettings, data io.Reader, tokenUser *model.User) ([]string, bool, error) {
+	if settings.GroupsClaim == nil || *settings.GroupsClaim == "" {
+		return nil, false, nil
+	}
+
+	claims, err := claimsFromJSON(data)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if groups, ok := readGroups(settings, claims); ok {
+		return groups, true, nil
+	}
+
+	if tokenUser != nil {
+		if groupsJSON, ok := tokenUser.GetProp(idTokenGroupsProp); ok {
+			var groups []string
+			if err := json.Unmarshal([]byte(groupsJSON), &groups); err != nil {
+				return nil, false, err
+			}
+			return groups, true, nil
+		}
+	}
+
+	// Identity providers leave the claim out when the user has no groups.
+	return []string{}, true, nil
+}
+
+// GetUserAttributes reads the claims of the user from the user info. Claims nested in objects
+// are named by their path, separated by dots, and claims holding arrays have one value per item.
+func (p *OpenIDProvider) GetUserAttributes(_ request.CTX, _ *model.SSOSettings, data io.Reader) (map[string][]string, error) {
+	claims, err := claimsFromJSON(data)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes := make(map[string][]string)
+	addClaimAttributes(attributes, "", claims, 0)
+
+	return attributes, nil
+}
+
+// maxClaimDepth is the maximum depth of the objects the attributes of users are read from.
+const maxClaimDepth = 5
+
+func addClaimAttributes(attributes map[string][]string, prefix string, claims map[string]any, depth int) {
+	for name, value := range claims {
+		if prefix != "" {
+			name = prefix + "." + name
+		}
+
+		switch v := value.(type) {
+		case map[string]any:
+			if depth < maxClaimDepth {
+				addClaimAttributes(attributes, name, v, depth+1)
+			}
+		case []any:
+			for _, item := range v {
+				if s, ok := claimString(item); ok && s != "" {
+					attributes[name] = append(attributes[name], s)
+				}
+			}
+		default:
+			if s, ok := claimString(v); ok && s != "" {
+				attributes[name] = append(attributes[name], s)
+			}
+		}
+	}
+}
+
+func (p *OpenIDProvider) IsSameUser(_ request.CTX, dbUser, oauthUser *model.User) bool {
+	return model.SafeDereference(dbUser.AuthData) == model.SafeDereference(oauthUser.AuthData)
+}
+
+func (p *OpenIDProvider) getMetadata(discoveryEndpoint string) (*model.OIDCProviderMetadata, error) {
+	p.cache.mutex.Lock()
+	cached, ok := p.cache.metadata[discoveryEndpoint]
+	p.cache.mutex.Unlock()
+	if ok && time.Since(cached.fetchedAt) < metadataCacheTime {
+		return cached.metadata, nil
+	}
+
+	var metadata model.OIDCProviderMetadata
+	if err := p.getJSON(discoveryEndpoint, &metadata); err != nil {
+		return nil, fmt.Errorf("failed to get the discovery document: %w", err)
+	}
+
+	if metadata.Issuer == "" {
+		return nil, errors.New("the discovery document has no issuer")
+	}
+
+	p.cache.mutex.Lock()
+	p.cache.metadata[discoveryEndpoint] = &cachedMetadata{metadata: &metadata, fetchedAt: time.Now()}
+	p.cache.mutex.Unlock()
+
+	return &metadata, nil
+}
+
+// getKey returns the key an ID token is signed with. The keys are fetched again when the key
+// isn't known, since identity providers rotate their keys.
+func (p *OpenIDProvider) getKey(jwksURI, keyId string) (any, error) {
+	p.cache.mutex.Lock()
+	cached, ok := p.cache.keySets[jwksURI]
+	p.cache.mutex.Unlock()
+
+	if ok {
+		if key := findKey(cached.keySet, keyId); key != nil {
+			return key.PublicKey()
+		}
+		if time.Since(cached.fetchedAt) < keySetRefreshInterval {
+			return nil, fmt.Errorf("unknown signing key %q", keyId)
+		}
+	}
+
+	var keySet model.JSONWebKeySet
+	if err := p.getJSON(jwksURI, &keySet); err != nil {
+		return nil, fmt.Errorf("failed to get the signing keys: %w", err)
+	}
+
+	p.cache.mutex.Lock()
+	p.cache.keySets[jwksURI] = &cachedKeySet{keySet: &keySet, fetchedAt: time.Now()}
+	p.cache.mutex.Unlock()
+
+	if key := findKey(&keySet, keyId); key != nil {
+		return key.PublicKey()
+	}
+
+	return nil, fmt.Errorf("unknown signing key %q", keyId)
+}
+
+func (p *OpenIDProvider) getJSON(url string, v any) error {
+	if p.client == nil {
+		return errors.New("the provider isn't bound to an HTTP client")
+	}
+
+	req, err := http.NewRequest(http.MethodGet, url, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unexpected status code %d", resp.StatusCode)
+	}
+
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// findKey returns the signing key with the given id, or the only signing key when the ID token
+// doesn't name one.
+func findKey(keySet *model.JSONWebKeySet, keyId string) *model.JSONWebKey {
+	var signingKeys []*model.JSONWebKey
+	for i := range keySet.Keys {
+		key := &keySet.Keys[i]
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+		if keyId != "" && key.KeyId == keyId {
+			return key
+		}
+		signingKeys = append(signingKeys, key)
+	}
+
+	if keyId == "" && len(signingKeys) == 1 {
+		return signingKeys[0]
+	}
+
+	return nil
+}
+
+func claimsFromJSON(data io.Reader) (map[string]any, error) {
+	decoder := json.NewDecoder(data)
+	decoder.UseNumber()
+
+	var claims map[string]any
+	if err := decoder.Decode(&claims); err != nil {
+		return nil, err
+	}
+	if claims == nil {
+		return nil, errors.New("no claims about the user")
+	}
+
+	return claims, nil
+}
+
+func userFromClaims(settings *model.SSOSettings, claims map[string]any) *model.User {
+	user := &model.User{
+		Username:  readString(claims, claimName(settings.UsernameClaim, model.OpenidSettingsDefaultUsernameClaim)),
+		Email:     readString(claims, claimName(settings.EmailClaim, model.OpenidSettingsDefaultEmailClaim)),
+		FirstName: readString(claims, claimName(settings.FirstNameClaim, model.OpenidSettingsDefaultFirstNameClaim)),
+		LastName:  readString(claims, claimName(settings.LastNameClaim, model.OpenidSettingsDefaultLastNameClaim)),
+		AuthData:  model.NewPointer(readString(claims, claimName(settings.AuthDataClaim, model.OpenidSettingsDefaultAuthDataClaim))),
+	}
+
+	return user
+}
+
+// claimName returns the configured claim, or the default one for services whose settings
+// don't configure claims.
+func claimName(setting *string, defaultClaim string) string {
+	if setting == nil || *setting == "" {
+		return defaultClaim
+	}
+	return *setting
+}
+
+// readClaim returns the value of a claim. Claims nested in objects are named by their path,
+// separated by dots, unless a top level claim has that name.
+func readClaim(claims map[string]any, name string) (any, bool) {
+	if value, ok := claims[name]; ok {
+		return value, true
+	}
+
+	parts := strings.Split(name, ".")
+	var value any = claims
+	for _, part := range parts {
+		object, ok := value.(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		if value, ok = object[part]; !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+func readString(claims map[string]any, name string) string {
+	value, _ := readClaim(claims, name)
+	s, _ := claimString(value)
+	return s
+}
+
+// claimString returns the value of a claim holding a string, a number or a boolean.
+func claimString(value any) (string, bool) {
+	switch v := value.(type) {
+	case string:
+		return strings.TrimSpace(v), true
+	case json.Number:
+		return v.String(), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(v), true
+	}
+	return "", false
+}
+
+// readGroups returns the groups of the groups claim, which is either an array or a single
+// group.
+func readGroups(settings *model.SSOSettings, claims map[string]any) ([]string, bool) {
+	if settings.GroupsClaim == nil || *settings.GroupsClaim == "" {
+		return nil, false
+	}
+
+	value, ok := readClaim(claims, *settings.GroupsClaim)
+	if !ok {
+		return nil, false
+	}
+
+	groups := []string{}
+	switch v := value.(type) {
+	case string:
+		if v != "" {
+			groups = append(groups, v)
+		}
+	case []any:
+		for _, item := range v {
+			if group, ok := item.(string); ok && group != "" {
+				groups = append(groups, group)
+			}
+		}
+	default:
+		return nil, false
+	}
+
+	return groups, true
+}
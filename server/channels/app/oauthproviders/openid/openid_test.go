@@ -0,0 +1,398 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package oauthopenid
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const testClientId = "test-client"
+
+// testIdentityProvider is a stand-in identity provider publishing a discovery document and
+// the keys its ID tokens are signed with.
+type testIdentityProvider struct {
+	server     *httptest.Server
+	rsaKey     *model.OIDCSigningKey
+	ecKey      *ecdsa.PrivateKey
+	keyFetches int
+}
+
+func newTestIdentityProvider(t *testing.T) *testIdentityProvider {
+	t.Helper()
+
+	rsaKey, err := model.NewOIDCSigningKey()
+	require.NoError(t, err)
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	idp := &testIdentityProvider{rsaKey: rsaKey, ecKey: ecKey}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		metadata := model.NewOIDCProviderMetadata(idp.server.URL)
+		require.NoError(t, json.NewEncoder(w).Encode(metadata))
+	})
+	mux.HandleFunc("/oauth/jwks", func(w http.ResponseWriter, r *http.Request) {
+		idp.keyFetches++
+		rsaJWK, err := idp.rsaKey.PublicJSONWebKey()
+		require.NoError(t, err)
+		ecJWK := model.JSONWebKey{
+			KeyType:   "EC",
+			Use:       "sig",
+			Algorithm: "ES256",
+			KeyId:     "ec-key",
+			Curve:     "P-256",
+			X:         base64.RawURLEncoding.EncodeToString(idp.ecKey.X.Bytes()),
+			Y:         base64.RawURLEncoding.EncodeToString(idp.ecKey.Y.Bytes()),
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(model.JSONWebKeySet{Keys: []model.JSONWebKey{*rsaJWK, ecJWK}}))
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+func (idp *testIdentityProvider) settings() *model.SSOSettings {
+	settings := defaultSettings()
+	settings.Id = model.NewPointer(testClientId)
+	settings.DiscoveryEndpoint = model.NewPointer(idp.server.URL + "/.well-known/openid-configuration")
+	return settings
+}
+
+func defaultSettings() *model.SSOSettings {
+	config := &model.Config{}
+	config.SetDefaults()
+	settings := config.OpenIdSettings
+	return &settings
+}
+
+func (idp *testIdentityProvider) claims() jwt.MapClaims {
+	now := time.Now()
+	return jwt.MapClaims{
+		"iss":                idp.server.URL,
+		"aud":                testClientId,
+		"sub":                "subject-1",
+		"iat":                now.Unix(),
+		"exp":                now.Add(time.Hour).Unix(),
+		"email":              "Jane.Doe@example.com",
+		"preferred_username": "jane.doe",
+		"given_name":         "Jane",
+		"family_name":        "Doe",
+	}
+}
+
+func (idp *testIdentityProvider) signRSA(t *testing.T, claims jwt.MapClaims) string {
+	t.Helper()
+	privateKey, err := idp.rsaKey.RSAPrivateKey()
+	require.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = idp.rsaKey.Id
+	signed, err := token.SignedString(privateKey)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestGetSSOSettings(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	provider := NewOpenIDProvider(idp.server.Client())
+
+	config := &model.Config{}
+	config.SetDefaults()
+	config.OpenIdSettings.DiscoveryEndpoint = idp.settings().DiscoveryEndpoint
+
+	settings, err := provider.GetSSOSettings(request.TestContext(t), config, model.ServiceOpenid)
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL+"/oauth/authorize", *settings.AuthEndpoint)
+	assert.Equal(t, idp.server.URL+"/oauth/access_token", *settings.TokenEndpoint)
+	assert.Equal(t, idp.server.URL+"/oauth/userinfo", *settings.UserAPIEndpoint)
+
+	// The config itself isn't changed.
+	assert.Empty(t, *config.OpenIdSettings.AuthEndpoint)
+
+	_, err = provider.GetSSOSettings(request.TestContext(t), config, "unknown")
+	assert.Error(t, err)
+}
+
+func TestWithHTTPClient(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	provider := NewOpenIDProvider(nil)
+
+	config := &model.Config{}
+	config.SetDefaults()
+	config.OpenIdSettings.DiscoveryEndpoint = idp.settings().DiscoveryEndpoint
+
+	// The provider makes no request until bound to an HTTP client.
+	_, err := provider.GetSSOSettings(request.TestContext(t), config, model.ServiceOpenid)
+	require.Error(t, err)
+
+	bound := provider.WithHTTPClient(idp.server.Client())
+	settings, err := bound.GetSSOSettings(request.TestContext(t), config, model.ServiceOpenid)
+	require.NoError(t, err)
+	assert.Equal(t, idp.server.URL+"/oauth/authorize", *settings.AuthEndpoint)
+
+	// The discovery document fetched by the bound copy is cached for the provider.
+	assert.Contains(t, provider.cache.metadata, *idp.settings().DiscoveryEndpoint)
+}
+
+func TestGetUserFromIdTokenWithSettings(t *testing.T) {
+	idp := newTestIdentityProvider(t)
+	provider := NewOpenIDProvider(idp.server.Client())
+	rctx := request.TestContext(t)
+
+	t.Run("valid rsa token", func(t *testing.T) {
+		user, err := provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), idp.signRSA(t, idp.claims()))
+		require.NoError(t, err)
+		assert.Equal(t, "subject-1", *user.AuthData)
+		assert.Equal(t, "Jane.Doe@example.com", user.Email)
+		assert.Equal(t, "jane.doe", user.Username)
+		assert.Equal(t, "Jane", user.FirstName)
+		assert.Equal(t, "Doe", user.LastName)
+	})
+
+	t.Run("valid elliptic curve token", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodES256, idp.claims())
+		token.Header["kid"] = "ec-key"
+		signed, err := token.SignedString(idp.ecKey)
+		require.NoError(t, err)
+
+		user, err := provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), signed)
+		require.NoError(t, err)
+		assert.Equal(t, "subject-1", *user.AuthData)
+	})
+
+	t.Run("wrong audience", func(t *testing.T) {
+		claims := idp.claims()
+		claims["aud"] = "another-client"
+		_, err := provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), idp.signRSA(t, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("wrong issuer", func(t *testing.T) {
+		claims := idp.claims()
+		claims["iss"] = "https://attacker.example.com"
+		_, err := provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), idp.signRSA(t, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		claims := idp.claims()
+		claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		_, err := provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), idp.signRSA(t, claims))
+		require.Error(t, err)
+	})
+
+	t.Run("bad signature", func(t *testing.T) {
+		otherKey, err := model.NewOIDCSigningKey()
+		require.NoError(t, err)
+		privateKey, err := otherKey.RSAPrivateKey()
+		require.NoError(t, err)
+
+		token := jwt.NewWithClaims(jwt.SigningMethodRS256, idp.claims())
+		token.Header["kid"] = idp.rsaKey.Id
+		signed, err := token.SignedString(privateKey)
+		require.NoError(t, err)
+
+		_, err = provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), signed)
+		require.Error(t, err)
+	})
+
+	t.Run("unsigned", func(t *testing.T) {
+		token := jwt.NewWithClaims(jwt.SigningMethodNone, idp.claims())
+		signed, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+		require.NoError(t, err)
+
+		_, err = provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), signed)
+		require.Error(t, err)
+	})
+
+	t.Run("rotated key", func(t *testing.T) {
+		rotatedKey, err := model.NewOIDCSigningKey()
+		require.NoError(t, err)
+		idp.rsaKey = rotatedKey
+
+		// The cached keys were fetched less than a minute ago.
+		fetches := idp.keyFetches
+		_, err = provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), idp.signRSA(t, idp.claims()))
+		require.Error(t, err)
+		assert.Equal(t, fetches, idp.keyFetches)
+
+		provider.cache.keySets[idp.server.URL+"/oauth/jwks"].fetchedAt = time.Now().Add(-keySetRefreshInterval)
+		user, err := provider.GetUserFromIdTokenWithSettings(rctx, idp.settings(), idp.signRSA(t, idp.claims()))
+		require.NoError(t, err)
+		assert.Equal(t, "subject-1", *user.AuthData)
+		assert.Equal(t, fetches+1, idp.keyFetches)
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		settings := idp.settings()
+		settings.GroupsClaim = model.NewPointer("groups")
+		claims := idp.claims()
+		claims["groups"] = []string{"engineering", "admins"}
+
+		tokenUser, err := provider.GetUserFromIdTokenWithSettings(rctx, settings, idp.signRSA(t, claims))
+		require.NoError(t, err)
+
+		// The user info doesn't include the groups.
+		groups, enabled, err := provider.GetUserGroups(rctx, settings, strings.NewReader(`{"sub": "subject-1"}`), tokenUser)
+		require.NoError(t, err)
+		assert.True(t, enabled)
+		assert.Equal(t, []string{"engineering", "admins"}, groups)
+	})
+
+	t.Run("without a discovery endpoint", func(t *testing.T) {
+		settings := idp.settings()
+		settings.DiscoveryEndpoint = model.NewPointer("")
+		user, err := provider.GetUserFromIdTokenWithSettings(rctx, settings, idp.signRSA(t, idp.claims()))
+		require.NoError(t, err)
+		assert.Nil(t, user)
+	})
+}
+
+func TestGetUserFromJSONWithSettings(t *testing.T) {
+	provider := NewOpenIDProvider(http.DefaultClient)
+	rctx := request.TestContext(t)
+
+	settings := defaultSettings()
+
+	t.Run("default claims", func(t *testing.T) {
+		user, err := provider.GetUserFromJSONWithSettings(rctx, settings, strings.NewReader(`{
+			"sub": "subject-1",
+			"email": "Jane.Doe@example.com",
+			"preferred_username": "Jane.Doe",
+			"given_name": "Jane",
+			"family_name": "Doe"
+		}`), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "subject-1", *user.AuthData)
+		assert.Equal(t, "jane.doe@example.com", user.Email)
+		assert.Equal(t, "jane.doe", user.Username)
+		assert.Equal(t, "Jane", user.FirstName)
+		assert.Equal(t, "Doe", user.LastName)
+	})
+
+	t.Run("mapped claims", func(t *testing.T) {
+		mapped := *settings
+		mapped.UsernameClaim = model.NewPointer("login")
+		mapped.EmailClaim = model.NewPointer("mail")
+		mapped.FirstNameClaim = model.NewPointer("name.first")
+		mapped.LastNameClaim = model.NewPointer("name.last")
+		mapped.AuthDataClaim = model.NewPointer("employee_id")
+
+		user, err := provider.GetUserFromJSONWithSettings(rctx, &mapped, strings.NewReader(`{
+			"sub": "subject-1",
+			"employee_id": 1234,
+			"mail": "jdoe@example.com",
+			"login": "jdoe",
+			"name": {"first": "Jane", "last": "Doe"}
+		}`), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "1234", *user.AuthData)
+		assert.Equal(t, "jdoe@example.com", user.Email)
+		assert.Equal(t, "jdoe", user.Username)
+		assert.Equal(t, "Jane", user.FirstName)
+		assert.Equal(t, "Doe", user.LastName)
+	})
+
+	t.Run("username from the email", func(t *testing.T) {
+		user, err := provider.GetUserFromJSONWithSettings(rctx, settings, strings.NewReader(`{"sub": "subject-1", "email": "jdoe@example.com"}`), nil)
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe", user.Username)
+	})
+
+	t.Run("completed by the id token", func(t *testing.T) {
+		tokenUser := &model.User{AuthData: model.NewPointer("subject-1"), Email: "jdoe@example.com", FirstName: "Jane"}
+		user, err := provider.GetUserFromJSONWithSettings(rctx, settings, strings.NewReader(`{"sub": "subject-1", "family_name": "Doe"}`), tokenUser)
+		require.NoError(t, err)
+		assert.Equal(t, "jdoe@example.com", user.Email)
+		assert.Equal(t, "Jane", user.FirstName)
+		assert.Equal(t, "Doe", user.LastName)
+	})
+
+	t.Run("subject mismatch", func(t *testing.T) {
+		tokenUser := &model.User{AuthData: model.NewPointer("subject-2")}
+		_, err := provider.GetUserFromJSONWithSettings(rctx, settings, strings.NewReader(`{"sub": "subject-1", "email": "jdoe@example.com"}`), tokenUser)
+		require.Error(t, err)
+	})
+
+	t.Run("missing claims", func(t *testing.T) {
+		_, err := provider.GetUserFromJSONWithSettings(rctx, settings, strings.NewReader(`{"email": "jdoe@example.com"}`), nil)
+		require.Error(t, err)
+
+		_, err = provider.GetUserFromJSONWithSettings(rctx, settings, strings.NewReader(`{"sub": "subject-1"}`), nil)
+		require.Error(t, err)
+	})
+}
+
+func TestGetUserGroups(t *testing.T) {
+	provider := NewOpenIDProvider(http.DefaultClient)
+	rctx := request.TestContext(t)
+
+	settings := defaultSettings()
+
+	_, enabled, err := provider.GetUserGroups(rctx, settings, strings.NewReader(`{"groups": ["a"]}`), nil)
+	require.NoError(t, err)
+	assert.False(t, enabled)
+
+	settings.GroupsClaim = model.NewPointer("groups")
+
+	groups, enabled, err := provider.GetUserGroups(rctx, settings, strings.NewReader(`{"groups": ["a", "b"]}`), nil)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Equal(t, []string{"a", "b"}, groups)
+
+	groups, _, err = provider.GetUserGroups(rctx, settings, strings.NewReader(`{"groups": "a"}`), nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a"}, groups)
+
+	groups, enabled, err = provider.GetUserGroups(rctx, settings, strings.NewReader(`{"sub": "subject-1"}`), nil)
+	require.NoError(t, err)
+	assert.True(t, enabled)
+	assert.Empty(t, groups)
+}
+
+func TestGetUserAttributes(t *testing.T) {
+	provider := NewOpenIDProvider(http.DefaultClient)
+	rctx := request.TestContext(t)
+
+	data := `{
+		"department": "Engineering",
+		"roles": ["developer", "", "reviewer"],
+		"level": 3,
+		"contractor": false,
+		"org": {"division": "R&D"},
+		"address": {"country": "FR"}
+	}`
+
+	attributes, err := provider.GetUserAttributes(rctx, defaultSettings(), strings.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"department":      {"Engineering"},
+		"roles":           {"developer", "reviewer"},
+		"level":           {"3"},
+		"contractor":      {"false"},
+		"org.division":    {"R&D"},
+		"address.country": {"FR"},
+	}, attributes)
+
+	_, err = provider.GetUserAttributes(rctx, defaultSettings(), strings.NewReader(`not json`))
+	require.Error(t, err)
+}
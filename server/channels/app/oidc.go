@@ -0,0 +1,274 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+// GetOIDCProviderMetadata returns the discovery document of the OpenID Connect provider, whose
+// issuer is the site URL.
+func (a *App) GetOIDCProviderMetadata() (*model.OIDCProviderMetadata, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOIDCProviderMetadata", "api.oauth.oidc.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	return model.NewOIDCProviderMetadata(a.GetSiteURL()), nil
+}
+
+// GetOIDCJSONWebKeySet returns the public keys ID tokens are signed with, including the keys
+// retired recently.
+func (a *App) GetOIDCJSONWebKeySet() (*model.JSONWebKeySet, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOIDCJSONWebKeySet", "api.oauth.oidc.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	keys, err := a.getOIDCSigningKeys()
+	if err != nil {
+		return nil, model.NewAppError("GetOIDCJSONWebKeySet", "app.oidc.get_signing_keys.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	keySet := &model.JSONWebKeySet{Keys: []model.JSONWebKey{}}
+	for _, key := range keys {
+		if key.IsExpired() {
+			continue
+		}
+
+		jwk, err := key.PublicJSONWebKey()
+		if err != nil {
+			return nil, model.NewAppError("GetOIDCJSONWebKeySet", "app.oidc.get_signing_keys.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		keySet.Keys = append(keySet.Keys, *jwk)
+	}
+
+	return keySet, nil
+}
+
+// RotateOIDCSigningKeys is called periodically from the job server to replace the signing key
+// of ID tokens once it has been used for long enough, and to stop publishing the keys retired
+// long enough ago.
+func (a *App) RotateOIDCSigningKeys(rctx request.CTX) error {
+	keys, err := a.getOIDCSigningKeys()
+	if err != nil {
+		return model.NewAppError("RotateOIDCSigningKeys", "app.oidc.get_signing_keys.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	// No key is created before an ID token is first issued.
+	if len(keys) == 0 {
+		return nil
+	}
+
+	rotated := make([]*model.OIDCSigningKey, 0, len(keys)+1)
+	needsKey := true
+	for _, key := range keys {
+		if key.IsExpired() {
+			rctx.Logger().Info("Removing expired OpenID Connect signing key", mlog.String("key_id", key.Id))
+			continue
+		}
+
+		if !key.IsRetired() {
+			if !key.NeedsRotation() {
+				needsKey = false
+			} else {
+				rctx.Logger().Info("Retiring OpenID Connect signing key", mlog.String("key_id", key.Id))
+				key.RetireAt = model.GetMillis()
+			}
+		}
+		rotated = append(rotated, key)
+	}
+
+	if needsKey {
+		key, err := model.NewOIDCSigningKey()
+		if err != nil {
+			return model.NewAppError("RotateOIDCSigningKeys", "app.oidc.save_signing_keys.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		rotated = append(rotated, key)
+	}
+
+	if len(rotated) == len(keys) && !needsKey {
+		return nil
+	}
+
+	value, err := json.Marshal(rotated)
+	if err != nil {
+		return model.NewAppError("RotateOIDCSigningKeys", "app.oidc.save_signing_keys.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().System().SaveOrUpdate(&model.System{Name: model.SystemOIDCSigningKeysKey, Value: string(value)}); err != nil {
+		return model.NewAppError("RotateOIDCSigningKeys", "app.oidc.save_signing_keys.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return nil
+}
+
+// GetOIDCUserInfo returns the claims about the user of an OAuth session allowed by the scopes
+// granted to its app.
+func (a *App) GetOIDCUserInfo(session *model.Session) (*model.OIDCUserInfo, *model.AppError) {
+	if !*a.Config().ServiceSettings.EnableOAuthServiceProvider {
+		return nil, model.NewAppError("GetOIDCUserInfo", "api.oauth.oidc.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	accessData, err := a.Srv().Store().OAuth().GetAccessData(session.Token)
+	if err != nil || !model.HasOIDCScope(accessData.Scope, model.OIDCScopeOpenID) {
+		return nil, model.NewAppError("GetOIDCUserInfo", "api.oauth.oidc.userinfo.insufficient_scope.app_error", nil, "", http.StatusForbidden)
+	}
+
+	user, appErr := a.GetUser(session.UserId)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.newOIDCUserInfo(user, accessData.Scope)
+}
+
+func (a *App) newOIDCUserInfo(user *model.User, scope string) (*model.OIDCUserInfo, *model.AppError) {
+	var groupNames []string
+	if model.HasOIDCScope(scope, model.OIDCScopeGroups) {
+		groups, appErr := a.GetGroupsByUserId(user.Id)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		for _, group := range groups {
+			if group.DeleteAt != 0 {
+				continue
+			}
+			// Groups synchronized from LDAP only have a name when they can be mentioned.
+			if name := group.GetName(); name != "" {
+				groupNames = append(groupNames, name)
+			} else {
+				groupNames = append(groupNames, group.DisplayName)
+			}
+		}
+	}
+
+	return model.NewOIDCUserInfo(user, scope, groupNames), nil
+}
+
+// newOIDCIdToken returns the ID token issued to the app along with an access token, or an empty
+// string if the app wasn't granted the openid scope.
+func (a *App) newOIDCIdToken(app *model.OAuthApp, user *model.User, scope, nonce string) (string, *model.AppError) {
+	if !model.HasOIDCScope(scope, model.OIDCScopeOpenID) {
+		return "", nil
+	}
+
+	info, appErr := a.newOIDCUserInfo(user, scope)
+	if appErr != nil {
+		return "", appErr
+	}
+
+	// The claims about the user are merged with those about the token itself.
+	var claims jwt.MapClaims
+	infoJSON, err := json.Marshal(info)
+	if err != nil {
+		return "", model.NewAppError("newOIDCIdToken", "app.oidc.sign_id_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err = json.Unmarshal(infoJSON, &claims); err != nil {
+		return "", model.NewAppError("newOIDCIdToken", "app.oidc.sign_id_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	now := time.Now()
+	claims["iss"] = a.GetSiteURL()
+	claims["aud"] = app.Id
+	claims["azp"] = app.Id
+	claims["iat"] = now.Unix()
+	claims["exp"] = now.Add(model.OIDCIdTokenExpireTime * time.Second).Unix()
+	if nonce != "" {
+		claims["nonce"] = nonce
+	}
+
+	key, err := a.getOIDCActiveSigningKey()
+	if err != nil {
+		return "", model.NewAppError("newOIDCIdToken", "app.oidc.get_signing_keys.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	privateKey, err := key.RSAPrivateKey()
+	if err != nil {
+		return "", model.NewAppError("newOIDCIdToken", "app.oidc.sign_id_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = key.Id
+
+	signed, err := token.SignedString(privateKey)
+	if err != nil {
+		return "", model.NewAppError("newOIDCIdToken", "app.oidc.sign_id_token.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return signed, nil
+}
+
+func (a *App) getOIDCSigningKeys() ([]*model.OIDCSigningKey, error) {
+	system, err := a.Srv().Store().System().GetByName(model.SystemOIDCSigningKeysKey)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if errors.As(err, &nfErr) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var keys []*model.OIDCSigningKey
+	if err := json.Unmarshal([]byte(system.Value), &keys); err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+// getOIDCActiveSigningKey returns the key ID tokens are currently signed with, creating the
+// first one if needed.
+func (a *App) getOIDCActiveSigningKey() (*model.OIDCSigningKey, error) {
+	keys, err := a.getOIDCSigningKeys()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, key := range keys {
+		if !key.IsRetired() {
+			return key, nil
+		}
+	}
+
+	if len(keys) > 0 {
+		return nil, errors.New("no active signing key")
+	}
+
+	key, err := model.NewOIDCSigningKey()
+	if err != nil {
+		return nil, err
+	}
+
+	value, err := json.Marshal([]*model.OIDCSigningKey{key})
+	if err != nil {
+		return nil, err
+	}
+
+	// If the key can't be saved, another server must have created the first key at the same
+	// time, so use that one instead.
+	if err = a.Srv().Store().System().Save(&model.System{Name: model.SystemOIDCSigningKeysKey, Value: string(value)}); err != nil {
+		keys, err = a.getOIDCSigningKeys()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			if !key.IsRetired() {
+				return key, nil
+			}
+		}
+		return nil, errors.New("no active signing key")
+	}
+
+	return key, nil
+}
@@ -0,0 +1,211 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"net/url"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestOIDCIdToken(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oapp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "fakeoauthapp" + model.NewRandomString(10),
+		CreatorId:    th.BasicUser2.Id,
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://nowhere.com"},
+	})
+	require.Nil(t, appErr)
+
+	group := th.CreateGroup()
+	_, appErr = th.App.UpsertGroupMember(group.Id, th.BasicUser.Id)
+	require.Nil(t, appErr)
+
+	getCode := func(t *testing.T, scope, nonce string) string {
+		t.Helper()
+		redirectURL, appErr := th.App.AllowOAuthAppAccessToUser(th.Context, th.BasicUser.Id, &model.AuthorizeRequest{
+			ResponseType: model.AuthCodeResponseType,
+			ClientId:     oapp.Id,
+			RedirectURI:  oapp.CallbackUrls[0],
+			State:        "123",
+			Scope:        scope,
+			Nonce:        nonce,
+		})
+		require.Nil(t, appErr)
+		uri, err := url.Parse(redirectURL)
+		require.NoError(t, err)
+		return uri.Query().Get("code")
+	}
+
+	parseIdToken := func(t *testing.T, idToken string) jwt.MapClaims {
+		t.Helper()
+		keySet, appErr := th.App.GetOIDCJSONWebKeySet()
+		require.Nil(t, appErr)
+
+		claims := jwt.MapClaims{}
+		_, err := jwt.ParseWithClaims(idToken, claims, func(token *jwt.Token) (any, error) {
+			for _, key := range keySet.Keys {
+				if key.KeyId == token.Header["kid"] {
+					return key.PublicKey()
+				}
+			}
+			return nil, jwt.ErrTokenUnverifiable
+		}, jwt.WithValidMethods([]string{model.OIDCSigningAlgorithm}), jwt.WithAudience(oapp.Id), jwt.WithIssuer(th.App.GetSiteURL()))
+		require.NoError(t, err)
+		return claims
+	}
+
+	t.Run("no id token without the openid scope", func(t *testing.T) {
+		code := getCode(t, "", "")
+		rsp, appErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oapp.Id, model.AccessTokenGrantType, oapp.CallbackUrls[0], code, oapp.ClientSecret, "", "")
+		require.Nil(t, appErr)
+		assert.Empty(t, rsp.IdToken)
+	})
+
+	t.Run("id token of the code and refresh flows", func(t *testing.T) {
+		require.Nil(t, th.App.DeauthorizeOAuthAppForUser(th.Context, th.BasicUser.Id, oapp.Id))
+
+		code := getCode(t, "openid email groups", "n-0S6_WzA2Mj")
+		rsp, appErr := th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oapp.Id, model.AccessTokenGrantType, oapp.CallbackUrls[0], code, oapp.ClientSecret, "", "")
+		require.Nil(t, appErr)
+		require.NotEmpty(t, rsp.IdToken)
+
+		claims := parseIdToken(t, rsp.IdToken)
+		assert.Equal(t, th.BasicUser.Id, claims["sub"])
+		assert.Equal(t, oapp.Id, claims["azp"])
+		assert.Equal(t, "n-0S6_WzA2Mj", claims["nonce"])
+		assert.Equal(t, th.BasicUser.Email, claims["email"])
+		assert.Equal(t, []any{*group.Name}, claims["groups"])
+		assert.NotContains(t, claims, "preferred_username")
+
+		rsp, appErr = th.App.GetOAuthAccessTokenForCodeFlow(th.Context, oapp.Id, model.RefreshTokenGrantType, "", "", oapp.ClientSecret, rsp.RefreshToken, "")
+		require.Nil(t, appErr)
+		require.NotEmpty(t, rsp.IdToken)
+
+		claims = parseIdToken(t, rsp.IdToken)
+		assert.Equal(t, th.BasicUser.Id, claims["sub"])
+		assert.NotContains(t, claims, "nonce")
+	})
+}
+
+func TestGetOIDCUserInfo(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oapp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "fakeoauthapp" + model.NewRandomString(10),
+		CreatorId:    th.BasicUser2.Id,
+		Homepage:     "https://nowhere.com",
+		Description:  "test",
+		CallbackUrls: []string{"https://nowhere.com"},
+	})
+	require.Nil(t, appErr)
+
+	getSession := func(t *testing.T, scope string) *model.Session {
+		t.Helper()
+		require.Nil(t, th.App.DeauthorizeOAuthAppForUser(th.Context, th.BasicUser.Id, oapp.Id))
+
+		session, appErr := th.App.GetOAuthAccessTokenForImplicitFlow(th.Context, th.BasicUser.Id, &model.AuthorizeRequest{
+			ResponseType: model.ImplicitResponseType,
+			ClientId:     oapp.Id,
+			RedirectURI:  oapp.CallbackUrls[0],
+			Scope:        scope,
+		})
+		require.Nil(t, appErr)
+		return session
+	}
+
+	t.Run("without the openid scope", func(t *testing.T) {
+		_, appErr := th.App.GetOIDCUserInfo(getSession(t, model.DefaultScope))
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.oidc.userinfo.insufficient_scope.app_error", appErr.Id)
+	})
+
+	t.Run("not an oauth session", func(t *testing.T) {
+		_, appErr := th.App.GetOIDCUserInfo(th.Context.Session())
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.oauth.oidc.userinfo.insufficient_scope.app_error", appErr.Id)
+	})
+
+	t.Run("with the profile scope", func(t *testing.T) {
+		info, appErr := th.App.GetOIDCUserInfo(getSession(t, "openid profile"))
+		require.Nil(t, appErr)
+		assert.Equal(t, th.BasicUser.Id, info.Subject)
+		assert.Equal(t, th.BasicUser.Username, info.PreferredUsername)
+		assert.Empty(t, info.Email)
+	})
+}
+
+func TestRotateOIDCSigningKeys(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	saveKeys := func(t *testing.T, keys []*model.OIDCSigningKey) {
+		t.Helper()
+		value, err := json.Marshal(keys)
+		require.NoError(t, err)
+		require.NoError(t, th.App.Srv().Store().System().SaveOrUpdate(&model.System{Name: model.SystemOIDCSigningKeysKey, Value: string(value)}))
+	}
+
+	// No key is created until the first ID token is signed.
+	require.NoError(t, th.App.RotateOIDCSigningKeys(th.Context))
+	keySet, appErr := th.App.GetOIDCJSONWebKeySet()
+	require.Nil(t, appErr)
+	require.Empty(t, keySet.Keys)
+
+	key, err := th.App.getOIDCActiveSigningKey()
+	require.NoError(t, err)
+
+	// A recent key isn't rotated.
+	require.NoError(t, th.App.RotateOIDCSigningKeys(th.Context))
+	active, err := th.App.getOIDCActiveSigningKey()
+	require.NoError(t, err)
+	require.Equal(t, key.Id, active.Id)
+
+	key.CreateAt = model.GetMillis() - model.OIDCSigningKeyRotationPeriod.Milliseconds() - 1
+	saveKeys(t, []*model.OIDCSigningKey{key})
+
+	require.NoError(t, th.App.RotateOIDCSigningKeys(th.Context))
+	active, err = th.App.getOIDCActiveSigningKey()
+	require.NoError(t, err)
+	require.NotEqual(t, key.Id, active.Id)
+
+	// The retired key is still published.
+	keySet, appErr = th.App.GetOIDCJSONWebKeySet()
+	require.Nil(t, appErr)
+	require.Len(t, keySet.Keys, 2)
+
+	keys, err := th.App.getOIDCSigningKeys()
+	require.NoError(t, err)
+	require.Len(t, keys, 2)
+	for _, k := range keys {
+		if k.Id == key.Id {
+			require.True(t, k.IsRetired())
+			k.RetireAt = model.GetMillis() - model.OIDCSigningKeyRetirementPeriod.Milliseconds() - 1
+		}
+	}
+	saveKeys(t, keys)
+
+	require.NoError(t, th.App.RotateOIDCSigningKeys(th.Context))
+	keySet, appErr = th.App.GetOIDCJSONWebKeySet()
+	require.Nil(t, appErr)
+	require.Len(t, keySet.Keys, 1)
+	assert.Equal(t, active.Id, keySet.Keys[0].KeyId)
+}
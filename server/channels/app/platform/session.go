@@ -157,21 +157,26 @@ func (ps *PlatformService) RevokeSessionsFromAllUsers() error {
 	return nil
 }
 
-func (ps *PlatformService) RevokeSessionsForDeviceId(c request.CTX, userID string, deviceID string, currentSessionId string) error {
+// RevokeSessionsForDeviceId revokes the other sessions of the user on the device, and returns the
+// sessions it revoked.
+func (ps *PlatformService) RevokeSessionsForDeviceId(c request.CTX, userID string, deviceID string, currentSessionId string) ([]*model.Session, error) {
 	sessions, err := ps.Store.Session().GetSessions(c, userID)
 	if err != nil {
-		return err
+		return nil, err
 	}
+	var revoked []*model.Session
 	for _, session := range sessions {
 		if session.DeviceId == deviceID && session.Id != currentSessionId {
 			c.Logger().Debug("Revoking sessionId for userId. Re-login with the same device Id", mlog.String("session_id", session.Id), mlog.String("user_id", userID))
 			if err := ps.RevokeSession(c, session); err != nil {
 				c.Logger().Warn("Could not revoke session for device", mlog.String("device_id", deviceID), mlog.Err(err))
+				continue
 			}
+			revoked = append(revoked, session)
 		}
 	}
 
-	return nil
+	return revoked, nil
 }
 
 func (ps *PlatformService) RevokeSession(c request.CTX, session *model.Session) error {
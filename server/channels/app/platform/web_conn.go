@@ -61,7 +61,10 @@ type pluginWSPostedHook struct {
 }
 
 type WebConnConfig struct {
-	WebSocket     *websocket.Conn
+	WebSocket *websocket.Conn
+	// EventStream is used instead of WebSocket for clients
+	// connected through the Server-Sent Events endpoint.
+	EventStream   *EventStream
 	Session       model.Session
 	TFunc         i18n.TranslateFunc
 	Locale        string
@@ -78,6 +81,14 @@ type WebConnConfig struct {
 	activeQueue      chan model.WebSocketMessage
 	deadQueue        []*model.WebSocketEvent
 	deadQueuePointer int
+	subscription     *webConnSubscription
+}
+
+// webConnSubscription restricts the events which are sent to a connection.
+// An empty set of event types or channels means no restriction on it.
+type webConnSubscription struct {
+	events     map[model.WebsocketEventType]struct{}
+	channelIDs map[string]struct{}
 }
 
 // WebConn represents a single websocket connection to a user.
@@ -89,6 +100,7 @@ type WebConn struct {
 	Suite            SuiteIFace
 	HookRunner       HookRunner
 	WebSocket        *websocket.Conn
+	EventStream      *EventStream
 	T                i18n.TranslateFunc
 	Locale           string
 	Sequence         int64
@@ -126,6 +138,8 @@ type WebConn struct {
 	// The X-Forwarded-For HTTP header value from the origina HTTP Upgrade request
 	xForwardedFor string
 
+	subscription atomic.Pointer[webConnSubscription]
+
 	activeChannelID                 atomic.Value
 	activeTeamID                    atomic.Value
 	activeRHSThreadChannelID        atomic.Value
@@ -152,6 +166,7 @@ type CheckConnResult struct {
 	DeadQueue        []*model.WebSocketEvent
 	DeadQueuePointer int
 	ReuseCount       int
+	subscription     *webConnSubscription
 }
 
 // PopulateWebConnConfig checks if the connection id already exists in the hub,
@@ -173,6 +188,7 @@ func (ps *PlatformService) PopulateWebConnConfig(s *model.Session, cfg *WebConnC
 		cfg.activeQueue = res.ActiveQueue
 		cfg.deadQueue = res.DeadQueue
 		cfg.deadQueuePointer = res.DeadQueuePointer
+		cfg.subscription = res.subscription
 		cfg.Active = false
 		cfg.ReuseCount = res.ReuseCount
 		// Now we get the sequence number
@@ -203,13 +219,15 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 
 	// Disable TCP_NO_DELAY for higher throughput
 	var tcpConn *net.TCPConn
-	switch conn := cfg.WebSocket.UnderlyingConn().(type) {
-	case *net.TCPConn:
-		tcpConn = conn
-	case *tls.Conn:
-		newConn, ok := conn.NetConn().(*net.TCPConn)
-		if ok {
-			tcpConn = newConn
+	if cfg.WebSocket != nil {
+		switch conn := cfg.WebSocket.UnderlyingConn().(type) {
+		case *net.TCPConn:
+			tcpConn = conn
+		case *tls.Conn:
+			newConn, ok := conn.NetConn().(*net.TCPConn)
+			if ok {
+				tcpConn = newConn
+			}
 		}
 	}
 
@@ -237,6 +255,7 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		deadQueuePointer:   cfg.deadQueuePointer,
 		Sequence:           int64(cfg.sequence),
 		WebSocket:          cfg.WebSocket,
+		EventStream:        cfg.EventStream,
 		lastUserActivityAt: model.GetMillis(),
 		UserId:             cfg.Session.UserId,
 		T:                  cfg.TFunc,
@@ -253,6 +272,7 @@ func (ps *PlatformService) NewWebConn(cfg *WebConnConfig, suite SuiteIFace, runn
 		xForwardedFor:      cfg.XForwardedFor,
 	}
 	wc.Active.Store(cfg.Active)
+	wc.subscription.Store(cfg.subscription)
 
 	wc.SetSession(&cfg.Session)
 	wc.SetSessionToken(cfg.Session.Token)
@@ -288,10 +308,19 @@ func (wc *WebConn) pluginPostedConsumer(wg *sync.WaitGroup) {
 
 // Close closes the WebConn.
 func (wc *WebConn) Close() {
-	wc.WebSocket.Close()
+	wc.closeTransport()
 	<-wc.pumpFinished
 }
 
+// closeTransport closes the underlying websocket or event stream.
+func (wc *WebConn) closeTransport() {
+	if wc.EventStream != nil {
+		wc.EventStream.close()
+		return
+	}
+	wc.WebSocket.Close()
+}
+
 // GetSessionExpiresAt returns the time at which the session expires.
 func (wc *WebConn) GetSessionExpiresAt() int64 {
 	return atomic.LoadInt64(&wc.sessionExpiresAt)
@@ -377,6 +406,56 @@ func (wc *WebConn) SetActiveThreadViewThreadChannelID(id string) {
 	wc.activeThreadViewThreadChannelID.Store(id)
 }
 
+// SetSubscription restricts the events sent to the connection to the given
+// event types and channels. Empty slices remove the respective restriction.
+func (wc *WebConn) SetSubscription(events []model.WebsocketEventType, channelIDs []string) {
+	if len(events) == 0 && len(channelIDs) == 0 {
+		wc.subscription.Store(nil)
+		return
+	}
+
+	sub := &webConnSubscription{
+		events:     make(map[model.WebsocketEventType]struct{}, len(events)),
+		channelIDs: make(map[string]struct{}, len(channelIDs)),
+	}
+	for _, event := range events {
+		sub.events[event] = struct{}{}
+	}
+	for _, channelID := range channelIDs {
+		sub.channelIDs[channelID] = struct{}{}
+	}
+	wc.subscription.Store(sub)
+}
+
+// isSubscribedTo returns whether the message matches the subscription of
+// the connection. When a set of channels is subscribed to, events which
+// are not scoped to one of those channels are filtered out.
+func (wc *WebConn) isSubscribedTo(msg *model.WebSocketEvent) bool {
+	sub := wc.subscription.Load()
+	if sub == nil {
+		return true
+	}
+
+	// Events explicitly destined to this connection are always sent.
+	if msg.GetBroadcast().ConnectionId != "" {
+		return true
+	}
+
+	if len(sub.events) > 0 {
+		if _, ok := sub.events[msg.EventType()]; !ok {
+			return false
+		}
+	}
+
+	if len(sub.channelIDs) > 0 {
+		if _, ok := sub.channelIDs[msg.GetBroadcast().ChannelId]; !ok {
+			return false
+		}
+	}
+
+	return true
+}
+
 // isSet is a helper to check if a value is unset or not.
 func (wc *WebConn) isSet(val string) bool {
 	return val != UnsetPresenceIndicator
@@ -399,6 +478,11 @@ func (wc *WebConn) SetSession(v *model.Session) {
 // Pump starts the WebConn instance. After this, the websocket
 // is ready to send/receive messages.
 func (wc *WebConn) Pump() {
+	if wc.EventStream != nil {
+		wc.eventStreamPump()
+		return
+	}
+
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
@@ -413,6 +497,27 @@ func (wc *WebConn) Pump() {
 	close(wc.endWritePump)
 	close(wc.pluginPosted)
 	wg.Wait()
+	wc.finishPump()
+}
+
+// eventStreamPump is the equivalent of Pump for connections using an
+// event stream. As there is nothing to read from the client, it only runs
+// the write pump until either side closes the stream.
+func (wc *WebConn) eventStreamPump() {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		wc.writePump()
+	}()
+
+	wc.EventStream.wait()
+	close(wc.endWritePump)
+	wg.Wait()
+	wc.finishPump()
+}
+
+func (wc *WebConn) finishPump() {
 	wc.Platform.HubUnregister(wc)
 	close(wc.pumpFinished)
 
@@ -509,7 +614,7 @@ func (wc *WebConn) writePump() {
 	defer func() {
 		ticker.Stop()
 		authTicker.Stop()
-		wc.WebSocket.Close()
+		wc.closeTransport()
 	}()
 
 	if wc.Sequence != 0 {
@@ -615,7 +720,7 @@ func (wc *WebConn) writePump() {
 
 		case <-authTicker.C:
 			if wc.GetSessionToken() == "" {
-				wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.Stringer("ip_address", wc.WebSocket.RemoteAddr()))
+				wc.Platform.logger.Debug("websocket.authTicker: did not authenticate", mlog.String("ip_address", wc.remoteAddress))
 				return
 			}
 			authTicker.Stop()
@@ -626,6 +731,10 @@ func (wc *WebConn) writePump() {
 // writeMessageBuf is a helper utility that wraps the write to the socket
 // along with setting the write deadline.
 func (wc *WebConn) writeMessageBuf(msgType int, data []byte) error {
+	if wc.EventStream != nil {
+		// The sequence has already been incremented past the message being written.
+		return wc.EventStream.write(msgType, data, fmt.Sprintf("%s:%d", wc.GetConnectionID(), wc.Sequence-1))
+	}
 	if err := wc.WebSocket.SetWriteDeadline(time.Now().Add(writeWaitTime)); err != nil {
 		return err
 	}
@@ -898,6 +1007,13 @@ func (wc *WebConn) ShouldSendEvent(msg *model.WebSocketEvent) bool {
 		return false
 	}
 
+	// Sessions of user access tokens restricted by scopes only get the events the scopes allow
+	if session := wc.GetSession(); session != nil {
+		if scopes := session.GetUserAccessTokenScopes(); len(scopes) > 0 && !wc.userAccessTokenScopesAllowEvent(scopes, msg) {
+			return false
+		}
+	}
+
 	// If the event is destined to a specific user
 	if msg.GetBroadcast().UserId != "" {
 		return wc.UserId == msg.GetBroadcast().UserId
@@ -940,6 +1056,32 @@ func (wc *WebConn) ShouldSendEvent(msg *model.WebSocketEvent) bool {
 	return true
 }
 
+// userAccessTokenScopesAllowEvent returns whether the scopes of a user access token allow the
+// event. A token restricted to some teams or channels only gets the events of those.
+func (wc *WebConn) userAccessTokenScopesAllowEvent(scopes model.UserAccessTokenScopes, msg *model.WebSocketEvent) bool {
+	if chID := msg.GetBroadcast().ChannelId; chID != "" {
+		if !scopes.AllowsPermission(model.PermissionReadChannelContent.Id) && !scopes.AllowsPermission(model.PermissionReadPublicChannel.Id) {
+			return false
+		}
+		if !scopes.RestrictsResources() {
+			return true
+		}
+
+		channel, err := wc.Platform.Store.Channel().Get(chID, true)
+		if err != nil {
+			wc.Platform.logger.Debug("webhub.shouldSendEvent: could not get the channel of the event", mlog.String("channel_id", chID), mlog.Err(err))
+			return false
+		}
+		return scopes.AllowsChannel(channel.Id, channel.TeamId)
+	}
+
+	if teamID := msg.GetBroadcast().TeamId; teamID != "" {
+		return scopes.AllowsTeam(teamID)
+	}
+
+	return !scopes.RestrictsResources()
+}
+
 func (wc *WebConn) notInChannel(val string) bool {
 	return (wc.isSet(wc.GetActiveChannelID()) && val != wc.GetActiveChannelID())
 }
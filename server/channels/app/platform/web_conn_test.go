@@ -241,3 +241,40 @@ func TestWebConnDrainDeadQueue(t *testing.T) {
 		t.Run("Overwritten First", func(t *testing.T) { run(int64(128), deadQueueSize+10) })
 	})
 }
+
+func TestWebConnIsSubscribedTo(t *testing.T) {
+	channelID := model.NewId()
+	otherChannelID := model.NewId()
+	posted := model.NewWebSocketEvent(model.WebsocketEventPosted, "", channelID, "", nil, "")
+	postedElsewhere := model.NewWebSocketEvent(model.WebsocketEventPosted, "", otherChannelID, "", nil, "")
+	typing := model.NewWebSocketEvent(model.WebsocketEventTyping, "", channelID, "", nil, "")
+	userUpdated := model.NewWebSocketEvent(model.WebsocketEventUserUpdated, "", "", "", nil, "")
+	direct := model.NewWebSocketEvent(model.WebsocketEventTyping, "", "", "", nil, "connID")
+
+	wc := &WebConn{}
+	for _, msg := range []*model.WebSocketEvent{posted, postedElsewhere, typing, userUpdated, direct} {
+		assert.True(t, wc.isSubscribedTo(msg), "unsubscribed connections receive everything")
+	}
+
+	wc.SetSubscription([]model.WebsocketEventType{model.WebsocketEventPosted}, nil)
+	assert.True(t, wc.isSubscribedTo(posted))
+	assert.True(t, wc.isSubscribedTo(postedElsewhere))
+	assert.False(t, wc.isSubscribedTo(typing))
+	assert.False(t, wc.isSubscribedTo(userUpdated))
+	assert.True(t, wc.isSubscribedTo(direct))
+
+	wc.SetSubscription(nil, []string{channelID})
+	assert.True(t, wc.isSubscribedTo(posted))
+	assert.False(t, wc.isSubscribedTo(postedElsewhere))
+	assert.True(t, wc.isSubscribedTo(typing))
+	assert.False(t, wc.isSubscribedTo(userUpdated))
+
+	wc.SetSubscription([]model.WebsocketEventType{model.WebsocketEventPosted}, []string{channelID})
+	assert.True(t, wc.isSubscribedTo(posted))
+	assert.False(t, wc.isSubscribedTo(postedElsewhere))
+	assert.False(t, wc.isSubscribedTo(typing))
+
+	wc.SetSubscription(nil, nil)
+	assert.True(t, wc.isSubscribedTo(typing))
+	assert.True(t, wc.isSubscribedTo(userUpdated))
+}
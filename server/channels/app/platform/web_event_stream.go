@@ -0,0 +1,126 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package platform
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// EventStream is a Server-Sent Events transport for a WebConn. It allows
+// clients which cannot upgrade to a websocket to receive the same events,
+// with the same filtering and sequence numbers, over a plain HTTP response.
+// It is write-only: clients cannot send websocket actions through it.
+type EventStream struct {
+	w       http.ResponseWriter
+	flusher http.Flusher
+	rc      *http.ResponseController
+	// done is closed when the underlying HTTP request goes away.
+	done <-chan struct{}
+
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+// NewEventStream prepares the given response to be used as an event stream
+// and writes the response headers. It returns an error if the response
+// does not support flushing.
+func NewEventStream(w http.ResponseWriter, r *http.Request) (*EventStream, error) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		return nil, errors.New("response writer does not support flushing")
+	}
+
+	es := &EventStream{
+		w:       w,
+		flusher: flusher,
+		rc:      http.NewResponseController(w),
+		done:    r.Context().Done(),
+		closed:  make(chan struct{}),
+	}
+
+	// The stream is long lived, so the server wide write timeout
+	// must not apply to it. Deadlines are set per write instead.
+	if err := es.setWriteDeadline(time.Time{}); err != nil {
+		return nil, err
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	// Disables response buffering in nginx.
+	w.Header().Set("X-Accel-Buffering", "no")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	return es, nil
+}
+
+func (es *EventStream) setWriteDeadline(t time.Time) error {
+	if err := es.rc.SetWriteDeadline(t); err != nil && !errors.Is(err, http.ErrNotSupported) {
+		return err
+	}
+	return nil
+}
+
+// write sends a single frame to the client. Text messages are sent as
+// events with the given id, pings are sent as comments so that
+// intermediaries keep the connection open.
+func (es *EventStream) write(msgType int, data []byte, id string) error {
+	select {
+	case <-es.closed:
+		return errors.New("event stream closed")
+	default:
+	}
+
+	var buf bytes.Buffer
+	switch msgType {
+	case websocket.TextMessage:
+		if id != "" {
+			fmt.Fprintf(&buf, "id: %s\n", id)
+		}
+		for _, line := range bytes.Split(bytes.TrimRight(data, "\n"), []byte("\n")) {
+			buf.WriteString("data: ")
+			buf.Write(line)
+			buf.WriteByte('\n')
+		}
+		buf.WriteByte('\n')
+	case websocket.PingMessage:
+		buf.WriteString(": ping\n\n")
+	default:
+		// There is no notion of a close frame, the stream just ends.
+		return nil
+	}
+
+	if err := es.setWriteDeadline(time.Now().Add(writeWaitTime)); err != nil {
+		return err
+	}
+	if _, err := es.w.Write(buf.Bytes()); err != nil {
+		return err
+	}
+	es.flusher.Flush()
+	return nil
+}
+
+// close marks the stream as finished. It is safe to call multiple times.
+func (es *EventStream) close() {
+	es.closeOnce.Do(func() {
+		close(es.closed)
+	})
+}
+
+// wait blocks until either the stream is closed by the server or
+// the client goes away.
+func (es *EventStream) wait() {
+	select {
+	case <-es.closed:
+	case <-es.done:
+	}
+}
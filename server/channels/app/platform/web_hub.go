@@ -433,6 +433,7 @@ func (h *Hub) Start() {
 						DeadQueue:        conn.deadQueue,
 						DeadQueuePointer: conn.deadQueuePointer,
 						ReuseCount:       conn.reuseCount + 1,
+						subscription:     conn.subscription.Load(),
 					}
 				}
 				req.result <- res
@@ -564,6 +565,10 @@ func (h *Hub) Start() {
 					if !connIndex.Has(webConn) {
 						return
 					}
+					// The subscription check is cheaper than ShouldSendEvent, so it goes first.
+					if !webConn.isSubscribedTo(msg) {
+						return
+					}
 					if webConn.ShouldSendEvent(msg) {
 						select {
 						case webConn.send <- h.runBroadcastHooks(msg, webConn, broadcastHooks, broadcastHookArgs):
@@ -106,6 +106,42 @@ func (wr *WebSocketRouter) ServeWebSocket(conn *WebConn, r *model.WebSocketReque
 		return
 	}
 
+	if r.Action == string(model.WebsocketSubscribe) {
+		events, ok := stringsFromWebSocketData(r.Data["events"])
+		if !ok {
+			err := model.NewAppError("ServeWebSocket", "api.web_socket_router.bad_subscription.app_error", map[string]any{"Name": "events"}, "", http.StatusBadRequest)
+			returnWebSocketError(conn.Platform, conn, r, err)
+			return
+		}
+		channelIDs, ok := stringsFromWebSocketData(r.Data["channel_ids"])
+		if !ok {
+			err := model.NewAppError("ServeWebSocket", "api.web_socket_router.bad_subscription.app_error", map[string]any{"Name": "channel_ids"}, "", http.StatusBadRequest)
+			returnWebSocketError(conn.Platform, conn, r, err)
+			return
+		}
+		for _, channelID := range channelIDs {
+			if !model.IsValidId(channelID) {
+				err := model.NewAppError("ServeWebSocket", "api.web_socket_router.bad_subscription.app_error", map[string]any{"Name": "channel_ids"}, "", http.StatusBadRequest)
+				returnWebSocketError(conn.Platform, conn, r, err)
+				return
+			}
+		}
+
+		eventTypes := make([]model.WebsocketEventType, len(events))
+		for i, event := range events {
+			eventTypes[i] = model.WebsocketEventType(event)
+		}
+		conn.SetSubscription(eventTypes, channelIDs)
+
+		resp := model.NewWebSocketResponse(model.StatusOk, r.Seq, nil)
+		hub := conn.Platform.GetHubForUserId(conn.UserId)
+		if hub == nil {
+			return
+		}
+		hub.SendMessage(conn, resp)
+		return
+	}
+
 	if !conn.IsAuthenticated() {
 		err := model.NewAppError("ServeWebSocket", "api.web_socket_router.not_authenticated.app_error", nil, "", http.StatusUnauthorized)
 		returnWebSocketError(conn.Platform, conn, r, err)
@@ -122,6 +158,27 @@ func (wr *WebSocketRouter) ServeWebSocket(conn *WebConn, r *model.WebSocketReque
 	handler.ServeWebSocket(conn, r)
 }
 
+// stringsFromWebSocketData converts a decoded list of strings from a
+// websocket request. A missing value is treated as an empty list.
+func stringsFromWebSocketData(value any) ([]string, bool) {
+	if value == nil {
+		return nil, true
+	}
+	items, ok := value.([]any)
+	if !ok {
+		return nil, false
+	}
+	result := make([]string, 0, len(items))
+	for _, item := range items {
+		str, ok := item.(string)
+		if !ok || str == "" {
+			return nil, false
+		}
+		result = append(result, str)
+	}
+	return result, true
+}
+
 func returnWebSocketError(ps *PlatformService, conn *WebConn, r *model.WebSocketRequest, err *model.AppError) {
 	logF := mlog.Error
 	if err.StatusCode >= http.StatusBadRequest && err.StatusCode < http.StatusInternalServerError {
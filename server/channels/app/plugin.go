@@ -15,6 +15,7 @@ import (
 	"sort"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/blang/semver/v4"
 	svg "github.com/h2non/go-is-svg"
@@ -105,6 +106,13 @@ func (ch *Channels) syncPluginsActiveState() {
 				pluginEnabled = value
 			}
 
+			if pluginEnabled {
+				if appErr := ch.checkPluginCapabilities(plugin.Manifest); appErr != nil {
+					ch.srv.Log().Warn("Not activating plugin with unapproved capabilities", mlog.String("plugin_id", pluginID), mlog.Err(appErr))
+					pluginEnabled = false
+				}
+			}
+
 			if pluginEnabled {
 				enabledPlugins = append(enabledPlugins, plugin)
 			} else {
@@ -112,48 +120,57 @@ func (ch *Channels) syncPluginsActiveState() {
 			}
 		}
 
-		// Concurrently activate/deactivate each plugin appropriately.
-		var wg sync.WaitGroup
+		// Plugins are activated after the plugins they depend on, and deactivated before them.
+		// The plugins of each group of dependencies are handled concurrently.
+		disabledGroups := plugin.GroupByDependencies(disabledPlugins)
+		enabledGroups := plugin.GroupByDependencies(enabledPlugins)
 
 		// Deactivate any plugins that have been disabled.
-		for _, plugin := range disabledPlugins {
-			wg.Add(1)
-			go func(plugin *model.BundleInfo) {
-				defer wg.Done()
-
-				deactivated := pluginsEnvironment.Deactivate(plugin.Manifest.Id)
-				if deactivated && plugin.Manifest.HasClient() {
-					message := model.NewWebSocketEvent(model.WebsocketEventPluginDisabled, "", "", "", nil, "")
-					message.Add("manifest", plugin.Manifest.ClientManifest())
-					ch.srv.platform.Publish(message)
-				}
-			}(plugin)
+		for i := len(disabledGroups) - 1; i >= 0; i-- {
+			var wg sync.WaitGroup
+			for _, plugin := range disabledGroups[i] {
+				wg.Add(1)
+				go func(plugin *model.BundleInfo) {
+					defer wg.Done()
+
+					deactivated := pluginsEnvironment.Deactivate(plugin.Manifest.Id)
+					if deactivated && plugin.Manifest.HasClient() {
+						message := model.NewWebSocketEvent(model.WebsocketEventPluginDisabled, "", "", "", nil, "")
+						message.Add("manifest", plugin.Manifest.ClientManifest())
+						ch.srv.platform.Publish(message)
+					}
+				}(plugin)
+			}
+			wg.Wait()
 		}
 
 		// Activate any plugins that have been enabled
-		for _, plugin := range enabledPlugins {
-			wg.Add(1)
-			go func(plugin *model.BundleInfo) {
-				defer wg.Done()
-
-				pluginID := plugin.Manifest.Id
-				logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID), mlog.String("bundle_path", plugin.Path))
-
-				updatedManifest, activated, err := pluginsEnvironment.Activate(pluginID)
-				if err != nil {
-					logger.Error("Unable to activate plugin", mlog.Err(err))
-					return
-				}
+		for _, group := range enabledGroups {
+			var wg sync.WaitGroup
+			for _, plugin := range group {
+				wg.Add(1)
+				go func(plugin *model.BundleInfo) {
+					defer wg.Done()
+
+					pluginID := plugin.Manifest.Id
+					logger := ch.srv.Log().With(mlog.String("plugin_id", pluginID), mlog.String("bundle_path", plugin.Path))
+
+					updatedManifest, activated, err := pluginsEnvironment.Activate(pluginID)
+					if err != nil {
+						logger.Error("Unable to activate plugin", mlog.Err(err))
+						return
+					}
 
-				if activated {
-					// Notify all cluster clients if ready
-					if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
-						logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
+					if activated {
+						// Notify all cluster clients if ready
+						if err := ch.notifyPluginEnabled(updatedManifest); err != nil {
+							logger.Error("Failed to notify cluster on plugin enable", mlog.Err(err))
+						}
 					}
-				}
-			}(plugin)
+				}(plugin)
+			}
+			wg.Wait()
 		}
-		wg.Wait()
 	} else { // If plugins are disabled, shutdown plugins.
 		pluginsEnvironment.Shutdown()
 	}
@@ -221,6 +238,10 @@ func (ch *Channels) initPlugins(c request.CTX, pluginDir, webappPluginDir string
 	ch.pluginsLock.Unlock()
 
 	ch.pluginsEnvironment.TogglePluginHealthCheckJob(*ch.cfgSvc.Config().PluginSettings.EnableHealthCheck)
+	ch.pluginsEnvironment.SetWasmLimits(wasmLimits(ch.cfgSvc.Config()))
+	ch.pluginsEnvironment.SetProcessLimits(processLimits(ch.cfgSvc.Config()))
+	ch.pluginsEnvironment.SetHookLimits(hookLimits(ch.cfgSvc.Config()))
+	ch.pluginsEnvironment.SetHookCircuitBreakerListener(ch.notifyPluginHooksSuspended)
 
 	if err := ch.syncPlugins(); err != nil {
 		ch.srv.Log().Error("Failed to sync plugins from the file store", mlog.Err(err))
@@ -238,6 +259,12 @@ func (ch *Channels) initPlugins(c request.CTX, pluginDir, webappPluginDir string
 	ch.pluginsLock.Lock()
 	ch.RemoveConfigListener(ch.pluginConfigListenerID)
 	ch.pluginConfigListenerID = ch.AddConfigListener(func(old, new *model.Config) {
+		if env := ch.GetPluginsEnvironment(); env != nil {
+			env.SetWasmLimits(wasmLimits(new))
+			env.SetProcessLimits(processLimits(new))
+			env.SetHookLimits(hookLimits(new))
+		}
+
 		// If plugin status remains unchanged, only then run this.
 		// Because (*App).InitPlugins is already run as a config change hook.
 		if *old.PluginSettings.Enable == *new.PluginSettings.Enable {
@@ -251,11 +278,32 @@ func (ch *Channels) initPlugins(c request.CTX, pluginDir, webappPluginDir string
 			return true
 		}, plugin.OnConfigurationChangeID)
 	})
+	ch.pluginHotReloader = newPluginHotReloader(ch)
+	ch.pluginHotReloader.start()
 	ch.pluginsLock.Unlock()
 
 	ch.syncPluginsActiveState()
 }
 
+// wasmLimits returns the resource limits of plugins running in the WebAssembly runtime. They
+// apply to plugins as they are activated.
+func wasmLimits(cfg *model.Config) plugin.WasmLimits {
+	return plugin.WasmLimits{
+		MaxMemoryMB:     *cfg.PluginSettings.WasmMaxMemoryMB,
+		MaxCallDuration: time.Duration(*cfg.PluginSettings.WasmMaxCallDurationMs) * time.Millisecond,
+	}
+}
+
+// processLimits returns the resource limits of each plugin running as a separate process. They
+// apply to plugins as they are activated.
+func processLimits(cfg *model.Config) plugin.ProcessLimits {
+	return plugin.ProcessLimits{
+		MaxMemoryMB:   *cfg.PluginSettings.ProcessMaxMemoryMB,
+		MaxCPUPercent: *cfg.PluginSettings.ProcessMaxCPUPercent,
+		MaxOpenFiles:  *cfg.PluginSettings.ProcessMaxOpenFiles,
+	}
+}
+
 // SyncPlugins synchronizes the plugins installed locally
 // with the plugin bundles available in the file store.
 func (a *App) SyncPlugins() *model.AppError {
@@ -361,6 +409,15 @@ func (ch *Channels) ShutDownPlugins() {
 
 	ch.srv.Log().Info("Shutting down plugins")
 
+	// The hot reloader is stopped without holding the lock, since a reload in progress needs it.
+	ch.pluginsLock.Lock()
+	pluginHotReloader := ch.pluginHotReloader
+	ch.pluginHotReloader = nil
+	ch.pluginsLock.Unlock()
+	if pluginHotReloader != nil {
+		pluginHotReloader.close()
+	}
+
 	pluginsEnvironment.Shutdown()
 
 	ch.RemoveConfigListener(ch.pluginConfigListenerID)
@@ -445,8 +502,12 @@ func (ch *Channels) enablePlugin(id string) *model.AppError {
 		return model.NewAppError("EnablePlugin", "app.plugin.not_installed.app_error", nil, "", http.StatusNotFound)
 	}
 
+	if appErr := ch.checkPluginCapabilities(manifest); appErr != nil {
+		return appErr
+	}
+
 	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
-		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: true}
+		cfg.PluginSettings.PluginStates[id] = pluginStateWithEnable(cfg.PluginSettings.PluginStates[id], true)
 	})
 
 	// This call will implicitly invoke SyncPluginsActiveState which will activate enabled plugins.
@@ -461,9 +522,10 @@ func (ch *Channels) enablePlugin(id string) *model.AppError {
 }
 
 // DisablePlugin will set the config for an installed plugin to disabled, triggering deactivation if active.
-// Notifies cluster peers through config change.
-func (a *App) DisablePlugin(id string) *model.AppError {
-	appErr := a.ch.disablePlugin(id)
+// Notifies cluster peers through config change. Plugins other enabled plugins depend on are only
+// disabled when forced to, along with the plugins depending on them.
+func (a *App) DisablePlugin(id string, force bool) *model.AppError {
+	appErr := a.ch.disablePlugin(id, force)
 	if appErr != nil {
 		return appErr
 	}
@@ -471,7 +533,7 @@ func (a *App) DisablePlugin(id string) *model.AppError {
 	return nil
 }
 
-func (ch *Channels) disablePlugin(id string) *model.AppError {
+func (ch *Channels) disablePlugin(id string, force bool) *model.AppError {
 	pluginsEnvironment := ch.GetPluginsEnvironment()
 	if pluginsEnvironment == nil {
 		return model.NewAppError("DisablePlugin", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
@@ -496,10 +558,33 @@ func (ch *Channels) disablePlugin(id string) *model.AppError {
 		return model.NewAppError("DisablePlugin", "app.plugin.not_installed.app_error", nil, "", http.StatusNotFound)
 	}
 
+	pluginStates := ch.cfgSvc.Config().PluginSettings.PluginStates
+	var enabledPlugins []*model.BundleInfo
+	for _, p := range availablePlugins {
+		if state, ok := pluginStates[p.Manifest.Id]; ok && state.Enable {
+			enabledPlugins = append(enabledPlugins, p)
+		}
+	}
+
+	// Forcing disables the plugins depending on the plugin as well, so that none keeps running
+	// without its dependency.
+	dependents := plugin.AllDependents(id, enabledPlugins)
+	if len(dependents) > 0 && !force {
+		return model.NewAppError("DisablePlugin", "app.plugin.disable.dependents.app_error", map[string]any{"Dependents": strings.Join(dependents, ", ")}, "", http.StatusConflict)
+	}
+	if len(dependents) > 0 {
+		ch.srv.Log().Warn("Disabling the plugins depending on a plugin disabled by force", mlog.String("plugin_id", id), mlog.Array("dependents", dependents))
+	}
+
+	disabled := append([]string{id}, dependents...)
 	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
-		cfg.PluginSettings.PluginStates[id] = &model.PluginState{Enable: false}
+		for _, pluginID := range disabled {
+			cfg.PluginSettings.PluginStates[pluginID] = pluginStateWithEnable(cfg.PluginSettings.PluginStates[pluginID], false)
+		}
 	})
-	ch.unregisterPluginCommands(id)
+	for _, pluginID := range disabled {
+		ch.unregisterPluginCommands(pluginID)
+	}
 
 	// This call will implicitly invoke SyncPluginsActiveState which will deactivate disabled plugins.
 	if _, _, err := ch.cfgSvc.SaveConfig(ch.cfgSvc.Config(), true); err != nil {
@@ -509,6 +594,16 @@ func (ch *Channels) disablePlugin(id string) *model.AppError {
 	return nil
 }
 
+// pluginStateWithEnable returns a copy of state, keeping any approved capabilities, with Enable set.
+func pluginStateWithEnable(state *model.PluginState, enable bool) *model.PluginState {
+	updated := &model.PluginState{}
+	if state != nil {
+		*updated = *state
+	}
+	updated.Enable = enable
+	return updated
+}
+
 func (a *App) GetPlugins() (*model.PluginsResponse, *model.AppError) {
 	pluginsEnvironment := a.GetPluginsEnvironment()
 	if pluginsEnvironment == nil {
@@ -528,6 +623,9 @@ func (a *App) GetPlugins() (*model.PluginsResponse, *model.AppError) {
 		info := &model.PluginInfo{
 			Manifest: *plugin.Manifest,
 		}
+		if state := a.Config().PluginSettings.PluginStates[plugin.Manifest.Id]; state != nil {
+			info.ApprovedCapabilities = state.ApprovedCapabilities
+		}
 
 		if pluginsEnvironment.IsActive(plugin.Manifest.Id) {
 			resp.Active = append(resp.Active, info)
@@ -552,6 +650,13 @@ func (a *App) GetMarketplacePlugins(rctx request.CTX, filter *model.MarketplaceP
 		plugins = p
 	}
 
+	if *a.Config().PluginSettings.EnableMarketplaceCatalog && !filter.LocalOnly {
+		appErr := a.mergeMarketplaceCatalogPlugins(plugins)
+		if appErr != nil {
+			return nil, appErr
+		}
+	}
+
 	if !filter.RemoteOnly {
 		appErr := a.mergePrepackagedPlugins(plugins)
 		if appErr != nil {
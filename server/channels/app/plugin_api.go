@@ -16,6 +16,7 @@ import (
 	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/i18n"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -913,7 +914,7 @@ func (api *PluginAPI) EnablePlugin(id string) *model.AppError {
 }
 
 func (api *PluginAPI) DisablePlugin(id string) *model.AppError {
-	return api.app.DisablePlugin(id)
+	return api.app.DisablePlugin(id, false)
 }
 
 func (api *PluginAPI) RemovePlugin(id string) *model.AppError {
@@ -975,6 +976,18 @@ func (api *PluginAPI) KVList(page, perPage int) ([]string, *model.AppError) {
 	return api.app.ListPluginKeys(api.id, page, perPage)
 }
 
+func (api *PluginAPI) KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError) {
+	return api.app.ListPluginKeysWithPrefix(api.id, prefix, page, perPage)
+}
+
+func (api *PluginAPI) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	return api.app.GetPluginKeys(api.id, keys)
+}
+
+func (api *PluginAPI) KVBatch(operations []*model.PluginKVBatchOperation) (bool, *model.AppError) {
+	return api.app.BatchPluginKeys(api.id, operations)
+}
+
 func (api *PluginAPI) PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast) {
 	ev := model.NewWebSocketEvent(model.WebsocketEventType(fmt.Sprintf("custom_%v_%v", api.id, event)), "", "", "", nil, "")
 	ev = ev.SetBroadcast(broadcast).SetData(payload)
@@ -1347,3 +1360,49 @@ func (api *PluginAPI) UninviteRemoteFromChannel(channelID string, remoteID strin
 func (api *PluginAPI) GetPluginID() string {
 	return api.id
 }
+
+func (api *PluginAPI) PublishPluginEvent(topic string, payload []byte) *model.AppError {
+	pluginsEnvironment, appErr := api.pluginEventsEnvironment("PublishPluginEvent", topic)
+	if appErr != nil {
+		return appErr
+	}
+
+	pluginsEnvironment.PublishPluginEvent(model.PluginEvent{
+		Topic:          topic,
+		SourcePluginId: api.id,
+		Payload:        payload,
+	})
+	return nil
+}
+
+func (api *PluginAPI) SubscribeToPluginEvents(topic string) *model.AppError {
+	pluginsEnvironment, appErr := api.pluginEventsEnvironment("SubscribeToPluginEvents", topic)
+	if appErr != nil {
+		return appErr
+	}
+
+	pluginsEnvironment.SubscribeToPluginEvents(api.id, topic)
+	return nil
+}
+
+func (api *PluginAPI) UnsubscribeFromPluginEvents(topic string) *model.AppError {
+	pluginsEnvironment, appErr := api.pluginEventsEnvironment("UnsubscribeFromPluginEvents", topic)
+	if appErr != nil {
+		return appErr
+	}
+
+	pluginsEnvironment.UnsubscribeFromPluginEvents(api.id, topic)
+	return nil
+}
+
+func (api *PluginAPI) pluginEventsEnvironment(where, topic string) (*plugin.Environment, *model.AppError) {
+	if !model.IsValidPluginEventTopic(topic) {
+		return nil, model.NewAppError(where, "app.plugin.event_topic.invalid.app_error", map[string]any{"MaxLength": model.PluginEventTopicMaxRunes}, "", http.StatusBadRequest)
+	}
+
+	pluginsEnvironment := api.app.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, model.NewAppError(where, "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return pluginsEnvironment, nil
+}
@@ -0,0 +1,90 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+// checkPluginCapabilities verifies that an administrator approved every capability the plugin
+// declares in its manifest, and that the plugin declares capabilities at all if the server
+// requires it.
+func (ch *Channels) checkPluginCapabilities(manifest *model.Manifest) *model.AppError {
+	settings := ch.cfgSvc.Config().PluginSettings
+
+	if !manifest.DeclaresCapabilities() {
+		if *settings.RequirePluginCapabilities {
+			return model.NewAppError("checkPluginCapabilities", "app.plugin.capabilities_required.app_error", map[string]any{"PluginId": manifest.Id}, "", http.StatusForbidden)
+		}
+		return nil
+	}
+
+	var approved []string
+	if state := settings.PluginStates[manifest.Id]; state != nil {
+		approved = state.ApprovedCapabilities
+	}
+
+	if unapproved := manifest.UnapprovedCapabilities(approved); len(unapproved) > 0 {
+		return model.NewAppError("checkPluginCapabilities", "app.plugin.capabilities_not_approved.app_error", map[string]any{"PluginId": manifest.Id, "Capabilities": strings.Join(unapproved, ", ")}, "", http.StatusForbidden)
+	}
+
+	return nil
+}
+
+// ApprovePluginCapabilities records the capabilities an administrator approved for an installed
+// plugin, replacing any earlier approval. Enabled plugins are activated once all of their
+// declared capabilities are approved.
+func (a *App) ApprovePluginCapabilities(id string, capabilities []string) (*model.PluginInfo, *model.AppError) {
+	return a.ch.approvePluginCapabilities(id, capabilities)
+}
+
+func (ch *Channels) approvePluginCapabilities(id string, capabilities []string) (*model.PluginInfo, *model.AppError) {
+	pluginsEnvironment := ch.GetPluginsEnvironment()
+	if pluginsEnvironment == nil {
+		return nil, model.NewAppError("ApprovePluginCapabilities", "app.plugin.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+
+	for _, capability := range capabilities {
+		if !model.IsValidPluginCapability(capability) {
+			return nil, model.NewAppError("ApprovePluginCapabilities", "app.plugin.capabilities_invalid.app_error", map[string]any{"Capability": capability}, "", http.StatusBadRequest)
+		}
+	}
+
+	id = strings.ToLower(id)
+	manifest, err := pluginsEnvironment.GetManifest(id)
+	if errors.Is(err, plugin.ErrNotFound) {
+		return nil, model.NewAppError("ApprovePluginCapabilities", "app.plugin.not_installed.app_error", nil, "", http.StatusNotFound)
+	} else if err != nil {
+		return nil, model.NewAppError("ApprovePluginCapabilities", "app.plugin.config.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	approved := slices.Clone(capabilities)
+	slices.Sort(approved)
+	approved = slices.Compact(approved)
+
+	ch.cfgSvc.UpdateConfig(func(cfg *model.Config) {
+		state := &model.PluginState{}
+		if current := cfg.PluginSettings.PluginStates[id]; current != nil {
+			*state = *current
+		}
+		state.ApprovedCapabilities = approved
+		cfg.PluginSettings.PluginStates[id] = state
+	})
+
+	// This call will implicitly invoke SyncPluginsActiveState which will activate the plugin if enabled.
+	if _, _, err := ch.cfgSvc.SaveConfig(ch.cfgSvc.Config(), true); err != nil {
+		if err.Id == "ent.cluster.save_config.error" {
+			return nil, model.NewAppError("ApprovePluginCapabilities", "app.plugin.cluster.save_config.app_error", nil, "", http.StatusInternalServerError)
+		}
+		return nil, model.NewAppError("ApprovePluginCapabilities", "app.plugin.config.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return &model.PluginInfo{Manifest: *manifest, ApprovedCapabilities: approved}, nil
+}
@@ -0,0 +1,134 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPluginCapabilitiesEnforced(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	// Activation fails unless the plugin observes the expected access.
+	setupPluginAPITest(t,
+		`
+		package main
+
+		import (
+			"fmt"
+
+			"github.com/mattermost/mattermost/server/public/model"
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) OnActivate() error {
+			if _, appErr := p.API.GetUser("`+th.BasicUser.Id+`"); appErr != nil {
+				return appErr
+			}
+
+			_, appErr := p.API.CreatePost(&model.Post{UserId: "`+th.BasicUser.Id+`", ChannelId: "`+th.BasicChannel.Id+`", Message: "message"})
+			if appErr == nil || appErr.Id != "plugin.api.capability_not_declared.app_error" {
+				return fmt.Errorf("expected CreatePost to be refused, got %v", appErr)
+			}
+
+			if appErr := p.API.KVSet("key", []byte("value")); appErr != nil {
+				return appErr
+			}
+
+			if _, err := p.Driver.Conn(true); err == nil {
+				return fmt.Errorf("expected database access to be refused")
+			}
+
+			return nil
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		`{"id": "testcapabilities", "server": {"executable": "backend.exe"}, "capabilities": ["read_users"]}`, "testcapabilities", th.App, th.Context)
+}
+
+func TestApprovePluginCapabilities(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	pluginID := "testapprovecapabilities"
+	setupPluginAPITest(t,
+		`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`,
+		`{"id": "testapprovecapabilities", "server": {"executable": "backend.exe"}, "capabilities": ["read_users", "read_posts"]}`, pluginID, th.App, th.Context)
+
+	appErr := th.App.EnablePlugin(pluginID)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.plugin.capabilities_not_approved.app_error", appErr.Id)
+	assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+
+	_, appErr = th.App.ApprovePluginCapabilities(pluginID, []string{"read_everything"})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.plugin.capabilities_invalid.app_error", appErr.Id)
+
+	_, appErr = th.App.ApprovePluginCapabilities("missing", []string{model.PluginCapabilityReadUsers})
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.plugin.not_installed.app_error", appErr.Id)
+
+	info, appErr := th.App.ApprovePluginCapabilities(pluginID, []string{model.PluginCapabilityReadUsers})
+	require.Nil(t, appErr)
+	assert.Equal(t, []string{model.PluginCapabilityReadUsers}, info.ApprovedCapabilities)
+
+	appErr = th.App.EnablePlugin(pluginID)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.plugin.capabilities_not_approved.app_error", appErr.Id)
+
+	_, appErr = th.App.ApprovePluginCapabilities(pluginID, []string{model.PluginCapabilityReadPosts, model.PluginCapabilityReadUsers, model.PluginCapabilityReadUsers})
+	require.Nil(t, appErr)
+	require.Nil(t, th.App.EnablePlugin(pluginID))
+
+	require.Nil(t, th.App.DisablePlugin(pluginID, false))
+	state := th.App.Config().PluginSettings.PluginStates[pluginID]
+	require.NotNil(t, state)
+	assert.False(t, state.Enable)
+	assert.Equal(t, []string{model.PluginCapabilityReadPosts, model.PluginCapabilityReadUsers}, state.ApprovedCapabilities)
+}
+
+func TestCheckPluginCapabilities(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	legacy := &model.Manifest{Id: "legacy"}
+	require.Nil(t, th.App.ch.checkPluginCapabilities(legacy))
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.PluginSettings.RequirePluginCapabilities = true
+	})
+	appErr := th.App.ch.checkPluginCapabilities(legacy)
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.plugin.capabilities_required.app_error", appErr.Id)
+
+	require.Nil(t, th.App.ch.checkPluginCapabilities(&model.Manifest{Id: "none", Capabilities: []string{}}))
+}
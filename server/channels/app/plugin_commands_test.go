@@ -96,7 +96,7 @@ func TestPluginCommand(t *testing.T) {
 		require.Equal(t, model.CommandResponseTypeEphemeral, resp.ResponseType)
 		require.Equal(t, "text", resp.Text)
 
-		err2 := th.App.DisablePlugin(pluginIDs[0])
+		err2 := th.App.DisablePlugin(pluginIDs[0], false)
 		require.Nil(t, err2)
 
 		commands, err3 := th.App.ListAutocompleteCommands(args.TeamId, i18n.T)
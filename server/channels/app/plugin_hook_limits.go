@@ -0,0 +1,72 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/shared/i18n"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// hookLimits returns the deadlines of plugin hooks and the settings of the circuit breaker
+// skipping the hooks of plugins that repeatedly miss them.
+func hookLimits(cfg *model.Config) plugin.HookLimits {
+	timeouts := make(map[string]time.Duration, len(cfg.PluginSettings.HookTimeoutsMs))
+	for hookName, timeout := range cfg.PluginSettings.HookTimeoutsMs {
+		timeouts[hookName] = time.Duration(timeout) * time.Millisecond
+	}
+
+	return plugin.HookLimits{
+		Timeouts:                timeouts,
+		CircuitBreakerThreshold: *cfg.PluginSettings.HookCircuitBreakerThreshold,
+		CircuitBreakerCooldown:  time.Duration(*cfg.PluginSettings.HookCircuitBreakerCooldownSeconds) * time.Second,
+	}
+}
+
+// notifyPluginHooksSuspended lets the system admins know that the hooks of a plugin are being
+// skipped after repeatedly exceeding their deadline.
+func (ch *Channels) notifyPluginHooksSuspended(pluginID, hookName string, until time.Time) {
+	a := New(ServerConnector(ch))
+	rctx := request.EmptyContext(a.Log())
+	logger := a.Log().With(mlog.String("plugin_id", pluginID))
+
+	systemBot, appErr := a.GetSystemBot(rctx)
+	if appErr != nil {
+		logger.Error("Failed to get the system bot to notify about suspended plugin hooks", mlog.Err(appErr))
+		return
+	}
+
+	sysAdmins, appErr := a.getAllSystemAdmins()
+	if appErr != nil {
+		logger.Error("Failed to get the system admins to notify about suspended plugin hooks", mlog.Err(appErr))
+		return
+	}
+
+	message := i18n.T("app.plugin.hooks_suspended.message", map[string]any{
+		"PluginId": pluginID,
+		"HookName": hookName,
+		"Until":    until.UTC().Format(time.RFC1123),
+	})
+
+	for _, sysAdmin := range sysAdmins {
+		channel, appErr := a.GetOrCreateDirectChannel(rctx, sysAdmin.Id, systemBot.UserId)
+		if appErr != nil {
+			logger.Error("Failed to get the direct channel to notify about suspended plugin hooks", mlog.String("user_id", sysAdmin.Id), mlog.Err(appErr))
+			continue
+		}
+
+		post := &model.Post{
+			Message:   message,
+			UserId:    systemBot.UserId,
+			ChannelId: channel.Id,
+		}
+		if _, appErr := a.CreatePost(rctx, post, channel, model.CreatePostFlags{}); appErr != nil {
+			logger.Error("Failed to notify about suspended plugin hooks", mlog.String("user_id", sysAdmin.Id), mlog.Err(appErr))
+		}
+	}
+}
@@ -699,6 +699,158 @@ func TestHookFileWillBeUploaded(t *testing.T) {
 	})
 }
 
+func TestHookFileWillBeDownloaded(t *testing.T) {
+	setupPlugin := func(t *testing.T, th *TestHelper, hook string) {
+		var mockAPI plugintest.API
+		mockAPI.On("LoadPluginConfiguration", mock.Anything).Return(nil)
+
+		tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+			package main
+
+			import (
+				"io"
+				"strings"
+
+				"github.com/mattermost/mattermost/server/public/plugin"
+				"github.com/mattermost/mattermost/server/public/model"
+			)
+
+			type MyPlugin struct {
+				plugin.MattermostPlugin
+			}
+
+			func (p *MyPlugin) FileWillBeDownloaded(c *plugin.Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string {
+				` + hook + `
+			}
+
+			func main() {
+				plugin.ClientMain(&MyPlugin{})
+			}
+		`}, th.App, func(*model.Manifest) plugin.API { return &mockAPI })
+		t.Cleanup(tearDown)
+	}
+
+	download := func(t *testing.T, th *TestHelper) (string, int64, *model.AppError) {
+		info := &model.FileInfo{Id: model.NewId(), Name: "testhook.txt"}
+		content, size, appErr := th.App.ApplyFileWillBeDownloadedHook(th.Context, info, th.BasicUser.Id, model.FileDownloadTypeFile, strings.NewReader("inputfile"), 9)
+		if appErr != nil {
+			return "", 0, appErr
+		}
+
+		data, err := io.ReadAll(content)
+		require.NoError(t, err)
+		return string(data), size, nil
+	}
+
+	t.Run("rejected", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		t.Cleanup(th.TearDown)
+
+		setupPlugin(t, th, `return "rejected"`)
+
+		_, _, appErr := download(t, th)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.file.download.rejected_by_plugin.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("allowed", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		t.Cleanup(th.TearDown)
+
+		setupPlugin(t, th, `return ""`)
+
+		content, size, appErr := download(t, th)
+		require.Nil(t, appErr)
+		assert.Equal(t, "inputfile", content)
+		assert.EqualValues(t, 9, size)
+	})
+
+	t.Run("replaced", func(t *testing.T) {
+		th := Setup(t).InitBasic()
+		t.Cleanup(th.TearDown)
+
+		setupPlugin(t, th, `
+				data, err := io.ReadAll(file)
+				if err != nil {
+					return err.Error()
+				}
+				if _, err := io.Copy(output, strings.NewReader(strings.ToUpper(string(data))+"!")); err != nil {
+					return err.Error()
+				}
+				return ""`)
+
+		content, size, appErr := download(t, th)
+		require.Nil(t, appErr)
+		assert.Equal(t, "INPUTFILE!", content)
+		assert.EqualValues(t, 10, size)
+	})
+}
+
+func TestHookSearchResultsWillBeReturned(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	var mockAPI plugintest.API
+	mockAPI.On("LoadPluginConfiguration", mock.Anything).Return(nil)
+
+	tearDown, _, _ := SetAppEnvironmentWithPlugins(t, []string{`
+		package main
+
+		import (
+			"strings"
+
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) SearchResultsWillBeReturned(c *plugin.Context, userID string, posts *model.PostList, files *model.FileInfoList) (*model.PostList, *model.FileInfoList) {
+			if posts == nil {
+				return nil, nil
+			}
+
+			filtered := model.NewPostList()
+			for _, id := range posts.Order {
+				post := posts.Posts[id]
+				if strings.Contains(post.Message, "secret") {
+					continue
+				}
+				post.Message = strings.ReplaceAll(post.Message, "classified", "[redacted]")
+				filtered.AddPost(post)
+				filtered.AddOrder(id)
+			}
+			return filtered, nil
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, func(*model.Manifest) plugin.API { return &mockAPI })
+	defer tearDown()
+
+	createPost := func(message string) *model.Post {
+		post, appErr := th.App.CreatePost(th.Context, &model.Post{
+			UserId:    th.BasicUser.Id,
+			ChannelId: th.BasicChannel.Id,
+			Message:   message,
+		}, th.BasicChannel, model.CreatePostFlags{})
+		require.Nil(t, appErr)
+		return post
+	}
+	secretPost := createPost("hookterm secret")
+	classifiedPost := createPost("hookterm classified")
+
+	results, appErr := th.App.SearchPostsForUser(th.Context, "hookterm", th.BasicUser.Id, th.BasicTeam.Id, false, false, 0, 0, 20)
+	require.Nil(t, appErr)
+	require.Equal(t, []string{classifiedPost.Id}, results.Order)
+	assert.Equal(t, "hookterm [redacted]", results.Posts[classifiedPost.Id].Message)
+	assert.NotContains(t, results.Matches, secretPost.Id)
+}
+
 func TestUserWillLogIn_Blocked(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -872,6 +1024,112 @@ func TestUserHasBeenDeactivated(t *testing.T) {
 	require.Equal(t, "plugin-callback-success", user.Nickname)
 }
 
+func TestUserAndSessionChangeHooks(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	tearDown, pluginIDs, _ := SetAppEnvironmentWithPlugins(t,
+		[]string{
+			`
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+			"github.com/mattermost/mattermost/server/public/model"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) UserHasBeenUpdated(c *plugin.Context, newUser, oldUser *model.User) {
+			p.API.KVSet("updated_"+newUser.Id, []byte(oldUser.Nickname+" -> "+newUser.Nickname+" "+newUser.Password))
+		}
+
+		func (p *MyPlugin) UserRolesHaveChanged(c *plugin.Context, user *model.User, oldRoles, newRoles string) {
+			p.API.KVSet("roles_"+user.Id, []byte(oldRoles+" -> "+newRoles))
+		}
+
+		func (p *MyPlugin) UserPasswordHasChanged(c *plugin.Context, user *model.User) {
+			p.API.KVSet("password_"+user.Id, []byte(user.Password))
+		}
+
+		func (p *MyPlugin) SessionHasBeenRevoked(c *plugin.Context, session *model.Session) {
+			p.API.KVSet("session_"+session.Id, []byte(session.UserId+" "+session.Token))
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`}, th.App, th.NewPluginAPI)
+	defer tearDown()
+	require.Len(t, pluginIDs, 1)
+
+	requireHookValue := func(t *testing.T, key, expected string) {
+		t.Helper()
+		require.Eventually(t, func() bool {
+			value, appErr := th.App.GetPluginKey(pluginIDs[0], key)
+			require.Nil(t, appErr)
+			return value != nil
+		}, 5*time.Second, 100*time.Millisecond)
+
+		value, appErr := th.App.GetPluginKey(pluginIDs[0], key)
+		require.Nil(t, appErr)
+		assert.Equal(t, expected, string(value))
+	}
+
+	t.Run("UserHasBeenUpdated", func(t *testing.T) {
+		user := th.CreateUser()
+		oldNickname := user.Nickname
+		user.Nickname = "updated-nickname"
+		_, appErr := th.App.UpdateUser(th.Context, user, false)
+		require.Nil(t, appErr)
+
+		requireHookValue(t, "updated_"+user.Id, oldNickname+" -> updated-nickname ")
+	})
+
+	t.Run("UserRolesHaveChanged", func(t *testing.T) {
+		user := th.CreateUser()
+		_, appErr := th.App.UpdateUserRoles(th.Context, user.Id, model.SystemUserRoleId+" "+model.SystemAdminRoleId, false)
+		require.Nil(t, appErr)
+
+		requireHookValue(t, "roles_"+user.Id, model.SystemUserRoleId+" -> "+model.SystemUserRoleId+" "+model.SystemAdminRoleId)
+	})
+
+	t.Run("UserPasswordHasChanged", func(t *testing.T) {
+		user := th.CreateUser()
+		appErr := th.App.UpdatePassword(th.Context, user, "Newpassword1!")
+		require.Nil(t, appErr)
+
+		requireHookValue(t, "password_"+user.Id, "")
+	})
+
+	t.Run("SessionHasBeenRevoked", func(t *testing.T) {
+		user := th.CreateUser()
+		session, appErr := th.App.CreateSession(th.Context, &model.Session{UserId: user.Id})
+		require.Nil(t, appErr)
+		appErr = th.App.RevokeSession(th.Context, session)
+		require.Nil(t, appErr)
+
+		requireHookValue(t, "session_"+session.Id, user.Id+" ")
+
+		session, appErr = th.App.CreateSession(th.Context, &model.Session{UserId: user.Id})
+		require.Nil(t, appErr)
+		appErr = th.App.RevokeAllSessions(th.Context, user.Id)
+		require.Nil(t, appErr)
+
+		requireHookValue(t, "session_"+session.Id, user.Id+" ")
+
+		deviceID := model.NewId()
+		session, appErr = th.App.CreateSession(th.Context, &model.Session{UserId: user.Id, DeviceId: deviceID})
+		require.Nil(t, appErr)
+		appErr = th.App.RevokeSessionsForDeviceId(th.Context, user.Id, deviceID, "")
+		require.Nil(t, appErr)
+
+		requireHookValue(t, "session_"+session.Id, user.Id+" ")
+	})
+}
+
 func TestUserHasBeenCreated(t *testing.T) {
 	th := Setup(t)
 	defer th.TearDown()
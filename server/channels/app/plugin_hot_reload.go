@@ -0,0 +1,226 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/utils"
+)
+
+// pluginHotReloadInterval is how often the directories of plugins under development are checked
+// for a new build.
+const pluginHotReloadInterval = 2 * time.Second
+
+// pluginHotReloadDirs are the directories of a plugin, besides its manifest, executables and
+// webapp bundle, copied when reloading it.
+var pluginHotReloadDirs = []string{"assets", "public"}
+
+// pluginHotReloader reinstalls the plugins built in the directories listed in
+// PluginSettings.HotReloadDirectories whenever their build changes, as long as developer mode
+// is enabled. This spares plugin developers from bundling and uploading every build.
+type pluginHotReloader struct {
+	ch   *Channels
+	stop chan struct{}
+	done chan struct{}
+
+	// installed holds the fingerprint of the build last installed from each directory, and
+	// pending the fingerprint of the build seen on the previous check, if different.
+	installed map[string]string
+	pending   map[string]string
+	// failures holds the last error reading each directory, so that it's only logged once.
+	failures map[string]string
+}
+
+func newPluginHotReloader(ch *Channels) *pluginHotReloader {
+	return &pluginHotReloader{
+		ch:        ch,
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+		installed: make(map[string]string),
+		pending:   make(map[string]string),
+		failures:  make(map[string]string),
+	}
+}
+
+func (r *pluginHotReloader) start() {
+	go func() {
+		defer close(r.done)
+
+		ticker := time.NewTicker(pluginHotReloadInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.check()
+			case <-r.stop:
+				return
+			}
+		}
+	}()
+}
+
+func (r *pluginHotReloader) close() {
+	close(r.stop)
+	<-r.done
+}
+
+// check reinstalls the plugins whose build changed since they were last installed. A build is
+// only installed once it is the same on two consecutive checks, so that a build still being
+// written isn't picked up.
+func (r *pluginHotReloader) check() {
+	cfg := r.ch.cfgSvc.Config()
+	if !*cfg.ServiceSettings.EnableDeveloper {
+		return
+	}
+
+	for _, dir := range cfg.PluginSettings.HotReloadDirectories {
+		logger := r.ch.srv.Log().With(mlog.String("plugin_dir", dir))
+
+		manifest, fingerprint, err := pluginBuildFingerprint(dir)
+		if err != nil {
+			if r.failures[dir] != err.Error() {
+				logger.Error("Failed to read the build of plugin to hot reload", mlog.Err(err))
+				r.failures[dir] = err.Error()
+			}
+			continue
+		}
+		delete(r.failures, dir)
+
+		if fingerprint == r.installed[dir] {
+			delete(r.pending, dir)
+			continue
+		}
+		if fingerprint != r.pending[dir] {
+			r.pending[dir] = fingerprint
+			continue
+		}
+
+		delete(r.pending, dir)
+		r.installed[dir] = fingerprint
+		r.ch.hotReloadPlugin(dir, manifest)
+	}
+}
+
+// pluginBuildFiles returns the files making up the build of a plugin in the given directory,
+// relative to it.
+func pluginBuildFiles(dir string, manifest *model.Manifest, manifestPath string) []string {
+	files := []string{filepath.Base(manifestPath)}
+	if manifest.Server != nil {
+		for _, executable := range manifest.Server.Executables {
+			files = append(files, executable)
+		}
+		if manifest.Server.Executable != "" {
+			files = append(files, manifest.Server.Executable)
+		}
+		if manifest.Server.Wasm != "" {
+			files = append(files, manifest.Server.Wasm)
+		}
+	}
+	if manifest.Webapp != nil && manifest.Webapp.BundlePath != "" {
+		files = append(files, manifest.Webapp.BundlePath)
+	}
+	if manifest.IconPath != "" {
+		files = append(files, manifest.IconPath)
+	}
+
+	for _, buildDir := range pluginHotReloadDirs {
+		filepath.WalkDir(filepath.Join(dir, buildDir), func(path string, d os.DirEntry, err error) error {
+			if err == nil && !d.IsDir() {
+				if relPath, err := filepath.Rel(dir, path); err == nil {
+					files = append(files, relPath)
+				}
+			}
+			return nil
+		})
+	}
+
+	sort.Strings(files)
+	return files
+}
+
+// pluginBuildFingerprint returns the manifest of the plugin built in the given directory, along
+// with a fingerprint of its build changing whenever any of its files does.
+func pluginBuildFingerprint(dir string) (*model.Manifest, string, error) {
+	manifest, manifestPath, err := model.FindManifest(dir)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var fingerprint strings.Builder
+	for _, file := range pluginBuildFiles(dir, manifest, manifestPath) {
+		info, err := os.Stat(filepath.Join(dir, file))
+		if os.IsNotExist(err) {
+			// Plugins are commonly built for the current platform only, leaving the executables
+			// of other platforms missing.
+			continue
+		} else if err != nil {
+			return nil, "", err
+		}
+		fmt.Fprintf(&fingerprint, "%s:%d:%d;", file, info.Size(), info.ModTime().UnixNano())
+	}
+
+	return manifest, fingerprint.String(), nil
+}
+
+// hotReloadPlugin installs the plugin built in the given directory in place of the installed one,
+// activating it if enabled. Failures are logged and reported in the status of the plugin.
+func (ch *Channels) hotReloadPlugin(dir string, manifest *model.Manifest) {
+	logger := ch.srv.Log().With(mlog.String("plugin_id", manifest.Id), mlog.String("plugin_dir", dir))
+	logger.Info("Reloading plugin after a new build")
+
+	if _, appErr := ch.installPluginBuild(dir, manifest); appErr != nil {
+		logger.Error("Failed to hot reload plugin", mlog.Err(appErr))
+		if pluginsEnvironment := ch.GetPluginsEnvironment(); pluginsEnvironment != nil {
+			pluginsEnvironment.SetPluginError(manifest.Id, appErr.Error())
+		}
+		if err := ch.notifyPluginStatusesChanged(); err != nil {
+			logger.Warn("Failed to notify plugin status changed", mlog.Err(err))
+		}
+		return
+	}
+
+	if err := ch.notifyPluginEnabled(manifest); err != nil {
+		logger.Warn("Failed to notify plugin enabled", mlog.Err(err))
+	}
+	if err := ch.notifyPluginStatusesChanged(); err != nil {
+		logger.Warn("Failed to notify plugin status changed", mlog.Err(err))
+	}
+}
+
+// installPluginBuild installs the plugin built in the given directory for the current server,
+// copying only its build rather than the whole directory, which usually holds its sources.
+func (ch *Channels) installPluginBuild(dir string, manifest *model.Manifest) (*model.Manifest, *model.AppError) {
+	tmpDir, err := os.MkdirTemp("", "plugintmp")
+	if err != nil {
+		return nil, model.NewAppError("installPluginBuild", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	_, manifestPath, err := model.FindManifest(dir)
+	if err != nil {
+		return nil, model.NewAppError("installPluginBuild", "app.plugin.manifest.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	for _, file := range pluginBuildFiles(dir, manifest, manifestPath) {
+		if _, err := os.Stat(filepath.Join(dir, file)); os.IsNotExist(err) {
+			continue
+		}
+
+		if err := utils.CopyFile(filepath.Join(dir, file), filepath.Join(tmpDir, file)); err != nil {
+			return nil, model.NewAppError("installPluginBuild", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	return ch.installExtractedPlugin(manifest, tmpDir, installPluginLocallyAlways)
+}
@@ -0,0 +1,147 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+	"github.com/mattermost/mattermost/server/public/plugin/utils"
+)
+
+func writePluginBuildFile(t *testing.T, dir, file, content string, modTime time.Time) {
+	t.Helper()
+
+	path := filepath.Join(dir, file)
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+	require.NoError(t, os.WriteFile(path, []byte(content), 0700))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestPluginBuildFingerprint(t *testing.T) {
+	dir := t.TempDir()
+	modTime := time.Now().Add(-time.Hour)
+	writePluginBuildFile(t, dir, "plugin.json", `{"id": "testplugin", "server": {"executables": {"linux-amd64": "server/dist/plugin-linux-amd64", "darwin-arm64": "server/dist/plugin-darwin-arm64"}}, "webapp": {"bundle_path": "webapp/dist/main.js"}}`, modTime)
+	writePluginBuildFile(t, dir, "server/dist/plugin-linux-amd64", "executable", modTime)
+	writePluginBuildFile(t, dir, "webapp/dist/main.js", "bundle", modTime)
+	writePluginBuildFile(t, dir, "assets/icon.svg", "icon", modTime)
+	writePluginBuildFile(t, dir, "server/main.go", "package main", modTime)
+
+	manifest, fingerprint, err := pluginBuildFingerprint(dir)
+	require.NoError(t, err)
+	assert.Equal(t, "testplugin", manifest.Id)
+
+	t.Run("unchanged build", func(t *testing.T) {
+		_, sameFingerprint, err := pluginBuildFingerprint(dir)
+		require.NoError(t, err)
+		assert.Equal(t, fingerprint, sameFingerprint)
+	})
+
+	t.Run("changed sources", func(t *testing.T) {
+		writePluginBuildFile(t, dir, "server/main.go", "package main // changed", time.Now())
+
+		_, sameFingerprint, err := pluginBuildFingerprint(dir)
+		require.NoError(t, err)
+		assert.Equal(t, fingerprint, sameFingerprint)
+	})
+
+	for _, file := range []string{"server/dist/plugin-linux-amd64", "webapp/dist/main.js", "assets/icon.svg"} {
+		t.Run("changed "+file, func(t *testing.T) {
+			writePluginBuildFile(t, dir, file, "changed", time.Now())
+
+			_, newFingerprint, err := pluginBuildFingerprint(dir)
+			require.NoError(t, err)
+			assert.NotEqual(t, fingerprint, newFingerprint)
+			fingerprint = newFingerprint
+		})
+	}
+
+	t.Run("missing manifest", func(t *testing.T) {
+		_, _, err := pluginBuildFingerprint(t.TempDir())
+		assert.Error(t, err)
+	})
+}
+
+func TestPluginHotReload(t *testing.T) {
+	th := Setup(t)
+	defer th.TearDown()
+
+	pluginDir := t.TempDir()
+	webappPluginDir := t.TempDir()
+	sourceDir := t.TempDir()
+	pluginID := "testplugin"
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableDeveloper = true
+		*cfg.PluginSettings.Directory = pluginDir
+		*cfg.PluginSettings.ClientDirectory = webappPluginDir
+		cfg.PluginSettings.HotReloadDirectories = []string{sourceDir}
+		cfg.PluginSettings.PluginStates[pluginID] = &model.PluginState{Enable: true}
+	})
+
+	env, err := plugin.NewEnvironment(th.NewPluginAPI, NewDriverImpl(th.Server), pluginDir, webappPluginDir, th.App.Log(), nil)
+	require.NoError(t, err)
+	th.App.ch.SetPluginsEnvironment(env)
+
+	writePluginBuildFile(t, sourceDir, "plugin.json", `{"id": "testplugin", "version": "0.1.0", "server": {"executable": "server/dist/plugin.exe"}}`, time.Now())
+	utils.CompileGo(t, `
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`, filepath.Join(sourceDir, "server", "dist", "plugin.exe"))
+
+	reloader := newPluginHotReloader(th.App.ch)
+
+	t.Run("new build installed once stable", func(t *testing.T) {
+		reloader.check()
+		assert.False(t, env.IsActive(pluginID))
+
+		reloader.check()
+		assert.True(t, env.IsActive(pluginID))
+		assert.FileExists(t, filepath.Join(pluginDir, pluginID, "server", "dist", "plugin.exe"))
+	})
+
+	t.Run("broken build reported in plugin status", func(t *testing.T) {
+		writePluginBuildFile(t, sourceDir, "server/dist/plugin.exe", "not an executable", time.Now())
+
+		reloader.check()
+		reloader.check()
+		assert.False(t, env.IsActive(pluginID))
+
+		status, appErr := th.App.GetPluginStatus(pluginID)
+		require.Nil(t, appErr)
+		assert.NotEmpty(t, status.Error)
+	})
+
+	t.Run("disabled without developer mode", func(t *testing.T) {
+		th.App.UpdateConfig(func(cfg *model.Config) {
+			*cfg.ServiceSettings.EnableDeveloper = false
+		})
+
+		writePluginBuildFile(t, sourceDir, "plugin.json", `{"id": "testplugin", "version": "0.2.0", "server": {"executable": "server/dist/plugin.exe"}}`, time.Now())
+		reloader.check()
+		reloader.check()
+
+		manifest, err := env.GetManifest(pluginID)
+		require.NoError(t, err)
+		assert.Equal(t, "0.1.0", manifest.Version)
+	})
+}
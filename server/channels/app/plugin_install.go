@@ -267,8 +267,9 @@ func (ch *Channels) installPluginToFilestore(manifest *model.Manifest, bundle, s
 }
 
 // InstallMarketplacePlugin installs a plugin listed in the marketplace server. It will get the
-// plugin bundle from the prepackaged folder, if available, or remotely if EnableRemoteMarketplace
-// is true.
+// plugin bundle from the prepackaged folder, if available, remotely if EnableRemoteMarketplace
+// is true, or from the offline marketplace catalog if EnableMarketplaceCatalog is true, installing
+// the newest version among them.
 func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.AppError) {
 	logger := ch.srv.Log().With(mlog.String("plugin_id", request.Id))
 
@@ -289,6 +290,16 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 		signatureFile = bytes.NewReader(prepackagedPlugin.Signature)
 	}
 
+	// The version of the plugin bundle found so far, if any.
+	var selectedVersion semver.Version
+	if prepackagedPlugin != nil {
+		var err error
+		selectedVersion, err = semver.Parse(prepackagedPlugin.Manifest.Version)
+		if err != nil {
+			return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
 	if *ch.cfgSvc.Config().PluginSettings.EnableRemoteMarketplace {
 		var plugin *model.BaseMarketplacePlugin
 		plugin, appErr = ch.getRemoteMarketplacePlugin(request.Id, request.Version)
@@ -298,21 +309,12 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 		}
 
 		if plugin != nil {
-			var prepackagedVersion semver.Version
-			if prepackagedPlugin != nil {
-				var err error
-				prepackagedVersion, err = semver.Parse(prepackagedPlugin.Manifest.Version)
-				if err != nil {
-					return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
-				}
-			}
-
 			marketplaceVersion, err := semver.Parse(plugin.Manifest.Version)
 			if err != nil {
 				return nil, model.NewAppError("InstallMarketplacePlugin", "app.prepackged-plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
 			}
 
-			if prepackagedVersion.LT(marketplaceVersion) { // Always true if no prepackaged plugin was found
+			if selectedVersion.LT(marketplaceVersion) { // Always true if no prepackaged plugin was found
 				downloadedPluginBytes, err := ch.srv.downloadFromURL(plugin.DownloadURL)
 				if err != nil {
 					return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.install_marketplace_plugin.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
@@ -323,6 +325,37 @@ func (ch *Channels) InstallMarketplacePlugin(request *model.InstallMarketplacePl
 				}
 				pluginFile = bytes.NewReader(downloadedPluginBytes)
 				signatureFile = signature
+				selectedVersion = marketplaceVersion
+			}
+		}
+	}
+
+	if *ch.cfgSvc.Config().PluginSettings.EnableMarketplaceCatalog {
+		var plugin *model.BaseMarketplacePlugin
+		plugin, appErr = ch.getMarketplaceCatalogPlugin(request.Id, request.Version)
+		if appErr != nil && appErr.Id != "app.plugin.marketplace_plugins.not_found.app_error" {
+			return nil, appErr
+		}
+
+		if plugin != nil {
+			catalogVersion, err := semver.Parse(plugin.Manifest.Version)
+			if err != nil {
+				return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+			}
+
+			if selectedVersion.LT(catalogVersion) {
+				bundle, appErr := ch.srv.fileReader(getMarketplaceCatalogBundlePath(plugin.Manifest.Id, plugin.Manifest.Version))
+				if appErr != nil {
+					return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.install_marketplace_plugin.app_error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+				}
+				defer bundle.Close()
+
+				signature, err := plugin.DecodeSignature()
+				if err != nil {
+					return nil, model.NewAppError("InstallMarketplacePlugin", "app.plugin.signature_decode.app_error", nil, "", http.StatusNotImplemented).Wrap(err)
+				}
+				pluginFile = bundle
+				signatureFile = signature
 			}
 		}
 	}
@@ -525,8 +558,9 @@ func (ch *Channels) RemovePlugin(id string) *model.AppError {
 	logger := ch.srv.Log().With(mlog.String("plugin_id", id))
 
 	// Disable plugin before removal to make sure this
-	// plugin remains disabled on re-install.
-	if err := ch.disablePlugin(id); err != nil {
+	// plugin remains disabled on re-install. Removing a plugin
+	// other plugins depend on is as deliberate as a forced disable.
+	if err := ch.disablePlugin(id, true); err != nil {
 		return err
 	}
 
@@ -124,3 +124,76 @@ func (a *App) DeleteAllExpiredPluginKeys() *model.AppError {
 func (a *App) ListPluginKeys(pluginID string, page, perPage int) ([]string, *model.AppError) {
 	return a.Srv().Platform().ListPluginKeys(pluginID, page, perPage)
 }
+
+func (a *App) ListPluginKeysWithPrefix(pluginID, prefix string, page, perPage int) ([]string, *model.AppError) {
+	keys, err := a.Srv().Store().Plugin().ListWithPrefix(pluginID, prefix, page*perPage, perPage)
+	if err != nil {
+		mlog.Error("Failed to list plugin key values with prefix", mlog.String("plugin_id", pluginID), mlog.String("prefix", prefix), mlog.Int("page", page), mlog.Int("perPage", perPage), mlog.Err(err))
+		return nil, model.NewAppError("ListPluginKeysWithPrefix", "app.plugin_store.list.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return keys, nil
+}
+
+// GetPluginKeys returns the values of the given keys of a plugin, omitting the keys which
+// don't exist.
+func (a *App) GetPluginKeys(pluginID string, keys []string) (map[string][]byte, *model.AppError) {
+	// Lookup the hashed versions of the keys as well for keys written prior to v5.6.
+	hashedKeys := make(map[string]string, len(keys))
+	lookup := make([]string, 0, 2*len(keys))
+	for _, key := range keys {
+		hashedKeys[getKeyHash(key)] = key
+		lookup = append(lookup, key, getKeyHash(key))
+	}
+
+	kvs, err := a.Srv().Store().Plugin().GetMany(pluginID, lookup)
+	if err != nil {
+		mlog.Error("Failed to query plugin key values", mlog.String("plugin_id", pluginID), mlog.Err(err))
+		return nil, model.NewAppError("GetPluginKeys", "app.plugin_store.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	values := make(map[string][]byte, len(keys))
+	for _, kv := range kvs {
+		if key, ok := hashedKeys[kv.Key]; ok {
+			if _, ok := values[key]; !ok {
+				values[key] = kv.Value
+			}
+			continue
+		}
+		values[kv.Key] = kv.Value
+	}
+
+	return values, nil
+}
+
+// BatchPluginKeys applies the given operations to the key-value pairs of a plugin in a single
+// transaction. It returns false without applying any of them if the condition of an atomic
+// operation doesn't hold.
+func (a *App) BatchPluginKeys(pluginID string, operations []*model.PluginKVBatchOperation) (bool, *model.AppError) {
+	if len(operations) > model.PluginKVBatchMaxOperations {
+		return false, model.NewAppError("BatchPluginKeys", "app.plugin_store.batch.too_many_operations.app_error", map[string]any{"Max": model.PluginKVBatchMaxOperations}, "", http.StatusBadRequest)
+	}
+
+	applied, err := a.Srv().Store().Plugin().Batch(pluginID, operations)
+	if err != nil {
+		mlog.Error("Failed to apply batch of plugin key values", mlog.String("plugin_id", pluginID), mlog.Err(err))
+		var appErr *model.AppError
+		switch {
+		case errors.As(err, &appErr):
+			return false, appErr
+		default:
+			return false, model.NewAppError("BatchPluginKeys", "app.plugin_store.save.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
+	if applied {
+		// Clean up previous entries using the hashed keys, if they exist.
+		for _, operation := range operations {
+			if err := a.Srv().Store().Plugin().Delete(pluginID, getKeyHash(operation.Key)); err != nil {
+				mlog.Warn("Failed to clean up previously hashed plugin key value", mlog.String("plugin_id", pluginID), mlog.String("key", operation.Key), mlog.Err(err))
+			}
+		}
+	}
+
+	return applied, nil
+}
@@ -0,0 +1,407 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// ### Marketplace Catalog
+//
+// Deployments that can't reach the marketplace server list plugins from an offline catalog
+// instead, if enabled (PluginSettings.EnableMarketplaceCatalog). The catalog is kept in the
+// filestore, so that every server of a cluster serves the same one:
+//
+//	marketplace/
+//	    catalog.json            the listing of every plugin, as served by the marketplace server
+//	    <id>-<version>.tar.gz   the bundle of every plugin listed
+//
+// Plugins are added to the catalog by importing a catalog bundle: a tar.gz archive of plugin
+// bundles, each alongside its signature (<bundle>.tar.gz.sig), and optionally of a metadata.json
+// file holding marketplace listings for the plugins, matched by manifest id and optionally
+// version, to provide details missing from their manifests such as labels. Every plugin bundle
+// must be signed by one of the keys trusted to sign plugins, and is verified again on install.
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/platform/services/marketplace"
+)
+
+const (
+	// fileStoreMarketplaceCatalogFolder is the folder name in the file store of the offline
+	// marketplace catalog.
+	fileStoreMarketplaceCatalogFolder = "marketplace"
+
+	// marketplaceCatalogMetadataFileName is the name of the optional file of a catalog bundle
+	// holding marketplace listings for its plugins.
+	marketplaceCatalogMetadataFileName = "metadata.json"
+)
+
+func getMarketplaceCatalogIndexPath() string {
+	return filepath.Join(fileStoreMarketplaceCatalogFolder, "catalog.json")
+}
+
+func getMarketplaceCatalogBundlePath(id, version string) string {
+	return filepath.Join(fileStoreMarketplaceCatalogFolder, fmt.Sprintf("%s-%s.tar.gz", id, version))
+}
+
+// GetMarketplaceCatalog returns every plugin of the offline marketplace catalog, including
+// every version of each.
+func (a *App) GetMarketplaceCatalog() ([]*model.BaseMarketplacePlugin, *model.AppError) {
+	return a.ch.getMarketplaceCatalog()
+}
+
+func (ch *Channels) getMarketplaceCatalog() ([]*model.BaseMarketplacePlugin, *model.AppError) {
+	indexPath := getMarketplaceCatalogIndexPath()
+	exists, appErr := ch.srv.fileExists(indexPath)
+	if appErr != nil {
+		return nil, model.NewAppError("getMarketplaceCatalog", "app.plugin.marketplace_catalog.read.app_error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+	}
+	if !exists {
+		return []*model.BaseMarketplacePlugin{}, nil
+	}
+
+	reader, appErr := ch.srv.fileReader(indexPath)
+	if appErr != nil {
+		return nil, model.NewAppError("getMarketplaceCatalog", "app.plugin.marketplace_catalog.read.app_error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+	}
+	defer reader.Close()
+
+	plugins, err := model.BaseMarketplacePluginsFromReader(reader)
+	if err != nil {
+		return nil, model.NewAppError("getMarketplaceCatalog", "app.plugin.marketplace_catalog.read.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return plugins, nil
+}
+
+func (ch *Channels) saveMarketplaceCatalog(plugins []*model.BaseMarketplacePlugin) *model.AppError {
+	sort.SliceStable(plugins, func(i, j int) bool {
+		if plugins[i].Manifest.Id != plugins[j].Manifest.Id {
+			return plugins[i].Manifest.Id < plugins[j].Manifest.Id
+		}
+		versionI, _ := semver.Parse(plugins[i].Manifest.Version)
+		versionJ, _ := semver.Parse(plugins[j].Manifest.Version)
+		return versionI.LT(versionJ)
+	})
+
+	data, err := json.Marshal(plugins)
+	if err != nil {
+		return model.NewAppError("saveMarketplaceCatalog", "app.plugin.marketplace_catalog.write.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if _, appErr := ch.srv.writeFile(bytes.NewReader(data), getMarketplaceCatalogIndexPath()); appErr != nil {
+		return model.NewAppError("saveMarketplaceCatalog", "app.plugin.marketplace_catalog.write.app_error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+	}
+
+	return nil
+}
+
+// ImportMarketplaceCatalog adds the plugins of the given catalog bundle to the offline
+// marketplace catalog, replacing the versions already listed. If replace is true, the plugins
+// previously listed are removed from the catalog. The bundle is rejected as a whole if any of its
+// plugins isn't signed by a trusted key.
+func (a *App) ImportMarketplaceCatalog(bundle io.ReadSeeker, replace bool) ([]*model.BaseMarketplacePlugin, *model.AppError) {
+	return a.ch.importMarketplaceCatalog(bundle, replace)
+}
+
+func (ch *Channels) importMarketplaceCatalog(bundle io.ReadSeeker, replace bool) ([]*model.BaseMarketplacePlugin, *model.AppError) {
+	tmpDir, err := os.MkdirTemp("", "marketplacecatalog")
+	if err != nil {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err = extractTarGz(bundle, tmpDir); err != nil {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.marketplace_catalog.extract.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	metadata, appErr := readMarketplaceCatalogMetadata(tmpDir)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var bundlePaths []string
+	err = filepath.WalkDir(tmpDir, func(path string, d os.DirEntry, err error) error {
+		if err == nil && !d.IsDir() && strings.HasSuffix(path, ".tar.gz") {
+			bundlePaths = append(bundlePaths, path)
+		}
+		return err
+	})
+	if err != nil {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if len(bundlePaths) == 0 {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.marketplace_catalog.no_plugins.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// The bundle of each plugin to import by its path in the catalog, the first one listed
+	// winning if a version of a plugin is included twice.
+	imported := make([]*model.BaseMarketplacePlugin, 0, len(bundlePaths))
+	importedBundles := make(map[string]string, len(bundlePaths))
+	for _, bundlePath := range bundlePaths {
+		plugin, appErr := ch.readMarketplaceCatalogBundle(tmpDir, bundlePath, metadata)
+		if appErr != nil {
+			return nil, appErr
+		}
+
+		catalogPath := getMarketplaceCatalogBundlePath(plugin.Manifest.Id, plugin.Manifest.Version)
+		if _, ok := importedBundles[catalogPath]; ok {
+			continue
+		}
+		importedBundles[catalogPath] = bundlePath
+		imported = append(imported, plugin)
+	}
+
+	ch.marketplaceCatalogLock.Lock()
+	defer ch.marketplaceCatalogLock.Unlock()
+
+	catalog, appErr := ch.getMarketplaceCatalog()
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	for catalogPath, bundlePath := range importedBundles {
+		file, err := os.Open(bundlePath)
+		if err != nil {
+			return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		_, appErr := ch.srv.writeFile(file, catalogPath)
+		file.Close()
+		if appErr != nil {
+			return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.marketplace_catalog.write.app_error", nil, "", http.StatusInternalServerError).Wrap(appErr)
+		}
+	}
+
+	result := imported
+	for _, plugin := range catalog {
+		bundlePath := getMarketplaceCatalogBundlePath(plugin.Manifest.Id, plugin.Manifest.Version)
+		if _, ok := importedBundles[bundlePath]; ok {
+			continue
+		}
+
+		if !replace {
+			result = append(result, plugin)
+			continue
+		}
+
+		if appErr := ch.srv.removeFile(bundlePath); appErr != nil {
+			ch.srv.Log().Warn("Failed to remove plugin bundle from the marketplace catalog", mlog.String("path", bundlePath), mlog.Err(appErr))
+		}
+	}
+
+	if appErr := ch.saveMarketplaceCatalog(result); appErr != nil {
+		return nil, appErr
+	}
+
+	return result, nil
+}
+
+// readMarketplaceCatalogMetadata reads the marketplace listings of the plugins of the catalog
+// bundle extracted to the given directory, if any.
+func readMarketplaceCatalogMetadata(dir string) ([]*model.BaseMarketplacePlugin, *model.AppError) {
+	file, err := os.Open(filepath.Join(dir, marketplaceCatalogMetadataFileName))
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, model.NewAppError("readMarketplaceCatalogMetadata", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer file.Close()
+
+	metadata, err := model.BaseMarketplacePluginsFromReader(file)
+	if err != nil {
+		return nil, model.NewAppError("readMarketplaceCatalogMetadata", "app.plugin.marketplace_catalog.metadata.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return metadata, nil
+}
+
+// readMarketplaceCatalogBundle verifies the signature of the given plugin bundle of a catalog
+// bundle, and returns its marketplace listing.
+func (ch *Channels) readMarketplaceCatalogBundle(dir, bundlePath string, metadata []*model.BaseMarketplacePlugin) (*model.BaseMarketplacePlugin, *model.AppError) {
+	fileName, _ := filepath.Rel(dir, bundlePath)
+	params := map[string]any{"FileName": fileName}
+
+	signature, err := os.ReadFile(bundlePath + ".sig")
+	if os.IsNotExist(err) {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.marketplace_catalog.signature_not_found.app_error", params, "", http.StatusBadRequest)
+	} else if err != nil {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	file, err := os.Open(bundlePath)
+	if err != nil {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer file.Close()
+
+	if appErr := ch.verifyPlugin(file, bytes.NewReader(signature)); appErr != nil {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.marketplace_catalog.signature.app_error", params, "", http.StatusBadRequest).Wrap(appErr)
+	}
+
+	extractDir, err := os.MkdirTemp("", "marketplacecatalogplugin")
+	if err != nil {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.filesystem.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	defer os.RemoveAll(extractDir)
+
+	manifest, pluginDir, appErr := extractPlugin(file, extractDir)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	// The version names the bundle in the catalog, so it must be valid.
+	if _, err = semver.Parse(manifest.Version); err != nil {
+		return nil, model.NewAppError("importMarketplaceCatalog", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	plugin := &model.BaseMarketplacePlugin{
+		HomepageURL:     manifest.HomepageURL,
+		ReleaseNotesURL: manifest.ReleaseNotesURL,
+		Signature:       base64.StdEncoding.EncodeToString(signature),
+		Manifest:        manifest,
+	}
+	if manifest.IconPath != "" {
+		if plugin.IconData, err = getIcon(filepath.Join(pluginDir, manifest.IconPath)); err != nil {
+			ch.srv.Log().Warn("Error loading marketplace catalog plugin icon", mlog.String("plugin_id", manifest.Id), mlog.String("icon_path", manifest.IconPath), mlog.Err(err))
+		}
+	}
+
+	for _, listing := range metadata {
+		if listing.Manifest == nil || listing.Manifest.Id != manifest.Id || (listing.Manifest.Version != "" && listing.Manifest.Version != manifest.Version) {
+			continue
+		}
+
+		if listing.HomepageURL != "" {
+			plugin.HomepageURL = listing.HomepageURL
+		}
+		if listing.IconData != "" {
+			plugin.IconData = listing.IconData
+		}
+		if listing.ReleaseNotesURL != "" {
+			plugin.ReleaseNotesURL = listing.ReleaseNotesURL
+		}
+		plugin.Labels = listing.Labels
+		plugin.Hosting = listing.Hosting
+		plugin.AuthorType = listing.AuthorType
+		plugin.ReleaseStage = listing.ReleaseStage
+		plugin.Enterprise = listing.Enterprise
+	}
+
+	return plugin, nil
+}
+
+// RemoveMarketplaceCatalogPlugin removes the given version of a plugin from the offline
+// marketplace catalog, or every version of it if none is given.
+func (a *App) RemoveMarketplaceCatalogPlugin(pluginID, version string) *model.AppError {
+	return a.ch.removeMarketplaceCatalogPlugin(pluginID, version)
+}
+
+func (ch *Channels) removeMarketplaceCatalogPlugin(pluginID, version string) *model.AppError {
+	ch.marketplaceCatalogLock.Lock()
+	defer ch.marketplaceCatalogLock.Unlock()
+
+	catalog, appErr := ch.getMarketplaceCatalog()
+	if appErr != nil {
+		return appErr
+	}
+
+	result := make([]*model.BaseMarketplacePlugin, 0, len(catalog))
+	var removed []*model.BaseMarketplacePlugin
+	for _, plugin := range catalog {
+		if plugin.Manifest.Id == pluginID && (version == "" || plugin.Manifest.Version == version) {
+			removed = append(removed, plugin)
+		} else {
+			result = append(result, plugin)
+		}
+	}
+	if len(removed) == 0 {
+		return model.NewAppError("removeMarketplaceCatalogPlugin", "app.plugin.marketplace_catalog.not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	if appErr := ch.saveMarketplaceCatalog(result); appErr != nil {
+		return appErr
+	}
+
+	for _, plugin := range removed {
+		bundlePath := getMarketplaceCatalogBundlePath(plugin.Manifest.Id, plugin.Manifest.Version)
+		if appErr := ch.srv.removeFile(bundlePath); appErr != nil {
+			ch.srv.Log().Warn("Failed to remove plugin bundle from the marketplace catalog", mlog.String("path", bundlePath), mlog.Err(appErr))
+		}
+	}
+
+	return nil
+}
+
+// getMarketplaceCatalogPlugin returns a plugin of the offline marketplace catalog.
+//
+// If version is empty, the latest compatible version is used.
+func (ch *Channels) getMarketplaceCatalogPlugin(pluginID, version string) (*model.BaseMarketplacePlugin, *model.AppError) {
+	plugins, appErr := ch.getMarketplaceCatalog()
+	if appErr != nil {
+		return nil, appErr
+	}
+	catalog := marketplace.NewCatalog(plugins)
+
+	filter := ch.getBaseMarketplaceFilter()
+	filter.PluginId = pluginID
+
+	var plugin *model.BaseMarketplacePlugin
+	var err error
+	if version != "" {
+		plugin, err = catalog.GetPlugin(filter, version)
+	} else {
+		plugin, err = catalog.GetLatestPlugin(filter)
+	}
+	if err != nil {
+		return nil, model.NewAppError("getMarketplaceCatalogPlugin", "app.plugin.marketplace_plugins.not_found.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return plugin, nil
+}
+
+// mergeMarketplaceCatalogPlugins merges the latest compatible version of every plugin of the
+// offline marketplace catalog to remote marketplace plugins list, overwriting remote plugins only
+// if newer.
+func (a *App) mergeMarketplaceCatalogPlugins(remoteMarketplacePlugins map[string]*model.MarketplacePlugin) *model.AppError {
+	plugins, appErr := a.ch.getMarketplaceCatalog()
+	if appErr != nil {
+		return appErr
+	}
+
+	filter := a.getBaseMarketplaceFilter()
+	catalogPlugins, err := marketplace.NewCatalog(plugins).GetPlugins(filter)
+	if err != nil {
+		return model.NewAppError("mergeMarketplaceCatalogPlugins", "app.plugin.marketplace_catalog.read.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, p := range catalogPlugins {
+		if remotePlugin := remoteMarketplacePlugins[p.Manifest.Id]; remotePlugin != nil {
+			catalogVersion, err := semver.Parse(p.Manifest.Version)
+			if err != nil {
+				return model.NewAppError("mergeMarketplaceCatalogPlugins", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+			}
+			remoteVersion, err := semver.Parse(remotePlugin.Manifest.Version)
+			if err != nil {
+				return model.NewAppError("mergeMarketplaceCatalogPlugins", "app.plugin.invalid_version.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+			}
+			if !catalogVersion.GT(remoteVersion) {
+				continue
+			}
+		}
+
+		remoteMarketplacePlugins[p.Manifest.Id] = &model.MarketplacePlugin{BaseMarketplacePlugin: p}
+	}
+
+	return nil
+}
@@ -1734,6 +1734,8 @@ func (a *App) SearchPostsForUser(c request.CTX, terms string, userID string, tea
 		return nil, appErr
 	}
 
+	a.applySearchResultsWillBeReturnedHook(c, userID, postSearchResults)
+
 	return postSearchResults, nil
 }
 
@@ -2403,6 +2405,24 @@ func (a *App) applyPostWillBeConsumedHook(post **model.Post) {
 	}, plugin.MessagesWillBeConsumedID)
 }
 
+// applySearchResultsWillBeReturnedHook lets plugins filter or redact the posts found by a search,
+// dropping the matches of the posts they removed.
+func (a *App) applySearchResultsWillBeReturnedHook(c request.CTX, userID string, results *model.PostSearchResults) {
+	pluginContext := pluginContext(c)
+	a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+		if posts, _ := hooks.SearchResultsWillBeReturned(pluginContext, userID, results.PostList, nil); posts != nil {
+			results.PostList = posts
+		}
+		return true
+	}, plugin.SearchResultsWillBeReturnedID)
+
+	for postID := range results.Matches {
+		if _, ok := results.Posts[postID]; !ok {
+			delete(results.Matches, postID)
+		}
+	}
+}
+
 func makePostLink(siteURL, teamName, postID string) string {
 	return fmt.Sprintf("%s/%s/pl/%s", siteURL, teamName, postID)
 }
@@ -142,15 +142,50 @@ func (a *App) SendPersistentNotifications() error {
 		if err := a.Srv().Store().PostPersistentNotification().UpdateLastActivity(postIds); err != nil {
 			return errors.Wrapf(err, "failed to update lastActivity for notifications: %v", postIds)
 		}
+
+		if _, err := a.escalatePersistentNotifications(request.EmptyContext(a.Log()), notificationPosts, posts, true); err != nil {
+			mlog.Warn("Failed to escalate persistent notifications", mlog.Err(err))
+		}
+	}
+
+	// Notifications sent for the last time are kept while their escalation steps are
+	// deferred by quiet hours, for the steps to run once they're over.
+	deferredPostIDs, err := a.escalateExpiredPersistentNotifications(notificationMaxCount)
+	if err != nil {
+		mlog.Warn("Failed to escalate expired persistent notifications", mlog.Err(err))
 	}
 
-	if err := a.Srv().Store().PostPersistentNotification().DeleteExpired(notificationMaxCount); err != nil {
+	if err := a.Srv().Store().PostPersistentNotification().DeleteExpired(notificationMaxCount, deferredPostIDs); err != nil {
 		return errors.Wrap(err, "failed to delete expired notifications")
 	}
 
 	return nil
 }
 
+// escalateExpiredPersistentNotifications runs the escalation steps still due for
+// the notifications which won't be sent anymore, and returns the ids of the
+// posts whose steps are deferred by quiet hours.
+func (a *App) escalateExpiredPersistentNotifications(maxSentCount int16) ([]string, error) {
+	notificationPosts, err := a.Srv().Store().PostPersistentNotification().GetExpired(maxSentCount)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get expired notifications")
+	}
+	if len(notificationPosts) == 0 {
+		return nil, nil
+	}
+
+	postIds := make([]string, 0, len(notificationPosts))
+	for _, p := range notificationPosts {
+		postIds = append(postIds, p.PostId)
+	}
+	posts, err := a.Srv().Store().Post().GetPostsByIds(postIds)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get posts by IDs")
+	}
+
+	return a.escalatePersistentNotifications(request.EmptyContext(a.Log()), notificationPosts, posts, false)
+}
+
 func (a *App) forEachPersistentNotificationPost(posts []*model.Post, fn func(post *model.Post, channel *model.Channel, team *model.Team, mentions *MentionResults, profileMap model.UserMap, channelNotifyProps map[string]map[string]model.StringMap) error) error {
 	channelsMap, teamsMap, err := a.channelTeamMapsForPosts(posts)
 	if err != nil {
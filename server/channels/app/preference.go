@@ -13,6 +13,12 @@ import (
 	"github.com/mattermost/mattermost/server/public/shared/request"
 )
 
+// isInternalPreference reports whether the preference is managed by the server, such as the
+// calendar which drives the user's status, and so can't be written through the preferences API.
+func isInternalPreference(preference model.Preference) bool {
+	return preference.Category == model.PreferenceCategoryCalendar
+}
+
 func (a *App) GetPreferencesForUser(c request.CTX, userID string) (model.Preferences, *model.AppError) {
 	preferences, err := a.Srv().Store().Preference().GetAll(userID)
 	if err != nil {
@@ -47,8 +53,17 @@ func (a *App) UpdatePreferences(c request.CTX, userID string, preferences model.
 			return model.NewAppError("savePreferences", "api.preference.update_preferences.set.app_error", nil,
 				"userId="+userID+", preference.UserId="+preference.UserId, http.StatusForbidden)
 		}
+		if isInternalPreference(preference) {
+			return model.NewAppError("UpdatePreferences", "api.preference.update_preferences.internal.app_error", nil,
+				"category="+preference.Category+", name="+preference.Name, http.StatusForbidden)
+		}
 	}
 
+	return a.updatePreferences(c, userID, preferences)
+}
+
+// updatePreferences saves the given preferences, including the ones managed by the server.
+func (a *App) updatePreferences(c request.CTX, userID string, preferences model.Preferences) *model.AppError {
 	if err := a.Srv().Store().Preference().Save(preferences); err != nil {
 		var appErr *model.AppError
 		switch {
@@ -93,8 +108,17 @@ func (a *App) DeletePreferences(c request.CTX, userID string, preferences model.
 				"userId="+userID+", preference.UserId="+preference.UserId, http.StatusForbidden)
 			return err
 		}
+		if isInternalPreference(preference) {
+			return model.NewAppError("DeletePreferences", "api.preference.update_preferences.internal.app_error", nil,
+				"category="+preference.Category+", name="+preference.Name, http.StatusForbidden)
+		}
 	}
 
+	return a.deletePreferences(c, userID, preferences)
+}
+
+// deletePreferences deletes the given preferences, including the ones managed by the server.
+func (a *App) deletePreferences(c request.CTX, userID string, preferences model.Preferences) *model.AppError {
 	for _, preference := range preferences {
 		if err := a.Srv().Store().Preference().Delete(userID, preference.Category, preference.Name); err != nil {
 			return model.NewAppError("DeletePreferences", "app.preference.delete.app_error", nil, "", http.StatusBadRequest).Wrap(err)
@@ -0,0 +1,125 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const samlAssertionNamespace = "urn:oasis:names:tc:SAML:2.0:assertion"
+
+// errSamlAssertionEncrypted is returned for responses whose assertion is encrypted, since the
+// attributes of encrypted assertions can't be read outside of the SAML service.
+var errSamlAssertionEncrypted = errors.New("the SAML assertion is encrypted")
+
+type samlAssertion struct {
+	AttributeStatements []struct {
+		Attributes []struct {
+			Name   string   `xml:"Name,attr"`
+			Values []string `xml:"AttributeValue"`
+		} `xml:"Attribute"`
+	} `xml:"AttributeStatement"`
+}
+
+// samlAssertionAttributes returns the attributes of the assertion of a base64 encoded SAML
+// response. The response has to hold a single assertion, so that the attributes are read from the
+// assertion the SAML service validated.
+func samlAssertionAttributes(encodedXML string) (map[string][]string, error) {
+	data, err := base64.StdEncoding.DecodeString(encodedXML)
+	if err != nil {
+		return nil, err
+	}
+
+	var assertions, encryptedAssertions int
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+
+		if start, ok := token.(xml.StartElement); ok && start.Name.Space == samlAssertionNamespace {
+			switch start.Name.Local {
+			case "Assertion":
+				assertions++
+			case "EncryptedAssertion":
+				encryptedAssertions++
+			}
+		}
+	}
+	if encryptedAssertions > 0 && assertions == 0 {
+		return nil, errSamlAssertionEncrypted
+	}
+	if assertions != 1 || encryptedAssertions != 0 {
+		return nil, errors.New("the SAML response has to hold a single assertion")
+	}
+
+	var assertion samlAssertion
+	decoder = xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		if start, ok := token.(xml.StartElement); ok && start.Name.Space == samlAssertionNamespace && start.Name.Local == "Assertion" {
+			if err := decoder.DecodeElement(&assertion, &start); err != nil {
+				return nil, err
+			}
+			break
+		}
+	}
+
+	attributes := make(map[string][]string)
+	for _, statement := range assertion.AttributeStatements {
+		for _, attribute := range statement.Attributes {
+			for _, value := range attribute.Values {
+				if value = strings.TrimSpace(value); value != "" {
+					attributes[attribute.Name] = append(attributes[attribute.Name], value)
+				}
+			}
+		}
+	}
+
+	return attributes, nil
+}
+
+// SyncSamlUserAttributeGroups synchronizes the attribute groups of a user logged in with the
+// given SAML response. The attributes are only read when the response matches the user, by the
+// id attribute or else the email attribute of the SAML settings. The attributes of encrypted
+// assertions can't be read, so users logging in with them keep their attribute groups.
+func (a *App) SyncSamlUserAttributeGroups(rctx request.CTX, user *model.User, encodedXML string) *model.AppError {
+	attributes, err := samlAssertionAttributes(encodedXML)
+	if errors.Is(err, errSamlAssertionEncrypted) {
+		rctx.Logger().Debug("Skipping the attribute groups of a user logged in with an encrypted SAML assertion", mlog.String("user_id", user.Id))
+		return nil
+	} else if err != nil {
+		return model.NewAppError("SyncSamlUserAttributeGroups", "app.attribute_group.saml_attributes.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	settings := a.Config().SamlSettings
+	matches := false
+	if idAttribute := *settings.IdAttribute; idAttribute != "" {
+		matches = user.AuthData != nil && len(attributes[idAttribute]) == 1 && attributes[idAttribute][0] == *user.AuthData
+	} else if emailAttribute := *settings.EmailAttribute; emailAttribute != "" {
+		matches = len(attributes[emailAttribute]) == 1 && strings.EqualFold(attributes[emailAttribute][0], user.Email)
+	}
+	if !matches {
+		return model.NewAppError("SyncSamlUserAttributeGroups", "app.attribute_group.saml_attributes.user_mismatch.app_error", nil, "user_id="+user.Id, http.StatusBadRequest)
+	}
+
+	return a.SyncUserAttributeGroups(rctx, user.Id, attributes)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func encodeSamlResponse(assertions string) string {
+	return base64.StdEncoding.EncodeToString([]byte(`<samlp:Response xmlns:samlp="urn:oasis:names:tc:SAML:2.0:protocol" xmlns:saml="urn:oasis:names:tc:SAML:2.0:assertion">` + assertions + `</samlp:Response>`))
+}
+
+const samlTestAssertion = `<saml:Assertion>
+	<saml:Subject><saml:NameID>user@example.com</saml:NameID></saml:Subject>
+	<saml:AttributeStatement>
+		<saml:Attribute Name="Email"><saml:AttributeValue>user@example.com</saml:AttributeValue></saml:Attribute>
+		<saml:Attribute Name="department">
+			<saml:AttributeValue>Engineering</saml:AttributeValue>
+			<saml:AttributeValue> </saml:AttributeValue>
+			<saml:AttributeValue>Research</saml:AttributeValue>
+		</saml:Attribute>
+	</saml:AttributeStatement>
+</saml:Assertion>`
+
+func TestSamlAssertionAttributes(t *testing.T) {
+	attributes, err := samlAssertionAttributes(encodeSamlResponse(samlTestAssertion))
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"Email":      {"user@example.com"},
+		"department": {"Engineering", "Research"},
+	}, attributes)
+
+	t.Run("several assertions", func(t *testing.T) {
+		_, err := samlAssertionAttributes(encodeSamlResponse(samlTestAssertion + samlTestAssertion))
+		assert.Error(t, err)
+	})
+
+	t.Run("nested assertion", func(t *testing.T) {
+		_, err := samlAssertionAttributes(encodeSamlResponse(`<samlp:Extensions>` + samlTestAssertion + `</samlp:Extensions>` + samlTestAssertion))
+		assert.Error(t, err)
+	})
+
+	t.Run("encrypted assertion", func(t *testing.T) {
+		_, err := samlAssertionAttributes(encodeSamlResponse(`<saml:EncryptedAssertion></saml:EncryptedAssertion>`))
+		assert.ErrorIs(t, err, errSamlAssertionEncrypted)
+	})
+
+	t.Run("invalid response", func(t *testing.T) {
+		_, err := samlAssertionAttributes("not base64")
+		assert.Error(t, err)
+	})
+}
+
+func TestSyncSamlUserAttributeGroups(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.SamlSettings.IdAttribute = ""
+		*cfg.SamlSettings.EmailAttribute = "Email"
+	})
+
+	user := th.CreateUser()
+	user.Email = "user@example.com"
+	_, err := th.App.Srv().Store().User().Update(th.Context, user, true)
+	require.NoError(t, err)
+
+	createAttributeGroup(t, th, "department", "Engineering")
+
+	require.Nil(t, th.App.SyncSamlUserAttributeGroups(th.Context, user, encodeSamlResponse(samlTestAssertion)))
+
+	attributes, err := th.App.Srv().Store().AttributeGroup().GetUserAttributes(user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Engineering", "Research"}, attributes["department"])
+
+	appErr := th.App.SyncSamlUserAttributeGroups(th.Context, th.BasicUser, encodeSamlResponse(samlTestAssertion))
+	require.NotNil(t, appErr)
+	assert.Equal(t, "app.attribute_group.saml_attributes.user_mismatch.app_error", appErr.Id)
+}
@@ -0,0 +1,795 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+// GetScimBaseURL returns the URL the SCIM resources are served under.
+func (a *App) GetScimBaseURL() string {
+	return a.GetSiteURL() + "/scim/v2"
+}
+
+func (a *App) checkScimEnabled(where string) *model.AppError {
+	if !*a.Config().ServiceSettings.EnableScimProvisioning {
+		return model.NewAppError(where, "api.scim.disabled.app_error", nil, "", http.StatusNotImplemented)
+	}
+	return nil
+}
+
+// getScimUserModel returns a user managed through SCIM, which excludes bots and the users of
+// remote clusters.
+func (a *App) getScimUserModel(userID string) (*model.User, *model.AppError) {
+	user, appErr := a.GetUser(userID)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return nil, model.NewAppError("getScimUserModel", "app.scim.user_not_found.app_error", nil, "", http.StatusNotFound).Wrap(appErr)
+		}
+		return nil, appErr
+	}
+
+	if user.IsBot || user.IsRemote() {
+		return nil, model.NewAppError("getScimUserModel", "app.scim.user_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	return user, nil
+}
+
+func (a *App) newScimUser(user *model.User) (*model.ScimUser, *model.AppError) {
+	groups, appErr := a.GetGroupsByUserId(user.Id)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	scimGroups := make([]*model.Group, 0, len(groups))
+	for _, group := range groups {
+		if group.Source == model.GroupSourceScim && group.DeleteAt == 0 {
+			scimGroups = append(scimGroups, group)
+		}
+	}
+
+	return model.NewScimUser(user, scimGroups, a.GetScimBaseURL()), nil
+}
+
+func (a *App) GetScimUser(userID string) (*model.ScimUser, *model.AppError) {
+	if appErr := a.checkScimEnabled("GetScimUser"); appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.getScimUserModel(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.newScimUser(user)
+}
+
+// GetScimUsers returns a page of users, starting at the 1-based startIndex. Users can only be
+// filtered by an equality with their userName, emails or externalId, possibly along with other
+// expressions, since the other attributes aren't indexed.
+func (a *App) GetScimUsers(filter string, startIndex, count int) (*model.ScimListResponse, *model.AppError) {
+	if appErr := a.checkScimEnabled("GetScimUsers"); appErr != nil {
+		return nil, appErr
+	}
+
+	if filter != "" {
+		return a.getScimUsersByFilter(filter)
+	}
+
+	// Bots and remote users aren't provisioned, so they are neither counted nor returned.
+	total, err := a.Srv().Store().User().Count(model.UserCountOptions{IncludeDeleted: true})
+	if err != nil {
+		return nil, model.NewAppError("GetScimUsers", "app.user.get_total_users_count.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	users, err := a.Srv().Store().User().GetAllLocalUsers(startIndex-1, count)
+	if err != nil {
+		return nil, model.NewAppError("GetScimUsers", "app.user.get_profiles.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	resources := []*model.ScimUser{}
+	for _, user := range users {
+		// The groups of users are only returned along with a single user.
+		resources = append(resources, model.NewScimUser(user, nil, a.GetScimBaseURL()))
+	}
+
+	return &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: int(total),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+func (a *App) getScimUsersByFilter(filter string) (*model.ScimListResponse, *model.AppError) {
+	parsed, err := model.ParseScimFilter(filter)
+	if err != nil {
+		return nil, model.NewAppError("GetScimUsers", "api.scim.invalid_filter.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	var user *model.User
+	var appErr *model.AppError
+	if userName, ok := parsed.Lookup("userName"); ok {
+		user, appErr = a.GetUserByUsername(userName)
+	} else if email, ok := parsed.Lookup("emails.value"); ok {
+		user, appErr = a.GetUserByEmail(email)
+	} else if email, ok := parsed.Lookup("emails"); ok {
+		user, appErr = a.GetUserByEmail(email)
+	} else if externalId, ok := parsed.Lookup("externalId"); ok {
+		user, appErr = a.getUserByScimExternalId(externalId)
+	} else {
+		return nil, model.NewAppError("GetScimUsers", "api.scim.invalid_filter.app_error", nil, "filter="+filter, http.StatusBadRequest)
+	}
+	if appErr != nil && appErr.StatusCode != http.StatusNotFound {
+		return nil, appErr
+	}
+
+	resources := []*model.ScimUser{}
+	if user != nil && !user.IsBot && !user.IsRemote() {
+		scimUser, appErr := a.newScimUser(user)
+		if appErr != nil {
+			return nil, appErr
+		}
+		if parsed.Matches(scimUserValues(scimUser)) {
+			resources = append(resources, scimUser)
+		}
+	}
+
+	return &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: len(resources),
+		StartIndex:   1,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+// getUserByScimExternalId returns the user with the given externalId, which is only indexed as
+// the auth data of the users of the auth service of provisioned users.
+func (a *App) getUserByScimExternalId(externalId string) (*model.User, *model.AppError) {
+	authService := *a.Config().ServiceSettings.ScimAuthService
+	if authService == "" {
+		return nil, model.NewAppError("getUserByScimExternalId", "api.scim.invalid_filter.app_error", nil, "externalId filters require an auth service", http.StatusBadRequest)
+	}
+
+	user, appErr := a.GetUserByAuth(model.NewPointer(externalId), authService)
+	if appErr != nil {
+		if appErr.Id == MissingAuthAccountError {
+			return nil, model.NewAppError("getUserByScimExternalId", "app.scim.user_not_found.app_error", nil, "", http.StatusNotFound).Wrap(appErr)
+		}
+		return nil, appErr
+	}
+
+	return user, nil
+}
+
+func scimUserValues(user *model.ScimUser) func(attribute string) []string {
+	return func(attribute string) []string {
+		switch attribute {
+		case "id":
+			return []string{user.Id}
+		case "username":
+			return []string{user.UserName}
+		case "externalid":
+			return []string{user.ExternalId}
+		case "displayname":
+			return []string{user.DisplayName}
+		case "nickname":
+			return []string{user.NickName}
+		case "locale":
+			return []string{user.Locale}
+		case "active":
+			return []string{strconv.FormatBool(user.Active != nil && *user.Active)}
+		case "emails", "emails.value":
+			values := make([]string, 0, len(user.Emails))
+			for _, email := range user.Emails {
+				values = append(values, email.Value)
+			}
+			return values
+		case "name.givenname":
+			if user.Name != nil {
+				return []string{user.Name.GivenName}
+			}
+		case "name.familyname":
+			if user.Name != nil {
+				return []string{user.Name.FamilyName}
+			}
+		}
+		return nil
+	}
+}
+
+// CreateScimUser provisions a user. Users logging in with a password get a random one unless
+// the identity provider sets it, and reset it to log in.
+func (a *App) CreateScimUser(rctx request.CTX, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	if appErr := a.checkScimEnabled("CreateScimUser"); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := scimUser.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	// Users are provisioned by a trusted identity provider.
+	user := &model.User{EmailVerified: true}
+	scimUser.ApplyTo(user)
+
+	if authService := *a.Config().ServiceSettings.ScimAuthService; authService != "" {
+		if scimUser.ExternalId == "" {
+			return nil, model.NewAppError("CreateScimUser", "app.scim.external_id_required.app_error", nil, "", http.StatusBadRequest)
+		}
+		user.AuthService = authService
+		user.AuthData = model.NewPointer(scimUser.ExternalId)
+	} else {
+		user.Password = scimUser.Password
+		if user.Password == "" {
+			password, err := generatePassword(*a.Config().PasswordSettings.MinimumLength)
+			if err != nil {
+				return nil, model.NewAppError("CreateScimUser", "app.scim.generate_password.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			user.Password = password
+		}
+	}
+
+	ruser, appErr := a.CreateUser(rctx, user)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	if scimUser.Active != nil && !*scimUser.Active {
+		if ruser, appErr = a.UpdateActive(rctx, ruser, false); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.newScimUser(ruser)
+}
+
+// ReplaceScimUser replaces the attributes of a user with the ones of the SCIM user.
+func (a *App) ReplaceScimUser(rctx request.CTX, userID string, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	if appErr := a.checkScimEnabled("ReplaceScimUser"); appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.getScimUserModel(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.replaceScimUser(rctx, user, scimUser)
+}
+
+func (a *App) replaceScimUser(rctx request.CTX, user *model.User, scimUser *model.ScimUser) (*model.ScimUser, *model.AppError) {
+	if appErr := scimUser.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	// Reactivate the user first, since only active users are updated.
+	if scimUser.Active != nil && *scimUser.Active && user.DeleteAt != 0 {
+		var appErr *model.AppError
+		if user, appErr = a.UpdateActive(rctx, user, true); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	scimUser.ApplyTo(user)
+	updated, appErr := a.UpdateUser(rctx, user, false)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	authService := *a.Config().ServiceSettings.ScimAuthService
+	if authService != "" && scimUser.ExternalId != "" && updated.AuthService == authService && model.SafeDereference(user.AuthData) != scimUser.ExternalId {
+		if _, appErr = a.UpdateUserAuth(rctx, updated.Id, &model.UserAuth{AuthService: authService, AuthData: model.NewPointer(scimUser.ExternalId)}); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if scimUser.Password != "" && updated.AuthService == "" {
+		if appErr = a.UpdatePassword(rctx, updated, scimUser.Password); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if scimUser.Active != nil && !*scimUser.Active && updated.DeleteAt == 0 {
+		if updated, appErr = a.UpdateActive(rctx, updated, false); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return a.newScimUser(updated)
+}
+
+// PatchScimUser applies the operations of the patch to a user.
+func (a *App) PatchScimUser(rctx request.CTX, userID string, patch *model.ScimPatchRequest) (*model.ScimUser, *model.AppError) {
+	if appErr := a.checkScimEnabled("PatchScimUser"); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := patch.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	user, appErr := a.getScimUserModel(userID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	current, appErr := a.newScimUser(user)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var patched model.ScimUser
+	if appErr := applyScimPatch(current, patch, model.ScimSchemaUser, &patched); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.replaceScimUser(rctx, user, &patched)
+}
+
+// DeleteScimUser deactivates a user, whose content is kept.
+func (a *App) DeleteScimUser(rctx request.CTX, userID string) *model.AppError {
+	if appErr := a.checkScimEnabled("DeleteScimUser"); appErr != nil {
+		return appErr
+	}
+
+	user, appErr := a.getScimUserModel(userID)
+	if appErr != nil {
+		return appErr
+	}
+
+	if user.DeleteAt != 0 {
+		return nil
+	}
+
+	_, appErr = a.UpdateActive(rctx, user, false)
+	return appErr
+}
+
+// applyScimPatch applies the patch to the JSON representation of the resource, decoding the
+// result in patched.
+func applyScimPatch(resource any, patch *model.ScimPatchRequest, schema string, patched any) *model.AppError {
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return model.NewAppError("applyScimPatch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	var object map[string]any
+	if err = json.Unmarshal(data, &object); err != nil {
+		return model.NewAppError("applyScimPatch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	for _, operation := range patch.Operations {
+		if err = model.ApplyScimPatch(object, operation, schema); err != nil {
+			return model.NewAppError("applyScimPatch", "api.scim.invalid_patch.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	if data, err = json.Marshal(object); err != nil {
+		return model.NewAppError("applyScimPatch", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	if err = json.Unmarshal(data, patched); err != nil {
+		return model.NewAppError("applyScimPatch", "api.scim.invalid_patch.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return nil
+}
+
+func (a *App) getScimGroupModel(groupID string) (*model.Group, *model.AppError) {
+	group, appErr := a.GetGroup(groupID, nil, nil)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return nil, model.NewAppError("getScimGroupModel", "app.scim.group_not_found.app_error", nil, "", http.StatusNotFound).Wrap(appErr)
+		}
+		return nil, appErr
+	}
+
+	if group.Source != model.GroupSourceScim || group.DeleteAt != 0 {
+		return nil, model.NewAppError("getScimGroupModel", "app.scim.group_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	return group, nil
+}
+
+func (a *App) newScimGroup(group *model.Group, withMembers bool) (*model.ScimGroup, *model.AppError) {
+	var members []*model.User
+	if withMembers {
+		var appErr *model.AppError
+		if members, appErr = a.GetGroupMemberUsers(group.Id); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	return model.NewScimGroup(group, members, a.GetScimBaseURL()), nil
+}
+
+// GetScimGroup returns a group provisioned through SCIM. Its members are left out unless
+// withMembers is true, since identity providers exclude them when they only check the group.
+func (a *App) GetScimGroup(groupID string, withMembers bool) (*model.ScimGroup, *model.AppError) {
+	if appErr := a.checkScimEnabled("GetScimGroup"); appErr != nil {
+		return nil, appErr
+	}
+
+	group, appErr := a.getScimGroupModel(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.newScimGroup(group, withMembers)
+}
+
+// GetScimGroups returns a page of the groups provisioned through SCIM matching the filter,
+// starting at the 1-based startIndex.
+func (a *App) GetScimGroups(filter string, startIndex, count int, withMembers bool) (*model.ScimListResponse, *model.AppError) {
+	if appErr := a.checkScimEnabled("GetScimGroups"); appErr != nil {
+		return nil, appErr
+	}
+
+	var parsed model.ScimFilter
+	if filter != "" {
+		var err error
+		if parsed, err = model.ParseScimFilter(filter); err != nil {
+			return nil, model.NewAppError("GetScimGroups", "api.scim.invalid_filter.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+		}
+	}
+
+	groups, appErr := a.GetGroupsBySource(model.GroupSourceScim)
+	if appErr != nil {
+		return nil, appErr
+	}
+	sort.Slice(groups, func(i, j int) bool { return groups[i].CreateAt < groups[j].CreateAt })
+
+	var matching []*model.Group
+	for _, group := range groups {
+		if group.DeleteAt != 0 {
+			continue
+		}
+		if parsed != nil {
+			matches, appErr := a.scimGroupMatches(group, parsed)
+			if appErr != nil {
+				return nil, appErr
+			}
+			if !matches {
+				continue
+			}
+		}
+		matching = append(matching, group)
+	}
+
+	resources := []*model.ScimGroup{}
+	for i := startIndex - 1; i >= 0 && i < len(matching) && len(resources) < count; i++ {
+		scimGroup, appErr := a.newScimGroup(matching[i], withMembers)
+		if appErr != nil {
+			return nil, appErr
+		}
+		resources = append(resources, scimGroup)
+	}
+
+	return &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: len(matching),
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}, nil
+}
+
+func (a *App) scimGroupMatches(group *model.Group, filter model.ScimFilter) (bool, *model.AppError) {
+	var memberIDs []string
+	var appErr *model.AppError
+
+	matches := filter.Matches(func(attribute string) []string {
+		switch attribute {
+		case "id":
+			return []string{group.Id}
+		case "displayname":
+			return []string{group.DisplayName}
+		case "externalid":
+			return []string{group.GetRemoteId()}
+		case "members", "members.value":
+			if memberIDs == nil && appErr == nil {
+				var members []*model.User
+				if members, appErr = a.GetGroupMemberUsers(group.Id); appErr == nil {
+					memberIDs = make([]string, 0, len(members))
+					for _, member := range members {
+						memberIDs = append(memberIDs, member.Id)
+					}
+				}
+			}
+			return memberIDs
+		}
+		return nil
+	})
+
+	return matches, appErr
+}
+
+// CreateScimGroup provisions a group along with its members.
+func (a *App) CreateScimGroup(rctx request.CTX, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	if appErr := a.checkScimEnabled("CreateScimGroup"); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := scimGroup.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.checkScimExternalIdIsUnique(scimGroup.ExternalId, ""); appErr != nil {
+		return nil, appErr
+	}
+
+	memberIDs := scimGroup.MemberIds()
+	if appErr := a.checkScimGroupMembersExist(memberIDs); appErr != nil {
+		return nil, appErr
+	}
+
+	group := &model.GroupWithUserIds{
+		Group: model.Group{
+			DisplayName: scimGroup.DisplayName,
+			Source:      model.GroupSourceScim,
+		},
+		UserIds: memberIDs,
+	}
+	if scimGroup.ExternalId != "" {
+		group.RemoteId = model.NewPointer(scimGroup.ExternalId)
+	}
+
+	created, appErr := a.CreateGroupWithUserIds(group)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.newScimGroup(created, true)
+}
+
+func (a *App) checkScimExternalIdIsUnique(externalId, groupID string) *model.AppError {
+	if externalId == "" {
+		return nil
+	}
+
+	existing, appErr := a.GetGroupByRemoteID(externalId, model.GroupSourceScim)
+	if appErr != nil {
+		if appErr.StatusCode == http.StatusNotFound {
+			return nil
+		}
+		return appErr
+	}
+
+	if existing.Id != groupID {
+		return model.NewAppError("checkScimExternalIdIsUnique", "app.scim.group_exists.app_error", nil, "", http.StatusConflict)
+	}
+
+	return nil
+}
+
+func (a *App) checkScimGroupMembersExist(userIDs []string) *model.AppError {
+	if len(userIDs) == 0 {
+		return nil
+	}
+
+	users, appErr := a.GetUsers(userIDs)
+	if appErr != nil {
+		return appErr
+	}
+
+	if len(users) != len(userIDs) {
+		return model.NewAppError("checkScimGroupMembersExist", "app.scim.member_not_found.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// ReplaceScimGroup replaces the attributes and the members of a group.
+func (a *App) ReplaceScimGroup(rctx request.CTX, groupID string, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	if appErr := a.checkScimEnabled("ReplaceScimGroup"); appErr != nil {
+		return nil, appErr
+	}
+
+	group, appErr := a.getScimGroupModel(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return a.replaceScimGroup(rctx, group, scimGroup)
+}
+
+func (a *App) replaceScimGroup(rctx request.CTX, group *model.Group, scimGroup *model.ScimGroup) (*model.ScimGroup, *model.AppError) {
+	if appErr := scimGroup.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := a.checkScimExternalIdIsUnique(scimGroup.ExternalId, group.Id); appErr != nil {
+		return nil, appErr
+	}
+
+	if scimGroup.DisplayName != group.DisplayName || scimGroup.ExternalId != group.GetRemoteId() {
+		group.DisplayName = scimGroup.DisplayName
+		group.RemoteId = nil
+		if scimGroup.ExternalId != "" {
+			group.RemoteId = model.NewPointer(scimGroup.ExternalId)
+		}
+
+		var appErr *model.AppError
+		if group, appErr = a.UpdateGroup(group); appErr != nil {
+			return nil, appErr
+		}
+	}
+
+	if appErr := a.setScimGroupMembers(rctx, group, scimGroup.MemberIds()); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.newScimGroup(group, true)
+}
+
+// setScimGroupMembers makes the users the only members of the group, and updates the members
+// of the teams and channels the group is linked to.
+func (a *App) setScimGroupMembers(rctx request.CTX, group *model.Group, userIDs []string) *model.AppError {
+	members, appErr := a.GetGroupMemberUsers(group.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	var removed []string
+	for _, member := range members {
+		if !slices.Contains(userIDs, member.Id) {
+			removed = append(removed, member.Id)
+		}
+	}
+
+	var added []string
+	for _, userID := range userIDs {
+		if !slices.ContainsFunc(members, func(member *model.User) bool { return member.Id == userID }) {
+			added = append(added, userID)
+		}
+	}
+
+	if appErr = a.checkScimGroupMembersExist(added); appErr != nil {
+		return appErr
+	}
+
+	syncables, appErr := a.getScimGroupSyncables(group.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	since := model.GetMillis()
+	if len(added) > 0 {
+		if _, appErr = a.UpsertGroupMembers(group.Id, added); appErr != nil {
+			return appErr
+		}
+	}
+	if len(removed) > 0 {
+		if _, appErr = a.DeleteGroupMembers(group.Id, removed); appErr != nil {
+			return appErr
+		}
+	}
+
+	a.syncScimGroupSyncables(rctx, syncables, since, len(added) > 0, len(removed) > 0)
+
+	return nil
+}
+
+// getScimGroupSyncables returns the teams and channels the group is linked to.
+func (a *App) getScimGroupSyncables(groupID string) ([]*model.GroupSyncable, *model.AppError) {
+	teams, appErr := a.GetGroupSyncables(groupID, model.GroupSyncableTypeTeam)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	channels, appErr := a.GetGroupSyncables(groupID, model.GroupSyncableTypeChannel)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	return append(teams, channels...), nil
+}
+
+// syncScimGroupSyncables adds the new members of a group to the teams and channels it is linked
+// to, and removes the members who left it from those of them constrained to groups, in the
+// background. Only the given teams and channels of the group are synchronized.
+func (a *App) syncScimGroupSyncables(rctx request.CTX, syncables []*model.GroupSyncable, since int64, membersAdded, membersRemoved bool) {
+	if len(syncables) == 0 || (!membersAdded && !membersRemoved) {
+		return
+	}
+
+	a.Srv().Go(func() {
+		for _, syncable := range syncables {
+			logger := rctx.Logger().With(mlog.String("group_id", syncable.GroupId), mlog.String("syncable_id", syncable.SyncableId))
+			params := model.CreateDefaultMembershipParams{Since: since}
+
+			switch syncable.Type {
+			case model.GroupSyncableTypeTeam:
+				params.ScopedTeamID = &syncable.SyncableId
+				if membersAdded {
+					if err := a.createDefaultTeamMemberships(rctx, params); err != nil {
+						logger.Warn("Failed to add the members of a SCIM group to its team", mlog.Err(err))
+					}
+				}
+				if membersRemoved {
+					if err := a.deleteGroupConstrainedTeamMemberships(rctx, &syncable.SyncableId); err != nil {
+						logger.Warn("Failed to remove the former members of a SCIM group from its team", mlog.Err(err))
+					}
+				}
+			case model.GroupSyncableTypeChannel:
+				params.ScopedChannelID = &syncable.SyncableId
+				if membersAdded {
+					if err := a.createDefaultChannelMemberships(rctx, params); err != nil {
+						logger.Warn("Failed to add the members of a SCIM group to its channel", mlog.Err(err))
+					}
+				}
+				if membersRemoved {
+					if err := a.deleteGroupConstrainedChannelMemberships(rctx, &syncable.SyncableId); err != nil {
+						logger.Warn("Failed to remove the former members of a SCIM group from its channel", mlog.Err(err))
+					}
+				}
+			}
+		}
+	})
+}
+
+// PatchScimGroup applies the operations of the patch to a group.
+func (a *App) PatchScimGroup(rctx request.CTX, groupID string, patch *model.ScimPatchRequest) (*model.ScimGroup, *model.AppError) {
+	if appErr := a.checkScimEnabled("PatchScimGroup"); appErr != nil {
+		return nil, appErr
+	}
+
+	if appErr := patch.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	group, appErr := a.getScimGroupModel(groupID)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	current, appErr := a.newScimGroup(group, true)
+	if appErr != nil {
+		return nil, appErr
+	}
+
+	var patched model.ScimGroup
+	if appErr := applyScimPatch(current, patch, model.ScimSchemaGroup, &patched); appErr != nil {
+		return nil, appErr
+	}
+
+	return a.replaceScimGroup(rctx, group, &patched)
+}
+
+// DeleteScimGroup deletes a group, removing its members from the teams and channels constrained
+// to it.
+func (a *App) DeleteScimGroup(rctx request.CTX, groupID string) *model.AppError {
+	if appErr := a.checkScimEnabled("DeleteScimGroup"); appErr != nil {
+		return appErr
+	}
+
+	group, appErr := a.getScimGroupModel(groupID)
+	if appErr != nil {
+		return appErr
+	}
+
+	syncables, appErr := a.getScimGroupSyncables(group.Id)
+	if appErr != nil {
+		return appErr
+	}
+
+	if _, appErr = a.DeleteGroup(group.Id); appErr != nil {
+		return appErr
+	}
+
+	a.syncScimGroupSyncables(rctx, syncables, 0, false, true)
+
+	return nil
+}
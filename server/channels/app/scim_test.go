@@ -0,0 +1,198 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestScimUsers(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	t.Run("disabled", func(t *testing.T) {
+		_, appErr := th.App.GetScimUser(th.BasicUser.Id)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotImplemented, appErr.StatusCode)
+	})
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableScimProvisioning = true })
+
+	username := "scim" + model.NewId()[:10]
+	created, appErr := th.App.CreateScimUser(th.Context, &model.ScimUser{
+		UserName:   username,
+		ExternalId: "ext-" + username,
+		Name:       &model.ScimName{GivenName: "Jane", FamilyName: "Doe"},
+		Emails:     []model.ScimMultiValue{{Value: username + "@example.com", Primary: true}},
+	})
+	require.Nil(t, appErr)
+	assert.Equal(t, username, created.UserName)
+	assert.Equal(t, "ext-"+username, created.ExternalId)
+	assert.True(t, *created.Active)
+
+	t.Run("duplicate", func(t *testing.T) {
+		_, appErr := th.App.CreateScimUser(th.Context, &model.ScimUser{
+			UserName: username,
+			Emails:   []model.ScimMultiValue{{Value: model.NewId() + "@example.com"}},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, "app.user.save.username_exists.app_error", appErr.Id)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		list, appErr := th.App.GetScimUsers(`userName eq "`+username+`"`, 1, model.ScimDefaultCount)
+		require.Nil(t, appErr)
+		assert.Equal(t, 1, list.TotalResults)
+
+		list, appErr = th.App.GetScimUsers(`userName eq "`+username+`" and active eq false`, 1, model.ScimDefaultCount)
+		require.Nil(t, appErr)
+		assert.Equal(t, 0, list.TotalResults)
+
+		_, appErr = th.App.GetScimUsers(`displayName eq "Jane Doe"`, 1, model.ScimDefaultCount)
+		require.NotNil(t, appErr)
+		assert.Equal(t, "api.scim.invalid_filter.app_error", appErr.Id)
+	})
+
+	t.Run("pages", func(t *testing.T) {
+		bot := th.CreateBot()
+
+		all, appErr := th.App.GetScimUsers("", 1, model.ScimMaxCount)
+		require.Nil(t, appErr)
+		users := all.Resources.([]*model.ScimUser)
+		require.Equal(t, all.TotalResults, len(users))
+		require.GreaterOrEqual(t, all.TotalResults, 2)
+		for _, user := range users {
+			assert.NotEqual(t, bot.UserId, user.Id)
+		}
+
+		page, appErr := th.App.GetScimUsers("", 2, 1)
+		require.Nil(t, appErr)
+		assert.Equal(t, all.TotalResults, page.TotalResults)
+		assert.Equal(t, 2, page.StartIndex)
+		pageUsers := page.Resources.([]*model.ScimUser)
+		require.Len(t, pageUsers, 1)
+		assert.Equal(t, users[1].Id, pageUsers[0].Id)
+	})
+
+	t.Run("patch", func(t *testing.T) {
+		patched, appErr := th.App.PatchScimUser(th.Context, created.Id, &model.ScimPatchRequest{
+			Operations: []model.ScimPatchOperation{
+				{Op: "replace", Path: "name.givenName", Value: json.RawMessage(`"Janet"`)},
+				{Op: "replace", Path: "active", Value: json.RawMessage(`"False"`)},
+			},
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, "Janet", patched.Name.GivenName)
+		assert.False(t, *patched.Active)
+
+		user, appErr := th.App.GetUser(created.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, user.DeleteAt)
+	})
+
+	t.Run("replace", func(t *testing.T) {
+		replaced, appErr := th.App.ReplaceScimUser(th.Context, created.Id, &model.ScimUser{
+			UserName: username,
+			Active:   model.NewPointer(true),
+			Emails:   []model.ScimMultiValue{{Value: username + "@example.org"}},
+		})
+		require.Nil(t, appErr)
+		assert.True(t, *replaced.Active)
+		assert.Equal(t, username+"@example.org", replaced.PrimaryEmail())
+		assert.Empty(t, replaced.ExternalId)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.Nil(t, th.App.DeleteScimUser(th.Context, created.Id))
+
+		user, appErr := th.App.GetUser(created.Id)
+		require.Nil(t, appErr)
+		assert.NotZero(t, user.DeleteAt)
+	})
+
+	t.Run("bots are not managed", func(t *testing.T) {
+		bot := th.CreateBot()
+		_, appErr := th.App.GetScimUser(bot.UserId)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
+
+func TestScimGroups(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableScimProvisioning = true })
+
+	externalID := "ext-" + model.NewId()
+	created, appErr := th.App.CreateScimGroup(th.Context, &model.ScimGroup{
+		DisplayName: "Engineering",
+		ExternalId:  externalID,
+		Members:     []model.ScimReference{{Value: th.BasicUser.Id}},
+	})
+	require.Nil(t, appErr)
+	assert.Equal(t, externalID, created.ExternalId)
+	assert.Equal(t, []string{th.BasicUser.Id}, created.MemberIds())
+
+	t.Run("unique external id", func(t *testing.T) {
+		_, appErr := th.App.CreateScimGroup(th.Context, &model.ScimGroup{DisplayName: "Other", ExternalId: externalID})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusConflict, appErr.StatusCode)
+	})
+
+	t.Run("unknown member", func(t *testing.T) {
+		_, appErr := th.App.CreateScimGroup(th.Context, &model.ScimGroup{
+			DisplayName: "Other",
+			Members:     []model.ScimReference{{Value: model.NewId()}},
+		})
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusBadRequest, appErr.StatusCode)
+	})
+
+	t.Run("filter", func(t *testing.T) {
+		list, appErr := th.App.GetScimGroups(`externalId eq "`+externalID+`"`, 1, model.ScimDefaultCount, false)
+		require.Nil(t, appErr)
+		require.Equal(t, 1, list.TotalResults)
+		groups := list.Resources.([]*model.ScimGroup)
+		assert.Equal(t, created.Id, groups[0].Id)
+		assert.Empty(t, groups[0].Members)
+
+		list, appErr = th.App.GetScimGroups(`members[value eq "`+th.BasicUser2.Id+`"]`, 1, model.ScimDefaultCount, false)
+		require.Nil(t, appErr)
+		assert.Equal(t, 0, list.TotalResults)
+	})
+
+	t.Run("patch members", func(t *testing.T) {
+		patched, appErr := th.App.PatchScimGroup(th.Context, created.Id, &model.ScimPatchRequest{
+			Operations: []model.ScimPatchOperation{
+				{Op: "add", Path: "members", Value: json.RawMessage(`[{"value": "` + th.BasicUser2.Id + `"}]`)},
+				{Op: "remove", Path: `members[value eq "` + th.BasicUser.Id + `"]`},
+			},
+		})
+		require.Nil(t, appErr)
+		assert.Equal(t, []string{th.BasicUser2.Id}, patched.MemberIds())
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.Nil(t, th.App.DeleteScimGroup(th.Context, created.Id))
+
+		_, appErr := th.App.GetScimGroup(created.Id, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+
+	t.Run("other sources", func(t *testing.T) {
+		group := th.CreateGroup()
+		_, appErr := th.App.GetScimGroup(group.Id, true)
+		require.NotNil(t, appErr)
+		assert.Equal(t, http.StatusNotFound, appErr.StatusCode)
+	})
+}
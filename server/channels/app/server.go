@@ -40,10 +40,13 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/audit"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/active_users"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/attribute_group_sync"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/calendar_status_sync"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/cleanup_desktop_tokens"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_dms_preferences_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_empty_drafts_migration"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/delete_orphan_drafts_migration"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/enforce_channel_access_policies"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/expirynotify"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_delete"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/export_process"
@@ -57,12 +60,14 @@ import (
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/migrations"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/mobile_session_metadata"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/notify_admin"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/oidc_signing_key_rotation"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/plugins"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/post_persistent_notifications"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/product_notices"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/refresh_post_stats"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/resend_invitation_email"
 	"github.com/mattermost/mattermost/server/v8/channels/jobs/s3_path_migration"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs/user_access_token_expiry_notify"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
 	"github.com/mattermost/mattermost/server/v8/channels/utils"
 	"github.com/mattermost/mattermost/server/v8/config"
@@ -128,6 +133,7 @@ type Server struct {
 	htmlTemplateWatcher     *templates.Container
 	seenPendingPostIdsCache cache.Cache
 	openGraphDataCache      cache.Cache
+	loginFailuresCache      cache.Cache
 	clusterLeaderListenerId string
 	loggerLicenseListenerId string
 
@@ -322,6 +328,12 @@ func NewServer(options ...Option) (*Server, error) {
 	}); err != nil {
 		return nil, errors.Wrap(err, "Unable to create opengraphdata cache")
 	}
+	if s.loginFailuresCache, err = s.platform.CacheProvider().NewCache(&cache.CacheOptions{
+		Name: "login_failures",
+		Size: loginFailuresCacheSize,
+	}); err != nil {
+		return nil, errors.Wrap(err, "Unable to create login failures cache")
+	}
 
 	s.createPushNotificationsHub(request.EmptyContext(s.Log()))
 
@@ -1277,6 +1289,19 @@ func doSessionCleanup(s *Server) {
 	if err != nil {
 		mlog.Warn("Error while cleaning up sessions", mlog.Err(err))
 	}
+
+	dur := time.Duration(*s.platform.Config().ServiceSettings.SessionActivityRetentionDays) * time.Hour * 24
+	expiry := model.GetMillisForTime(time.Now().Add(-dur))
+	for {
+		deleted, err := s.Store().SessionActivity().PermanentDeleteBefore(expiry, sessionsCleanupBatchSize)
+		if err != nil {
+			mlog.Warn("Error while cleaning up session activities", mlog.Err(err))
+			return
+		}
+		if deleted < sessionsCleanupBatchSize {
+			return
+		}
+	}
 }
 
 func doJobsCleanup(s *Server) {
@@ -1633,6 +1658,36 @@ func (s *Server) initJobs() {
 		cleanup_desktop_tokens.MakeScheduler(s.Jobs),
 	)
 
+	s.Jobs.RegisterJobType(
+		model.JobTypeCalendarStatusSync,
+		calendar_status_sync.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		calendar_status_sync.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeUserAccessTokenExpiryNotify,
+		user_access_token_expiry_notify.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		user_access_token_expiry_notify.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeOIDCSigningKeyRotation,
+		oidc_signing_key_rotation.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		oidc_signing_key_rotation.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeAttributeGroupSync,
+		attribute_group_sync.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		attribute_group_sync.MakeScheduler(s.Jobs),
+	)
+
+	s.Jobs.RegisterJobType(
+		model.JobTypeEnforceChannelAccessPolicies,
+		enforce_channel_access_policies.MakeWorker(s.Jobs, New(ServerConnector(s.Channels()))),
+		enforce_channel_access_policies.MakeScheduler(s.Jobs),
+	)
+
 	s.Jobs.RegisterJobType(
 		model.JobTypeRefreshPostStats,
 		refresh_post_stats.MakeWorker(s.Jobs, *s.platform.Config().SqlSettings.DriverName),
@@ -9,8 +9,10 @@ import (
 	"math"
 	"net/http"
 	"os"
+	"strings"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
 	"github.com/mattermost/mattermost/server/public/shared/request"
 	"github.com/mattermost/mattermost/server/v8/channels/app/platform"
@@ -22,6 +24,10 @@ import (
 // maxSessionsLimit prevents a potential DOS caused by creating an unbounded number of sessions; MM-55320
 const maxSessionsLimit = 500
 
+// revokedSessionsPerPage is the number of sessions read at once to notify the plugins of their
+// revocation.
+const revokedSessionsPerPage = 1000
+
 func (a *App) CreateSession(c request.CTX, session *model.Session) (*model.Session, *model.AppError) {
 	if appErr := a.limitNumberOfSessions(c, session.UserId); appErr != nil {
 		return nil, appErr
@@ -120,6 +126,8 @@ func (a *App) GetSession(token string) (*model.Session, *model.AppError) {
 		return nil, model.NewAppError("GetSession", "api.context.invalid_token.error", map[string]any{"Token": token, "Error": ""}, "session is either nil or expired", http.StatusUnauthorized)
 	}
 
+	a.updateUserAccessTokenLastUsedIfNeeded(c, session)
+
 	if *a.Config().ServiceSettings.SessionIdleTimeoutInMinutes > 0 &&
 		!session.IsOAuth && !session.IsMobileApp() &&
 		session.Props[model.SessionPropType] != model.SessionTypeUserAccessToken &&
@@ -190,6 +198,16 @@ func (a *App) GetLRUSessions(c request.CTX, userID string, limit uint64, offset
 }
 
 func (a *App) RevokeAllSessions(c request.CTX, userID string) *model.AppError {
+	// The sessions are only read for the plugins to be notified of their revocation.
+	var sessions []*model.Session
+	if a.ch.IsHookImplemented(plugin.SessionHasBeenRevokedID) {
+		var err error
+		sessions, err = a.Srv().Store().Session().GetSessions(c, userID)
+		if err != nil {
+			return model.NewAppError("RevokeAllSessions", "app.session.get_sessions.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+	}
+
 	if err := a.ch.srv.platform.RevokeAllSessions(c, userID); err != nil {
 		switch {
 		case errors.Is(err, platform.GetSessionError):
@@ -201,6 +219,8 @@ func (a *App) RevokeAllSessions(c request.CTX, userID string) *model.AppError {
 		}
 	}
 
+	a.sessionsHaveBeenRevoked(c, sessions)
+
 	return nil
 }
 
@@ -211,6 +231,25 @@ func (a *App) AddSessionToCache(session *model.Session) {
 // RevokeSessionsFromAllUsers will go through all the sessions active
 // in the server and revoke them
 func (a *App) RevokeSessionsFromAllUsers() *model.AppError {
+	// The sessions are only read for the plugins to be notified of their revocation.
+	var sessions []*model.Session
+	if a.ch.IsHookImplemented(plugin.SessionHasBeenRevokedID) {
+		for {
+			afterID := ""
+			if len(sessions) > 0 {
+				afterID = sessions[len(sessions)-1].Id
+			}
+			page, err := a.Srv().Store().Session().GetAllSessions(afterID, revokedSessionsPerPage)
+			if err != nil {
+				return model.NewAppError("RevokeSessionsFromAllUsers", "app.session.get_sessions.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+			sessions = append(sessions, page...)
+			if len(page) < revokedSessionsPerPage {
+				break
+			}
+		}
+	}
+
 	if err := a.ch.srv.platform.RevokeSessionsFromAllUsers(); err != nil {
 		switch {
 		case errors.Is(err, users.DeleteAllAccessDataError):
@@ -220,6 +259,8 @@ func (a *App) RevokeSessionsFromAllUsers() *model.AppError {
 		}
 	}
 
+	a.sessionsHaveBeenRevoked(request.EmptyContext(a.Log()), sessions)
+
 	return nil
 }
 
@@ -240,10 +281,13 @@ func (a *App) ClearSessionCacheForAllUsersSkipClusterSend() {
 }
 
 func (a *App) RevokeSessionsForDeviceId(c request.CTX, userID string, deviceID string, currentSessionId string) *model.AppError {
-	if err := a.ch.srv.platform.RevokeSessionsForDeviceId(c, userID, deviceID, currentSessionId); err != nil {
+	sessions, err := a.ch.srv.platform.RevokeSessionsForDeviceId(c, userID, deviceID, currentSessionId)
+	if err != nil {
 		return model.NewAppError("RevokeSessionsForDeviceId", "app.session.get_sessions.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	a.sessionsHaveBeenRevoked(c, sessions)
+
 	return nil
 }
 
@@ -275,9 +319,35 @@ func (a *App) RevokeSession(c request.CTX, session *model.Session) *model.AppErr
 		}
 	}
 
+	a.sessionsHaveBeenRevoked(c, []*model.Session{session})
+
 	return nil
 }
 
+// sessionsHaveBeenRevoked notifies the plugins of the given revoked sessions.
+func (a *App) sessionsHaveBeenRevoked(c request.CTX, sessions []*model.Session) {
+	if len(sessions) == 0 {
+		return
+	}
+
+	pluginContext := pluginContext(c)
+	hookSessions := make([]*model.Session, 0, len(sessions))
+	for _, session := range sessions {
+		hookSession := session.DeepCopy()
+		hookSession.Sanitize()
+		hookSessions = append(hookSessions, hookSession)
+	}
+
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			for _, session := range hookSessions {
+				hooks.SessionHasBeenRevoked(pluginContext, session)
+			}
+			return true
+		}, plugin.SessionHasBeenRevokedID)
+	})
+}
+
 func (a *App) AttachDeviceId(sessionID string, deviceID string, expiresAt int64) *model.AppError {
 	_, err := a.Srv().Store().Session().UpdateDeviceId(sessionID, deviceID, expiresAt)
 	if err != nil {
@@ -404,6 +474,10 @@ func (a *App) CreateUserAccessToken(rctx request.CTX, token *model.UserAccessTok
 		return nil, model.NewAppError("CreateUserAccessToken", "app.user_access_token.disabled", nil, "", http.StatusNotImplemented)
 	}
 
+	if token.ExpiresAt != 0 && token.ExpiresAt <= model.GetMillis() {
+		return nil, model.NewAppError("CreateUserAccessToken", "app.user_access_token.expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	token.Token = model.NewId()
 
 	token, nErr = a.Srv().Store().UserAccessToken().Save(token)
@@ -437,6 +511,10 @@ func (a *App) createSessionForUserAccessToken(c request.CTX, tokenString string)
 		return nil, model.NewAppError("createSessionForUserAccessToken", "app.user_access_token.invalid_or_missing", nil, "inactive_token", http.StatusUnauthorized)
 	}
 
+	if token.IsExpired() {
+		return nil, model.NewAppError("createSessionForUserAccessToken", "app.user_access_token.invalid_or_missing", nil, "expired_token", http.StatusUnauthorized)
+	}
+
 	user, nErr := a.Srv().Store().User().Get(c.Context(), token.UserId)
 	if nErr != nil {
 		var nfErr *store.ErrNotFound
@@ -477,7 +555,13 @@ func (a *App) createSessionForUserAccessToken(c request.CTX, tokenString string)
 	} else {
 		session.AddProp(model.SessionPropIsGuest, "false")
 	}
+	if len(token.Scopes) > 0 {
+		session.AddProp(model.SessionPropUserAccessTokenScopes, strings.Join(token.Scopes, " "))
+	}
 	a.ch.srv.platform.SetSessionExpireInHours(session, model.SessionUserAccessTokenExpiryHours)
+	if token.ExpiresAt > 0 && token.ExpiresAt < session.ExpiresAt {
+		session.ExpiresAt = token.ExpiresAt
+	}
 
 	session, nErr = a.Srv().Store().Session().Save(c, session)
 	if nErr != nil {
@@ -492,9 +576,30 @@ func (a *App) createSessionForUserAccessToken(c request.CTX, tokenString string)
 
 	a.ch.srv.platform.AddSessionToCache(session)
 
+	// Later uses of the token are recorded along with the activity of its session.
+	if err := a.Srv().Store().UserAccessToken().UpdateLastUsedAt(token.Id, session.CreateAt); err != nil {
+		c.Logger().Warn("Failed to update the last use of the user access token", mlog.String("user_access_token_id", token.Id), mlog.Err(err))
+	}
+
 	return session, nil
 }
 
+// updateUserAccessTokenLastUsedIfNeeded records the use of the user access token of the session,
+// along with the activity of the session, at most once per session activity timeout.
+func (a *App) updateUserAccessTokenLastUsedIfNeeded(c request.CTX, session *model.Session) {
+	now := model.GetMillis()
+	if !session.IsUserAccessToken() || now-session.LastActivityAt < model.SessionActivityTimeout {
+		return
+	}
+
+	tokenID := session.Props[model.SessionPropUserAccessTokenId]
+	if err := a.Srv().Store().UserAccessToken().UpdateLastUsedAt(tokenID, now); err != nil {
+		c.Logger().Warn("Failed to update the last use of the user access token", mlog.String("user_access_token_id", tokenID), mlog.Err(err))
+	}
+
+	a.ch.srv.platform.UpdateLastActivityAtIfNeeded(*session)
+}
+
 func (a *App) RevokeUserAccessToken(c request.CTX, token *model.UserAccessToken) *model.AppError {
 	var session *model.Session
 	session, _ = a.ch.srv.platform.GetSessionContext(c, token.Token)
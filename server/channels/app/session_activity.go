@@ -0,0 +1,235 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/avct/uasurfer"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+	"github.com/mattermost/mattermost/server/v8/platform/services/geoip"
+)
+
+const (
+	sessionActivityCacheSize = 10000
+
+	// impossibleTravelMinDistanceKm ignores jumps between nearby regions, since locating an IP
+	// address is only accurate to a region.
+	impossibleTravelMinDistanceKm = 100
+)
+
+// RecordSessionActivity records that the session is used from the given IP address. It's
+// throttled so that the activity is updated at most once per SessionActivityTimeout. Without a
+// cache shared between the nodes of a cluster, each node may update the activity once per
+// SessionActivityTimeout, but the unique (SessionId, IpAddress) index still keeps a single row.
+func (a *App) RecordSessionActivity(c request.CTX, session *model.Session, ipAddress, userAgent string) {
+	a.recordSessionActivityAsync(c, session, ipAddress, userAgent, false)
+}
+
+func (a *App) recordSessionActivityAsync(c request.CTX, session *model.Session, ipAddress, userAgent string, signIn bool) {
+	if !*a.Config().ServiceSettings.EnableSessionActivityLog || session.Id == "" || !model.IsValidId(session.UserId) || ipAddress == "" {
+		return
+	}
+
+	key := session.Id + ":" + ipAddress
+	var seen bool
+	if !signIn && a.ch.sessionActivityCache.Get(key, &seen) == nil {
+		return
+	}
+	if err := a.ch.sessionActivityCache.SetWithExpiry(key, true, model.SessionActivityTimeout*time.Millisecond); err != nil {
+		c.Logger().Warn("Failed to cache the session activity", mlog.String("session_id", session.Id), mlog.Err(err))
+	}
+
+	sessionVal := *session
+	a.Srv().Go(func() {
+		a.recordSessionActivity(c, &sessionVal, ipAddress, userAgent, signIn)
+	})
+}
+
+func (a *App) recordSessionActivity(c request.CTX, session *model.Session, ipAddress, userAgent string, signIn bool) {
+	now := model.GetMillis()
+
+	err := a.Srv().Store().SessionActivity().UpdateLastSeenAt(session.Id, ipAddress, now)
+	var nfErr *store.ErrNotFound
+	if err == nil {
+		return
+	} else if !errors.As(err, &nfErr) {
+		c.Logger().Warn("Failed to update the session activity", mlog.String("session_id", session.Id), mlog.Err(err))
+		return
+	}
+
+	ua := uasurfer.Parse(userAgent)
+	activity := &model.SessionActivity{
+		SessionId:      session.Id,
+		UserId:         session.UserId,
+		IpAddress:      ipAddress,
+		UserAgent:      userAgent,
+		Platform:       getPlatformName(ua, userAgent),
+		Os:             getOSName(ua, userAgent),
+		Browser:        getBrowserName(ua, userAgent),
+		BrowserVersion: getBrowserVersion(ua, userAgent),
+		DeviceId:       session.DeviceId,
+		FirstSeenAt:    now,
+		LastSeenAt:     now,
+	}
+	a.locateSessionActivity(c, activity)
+	activity.Anomaly = a.getSessionActivityAnomaly(c, activity)
+
+	// Whether the device is unrecognized has to be checked before the activity is saved.
+	unrecognized := signIn && *a.Config().EmailSettings.SendNewSignInEmails && a.isUnrecognizedDevice(c, activity)
+
+	if _, err := a.Srv().Store().SessionActivity().Save(activity); err != nil {
+		var conflictErr *store.ErrConflict
+		if !errors.As(err, &conflictErr) {
+			c.Logger().Warn("Failed to save the session activity", mlog.String("session_id", session.Id), mlog.Err(err))
+		}
+		// Otherwise another request already recorded the activity.
+		return
+	}
+
+	if activity.Anomaly != "" {
+		c.Logger().Warn("Anomalous session activity",
+			mlog.String("user_id", activity.UserId),
+			mlog.String("session_id", activity.SessionId),
+			mlog.String("anomaly", activity.Anomaly),
+			mlog.String("ip_address", activity.IpAddress),
+			mlog.String("region", activity.Region),
+		)
+
+		if activity.Anomaly == model.SessionAnomalyImpossibleTravel && *a.Config().ServiceSettings.RevokeSessionsOnImpossibleTravel {
+			if appErr := a.RevokeSession(c, session); appErr != nil {
+				c.Logger().Warn("Failed to revoke the session", mlog.String("session_id", session.Id), mlog.Err(appErr))
+			}
+		}
+	}
+
+	if unrecognized {
+		a.sendNewSignInEmail(c, activity)
+	}
+}
+
+func (a *App) locateSessionActivity(c request.CTX, activity *model.SessionActivity) {
+	path := *a.Config().ServiceSettings.SessionGeoIPDatabasePath
+	if path == "" {
+		return
+	}
+
+	db, err := a.ch.geoIPLoader.Get(path)
+	if err != nil {
+		c.Logger().Warn("Failed to load the GeoIP database", mlog.String("path", path), mlog.Err(err))
+		return
+	}
+
+	if location, ok := db.Lookup(activity.IpAddress); ok {
+		activity.Region = location.Region
+		activity.Latitude = location.Latitude
+		activity.Longitude = location.Longitude
+	}
+}
+
+// getSessionActivityAnomaly returns the anomaly detection rule the activity breaks, if any.
+func (a *App) getSessionActivityAnomaly(c request.CTX, activity *model.SessionActivity) string {
+	speedKmh := *a.Config().ServiceSettings.SessionImpossibleTravelSpeedKmh
+	if speedKmh == 0 || !activity.IsLocated() {
+		return ""
+	}
+
+	previous, err := a.Srv().Store().SessionActivity().GetLatestLocatedForUser(activity.UserId)
+	if err != nil {
+		var nfErr *store.ErrNotFound
+		if !errors.As(err, &nfErr) {
+			c.Logger().Warn("Failed to get the latest session activity", mlog.String("user_id", activity.UserId), mlog.Err(err))
+		}
+		return ""
+	}
+
+	if isImpossibleTravel(previous, activity, speedKmh) {
+		return model.SessionAnomalyImpossibleTravel
+	}
+
+	return ""
+}
+
+// isImpossibleTravel returns whether going from the location of the previous activity to the
+// location of the next one requires travelling faster than the given speed.
+func isImpossibleTravel(previous, next *model.SessionActivity, speedKmh int) bool {
+	distance := geoip.Distance(
+		geoip.Location{Latitude: previous.Latitude, Longitude: previous.Longitude},
+		geoip.Location{Latitude: next.Latitude, Longitude: next.Longitude},
+	)
+	if distance < impossibleTravelMinDistanceKm {
+		return false
+	}
+
+	hours := float64(next.FirstSeenAt-previous.LastSeenAt) / float64(time.Hour/time.Millisecond)
+	return hours <= 0 || distance/hours > float64(speedKmh)
+}
+
+func (a *App) isUnrecognizedDevice(c request.CTX, activity *model.SessionActivity) bool {
+	// The first sign-in of a user comes from a device that can't be recognized either way.
+	hasActivity, err := a.Srv().Store().SessionActivity().HasUserActivity(activity.UserId, activity.SessionId)
+	if err != nil {
+		c.Logger().Warn("Failed to get the session activity of the user", mlog.String("user_id", activity.UserId), mlog.Err(err))
+		return false
+	}
+	if !hasActivity {
+		return false
+	}
+
+	usedDevice, err := a.Srv().Store().SessionActivity().HasUserUsedDevice(activity)
+	if err != nil {
+		c.Logger().Warn("Failed to get the devices of the user", mlog.String("user_id", activity.UserId), mlog.Err(err))
+		return false
+	}
+
+	return !usedDevice
+}
+
+func (a *App) sendNewSignInEmail(c request.CTX, activity *model.SessionActivity) {
+	user, appErr := a.GetUser(activity.UserId)
+	if appErr != nil {
+		c.Logger().Warn("Failed to get the user to send the new sign-in email", mlog.String("user_id", activity.UserId), mlog.Err(appErr))
+		return
+	}
+
+	device := fmt.Sprintf("%s %s (%s)", activity.Browser, activity.BrowserVersion, activity.Os)
+	if err := a.Srv().EmailService.SendNewSignInEmail(user.Email, user.Locale, a.GetSiteURL(), device, activity.IpAddress, activity.Region, activity.FirstSeenAt); err != nil {
+		c.Logger().Error("Failed to send the new sign-in email", mlog.String("user_id", user.Id), mlog.Err(err))
+	}
+}
+
+// GetSessionActivities returns the activity of the sessions of a user, most recent first.
+func (a *App) GetSessionActivities(userID string, page, perPage int) ([]*model.SessionActivity, *model.AppError) {
+	activities, err := a.Srv().Store().SessionActivity().GetAll(model.SessionActivityGetOptions{
+		UserId:  userID,
+		Page:    page,
+		PerPage: perPage,
+	})
+	if err != nil {
+		return nil, model.NewAppError("GetSessionActivities", "app.session_activity.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return activities, nil
+}
+
+// GetSessionAnomalies returns the anomalous session activity of all users, most recent first.
+func (a *App) GetSessionAnomalies(page, perPage int) ([]*model.SessionActivity, *model.AppError) {
+	activities, err := a.Srv().Store().SessionActivity().GetAll(model.SessionActivityGetOptions{
+		AnomaliesOnly: true,
+		Page:          page,
+		PerPage:       perPage,
+	})
+	if err != nil {
+		return nil, model.NewAppError("GetSessionAnomalies", "app.session_activity.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return activities, nil
+}
@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestIsImpossibleTravel(t *testing.T) {
+	hour := int64(time.Hour / time.Millisecond)
+	paris := &model.SessionActivity{Region: "Paris", Latitude: 48.86, Longitude: 2.35, LastSeenAt: 10 * hour}
+	versailles := &model.SessionActivity{Region: "Versailles", Latitude: 48.80, Longitude: 2.13}
+	tokyo := &model.SessionActivity{Region: "Tokyo", Latitude: 35.68, Longitude: 139.69}
+
+	versailles.FirstSeenAt = paris.LastSeenAt
+	assert.False(t, isImpossibleTravel(paris, versailles, 1000), "nearby regions are ignored")
+
+	tokyo.FirstSeenAt = paris.LastSeenAt + hour
+	assert.True(t, isImpossibleTravel(paris, tokyo, 1000))
+
+	tokyo.FirstSeenAt = paris.LastSeenAt + 24*hour
+	assert.False(t, isImpossibleTravel(paris, tokyo, 1000))
+
+	tokyo.FirstSeenAt = paris.LastSeenAt
+	assert.True(t, isImpossibleTravel(paris, tokyo, 1000))
+}
+
+func TestRecordSessionActivity(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	require.NoError(t, os.WriteFile(path, []byte("10.0.0.0/8,Paris,48.86,2.35\n192.168.0.0/16,Tokyo,35.68,139.69\n"), 0600))
+	th.App.UpdateConfig(func(cfg *model.Config) {
+		*cfg.ServiceSettings.EnableSessionActivityLog = true
+		*cfg.ServiceSettings.SessionGeoIPDatabasePath = path
+		*cfg.ServiceSettings.RevokeSessionsOnImpossibleTravel = true
+	})
+
+	userAgent := "Mozilla/5.0 (X11; Linux x86_64; rv:120.0) Gecko/20100101 Firefox/120.0"
+
+	session, appErr := th.App.CreateSession(th.Context, &model.Session{UserId: th.BasicUser.Id})
+	require.Nil(t, appErr)
+	th.App.recordSessionActivity(th.Context, session, "10.0.0.1", userAgent, true)
+	th.App.recordSessionActivity(th.Context, session, "10.0.0.1", userAgent, false)
+
+	activities, appErr := th.App.GetSessionActivities(th.BasicUser.Id, 0, 10)
+	require.Nil(t, appErr)
+	require.Len(t, activities, 1)
+	assert.Equal(t, "Paris", activities[0].Region)
+	assert.Equal(t, "Firefox", activities[0].Browser)
+	assert.Empty(t, activities[0].Anomaly)
+
+	other, appErr := th.App.CreateSession(th.Context, &model.Session{UserId: th.BasicUser.Id})
+	require.Nil(t, appErr)
+	th.App.recordSessionActivity(th.Context, other, "192.168.0.1", userAgent, true)
+
+	anomalies, appErr := th.App.GetSessionAnomalies(0, 10)
+	require.Nil(t, appErr)
+	require.Len(t, anomalies, 1)
+	assert.Equal(t, other.Id, anomalies[0].SessionId)
+	assert.Equal(t, model.SessionAnomalyImpossibleTravel, anomalies[0].Anomaly)
+
+	_, appErr = th.App.GetSession(other.Token)
+	require.NotNil(t, appErr, "the session should have been revoked")
+}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 
@@ -348,7 +349,7 @@ func (a *App) CreateOAuthUser(c request.CTX, service string, userData io.Reader,
 	if e != nil {
 		return nil, e
 	}
-	user, err1 := provider.GetUserFromJSON(c, userData, tokenUser)
+	user, err1 := a.getOAuthUserFromJSON(c, provider, service, userData, tokenUser)
 	if err1 != nil {
 		return nil, model.NewAppError("CreateOAuthUser", "api.user.create_oauth_user.create.app_error", map[string]any{"Service": service}, "", http.StatusInternalServerError).Wrap(err1)
 	}
@@ -1358,6 +1359,17 @@ func (a *App) UpdateUser(c request.CTX, user *model.User, sendNotifications bool
 
 	newUser.Sanitize(map[string]bool{})
 
+	pluginContext := pluginContext(c)
+	hookNewUser := newUser.DeepCopy()
+	hookOldUser := userUpdate.Old.DeepCopy()
+	hookOldUser.Sanitize(map[string]bool{})
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.UserHasBeenUpdated(pluginContext, hookNewUser, hookOldUser)
+			return true
+		}, plugin.UserHasBeenUpdatedID)
+	})
+
 	return newUser, nil
 }
 
@@ -1437,6 +1449,20 @@ func (a *App) UpdatePassword(rctx request.CTX, user *model.User, newPassword str
 		return model.NewAppError("UpdatePassword", "api.user.update_password.failed.app_error", nil, "", http.StatusInternalServerError)
 	}
 
+	var previousHash string
+	if *a.Config().PasswordSettings.HistoryCount > 0 {
+		// The given user may be stale, so get the current password from the store.
+		storedUser, err := a.Srv().Store().User().Get(context.Background(), user.Id)
+		if err != nil {
+			return model.NewAppError("UpdatePassword", "api.user.update_password.failed.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		previousHash = storedUser.Password
+
+		if err := a.checkPasswordNotReused(user.Id, previousHash, newPassword); err != nil {
+			return err
+		}
+	}
+
 	hashedPassword, err := model.HashPassword(newPassword)
 	if err != nil {
 		// can't be password length (checked in IsPasswordValid)
@@ -1448,6 +1474,17 @@ func (a *App) UpdatePassword(rctx request.CTX, user *model.User, newPassword str
 	}
 
 	a.InvalidateCacheForUser(user.Id)
+	a.savePasswordHistory(rctx, user.Id, previousHash)
+
+	pluginContext := pluginContext(rctx)
+	hookUser := user.DeepCopy()
+	hookUser.Sanitize(map[string]bool{})
+	a.Srv().Go(func() {
+		a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+			hooks.UserPasswordHasChanged(pluginContext, hookUser)
+			return true
+		}, plugin.UserPasswordHasChangedID)
+	})
 
 	if *a.Config().ServiceSettings.TerminateSessionsOnPasswordChange {
 		// Get currently active sessions if request is user-initiated to retain it
@@ -1477,6 +1514,75 @@ func (a *App) UpdatePassword(rctx request.CTX, user *model.User, newPassword str
 	return nil
 }
 
+// checkPasswordNotReused checks that the password isn't one of the last passwords of the user,
+// including the current one.
+func (a *App) checkPasswordNotReused(userID, currentHash, password string) *model.AppError {
+	count := *a.Config().PasswordSettings.HistoryCount
+
+	hashes := []string{currentHash}
+	if count > 1 {
+		history, err := a.Srv().Store().PasswordHistory().GetForUser(userID, count-1)
+		if err != nil {
+			return model.NewAppError("checkPasswordNotReused", "app.password_history.get.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+		for _, h := range history {
+			hashes = append(hashes, h.PasswordHash)
+		}
+	}
+
+	for _, hash := range hashes {
+		if users.ComparePassword(hash, password) == nil {
+			return model.NewAppError("checkPasswordNotReused", "api.user.update_password.reused.app_error", map[string]any{"Count": count}, "user_id="+userID, http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// savePasswordHistory keeps the previous password of the user, as long as the password history
+// requires it.
+func (a *App) savePasswordHistory(rctx request.CTX, userID, previousHash string) {
+	keep := max(*a.Config().PasswordSettings.HistoryCount-1, 0)
+
+	if keep > 0 && previousHash != "" {
+		if err := a.Srv().Store().PasswordHistory().Save(&model.PasswordHistory{UserId: userID, PasswordHash: previousHash}); err != nil {
+			rctx.Logger().Warn("Failed to save the password history", mlog.String("user_id", userID), mlog.Err(err))
+			return
+		}
+	}
+
+	if err := a.Srv().Store().PasswordHistory().PruneForUser(userID, keep); err != nil {
+		rctx.Logger().Warn("Failed to prune the password history", mlog.String("user_id", userID), mlog.Err(err))
+	}
+}
+
+// GetUsersWithExpiredPasswords returns the users who have to reset their password the next
+// time they log in.
+func (a *App) GetUsersWithExpiredPasswords(page int, perPage int) ([]*model.User, *model.AppError) {
+	expiredBefore, ok := a.getPasswordExpiredBefore()
+	if !ok {
+		return []*model.User{}, nil
+	}
+
+	users, err := a.Srv().Store().User().GetUsersWithExpiredPasswords(expiredBefore, page, perPage)
+	if err != nil {
+		return nil, model.NewAppError("GetUsersWithExpiredPasswords", "app.user.get_users_with_expired_passwords.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return users, nil
+}
+
+// getPasswordExpiredBefore returns the time passwords changed before are expired, if they
+// expire.
+func (a *App) getPasswordExpiredBefore() (int64, bool) {
+	days := *a.Config().PasswordSettings.MaximumAgeDays
+	if days == 0 {
+		return 0, false
+	}
+
+	return model.GetMillisForTime(time.Now().Add(-time.Duration(days) * 24 * time.Hour)), true
+}
+
 func (a *App) UpdatePasswordSendEmail(c request.CTX, user *model.User, newPassword, method string) *model.AppError {
 	if err := a.UpdatePassword(c, user, newPassword); err != nil {
 		return err
@@ -1658,6 +1764,24 @@ func (a *App) InvalidatePasswordRecoveryTokensForUser(userID string) *model.AppE
 	return appErr
 }
 
+// hasPendingPasswordRecoveryToken returns whether the user has a password recovery token that
+// hasn't expired yet.
+func (a *App) hasPendingPasswordRecoveryToken(userID string) (bool, *model.AppError) {
+	tokens, err := a.Srv().Store().Token().GetAllTokensByTypeAndUser(TokenTypePasswordRecovery, userID)
+	if err != nil {
+		return false, model.NewAppError("hasPendingPasswordRecoveryToken", "api.user.invalidate_password_recovery_tokens.error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	now := model.GetMillis()
+	for _, token := range tokens {
+		if now-token.CreateAt < PasswordRecoverExpiryTime {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
 func (a *App) GetPasswordRecoveryToken(token string) (*model.Token, *model.AppError) {
 	rtoken, err := a.Srv().Store().Token().GetByToken(token)
 	if err != nil {
@@ -1726,6 +1850,7 @@ func (a *App) UpdateUserRolesWithUser(c request.CTX, user *model.User, newRoles
 		}
 	}
 
+	oldRoles := user.Roles
 	user.Roles = newRoles
 	uchan := make(chan store.StoreResult[*model.UserUpdate], 1)
 	go func() {
@@ -1771,6 +1896,18 @@ func (a *App) UpdateUserRolesWithUser(c request.CTX, user *model.User, newRoles
 		a.Publish(message)
 	}
 
+	if oldRoles != newRoles {
+		pluginContext := pluginContext(c)
+		hookUser := ruser.DeepCopy()
+		hookUser.Sanitize(map[string]bool{})
+		a.Srv().Go(func() {
+			a.ch.RunMultiHook(func(hooks plugin.Hooks, _ *model.Manifest) bool {
+				hooks.UserRolesHaveChanged(pluginContext, hookUser, oldRoles, newRoles)
+				return true
+			}, plugin.UserRolesHaveChangedID)
+		})
+	}
+
 	return ruser, nil
 }
 
@@ -1788,6 +1925,18 @@ func (a *App) PermanentDeleteUser(rctx request.CTX, user *model.User) *model.App
 		return model.NewAppError("PermanentDeleteUser", "app.session.permanent_delete_sessions_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
 
+	if err := a.Srv().Store().PasswordHistory().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.password_history.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().AttributeGroup().PermanentDeleteUserAttributes(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.attribute_group.permanent_delete_user_attributes.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	if err := a.Srv().Store().SessionActivity().PermanentDeleteByUser(user.Id); err != nil {
+		return model.NewAppError("PermanentDeleteUser", "app.session_activity.permanent_delete_by_user.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
 	if err := a.Srv().Store().UserAccessToken().DeleteAllForUser(user.Id); err != nil {
 		return model.NewAppError("PermanentDeleteUser", "app.user_access_token.delete.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
 	}
@@ -2206,7 +2355,7 @@ func (a *App) AutocompleteUsersInTeam(rctx request.CTX, teamID string, term stri
 }
 
 func (a *App) UpdateOAuthUserAttrs(c request.CTX, userData io.Reader, user *model.User, provider einterfaces.OAuthProvider, service string, tokenUser *model.User) *model.AppError {
-	oauthUser, err1 := provider.GetUserFromJSON(c, userData, tokenUser)
+	oauthUser, err1 := a.getOAuthUserFromJSON(c, provider, service, userData, tokenUser)
 	if err1 != nil {
 		return model.NewAppError("UpdateOAuthUserAttrs", "api.user.update_oauth_user_attrs.get_user.app_error", map[string]any{"Service": service}, "", http.StatusBadRequest).Wrap(err1)
 	}
@@ -0,0 +1,80 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package app
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+)
+
+const (
+	// userAccessTokenExpiryNotificationWindow is how long before the expiry of a user access
+	// token its owner is notified.
+	userAccessTokenExpiryNotificationWindow = 7 * 24 * time.Hour
+
+	userAccessTokenExpiryNotificationBatchSize = 100
+)
+
+// NotifyUserAccessTokensExpiring is called periodically from the job server to notify the owners
+// of user access tokens expiring soon, once per token. The owner of the token of a bot is the
+// owner of the bot.
+func (a *App) NotifyUserAccessTokensExpiring(rctx request.CTX) error {
+	for {
+		now := model.GetMillis()
+		tokens, err := a.Srv().Store().UserAccessToken().GetExpiringUnnotified(now, now+userAccessTokenExpiryNotificationWindow.Milliseconds(), userAccessTokenExpiryNotificationBatchSize)
+		if err != nil {
+			return model.NewAppError("NotifyUserAccessTokensExpiring", "app.user_access_token.get_expiring.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+		}
+
+		for _, token := range tokens {
+			a.notifyUserAccessTokenExpiring(rctx, token)
+
+			// Tokens are marked as notified even if their owner couldn't be, so that a failure
+			// doesn't cause the owner to be notified repeatedly.
+			if err := a.Srv().Store().UserAccessToken().UpdateExpiryNotified(token.Id); err != nil {
+				return model.NewAppError("NotifyUserAccessTokensExpiring", "app.user_access_token.update_expiry_notified.app_error", nil, "", http.StatusInternalServerError).Wrap(err)
+			}
+		}
+
+		if len(tokens) < userAccessTokenExpiryNotificationBatchSize {
+			return nil
+		}
+	}
+}
+
+func (a *App) notifyUserAccessTokenExpiring(rctx request.CTX, token *model.UserAccessToken) {
+	logger := rctx.Logger().With(mlog.String("user_access_token_id", token.Id), mlog.String("user_id", token.UserId))
+
+	owner, appErr := a.GetUser(token.UserId)
+	if appErr != nil {
+		logger.Warn("Unable to get the user of the expiring user access token", mlog.Err(appErr))
+		return
+	}
+
+	if owner.IsBot {
+		bot, appErr := a.GetBot(rctx, owner.Id, false)
+		if appErr != nil {
+			logger.Warn("Unable to get the bot of the expiring user access token", mlog.Err(appErr))
+			return
+		}
+
+		// Bots owned by plugins have no user to notify.
+		if owner, appErr = a.GetUser(bot.OwnerId); appErr != nil {
+			logger.Debug("Not notifying of the expiring user access token of a bot without an owner", mlog.Err(appErr))
+			return
+		}
+	}
+
+	if owner.DeleteAt != 0 || owner.Email == "" {
+		return
+	}
+
+	if err := a.Srv().EmailService.SendUserAccessTokenExpiringEmail(owner.Email, owner.Locale, a.GetSiteURL(), token.Description, token.ExpiresAt); err != nil {
+		logger.Error("Unable to send user access token expiring email", mlog.Err(err))
+	}
+}
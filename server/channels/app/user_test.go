@@ -6,9 +6,12 @@ package app
 import (
 	"bytes"
 	"context"
+	"crypto/sha1"
 	"database/sql"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
+	"os"
 	"path/filepath"
 	"strings"
 	"testing"
@@ -1278,6 +1281,105 @@ func TestInvalidatePasswordRecoveryTokens(t *testing.T) {
 	})
 }
 
+func TestUpdatePasswordHistory(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.HistoryCount = 3 })
+
+	for _, password := range []string{"Password2", "Password3"} {
+		require.Nil(t, th.App.UpdatePassword(th.Context, th.BasicUser, password))
+	}
+
+	for _, password := range []string{"Password3", "Password2", "Password1"} {
+		appErr := th.App.UpdatePassword(th.Context, th.BasicUser, password)
+		require.NotNil(t, appErr, password)
+		assert.Equal(t, "api.user.update_password.reused.app_error", appErr.Id)
+	}
+
+	require.Nil(t, th.App.UpdatePassword(th.Context, th.BasicUser, "Password4"))
+	// The oldest password fell out of the history.
+	require.Nil(t, th.App.UpdatePassword(th.Context, th.BasicUser, "Password1"))
+
+	history, err := th.App.Srv().Store().PasswordHistory().GetForUser(th.BasicUser.Id, 10)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.HistoryCount = 0 })
+	require.Nil(t, th.App.UpdatePassword(th.Context, th.BasicUser, "Password2"))
+
+	history, err = th.App.Srv().Store().PasswordHistory().GetForUser(th.BasicUser.Id, 10)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func TestUpdatePasswordBreached(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	hash := sha1.Sum([]byte("Password2"))
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(hex.EncodeToString(hash[:])+":10\n"), 0600))
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.BreachedPasswordsFile = path })
+
+	appErr := th.App.UpdatePassword(th.Context, th.BasicUser, "Password2")
+	require.NotNil(t, appErr)
+	assert.Equal(t, "model.user.is_valid.pwd_breached.app_error", appErr.Id)
+
+	require.Nil(t, th.App.UpdatePassword(th.Context, th.BasicUser, "Password3"))
+}
+
+func TestGetUsersWithExpiredPasswords(t *testing.T) {
+	th := Setup(t).InitBasic()
+	defer th.TearDown()
+
+	users, appErr := th.App.GetUsersWithExpiredPasswords(0, 100)
+	require.Nil(t, appErr)
+	assert.Empty(t, users, "passwords don't expire by default")
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.PasswordSettings.MaximumAgeDays = 30 })
+	expiredAt := model.GetMillisForTime(time.Now().Add(-31 * 24 * time.Hour))
+	_, err := th.GetSqlStore().GetMaster().Exec("UPDATE Users SET LastPasswordUpdate = ? WHERE Id = ?", expiredAt, th.BasicUser.Id)
+	require.NoError(t, err)
+	th.App.InvalidateCacheForUser(th.BasicUser.Id)
+
+	users, appErr = th.App.GetUsersWithExpiredPasswords(0, 100)
+	require.Nil(t, appErr)
+	userIds := []string{}
+	for _, user := range users {
+		userIds = append(userIds, user.Id)
+	}
+	assert.Contains(t, userIds, th.BasicUser.Id)
+	assert.NotContains(t, userIds, th.BasicUser2.Id)
+
+	appErr = th.App.CheckPasswordAndAllCriteria(th.Context, th.BasicUser.Id, "Password1", "")
+	require.NotNil(t, appErr)
+	assert.Equal(t, "api.user.login.password_expired.app_error", appErr.Id)
+
+	t.Run("the reset email is sent only when no reset link is pending", func(t *testing.T) {
+		require.Eventually(t, func() bool {
+			pending, appErr := th.App.hasPendingPasswordRecoveryToken(th.BasicUser.Id)
+			require.Nil(t, appErr)
+			return pending
+		}, 5*time.Second, 100*time.Millisecond)
+
+		pending, appErr := th.App.hasPendingPasswordRecoveryToken(th.BasicUser2.Id)
+		require.Nil(t, appErr)
+		assert.False(t, pending)
+
+		tokens, err := th.App.Srv().Store().Token().GetAllTokensByType(TokenTypePasswordRecovery)
+		require.NoError(t, err)
+
+		appErr = th.App.CheckPasswordAndAllCriteria(th.Context, th.BasicUser.Id, "Password1", "")
+		require.NotNil(t, appErr)
+
+		time.Sleep(500 * time.Millisecond)
+		tokensAfter, err := th.App.Srv().Store().Token().GetAllTokensByType(TokenTypePasswordRecovery)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, tokens, tokensAfter, "the pending token shouldn't be replaced")
+	})
+}
+
 func TestPasswordChangeSessionTermination(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
@@ -0,0 +1,156 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package users
+
+import (
+	"bufio"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+type passwordHash [sha1.Size]byte
+
+const (
+	// breachedPasswordsRangePrefixLength is the number of hexadecimal characters of the hashes
+	// used as file names in a directory of range files.
+	breachedPasswordsRangePrefixLength = 5
+	// breachedPasswordsRangeSuffixLength is the number of hexadecimal characters of the hashes
+	// listed in a range file.
+	breachedPasswordsRangeSuffixLength = 2*sha1.Size - breachedPasswordsRangePrefixLength
+)
+
+// breachedPasswords is a list of the SHA-1 hashes of breached passwords loaded from a file, and
+// loaded again when the file changes. Only hashes are compared, so that the list can be
+// generated from the k-anonymity range files of breach databases without holding any password.
+//
+// The list can also be a directory of the range files themselves, in which case only the file of
+// the range of the password is read for each check and nothing is kept in memory.
+type breachedPasswords struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	hashes  map[passwordHash]struct{}
+}
+
+// parseBreachedPasswords reads hexadecimal SHA-1 hashes, one per line and optionally followed by
+// a colon and the number of times the password was seen. Empty lines and lines starting with #
+// are ignored.
+func parseBreachedPasswords(r io.Reader) (map[passwordHash]struct{}, error) {
+	hashes := map[passwordHash]struct{}{}
+
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		text, _, _ = strings.Cut(text, ":")
+
+		var hash passwordHash
+		if len(text) != hex.EncodedLen(sha1.Size) {
+			return nil, fmt.Errorf("invalid hash on line %d", line)
+		}
+		if _, err := hex.Decode(hash[:], []byte(text)); err != nil {
+			return nil, fmt.Errorf("invalid hash on line %d: %w", line, err)
+		}
+		hashes[hash] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read the breached passwords: %w", err)
+	}
+
+	return hashes, nil
+}
+
+// contains returns whether the password is in the list of the file, or the directory of range
+// files, at the given path.
+func (b *breachedPasswords) contains(path, password string) (bool, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return false, fmt.Errorf("failed to read the breached passwords: %w", err)
+	}
+
+	if info.IsDir() {
+		return rangeContains(path, password)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.hashes == nil || b.path != path || !b.modTime.Equal(info.ModTime()) {
+		file, err := os.Open(path)
+		if err != nil {
+			return false, fmt.Errorf("failed to read the breached passwords: %w", err)
+		}
+		defer file.Close()
+
+		hashes, err := parseBreachedPasswords(file)
+		if err != nil {
+			return false, err
+		}
+
+		b.path = path
+		b.modTime = info.ModTime()
+		b.hashes = hashes
+	}
+
+	_, ok := b.hashes[sha1.Sum([]byte(password))]
+	return ok, nil
+}
+
+// rangeContains returns whether the password is in the range file of its hash in the directory at
+// the given path. Range files are named after the first 5 hexadecimal characters of the hashes,
+// with or without a .txt extension, and list the remaining 35 characters one per line and
+// optionally followed by a colon and a count. A missing range file means no password of the range
+// is breached.
+func rangeContains(dir, password string) (bool, error) {
+	hash := sha1.Sum([]byte(password))
+	encoded := strings.ToUpper(hex.EncodeToString(hash[:]))
+	prefix, suffix := encoded[:breachedPasswordsRangePrefixLength], encoded[breachedPasswordsRangePrefixLength:]
+
+	var file *os.File
+	for _, name := range []string{prefix, prefix + ".txt"} {
+		var err error
+		file, err = os.Open(filepath.Join(dir, name))
+		if err == nil {
+			break
+		} else if !errors.Is(err, os.ErrNotExist) {
+			return false, fmt.Errorf("failed to read the breached passwords: %w", err)
+		}
+	}
+	if file == nil {
+		return false, nil
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		text, _, _ = strings.Cut(text, ":")
+		if len(text) != breachedPasswordsRangeSuffixLength {
+			return false, fmt.Errorf("invalid hash suffix on line %d of range %s", line, prefix)
+		}
+		if strings.EqualFold(text, suffix) {
+			return true, nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return false, fmt.Errorf("failed to read the breached passwords: %w", err)
+	}
+
+	return false, nil
+}
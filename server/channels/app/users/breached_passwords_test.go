@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package users
+
+import (
+	"crypto/sha1"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func hashPasswordForBreachList(password string) string {
+	hash := sha1.Sum([]byte(password))
+	return strings.ToUpper(hex.EncodeToString(hash[:]))
+}
+
+func TestParseBreachedPasswords(t *testing.T) {
+	hashes, err := parseBreachedPasswords(strings.NewReader("# breached passwords\n\n" +
+		hashPasswordForBreachList("password") + ":3861493\n" +
+		strings.ToLower(hashPasswordForBreachList("123456")) + "\n"))
+	require.NoError(t, err)
+	assert.Len(t, hashes, 2)
+	assert.Contains(t, hashes, passwordHash(sha1.Sum([]byte("123456"))))
+
+	_, err = parseBreachedPasswords(strings.NewReader("not a hash\n"))
+	assert.Error(t, err)
+
+	_, err = parseBreachedPasswords(strings.NewReader(strings.Repeat("Z", 40) + "\n"))
+	assert.Error(t, err)
+}
+
+func TestBreachedPasswordsContains(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "breached.txt")
+	require.NoError(t, os.WriteFile(path, []byte(hashPasswordForBreachList("password")+":1\n"), 0600))
+
+	var list breachedPasswords
+	breached, err := list.contains(path, "password")
+	require.NoError(t, err)
+	assert.True(t, breached)
+
+	breached, err = list.contains(path, "correct horse battery staple")
+	require.NoError(t, err)
+	assert.False(t, breached)
+
+	t.Run("reloads the file when it changes", func(t *testing.T) {
+		require.NoError(t, os.WriteFile(path, []byte(hashPasswordForBreachList("correct horse battery staple")+"\n"), 0600))
+		later := time.Now().Add(time.Minute)
+		require.NoError(t, os.Chtimes(path, later, later))
+
+		breached, err := list.contains(path, "correct horse battery staple")
+		require.NoError(t, err)
+		assert.True(t, breached)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := list.contains(filepath.Join(t.TempDir(), "missing.txt"), "password")
+		assert.Error(t, err)
+	})
+}
+
+func TestBreachedPasswordsContainsRange(t *testing.T) {
+	dir := t.TempDir()
+	hash := hashPasswordForBreachList("password")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, hash[:5]), []byte(strings.Repeat("0", 35)+":2\n"+hash[5:]+":3861493\n"), 0600))
+
+	var list breachedPasswords
+	breached, err := list.contains(dir, "password")
+	require.NoError(t, err)
+	assert.True(t, breached)
+	assert.Nil(t, list.hashes)
+
+	t.Run("missing range file", func(t *testing.T) {
+		breached, err := list.contains(dir, "correct horse battery staple")
+		require.NoError(t, err)
+		assert.False(t, breached)
+	})
+
+	t.Run("range file with a .txt extension", func(t *testing.T) {
+		hash := hashPasswordForBreachList("123456")
+		require.NoError(t, os.WriteFile(filepath.Join(dir, hash[:5]+".txt"), []byte(strings.ToLower(hash[5:])+"\n"), 0600))
+
+		breached, err := list.contains(dir, "123456")
+		require.NoError(t, err)
+		assert.True(t, breached)
+	})
+
+	t.Run("invalid range file", func(t *testing.T) {
+		hash := hashPasswordForBreachList("qwerty")
+		require.NoError(t, os.WriteFile(filepath.Join(dir, hash[:5]), []byte(hash+"\n"), 0600))
+
+		_, err := list.contains(dir, "qwerty")
+		assert.Error(t, err)
+	})
+}
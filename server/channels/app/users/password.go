@@ -10,6 +10,7 @@ import (
 	"golang.org/x/crypto/bcrypt"
 
 	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
 )
 
 func CheckUserPassword(user *model.User, password string) error {
@@ -28,8 +29,29 @@ func ComparePassword(hash string, password string) error {
 	return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
 }
 
-func (us *UserService) isPasswordValid(password string) error {
-	return IsPasswordValidWithSettings(password, &us.config().PasswordSettings)
+// IsPasswordValid checks that the password conforms to the password settings, and that it
+// isn't a breached password. It returns the error id as error value.
+func (us *UserService) IsPasswordValid(password string) error {
+	if err := IsPasswordValidWithSettings(password, &us.config().PasswordSettings); err != nil {
+		return err
+	}
+
+	path := *us.config().PasswordSettings.BreachedPasswordsFile
+	if path == "" {
+		return nil
+	}
+
+	breached, err := us.breachedPasswords.contains(path, password)
+	if err != nil {
+		// An unreadable list shouldn't prevent users from setting passwords.
+		mlog.Warn("Failed to check the password against the breached passwords", mlog.String("path", path), mlog.Err(err))
+		return nil
+	}
+	if breached {
+		return NewErrInvalidPassword("model.user.is_valid.pwd_breached.app_error")
+	}
+
+	return nil
 }
 
 // IsPasswordValidWithSettings is a utility functions that checks if the given password
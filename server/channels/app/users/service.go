@@ -19,6 +19,8 @@ type UserService struct {
 	cluster      einterfaces.ClusterInterface
 	config       func() *model.Config
 	license      func() *model.License
+
+	breachedPasswords breachedPasswords
 }
 
 // ServiceConfig is used to initialize the UserService.
@@ -60,7 +60,7 @@ func (us *UserService) CreateUser(rctx request.CTX, user *model.User, opts UserC
 func (us *UserService) createUser(rctx request.CTX, user *model.User) (*model.User, error) {
 	user.MakeNonNil()
 
-	if err := us.isPasswordValid(user.Password); user.AuthService == "" && err != nil {
+	if err := us.IsPasswordValid(user.Password); user.AuthService == "" && err != nil {
 		return nil, err
 	}
 
@@ -233,4 +233,25 @@ func TestWebConnShouldSendEvent(t *testing.T) {
 
 	event3 := model.NewWebSocketEvent(model.WebsocketEventUpdateTeam, "wrongId", "", "", nil, "")
 	assert.False(t, basicUserWc.ShouldSendEvent(event3))
+
+	t.Run("scoped user access tokens only get the events of their scopes", func(t *testing.T) {
+		scopedSession := session.DeepCopy()
+		scopedSession.AddProp(model.SessionPropType, model.SessionTypeUserAccessToken)
+		scopedSession.AddProp(model.SessionPropUserAccessTokenScopes, "channel:"+th.BasicChannel.Id)
+		scopedWc := &platform.WebConn{
+			Platform: th.Server.Platform(),
+			Suite:    th.App,
+			UserId:   th.BasicUser.Id,
+			T:        i18n.T,
+		}
+		scopedWc.SetConnectionID(model.NewId())
+		scopedWc.SetSession(scopedSession)
+		scopedWc.SetSessionToken(scopedSession.Token)
+		scopedWc.SetSessionExpiresAt(scopedSession.ExpiresAt)
+
+		assert.True(t, scopedWc.ShouldSendEvent(model.NewWebSocketEvent(model.WebsocketEventPosted, "", th.BasicChannel.Id, "", nil, "")))
+		assert.False(t, scopedWc.ShouldSendEvent(model.NewWebSocketEvent(model.WebsocketEventPosted, "", channel2.Id, "", nil, "")))
+		assert.False(t, scopedWc.ShouldSendEvent(model.NewWebSocketEvent(model.WebsocketEventUpdateTeam, th.BasicTeam.Id, "", "", nil, "")))
+		assert.False(t, scopedWc.ShouldSendEvent(model.NewWebSocketEvent(model.WebsocketEventPreferencesChanged, "", "", th.BasicUser.Id, nil, "")))
+	})
 }
@@ -0,0 +1,24 @@
+CREATE TABLE IF NOT EXISTS EscalationPolicies (
+	Id VARCHAR(26) PRIMARY KEY,
+	ChannelId VARCHAR(26) NOT NULL,
+	Priority VARCHAR(32) NOT NULL,
+	Steps json NOT NULL,
+	QuietHours json,
+	CreatorId VARCHAR(26) NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	UpdateAt bigint(20) NOT NULL
+);
+
+SET @preparedStatement = (SELECT IF(
+	 (
+		 SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		 WHERE table_name = 'EscalationPolicies'
+		   AND table_schema = DATABASE()
+		   AND index_name = 'idx_escalationpolicies_channelid_priority'
+	 ) > 0,
+	 'SELECT 1',
+	 'CREATE UNIQUE INDEX idx_escalationpolicies_channelid_priority ON EscalationPolicies (ChannelId, Priority);'
+ ));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'PersistentNotifications'
+        AND table_schema = DATABASE()
+        AND column_name = 'EscalationLevel'
+    ) > 0,
+    'ALTER TABLE PersistentNotifications DROP COLUMN EscalationLevel;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'PersistentNotifications'
+        AND table_schema = DATABASE()
+        AND column_name = 'EscalationLevel'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE PersistentNotifications ADD EscalationLevel smallint DEFAULT 0;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
@@ -0,0 +1,59 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'UserAccessTokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'ExpiresAt'
+    ) > 0,
+    'ALTER TABLE UserAccessTokens DROP COLUMN ExpiresAt;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'UserAccessTokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'Scopes'
+    ) > 0,
+    'ALTER TABLE UserAccessTokens DROP COLUMN Scopes;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'UserAccessTokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'LastUsedAt'
+    ) > 0,
+    'ALTER TABLE UserAccessTokens DROP COLUMN LastUsedAt;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'UserAccessTokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'ExpiryNotified'
+    ) > 0,
+    'ALTER TABLE UserAccessTokens DROP COLUMN ExpiryNotified;',
+    'SELECT 1'
+));
+
+PREPARE alterIfExists FROM @preparedStatement;
+EXECUTE alterIfExists;
+DEALLOCATE PREPARE alterIfExists;
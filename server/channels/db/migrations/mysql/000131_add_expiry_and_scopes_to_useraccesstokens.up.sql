@@ -0,0 +1,59 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'UserAccessTokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'ExpiresAt'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE UserAccessTokens ADD ExpiresAt bigint(20) DEFAULT 0;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'UserAccessTokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'Scopes'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE UserAccessTokens ADD Scopes varchar(1024) DEFAULT NULL;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'UserAccessTokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'LastUsedAt'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE UserAccessTokens ADD LastUsedAt bigint(20) DEFAULT 0;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'UserAccessTokens'
+        AND table_schema = DATABASE()
+        AND column_name = 'ExpiryNotified'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE UserAccessTokens ADD ExpiryNotified tinyint(1) DEFAULT 0;'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
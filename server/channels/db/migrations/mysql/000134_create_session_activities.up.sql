@@ -0,0 +1,60 @@
+CREATE TABLE IF NOT EXISTS SessionActivities (
+	Id VARCHAR(26) PRIMARY KEY,
+	SessionId VARCHAR(26) NOT NULL,
+	UserId VARCHAR(26) NOT NULL,
+	IpAddress VARCHAR(64) NOT NULL,
+	UserAgent VARCHAR(512) NOT NULL,
+	Platform VARCHAR(64) NOT NULL,
+	Os VARCHAR(64) NOT NULL,
+	Browser VARCHAR(64) NOT NULL,
+	BrowserVersion VARCHAR(64) NOT NULL,
+	DeviceId VARCHAR(512) NOT NULL,
+	Region VARCHAR(128) NOT NULL,
+	Latitude double NOT NULL,
+	Longitude double NOT NULL,
+	Anomaly VARCHAR(32) NOT NULL,
+	FirstSeenAt bigint(20) NOT NULL,
+	LastSeenAt bigint(20) NOT NULL
+);
+
+SET @preparedStatement = (SELECT IF(
+	 (
+		 SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		 WHERE table_name = 'SessionActivities'
+		   AND table_schema = DATABASE()
+		   AND index_name = 'idx_sessionactivities_sessionid_ipaddress'
+	 ) > 0,
+	 'SELECT 1',
+	 'CREATE UNIQUE INDEX idx_sessionactivities_sessionid_ipaddress ON SessionActivities (SessionId, IpAddress);'
+ ));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	 (
+		 SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		 WHERE table_name = 'SessionActivities'
+		   AND table_schema = DATABASE()
+		   AND index_name = 'idx_sessionactivities_userid_lastseenat'
+	 ) > 0,
+	 'SELECT 1',
+	 'CREATE INDEX idx_sessionactivities_userid_lastseenat ON SessionActivities (UserId, LastSeenAt);'
+ ));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
+
+SET @preparedStatement = (SELECT IF(
+	 (
+		 SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		 WHERE table_name = 'SessionActivities'
+		   AND table_schema = DATABASE()
+		   AND index_name = 'idx_sessionactivities_lastseenat'
+	 ) > 0,
+	 'SELECT 1',
+	 'CREATE INDEX idx_sessionactivities_lastseenat ON SessionActivities (LastSeenAt);'
+ ));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS PasswordHistory (
+	Id VARCHAR(26) PRIMARY KEY,
+	UserId VARCHAR(26) NOT NULL,
+	PasswordHash VARCHAR(128) NOT NULL,
+	CreateAt bigint(20) NOT NULL
+);
+
+SET @preparedStatement = (SELECT IF(
+	 (
+		 SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		 WHERE table_name = 'PasswordHistory'
+		   AND table_schema = DATABASE()
+		   AND index_name = 'idx_passwordhistory_userid_createat'
+	 ) > 0,
+	 'SELECT 1',
+	 'CREATE INDEX idx_passwordhistory_userid_createat ON PasswordHistory (UserId, CreateAt);'
+ ));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
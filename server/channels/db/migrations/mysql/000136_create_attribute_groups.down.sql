@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS UserAttributes;
+DROP TABLE IF EXISTS GroupAttributeRules;
@@ -0,0 +1,28 @@
+CREATE TABLE IF NOT EXISTS GroupAttributeRules (
+	GroupId VARCHAR(26) PRIMARY KEY,
+	Attribute VARCHAR(255) NOT NULL,
+	Value VARCHAR(255) NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	UpdateAt bigint(20) NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS UserAttributes (
+	UserId VARCHAR(26) NOT NULL,
+	Name VARCHAR(255) NOT NULL,
+	Value VARCHAR(255) NOT NULL,
+	PRIMARY KEY (UserId, Name, Value)
+);
+
+SET @preparedStatement = (SELECT IF(
+	 (
+		 SELECT COUNT(*) FROM INFORMATION_SCHEMA.STATISTICS
+		 WHERE table_name = 'UserAttributes'
+		   AND table_schema = DATABASE()
+		   AND index_name = 'idx_userattributes_name_value'
+	 ) > 0,
+	 'SELECT 1',
+	 'CREATE INDEX idx_userattributes_name_value ON UserAttributes (Name, Value);'
+ ));
+PREPARE createIndexIfNotExists FROM @preparedStatement;
+EXECUTE createIndexIfNotExists;
+DEALLOCATE PREPARE createIndexIfNotExists;
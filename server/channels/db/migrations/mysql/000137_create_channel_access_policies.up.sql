@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS ChannelAccessPolicies (
+	ChannelId VARCHAR(26) PRIMARY KEY,
+	Expression text NOT NULL,
+	CreateAt bigint(20) NOT NULL,
+	UpdateAt bigint(20) NOT NULL
+);
@@ -0,0 +1,14 @@
+SET @preparedStatement = (SELECT IF(
+    (
+        SELECT COUNT(*) FROM INFORMATION_SCHEMA.COLUMNS
+        WHERE table_name = 'OAuthAccessData'
+        AND table_schema = DATABASE()
+        AND column_name = 'GrantType'
+    ) > 0,
+    'SELECT 1',
+    'ALTER TABLE OAuthAccessData ADD GrantType varchar(64) DEFAULT '''';'
+));
+
+PREPARE alterIfNotExists FROM @preparedStatement;
+EXECUTE alterIfNotExists;
+DEALLOCATE PREPARE alterIfNotExists;
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_escalationpolicies_channelid_priority;
+DROP TABLE IF EXISTS escalationpolicies;
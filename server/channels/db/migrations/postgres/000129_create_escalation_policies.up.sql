@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS escalationpolicies (
+	id VARCHAR(26) PRIMARY KEY,
+	channelid VARCHAR(26) NOT NULL,
+	priority VARCHAR(32) NOT NULL,
+	steps jsonb NOT NULL,
+	quiethours jsonb,
+	creatorid VARCHAR(26) NOT NULL,
+	createat bigint NOT NULL,
+	updateat bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_escalationpolicies_channelid_priority ON escalationpolicies (channelid, priority);
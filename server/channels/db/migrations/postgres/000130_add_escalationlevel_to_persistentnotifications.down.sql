@@ -0,0 +1 @@
+ALTER TABLE persistentnotifications DROP COLUMN IF EXISTS escalationlevel;
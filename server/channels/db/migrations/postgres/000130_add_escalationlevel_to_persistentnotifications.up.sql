@@ -0,0 +1 @@
+ALTER TABLE persistentnotifications ADD COLUMN IF NOT EXISTS escalationlevel smallint DEFAULT 0;
@@ -0,0 +1,4 @@
+ALTER TABLE useraccesstokens DROP COLUMN IF EXISTS expiresat;
+ALTER TABLE useraccesstokens DROP COLUMN IF EXISTS scopes;
+ALTER TABLE useraccesstokens DROP COLUMN IF EXISTS lastusedat;
+ALTER TABLE useraccesstokens DROP COLUMN IF EXISTS expirynotified;
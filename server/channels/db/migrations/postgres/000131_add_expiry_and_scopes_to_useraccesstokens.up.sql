@@ -0,0 +1,4 @@
+ALTER TABLE useraccesstokens ADD COLUMN IF NOT EXISTS expiresat bigint DEFAULT 0;
+ALTER TABLE useraccesstokens ADD COLUMN IF NOT EXISTS scopes varchar(1024) DEFAULT NULL;
+ALTER TABLE useraccesstokens ADD COLUMN IF NOT EXISTS lastusedat bigint DEFAULT 0;
+ALTER TABLE useraccesstokens ADD COLUMN IF NOT EXISTS expirynotified boolean DEFAULT false;
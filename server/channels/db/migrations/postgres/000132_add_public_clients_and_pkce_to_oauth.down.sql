@@ -0,0 +1,7 @@
+ALTER TABLE oauthapps DROP COLUMN IF EXISTS ispublic;
+ALTER TABLE oauthapps DROP COLUMN IF EXISTS scopes;
+ALTER TABLE oauthapps DROP COLUMN IF EXISTS botuserid;
+ALTER TABLE oauthauthdata DROP COLUMN IF EXISTS codechallenge;
+ALTER TABLE oauthauthdata DROP COLUMN IF EXISTS codechallengemethod;
+ALTER TABLE oauthauthdata ALTER COLUMN scope TYPE varchar(128);
+ALTER TABLE oauthaccessdata ALTER COLUMN scope TYPE varchar(128);
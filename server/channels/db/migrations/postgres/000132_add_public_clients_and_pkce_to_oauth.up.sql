@@ -0,0 +1,7 @@
+ALTER TABLE oauthapps ADD COLUMN IF NOT EXISTS ispublic boolean DEFAULT false;
+ALTER TABLE oauthapps ADD COLUMN IF NOT EXISTS scopes varchar(1024) DEFAULT NULL;
+ALTER TABLE oauthapps ADD COLUMN IF NOT EXISTS botuserid varchar(26) DEFAULT '';
+ALTER TABLE oauthauthdata ADD COLUMN IF NOT EXISTS codechallenge varchar(128) DEFAULT '';
+ALTER TABLE oauthauthdata ADD COLUMN IF NOT EXISTS codechallengemethod varchar(16) DEFAULT '';
+ALTER TABLE oauthauthdata ALTER COLUMN scope TYPE varchar(1024);
+ALTER TABLE oauthaccessdata ALTER COLUMN scope TYPE varchar(1024);
@@ -0,0 +1 @@
+ALTER TABLE oauthauthdata DROP COLUMN IF EXISTS nonce;
@@ -0,0 +1 @@
+ALTER TABLE oauthauthdata ADD COLUMN IF NOT EXISTS nonce varchar(256) DEFAULT '';
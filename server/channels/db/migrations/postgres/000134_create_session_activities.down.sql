@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_sessionactivities_lastseenat;
+DROP INDEX IF EXISTS idx_sessionactivities_userid_lastseenat;
+DROP INDEX IF EXISTS idx_sessionactivities_sessionid_ipaddress;
+DROP TABLE IF EXISTS sessionactivities;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS sessionactivities (
+	id VARCHAR(26) PRIMARY KEY,
+	sessionid VARCHAR(26) NOT NULL,
+	userid VARCHAR(26) NOT NULL,
+	ipaddress VARCHAR(64) NOT NULL,
+	useragent VARCHAR(512) NOT NULL,
+	platform VARCHAR(64) NOT NULL,
+	os VARCHAR(64) NOT NULL,
+	browser VARCHAR(64) NOT NULL,
+	browserversion VARCHAR(64) NOT NULL,
+	deviceid VARCHAR(512) NOT NULL,
+	region VARCHAR(128) NOT NULL,
+	latitude double precision NOT NULL,
+	longitude double precision NOT NULL,
+	anomaly VARCHAR(32) NOT NULL,
+	firstseenat bigint NOT NULL,
+	lastseenat bigint NOT NULL
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_sessionactivities_sessionid_ipaddress ON sessionactivities (sessionid, ipaddress);
+CREATE INDEX IF NOT EXISTS idx_sessionactivities_userid_lastseenat ON sessionactivities (userid, lastseenat);
+CREATE INDEX IF NOT EXISTS idx_sessionactivities_lastseenat ON sessionactivities (lastseenat);
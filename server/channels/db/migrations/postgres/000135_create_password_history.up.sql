@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS passwordhistory (
+	id VARCHAR(26) PRIMARY KEY,
+	userid VARCHAR(26) NOT NULL,
+	passwordhash VARCHAR(128) NOT NULL,
+	createat bigint NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_passwordhistory_userid_createat ON passwordhistory (userid, createat);
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_userattributes_name_value;
+DROP TABLE IF EXISTS userattributes;
+DROP TABLE IF EXISTS groupattributerules;
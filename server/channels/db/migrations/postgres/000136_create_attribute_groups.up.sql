@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS groupattributerules (
+	groupid VARCHAR(26) PRIMARY KEY,
+	attribute VARCHAR(255) NOT NULL,
+	value VARCHAR(255) NOT NULL,
+	createat bigint NOT NULL,
+	updateat bigint NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS userattributes (
+	userid VARCHAR(26) NOT NULL,
+	name VARCHAR(255) NOT NULL,
+	value VARCHAR(255) NOT NULL,
+	PRIMARY KEY (userid, name, value)
+);
+
+CREATE INDEX IF NOT EXISTS idx_userattributes_name_value ON userattributes (name, value);
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS channelaccesspolicies (
+	channelid VARCHAR(26) PRIMARY KEY,
+	expression text NOT NULL,
+	createat bigint NOT NULL,
+	updateat bigint NOT NULL
+);
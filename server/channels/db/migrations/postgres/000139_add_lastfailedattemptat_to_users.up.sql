@@ -0,0 +1 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS lastfailedattemptat bigint DEFAULT 0;
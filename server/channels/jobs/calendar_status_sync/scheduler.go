@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package calendar_status_sync
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+const schedFreq = 5 * time.Minute
+
+func MakeScheduler(jobServer *jobs.JobServer) *jobs.PeriodicScheduler {
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeCalendarStatusSync, schedFreq, isEnabled)
+}
+
+func isEnabled(cfg *model.Config) bool {
+	return *cfg.ServiceSettings.EnableCalendarStatusSync && *cfg.ServiceSettings.EnableUserStatuses
+}
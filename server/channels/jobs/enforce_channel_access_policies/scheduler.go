@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package enforce_channel_access_policies
+
+import (
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/jobs"
+)
+
+const schedFreq = 1 * time.Hour
+
+func MakeScheduler(jobServer *jobs.JobServer) *jobs.PeriodicScheduler {
+	return jobs.NewPeriodicScheduler(jobServer, model.JobTypeEnforceChannelAccessPolicies, schedFreq, isEnabled)
+}
+
+func isEnabled(cfg *model.Config) bool {
+	return true
+}
@@ -53,6 +53,11 @@ func MakeWorker(jobServer *jobs.JobServer, app AppIface) *jobs.SimpleWorker {
 			opts.IncludeRolesAndSchemes = true
 		}
 
+		includePluginKeyValues, ok := job.Data["include_plugin_key_values"]
+		if ok && includePluginKeyValues == "true" {
+			opts.IncludePluginKeyValues = true
+		}
+
 		outPath := *app.Config().ExportSettings.Directory
 		exportFilename := job.Id + "_export.zip"
 
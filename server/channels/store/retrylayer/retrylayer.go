@@ -23,9 +23,11 @@ const mySQLDeadlockCode = uint16(1213)
 
 type RetryLayer struct {
 	store.Store
+	AttributeGroupStore             store.AttributeGroupStore
 	AuditStore                      store.AuditStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
+	ChannelAccessPolicyStore        store.ChannelAccessPolicyStore
 	ChannelBookmarkStore            store.ChannelBookmarkStore
 	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
 	ClusterDiscoveryStore           store.ClusterDiscoveryStore
@@ -35,6 +37,7 @@ type RetryLayer struct {
 	DesktopTokensStore              store.DesktopTokensStore
 	DraftStore                      store.DraftStore
 	EmojiStore                      store.EmojiStore
+	EscalationPolicyStore           store.EscalationPolicyStore
 	FileInfoStore                   store.FileInfoStore
 	GroupStore                      store.GroupStore
 	JobStore                        store.JobStore
@@ -43,6 +46,7 @@ type RetryLayer struct {
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PasswordHistoryStore            store.PasswordHistoryStore
 	PluginStore                     store.PluginStore
 	PostStore                       store.PostStore
 	PostAcknowledgementStore        store.PostAcknowledgementStore
@@ -57,6 +61,7 @@ type RetryLayer struct {
 	ScheduledPostStore              store.ScheduledPostStore
 	SchemeStore                     store.SchemeStore
 	SessionStore                    store.SessionStore
+	SessionActivityStore            store.SessionActivityStore
 	SharedChannelStore              store.SharedChannelStore
 	StatusStore                     store.StatusStore
 	SystemStore                     store.SystemStore
@@ -71,6 +76,10 @@ type RetryLayer struct {
 	WebhookStore                    store.WebhookStore
 }
 
+func (s *RetryLayer) AttributeGroup() store.AttributeGroupStore {
+	return s.AttributeGroupStore
+}
+
 func (s *RetryLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
@@ -83,6 +92,10 @@ func (s *RetryLayer) Channel() store.ChannelStore {
 	return s.ChannelStore
 }
 
+func (s *RetryLayer) ChannelAccessPolicy() store.ChannelAccessPolicyStore {
+	return s.ChannelAccessPolicyStore
+}
+
 func (s *RetryLayer) ChannelBookmark() store.ChannelBookmarkStore {
 	return s.ChannelBookmarkStore
 }
@@ -119,6 +132,10 @@ func (s *RetryLayer) Emoji() store.EmojiStore {
 	return s.EmojiStore
 }
 
+func (s *RetryLayer) EscalationPolicy() store.EscalationPolicyStore {
+	return s.EscalationPolicyStore
+}
+
 func (s *RetryLayer) FileInfo() store.FileInfoStore {
 	return s.FileInfoStore
 }
@@ -151,6 +168,10 @@ func (s *RetryLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStor
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *RetryLayer) PasswordHistory() store.PasswordHistoryStore {
+	return s.PasswordHistoryStore
+}
+
 func (s *RetryLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -207,6 +228,10 @@ func (s *RetryLayer) Session() store.SessionStore {
 	return s.SessionStore
 }
 
+func (s *RetryLayer) SessionActivity() store.SessionActivityStore {
+	return s.SessionActivityStore
+}
+
 func (s *RetryLayer) SharedChannel() store.SharedChannelStore {
 	return s.SharedChannelStore
 }
@@ -255,6 +280,11 @@ func (s *RetryLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
 
+type RetryLayerAttributeGroupStore struct {
+	store.AttributeGroupStore
+	Root *RetryLayer
+}
+
 type RetryLayerAuditStore struct {
 	store.AuditStore
 	Root *RetryLayer
@@ -270,6 +300,11 @@ type RetryLayerChannelStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerChannelAccessPolicyStore struct {
+	store.ChannelAccessPolicyStore
+	Root *RetryLayer
+}
+
 type RetryLayerChannelBookmarkStore struct {
 	store.ChannelBookmarkStore
 	Root *RetryLayer
@@ -315,6 +350,11 @@ type RetryLayerEmojiStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerEscalationPolicyStore struct {
+	store.EscalationPolicyStore
+	Root *RetryLayer
+}
+
 type RetryLayerFileInfoStore struct {
 	store.FileInfoStore
 	Root *RetryLayer
@@ -355,6 +395,11 @@ type RetryLayerOutgoingOAuthConnectionStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerPasswordHistoryStore struct {
+	store.PasswordHistoryStore
+	Root *RetryLayer
+}
+
 type RetryLayerPluginStore struct {
 	store.PluginStore
 	Root *RetryLayer
@@ -425,6 +470,11 @@ type RetryLayerSessionStore struct {
 	Root *RetryLayer
 }
 
+type RetryLayerSessionActivityStore struct {
+	store.SessionActivityStore
+	Root *RetryLayer
+}
+
 type RetryLayerSharedChannelStore struct {
 	store.SharedChannelStore
 	Root *RetryLayer
@@ -501,6 +551,174 @@ func isRepeatableError(err error) bool {
 	return false
 }
 
+func (s *RetryLayerAttributeGroupStore) DeleteRule(groupID string) error {
+
+	tries := 0
+	for {
+		err := s.AttributeGroupStore.DeleteRule(groupID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAttributeGroupStore) GetRule(groupID string) (*model.GroupAttributeRule, error) {
+
+	tries := 0
+	for {
+		result, err := s.AttributeGroupStore.GetRule(groupID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAttributeGroupStore) GetRules() ([]*model.GroupAttributeRule, error) {
+
+	tries := 0
+	for {
+		result, err := s.AttributeGroupStore.GetRules()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAttributeGroupStore) GetUserAttributes(userID string) (map[string][]string, error) {
+
+	tries := 0
+	for {
+		result, err := s.AttributeGroupStore.GetUserAttributes(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAttributeGroupStore) GetUserIDsByAttribute(attribute string, value string) ([]string, error) {
+
+	tries := 0
+	for {
+		result, err := s.AttributeGroupStore.GetUserIDsByAttribute(attribute, value)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAttributeGroupStore) PermanentDeleteUserAttributes(userID string) error {
+
+	tries := 0
+	for {
+		err := s.AttributeGroupStore.PermanentDeleteUserAttributes(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAttributeGroupStore) SaveRule(rule *model.GroupAttributeRule) (*model.GroupAttributeRule, error) {
+
+	tries := 0
+	for {
+		result, err := s.AttributeGroupStore.SaveRule(rule)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerAttributeGroupStore) SetUserAttributes(userID string, attributes map[string][]string) error {
+
+	tries := 0
+	for {
+		err := s.AttributeGroupStore.SetUserAttributes(userID, attributes)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerAuditStore) Get(userID string, offset int, limit int) (model.Audits, error) {
 
 	tries := 0
@@ -3081,6 +3299,90 @@ func (s *RetryLayerChannelStore) UserBelongsToChannels(userID string, channelIds
 
 }
 
+func (s *RetryLayerChannelAccessPolicyStore) Delete(channelID string) error {
+
+	tries := 0
+	for {
+		err := s.ChannelAccessPolicyStore.Delete(channelID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelAccessPolicyStore) Get(channelID string) (*model.ChannelAccessPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelAccessPolicyStore.Get(channelID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelAccessPolicyStore) GetAll(offset int, limit int) ([]*model.ChannelAccessPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelAccessPolicyStore.GetAll(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerChannelAccessPolicyStore) Save(policy *model.ChannelAccessPolicy) (*model.ChannelAccessPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.ChannelAccessPolicyStore.Save(policy)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerChannelBookmarkStore) Delete(bookmarkID string, deleteFile bool) error {
 
 	tries := 0
@@ -4305,11 +4607,11 @@ func (s *RetryLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 
 }
 
-func (s *RetryLayerFileInfoStore) AttachToPost(c request.CTX, fileID string, postID string, channelID string, creatorID string) error {
+func (s *RetryLayerEscalationPolicyStore) Delete(id string) error {
 
 	tries := 0
 	for {
-		err := s.FileInfoStore.AttachToPost(c, fileID, postID, channelID, creatorID)
+		err := s.EscalationPolicyStore.Delete(id)
 		if err == nil {
 			return nil
 		}
@@ -4326,7 +4628,112 @@ func (s *RetryLayerFileInfoStore) AttachToPost(c request.CTX, fileID string, pos
 
 }
 
-func (s *RetryLayerFileInfoStore) ClearCaches() {
+func (s *RetryLayerEscalationPolicyStore) Get(id string) (*model.EscalationPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.EscalationPolicyStore.Get(id)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEscalationPolicyStore) GetForChannels(channelIDs []string) ([]*model.EscalationPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.EscalationPolicyStore.GetForChannels(channelIDs)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEscalationPolicyStore) Save(policy *model.EscalationPolicy) (*model.EscalationPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.EscalationPolicyStore.Save(policy)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerEscalationPolicyStore) Update(policy *model.EscalationPolicy) (*model.EscalationPolicy, error) {
+
+	tries := 0
+	for {
+		result, err := s.EscalationPolicyStore.Update(policy)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerFileInfoStore) AttachToPost(c request.CTX, fileID string, postID string, channelID string, creatorID string) error {
+
+	tries := 0
+	for {
+		err := s.FileInfoStore.AttachToPost(c, fileID, postID, channelID, creatorID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerFileInfoStore) ClearCaches() {
 
 	s.FileInfoStore.ClearCaches()
 
@@ -7005,6 +7412,111 @@ func (s *RetryLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX,
 
 }
 
+func (s *RetryLayerPasswordHistoryStore) GetForUser(userID string, limit int) ([]*model.PasswordHistory, error) {
+
+	tries := 0
+	for {
+		result, err := s.PasswordHistoryStore.GetForUser(userID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPasswordHistoryStore) PermanentDeleteByUser(userID string) error {
+
+	tries := 0
+	for {
+		err := s.PasswordHistoryStore.PermanentDeleteByUser(userID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPasswordHistoryStore) PruneForUser(userID string, keep int) error {
+
+	tries := 0
+	for {
+		err := s.PasswordHistoryStore.PruneForUser(userID, keep)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPasswordHistoryStore) Save(history *model.PasswordHistory) error {
+
+	tries := 0
+	for {
+		err := s.PasswordHistoryStore.Save(history)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPluginStore) Batch(pluginID string, operations []*model.PluginKVBatchOperation) (bool, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.Batch(pluginID, operations)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 
 	tries := 0
@@ -7131,6 +7643,48 @@ func (s *RetryLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 
 }
 
+func (s *RetryLayerPluginStore) GetAllForExport(afterPluginID string, afterKey string, limit int) ([]*model.PluginKeyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.GetAllForExport(afterPluginID, afterKey, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerPluginStore) GetMany(pluginID string, keys []string) ([]*model.PluginKeyValue, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.GetMany(pluginID, keys)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 
 	tries := 0
@@ -7152,6 +7706,27 @@ func (s *RetryLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 
 }
 
+func (s *RetryLayerPluginStore) ListWithPrefix(pluginID string, prefix string, page int, perPage int) ([]string, error) {
+
+	tries := 0
+	for {
+		result, err := s.PluginStore.ListWithPrefix(pluginID, prefix, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 
 	tries := 0
@@ -8310,11 +8885,11 @@ func (s *RetryLayerPostPersistentNotificationStore) DeleteByTeam(teamIds []strin
 
 }
 
-func (s *RetryLayerPostPersistentNotificationStore) DeleteExpired(maxSentCount int16) error {
+func (s *RetryLayerPostPersistentNotificationStore) DeleteExpired(maxSentCount int16, exceptPostIds []string) error {
 
 	tries := 0
 	for {
-		err := s.PostPersistentNotificationStore.DeleteExpired(maxSentCount)
+		err := s.PostPersistentNotificationStore.DeleteExpired(maxSentCount, exceptPostIds)
 		if err == nil {
 			return nil
 		}
@@ -8352,6 +8927,27 @@ func (s *RetryLayerPostPersistentNotificationStore) Get(params model.GetPersiste
 
 }
 
+func (s *RetryLayerPostPersistentNotificationStore) GetExpired(maxSentCount int16) ([]*model.PostPersistentNotifications, error) {
+
+	tries := 0
+	for {
+		result, err := s.PostPersistentNotificationStore.GetExpired(maxSentCount)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostPersistentNotificationStore) GetSingle(postID string) (*model.PostPersistentNotifications, error) {
 
 	tries := 0
@@ -8373,6 +8969,27 @@ func (s *RetryLayerPostPersistentNotificationStore) GetSingle(postID string) (*m
 
 }
 
+func (s *RetryLayerPostPersistentNotificationStore) UpdateEscalationLevel(postID string, level int16) error {
+
+	tries := 0
+	for {
+		err := s.PostPersistentNotificationStore.UpdateEscalationLevel(postID, level)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerPostPersistentNotificationStore) UpdateLastActivity(postIds []string) error {
 
 	tries := 0
@@ -10212,26 +10829,215 @@ func (s *RetryLayerSessionStore) Get(c request.CTX, sessionIDOrToken string) (*m
 	for {
 		result, err := s.SessionStore.Get(c, sessionIDOrToken)
 		if err == nil {
-			return result, nil
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetAllSessions(afterID string, limit uint64) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetAllSessions(afterID, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetLRUSessions(c request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetLRUSessions(c, userID, limit, offset)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetMobileSessionMetadata() ([]*model.MobileSessionMetadata, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetMobileSessionMetadata()
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetSessions(c request.CTX, userID string) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetSessions(c, userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+
+	tries := 0
+	for {
+		result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) Remove(sessionIDOrToken string) error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.Remove(sessionIDOrToken)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerSessionStore) RemoveAllSessions() error {
+
+	tries := 0
+	for {
+		err := s.SessionStore.RemoveAllSessions()
+		if err == nil {
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) GetLRUSessions(c request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
+func (s *RetryLayerSessionStore) Save(c request.CTX, session *model.Session) (*model.Session, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetLRUSessions(c, userID, limit, offset)
+		result, err := s.SessionStore.Save(c, session)
 		if err == nil {
 			return result, nil
 		}
@@ -10248,11 +11054,11 @@ func (s *RetryLayerSessionStore) GetLRUSessions(c request.CTX, userID string, li
 
 }
 
-func (s *RetryLayerSessionStore) GetMobileSessionMetadata() ([]*model.MobileSessionMetadata, error) {
+func (s *RetryLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetMobileSessionMetadata()
+		result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
 		if err == nil {
 			return result, nil
 		}
@@ -10269,74 +11075,74 @@ func (s *RetryLayerSessionStore) GetMobileSessionMetadata() ([]*model.MobileSess
 
 }
 
-func (s *RetryLayerSessionStore) GetSessions(c request.CTX, userID string) ([]*model.Session, error) {
+func (s *RetryLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessions(c, userID)
+		err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error) {
+func (s *RetryLayerSessionStore) UpdateExpiresAt(sessionID string, timestamp int64) error {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessionsExpired(thresholdMillis, mobileOnly, unnotifiedOnly)
+		err := s.SessionStore.UpdateExpiresAt(sessionID, timestamp)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error) {
+func (s *RetryLayerSessionStore) UpdateLastActivityAt(sessionID string, timestamp int64) error {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.GetSessionsWithActiveDeviceIds(userID)
+		err := s.SessionStore.UpdateLastActivityAt(sessionID, timestamp)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) error {
+func (s *RetryLayerSessionStore) UpdateProps(session *model.Session) error {
 
 	tries := 0
 	for {
-		err := s.SessionStore.PermanentDeleteSessionsByUser(teamID)
+		err := s.SessionStore.UpdateProps(session)
 		if err == nil {
 			return nil
 		}
@@ -10353,53 +11159,53 @@ func (s *RetryLayerSessionStore) PermanentDeleteSessionsByUser(teamID string) er
 
 }
 
-func (s *RetryLayerSessionStore) Remove(sessionIDOrToken string) error {
+func (s *RetryLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.Remove(sessionIDOrToken)
+		result, err := s.SessionStore.UpdateRoles(userID, roles)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) RemoveAllSessions() error {
+func (s *RetryLayerSessionActivityStore) GetAll(opts model.SessionActivityGetOptions) ([]*model.SessionActivity, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.RemoveAllSessions()
+		result, err := s.SessionActivityStore.GetAll(opts)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) Save(c request.CTX, session *model.Session) (*model.Session, error) {
+func (s *RetryLayerSessionActivityStore) GetLatestLocatedForUser(userID string) (*model.SessionActivity, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.Save(c, session)
+		result, err := s.SessionActivityStore.GetLatestLocatedForUser(userID)
 		if err == nil {
 			return result, nil
 		}
@@ -10416,11 +11222,11 @@ func (s *RetryLayerSessionStore) Save(c request.CTX, session *model.Session) (*m
 
 }
 
-func (s *RetryLayerSessionStore) UpdateDeviceId(id string, deviceID string, expiresAt int64) (string, error) {
+func (s *RetryLayerSessionActivityStore) HasUserActivity(userID string, excludeSessionID string) (bool, error) {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.UpdateDeviceId(id, deviceID, expiresAt)
+		result, err := s.SessionActivityStore.HasUserActivity(userID, excludeSessionID)
 		if err == nil {
 			return result, nil
 		}
@@ -10437,53 +11243,53 @@ func (s *RetryLayerSessionStore) UpdateDeviceId(id string, deviceID string, expi
 
 }
 
-func (s *RetryLayerSessionStore) UpdateExpiredNotify(sessionid string, notified bool) error {
+func (s *RetryLayerSessionActivityStore) HasUserUsedDevice(activity *model.SessionActivity) (bool, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateExpiredNotify(sessionid, notified)
+		result, err := s.SessionActivityStore.HasUserUsedDevice(activity)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateExpiresAt(sessionID string, timestamp int64) error {
+func (s *RetryLayerSessionActivityStore) PermanentDeleteBefore(lastSeenAt int64, limit int64) (int64, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateExpiresAt(sessionID, timestamp)
+		result, err := s.SessionActivityStore.PermanentDeleteBefore(lastSeenAt, limit)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateLastActivityAt(sessionID string, timestamp int64) error {
+func (s *RetryLayerSessionActivityStore) PermanentDeleteByUser(userID string) error {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateLastActivityAt(sessionID, timestamp)
+		err := s.SessionActivityStore.PermanentDeleteByUser(userID)
 		if err == nil {
 			return nil
 		}
@@ -10500,42 +11306,42 @@ func (s *RetryLayerSessionStore) UpdateLastActivityAt(sessionID string, timestam
 
 }
 
-func (s *RetryLayerSessionStore) UpdateProps(session *model.Session) error {
+func (s *RetryLayerSessionActivityStore) Save(activity *model.SessionActivity) (*model.SessionActivity, error) {
 
 	tries := 0
 	for {
-		err := s.SessionStore.UpdateProps(session)
+		result, err := s.SessionActivityStore.Save(activity)
 		if err == nil {
-			return nil
+			return result, nil
 		}
 		if !isRepeatableError(err) {
-			return err
+			return result, err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return err
+			return result, err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
 
 }
 
-func (s *RetryLayerSessionStore) UpdateRoles(userID string, roles string) (string, error) {
+func (s *RetryLayerSessionActivityStore) UpdateLastSeenAt(sessionID string, ipAddress string, lastSeenAt int64) error {
 
 	tries := 0
 	for {
-		result, err := s.SessionStore.UpdateRoles(userID, roles)
+		err := s.SessionActivityStore.UpdateLastSeenAt(sessionID, ipAddress, lastSeenAt)
 		if err == nil {
-			return result, nil
+			return nil
 		}
 		if !isRepeatableError(err) {
-			return result, err
+			return err
 		}
 		tries++
 		if tries >= 3 {
 			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
-			return result, err
+			return err
 		}
 		timepkg.Sleep(100 * timepkg.Millisecond)
 	}
@@ -13101,6 +13907,27 @@ func (s *RetryLayerTokenStore) GetAllTokensByType(tokenType string) ([]*model.To
 
 }
 
+func (s *RetryLayerTokenStore) GetAllTokensByTypeAndUser(tokenType string, userID string) ([]*model.Token, error) {
+
+	tries := 0
+	for {
+		result, err := s.TokenStore.GetAllTokensByTypeAndUser(tokenType, userID)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerTokenStore) GetByToken(token string) (*model.Token, error) {
 
 	tries := 0
@@ -13164,6 +13991,27 @@ func (s *RetryLayerTokenStore) Save(recovery *model.Token) error {
 
 }
 
+func (s *RetryLayerTokenStore) UpdateExtra(token string, previousExtra string, extra string) error {
+
+	tries := 0
+	for {
+		err := s.TokenStore.UpdateExtra(token, previousExtra, extra)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUploadSessionStore) Delete(id string) error {
 
 	tries := 0
@@ -13569,6 +14417,27 @@ func (s *RetryLayerUserStore) GetAllAfter(limit int, afterID string) ([]*model.U
 
 }
 
+func (s *RetryLayerUserStore) GetAllLocalUsers(offset int, limit int) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetAllLocalUsers(offset, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
 
 	tries := 0
@@ -14280,6 +15149,27 @@ func (s *RetryLayerUserStore) GetUsersBatchForIndexing(startTime int64, startFil
 
 }
 
+func (s *RetryLayerUserStore) GetUsersWithExpiredPasswords(lastPasswordUpdateBefore int64, page int, perPage int) ([]*model.User, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserStore.GetUsersWithExpiredPasswords(lastPasswordUpdateBefore, page, perPage)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserStore) GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error) {
 
 	tries := 0
@@ -14424,6 +15314,27 @@ func (s *RetryLayerUserStore) PromoteGuestToUser(userID string) error {
 
 }
 
+func (s *RetryLayerUserStore) RecordFailedPasswordAttempt(userID string, attempts int, failedAt int64) error {
+
+	tries := 0
+	for {
+		err := s.UserStore.RecordFailedPasswordAttempt(userID, attempts, failedAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserStore) RefreshPostStatsForUsers() error {
 
 	tries := 0
@@ -15033,6 +15944,27 @@ func (s *RetryLayerUserAccessTokenStore) GetByUser(userID string, page int, perP
 
 }
 
+func (s *RetryLayerUserAccessTokenStore) GetExpiringUnnotified(expiresAfter int64, expiresBefore int64, limit int) ([]*model.UserAccessToken, error) {
+
+	tries := 0
+	for {
+		result, err := s.UserAccessTokenStore.GetExpiringUnnotified(expiresAfter, expiresBefore, limit)
+		if err == nil {
+			return result, nil
+		}
+		if !isRepeatableError(err) {
+			return result, err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return result, err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
 
 	tries := 0
@@ -15075,6 +16007,48 @@ func (s *RetryLayerUserAccessTokenStore) Search(term string) ([]*model.UserAcces
 
 }
 
+func (s *RetryLayerUserAccessTokenStore) UpdateExpiryNotified(tokenID string) error {
+
+	tries := 0
+	for {
+		err := s.UserAccessTokenStore.UpdateExpiryNotified(tokenID)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
+func (s *RetryLayerUserAccessTokenStore) UpdateLastUsedAt(tokenID string, lastUsedAt int64) error {
+
+	tries := 0
+	for {
+		err := s.UserAccessTokenStore.UpdateLastUsedAt(tokenID, lastUsedAt)
+		if err == nil {
+			return nil
+		}
+		if !isRepeatableError(err) {
+			return err
+		}
+		tries++
+		if tries >= 3 {
+			err = errors.Wrap(err, "giving up after 3 consecutive repeatable transaction failures")
+			return err
+		}
+		timepkg.Sleep(100 * timepkg.Millisecond)
+	}
+
+}
+
 func (s *RetryLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
 
 	tries := 0
@@ -15758,9 +16732,11 @@ func New(childStore store.Store) *RetryLayer {
 		Store: childStore,
 	}
 
+	newStore.AttributeGroupStore = &RetryLayerAttributeGroupStore{AttributeGroupStore: childStore.AttributeGroup(), Root: &newStore}
 	newStore.AuditStore = &RetryLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
 	newStore.BotStore = &RetryLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &RetryLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelAccessPolicyStore = &RetryLayerChannelAccessPolicyStore{ChannelAccessPolicyStore: childStore.ChannelAccessPolicy(), Root: &newStore}
 	newStore.ChannelBookmarkStore = &RetryLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &RetryLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &RetryLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
@@ -15770,6 +16746,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.DesktopTokensStore = &RetryLayerDesktopTokensStore{DesktopTokensStore: childStore.DesktopTokens(), Root: &newStore}
 	newStore.DraftStore = &RetryLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
 	newStore.EmojiStore = &RetryLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.EscalationPolicyStore = &RetryLayerEscalationPolicyStore{EscalationPolicyStore: childStore.EscalationPolicy(), Root: &newStore}
 	newStore.FileInfoStore = &RetryLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &RetryLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &RetryLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
@@ -15778,6 +16755,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.NotifyAdminStore = &RetryLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &RetryLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &RetryLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PasswordHistoryStore = &RetryLayerPasswordHistoryStore{PasswordHistoryStore: childStore.PasswordHistory(), Root: &newStore}
 	newStore.PluginStore = &RetryLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &RetryLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &RetryLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -15792,6 +16770,7 @@ func New(childStore store.Store) *RetryLayer {
 	newStore.ScheduledPostStore = &RetryLayerScheduledPostStore{ScheduledPostStore: childStore.ScheduledPost(), Root: &newStore}
 	newStore.SchemeStore = &RetryLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &RetryLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.SessionActivityStore = &RetryLayerSessionActivityStore{SessionActivityStore: childStore.SessionActivity(), Root: &newStore}
 	newStore.SharedChannelStore = &RetryLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
 	newStore.StatusStore = &RetryLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.SystemStore = &RetryLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
@@ -0,0 +1,221 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"sort"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlAttributeGroupStore struct {
+	*SqlStore
+}
+
+func newSqlAttributeGroupStore(sqlStore *SqlStore) store.AttributeGroupStore {
+	return &SqlAttributeGroupStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlAttributeGroupStore) SaveRule(rule *model.GroupAttributeRule) (_ *model.GroupAttributeRule, err error) {
+	rule.PreSave()
+	if appErr := rule.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	// Keep the creation time of the rule the group already has.
+	var createAt int64
+	query := s.getQueryBuilder().
+		Select("CreateAt").
+		From("GroupAttributeRules").
+		Where(sq.Eq{"GroupId": rule.GroupId})
+	if err = transaction.GetBuilder(&createAt, query); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to get the attribute rule of group with id=%s", rule.GroupId)
+	}
+
+	if err == sql.ErrNoRows {
+		builder := s.getQueryBuilder().
+			Insert("GroupAttributeRules").
+			Columns("GroupId", "Attribute", "Value", "CreateAt", "UpdateAt").
+			Values(rule.GroupId, rule.Attribute, rule.Value, rule.CreateAt, rule.UpdateAt)
+		if _, err = transaction.ExecBuilder(builder); err != nil {
+			return nil, errors.Wrapf(err, "failed to save the attribute rule of group with id=%s", rule.GroupId)
+		}
+	} else {
+		rule.CreateAt = createAt
+		builder := s.getQueryBuilder().
+			Update("GroupAttributeRules").
+			Set("Attribute", rule.Attribute).
+			Set("Value", rule.Value).
+			Set("UpdateAt", rule.UpdateAt).
+			Where(sq.Eq{"GroupId": rule.GroupId})
+		if _, err = transaction.ExecBuilder(builder); err != nil {
+			return nil, errors.Wrapf(err, "failed to update the attribute rule of group with id=%s", rule.GroupId)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return rule, nil
+}
+
+func (s *SqlAttributeGroupStore) GetRule(groupID string) (*model.GroupAttributeRule, error) {
+	builder := s.getQueryBuilder().
+		Select("GroupId", "Attribute", "Value", "CreateAt", "UpdateAt").
+		From("GroupAttributeRules").
+		Where(sq.Eq{"GroupId": groupID})
+
+	var rule model.GroupAttributeRule
+	if err := s.GetReplica().GetBuilder(&rule, builder); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("GroupAttributeRule", "group_id="+groupID)
+		}
+		return nil, errors.Wrapf(err, "failed to get the attribute rule of group with id=%s", groupID)
+	}
+
+	return &rule, nil
+}
+
+func (s *SqlAttributeGroupStore) GetRules() ([]*model.GroupAttributeRule, error) {
+	builder := s.getQueryBuilder().
+		Select("GroupAttributeRules.GroupId", "GroupAttributeRules.Attribute", "GroupAttributeRules.Value",
+			"GroupAttributeRules.CreateAt", "GroupAttributeRules.UpdateAt").
+		From("GroupAttributeRules").
+		Join("UserGroups ON UserGroups.Id = GroupAttributeRules.GroupId").
+		Where(sq.Eq{"UserGroups.DeleteAt": 0, "UserGroups.Source": model.GroupSourceAttribute}).
+		OrderBy("GroupAttributeRules.GroupId")
+
+	rules := []*model.GroupAttributeRule{}
+	if err := s.GetReplica().SelectBuilder(&rules, builder); err != nil {
+		return nil, errors.Wrap(err, "failed to get the attribute rules")
+	}
+
+	return rules, nil
+}
+
+func (s *SqlAttributeGroupStore) DeleteRule(groupID string) error {
+	builder := s.getQueryBuilder().
+		Delete("GroupAttributeRules").
+		Where(sq.Eq{"GroupId": groupID})
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to delete the attribute rule of group with id=%s", groupID)
+	}
+
+	return nil
+}
+
+func (s *SqlAttributeGroupStore) SetUserAttributes(userID string, attributes map[string][]string) (err error) {
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	deleteBuilder := s.getQueryBuilder().
+		Delete("UserAttributes").
+		Where(sq.Eq{"UserId": userID})
+	if _, err = transaction.ExecBuilder(deleteBuilder); err != nil {
+		return errors.Wrapf(err, "failed to delete the attributes of user with id=%s", userID)
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	builder := s.getQueryBuilder().
+		Insert("UserAttributes").
+		Columns("UserId", "Name", "Value")
+	hasValues := false
+	for _, name := range names {
+		seen := map[string]bool{}
+		for _, value := range attributes[name] {
+			if seen[value] {
+				continue
+			}
+			seen[value] = true
+			builder = builder.Values(userID, name, value)
+			hasValues = true
+		}
+	}
+
+	if hasValues {
+		if _, err = transaction.ExecBuilder(builder); err != nil {
+			return errors.Wrapf(err, "failed to save the attributes of user with id=%s", userID)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return errors.Wrap(err, "commit_transaction")
+	}
+
+	return nil
+}
+
+func (s *SqlAttributeGroupStore) GetUserAttributes(userID string) (map[string][]string, error) {
+	builder := s.getQueryBuilder().
+		Select("Name", "Value").
+		From("UserAttributes").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("Name", "Value")
+
+	rows := []struct {
+		Name  string
+		Value string
+	}{}
+	if err := s.GetReplica().SelectBuilder(&rows, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the attributes of user with id=%s", userID)
+	}
+
+	attributes := make(map[string][]string)
+	for _, row := range rows {
+		attributes[row.Name] = append(attributes[row.Name], row.Value)
+	}
+
+	return attributes, nil
+}
+
+func (s *SqlAttributeGroupStore) GetUserIDsByAttribute(attribute, value string) ([]string, error) {
+	builder := s.getQueryBuilder().
+		Select("UserAttributes.UserId").
+		From("UserAttributes").
+		Join("Users ON Users.Id = UserAttributes.UserId").
+		Where(sq.Eq{"UserAttributes.Name": attribute, "UserAttributes.Value": value, "Users.DeleteAt": 0}).
+		OrderBy("UserAttributes.UserId")
+
+	userIDs := []string{}
+	if err := s.GetReplica().SelectBuilder(&userIDs, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the users with attribute=%s", attribute)
+	}
+
+	return userIDs, nil
+}
+
+func (s *SqlAttributeGroupStore) PermanentDeleteUserAttributes(userID string) error {
+	builder := s.getQueryBuilder().
+		Delete("UserAttributes").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to delete the attributes of user with id=%s", userID)
+	}
+
+	return nil
+}
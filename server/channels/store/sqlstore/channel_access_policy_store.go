@@ -0,0 +1,121 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlChannelAccessPolicyStore struct {
+	*SqlStore
+}
+
+func newSqlChannelAccessPolicyStore(sqlStore *SqlStore) store.ChannelAccessPolicyStore {
+	return &SqlChannelAccessPolicyStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlChannelAccessPolicyStore) Save(policy *model.ChannelAccessPolicy) (_ *model.ChannelAccessPolicy, err error) {
+	policy.PreSave()
+	if appErr := policy.IsValid(); appErr != nil {
+		return nil, appErr
+	}
+
+	transaction, err := s.GetMaster().Beginx()
+	if err != nil {
+		return nil, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	// Keep the creation time of the policy the channel already has.
+	var createAt int64
+	query := s.getQueryBuilder().
+		Select("CreateAt").
+		From("ChannelAccessPolicies").
+		Where(sq.Eq{"ChannelId": policy.ChannelId})
+	if err = transaction.GetBuilder(&createAt, query); err != nil && err != sql.ErrNoRows {
+		return nil, errors.Wrapf(err, "failed to get the access policy of channel with id=%s", policy.ChannelId)
+	}
+
+	if err == sql.ErrNoRows {
+		builder := s.getQueryBuilder().
+			Insert("ChannelAccessPolicies").
+			Columns("ChannelId", "Expression", "CreateAt", "UpdateAt").
+			Values(policy.ChannelId, policy.Expression, policy.CreateAt, policy.UpdateAt)
+		if _, err = transaction.ExecBuilder(builder); err != nil {
+			return nil, errors.Wrapf(err, "failed to save the access policy of channel with id=%s", policy.ChannelId)
+		}
+	} else {
+		policy.CreateAt = createAt
+		builder := s.getQueryBuilder().
+			Update("ChannelAccessPolicies").
+			Set("Expression", policy.Expression).
+			Set("UpdateAt", policy.UpdateAt).
+			Where(sq.Eq{"ChannelId": policy.ChannelId})
+		if _, err = transaction.ExecBuilder(builder); err != nil {
+			return nil, errors.Wrapf(err, "failed to update the access policy of channel with id=%s", policy.ChannelId)
+		}
+	}
+
+	if err = transaction.Commit(); err != nil {
+		return nil, errors.Wrap(err, "commit_transaction")
+	}
+
+	return policy, nil
+}
+
+func (s *SqlChannelAccessPolicyStore) Get(channelID string) (*model.ChannelAccessPolicy, error) {
+	builder := s.getQueryBuilder().
+		Select("ChannelId", "Expression", "CreateAt", "UpdateAt").
+		From("ChannelAccessPolicies").
+		Where(sq.Eq{"ChannelId": channelID})
+
+	var policy model.ChannelAccessPolicy
+	if err := s.GetReplica().GetBuilder(&policy, builder); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("ChannelAccessPolicy", "channel_id="+channelID)
+		}
+		return nil, errors.Wrapf(err, "failed to get the access policy of channel with id=%s", channelID)
+	}
+
+	return &policy, nil
+}
+
+func (s *SqlChannelAccessPolicyStore) GetAll(offset, limit int) ([]*model.ChannelAccessPolicy, error) {
+	builder := s.getQueryBuilder().
+		Select("ChannelAccessPolicies.ChannelId", "ChannelAccessPolicies.Expression",
+			"ChannelAccessPolicies.CreateAt", "ChannelAccessPolicies.UpdateAt").
+		From("ChannelAccessPolicies").
+		Join("Channels ON Channels.Id = ChannelAccessPolicies.ChannelId").
+		Where(sq.Eq{"Channels.DeleteAt": 0}).
+		OrderBy("ChannelAccessPolicies.ChannelId").
+		Offset(uint64(offset)).
+		Limit(uint64(limit))
+
+	policies := []*model.ChannelAccessPolicy{}
+	if err := s.GetReplica().SelectBuilder(&policies, builder); err != nil {
+		return nil, errors.Wrap(err, "failed to get the channel access policies")
+	}
+
+	return policies, nil
+}
+
+func (s *SqlChannelAccessPolicyStore) Delete(channelID string) error {
+	builder := s.getQueryBuilder().
+		Delete("ChannelAccessPolicies").
+		Where(sq.Eq{"ChannelId": channelID})
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to delete the access policy of channel with id=%s", channelID)
+	}
+
+	return nil
+}
@@ -0,0 +1,219 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlEscalationPolicyStore struct {
+	*SqlStore
+}
+
+// escalationPolicyRow is the database representation of an escalation
+// policy, with the steps and quiet hours stored as JSON.
+type escalationPolicyRow struct {
+	Id         string
+	ChannelId  string
+	Priority   string
+	Steps      string
+	QuietHours sql.NullString
+	CreatorId  string
+	CreateAt   int64
+	UpdateAt   int64
+}
+
+func newSqlEscalationPolicyStore(sqlStore *SqlStore) store.EscalationPolicyStore {
+	return &SqlEscalationPolicyStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func escalationPolicyColumns() []string {
+	return []string{
+		"Id",
+		"ChannelId",
+		"Priority",
+		"Steps",
+		"QuietHours",
+		"CreatorId",
+		"CreateAt",
+		"UpdateAt",
+	}
+}
+
+func escalationPolicyToRow(policy *model.EscalationPolicy) (*escalationPolicyRow, error) {
+	steps, err := json.Marshal(policy.Steps)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal escalation steps")
+	}
+
+	row := &escalationPolicyRow{
+		Id:        policy.Id,
+		ChannelId: policy.ChannelId,
+		Priority:  policy.Priority,
+		Steps:     string(steps),
+		CreatorId: policy.CreatorId,
+		CreateAt:  policy.CreateAt,
+		UpdateAt:  policy.UpdateAt,
+	}
+
+	if policy.QuietHours != nil {
+		quietHours, err := json.Marshal(policy.QuietHours)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to marshal quiet hours")
+		}
+		row.QuietHours = sql.NullString{String: string(quietHours), Valid: true}
+	}
+
+	return row, nil
+}
+
+func (row *escalationPolicyRow) toModel() (*model.EscalationPolicy, error) {
+	policy := &model.EscalationPolicy{
+		Id:        row.Id,
+		ChannelId: row.ChannelId,
+		Priority:  row.Priority,
+		CreatorId: row.CreatorId,
+		CreateAt:  row.CreateAt,
+		UpdateAt:  row.UpdateAt,
+	}
+
+	if err := json.Unmarshal([]byte(row.Steps), &policy.Steps); err != nil {
+		return nil, errors.Wrapf(err, "failed to unmarshal escalation steps of policy %s", row.Id)
+	}
+
+	if row.QuietHours.Valid && row.QuietHours.String != "" {
+		if err := json.Unmarshal([]byte(row.QuietHours.String), &policy.QuietHours); err != nil {
+			return nil, errors.Wrapf(err, "failed to unmarshal quiet hours of policy %s", row.Id)
+		}
+	}
+
+	return policy, nil
+}
+
+func (s *SqlEscalationPolicyStore) Save(policy *model.EscalationPolicy) (*model.EscalationPolicy, error) {
+	policy.PreSave()
+	if err := policy.IsValid(); err != nil {
+		return nil, err
+	}
+
+	row, err := escalationPolicyToRow(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("EscalationPolicies").
+		Columns(escalationPolicyColumns()...).
+		Values(row.Id, row.ChannelId, row.Priority, row.Steps, row.QuietHours, row.CreatorId, row.CreateAt, row.UpdateAt)
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		if IsUniqueConstraintError(err, []string{"ChannelId", "idx_escalationpolicies_channelid_priority"}) {
+			return nil, store.NewErrConflict("EscalationPolicy", err, "channel_id="+policy.ChannelId+", priority="+policy.Priority)
+		}
+		return nil, errors.Wrap(err, "failed to save escalation policy")
+	}
+
+	return policy, nil
+}
+
+func (s *SqlEscalationPolicyStore) Update(policy *model.EscalationPolicy) (*model.EscalationPolicy, error) {
+	policy.PreUpdate()
+	if err := policy.IsValid(); err != nil {
+		return nil, err
+	}
+
+	row, err := escalationPolicyToRow(policy)
+	if err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Update("EscalationPolicies").
+		SetMap(map[string]any{
+			"Steps":      row.Steps,
+			"QuietHours": row.QuietHours,
+			"UpdateAt":   row.UpdateAt,
+		}).
+		Where(sq.Eq{"Id": policy.Id})
+
+	result, err := s.GetMaster().ExecBuilder(builder)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to update escalation policy with id=%s", policy.Id)
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return nil, store.NewErrNotFound("EscalationPolicy", policy.Id)
+	}
+
+	return policy, nil
+}
+
+func (s *SqlEscalationPolicyStore) Get(id string) (*model.EscalationPolicy, error) {
+	builder := s.getQueryBuilder().
+		Select(escalationPolicyColumns()...).
+		From("EscalationPolicies").
+		Where(sq.Eq{"Id": id})
+
+	var row escalationPolicyRow
+	if err := s.GetReplica().GetBuilder(&row, builder); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("EscalationPolicy", id)
+		}
+		return nil, errors.Wrapf(err, "failed to get escalation policy with id=%s", id)
+	}
+
+	return row.toModel()
+}
+
+func (s *SqlEscalationPolicyStore) GetForChannels(channelIDs []string) ([]*model.EscalationPolicy, error) {
+	if len(channelIDs) == 0 {
+		return []*model.EscalationPolicy{}, nil
+	}
+
+	builder := s.getQueryBuilder().
+		Select(escalationPolicyColumns()...).
+		From("EscalationPolicies").
+		Where(sq.Eq{"ChannelId": channelIDs}).
+		OrderBy("ChannelId", "Priority")
+
+	var rows []*escalationPolicyRow
+	if err := s.GetReplica().SelectBuilder(&rows, builder); err != nil {
+		return nil, errors.Wrap(err, "failed to get escalation policies")
+	}
+
+	policies := make([]*model.EscalationPolicy, 0, len(rows))
+	for _, row := range rows {
+		policy, err := row.toModel()
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, policy)
+	}
+
+	return policies, nil
+}
+
+func (s *SqlEscalationPolicyStore) Delete(id string) error {
+	builder := s.getQueryBuilder().
+		Delete("EscalationPolicies").
+		Where(sq.Eq{"Id": id})
+
+	result, err := s.GetMaster().ExecBuilder(builder)
+	if err != nil {
+		return errors.Wrapf(err, "failed to delete escalation policy with id=%s", id)
+	}
+	if count, _ := result.RowsAffected(); count == 0 {
+		return store.NewErrNotFound("EscalationPolicy", id)
+	}
+
+	return nil
+}
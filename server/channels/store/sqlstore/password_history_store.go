@@ -0,0 +1,92 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlPasswordHistoryStore struct {
+	*SqlStore
+}
+
+func newSqlPasswordHistoryStore(sqlStore *SqlStore) store.PasswordHistoryStore {
+	return &SqlPasswordHistoryStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func (s *SqlPasswordHistoryStore) Save(history *model.PasswordHistory) error {
+	history.PreSave()
+
+	builder := s.getQueryBuilder().
+		Insert("PasswordHistory").
+		Columns("Id", "UserId", "PasswordHash", "CreateAt").
+		Values(history.Id, history.UserId, history.PasswordHash, history.CreateAt)
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to save the password history of user with id=%s", history.UserId)
+	}
+
+	return nil
+}
+
+func (s *SqlPasswordHistoryStore) GetForUser(userID string, limit int) ([]*model.PasswordHistory, error) {
+	builder := s.getQueryBuilder().
+		Select("Id", "UserId", "PasswordHash", "CreateAt").
+		From("PasswordHistory").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt DESC", "Id").
+		Limit(uint64(limit))
+
+	history := []*model.PasswordHistory{}
+	if err := s.GetMaster().SelectBuilder(&history, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the password history of user with id=%s", userID)
+	}
+
+	return history, nil
+}
+
+func (s *SqlPasswordHistoryStore) PruneForUser(userID string, keep int) error {
+	builder := s.getQueryBuilder().
+		Select("Id").
+		From("PasswordHistory").
+		Where(sq.Eq{"UserId": userID}).
+		OrderBy("CreateAt DESC", "Id")
+
+	ids := []string{}
+	if err := s.GetMaster().SelectBuilder(&ids, builder); err != nil {
+		return errors.Wrapf(err, "failed to get the password history of user with id=%s", userID)
+	}
+	if len(ids) <= keep {
+		return nil
+	}
+	ids = ids[keep:]
+
+	deleteBuilder := s.getQueryBuilder().
+		Delete("PasswordHistory").
+		Where(sq.Eq{"Id": ids})
+
+	if _, err := s.GetMaster().ExecBuilder(deleteBuilder); err != nil {
+		return errors.Wrapf(err, "failed to prune the password history of user with id=%s", userID)
+	}
+
+	return nil
+}
+
+func (s *SqlPasswordHistoryStore) PermanentDeleteByUser(userID string) error {
+	builder := s.getQueryBuilder().
+		Delete("PasswordHistory").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to delete the password history of user with id=%s", userID)
+	}
+
+	return nil
+}
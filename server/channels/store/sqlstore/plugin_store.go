@@ -7,6 +7,7 @@ import (
 	"bytes"
 	"database/sql"
 	"fmt"
+	"strings"
 
 	sq "github.com/mattermost/squirrel"
 	"github.com/pkg/errors"
@@ -28,13 +29,17 @@ func newSqlPluginStore(sqlStore *SqlStore) store.PluginStore {
 }
 
 func (ps SqlPluginStore) SaveOrUpdate(kv *model.PluginKeyValue) (*model.PluginKeyValue, error) {
+	return ps.saveOrUpdate(ps.GetMaster(), kv)
+}
+
+func (ps SqlPluginStore) saveOrUpdate(executor sqlxExecutor, kv *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 	if err := kv.IsValid(); err != nil {
 		return nil, err
 	}
 
 	if kv.Value == nil {
 		// Setting a key to nil is the same as removing it
-		err := ps.Delete(kv.PluginId, kv.Key)
+		err := ps.delete(executor, kv.PluginId, kv.Key)
 		if err != nil {
 			return nil, err
 		}
@@ -57,7 +62,7 @@ func (ps SqlPluginStore) SaveOrUpdate(kv *model.PluginKeyValue) (*model.PluginKe
 		return nil, errors.Wrap(err, "plugin_tosql")
 	}
 
-	if _, err := ps.GetMaster().Exec(queryString, args...); err != nil {
+	if _, err := executor.Exec(queryString, args...); err != nil {
 		return nil, errors.Wrap(err, "failed to upsert PluginKeyValue")
 	}
 
@@ -65,13 +70,17 @@ func (ps SqlPluginStore) SaveOrUpdate(kv *model.PluginKeyValue) (*model.PluginKe
 }
 
 func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	return ps.compareAndSet(ps.GetMaster(), kv, oldValue)
+}
+
+func (ps SqlPluginStore) compareAndSet(executor sqlxExecutor, kv *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	if err := kv.IsValid(); err != nil {
 		return false, err
 	}
 
 	if kv.Value == nil {
 		// Setting a key to nil is the same as removing it
-		return ps.CompareAndDelete(kv, oldValue)
+		return ps.compareAndDelete(executor, kv, oldValue)
 	}
 
 	if oldValue == nil {
@@ -88,7 +97,7 @@ func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte
 			return false, errors.Wrap(err, "plugin_tosql")
 		}
 
-		if _, err = ps.GetMaster().Exec(queryString, args...); err != nil {
+		if _, err = executor.Exec(queryString, args...); err != nil {
 			return false, errors.Wrap(err, "failed to delete PluginKeyValue")
 		}
 
@@ -101,7 +110,7 @@ func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte
 			return false, errors.Wrap(err, "plugin_tosql")
 		}
 
-		if _, err := ps.GetMaster().Exec(queryString, args...); err != nil {
+		if _, err := executor.Exec(queryString, args...); err != nil {
 			// If the error is from unique constraints violation, it's the result of a
 			// race condition, return false and no error. Otherwise we have a real error and
 			// need to return it.
@@ -131,7 +140,7 @@ func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte
 			return false, errors.Wrap(err, "plugin_tosql")
 		}
 
-		updateResult, err := ps.GetMaster().Exec(queryString, args...)
+		updateResult, err := executor.Exec(queryString, args...)
 		if err != nil {
 			return false, errors.Wrap(err, "failed to update PluginKeyValue")
 		}
@@ -163,7 +172,7 @@ func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte
 				}
 
 				var count int64
-				err = ps.GetReplica().Get(&count, queryString, args...)
+				err = executor.Get(&count, queryString, args...)
 				if err != nil {
 					return false, errors.Wrapf(err, "failed to count PluginKeyValue with pluginId=%s and key=%s", kv.PluginId, kv.Key)
 				}
@@ -186,6 +195,10 @@ func (ps SqlPluginStore) CompareAndSet(kv *model.PluginKeyValue, oldValue []byte
 }
 
 func (ps SqlPluginStore) CompareAndDelete(kv *model.PluginKeyValue, oldValue []byte) (bool, error) {
+	return ps.compareAndDelete(ps.GetMaster(), kv, oldValue)
+}
+
+func (ps SqlPluginStore) compareAndDelete(executor sqlxExecutor, kv *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	if err := kv.IsValid(); err != nil {
 		return false, err
 	}
@@ -210,7 +223,7 @@ func (ps SqlPluginStore) CompareAndDelete(kv *model.PluginKeyValue, oldValue []b
 		return false, errors.Wrap(err, "plugin_tosql")
 	}
 
-	deleteResult, err := ps.GetMaster().Exec(queryString, args...)
+	deleteResult, err := executor.Exec(queryString, args...)
 	if err != nil {
 		return false, errors.Wrap(err, "failed to delete PluginKeyValue")
 	}
@@ -225,6 +238,10 @@ func (ps SqlPluginStore) CompareAndDelete(kv *model.PluginKeyValue, oldValue []b
 }
 
 func (ps SqlPluginStore) SetWithOptions(pluginId string, key string, value []byte, opt model.PluginKVSetOptions) (bool, error) {
+	return ps.setWithOptions(ps.GetMaster(), pluginId, key, value, opt)
+}
+
+func (ps SqlPluginStore) setWithOptions(executor sqlxExecutor, pluginId string, key string, value []byte, opt model.PluginKVSetOptions) (bool, error) {
 	if err := opt.IsValid(); err != nil {
 		return false, err
 	}
@@ -235,10 +252,10 @@ func (ps SqlPluginStore) SetWithOptions(pluginId string, key string, value []byt
 	}
 
 	if opt.Atomic {
-		return ps.CompareAndSet(kv, opt.OldValue)
+		return ps.compareAndSet(executor, kv, opt.OldValue)
 	}
 
-	savedKv, nErr := ps.SaveOrUpdate(kv)
+	savedKv, nErr := ps.saveOrUpdate(executor, kv)
 	if nErr != nil {
 		return false, nErr
 	}
@@ -271,6 +288,10 @@ func (ps SqlPluginStore) Get(pluginId, key string) (*model.PluginKeyValue, error
 }
 
 func (ps SqlPluginStore) Delete(pluginId, key string) error {
+	return ps.delete(ps.GetMaster(), pluginId, key)
+}
+
+func (ps SqlPluginStore) delete(executor sqlxExecutor, pluginId, key string) error {
 	query := ps.getQueryBuilder().
 		Delete("PluginKeyValueStore").
 		Where(sq.Eq{"PluginId": pluginId}).
@@ -281,7 +302,7 @@ func (ps SqlPluginStore) Delete(pluginId, key string) error {
 		return errors.Wrap(err, "plugin_tosql")
 	}
 
-	if _, err := ps.GetMaster().Exec(queryString, args...); err != nil {
+	if _, err := executor.Exec(queryString, args...); err != nil {
 		return errors.Wrapf(err, "failed to delete PluginKeyValue with pluginId=%s and key=%s", pluginId, key)
 	}
 	return nil
@@ -355,3 +376,157 @@ func (ps SqlPluginStore) List(pluginId string, offset int, limit int) ([]string,
 
 	return keys, nil
 }
+
+func (ps SqlPluginStore) ListWithPrefix(pluginId, prefix string, offset int, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = defaultPluginKeyFetchLimit
+	}
+
+	if offset <= 0 {
+		offset = 0
+	}
+
+	query := ps.getQueryBuilder().
+		Select("Pkey").
+		From("PluginKeyValueStore").
+		Where(sq.Eq{"PluginId": pluginId}).
+		Where(sq.Or{
+			sq.Eq{"ExpireAt": int(0)},
+			sq.Gt{"ExpireAt": model.GetMillis()},
+		}).
+		OrderBy("PKey").
+		Limit(uint64(limit)).
+		Offset(uint64(offset))
+
+	if prefix != "" {
+		query = query.Where("PKey LIKE ? ESCAPE '*'", escapeLikePrefix(prefix)+"%")
+	}
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "plugin_tosql")
+	}
+
+	keys := []string{}
+	err = ps.GetReplica().Select(&keys, queryString, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get PluginKeyValues with pluginId=%s and prefix=%s", pluginId, prefix)
+	}
+
+	return keys, nil
+}
+
+// escapeLikePrefix escapes the wildcards of the given prefix, so that it only matches itself in
+// a LIKE pattern using '*' as escape character.
+func escapeLikePrefix(prefix string) string {
+	return strings.NewReplacer("*", "**", "%", "*%", "_", "*_").Replace(prefix)
+}
+
+func (ps SqlPluginStore) GetMany(pluginId string, keys []string) ([]*model.PluginKeyValue, error) {
+	if len(keys) == 0 {
+		return []*model.PluginKeyValue{}, nil
+	}
+
+	query := ps.getQueryBuilder().
+		Select("PluginId", "PKey", "PValue", "ExpireAt").
+		From("PluginKeyValueStore").
+		Where(sq.Eq{"PluginId": pluginId}).
+		Where(sq.Eq{"PKey": keys}).
+		Where(sq.Or{
+			sq.Eq{"ExpireAt": int(0)},
+			sq.Gt{"ExpireAt": model.GetMillis()},
+		})
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "plugin_tosql")
+	}
+
+	kvs, err := ps.selectPluginKeyValues(queryString, args...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get PluginKeyValues with pluginId=%s", pluginId)
+	}
+
+	return kvs, nil
+}
+
+func (ps SqlPluginStore) Batch(pluginId string, operations []*model.PluginKVBatchOperation) (_ bool, err error) {
+	for _, operation := range operations {
+		if appErr := operation.Options.IsValid(); appErr != nil {
+			return false, appErr
+		}
+	}
+
+	transaction, err := ps.GetMaster().Beginx()
+	if err != nil {
+		return false, errors.Wrap(err, "begin_transaction")
+	}
+	defer finalizeTransactionX(transaction, &err)
+
+	for _, operation := range operations {
+		set, err := ps.setWithOptions(transaction, pluginId, operation.Key, operation.Value, operation.Options)
+		if err != nil {
+			return false, err
+		}
+		if !set {
+			// The condition of an atomic operation doesn't hold, so none of the batch applies.
+			return false, nil
+		}
+	}
+
+	if err := transaction.Commit(); err != nil {
+		return false, errors.Wrap(err, "commit_transaction")
+	}
+
+	return true, nil
+}
+
+func (ps SqlPluginStore) GetAllForExport(afterPluginId, afterKey string, limit int) ([]*model.PluginKeyValue, error) {
+	query := ps.getQueryBuilder().
+		Select("PluginId", "PKey", "PValue", "ExpireAt").
+		From("PluginKeyValueStore").
+		Where(sq.Or{
+			sq.Gt{"PluginId": afterPluginId},
+			sq.And{
+				sq.Eq{"PluginId": afterPluginId},
+				sq.Gt{"PKey": afterKey},
+			},
+		}).
+		Where(sq.Or{
+			sq.Eq{"ExpireAt": int(0)},
+			sq.Gt{"ExpireAt": model.GetMillis()},
+		}).
+		OrderBy("PluginId", "PKey").
+		Limit(uint64(limit))
+
+	queryString, args, err := query.ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "plugin_tosql")
+	}
+
+	kvs, err := ps.selectPluginKeyValues(queryString, args...)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get PluginKeyValues for export")
+	}
+
+	return kvs, nil
+}
+
+func (ps SqlPluginStore) selectPluginKeyValues(query string, args ...any) (_ []*model.PluginKeyValue, err error) {
+	rows, err := ps.GetReplica().QueryX(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer deferClose(rows, &err)
+
+	kvs := []*model.PluginKeyValue{}
+	for rows.Next() {
+		var kv model.PluginKeyValue
+		if err := rows.Scan(&kv.PluginId, &kv.Key, &kv.Value, &kv.ExpireAt); err != nil {
+			return nil, err
+		}
+		kvs = append(kvs, &kv)
+	}
+
+	return kvs, rows.Err()
+}
@@ -24,7 +24,7 @@ func newSqlPostPersistentNotificationStore(sqlStore *SqlStore) store.PostPersist
 
 func (s *SqlPostPersistentNotificationStore) GetSingle(postID string) (*model.PostPersistentNotifications, error) {
 	builder := s.getQueryBuilder().
-		Select("PostId, CreateAt, LastSentAt, DeleteAt, SentCount").
+		Select("PostId, CreateAt, LastSentAt, DeleteAt, SentCount, EscalationLevel").
 		From("PersistentNotifications").
 		Where(sq.And{
 			sq.Eq{"DeleteAt": 0},
@@ -49,7 +49,7 @@ func (s *SqlPostPersistentNotificationStore) Get(params model.GetPersistentNotif
 	}
 
 	builder := s.getQueryBuilder().
-		Select("PostId, CreateAt, LastSentAt, DeleteAt, SentCount").
+		Select("PostId, CreateAt, LastSentAt, DeleteAt, SentCount, EscalationLevel").
 		From("PersistentNotifications").
 		Where(sq.And{
 			sq.Eq{"DeleteAt": 0},
@@ -85,6 +85,20 @@ func (s *SqlPostPersistentNotificationStore) UpdateLastActivity(postIds []string
 	return nil
 }
 
+func (s *SqlPostPersistentNotificationStore) UpdateEscalationLevel(postID string, level int16) error {
+	builder := s.getQueryBuilder().
+		Update("PersistentNotifications").
+		Set("EscalationLevel", level).
+		Where(sq.Eq{"PostId": postID})
+
+	_, err := s.GetMaster().ExecBuilder(builder)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update escalation level for post %s", postID)
+	}
+
+	return nil
+}
+
 func (s *SqlPostPersistentNotificationStore) Delete(postIds []string) error {
 	count := len(postIds)
 	if count == 0 {
@@ -104,15 +118,38 @@ func (s *SqlPostPersistentNotificationStore) Delete(postIds []string) error {
 	return nil
 }
 
-func (s *SqlPostPersistentNotificationStore) DeleteExpired(maxSentCount int16) error {
+func (s *SqlPostPersistentNotificationStore) GetExpired(maxSentCount int16) ([]*model.PostPersistentNotifications, error) {
 	builder := s.getQueryBuilder().
-		Update("PersistentNotifications").
-		Set("DeleteAt", model.GetMillis()).
+		Select("PostId, CreateAt, LastSentAt, DeleteAt, SentCount, EscalationLevel").
+		From("PersistentNotifications").
 		Where(sq.And{
 			sq.Eq{"DeleteAt": 0},
 			sq.GtOrEq{"SentCount": maxSentCount},
 		})
 
+	var posts []*model.PostPersistentNotifications
+	err := s.GetMaster().SelectBuilder(&posts, builder)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to get expired notifications")
+	}
+
+	return posts, nil
+}
+
+func (s *SqlPostPersistentNotificationStore) DeleteExpired(maxSentCount int16, exceptPostIds []string) error {
+	conditions := sq.And{
+		sq.Eq{"DeleteAt": 0},
+		sq.GtOrEq{"SentCount": maxSentCount},
+	}
+	if len(exceptPostIds) > 0 {
+		conditions = append(conditions, sq.NotEq{"PostId": exceptPostIds})
+	}
+
+	builder := s.getQueryBuilder().
+		Update("PersistentNotifications").
+		Set("DeleteAt", model.GetMillis()).
+		Where(conditions)
+
 	_, err := s.GetMaster().ExecBuilder(builder)
 	if err != nil {
 		return errors.Wrap(err, "failed to delete notifications")
@@ -0,0 +1,240 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package sqlstore
+
+import (
+	"database/sql"
+
+	sq "github.com/mattermost/squirrel"
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+type SqlSessionActivityStore struct {
+	*SqlStore
+}
+
+func newSqlSessionActivityStore(sqlStore *SqlStore) store.SessionActivityStore {
+	return &SqlSessionActivityStore{
+		SqlStore: sqlStore,
+	}
+}
+
+func sessionActivityColumns() []string {
+	return []string{
+		"Id",
+		"SessionId",
+		"UserId",
+		"IpAddress",
+		"UserAgent",
+		"Platform",
+		"Os",
+		"Browser",
+		"BrowserVersion",
+		"DeviceId",
+		"Region",
+		"Latitude",
+		"Longitude",
+		"Anomaly",
+		"FirstSeenAt",
+		"LastSeenAt",
+	}
+}
+
+func (s *SqlSessionActivityStore) Save(activity *model.SessionActivity) (*model.SessionActivity, error) {
+	activity.PreSave()
+	if err := activity.IsValid(); err != nil {
+		return nil, err
+	}
+
+	builder := s.getQueryBuilder().
+		Insert("SessionActivities").
+		Columns(sessionActivityColumns()...).
+		Values(
+			activity.Id,
+			activity.SessionId,
+			activity.UserId,
+			activity.IpAddress,
+			activity.UserAgent,
+			activity.Platform,
+			activity.Os,
+			activity.Browser,
+			activity.BrowserVersion,
+			activity.DeviceId,
+			activity.Region,
+			activity.Latitude,
+			activity.Longitude,
+			activity.Anomaly,
+			activity.FirstSeenAt,
+			activity.LastSeenAt,
+		)
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		if IsUniqueConstraintError(err, []string{"SessionId", "idx_sessionactivities_sessionid_ipaddress"}) {
+			return nil, store.NewErrConflict("SessionActivity", err, "session_id="+activity.SessionId+", ip_address="+activity.IpAddress)
+		}
+		return nil, errors.Wrap(err, "failed to save session activity")
+	}
+
+	return activity, nil
+}
+
+func (s *SqlSessionActivityStore) UpdateLastSeenAt(sessionID, ipAddress string, lastSeenAt int64) error {
+	builder := s.getQueryBuilder().
+		Update("SessionActivities").
+		Set("LastSeenAt", lastSeenAt).
+		Where(sq.Eq{"SessionId": sessionID, "IpAddress": ipAddress}).
+		Where(sq.Lt{"LastSeenAt": lastSeenAt})
+
+	result, err := s.GetMaster().ExecBuilder(builder)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update the last activity of session with id=%s", sessionID)
+	}
+	if count, _ := result.RowsAffected(); count > 0 {
+		return nil
+	}
+
+	// Nothing was updated either because the session wasn't used from this address before, or
+	// because it was seen later already.
+	var exists bool
+	existsBuilder := s.getQueryBuilder().
+		Select("1").
+		From("SessionActivities").
+		Where(sq.Eq{"SessionId": sessionID, "IpAddress": ipAddress})
+	if err := s.GetMaster().GetBuilder(&exists, existsBuilder); err != nil {
+		if err == sql.ErrNoRows {
+			return store.NewErrNotFound("SessionActivity", "session_id="+sessionID+", ip_address="+ipAddress)
+		}
+		return errors.Wrapf(err, "failed to get the activity of session with id=%s", sessionID)
+	}
+
+	return nil
+}
+
+func (s *SqlSessionActivityStore) GetAll(opts model.SessionActivityGetOptions) ([]*model.SessionActivity, error) {
+	builder := s.getQueryBuilder().
+		Select(sessionActivityColumns()...).
+		From("SessionActivities").
+		OrderBy("LastSeenAt DESC", "Id").
+		Limit(uint64(opts.PerPage)).
+		Offset(uint64(opts.Page * opts.PerPage))
+
+	if opts.UserId != "" {
+		builder = builder.Where(sq.Eq{"UserId": opts.UserId})
+	}
+	if opts.AnomaliesOnly {
+		builder = builder.Where(sq.NotEq{"Anomaly": ""})
+	}
+
+	activities := []*model.SessionActivity{}
+	if err := s.GetReplica().SelectBuilder(&activities, builder); err != nil {
+		return nil, errors.Wrap(err, "failed to get session activities")
+	}
+
+	return activities, nil
+}
+
+func (s *SqlSessionActivityStore) GetLatestLocatedForUser(userID string) (*model.SessionActivity, error) {
+	builder := s.getQueryBuilder().
+		Select(sessionActivityColumns()...).
+		From("SessionActivities").
+		Where(sq.Eq{"UserId": userID}).
+		Where(sq.NotEq{"Region": ""}).
+		OrderBy("LastSeenAt DESC").
+		Limit(1)
+
+	var activity model.SessionActivity
+	if err := s.GetMaster().GetBuilder(&activity, builder); err != nil {
+		if err == sql.ErrNoRows {
+			return nil, store.NewErrNotFound("SessionActivity", "user_id="+userID)
+		}
+		return nil, errors.Wrapf(err, "failed to get the latest located activity of user with id=%s", userID)
+	}
+
+	return &activity, nil
+}
+
+func (s *SqlSessionActivityStore) HasUserUsedDevice(activity *model.SessionActivity) (bool, error) {
+	builder := s.getQueryBuilder().
+		Select("1").
+		From("SessionActivities").
+		Where(sq.Eq{"UserId": activity.UserId}).
+		Where(sq.NotEq{"SessionId": activity.SessionId}).
+		Limit(1)
+
+	if activity.DeviceId != "" {
+		builder = builder.Where(sq.Eq{"DeviceId": activity.DeviceId})
+	} else {
+		builder = builder.Where(sq.Eq{
+			"DeviceId": "",
+			"Platform": activity.Platform,
+			"Os":       activity.Os,
+			"Browser":  activity.Browser,
+		})
+	}
+
+	var found bool
+	if err := s.GetReplica().GetBuilder(&found, builder); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get the devices of user with id=%s", activity.UserId)
+	}
+
+	return true, nil
+}
+
+func (s *SqlSessionActivityStore) HasUserActivity(userID, excludeSessionID string) (bool, error) {
+	builder := s.getQueryBuilder().
+		Select("1").
+		From("SessionActivities").
+		Where(sq.Eq{"UserId": userID}).
+		Where(sq.NotEq{"SessionId": excludeSessionID}).
+		Limit(1)
+
+	var found bool
+	if err := s.GetReplica().GetBuilder(&found, builder); err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, errors.Wrapf(err, "failed to get the activity of user with id=%s", userID)
+	}
+
+	return true, nil
+}
+
+func (s *SqlSessionActivityStore) PermanentDeleteByUser(userID string) error {
+	builder := s.getQueryBuilder().
+		Delete("SessionActivities").
+		Where(sq.Eq{"UserId": userID})
+
+	if _, err := s.GetMaster().ExecBuilder(builder); err != nil {
+		return errors.Wrapf(err, "failed to delete the session activities of user with id=%s", userID)
+	}
+
+	return nil
+}
+
+func (s *SqlSessionActivityStore) PermanentDeleteBefore(lastSeenAt int64, limit int64) (int64, error) {
+	var query string
+	if s.DriverName() == model.DatabaseDriverPostgres {
+		query = "DELETE FROM SessionActivities WHERE Id IN (SELECT Id FROM SessionActivities WHERE LastSeenAt < ? LIMIT ?)"
+	} else {
+		query = "DELETE FROM SessionActivities WHERE LastSeenAt < ? LIMIT ?"
+	}
+
+	result, err := s.GetMaster().Exec(query, lastSeenAt, limit)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to delete session activities")
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to get the number of deleted session activities")
+	}
+
+	return count, nil
+}
@@ -145,6 +145,21 @@ func (me SqlSessionStore) GetLRUSessions(c request.CTX, userId string, limit uin
 	return sessions, nil
 }
 
+func (me SqlSessionStore) GetAllSessions(afterID string, limit uint64) ([]*model.Session, error) {
+	builder := me.getQueryBuilder().
+		Select("*").
+		From("Sessions").
+		Where(sq.Gt{"Id": afterID}).
+		OrderBy("Id ASC").
+		Limit(limit)
+
+	var sessions []*model.Session
+	if err := me.GetReplica().SelectBuilder(&sessions, builder); err != nil {
+		return nil, errors.Wrapf(err, "failed to find Sessions after id=%s", afterID)
+	}
+	return sessions, nil
+}
+
 func (me SqlSessionStore) GetSessionsWithActiveDeviceIds(userId string) ([]*model.Session, error) {
 	lastRemovedQuery := `DeviceId != COALESCE(Props->>'last_removed_device_id', '')`
 	if me.DriverName() == model.DatabaseDriverMysql {
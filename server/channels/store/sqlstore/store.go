@@ -113,6 +113,11 @@ type SqlStoreStores struct {
 	desktopTokens              store.DesktopTokensStore
 	channelBookmarks           store.ChannelBookmarkStore
 	scheduledPost              store.ScheduledPostStore
+	escalationPolicy           store.EscalationPolicyStore
+	sessionActivity            store.SessionActivityStore
+	passwordHistory            store.PasswordHistoryStore
+	attributeGroup             store.AttributeGroupStore
+	channelAccessPolicy        store.ChannelAccessPolicyStore
 }
 
 type SqlStore struct {
@@ -239,6 +244,11 @@ func New(settings model.SqlSettings, logger mlog.LoggerIFace, metrics einterface
 	store.stores.desktopTokens = newSqlDesktopTokensStore(store, metrics)
 	store.stores.channelBookmarks = newSqlChannelBookmarkStore(store)
 	store.stores.scheduledPost = newScheduledPostStore(store)
+	store.stores.escalationPolicy = newSqlEscalationPolicyStore(store)
+	store.stores.sessionActivity = newSqlSessionActivityStore(store)
+	store.stores.passwordHistory = newSqlPasswordHistoryStore(store)
+	store.stores.attributeGroup = newSqlAttributeGroupStore(store)
+	store.stores.channelAccessPolicy = newSqlChannelAccessPolicyStore(store)
 
 	store.stores.preference.(*SqlPreferenceStore).deleteUnusedFeatures()
 
@@ -1345,3 +1355,23 @@ func (ss *SqlStore) determineMaxColumnSize(tableName, columnName string) (int, e
 func (ss *SqlStore) ScheduledPost() store.ScheduledPostStore {
 	return ss.stores.scheduledPost
 }
+
+func (ss *SqlStore) EscalationPolicy() store.EscalationPolicyStore {
+	return ss.stores.escalationPolicy
+}
+
+func (ss *SqlStore) SessionActivity() store.SessionActivityStore {
+	return ss.stores.sessionActivity
+}
+
+func (ss *SqlStore) PasswordHistory() store.PasswordHistoryStore {
+	return ss.stores.passwordHistory
+}
+
+func (ss *SqlStore) AttributeGroup() store.AttributeGroupStore {
+	return ss.stores.attributeGroup
+}
+
+func (ss *SqlStore) ChannelAccessPolicy() store.ChannelAccessPolicyStore {
+	return ss.stores.channelAccessPolicy
+}
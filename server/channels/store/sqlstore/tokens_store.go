@@ -85,9 +85,53 @@ func (s SqlTokenStore) GetAllTokensByType(tokenType string) ([]*model.Token, err
 	return tokens, nil
 }
 
+func (s SqlTokenStore) GetAllTokensByTypeAndUser(tokenType, userID string) ([]*model.Token, error) {
+	tokens := []*model.Token{}
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("Tokens").
+		Where(sq.Eq{"Type": tokenType}).
+		Where(sq.Like{"Extra": "%" + fmt.Sprintf(`"UserId":%q`, userID) + "%"}).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not build sql query to get all tokens by type and user")
+	}
+
+	if err := s.GetReplica().Select(&tokens, query, args...); err != nil {
+		return nil, errors.Wrapf(err, "failed to get all tokens of Type=%s for UserId=%s", tokenType, userID)
+	}
+	return tokens, nil
+}
+
 func (s SqlTokenStore) RemoveAllTokensByType(tokenType string) error {
 	if _, err := s.GetMaster().Exec("DELETE FROM Tokens WHERE Type = ?", tokenType); err != nil {
 		return errors.Wrapf(err, "failed to remove all Tokens with Type=%s", tokenType)
 	}
 	return nil
 }
+
+func (s SqlTokenStore) UpdateExtra(token, previousExtra, extra string) error {
+	query, args, err := s.getQueryBuilder().
+		Update("Tokens").
+		Set("Extra", extra).
+		Where(sq.Eq{"Token": token, "Extra": previousExtra}).
+		ToSql()
+	if err != nil {
+		return errors.Wrap(err, "token_tosql")
+	}
+
+	result, err := s.GetMaster().Exec(query, args...)
+	if err != nil {
+		return errors.Wrapf(err, "failed to update Token with value %s", token)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return errors.Wrap(err, "failed to get the number of updated Tokens")
+	}
+	if rowsAffected == 0 {
+		return store.NewErrNotFound("Token", fmt.Sprintf("Token=%s", token))
+	}
+
+	return nil
+}
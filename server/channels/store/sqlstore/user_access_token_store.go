@@ -11,6 +11,7 @@ import (
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/v8/channels/store"
+	sq "github.com/mattermost/squirrel"
 )
 
 type SqlUserAccessTokenStore struct {
@@ -29,8 +30,8 @@ func (s SqlUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.User
 	}
 
 	query, args, err := s.getQueryBuilder().Insert("UserAccessTokens").
-		Columns("Id", "Token", "UserId", "Description", "IsActive").
-		Values(token.Id, token.Token, token.UserId, token.Description, token.IsActive).
+		Columns("Id", "Token", "UserId", "Description", "IsActive", "ExpiresAt", "Scopes", "LastUsedAt", "ExpiryNotified").
+		Values(token.Id, token.Token, token.UserId, token.Description, token.IsActive, token.ExpiresAt, token.Scopes, token.LastUsedAt, token.ExpiryNotified).
 		ToSql()
 	if err != nil {
 		return nil, errors.Wrap(err, "UserAccessToken_tosql")
@@ -233,3 +234,40 @@ func (s SqlUserAccessTokenStore) updateTokenDisable(transaction *sqlxTxWrapper,
 
 	return nil
 }
+
+func (s SqlUserAccessTokenStore) UpdateLastUsedAt(tokenId string, lastUsedAt int64) error {
+	if _, err := s.GetMaster().Exec("UPDATE UserAccessTokens SET LastUsedAt = ? WHERE Id = ?", lastUsedAt, tokenId); err != nil {
+		return errors.Wrapf(err, "failed to update LastUsedAt of UserAccessToken with id=%s", tokenId)
+	}
+	return nil
+}
+
+func (s SqlUserAccessTokenStore) GetExpiringUnnotified(expiresAfter, expiresBefore int64, limit int) ([]*model.UserAccessToken, error) {
+	tokens := []*model.UserAccessToken{}
+
+	query, args, err := s.getQueryBuilder().
+		Select("*").
+		From("UserAccessTokens").
+		Where(sq.Eq{"IsActive": true, "ExpiryNotified": false}).
+		Where(sq.Gt{"ExpiresAt": expiresAfter}).
+		Where(sq.LtOrEq{"ExpiresAt": expiresBefore}).
+		OrderBy("ExpiresAt").
+		Limit(uint64(limit)).
+		ToSql()
+	if err != nil {
+		return nil, errors.Wrap(err, "UserAccessToken_tosql")
+	}
+
+	if err := s.GetReplica().Select(&tokens, query, args...); err != nil {
+		return nil, errors.Wrap(err, "failed to find expiring UserAccessTokens")
+	}
+
+	return tokens, nil
+}
+
+func (s SqlUserAccessTokenStore) UpdateExpiryNotified(tokenId string) error {
+	if _, err := s.GetMaster().Exec("UPDATE UserAccessTokens SET ExpiryNotified = TRUE WHERE Id = ?", tokenId); err != nil {
+		return errors.Wrapf(err, "failed to update ExpiryNotified of UserAccessToken with id=%s", tokenId)
+	}
+	return nil
+}
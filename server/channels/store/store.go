@@ -92,6 +92,11 @@ type Store interface {
 	DesktopTokens() DesktopTokensStore
 	ChannelBookmark() ChannelBookmarkStore
 	ScheduledPost() ScheduledPostStore
+	EscalationPolicy() EscalationPolicyStore
+	SessionActivity() SessionActivityStore
+	PasswordHistory() PasswordHistoryStore
+	AttributeGroup() AttributeGroupStore
+	ChannelAccessPolicy() ChannelAccessPolicyStore
 }
 
 type RetentionPolicyStore interface {
@@ -439,6 +444,10 @@ type UserStore interface {
 	GetProfilesWithoutTeam(options *model.UserGetOptions) ([]*model.User, error)
 	GetProfilesByUsernames(usernames []string, viewRestrictions *model.ViewUsersRestrictions) ([]*model.User, error)
 	GetAllProfiles(options *model.UserGetOptions) ([]*model.User, error)
+	// GetAllLocalUsers returns the users, deleted ones included, in the order of their username,
+	// leaving out bots and remote users. The users counted by Count with the default options are
+	// the ones it returns.
+	GetAllLocalUsers(offset, limit int) ([]*model.User, error)
 	GetProfiles(options *model.UserGetOptions) ([]*model.User, error)
 	GetProfileByIds(ctx context.Context, userIds []string, options *UserGetByIdsOpts, allowFromCache bool) ([]*model.User, error)
 	GetProfileByGroupChannelIdsForUser(userID string, channelIds []string) (map[string][]*model.User, error)
@@ -454,6 +463,9 @@ type UserStore interface {
 	GetEtagForAllProfiles() string
 	GetEtagForProfiles(teamID string) string
 	UpdateFailedPasswordAttempts(userID string, attempts int) error
+	// RecordFailedPasswordAttempt sets the number of failed logins of the user and the time of
+	// the last one.
+	RecordFailedPasswordAttempt(userID string, attempts int, failedAt int64) error
 	GetSystemAdminProfiles() (map[string]*model.User, error)
 	PermanentDelete(rctx request.CTX, userID string) error
 	AnalyticsActiveCount(timestamp int64, options model.UserCountOptions) (int64, error)
@@ -490,6 +502,9 @@ type UserStore interface {
 	GetKnownUsers(userID string) ([]string, error)
 	IsEmpty(excludeBots bool) (bool, error)
 	GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error)
+	// GetUsersWithExpiredPasswords returns the active users logging in with a password who
+	// didn't change it since the given time.
+	GetUsersWithExpiredPasswords(lastPasswordUpdateBefore int64, page int, perPage int) ([]*model.User, error)
 	InsertUsers(users []*model.User) error
 	RefreshPostStatsForUsers() error
 	GetUserReport(filter *model.UserReportOptions) ([]*model.UserReportQuery, error)
@@ -511,6 +526,8 @@ type SessionStore interface {
 	Save(c request.CTX, session *model.Session) (*model.Session, error)
 	GetSessions(c request.CTX, userID string) ([]*model.Session, error)
 	GetLRUSessions(c request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error)
+	// GetAllSessions returns the sessions of all users ordered by id, starting after afterID.
+	GetAllSessions(afterID string, limit uint64) ([]*model.Session, error)
 	GetMobileSessionMetadata() ([]*model.MobileSessionMetadata, error)
 	GetSessionsWithActiveDeviceIds(userID string) ([]*model.Session, error)
 	GetSessionsExpired(thresholdMillis int64, mobileOnly bool, unnotifiedOnly bool) ([]*model.Session, error)
@@ -527,6 +544,55 @@ type SessionStore interface {
 	Cleanup(expiryTime int64, batchSize int64) error
 }
 
+type SessionActivityStore interface {
+	Save(activity *model.SessionActivity) (*model.SessionActivity, error)
+	// UpdateLastSeenAt returns a not found error when the session wasn't used from the IP
+	// address before.
+	UpdateLastSeenAt(sessionID, ipAddress string, lastSeenAt int64) error
+	GetAll(opts model.SessionActivityGetOptions) ([]*model.SessionActivity, error)
+	GetLatestLocatedForUser(userID string) (*model.SessionActivity, error)
+	// HasUserUsedDevice returns whether the user used the device of the activity in another
+	// session.
+	HasUserUsedDevice(activity *model.SessionActivity) (bool, error)
+	HasUserActivity(userID, excludeSessionID string) (bool, error)
+	PermanentDeleteByUser(userID string) error
+	PermanentDeleteBefore(lastSeenAt int64, limit int64) (int64, error)
+}
+
+type PasswordHistoryStore interface {
+	Save(history *model.PasswordHistory) error
+	// GetForUser returns the last passwords of the user, most recent first.
+	GetForUser(userID string, limit int) ([]*model.PasswordHistory, error)
+	// PruneForUser deletes all but the given number of last passwords of the user.
+	PruneForUser(userID string, keep int) error
+	PermanentDeleteByUser(userID string) error
+}
+
+// AttributeGroupStore stores the attribute rules of attribute groups, and the attributes of
+// users they are matched against.
+type AttributeGroupStore interface {
+	SaveRule(rule *model.GroupAttributeRule) (*model.GroupAttributeRule, error)
+	GetRule(groupID string) (*model.GroupAttributeRule, error)
+	// GetRules returns the rules of the attribute groups which aren't deleted.
+	GetRules() ([]*model.GroupAttributeRule, error)
+	DeleteRule(groupID string) error
+	// SetUserAttributes replaces the attributes of the user.
+	SetUserAttributes(userID string, attributes map[string][]string) error
+	GetUserAttributes(userID string) (map[string][]string, error)
+	// GetUserIDsByAttribute returns the ids of the active users with the value of the attribute.
+	GetUserIDsByAttribute(attribute, value string) ([]string, error)
+	PermanentDeleteUserAttributes(userID string) error
+}
+
+type ChannelAccessPolicyStore interface {
+	// Save creates the access policy of the channel, or replaces its expression.
+	Save(policy *model.ChannelAccessPolicy) (*model.ChannelAccessPolicy, error)
+	Get(channelID string) (*model.ChannelAccessPolicy, error)
+	// GetAll returns a page of the access policies of the channels which aren't archived.
+	GetAll(offset, limit int) ([]*model.ChannelAccessPolicy, error)
+	Delete(channelID string) error
+}
+
 type AuditStore interface {
 	Save(audit *model.Audit) error
 	Get(userID string, offset int, limit int) (model.Audits, error)
@@ -683,6 +749,12 @@ type TokenStore interface {
 	Cleanup(expiryTime int64)
 	GetAllTokensByType(tokenType string) ([]*model.Token, error)
 	RemoveAllTokensByType(tokenType string) error
+	// GetAllTokensByTypeAndUser returns the tokens of the given type whose extra data is the JSON
+	// encoding of an object with the given UserId.
+	GetAllTokensByTypeAndUser(tokenType, userID string) ([]*model.Token, error)
+	// UpdateExtra replaces the extra data of a token, as long as it still holds the given
+	// previous value. It returns a not found error otherwise.
+	UpdateExtra(token, previousExtra, extra string) error
 }
 
 type DesktopTokensStore interface {
@@ -797,6 +869,11 @@ type UserAccessTokenStore interface {
 	Search(term string) ([]*model.UserAccessToken, error)
 	UpdateTokenEnable(tokenID string) error
 	UpdateTokenDisable(tokenID string) error
+	UpdateLastUsedAt(tokenID string, lastUsedAt int64) error
+	// GetExpiringUnnotified returns the active tokens expiring within the given bounds whose
+	// owners haven't been notified yet, the soonest to expire first.
+	GetExpiringUnnotified(expiresAfter, expiresBefore int64, limit int) ([]*model.UserAccessToken, error)
+	UpdateExpiryNotified(tokenID string) error
 }
 
 type PluginStore interface {
@@ -809,6 +886,14 @@ type PluginStore interface {
 	DeleteAllForPlugin(PluginID string) error
 	DeleteAllExpired() error
 	List(pluginID string, page, perPage int) ([]string, error)
+	ListWithPrefix(pluginID, prefix string, page, perPage int) ([]string, error)
+	GetMany(pluginID string, keys []string) ([]*model.PluginKeyValue, error)
+	// Batch applies the given operations in a single transaction, returning false without applying
+	// any of them if the condition of an atomic operation doesn't hold.
+	Batch(pluginID string, operations []*model.PluginKVBatchOperation) (bool, error)
+	// GetAllForExport returns the unexpired key-value pairs of all plugins, ordered by plugin id
+	// and key, following the given plugin id and key.
+	GetAllForExport(afterPluginID, afterKey string, limit int) ([]*model.PluginKeyValue, error)
 }
 
 type RoleStore interface {
@@ -1041,11 +1126,26 @@ type PostPersistentNotificationStore interface {
 	Get(params model.GetPersistentNotificationsPostsParams) ([]*model.PostPersistentNotifications, error)
 	GetSingle(postID string) (*model.PostPersistentNotifications, error)
 	UpdateLastActivity(postIds []string) error
+	UpdateEscalationLevel(postID string, level int16) error
 	Delete(postIds []string) error
-	DeleteExpired(maxSentCount int16) error
+	// GetExpired returns the notifications which were sent the maximum number of times.
+	GetExpired(maxSentCount int16) ([]*model.PostPersistentNotifications, error)
+	// DeleteExpired deletes the notifications which were sent the maximum number of
+	// times, except for the given posts.
+	DeleteExpired(maxSentCount int16, exceptPostIds []string) error
 	DeleteByChannel(channelIds []string) error
 	DeleteByTeam(teamIds []string) error
 }
+type EscalationPolicyStore interface {
+	Save(policy *model.EscalationPolicy) (*model.EscalationPolicy, error)
+	Update(policy *model.EscalationPolicy) (*model.EscalationPolicy, error)
+	Get(id string) (*model.EscalationPolicy, error)
+	// GetForChannels returns the policies of the given channels. An empty
+	// channel id matches the policies which apply to every channel.
+	GetForChannels(channelIDs []string) ([]*model.EscalationPolicy, error)
+	Delete(id string) error
+}
+
 type ChannelBookmarkStore interface {
 	ErrorIfBookmarkFileInfoAlreadyAttached(fileID string) error
 	Get(Id string, includeDeleted bool) (b *model.ChannelBookmarkWithFileInfo, err error)
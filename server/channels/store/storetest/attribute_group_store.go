@@ -0,0 +1,105 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestAttributeGroupStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("Rules", func(t *testing.T) { testAttributeGroupStoreRules(t, ss) })
+	t.Run("UserAttributes", func(t *testing.T) { testAttributeGroupStoreUserAttributes(t, rctx, ss) })
+}
+
+func testAttributeGroupStoreRules(t *testing.T, ss store.Store) {
+	group, err := ss.Group().Create(&model.Group{
+		Name:        model.NewPointer("attribute-" + model.NewId()),
+		DisplayName: "Engineering",
+		Source:      model.GroupSourceAttribute,
+	})
+	require.NoError(t, err)
+
+	_, err = ss.AttributeGroup().GetRule(group.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	rule, err := ss.AttributeGroup().SaveRule(&model.GroupAttributeRule{GroupId: group.Id, Attribute: "department", Value: "Engineering"})
+	require.NoError(t, err)
+	createAt := rule.CreateAt
+
+	rule, err = ss.AttributeGroup().SaveRule(&model.GroupAttributeRule{GroupId: group.Id, Attribute: "department", Value: "Sales"})
+	require.NoError(t, err)
+	assert.Equal(t, createAt, rule.CreateAt)
+
+	rule, err = ss.AttributeGroup().GetRule(group.Id)
+	require.NoError(t, err)
+	assert.Equal(t, "Sales", rule.Value)
+
+	_, err = ss.AttributeGroup().SaveRule(&model.GroupAttributeRule{GroupId: group.Id, Attribute: "", Value: "Sales"})
+	require.Error(t, err)
+
+	rules, err := ss.AttributeGroup().GetRules()
+	require.NoError(t, err)
+	assert.Contains(t, rules, rule)
+
+	_, err = ss.Group().Delete(group.Id)
+	require.NoError(t, err)
+
+	rules, err = ss.AttributeGroup().GetRules()
+	require.NoError(t, err)
+	assert.NotContains(t, rules, rule)
+
+	require.NoError(t, ss.AttributeGroup().DeleteRule(group.Id))
+	_, err = ss.AttributeGroup().GetRule(group.Id)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testAttributeGroupStoreUserAttributes(t *testing.T, rctx request.CTX, ss store.Store) {
+	user, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: model.NewUsername(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, user.Id)) }()
+
+	err = ss.AttributeGroup().SetUserAttributes(user.Id, map[string][]string{
+		"department": {"Engineering", "Sales", "Engineering"},
+		"location":   {"Paris"},
+	})
+	require.NoError(t, err)
+
+	attributes, err := ss.AttributeGroup().GetUserAttributes(user.Id)
+	require.NoError(t, err)
+	assert.Equal(t, map[string][]string{
+		"department": {"Engineering", "Sales"},
+		"location":   {"Paris"},
+	}, attributes)
+
+	userIDs, err := ss.AttributeGroup().GetUserIDsByAttribute("department", "Sales")
+	require.NoError(t, err)
+	assert.Equal(t, []string{user.Id}, userIDs)
+
+	err = ss.AttributeGroup().SetUserAttributes(user.Id, map[string][]string{"department": {"Engineering"}})
+	require.NoError(t, err)
+
+	userIDs, err = ss.AttributeGroup().GetUserIDsByAttribute("department", "Sales")
+	require.NoError(t, err)
+	assert.Empty(t, userIDs)
+
+	userIDs, err = ss.AttributeGroup().GetUserIDsByAttribute("department", "Engineering")
+	require.NoError(t, err)
+	assert.Equal(t, []string{user.Id}, userIDs)
+
+	require.NoError(t, ss.AttributeGroup().PermanentDeleteUserAttributes(user.Id))
+	attributes, err = ss.AttributeGroup().GetUserAttributes(user.Id)
+	require.NoError(t, err)
+	assert.Empty(t, attributes)
+}
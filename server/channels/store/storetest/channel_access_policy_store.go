@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestChannelAccessPolicyStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveGetDelete", func(t *testing.T) { testChannelAccessPolicyStoreSaveGetDelete(t, rctx, ss) })
+	t.Run("GetAll", func(t *testing.T) { testChannelAccessPolicyStoreGetAll(t, rctx, ss) })
+}
+
+func saveChannelForAccessPolicy(t *testing.T, rctx request.CTX, ss store.Store) *model.Channel {
+	channel, err := ss.Channel().Save(rctx, &model.Channel{
+		TeamId:      model.NewId(),
+		DisplayName: "Restricted",
+		Name:        NewTestID(),
+		Type:        model.ChannelTypePrivate,
+	}, -1)
+	require.NoError(t, err)
+	return channel
+}
+
+func testChannelAccessPolicyStoreSaveGetDelete(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel := saveChannelForAccessPolicy(t, rctx, ss)
+
+	_, err := ss.ChannelAccessPolicy().Get(channel.Id)
+	var nfErr *store.ErrNotFound
+	require.ErrorAs(t, err, &nfErr)
+
+	_, err = ss.ChannelAccessPolicy().Save(&model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: `user.position ==`})
+	require.Error(t, err)
+
+	policy, err := ss.ChannelAccessPolicy().Save(&model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: `user.position == "Engineer"`})
+	require.NoError(t, err)
+	createAt := policy.CreateAt
+
+	policy, err = ss.ChannelAccessPolicy().Save(&model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: `user.attributes.department == "Sales"`})
+	require.NoError(t, err)
+	assert.Equal(t, createAt, policy.CreateAt)
+
+	policy, err = ss.ChannelAccessPolicy().Get(channel.Id)
+	require.NoError(t, err)
+	assert.Equal(t, `user.attributes.department == "Sales"`, policy.Expression)
+	assert.Equal(t, createAt, policy.CreateAt)
+
+	require.NoError(t, ss.ChannelAccessPolicy().Delete(channel.Id))
+	_, err = ss.ChannelAccessPolicy().Get(channel.Id)
+	require.ErrorAs(t, err, &nfErr)
+}
+
+func testChannelAccessPolicyStoreGetAll(t *testing.T, rctx request.CTX, ss store.Store) {
+	channel1 := saveChannelForAccessPolicy(t, rctx, ss)
+	channel2 := saveChannelForAccessPolicy(t, rctx, ss)
+	archived := saveChannelForAccessPolicy(t, rctx, ss)
+	for _, channel := range []*model.Channel{channel1, channel2, archived} {
+		_, err := ss.ChannelAccessPolicy().Save(&model.ChannelAccessPolicy{ChannelId: channel.Id, Expression: `user.position == "Engineer"`})
+		require.NoError(t, err)
+	}
+	require.NoError(t, ss.Channel().Delete(archived.Id, model.GetMillis()))
+
+	var channelIDs []string
+	for offset := 0; ; offset++ {
+		policies, err := ss.ChannelAccessPolicy().GetAll(offset, 1)
+		require.NoError(t, err)
+		if len(policies) == 0 {
+			break
+		}
+		require.Len(t, policies, 1)
+		channelIDs = append(channelIDs, policies[0].ChannelId)
+	}
+	assert.Contains(t, channelIDs, channel1.Id)
+	assert.Contains(t, channelIDs, channel2.Id)
+	assert.NotContains(t, channelIDs, archived.Id)
+}
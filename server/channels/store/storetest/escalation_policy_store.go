@@ -0,0 +1,128 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestEscalationPolicyStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndGet", func(t *testing.T) { testEscalationPolicyStoreSaveAndGet(t, ss) })
+	t.Run("Update", func(t *testing.T) { testEscalationPolicyStoreUpdate(t, ss) })
+	t.Run("GetForChannels", func(t *testing.T) { testEscalationPolicyStoreGetForChannels(t, ss) })
+	t.Run("Delete", func(t *testing.T) { testEscalationPolicyStoreDelete(t, ss) })
+}
+
+func newTestEscalationPolicy(channelID, priority string) *model.EscalationPolicy {
+	return &model.EscalationPolicy{
+		ChannelId: channelID,
+		Priority:  priority,
+		CreatorId: model.NewId(),
+		Steps: []*model.EscalationStep{
+			{AfterCount: 3, ChannelId: model.NewId()},
+			{AfterCount: 1, UserIds: []string{model.NewId()}},
+		},
+	}
+}
+
+func testEscalationPolicyStoreSaveAndGet(t *testing.T, ss store.Store) {
+	policy := newTestEscalationPolicy(model.NewId(), model.PostPriorityUrgent)
+	policy.QuietHours = &model.EscalationQuietHours{Start: "22:00", End: "07:00", Timezone: "UTC"}
+
+	saved, err := ss.EscalationPolicy().Save(policy)
+	require.NoError(t, err)
+	defer ss.EscalationPolicy().Delete(saved.Id)
+	require.NotEmpty(t, saved.Id)
+
+	// Steps are ordered by the number of notifications they run after.
+	assert.Equal(t, int16(1), saved.Steps[0].AfterCount)
+
+	got, err := ss.EscalationPolicy().Get(saved.Id)
+	require.NoError(t, err)
+	assert.Equal(t, saved, got)
+
+	t.Run("conflict", func(t *testing.T) {
+		_, err := ss.EscalationPolicy().Save(newTestEscalationPolicy(policy.ChannelId, policy.Priority))
+		var cErr *store.ErrConflict
+		require.True(t, errors.As(err, &cErr))
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		invalid := newTestEscalationPolicy(model.NewId(), "")
+		invalid.Steps = nil
+		_, err := ss.EscalationPolicy().Save(invalid)
+		require.Error(t, err)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := ss.EscalationPolicy().Get(model.NewId())
+		var nfErr *store.ErrNotFound
+		require.True(t, errors.As(err, &nfErr))
+	})
+}
+
+func testEscalationPolicyStoreUpdate(t *testing.T, ss store.Store) {
+	policy, err := ss.EscalationPolicy().Save(newTestEscalationPolicy(model.NewId(), ""))
+	require.NoError(t, err)
+	defer ss.EscalationPolicy().Delete(policy.Id)
+
+	policy.Steps = policy.Steps[:1]
+	policy.QuietHours = &model.EscalationQuietHours{Start: "20:00", End: "08:00", Timezone: "Europe/Berlin"}
+	_, err = ss.EscalationPolicy().Update(policy)
+	require.NoError(t, err)
+
+	got, err := ss.EscalationPolicy().Get(policy.Id)
+	require.NoError(t, err)
+	assert.Len(t, got.Steps, 1)
+	assert.Equal(t, policy.QuietHours, got.QuietHours)
+
+	_, err = ss.EscalationPolicy().Update(newTestEscalationPolicy(model.NewId(), ""))
+	require.Error(t, err)
+}
+
+func testEscalationPolicyStoreGetForChannels(t *testing.T, ss store.Store) {
+	channelID := model.NewId()
+	p1, err := ss.EscalationPolicy().Save(newTestEscalationPolicy(channelID, ""))
+	require.NoError(t, err)
+	defer ss.EscalationPolicy().Delete(p1.Id)
+	p2, err := ss.EscalationPolicy().Save(newTestEscalationPolicy(channelID, model.PostPriorityUrgent))
+	require.NoError(t, err)
+	defer ss.EscalationPolicy().Delete(p2.Id)
+	p3, err := ss.EscalationPolicy().Save(newTestEscalationPolicy(model.NewId(), ""))
+	require.NoError(t, err)
+	defer ss.EscalationPolicy().Delete(p3.Id)
+
+	policies, err := ss.EscalationPolicy().GetForChannels([]string{channelID})
+	require.NoError(t, err)
+	require.Len(t, policies, 2)
+	assert.Equal(t, p1.Id, policies[0].Id)
+	assert.Equal(t, p2.Id, policies[1].Id)
+
+	policies, err = ss.EscalationPolicy().GetForChannels(nil)
+	require.NoError(t, err)
+	assert.Empty(t, policies)
+}
+
+func testEscalationPolicyStoreDelete(t *testing.T, ss store.Store) {
+	policy, err := ss.EscalationPolicy().Save(newTestEscalationPolicy(model.NewId(), ""))
+	require.NoError(t, err)
+
+	err = ss.EscalationPolicy().Delete(policy.Id)
+	require.NoError(t, err)
+
+	_, err = ss.EscalationPolicy().Get(policy.Id)
+	var nfErr *store.ErrNotFound
+	require.True(t, errors.As(err, &nfErr))
+
+	err = ss.EscalationPolicy().Delete(policy.Id)
+	require.True(t, errors.As(err, &nfErr))
+}
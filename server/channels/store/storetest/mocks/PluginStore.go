@@ -14,6 +14,34 @@ type PluginStore struct {
 	mock.Mock
 }
 
+// Batch provides a mock function with given fields: pluginID, operations
+func (_m *PluginStore) Batch(pluginID string, operations []*model.PluginKVBatchOperation) (bool, error) {
+	ret := _m.Called(pluginID, operations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for Batch")
+	}
+
+	var r0 bool
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []*model.PluginKVBatchOperation) (bool, error)); ok {
+		return rf(pluginID, operations)
+	}
+	if rf, ok := ret.Get(0).(func(string, []*model.PluginKVBatchOperation) bool); ok {
+		r0 = rf(pluginID, operations)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []*model.PluginKVBatchOperation) error); ok {
+		r1 = rf(pluginID, operations)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // CompareAndDelete provides a mock function with given fields: keyVal, oldValue
 func (_m *PluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	ret := _m.Called(keyVal, oldValue)
@@ -154,6 +182,66 @@ func (_m *PluginStore) Get(pluginID string, key string) (*model.PluginKeyValue,
 	return r0, r1
 }
 
+// GetAllForExport provides a mock function with given fields: afterPluginID, afterKey, limit
+func (_m *PluginStore) GetAllForExport(afterPluginID string, afterKey string, limit int) ([]*model.PluginKeyValue, error) {
+	ret := _m.Called(afterPluginID, afterKey, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllForExport")
+	}
+
+	var r0 []*model.PluginKeyValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int) ([]*model.PluginKeyValue, error)); ok {
+		return rf(afterPluginID, afterKey, limit)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int) []*model.PluginKeyValue); ok {
+		r0 = rf(afterPluginID, afterKey, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PluginKeyValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int) error); ok {
+		r1 = rf(afterPluginID, afterKey, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMany provides a mock function with given fields: pluginID, keys
+func (_m *PluginStore) GetMany(pluginID string, keys []string) ([]*model.PluginKeyValue, error) {
+	ret := _m.Called(pluginID, keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMany")
+	}
+
+	var r0 []*model.PluginKeyValue
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, []string) ([]*model.PluginKeyValue, error)); ok {
+		return rf(pluginID, keys)
+	}
+	if rf, ok := ret.Get(0).(func(string, []string) []*model.PluginKeyValue); ok {
+		r0 = rf(pluginID, keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PluginKeyValue)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, []string) error); ok {
+		r1 = rf(pluginID, keys)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // List provides a mock function with given fields: pluginID, page, perPage
 func (_m *PluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 	ret := _m.Called(pluginID, page, perPage)
@@ -184,6 +272,36 @@ func (_m *PluginStore) List(pluginID string, page int, perPage int) ([]string, e
 	return r0, r1
 }
 
+// ListWithPrefix provides a mock function with given fields: pluginID, prefix, page, perPage
+func (_m *PluginStore) ListWithPrefix(pluginID string, prefix string, page int, perPage int) ([]string, error) {
+	ret := _m.Called(pluginID, prefix, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ListWithPrefix")
+	}
+
+	var r0 []string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string, int, int) ([]string, error)); ok {
+		return rf(pluginID, prefix, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(string, string, int, int) []string); ok {
+		r0 = rf(pluginID, prefix, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string, int, int) error); ok {
+		r1 = rf(pluginID, prefix, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveOrUpdate provides a mock function with given fields: keyVal
 func (_m *PluginStore) SaveOrUpdate(keyVal *model.PluginKeyValue) (*model.PluginKeyValue, error) {
 	ret := _m.Called(keyVal)
@@ -68,17 +68,17 @@ func (_m *PostPersistentNotificationStore) DeleteByTeam(teamIds []string) error
 	return r0
 }
 
-// DeleteExpired provides a mock function with given fields: maxSentCount
-func (_m *PostPersistentNotificationStore) DeleteExpired(maxSentCount int16) error {
-	ret := _m.Called(maxSentCount)
+// DeleteExpired provides a mock function with given fields: maxSentCount, exceptPostIds
+func (_m *PostPersistentNotificationStore) DeleteExpired(maxSentCount int16, exceptPostIds []string) error {
+	ret := _m.Called(maxSentCount, exceptPostIds)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteExpired")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(int16) error); ok {
-		r0 = rf(maxSentCount)
+	if rf, ok := ret.Get(0).(func(int16, []string) error); ok {
+		r0 = rf(maxSentCount, exceptPostIds)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -116,6 +116,36 @@ func (_m *PostPersistentNotificationStore) Get(params model.GetPersistentNotific
 	return r0, r1
 }
 
+// GetExpired provides a mock function with given fields: maxSentCount
+func (_m *PostPersistentNotificationStore) GetExpired(maxSentCount int16) ([]*model.PostPersistentNotifications, error) {
+	ret := _m.Called(maxSentCount)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpired")
+	}
+
+	var r0 []*model.PostPersistentNotifications
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int16) ([]*model.PostPersistentNotifications, error)); ok {
+		return rf(maxSentCount)
+	}
+	if rf, ok := ret.Get(0).(func(int16) []*model.PostPersistentNotifications); ok {
+		r0 = rf(maxSentCount)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.PostPersistentNotifications)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int16) error); ok {
+		r1 = rf(maxSentCount)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetSingle provides a mock function with given fields: postID
 func (_m *PostPersistentNotificationStore) GetSingle(postID string) (*model.PostPersistentNotifications, error) {
 	ret := _m.Called(postID)
@@ -146,6 +176,24 @@ func (_m *PostPersistentNotificationStore) GetSingle(postID string) (*model.Post
 	return r0, r1
 }
 
+// UpdateEscalationLevel provides a mock function with given fields: postID, level
+func (_m *PostPersistentNotificationStore) UpdateEscalationLevel(postID string, level int16) error {
+	ret := _m.Called(postID, level)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateEscalationLevel")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int16) error); ok {
+		r0 = rf(postID, level)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateLastActivity provides a mock function with given fields: postIds
 func (_m *PostPersistentNotificationStore) UpdateLastActivity(postIds []string) error {
 	ret := _m.Called(postIds)
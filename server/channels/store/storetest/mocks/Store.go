@@ -24,6 +24,26 @@ type Store struct {
 	mock.Mock
 }
 
+// AttributeGroup provides a mock function with given fields:
+func (_m *Store) AttributeGroup() store.AttributeGroupStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for AttributeGroup")
+	}
+
+	var r0 store.AttributeGroupStore
+	if rf, ok := ret.Get(0).(func() store.AttributeGroupStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.AttributeGroupStore)
+		}
+	}
+
+	return r0
+}
+
 // Audit provides a mock function with given fields:
 func (_m *Store) Audit() store.AuditStore {
 	ret := _m.Called()
@@ -84,6 +104,26 @@ func (_m *Store) Channel() store.ChannelStore {
 	return r0
 }
 
+// ChannelAccessPolicy provides a mock function with given fields:
+func (_m *Store) ChannelAccessPolicy() store.ChannelAccessPolicyStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ChannelAccessPolicy")
+	}
+
+	var r0 store.ChannelAccessPolicyStore
+	if rf, ok := ret.Get(0).(func() store.ChannelAccessPolicyStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.ChannelAccessPolicyStore)
+		}
+	}
+
+	return r0
+}
+
 // ChannelBookmark provides a mock function with given fields:
 func (_m *Store) ChannelBookmark() store.ChannelBookmarkStore {
 	ret := _m.Called()
@@ -314,6 +354,26 @@ func (_m *Store) Emoji() store.EmojiStore {
 	return r0
 }
 
+// EscalationPolicy provides a mock function with given fields:
+func (_m *Store) EscalationPolicy() store.EscalationPolicyStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for EscalationPolicy")
+	}
+
+	var r0 store.EscalationPolicyStore
+	if rf, ok := ret.Get(0).(func() store.EscalationPolicyStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.EscalationPolicyStore)
+		}
+	}
+
+	return r0
+}
+
 // FileInfo provides a mock function with given fields:
 func (_m *Store) FileInfo() store.FileInfoStore {
 	ret := _m.Called()
@@ -658,6 +718,26 @@ func (_m *Store) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStore {
 	return r0
 }
 
+// PasswordHistory provides a mock function with given fields:
+func (_m *Store) PasswordHistory() store.PasswordHistoryStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for PasswordHistory")
+	}
+
+	var r0 store.PasswordHistoryStore
+	if rf, ok := ret.Get(0).(func() store.PasswordHistoryStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.PasswordHistoryStore)
+		}
+	}
+
+	return r0
+}
+
 // Plugin provides a mock function with given fields:
 func (_m *Store) Plugin() store.PluginStore {
 	ret := _m.Called()
@@ -979,6 +1059,26 @@ func (_m *Store) Session() store.SessionStore {
 	return r0
 }
 
+// SessionActivity provides a mock function with given fields:
+func (_m *Store) SessionActivity() store.SessionActivityStore {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for SessionActivity")
+	}
+
+	var r0 store.SessionActivityStore
+	if rf, ok := ret.Get(0).(func() store.SessionActivityStore); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(store.SessionActivityStore)
+		}
+	}
+
+	return r0
+}
+
 // SetContext provides a mock function with given fields: _a0
 func (_m *Store) SetContext(_a0 context.Context) {
 	_m.Called(_a0)
@@ -67,6 +67,36 @@ func (_m *TokenStore) GetAllTokensByType(tokenType string) ([]*model.Token, erro
 	return r0, r1
 }
 
+// GetAllTokensByTypeAndUser provides a mock function with given fields: tokenType, userID
+func (_m *TokenStore) GetAllTokensByTypeAndUser(tokenType string, userID string) ([]*model.Token, error) {
+	ret := _m.Called(tokenType, userID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllTokensByTypeAndUser")
+	}
+
+	var r0 []*model.Token
+	var r1 error
+	if rf, ok := ret.Get(0).(func(string, string) ([]*model.Token, error)); ok {
+		return rf(tokenType, userID)
+	}
+	if rf, ok := ret.Get(0).(func(string, string) []*model.Token); ok {
+		r0 = rf(tokenType, userID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Token)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, string) error); ok {
+		r1 = rf(tokenType, userID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetByToken provides a mock function with given fields: token
 func (_m *TokenStore) GetByToken(token string) (*model.Token, error) {
 	ret := _m.Called(token)
@@ -133,6 +163,24 @@ func (_m *TokenStore) Save(recovery *model.Token) error {
 	return r0
 }
 
+// UpdateExtra provides a mock function with given fields: token, previousExtra, extra
+func (_m *TokenStore) UpdateExtra(token string, previousExtra string, extra string) error {
+	ret := _m.Called(token, previousExtra, extra)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExtra")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, string, string) error); ok {
+		r0 = rf(token, previousExtra, extra)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // NewTokenStore creates a new instance of TokenStore. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewTokenStore(t interface {
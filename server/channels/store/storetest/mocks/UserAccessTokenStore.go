@@ -170,6 +170,36 @@ func (_m *UserAccessTokenStore) GetByUser(userID string, page int, perPage int)
 	return r0, r1
 }
 
+// GetExpiringUnnotified provides a mock function with given fields: expiresAfter, expiresBefore, limit
+func (_m *UserAccessTokenStore) GetExpiringUnnotified(expiresAfter int64, expiresBefore int64, limit int) ([]*model.UserAccessToken, error) {
+	ret := _m.Called(expiresAfter, expiresBefore, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetExpiringUnnotified")
+	}
+
+	var r0 []*model.UserAccessToken
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int64, int) ([]*model.UserAccessToken, error)); ok {
+		return rf(expiresAfter, expiresBefore, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int64, int) []*model.UserAccessToken); ok {
+		r0 = rf(expiresAfter, expiresBefore, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UserAccessToken)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int64, int) error); ok {
+		r1 = rf(expiresAfter, expiresBefore, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Save provides a mock function with given fields: token
 func (_m *UserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
 	ret := _m.Called(token)
@@ -230,6 +260,42 @@ func (_m *UserAccessTokenStore) Search(term string) ([]*model.UserAccessToken, e
 	return r0, r1
 }
 
+// UpdateExpiryNotified provides a mock function with given fields: tokenID
+func (_m *UserAccessTokenStore) UpdateExpiryNotified(tokenID string) error {
+	ret := _m.Called(tokenID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateExpiryNotified")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string) error); ok {
+		r0 = rf(tokenID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateLastUsedAt provides a mock function with given fields: tokenID, lastUsedAt
+func (_m *UserAccessTokenStore) UpdateLastUsedAt(tokenID string, lastUsedAt int64) error {
+	ret := _m.Called(tokenID, lastUsedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateLastUsedAt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int64) error); ok {
+		r0 = rf(tokenID, lastUsedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // UpdateTokenDisable provides a mock function with given fields: tokenID
 func (_m *UserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
 	ret := _m.Called(tokenID)
@@ -419,6 +419,36 @@ func (_m *UserStore) GetAllAfter(limit int, afterID string) ([]*model.User, erro
 	return r0, r1
 }
 
+// GetAllLocalUsers provides a mock function with given fields: offset, limit
+func (_m *UserStore) GetAllLocalUsers(offset int, limit int) ([]*model.User, error) {
+	ret := _m.Called(offset, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAllLocalUsers")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int, int) ([]*model.User, error)); ok {
+		return rf(offset, limit)
+	}
+	if rf, ok := ret.Get(0).(func(int, int) []*model.User); ok {
+		r0 = rf(offset, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int, int) error); ok {
+		r1 = rf(offset, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetAllNotInAuthService provides a mock function with given fields: authServices
 func (_m *UserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
 	ret := _m.Called(authServices)
@@ -1455,6 +1485,36 @@ func (_m *UserStore) GetUsersBatchForIndexing(startTime int64, startFileID strin
 	return r0, r1
 }
 
+// GetUsersWithExpiredPasswords provides a mock function with given fields: lastPasswordUpdateBefore, page, perPage
+func (_m *UserStore) GetUsersWithExpiredPasswords(lastPasswordUpdateBefore int64, page int, perPage int) ([]*model.User, error) {
+	ret := _m.Called(lastPasswordUpdateBefore, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetUsersWithExpiredPasswords")
+	}
+
+	var r0 []*model.User
+	var r1 error
+	if rf, ok := ret.Get(0).(func(int64, int, int) ([]*model.User, error)); ok {
+		return rf(lastPasswordUpdateBefore, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(int64, int, int) []*model.User); ok {
+		r0 = rf(lastPasswordUpdateBefore, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.User)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(int64, int, int) error); ok {
+		r1 = rf(lastPasswordUpdateBefore, page, perPage)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetUsersWithInvalidEmails provides a mock function with given fields: page, perPage, restrictedDomains
 func (_m *UserStore) GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error) {
 	ret := _m.Called(page, perPage, restrictedDomains)
@@ -1610,6 +1670,24 @@ func (_m *UserStore) PromoteGuestToUser(userID string) error {
 	return r0
 }
 
+// RecordFailedPasswordAttempt provides a mock function with given fields: userID, attempts, failedAt
+func (_m *UserStore) RecordFailedPasswordAttempt(userID string, attempts int, failedAt int64) error {
+	ret := _m.Called(userID, attempts, failedAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RecordFailedPasswordAttempt")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(string, int, int64) error); ok {
+		r0 = rf(userID, attempts, failedAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // RefreshPostStatsForUsers provides a mock function with given fields:
 func (_m *UserStore) RefreshPostStatsForUsers() error {
 	ret := _m.Called()
@@ -18,6 +18,7 @@ func TestOAuthStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("SaveApp", func(t *testing.T) { testOAuthStoreSaveApp(t, rctx, ss) })
 	t.Run("GetApp", func(t *testing.T) { testOAuthStoreGetApp(t, rctx, ss) })
 	t.Run("UpdateApp", func(t *testing.T) { testOAuthStoreUpdateApp(t, rctx, ss) })
+	t.Run("PublicClientAndPKCE", func(t *testing.T) { testOAuthStorePublicClientAndPKCE(t, rctx, ss) })
 	t.Run("SaveAccessData", func(t *testing.T) { testOAuthStoreSaveAccessData(t, rctx, ss) })
 	t.Run("OAuthUpdateAccessData", func(t *testing.T) { testOAuthUpdateAccessData(t, rctx, ss) })
 	t.Run("GetAccessData", func(t *testing.T) { testOAuthStoreGetAccessData(t, rctx, ss) })
@@ -60,6 +61,64 @@ func testOAuthStoreSaveApp(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.NoError(t, err)
 }
 
+func testOAuthStorePublicClientAndPKCE(t *testing.T, rctx request.CTX, ss store.Store) {
+	a1 := model.OAuthApp{}
+	a1.CreatorId = model.NewId()
+	a1.Name = "TestApp" + model.NewId()
+	a1.CallbackUrls = []string{"https://nowhere.com"}
+	a1.Homepage = "https://nowhere.com"
+	a1.IsPublic = true
+	a1.Scopes = model.StringArray{"channel:" + model.NewId()}
+	_, err := ss.OAuth().SaveApp(&a1)
+	require.NoError(t, err)
+
+	app, err := ss.OAuth().GetApp(a1.Id)
+	require.NoError(t, err)
+	assert.True(t, app.IsPublic)
+	assert.Equal(t, a1.Scopes, app.Scopes)
+	assert.Empty(t, app.BotUserId)
+
+	app.IsPublic = false
+	app.BotUserId = model.NewId()
+	_, err = ss.OAuth().UpdateApp(app)
+	require.NoError(t, err)
+
+	app, err = ss.OAuth().GetApp(a1.Id)
+	require.NoError(t, err)
+	assert.False(t, app.IsPublic)
+	assert.Equal(t, a1.Scopes, app.Scopes)
+	assert.NotEmpty(t, app.BotUserId)
+
+	authData := model.AuthData{}
+	authData.ClientId = a1.Id
+	authData.UserId = model.NewId()
+	authData.Code = model.NewId()
+	authData.RedirectUri = "http://example.com"
+	authData.CodeChallenge = model.NewRandomString(43)
+	authData.Nonce = model.NewId()
+	_, err = ss.OAuth().SaveAuthData(&authData)
+	require.NoError(t, err)
+
+	savedAuthData, err := ss.OAuth().GetAuthData(authData.Code)
+	require.NoError(t, err)
+	assert.Equal(t, authData.CodeChallenge, savedAuthData.CodeChallenge)
+	assert.Equal(t, model.PKCECodeChallengeMethodPlain, savedAuthData.CodeChallengeMethod)
+	assert.Equal(t, authData.Nonce, savedAuthData.Nonce)
+
+	accessData := model.AccessData{}
+	accessData.ClientId = a1.Id
+	accessData.UserId = model.NewId()
+	accessData.Token = model.NewId()
+	accessData.GrantType = model.DeviceCodeGrantType
+	_, err = ss.OAuth().SaveAccessData(&accessData)
+	require.NoError(t, err)
+
+	savedAccessData, err := ss.OAuth().GetAccessData(accessData.Token)
+	require.NoError(t, err)
+	assert.Equal(t, model.DeviceCodeGrantType, savedAccessData.GrantType)
+	assert.Empty(t, savedAccessData.RedirectUri)
+}
+
 func testOAuthStoreGetApp(t *testing.T, rctx request.CTX, ss store.Store) {
 	a1 := model.OAuthApp{}
 	a1.CreatorId = model.NewId()
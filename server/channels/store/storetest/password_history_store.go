@@ -0,0 +1,84 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestPasswordHistoryStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("GetForUser", func(t *testing.T) { testPasswordHistoryStoreGetForUser(t, ss) })
+	t.Run("PruneForUser", func(t *testing.T) { testPasswordHistoryStorePruneForUser(t, ss) })
+	t.Run("PermanentDeleteByUser", func(t *testing.T) { testPasswordHistoryStorePermanentDeleteByUser(t, ss) })
+}
+
+func savePasswordHistory(t *testing.T, ss store.Store, userID string, count int) {
+	t.Helper()
+	for i := range count {
+		require.NoError(t, ss.PasswordHistory().Save(&model.PasswordHistory{
+			UserId:       userID,
+			PasswordHash: model.NewId(),
+			CreateAt:     int64(1000 + i),
+		}))
+	}
+}
+
+func testPasswordHistoryStoreGetForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	savePasswordHistory(t, ss, userID, 3)
+	savePasswordHistory(t, ss, model.NewId(), 1)
+
+	history, err := ss.PasswordHistory().GetForUser(userID, 2)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, userID, history[0].UserId)
+	assert.Equal(t, int64(1002), history[0].CreateAt)
+	assert.Equal(t, int64(1001), history[1].CreateAt)
+}
+
+func testPasswordHistoryStorePruneForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	savePasswordHistory(t, ss, userID, 4)
+
+	require.NoError(t, ss.PasswordHistory().PruneForUser(userID, 2))
+	history, err := ss.PasswordHistory().GetForUser(userID, 10)
+	require.NoError(t, err)
+	require.Len(t, history, 2)
+	assert.Equal(t, int64(1003), history[0].CreateAt)
+	assert.Equal(t, int64(1002), history[1].CreateAt)
+
+	require.NoError(t, ss.PasswordHistory().PruneForUser(userID, 5))
+	history, err = ss.PasswordHistory().GetForUser(userID, 10)
+	require.NoError(t, err)
+	assert.Len(t, history, 2)
+
+	require.NoError(t, ss.PasswordHistory().PruneForUser(userID, 0))
+	history, err = ss.PasswordHistory().GetForUser(userID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+}
+
+func testPasswordHistoryStorePermanentDeleteByUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+	savePasswordHistory(t, ss, userID, 2)
+	savePasswordHistory(t, ss, otherUserID, 1)
+
+	require.NoError(t, ss.PasswordHistory().PermanentDeleteByUser(userID))
+
+	history, err := ss.PasswordHistory().GetForUser(userID, 10)
+	require.NoError(t, err)
+	assert.Empty(t, history)
+
+	history, err = ss.PasswordHistory().GetForUser(otherUserID, 10)
+	require.NoError(t, err)
+	assert.Len(t, history, 1)
+}
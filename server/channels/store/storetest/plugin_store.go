@@ -25,6 +25,10 @@ func TestPluginStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore)
 	t.Run("DeleteAllForPlugin", func(t *testing.T) { testPluginDeleteAllForPlugin(t, rctx, ss) })
 	t.Run("DeleteAllExpired", func(t *testing.T) { testPluginDeleteAllExpired(t, rctx, ss) })
 	t.Run("List", func(t *testing.T) { testPluginList(t, rctx, ss) })
+	t.Run("ListWithPrefix", func(t *testing.T) { testPluginListWithPrefix(t, rctx, ss) })
+	t.Run("GetMany", func(t *testing.T) { testPluginGetMany(t, rctx, ss) })
+	t.Run("Batch", func(t *testing.T) { testPluginBatch(t, rctx, ss) })
+	t.Run("GetAllForExport", func(t *testing.T) { testPluginGetAllForExport(t, rctx, ss) })
 }
 
 func setupKVs(t *testing.T, rctx request.CTX, ss store.Store) (string, func()) {
@@ -1370,3 +1374,167 @@ func testPluginList(t *testing.T, rctx request.CTX, ss store.Store) {
 		})
 	})
 }
+
+func testPluginListWithPrefix(t *testing.T, rctx request.CTX, ss store.Store) {
+	pluginID, tearDown := setupKVs(t, rctx, ss)
+	defer tearDown()
+
+	for _, key := range []string{"user_1", "user_2", "user%3", "userA", "team_1"} {
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: key, Value: []byte(key)})
+		require.NoError(t, err)
+	}
+	_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "user_expired", Value: []byte("expired"), ExpireAt: model.GetMillis() - 1000})
+	require.NoError(t, err)
+
+	t.Run("prefix", func(t *testing.T) {
+		keys, err := ss.Plugin().ListWithPrefix(pluginID, "user_", 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user_1", "user_2"}, keys)
+	})
+
+	t.Run("prefix with wildcard", func(t *testing.T) {
+		keys, err := ss.Plugin().ListWithPrefix(pluginID, "user%", 0, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user%3"}, keys)
+	})
+
+	t.Run("paging", func(t *testing.T) {
+		keys, err := ss.Plugin().ListWithPrefix(pluginID, "user_", 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"user_2"}, keys)
+	})
+
+	t.Run("no matches", func(t *testing.T) {
+		keys, err := ss.Plugin().ListWithPrefix(pluginID, "channel_", 0, 100)
+		require.NoError(t, err)
+		assert.Empty(t, keys)
+	})
+}
+
+func testPluginGetMany(t *testing.T, rctx request.CTX, ss store.Store) {
+	pluginID, tearDown := setupKVs(t, rctx, ss)
+	defer tearDown()
+
+	kv1 := &model.PluginKeyValue{PluginId: pluginID, Key: "key1", Value: []byte("value1")}
+	kv2 := &model.PluginKeyValue{PluginId: pluginID, Key: "key2", Value: []byte("value2")}
+	expired := &model.PluginKeyValue{PluginId: pluginID, Key: "expired", Value: []byte("value"), ExpireAt: model.GetMillis() - 1000}
+	for _, kv := range []*model.PluginKeyValue{kv1, kv2, expired} {
+		_, err := ss.Plugin().SaveOrUpdate(kv)
+		require.NoError(t, err)
+	}
+
+	kvs, err := ss.Plugin().GetMany(pluginID, []string{"key1", "key2", "expired", "missing"})
+	require.NoError(t, err)
+	sort.Slice(kvs, func(i, j int) bool { return kvs[i].Key < kvs[j].Key })
+	assert.Equal(t, []*model.PluginKeyValue{kv1, kv2}, kvs)
+
+	kvs, err = ss.Plugin().GetMany(pluginID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, kvs)
+}
+
+func testPluginBatch(t *testing.T, rctx request.CTX, ss store.Store) {
+	t.Run("all operations apply", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "existing", Value: []byte("old")})
+		require.NoError(t, err)
+		_, err = ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "deleted", Value: []byte("value")})
+		require.NoError(t, err)
+
+		applied, err := ss.Plugin().Batch(pluginID, []*model.PluginKVBatchOperation{
+			{Key: "new", Value: []byte("value")},
+			{Key: "existing", Value: []byte("new"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("old")}},
+			{Key: "deleted", Value: nil},
+		})
+		require.NoError(t, err)
+		assert.True(t, applied)
+
+		kv, err := ss.Plugin().Get(pluginID, "new")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("value"), kv.Value)
+
+		kv, err = ss.Plugin().Get(pluginID, "existing")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("new"), kv.Value)
+
+		_, err = ss.Plugin().Get(pluginID, "deleted")
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+	})
+
+	t.Run("failed condition rolls back the batch", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "existing", Value: []byte("current")})
+		require.NoError(t, err)
+
+		applied, err := ss.Plugin().Batch(pluginID, []*model.PluginKVBatchOperation{
+			{Key: "new", Value: []byte("value")},
+			{Key: "existing", Value: []byte("new"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("old")}},
+		})
+		require.NoError(t, err)
+		assert.False(t, applied)
+
+		_, err = ss.Plugin().Get(pluginID, "new")
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+
+		kv, err := ss.Plugin().Get(pluginID, "existing")
+		require.NoError(t, err)
+		assert.Equal(t, []byte("current"), kv.Value)
+	})
+
+	t.Run("invalid operation", func(t *testing.T) {
+		pluginID, tearDown := setupKVs(t, rctx, ss)
+		defer tearDown()
+
+		applied, err := ss.Plugin().Batch(pluginID, []*model.PluginKVBatchOperation{
+			{Key: "new", Value: []byte("value")},
+			{Key: "other", Value: []byte("value"), Options: model.PluginKVSetOptions{OldValue: []byte("old")}},
+		})
+		require.Error(t, err)
+		assert.False(t, applied)
+
+		_, err = ss.Plugin().Get(pluginID, "new")
+		var nfErr *store.ErrNotFound
+		assert.ErrorAs(t, err, &nfErr)
+	})
+}
+
+func testPluginGetAllForExport(t *testing.T, rctx request.CTX, ss store.Store) {
+	_, tearDown := setupKVs(t, rctx, ss)
+	defer tearDown()
+
+	require.NoError(t, ss.Plugin().DeleteAllExpired())
+
+	pluginID := "a" + model.NewId()
+	for _, key := range []string{"key1", "key2", "key3"} {
+		_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: key, Value: []byte(key)})
+		require.NoError(t, err)
+	}
+	_, err := ss.Plugin().SaveOrUpdate(&model.PluginKeyValue{PluginId: pluginID, Key: "expired", Value: []byte("value"), ExpireAt: model.GetMillis() - 1000})
+	require.NoError(t, err)
+
+	var exported []*model.PluginKeyValue
+	afterPluginID, afterKey := "", ""
+	for {
+		kvs, err := ss.Plugin().GetAllForExport(afterPluginID, afterKey, 2)
+		require.NoError(t, err)
+		if len(kvs) == 0 {
+			break
+		}
+		exported = append(exported, kvs...)
+		afterPluginID, afterKey = kvs[len(kvs)-1].PluginId, kvs[len(kvs)-1].Key
+	}
+
+	var keys []string
+	for _, kv := range exported {
+		if kv.PluginId == pluginID {
+			keys = append(keys, kv.Key)
+		}
+	}
+	assert.Equal(t, []string{"key1", "key2", "key3"}, keys)
+}
@@ -18,6 +18,7 @@ func TestPostPersistentNotificationStore(t *testing.T, rctx request.CTX, ss stor
 	t.Run("Get", func(t *testing.T) { testPostPersistentNotificationStoreGet(t, rctx, ss) })
 	t.Run("Delete", func(t *testing.T) { testPostPersistentNotificationStoreDelete(t, rctx, ss) })
 	t.Run("UpdateLastSentAt", func(t *testing.T) { testPostPersistentNotificationStoreUpdateLastSentAt(t, rctx, ss) })
+	t.Run("UpdateEscalationLevel", func(t *testing.T) { testPostPersistentNotificationStoreUpdateEscalationLevel(t, rctx, ss) })
 }
 
 func testPostPersistentNotificationStoreGet(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -194,6 +195,39 @@ func testPostPersistentNotificationStoreUpdateLastSentAt(t *testing.T, rctx requ
 	assert.WithinDuration(t, now, model.GetTimeForMillis(pn[0].LastSentAt), delta)
 }
 
+func testPostPersistentNotificationStoreUpdateEscalationLevel(t *testing.T, rctx request.CTX, ss store.Store) {
+	p1 := model.Post{}
+	p1.ChannelId = model.NewId()
+	p1.UserId = model.NewId()
+	p1.Message = NewTestID()
+	p1.CreateAt = 10
+	p1.Metadata = &model.PostMetadata{
+		Priority: &model.PostPriority{
+			Priority:                model.NewPointer(model.PostPriorityUrgent),
+			RequestedAck:            model.NewPointer(false),
+			PersistentNotifications: model.NewPointer(true),
+		},
+	}
+
+	_, errIdx, err := ss.Post().SaveMultiple([]*model.Post{&p1})
+	require.NoError(t, err)
+	require.Equal(t, -1, errIdx)
+
+	defer ss.Post().PermanentDeleteByChannel(rctx, p1.ChannelId)
+	defer ss.PostPersistentNotification().Delete([]string{p1.Id})
+
+	pn, err := ss.PostPersistentNotification().GetSingle(p1.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int16(0), pn.EscalationLevel)
+
+	err = ss.PostPersistentNotification().UpdateEscalationLevel(p1.Id, 2)
+	require.NoError(t, err)
+
+	pn, err = ss.PostPersistentNotification().GetSingle(p1.Id)
+	require.NoError(t, err)
+	assert.Equal(t, int16(2), pn.EscalationLevel)
+}
+
 func testPostPersistentNotificationStoreDelete(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("Delete", func(t *testing.T) {
 		p1 := model.Post{}
@@ -255,6 +289,55 @@ func testPostPersistentNotificationStoreDelete(t *testing.T, rctx request.CTX, s
 		assert.Equal(t, p2.Id, pn[0].PostId)
 	})
 
+	t.Run("Delete Expired", func(t *testing.T) {
+		channelID := model.NewId()
+		posts := make([]*model.Post, 3)
+		for i := range posts {
+			posts[i] = &model.Post{
+				ChannelId: channelID,
+				UserId:    model.NewId(),
+				Message:   NewTestID(),
+				CreateAt:  10,
+				Metadata: &model.PostMetadata{
+					Priority: &model.PostPriority{
+						Priority:                model.NewPointer(model.PostPriorityUrgent),
+						RequestedAck:            model.NewPointer(false),
+						PersistentNotifications: model.NewPointer(true),
+					},
+				},
+			}
+		}
+
+		_, errIdx, err := ss.Post().SaveMultiple(posts)
+		require.NoError(t, err)
+		require.Equal(t, -1, errIdx)
+
+		defer ss.Post().PermanentDeleteByChannel(rctx, channelID)
+		defer ss.PostPersistentNotification().Delete([]string{posts[0].Id, posts[1].Id, posts[2].Id})
+
+		// The first two notifications reach the max count of 1.
+		err = ss.PostPersistentNotification().UpdateLastActivity([]string{posts[0].Id, posts[1].Id})
+		require.NoError(t, err)
+
+		expired, err := ss.PostPersistentNotification().GetExpired(1)
+		require.NoError(t, err)
+		expiredIDs := []string{}
+		for _, pn := range expired {
+			expiredIDs = append(expiredIDs, pn.PostId)
+		}
+		assert.ElementsMatch(t, []string{posts[0].Id, posts[1].Id}, expiredIDs)
+
+		err = ss.PostPersistentNotification().DeleteExpired(1, []string{posts[1].Id})
+		require.NoError(t, err)
+
+		_, err = ss.PostPersistentNotification().GetSingle(posts[0].Id)
+		require.Error(t, err)
+		for _, post := range posts[1:] {
+			_, err = ss.PostPersistentNotification().GetSingle(post.Id)
+			require.NoError(t, err)
+		}
+	})
+
 	t.Run("Delete By Channel", func(t *testing.T) {
 		p1 := model.Post{}
 		p1.ChannelId = model.NewId()
@@ -0,0 +1,178 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package storetest
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/request"
+	"github.com/mattermost/mattermost/server/v8/channels/store"
+)
+
+func TestSessionActivityStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
+	t.Run("SaveAndUpdateLastSeenAt", func(t *testing.T) { testSessionActivityStoreSaveAndUpdateLastSeenAt(t, ss) })
+	t.Run("GetAll", func(t *testing.T) { testSessionActivityStoreGetAll(t, ss) })
+	t.Run("GetLatestLocatedForUser", func(t *testing.T) { testSessionActivityStoreGetLatestLocatedForUser(t, ss) })
+	t.Run("HasUserUsedDevice", func(t *testing.T) { testSessionActivityStoreHasUserUsedDevice(t, ss) })
+	t.Run("PermanentDelete", func(t *testing.T) { testSessionActivityStorePermanentDelete(t, ss) })
+}
+
+func newTestSessionActivity(userID, ipAddress string, lastSeenAt int64) *model.SessionActivity {
+	return &model.SessionActivity{
+		SessionId:   model.NewId(),
+		UserId:      userID,
+		IpAddress:   ipAddress,
+		UserAgent:   "Mozilla/5.0",
+		Platform:    "Linux",
+		Os:          "Linux",
+		Browser:     "Firefox",
+		FirstSeenAt: lastSeenAt,
+		LastSeenAt:  lastSeenAt,
+	}
+}
+
+func testSessionActivityStoreSaveAndUpdateLastSeenAt(t *testing.T, ss store.Store) {
+	activity := newTestSessionActivity(model.NewId(), "10.0.0.1", 1000)
+	saved, err := ss.SessionActivity().Save(activity)
+	require.NoError(t, err)
+	require.NotEmpty(t, saved.Id)
+
+	duplicate := *activity
+	duplicate.Id = ""
+	_, err = ss.SessionActivity().Save(&duplicate)
+	var conflictErr *store.ErrConflict
+	require.True(t, errors.As(err, &conflictErr))
+
+	require.NoError(t, ss.SessionActivity().UpdateLastSeenAt(activity.SessionId, activity.IpAddress, 2000))
+	// Older uses don't move the last use back.
+	require.NoError(t, ss.SessionActivity().UpdateLastSeenAt(activity.SessionId, activity.IpAddress, 1500))
+
+	activities, err := ss.SessionActivity().GetAll(model.SessionActivityGetOptions{UserId: activity.UserId, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	assert.Equal(t, int64(1000), activities[0].FirstSeenAt)
+	assert.Equal(t, int64(2000), activities[0].LastSeenAt)
+
+	err = ss.SessionActivity().UpdateLastSeenAt(activity.SessionId, "10.0.0.2", 2000)
+	var notFoundErr *store.ErrNotFound
+	require.True(t, errors.As(err, &notFoundErr))
+}
+
+func testSessionActivityStoreGetAll(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	first := newTestSessionActivity(userID, "10.0.0.1", 1000)
+	second := newTestSessionActivity(userID, "10.0.0.2", 2000)
+	second.Anomaly = model.SessionAnomalyImpossibleTravel
+	other := newTestSessionActivity(model.NewId(), "10.0.0.3", 3000)
+	for _, activity := range []*model.SessionActivity{first, second, other} {
+		_, err := ss.SessionActivity().Save(activity)
+		require.NoError(t, err)
+	}
+
+	activities, err := ss.SessionActivity().GetAll(model.SessionActivityGetOptions{UserId: userID, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, activities, 2)
+	assert.Equal(t, second.Id, activities[0].Id)
+	assert.Equal(t, first.Id, activities[1].Id)
+
+	activities, err = ss.SessionActivity().GetAll(model.SessionActivityGetOptions{UserId: userID, Page: 1, PerPage: 1})
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	assert.Equal(t, first.Id, activities[0].Id)
+
+	activities, err = ss.SessionActivity().GetAll(model.SessionActivityGetOptions{UserId: userID, AnomaliesOnly: true, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	assert.Equal(t, second.Id, activities[0].Id)
+}
+
+func testSessionActivityStoreGetLatestLocatedForUser(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+
+	_, err := ss.SessionActivity().GetLatestLocatedForUser(userID)
+	var notFoundErr *store.ErrNotFound
+	require.True(t, errors.As(err, &notFoundErr))
+
+	located := newTestSessionActivity(userID, "10.0.0.1", 1000)
+	located.Region = "Paris"
+	located.Latitude = 48.86
+	located.Longitude = 2.35
+	unlocated := newTestSessionActivity(userID, "10.0.0.2", 2000)
+	for _, activity := range []*model.SessionActivity{located, unlocated} {
+		_, err = ss.SessionActivity().Save(activity)
+		require.NoError(t, err)
+	}
+
+	latest, err := ss.SessionActivity().GetLatestLocatedForUser(userID)
+	require.NoError(t, err)
+	assert.Equal(t, located.Id, latest.Id)
+	assert.InDelta(t, 48.86, latest.Latitude, 0.001)
+}
+
+func testSessionActivityStoreHasUserUsedDevice(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	activity := newTestSessionActivity(userID, "10.0.0.1", 1000)
+	_, err := ss.SessionActivity().Save(activity)
+	require.NoError(t, err)
+
+	found, err := ss.SessionActivity().HasUserActivity(userID, activity.SessionId)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	found, err = ss.SessionActivity().HasUserActivity(userID, model.NewId())
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	sameDevice := newTestSessionActivity(userID, "10.0.0.2", 2000)
+	found, err = ss.SessionActivity().HasUserUsedDevice(sameDevice)
+	require.NoError(t, err)
+	assert.True(t, found)
+
+	// The device of the activity's own session doesn't count.
+	sameDevice.SessionId = activity.SessionId
+	found, err = ss.SessionActivity().HasUserUsedDevice(sameDevice)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	otherBrowser := newTestSessionActivity(userID, "10.0.0.2", 2000)
+	otherBrowser.Browser = "Chrome"
+	found, err = ss.SessionActivity().HasUserUsedDevice(otherBrowser)
+	require.NoError(t, err)
+	assert.False(t, found)
+
+	mobile := newTestSessionActivity(userID, "10.0.0.2", 2000)
+	mobile.DeviceId = "apple:" + model.NewId()
+	found, err = ss.SessionActivity().HasUserUsedDevice(mobile)
+	require.NoError(t, err)
+	assert.False(t, found)
+}
+
+func testSessionActivityStorePermanentDelete(t *testing.T, ss store.Store) {
+	userID := model.NewId()
+	old := newTestSessionActivity(userID, "10.0.0.1", 1000)
+	recent := newTestSessionActivity(userID, "10.0.0.2", 5000)
+	for _, activity := range []*model.SessionActivity{old, recent} {
+		_, err := ss.SessionActivity().Save(activity)
+		require.NoError(t, err)
+	}
+
+	count, err := ss.SessionActivity().PermanentDeleteBefore(2000, 100)
+	require.NoError(t, err)
+	assert.GreaterOrEqual(t, count, int64(1))
+
+	activities, err := ss.SessionActivity().GetAll(model.SessionActivityGetOptions{UserId: userID, PerPage: 10})
+	require.NoError(t, err)
+	require.Len(t, activities, 1)
+	assert.Equal(t, recent.Id, activities[0].Id)
+
+	require.NoError(t, ss.SessionActivity().PermanentDeleteByUser(userID))
+	activities, err = ss.SessionActivity().GetAll(model.SessionActivityGetOptions{UserId: userID, PerPage: 10})
+	require.NoError(t, err)
+	assert.Empty(t, activities)
+}
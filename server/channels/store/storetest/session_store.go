@@ -4,6 +4,7 @@
 package storetest
 
 import (
+	"sort"
 	"testing"
 	"time"
 
@@ -38,6 +39,7 @@ func TestSessionStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("GetSessionsExpired", func(t *testing.T) { testGetSessionsExpired(t, rctx, ss) })
 	t.Run("UpdateExpiredNotify", func(t *testing.T) { testUpdateExpiredNotify(t, rctx, ss) })
 	t.Run("GetLRUSessions", func(t *testing.T) { testGetLRUSessions(t, rctx, ss) })
+	t.Run("GetAllSessions", func(t *testing.T) { testGetAllSessions(t, rctx, ss) })
 	t.Run("GetMobileSessionMetadata", func(t *testing.T) { testGetMobileSessionMetadata(t, rctx, ss) })
 }
 
@@ -472,6 +474,31 @@ func testGetLRUSessions(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.Equal(t, s1.Id, sessions[2].Id)
 }
 
+func testGetAllSessions(t *testing.T, rctx request.CTX, ss store.Store) {
+	// Clear existing sessions.
+	err := ss.Session().RemoveAllSessions()
+	require.NoError(t, err)
+
+	var ids []string
+	for range 3 {
+		s, err := ss.Session().Save(rctx, &model.Session{UserId: model.NewId()})
+		require.NoError(t, err)
+		ids = append(ids, s.Id)
+	}
+	sort.Strings(ids)
+
+	sessions, err := ss.Session().GetAllSessions("", 2)
+	require.NoError(t, err)
+	require.Len(t, sessions, 2)
+	require.Equal(t, ids[0], sessions[0].Id)
+	require.Equal(t, ids[1], sessions[1].Id)
+
+	sessions, err = ss.Session().GetAllSessions(sessions[1].Id, 2)
+	require.NoError(t, err)
+	require.Len(t, sessions, 1)
+	require.Equal(t, ids[2], sessions[0].Id)
+}
+
 func testGetMobileSessionMetadata(t *testing.T, rctx request.CTX, ss store.Store) {
 	userId1 := model.NewId()
 	userId2 := model.NewId()
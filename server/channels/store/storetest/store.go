@@ -66,6 +66,11 @@ type Store struct {
 	DesktopTokensStore              mocks.DesktopTokensStore
 	ChannelBookmarkStore            mocks.ChannelBookmarkStore
 	ScheduledPostStore              mocks.ScheduledPostStore
+	EscalationPolicyStore           mocks.EscalationPolicyStore
+	SessionActivityStore            mocks.SessionActivityStore
+	PasswordHistoryStore            mocks.PasswordHistoryStore
+	AttributeGroupStore             mocks.AttributeGroupStore
+	ChannelAccessPolicyStore        mocks.ChannelAccessPolicyStore
 }
 
 func (s *Store) SetContext(context context.Context)            { s.context = context }
@@ -111,14 +116,21 @@ func (s *Store) Draft() store.DraftStore                           { return &s.D
 func (s *Store) ChannelMemberHistory() store.ChannelMemberHistoryStore {
 	return &s.ChannelMemberHistoryStore
 }
-func (s *Store) ChannelBookmark() store.ChannelBookmarkStore { return &s.ChannelBookmarkStore }
-func (s *Store) DesktopTokens() store.DesktopTokensStore     { return &s.DesktopTokensStore }
-func (s *Store) NotifyAdmin() store.NotifyAdminStore         { return &s.NotifyAdminStore }
-func (s *Store) Group() store.GroupStore                     { return &s.GroupStore }
-func (s *Store) LinkMetadata() store.LinkMetadataStore       { return &s.LinkMetadataStore }
-func (s *Store) SharedChannel() store.SharedChannelStore     { return &s.SharedChannelStore }
-func (s *Store) PostPriority() store.PostPriorityStore       { return &s.PostPriorityStore }
-func (s *Store) ScheduledPost() store.ScheduledPostStore     { return &s.ScheduledPostStore }
+func (s *Store) ChannelBookmark() store.ChannelBookmarkStore   { return &s.ChannelBookmarkStore }
+func (s *Store) DesktopTokens() store.DesktopTokensStore       { return &s.DesktopTokensStore }
+func (s *Store) NotifyAdmin() store.NotifyAdminStore           { return &s.NotifyAdminStore }
+func (s *Store) Group() store.GroupStore                       { return &s.GroupStore }
+func (s *Store) LinkMetadata() store.LinkMetadataStore         { return &s.LinkMetadataStore }
+func (s *Store) SharedChannel() store.SharedChannelStore       { return &s.SharedChannelStore }
+func (s *Store) PostPriority() store.PostPriorityStore         { return &s.PostPriorityStore }
+func (s *Store) ScheduledPost() store.ScheduledPostStore       { return &s.ScheduledPostStore }
+func (s *Store) EscalationPolicy() store.EscalationPolicyStore { return &s.EscalationPolicyStore }
+func (s *Store) SessionActivity() store.SessionActivityStore   { return &s.SessionActivityStore }
+func (s *Store) PasswordHistory() store.PasswordHistoryStore   { return &s.PasswordHistoryStore }
+func (s *Store) AttributeGroup() store.AttributeGroupStore     { return &s.AttributeGroupStore }
+func (s *Store) ChannelAccessPolicy() store.ChannelAccessPolicyStore {
+	return &s.ChannelAccessPolicyStore
+}
 func (s *Store) PostAcknowledgement() store.PostAcknowledgementStore {
 	return &s.PostAcknowledgementStore
 }
@@ -191,5 +203,10 @@ func (s *Store) AssertExpectations(t mock.TestingT) bool {
 		&s.DesktopTokensStore,
 		&s.ChannelBookmarkStore,
 		&s.ScheduledPostStore,
+		&s.EscalationPolicyStore,
+		&s.SessionActivityStore,
+		&s.PasswordHistoryStore,
+		&s.AttributeGroupStore,
+		&s.ChannelAccessPolicyStore,
 	)
 }
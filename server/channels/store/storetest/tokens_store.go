@@ -16,6 +16,8 @@ import (
 
 func TestTokensStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("TokensCleanup", func(t *testing.T) { testTokensCleanup(t, rctx, ss) })
+	t.Run("UpdateExtra", func(t *testing.T) { testTokensUpdateExtra(t, rctx, ss) })
+	t.Run("GetAllTokensByTypeAndUser", func(t *testing.T) { testTokensGetAllTokensByTypeAndUser(t, rctx, ss) })
 }
 
 func testTokensCleanup(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -41,3 +43,54 @@ func testTokensCleanup(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.NoError(t, err)
 	assert.Len(t, tokens, 0)
 }
+
+func testTokensUpdateExtra(t *testing.T, rctx request.CTX, ss store.Store) {
+	token := &model.Token{
+		Token:    model.NewRandomString(model.TokenSize),
+		CreateAt: model.GetMillis(),
+		Type:     model.TokenTypeOAuth,
+		Extra:    "first",
+	}
+	require.NoError(t, ss.Token().Save(token))
+	defer ss.Token().Delete(token.Token)
+
+	require.NoError(t, ss.Token().UpdateExtra(token.Token, "first", "second"))
+
+	saved, err := ss.Token().GetByToken(token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "second", saved.Extra)
+
+	err = ss.Token().UpdateExtra(token.Token, "first", "third")
+	var nfErr *store.ErrNotFound
+	assert.ErrorAs(t, err, &nfErr)
+
+	saved, err = ss.Token().GetByToken(token.Token)
+	require.NoError(t, err)
+	assert.Equal(t, "second", saved.Extra)
+}
+
+func testTokensGetAllTokensByTypeAndUser(t *testing.T, rctx request.CTX, ss store.Store) {
+	userID := model.NewId()
+	otherUserID := model.NewId()
+
+	var saved []*model.Token
+	for _, token := range []*model.Token{
+		model.NewToken(model.TokenTypeOAuth, string(model.ToJSON(map[string]string{"UserId": userID, "Email": "user@example.com"}))),
+		model.NewToken(model.TokenTypeOAuth, string(model.ToJSON(map[string]string{"UserId": otherUserID}))),
+		model.NewToken(model.TokenTypeOAuth, ""),
+		model.NewToken(model.TokenTypeSaml, string(model.ToJSON(map[string]string{"UserId": userID}))),
+	} {
+		require.NoError(t, ss.Token().Save(token))
+		saved = append(saved, token)
+	}
+	defer func() {
+		for _, token := range saved {
+			require.NoError(t, ss.Token().Delete(token.Token))
+		}
+	}()
+
+	tokens, err := ss.Token().GetAllTokensByTypeAndUser(model.TokenTypeOAuth, userID)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	assert.Equal(t, saved[0].Token, tokens[0].Token)
+}
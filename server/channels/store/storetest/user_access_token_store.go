@@ -17,6 +17,7 @@ func TestUserAccessTokenStore(t *testing.T, rctx request.CTX, ss store.Store) {
 	t.Run("UserAccessTokenSaveGetDelete", func(t *testing.T) { testUserAccessTokenSaveGetDelete(t, rctx, ss) })
 	t.Run("UserAccessTokenDisableEnable", func(t *testing.T) { testUserAccessTokenDisableEnable(t, rctx, ss) })
 	t.Run("UserAccessTokenSearch", func(t *testing.T) { testUserAccessTokenSearch(t, rctx, ss) })
+	t.Run("UserAccessTokenExpiryAndScopes", func(t *testing.T) { testUserAccessTokenExpiryAndScopes(t, rctx, ss) })
 }
 
 func testUserAccessTokenSaveGetDelete(t *testing.T, rctx request.CTX, ss store.Store) {
@@ -155,3 +156,66 @@ func testUserAccessTokenSearch(t *testing.T, rctx request.CTX, ss store.Store) {
 	require.NoError(t, nErr)
 	require.Equal(t, 1, len(received), "received incorrect number of tokens after search")
 }
+
+func testUserAccessTokenExpiryAndScopes(t *testing.T, rctx request.CTX, ss store.Store) {
+	now := model.GetMillis()
+	userID := model.NewId()
+
+	expiring := &model.UserAccessToken{
+		Token:       model.NewId(),
+		UserId:      userID,
+		Description: "expiring",
+		ExpiresAt:   now + 1000*60*60,
+		Scopes:      model.StringArray{"permission:" + model.PermissionCreatePost.Id, "team:" + model.NewId()},
+	}
+	_, err := ss.UserAccessToken().Save(expiring)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.UserAccessToken().Delete(expiring.Id)) }()
+
+	later := &model.UserAccessToken{
+		Token:       model.NewId(),
+		UserId:      userID,
+		Description: "later",
+		ExpiresAt:   now + 1000*60*60*24*30,
+	}
+	_, err = ss.UserAccessToken().Save(later)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.UserAccessToken().Delete(later.Id)) }()
+
+	unlimited := &model.UserAccessToken{
+		Token:       model.NewId(),
+		UserId:      userID,
+		Description: "unlimited",
+	}
+	_, err = ss.UserAccessToken().Save(unlimited)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.UserAccessToken().Delete(unlimited.Id)) }()
+
+	received, err := ss.UserAccessToken().Get(expiring.Id)
+	require.NoError(t, err)
+	require.Equal(t, expiring.ExpiresAt, received.ExpiresAt)
+	require.Equal(t, expiring.Scopes, received.Scopes)
+
+	received, err = ss.UserAccessToken().Get(unlimited.Id)
+	require.NoError(t, err)
+	require.Zero(t, received.ExpiresAt)
+	require.Empty(t, received.Scopes)
+
+	err = ss.UserAccessToken().UpdateLastUsedAt(expiring.Id, now)
+	require.NoError(t, err)
+	received, err = ss.UserAccessToken().Get(expiring.Id)
+	require.NoError(t, err)
+	require.Equal(t, now, received.LastUsedAt)
+
+	tokens, err := ss.UserAccessToken().GetExpiringUnnotified(now, now+1000*60*60*24, 100)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+	require.Equal(t, expiring.Id, tokens[0].Id)
+
+	err = ss.UserAccessToken().UpdateExpiryNotified(expiring.Id)
+	require.NoError(t, err)
+
+	tokens, err = ss.UserAccessToken().GetExpiringUnnotified(now, now+1000*60*60*24, 100)
+	require.NoError(t, err)
+	require.Empty(t, tokens)
+}
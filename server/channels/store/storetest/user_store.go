@@ -54,9 +54,11 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("Update", func(t *testing.T) { testUserStoreUpdate(t, rctx, ss) })
 	t.Run("UpdateUpdateAt", func(t *testing.T) { testUserStoreUpdateUpdateAt(t, rctx, ss) })
 	t.Run("UpdateFailedPasswordAttempts", func(t *testing.T) { testUserStoreUpdateFailedPasswordAttempts(t, rctx, ss) })
+	t.Run("RecordFailedPasswordAttempt", func(t *testing.T) { testUserStoreRecordFailedPasswordAttempt(t, rctx, ss) })
 	t.Run("Get", func(t *testing.T) { testUserStoreGet(t, rctx, ss) })
 	t.Run("GetAllUsingAuthService", func(t *testing.T) { testGetAllUsingAuthService(t, rctx, ss) })
 	t.Run("GetAllProfiles", func(t *testing.T) { testUserStoreGetAllProfiles(t, rctx, ss) })
+	t.Run("GetAllLocalUsers", func(t *testing.T) { testUserStoreGetAllLocalUsers(t, rctx, ss) })
 	t.Run("GetProfiles", func(t *testing.T) { testUserStoreGetProfiles(t, rctx, ss) })
 	t.Run("GetProfilesInChannel", func(t *testing.T) { testUserStoreGetProfilesInChannel(t, rctx, ss) })
 	t.Run("GetProfilesInChannelByStatus", func(t *testing.T) { testUserStoreGetProfilesInChannelByStatus(t, rctx, ss, s) })
@@ -100,6 +102,7 @@ func TestUserStore(t *testing.T, rctx request.CTX, ss store.Store, s SqlStore) {
 	t.Run("ResetLastPictureUpdate", func(t *testing.T) { testUserStoreResetLastPictureUpdate(t, rctx, ss) })
 	t.Run("GetKnownUsers", func(t *testing.T) { testGetKnownUsers(t, rctx, ss) })
 	t.Run("GetUsersWithInvalidEmails", func(t *testing.T) { testGetUsersWithInvalidEmails(t, rctx, ss) })
+	t.Run("GetUsersWithExpiredPasswords", func(t *testing.T) { testGetUsersWithExpiredPasswords(t, rctx, ss) })
 	t.Run("UpdateLastLogin", func(t *testing.T) { testUpdateLastLogin(t, rctx, ss) })
 	t.Run("GetUserReport", func(t *testing.T) { testGetUserReport(t, rctx, ss, s) })
 	t.Run("MfaUsedTimestamps", func(t *testing.T) { testMfaUsedTimestamps(t, rctx, ss) })
@@ -304,6 +307,31 @@ func testUserStoreUpdateFailedPasswordAttempts(t *testing.T, rctx request.CTX, s
 	require.Equal(t, 3, user.FailedAttempts, "FailedAttempts not updated correctly")
 }
 
+func testUserStoreRecordFailedPasswordAttempt(t *testing.T, rctx request.CTX, ss store.Store) {
+	u1 := &model.User{}
+	u1.Email = MakeEmail()
+	_, err := ss.User().Save(rctx, u1)
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u1.Id)) }()
+
+	failedAt := model.GetMillis()
+	err = ss.User().RecordFailedPasswordAttempt(u1.Id, 2, failedAt)
+	require.NoError(t, err)
+
+	user, err := ss.User().Get(context.Background(), u1.Id)
+	require.NoError(t, err)
+	require.Equal(t, 2, user.FailedAttempts)
+	require.Equal(t, failedAt, user.LastFailedAttemptAt)
+
+	user.Nickname = "nickname"
+	_, err = ss.User().Update(rctx, user, false)
+	require.NoError(t, err)
+
+	user, err = ss.User().Get(context.Background(), u1.Id)
+	require.NoError(t, err)
+	require.Equal(t, failedAt, user.LastFailedAttemptAt, "LastFailedAttemptAt shouldn't be changed by updates")
+}
+
 func testUserStoreGet(t *testing.T, rctx request.CTX, ss store.Store) {
 	u1 := &model.User{
 		Email: MakeEmail(),
@@ -420,6 +448,66 @@ func sanitized(user *model.User) *model.User {
 	return clonedUser
 }
 
+func testUserStoreGetAllLocalUsers(t *testing.T, rctx request.CTX, ss store.Store) {
+	u1, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u1" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u1.Id)) }()
+
+	u2, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u2" + model.NewId(),
+		DeleteAt: model.GetMillis(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u2.Id)) }()
+
+	u3, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u3" + model.NewId(),
+	})
+	require.NoError(t, err)
+	_, nErr := ss.Bot().Save(&model.Bot{
+		UserId:   u3.Id,
+		Username: u3.Username,
+		OwnerId:  u1.Id,
+	})
+	require.NoError(t, nErr)
+	defer func() { require.NoError(t, ss.Bot().PermanentDelete(u3.Id)) }()
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u3.Id)) }()
+
+	u4, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u4" + model.NewId(),
+		RemoteId: model.NewPointer(model.NewId()),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u4.Id)) }()
+
+	count, err := ss.User().Count(model.UserCountOptions{IncludeDeleted: true})
+	require.NoError(t, err)
+
+	users, err := ss.User().GetAllLocalUsers(0, int(count)+1)
+	require.NoError(t, err)
+	require.Len(t, users, int(count))
+
+	var ids []string
+	for _, user := range users {
+		ids = append(ids, user.Id)
+	}
+	assert.Contains(t, ids, u1.Id)
+	assert.Contains(t, ids, u2.Id)
+	assert.NotContains(t, ids, u3.Id)
+	assert.NotContains(t, ids, u4.Id)
+
+	page, err := ss.User().GetAllLocalUsers(1, 1)
+	require.NoError(t, err)
+	require.Len(t, page, 1)
+	assert.Equal(t, users[1].Id, page[0].Id)
+}
+
 func testUserStoreGetAllProfiles(t *testing.T, rctx request.CTX, ss store.Store) {
 	u1, err := ss.User().Save(rctx, &model.User{
 		Email:    MakeEmail(),
@@ -6240,6 +6328,41 @@ func testGetUsersWithInvalidEmails(t *testing.T, rctx request.CTX, ss store.Stor
 	assert.Len(t, users, 1)
 }
 
+func testGetUsersWithExpiredPasswords(t *testing.T, rctx request.CTX, ss store.Store) {
+	u1, err := ss.User().Save(rctx, &model.User{
+		Email:    MakeEmail(),
+		Username: "u1" + model.NewId(),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u1.Id)) }()
+
+	u2, err := ss.User().Save(rctx, &model.User{
+		Email:       MakeEmail(),
+		Username:    "u2" + model.NewId(),
+		AuthService: model.UserAuthServiceGitlab,
+		AuthData:    model.NewPointer(model.NewId()),
+	})
+	require.NoError(t, err)
+	defer func() { require.NoError(t, ss.User().PermanentDelete(rctx, u2.Id)) }()
+
+	userIds := func(users []*model.User) []string {
+		ids := make([]string, 0, len(users))
+		for _, u := range users {
+			ids = append(ids, u.Id)
+		}
+		return ids
+	}
+
+	users, err := ss.User().GetUsersWithExpiredPasswords(u1.LastPasswordUpdate+1, 0, 10000)
+	require.NoError(t, err)
+	assert.Contains(t, userIds(users), u1.Id)
+	assert.NotContains(t, userIds(users), u2.Id)
+
+	users, err = ss.User().GetUsersWithExpiredPasswords(u1.LastPasswordUpdate, 0, 10000)
+	require.NoError(t, err)
+	assert.NotContains(t, userIds(users), u1.Id)
+}
+
 func testUpdateLastLogin(t *testing.T, rctx request.CTX, ss store.Store) {
 	u1 := model.User{}
 	u1.Email = MakeEmail()
@@ -19,9 +19,11 @@ import (
 type TimerLayer struct {
 	store.Store
 	Metrics                         einterfaces.MetricsInterface
+	AttributeGroupStore             store.AttributeGroupStore
 	AuditStore                      store.AuditStore
 	BotStore                        store.BotStore
 	ChannelStore                    store.ChannelStore
+	ChannelAccessPolicyStore        store.ChannelAccessPolicyStore
 	ChannelBookmarkStore            store.ChannelBookmarkStore
 	ChannelMemberHistoryStore       store.ChannelMemberHistoryStore
 	ClusterDiscoveryStore           store.ClusterDiscoveryStore
@@ -31,6 +33,7 @@ type TimerLayer struct {
 	DesktopTokensStore              store.DesktopTokensStore
 	DraftStore                      store.DraftStore
 	EmojiStore                      store.EmojiStore
+	EscalationPolicyStore           store.EscalationPolicyStore
 	FileInfoStore                   store.FileInfoStore
 	GroupStore                      store.GroupStore
 	JobStore                        store.JobStore
@@ -39,6 +42,7 @@ type TimerLayer struct {
 	NotifyAdminStore                store.NotifyAdminStore
 	OAuthStore                      store.OAuthStore
 	OutgoingOAuthConnectionStore    store.OutgoingOAuthConnectionStore
+	PasswordHistoryStore            store.PasswordHistoryStore
 	PluginStore                     store.PluginStore
 	PostStore                       store.PostStore
 	PostAcknowledgementStore        store.PostAcknowledgementStore
@@ -53,6 +57,7 @@ type TimerLayer struct {
 	ScheduledPostStore              store.ScheduledPostStore
 	SchemeStore                     store.SchemeStore
 	SessionStore                    store.SessionStore
+	SessionActivityStore            store.SessionActivityStore
 	SharedChannelStore              store.SharedChannelStore
 	StatusStore                     store.StatusStore
 	SystemStore                     store.SystemStore
@@ -67,6 +72,10 @@ type TimerLayer struct {
 	WebhookStore                    store.WebhookStore
 }
 
+func (s *TimerLayer) AttributeGroup() store.AttributeGroupStore {
+	return s.AttributeGroupStore
+}
+
 func (s *TimerLayer) Audit() store.AuditStore {
 	return s.AuditStore
 }
@@ -79,6 +88,10 @@ func (s *TimerLayer) Channel() store.ChannelStore {
 	return s.ChannelStore
 }
 
+func (s *TimerLayer) ChannelAccessPolicy() store.ChannelAccessPolicyStore {
+	return s.ChannelAccessPolicyStore
+}
+
 func (s *TimerLayer) ChannelBookmark() store.ChannelBookmarkStore {
 	return s.ChannelBookmarkStore
 }
@@ -115,6 +128,10 @@ func (s *TimerLayer) Emoji() store.EmojiStore {
 	return s.EmojiStore
 }
 
+func (s *TimerLayer) EscalationPolicy() store.EscalationPolicyStore {
+	return s.EscalationPolicyStore
+}
+
 func (s *TimerLayer) FileInfo() store.FileInfoStore {
 	return s.FileInfoStore
 }
@@ -147,6 +164,10 @@ func (s *TimerLayer) OutgoingOAuthConnection() store.OutgoingOAuthConnectionStor
 	return s.OutgoingOAuthConnectionStore
 }
 
+func (s *TimerLayer) PasswordHistory() store.PasswordHistoryStore {
+	return s.PasswordHistoryStore
+}
+
 func (s *TimerLayer) Plugin() store.PluginStore {
 	return s.PluginStore
 }
@@ -203,6 +224,10 @@ func (s *TimerLayer) Session() store.SessionStore {
 	return s.SessionStore
 }
 
+func (s *TimerLayer) SessionActivity() store.SessionActivityStore {
+	return s.SessionActivityStore
+}
+
 func (s *TimerLayer) SharedChannel() store.SharedChannelStore {
 	return s.SharedChannelStore
 }
@@ -251,6 +276,11 @@ func (s *TimerLayer) Webhook() store.WebhookStore {
 	return s.WebhookStore
 }
 
+type TimerLayerAttributeGroupStore struct {
+	store.AttributeGroupStore
+	Root *TimerLayer
+}
+
 type TimerLayerAuditStore struct {
 	store.AuditStore
 	Root *TimerLayer
@@ -266,6 +296,11 @@ type TimerLayerChannelStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerChannelAccessPolicyStore struct {
+	store.ChannelAccessPolicyStore
+	Root *TimerLayer
+}
+
 type TimerLayerChannelBookmarkStore struct {
 	store.ChannelBookmarkStore
 	Root *TimerLayer
@@ -311,6 +346,11 @@ type TimerLayerEmojiStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerEscalationPolicyStore struct {
+	store.EscalationPolicyStore
+	Root *TimerLayer
+}
+
 type TimerLayerFileInfoStore struct {
 	store.FileInfoStore
 	Root *TimerLayer
@@ -351,6 +391,11 @@ type TimerLayerOutgoingOAuthConnectionStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerPasswordHistoryStore struct {
+	store.PasswordHistoryStore
+	Root *TimerLayer
+}
+
 type TimerLayerPluginStore struct {
 	store.PluginStore
 	Root *TimerLayer
@@ -421,6 +466,11 @@ type TimerLayerSessionStore struct {
 	Root *TimerLayer
 }
 
+type TimerLayerSessionActivityStore struct {
+	store.SessionActivityStore
+	Root *TimerLayer
+}
+
 type TimerLayerSharedChannelStore struct {
 	store.SharedChannelStore
 	Root *TimerLayer
@@ -481,6 +531,134 @@ type TimerLayerWebhookStore struct {
 	Root *TimerLayer
 }
 
+func (s *TimerLayerAttributeGroupStore) DeleteRule(groupID string) error {
+	start := time.Now()
+
+	err := s.AttributeGroupStore.DeleteRule(groupID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AttributeGroupStore.DeleteRule", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerAttributeGroupStore) GetRule(groupID string) (*model.GroupAttributeRule, error) {
+	start := time.Now()
+
+	result, err := s.AttributeGroupStore.GetRule(groupID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AttributeGroupStore.GetRule", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAttributeGroupStore) GetRules() ([]*model.GroupAttributeRule, error) {
+	start := time.Now()
+
+	result, err := s.AttributeGroupStore.GetRules()
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AttributeGroupStore.GetRules", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAttributeGroupStore) GetUserAttributes(userID string) (map[string][]string, error) {
+	start := time.Now()
+
+	result, err := s.AttributeGroupStore.GetUserAttributes(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AttributeGroupStore.GetUserAttributes", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAttributeGroupStore) GetUserIDsByAttribute(attribute string, value string) ([]string, error) {
+	start := time.Now()
+
+	result, err := s.AttributeGroupStore.GetUserIDsByAttribute(attribute, value)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AttributeGroupStore.GetUserIDsByAttribute", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAttributeGroupStore) PermanentDeleteUserAttributes(userID string) error {
+	start := time.Now()
+
+	err := s.AttributeGroupStore.PermanentDeleteUserAttributes(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AttributeGroupStore.PermanentDeleteUserAttributes", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerAttributeGroupStore) SaveRule(rule *model.GroupAttributeRule) (*model.GroupAttributeRule, error) {
+	start := time.Now()
+
+	result, err := s.AttributeGroupStore.SaveRule(rule)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AttributeGroupStore.SaveRule", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerAttributeGroupStore) SetUserAttributes(userID string, attributes map[string][]string) error {
+	start := time.Now()
+
+	err := s.AttributeGroupStore.SetUserAttributes(userID, attributes)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("AttributeGroupStore.SetUserAttributes", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerAuditStore) Get(userID string, offset int, limit int) (model.Audits, error) {
 	start := time.Now()
 
@@ -2552,6 +2730,70 @@ func (s *TimerLayerChannelStore) UserBelongsToChannels(userID string, channelIds
 	return result, err
 }
 
+func (s *TimerLayerChannelAccessPolicyStore) Delete(channelID string) error {
+	start := time.Now()
+
+	err := s.ChannelAccessPolicyStore.Delete(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelAccessPolicyStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerChannelAccessPolicyStore) Get(channelID string) (*model.ChannelAccessPolicy, error) {
+	start := time.Now()
+
+	result, err := s.ChannelAccessPolicyStore.Get(channelID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelAccessPolicyStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelAccessPolicyStore) GetAll(offset int, limit int) ([]*model.ChannelAccessPolicy, error) {
+	start := time.Now()
+
+	result, err := s.ChannelAccessPolicyStore.GetAll(offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelAccessPolicyStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerChannelAccessPolicyStore) Save(policy *model.ChannelAccessPolicy) (*model.ChannelAccessPolicy, error) {
+	start := time.Now()
+
+	result, err := s.ChannelAccessPolicyStore.Save(policy)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("ChannelAccessPolicyStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerChannelBookmarkStore) Delete(bookmarkID string, deleteFile bool) error {
 	start := time.Now()
 
@@ -3495,6 +3737,86 @@ func (s *TimerLayerEmojiStore) Search(name string, prefixOnly bool, limit int) (
 	return result, err
 }
 
+func (s *TimerLayerEscalationPolicyStore) Delete(id string) error {
+	start := time.Now()
+
+	err := s.EscalationPolicyStore.Delete(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EscalationPolicyStore.Delete", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerEscalationPolicyStore) Get(id string) (*model.EscalationPolicy, error) {
+	start := time.Now()
+
+	result, err := s.EscalationPolicyStore.Get(id)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EscalationPolicyStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEscalationPolicyStore) GetForChannels(channelIDs []string) ([]*model.EscalationPolicy, error) {
+	start := time.Now()
+
+	result, err := s.EscalationPolicyStore.GetForChannels(channelIDs)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EscalationPolicyStore.GetForChannels", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEscalationPolicyStore) Save(policy *model.EscalationPolicy) (*model.EscalationPolicy, error) {
+	start := time.Now()
+
+	result, err := s.EscalationPolicyStore.Save(policy)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EscalationPolicyStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerEscalationPolicyStore) Update(policy *model.EscalationPolicy) (*model.EscalationPolicy, error) {
+	start := time.Now()
+
+	result, err := s.EscalationPolicyStore.Update(policy)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("EscalationPolicyStore.Update", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerFileInfoStore) AttachToPost(c request.CTX, fileID string, postID string, channelID string, creatorID string) error {
 	start := time.Now()
 
@@ -5573,6 +5895,86 @@ func (s *TimerLayerOutgoingOAuthConnectionStore) UpdateConnection(c request.CTX,
 	return result, err
 }
 
+func (s *TimerLayerPasswordHistoryStore) GetForUser(userID string, limit int) ([]*model.PasswordHistory, error) {
+	start := time.Now()
+
+	result, err := s.PasswordHistoryStore.GetForUser(userID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PasswordHistoryStore.GetForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPasswordHistoryStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.PasswordHistoryStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PasswordHistoryStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPasswordHistoryStore) PruneForUser(userID string, keep int) error {
+	start := time.Now()
+
+	err := s.PasswordHistoryStore.PruneForUser(userID, keep)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PasswordHistoryStore.PruneForUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPasswordHistoryStore) Save(history *model.PasswordHistory) error {
+	start := time.Now()
+
+	err := s.PasswordHistoryStore.Save(history)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PasswordHistoryStore.Save", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerPluginStore) Batch(pluginID string, operations []*model.PluginKVBatchOperation) (bool, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.Batch(pluginID, operations)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Batch", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPluginStore) CompareAndDelete(keyVal *model.PluginKeyValue, oldValue []byte) (bool, error) {
 	start := time.Now()
 
@@ -5653,10 +6055,58 @@ func (s *TimerLayerPluginStore) DeleteAllForPlugin(PluginID string) error {
 	return err
 }
 
-func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginKeyValue, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.Get(pluginID, key)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPluginStore) GetAllForExport(afterPluginID string, afterKey string, limit int) ([]*model.PluginKeyValue, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.GetAllForExport(afterPluginID, afterKey, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.GetAllForExport", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPluginStore) GetMany(pluginID string, keys []string) ([]*model.PluginKeyValue, error) {
+	start := time.Now()
+
+	result, err := s.PluginStore.GetMany(pluginID, keys)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.GetMany", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.Get(pluginID, key)
+	result, err := s.PluginStore.List(pluginID, page, perPage)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5664,15 +6114,15 @@ func (s *TimerLayerPluginStore) Get(pluginID string, key string) (*model.PluginK
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.Get", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
 	}
 	return result, err
 }
 
-func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]string, error) {
+func (s *TimerLayerPluginStore) ListWithPrefix(pluginID string, prefix string, page int, perPage int) ([]string, error) {
 	start := time.Now()
 
-	result, err := s.PluginStore.List(pluginID, page, perPage)
+	result, err := s.PluginStore.ListWithPrefix(pluginID, prefix, page, perPage)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -5680,7 +6130,7 @@ func (s *TimerLayerPluginStore) List(pluginID string, page int, perPage int) ([]
 		if err == nil {
 			success = "true"
 		}
-		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.List", success, elapsed)
+		s.Root.Metrics.ObserveStoreMethodDuration("PluginStore.ListWithPrefix", success, elapsed)
 	}
 	return result, err
 }
@@ -6611,10 +7061,10 @@ func (s *TimerLayerPostPersistentNotificationStore) DeleteByTeam(teamIds []strin
 	return err
 }
 
-func (s *TimerLayerPostPersistentNotificationStore) DeleteExpired(maxSentCount int16) error {
+func (s *TimerLayerPostPersistentNotificationStore) DeleteExpired(maxSentCount int16, exceptPostIds []string) error {
 	start := time.Now()
 
-	err := s.PostPersistentNotificationStore.DeleteExpired(maxSentCount)
+	err := s.PostPersistentNotificationStore.DeleteExpired(maxSentCount, exceptPostIds)
 
 	elapsed := float64(time.Since(start)) / float64(time.Second)
 	if s.Root.Metrics != nil {
@@ -6643,6 +7093,22 @@ func (s *TimerLayerPostPersistentNotificationStore) Get(params model.GetPersiste
 	return result, err
 }
 
+func (s *TimerLayerPostPersistentNotificationStore) GetExpired(maxSentCount int16) ([]*model.PostPersistentNotifications, error) {
+	start := time.Now()
+
+	result, err := s.PostPersistentNotificationStore.GetExpired(maxSentCount)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPersistentNotificationStore.GetExpired", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerPostPersistentNotificationStore) GetSingle(postID string) (*model.PostPersistentNotifications, error) {
 	start := time.Now()
 
@@ -6659,6 +7125,22 @@ func (s *TimerLayerPostPersistentNotificationStore) GetSingle(postID string) (*m
 	return result, err
 }
 
+func (s *TimerLayerPostPersistentNotificationStore) UpdateEscalationLevel(postID string, level int16) error {
+	start := time.Now()
+
+	err := s.PostPersistentNotificationStore.UpdateEscalationLevel(postID, level)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("PostPersistentNotificationStore.UpdateEscalationLevel", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerPostPersistentNotificationStore) UpdateLastActivity(postIds []string) error {
 	start := time.Now()
 
@@ -8083,6 +8565,22 @@ func (s *TimerLayerSessionStore) Get(c request.CTX, sessionIDOrToken string) (*m
 	return result, err
 }
 
+func (s *TimerLayerSessionStore) GetAllSessions(afterID string, limit uint64) ([]*model.Session, error) {
+	start := time.Now()
+
+	result, err := s.SessionStore.GetAllSessions(afterID, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionStore.GetAllSessions", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerSessionStore) GetLRUSessions(c request.CTX, userID string, limit uint64, offset uint64) ([]*model.Session, error) {
 	start := time.Now()
 
@@ -8323,6 +8821,134 @@ func (s *TimerLayerSessionStore) UpdateRoles(userID string, roles string) (strin
 	return result, err
 }
 
+func (s *TimerLayerSessionActivityStore) GetAll(opts model.SessionActivityGetOptions) ([]*model.SessionActivity, error) {
+	start := time.Now()
+
+	result, err := s.SessionActivityStore.GetAll(opts)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionActivityStore.GetAll", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionActivityStore) GetLatestLocatedForUser(userID string) (*model.SessionActivity, error) {
+	start := time.Now()
+
+	result, err := s.SessionActivityStore.GetLatestLocatedForUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionActivityStore.GetLatestLocatedForUser", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionActivityStore) HasUserActivity(userID string, excludeSessionID string) (bool, error) {
+	start := time.Now()
+
+	result, err := s.SessionActivityStore.HasUserActivity(userID, excludeSessionID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionActivityStore.HasUserActivity", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionActivityStore) HasUserUsedDevice(activity *model.SessionActivity) (bool, error) {
+	start := time.Now()
+
+	result, err := s.SessionActivityStore.HasUserUsedDevice(activity)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionActivityStore.HasUserUsedDevice", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionActivityStore) PermanentDeleteBefore(lastSeenAt int64, limit int64) (int64, error) {
+	start := time.Now()
+
+	result, err := s.SessionActivityStore.PermanentDeleteBefore(lastSeenAt, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionActivityStore.PermanentDeleteBefore", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionActivityStore) PermanentDeleteByUser(userID string) error {
+	start := time.Now()
+
+	err := s.SessionActivityStore.PermanentDeleteByUser(userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionActivityStore.PermanentDeleteByUser", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerSessionActivityStore) Save(activity *model.SessionActivity) (*model.SessionActivity, error) {
+	start := time.Now()
+
+	result, err := s.SessionActivityStore.Save(activity)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionActivityStore.Save", success, elapsed)
+	}
+	return result, err
+}
+
+func (s *TimerLayerSessionActivityStore) UpdateLastSeenAt(sessionID string, ipAddress string, lastSeenAt int64) error {
+	start := time.Now()
+
+	err := s.SessionActivityStore.UpdateLastSeenAt(sessionID, ipAddress, lastSeenAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("SessionActivityStore.UpdateLastSeenAt", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerSharedChannelStore) Delete(channelID string) (bool, error) {
 	start := time.Now()
 
@@ -10304,6 +10930,22 @@ func (s *TimerLayerTokenStore) GetAllTokensByType(tokenType string) ([]*model.To
 	return result, err
 }
 
+func (s *TimerLayerTokenStore) GetAllTokensByTypeAndUser(tokenType string, userID string) ([]*model.Token, error) {
+	start := time.Now()
+
+	result, err := s.TokenStore.GetAllTokensByTypeAndUser(tokenType, userID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.GetAllTokensByTypeAndUser", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerTokenStore) GetByToken(token string) (*model.Token, error) {
 	start := time.Now()
 
@@ -10352,6 +10994,22 @@ func (s *TimerLayerTokenStore) Save(recovery *model.Token) error {
 	return err
 }
 
+func (s *TimerLayerTokenStore) UpdateExtra(token string, previousExtra string, extra string) error {
+	start := time.Now()
+
+	err := s.TokenStore.UpdateExtra(token, previousExtra, extra)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("TokenStore.UpdateExtra", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerUploadSessionStore) Delete(id string) error {
 	start := time.Now()
 
@@ -10671,6 +11329,22 @@ func (s *TimerLayerUserStore) GetAllAfter(limit int, afterID string) ([]*model.U
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetAllLocalUsers(offset int, limit int) ([]*model.User, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetAllLocalUsers(offset, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetAllLocalUsers", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetAllNotInAuthService(authServices []string) ([]*model.User, error) {
 	start := time.Now()
 
@@ -11247,6 +11921,22 @@ func (s *TimerLayerUserStore) GetUsersBatchForIndexing(startTime int64, startFil
 	return result, err
 }
 
+func (s *TimerLayerUserStore) GetUsersWithExpiredPasswords(lastPasswordUpdateBefore int64, page int, perPage int) ([]*model.User, error) {
+	start := time.Now()
+
+	result, err := s.UserStore.GetUsersWithExpiredPasswords(lastPasswordUpdateBefore, page, perPage)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.GetUsersWithExpiredPasswords", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserStore) GetUsersWithInvalidEmails(page int, perPage int, restrictedDomains string) ([]*model.User, error) {
 	start := time.Now()
 
@@ -11388,6 +12078,22 @@ func (s *TimerLayerUserStore) PromoteGuestToUser(userID string) error {
 	return err
 }
 
+func (s *TimerLayerUserStore) RecordFailedPasswordAttempt(userID string, attempts int, failedAt int64) error {
+	start := time.Now()
+
+	err := s.UserStore.RecordFailedPasswordAttempt(userID, attempts, failedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserStore.RecordFailedPasswordAttempt", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerUserStore) RefreshPostStatsForUsers() error {
 	start := time.Now()
 
@@ -11852,6 +12558,22 @@ func (s *TimerLayerUserAccessTokenStore) GetByUser(userID string, page int, perP
 	return result, err
 }
 
+func (s *TimerLayerUserAccessTokenStore) GetExpiringUnnotified(expiresAfter int64, expiresBefore int64, limit int) ([]*model.UserAccessToken, error) {
+	start := time.Now()
+
+	result, err := s.UserAccessTokenStore.GetExpiringUnnotified(expiresAfter, expiresBefore, limit)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.GetExpiringUnnotified", success, elapsed)
+	}
+	return result, err
+}
+
 func (s *TimerLayerUserAccessTokenStore) Save(token *model.UserAccessToken) (*model.UserAccessToken, error) {
 	start := time.Now()
 
@@ -11884,6 +12606,38 @@ func (s *TimerLayerUserAccessTokenStore) Search(term string) ([]*model.UserAcces
 	return result, err
 }
 
+func (s *TimerLayerUserAccessTokenStore) UpdateExpiryNotified(tokenID string) error {
+	start := time.Now()
+
+	err := s.UserAccessTokenStore.UpdateExpiryNotified(tokenID)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateExpiryNotified", success, elapsed)
+	}
+	return err
+}
+
+func (s *TimerLayerUserAccessTokenStore) UpdateLastUsedAt(tokenID string, lastUsedAt int64) error {
+	start := time.Now()
+
+	err := s.UserAccessTokenStore.UpdateLastUsedAt(tokenID, lastUsedAt)
+
+	elapsed := float64(time.Since(start)) / float64(time.Second)
+	if s.Root.Metrics != nil {
+		success := "false"
+		if err == nil {
+			success = "true"
+		}
+		s.Root.Metrics.ObserveStoreMethodDuration("UserAccessTokenStore.UpdateLastUsedAt", success, elapsed)
+	}
+	return err
+}
+
 func (s *TimerLayerUserAccessTokenStore) UpdateTokenDisable(tokenID string) error {
 	start := time.Now()
 
@@ -12436,9 +13190,11 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 		Metrics: metrics,
 	}
 
+	newStore.AttributeGroupStore = &TimerLayerAttributeGroupStore{AttributeGroupStore: childStore.AttributeGroup(), Root: &newStore}
 	newStore.AuditStore = &TimerLayerAuditStore{AuditStore: childStore.Audit(), Root: &newStore}
 	newStore.BotStore = &TimerLayerBotStore{BotStore: childStore.Bot(), Root: &newStore}
 	newStore.ChannelStore = &TimerLayerChannelStore{ChannelStore: childStore.Channel(), Root: &newStore}
+	newStore.ChannelAccessPolicyStore = &TimerLayerChannelAccessPolicyStore{ChannelAccessPolicyStore: childStore.ChannelAccessPolicy(), Root: &newStore}
 	newStore.ChannelBookmarkStore = &TimerLayerChannelBookmarkStore{ChannelBookmarkStore: childStore.ChannelBookmark(), Root: &newStore}
 	newStore.ChannelMemberHistoryStore = &TimerLayerChannelMemberHistoryStore{ChannelMemberHistoryStore: childStore.ChannelMemberHistory(), Root: &newStore}
 	newStore.ClusterDiscoveryStore = &TimerLayerClusterDiscoveryStore{ClusterDiscoveryStore: childStore.ClusterDiscovery(), Root: &newStore}
@@ -12448,6 +13204,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.DesktopTokensStore = &TimerLayerDesktopTokensStore{DesktopTokensStore: childStore.DesktopTokens(), Root: &newStore}
 	newStore.DraftStore = &TimerLayerDraftStore{DraftStore: childStore.Draft(), Root: &newStore}
 	newStore.EmojiStore = &TimerLayerEmojiStore{EmojiStore: childStore.Emoji(), Root: &newStore}
+	newStore.EscalationPolicyStore = &TimerLayerEscalationPolicyStore{EscalationPolicyStore: childStore.EscalationPolicy(), Root: &newStore}
 	newStore.FileInfoStore = &TimerLayerFileInfoStore{FileInfoStore: childStore.FileInfo(), Root: &newStore}
 	newStore.GroupStore = &TimerLayerGroupStore{GroupStore: childStore.Group(), Root: &newStore}
 	newStore.JobStore = &TimerLayerJobStore{JobStore: childStore.Job(), Root: &newStore}
@@ -12456,6 +13213,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.NotifyAdminStore = &TimerLayerNotifyAdminStore{NotifyAdminStore: childStore.NotifyAdmin(), Root: &newStore}
 	newStore.OAuthStore = &TimerLayerOAuthStore{OAuthStore: childStore.OAuth(), Root: &newStore}
 	newStore.OutgoingOAuthConnectionStore = &TimerLayerOutgoingOAuthConnectionStore{OutgoingOAuthConnectionStore: childStore.OutgoingOAuthConnection(), Root: &newStore}
+	newStore.PasswordHistoryStore = &TimerLayerPasswordHistoryStore{PasswordHistoryStore: childStore.PasswordHistory(), Root: &newStore}
 	newStore.PluginStore = &TimerLayerPluginStore{PluginStore: childStore.Plugin(), Root: &newStore}
 	newStore.PostStore = &TimerLayerPostStore{PostStore: childStore.Post(), Root: &newStore}
 	newStore.PostAcknowledgementStore = &TimerLayerPostAcknowledgementStore{PostAcknowledgementStore: childStore.PostAcknowledgement(), Root: &newStore}
@@ -12470,6 +13228,7 @@ func New(childStore store.Store, metrics einterfaces.MetricsInterface) *TimerLay
 	newStore.ScheduledPostStore = &TimerLayerScheduledPostStore{ScheduledPostStore: childStore.ScheduledPost(), Root: &newStore}
 	newStore.SchemeStore = &TimerLayerSchemeStore{SchemeStore: childStore.Scheme(), Root: &newStore}
 	newStore.SessionStore = &TimerLayerSessionStore{SessionStore: childStore.Session(), Root: &newStore}
+	newStore.SessionActivityStore = &TimerLayerSessionActivityStore{SessionActivityStore: childStore.SessionActivity(), Root: &newStore}
 	newStore.SharedChannelStore = &TimerLayerSharedChannelStore{SharedChannelStore: childStore.SharedChannel(), Root: &newStore}
 	newStore.StatusStore = &TimerLayerStatusStore{StatusStore: childStore.Status(), Root: &newStore}
 	newStore.SystemStore = &TimerLayerSystemStore{SystemStore: childStore.System(), Root: &newStore}
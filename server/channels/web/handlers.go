@@ -305,6 +305,7 @@ func (h Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 			c.Err = model.NewAppError("ServeHTTP", "api.context.token_provided.app_error", nil, "token="+token, http.StatusUnauthorized)
 		} else {
 			c.AppContext = c.AppContext.WithSession(session)
+			c.App.RecordSessionActivity(c.AppContext, session, c.AppContext.IPAddress(), r.UserAgent())
 		}
 
 		// Rate limit by UserID
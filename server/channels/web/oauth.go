@@ -28,6 +28,14 @@ func (w *Web) InitOAuth() {
 	w.MainRouter.Handle("/oauth/authorize", w.APISessionRequired(authorizeOAuthApp)).Methods(http.MethodPost)
 	w.MainRouter.Handle("/oauth/deauthorize", w.APISessionRequired(deauthorizeOAuthApp)).Methods(http.MethodPost)
 	w.MainRouter.Handle("/oauth/access_token", w.APIHandlerTrustRequester(getAccessToken)).Methods(http.MethodPost)
+	w.MainRouter.Handle("/oauth/device_authorization", w.APIHandlerTrustRequester(createDeviceAuthorization)).Methods(http.MethodPost)
+	w.MainRouter.Handle("/oauth/device", w.APIHandlerTrustRequester(authorizeDevicePage)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/oauth/device", w.APISessionRequired(authorizeDevice)).Methods(http.MethodPost)
+
+	// API version independent OpenID Connect provider endpoints
+	w.MainRouter.Handle("/.well-known/openid-configuration", w.APIHandlerTrustRequester(getOIDCProviderMetadata)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/oauth/jwks", w.APIHandlerTrustRequester(getOIDCJSONWebKeySet)).Methods(http.MethodGet)
+	w.MainRouter.Handle("/oauth/userinfo", w.APISessionRequired(getOIDCUserInfo)).Methods(http.MethodGet, http.MethodPost)
 
 	// API version independent OAuth as a client endpoints
 	w.MainRouter.Handle("/oauth/{service:[A-Za-z0-9]+}/complete", w.APIHandler(completeOAuth)).Methods(http.MethodGet)
@@ -117,11 +125,14 @@ func authorizeOAuthPage(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 
 	authRequest := &model.AuthorizeRequest{
-		ResponseType: r.URL.Query().Get("response_type"),
-		ClientId:     r.URL.Query().Get("client_id"),
-		RedirectURI:  r.URL.Query().Get("redirect_uri"),
-		Scope:        r.URL.Query().Get("scope"),
-		State:        r.URL.Query().Get("state"),
+		ResponseType:        r.URL.Query().Get("response_type"),
+		ClientId:            r.URL.Query().Get("client_id"),
+		RedirectURI:         r.URL.Query().Get("redirect_uri"),
+		Scope:               r.URL.Query().Get("scope"),
+		State:               r.URL.Query().Get("state"),
+		CodeChallenge:       r.URL.Query().Get("code_challenge"),
+		CodeChallengeMethod: r.URL.Query().Get("code_challenge_method"),
+		Nonce:               r.URL.Query().Get("nonce"),
 	}
 
 	loginHint := r.URL.Query().Get("login_hint")
@@ -171,9 +182,10 @@ func authorizeOAuthPage(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	isAuthorized := false
 
-	if _, err := c.App.GetPreferenceByCategoryAndNameForUser(c.AppContext, c.AppContext.Session().UserId, model.PreferenceCategoryAuthorizedOAuthApp, authRequest.ClientId); err == nil {
-		// when we support scopes we should check if the scopes match
-		isAuthorized = true
+	if preference, err := c.App.GetPreferenceByCategoryAndNameForUser(c.AppContext, c.AppContext.Session().UserId, model.PreferenceCategoryAuthorizedOAuthApp, authRequest.ClientId); err == nil {
+		// The user must authorize the app again when it requests different scopes.
+		scope, ok := oauthApp.GetGrantedScope(authRequest.Scope)
+		isAuthorized = ok && (len(oauthApp.Scopes) == 0 || preference.Value == scope)
 	}
 
 	// Automatically allow if the app is trusted
@@ -211,6 +223,7 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 
 	code := r.FormValue("code")
 	refreshToken := r.FormValue("refresh_token")
+	deviceCode := r.FormValue("device_code")
 
 	grantType := r.FormValue("grant_type")
 	switch grantType {
@@ -224,23 +237,24 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 			c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.missing_refresh_token.app_error", nil, "", http.StatusBadRequest)
 			return
 		}
+	case model.ClientCredentialsGrantType:
+	case model.DeviceCodeGrantType:
+		if deviceCode == "" {
+			c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.missing_device_code.app_error", nil, "", http.StatusBadRequest)
+			return
+		}
 	default:
 		c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.bad_grant.app_error", nil, "", http.StatusBadRequest)
 		return
 	}
 
-	clientId := r.FormValue("client_id")
+	// The secret is checked when authenticating the client, since public clients don't have one.
+	clientId, secret := getOAuthClientCredentials(r)
 	if !model.IsValidId(clientId) {
 		c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.bad_client_id.app_error", nil, "", http.StatusBadRequest)
 		return
 	}
 
-	secret := r.FormValue("client_secret")
-	if secret == "" {
-		c.Err = model.NewAppError("getAccessToken", "api.oauth.get_access_token.bad_client_secret.app_error", nil, "", http.StatusBadRequest)
-		return
-	}
-
 	redirectURI := r.FormValue("redirect_uri")
 
 	auditRec := c.MakeAuditRecord("getAccessToken", audit.Fail)
@@ -249,8 +263,22 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	auditRec.AddMeta("client_id", clientId)
 	c.LogAudit("attempt")
 
-	accessRsp, err := c.App.GetOAuthAccessTokenForCodeFlow(c.AppContext, clientId, grantType, redirectURI, code, secret, refreshToken)
+	var accessRsp *model.AccessResponse
+	var err *model.AppError
+	switch grantType {
+	case model.ClientCredentialsGrantType:
+		accessRsp, err = c.App.GetOAuthAccessTokenForClientCredentials(c.AppContext, clientId, secret, r.FormValue("scope"))
+	case model.DeviceCodeGrantType:
+		accessRsp, err = c.App.GetOAuthAccessTokenForDeviceFlow(c.AppContext, clientId, secret, deviceCode)
+	default:
+		accessRsp, err = c.App.GetOAuthAccessTokenForCodeFlow(c.AppContext, clientId, grantType, redirectURI, code, secret, refreshToken, r.FormValue("code_verifier"))
+	}
 	if err != nil {
+		// Devices polling for their token expect the error responses of RFC 8628.
+		if errorCode, ok := oauthDeviceErrorCodes[err.Id]; ok {
+			writeOAuthError(c, w, errorCode, err)
+			return
+		}
 		c.Err = err
 		return
 	}
@@ -267,6 +295,136 @@ func getAccessToken(c *Context, w http.ResponseWriter, r *http.Request) {
 	}
 }
 
+func createDeviceAuthorization(c *Context, w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		c.Err = model.NewAppError("createDeviceAuthorization", "api.oauth.get_access_token.bad_request.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	clientId, secret := getOAuthClientCredentials(r)
+	if !model.IsValidId(clientId) {
+		c.Err = model.NewAppError("createDeviceAuthorization", "api.oauth.get_access_token.bad_client_id.app_error", nil, "", http.StatusBadRequest)
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("createDeviceAuthorization", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("client_id", clientId)
+	c.LogAudit("attempt")
+
+	deviceRsp, err := c.App.CreateOAuthDeviceAuthorization(clientId, secret, r.FormValue("scope"))
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Pragma", "no-cache")
+
+	auditRec.Success()
+	c.LogAudit("success")
+
+	if err := json.NewEncoder(w).Encode(deviceRsp); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+func authorizeDevicePage(c *Context, w http.ResponseWriter, r *http.Request) {
+	if !*c.App.Config().ServiceSettings.EnableOAuthServiceProvider {
+		err := model.NewAppError("authorizeDevicePage", "api.oauth.authorize_oauth.disabled.app_error", nil, "", http.StatusNotImplemented)
+		utils.RenderWebAppError(c.App.Config(), w, r, err, c.App.AsymmetricSigningKey())
+		return
+	}
+
+	if c.AppContext.Session().UserId == "" {
+		http.Redirect(w, r, c.GetSiteURLHeader()+"/login?redirect_to="+url.QueryEscape(r.RequestURI), http.StatusFound)
+		return
+	}
+
+	w.Header().Set("X-Frame-Options", "SAMEORIGIN")
+	w.Header().Set("Content-Security-Policy", fmt.Sprintf("frame-ancestors %s", frameAncestors))
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-cache, max-age=31556926")
+
+	staticDir, _ := fileutils.FindDir(model.ClientDir)
+	http.ServeFile(w, r, filepath.Join(staticDir, "root.html"))
+}
+
+func authorizeDevice(c *Context, w http.ResponseWriter, r *http.Request) {
+	var authorizeRequest *model.OAuthDeviceAuthorizeRequest
+	err := json.NewDecoder(r.Body).Decode(&authorizeRequest)
+	if err != nil || authorizeRequest == nil {
+		c.SetInvalidParamWithErr("authorize_request", err)
+		return
+	}
+
+	if authorizeRequest.UserCode == "" {
+		c.SetInvalidParam("user_code")
+		return
+	}
+
+	if c.AppContext.Session().IsOAuth {
+		c.SetPermissionError(model.PermissionEditOtherUsers)
+		c.Err.DetailedError += ", attempted access by oauth app"
+		return
+	}
+
+	auditRec := c.MakeAuditRecord("authorizeDevice", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("approve", authorizeRequest.Approve)
+	c.LogAudit("attempt")
+
+	if appErr := c.App.AuthorizeOAuthDevice(c.AppContext, c.AppContext.Session().UserId, authorizeRequest.UserCode, authorizeRequest.Approve); appErr != nil {
+		c.Err = appErr
+		return
+	}
+
+	auditRec.Success()
+	c.LogAudit("success")
+
+	ReturnStatusOK(w)
+}
+
+// getOAuthClientCredentials returns the credentials of the client from the request body, or
+// from its basic authentication header as allowed by RFC 6749.
+func getOAuthClientCredentials(r *http.Request) (string, string) {
+	clientId := r.FormValue("client_id")
+	secret := r.FormValue("client_secret")
+	if clientId != "" {
+		return clientId, secret
+	}
+
+	basicId, basicSecret, ok := r.BasicAuth()
+	if !ok {
+		return "", ""
+	}
+
+	// The credentials are form encoded before being encoded in the header.
+	clientId, _ = url.QueryUnescape(basicId)
+	secret, _ = url.QueryUnescape(basicSecret)
+	return clientId, secret
+}
+
+var oauthDeviceErrorCodes = map[string]string{
+	"api.oauth.get_access_token." + model.OAuthErrorAuthorizationPending + ".app_error": model.OAuthErrorAuthorizationPending,
+	"api.oauth.get_access_token." + model.OAuthErrorSlowDown + ".app_error":             model.OAuthErrorSlowDown,
+	"api.oauth.get_access_token." + model.OAuthErrorAccessDenied + ".app_error":         model.OAuthErrorAccessDenied,
+	"api.oauth.get_access_token." + model.OAuthErrorExpiredToken + ".app_error":         model.OAuthErrorExpiredToken,
+}
+
+func writeOAuthError(c *Context, w http.ResponseWriter, errorCode string, err *model.AppError) {
+	err.Translate(c.AppContext.T)
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(err.StatusCode)
+
+	if err := json.NewEncoder(w).Encode(map[string]string{"error": errorCode, "error_description": err.Message}); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
 func completeOAuth(c *Context, w http.ResponseWriter, r *http.Request) {
 	c.RequireService()
 	if c.Err != nil {
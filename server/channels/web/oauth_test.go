@@ -394,6 +394,180 @@ func TestOAuthAccessToken(t *testing.T) {
 	apiClient.ClearOAuthToken()
 }
 
+func TestOAuthAccessTokenPublicClientGrants(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	th := Setup(t).InitBasic()
+	th.Login(apiClient, th.BasicUser)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "TestApp5" + model.NewId(),
+		Homepage:     "https://nowhere.com",
+		CallbackUrls: []string{"https://nowhere.com"},
+		CreatorId:    th.SystemAdminUser.Id,
+		IsPublic:     true,
+	})
+	require.Nil(t, appErr)
+
+	t.Run("authorization code with PKCE", func(t *testing.T) {
+		// The example of RFC 7636 appendix B.
+		verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+		redirect, _, err := apiClient.AuthorizeOAuthApp(context.Background(), &model.AuthorizeRequest{
+			ResponseType:        model.AuthCodeResponseType,
+			ClientId:            oauthApp.Id,
+			RedirectURI:         oauthApp.CallbackUrls[0],
+			State:               "123",
+			CodeChallenge:       "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM",
+			CodeChallengeMethod: model.PKCECodeChallengeMethodS256,
+		})
+		require.NoError(t, err)
+		rurl, err := url.Parse(redirect)
+		require.NoError(t, err)
+
+		data := url.Values{"grant_type": []string{model.AccessTokenGrantType}, "client_id": []string{oauthApp.Id}, "code": []string{rurl.Query().Get("code")}, "redirect_uri": []string{oauthApp.CallbackUrls[0]}}
+		_, _, err = apiClient.GetOAuthAccessToken(context.Background(), data)
+		require.Error(t, err, "should have failed - missing code verifier")
+
+		data.Set("code_verifier", verifier)
+		rsp, _, err := apiClient.GetOAuthAccessToken(context.Background(), data)
+		require.NoError(t, err)
+		require.NotEmpty(t, rsp.AccessToken)
+	})
+
+	t.Run("device authorization", func(t *testing.T) {
+		deviceRsp, _, err := apiClient.CreateOAuthDeviceAuthorization(context.Background(), url.Values{"client_id": []string{oauthApp.Id}})
+		require.NoError(t, err)
+		require.NotEmpty(t, deviceRsp.DeviceCode)
+		require.NotEmpty(t, deviceRsp.UserCode)
+
+		data := url.Values{"grant_type": []string{model.DeviceCodeGrantType}, "client_id": []string{oauthApp.Id}, "device_code": []string{deviceRsp.DeviceCode}}
+		resp, err := http.PostForm(apiClient.URL+"/oauth/access_token", data)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode)
+		var oauthErr map[string]string
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&oauthErr))
+		require.Equal(t, model.OAuthErrorAuthorizationPending, oauthErr["error"])
+
+		_, err = apiClient.AuthorizeOAuthDevice(context.Background(), &model.OAuthDeviceAuthorizeRequest{UserCode: deviceRsp.UserCode, Approve: true})
+		require.NoError(t, err)
+
+		rsp, _, err := apiClient.GetOAuthAccessToken(context.Background(), data)
+		require.NoError(t, err)
+		require.NotEmpty(t, rsp.AccessToken)
+		require.NotEmpty(t, rsp.RefreshToken)
+	})
+
+	t.Run("client credentials", func(t *testing.T) {
+		bot, appErr := th.App.CreateBot(th.Context, &model.Bot{Username: "bot" + model.NewRandomString(10), OwnerId: th.BasicUser.Id})
+		require.Nil(t, appErr)
+
+		confidentialApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+			Name:         "TestApp5" + model.NewId(),
+			Homepage:     "https://nowhere.com",
+			CallbackUrls: []string{"https://nowhere.com"},
+			CreatorId:    th.BasicUser.Id,
+			BotUserId:    bot.UserId,
+		})
+		require.Nil(t, appErr)
+
+		// Public clients can't use the client credentials grant.
+		data := url.Values{"grant_type": []string{model.ClientCredentialsGrantType}, "client_id": []string{oauthApp.Id}}
+		_, _, err := apiClient.GetOAuthAccessToken(context.Background(), data)
+		require.Error(t, err)
+
+		req, err := http.NewRequest(http.MethodPost, apiClient.URL+"/oauth/access_token", strings.NewReader(url.Values{"grant_type": []string{model.ClientCredentialsGrantType}}.Encode()))
+		require.NoError(t, err)
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.SetBasicAuth(confidentialApp.Id, confidentialApp.ClientSecret)
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+
+		var rsp model.AccessResponse
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(&rsp))
+		require.NotEmpty(t, rsp.AccessToken)
+		require.Empty(t, rsp.RefreshToken)
+
+		botClient := model.NewAPIv4Client(apiClient.URL)
+		botClient.SetOAuthToken(rsp.AccessToken)
+		me, _, err := botClient.GetMe(context.Background(), "")
+		require.NoError(t, err)
+		require.Equal(t, bot.UserId, me.Id)
+	})
+}
+
+func TestOIDCProvider(t *testing.T) {
+	if testing.Short() {
+		t.SkipNow()
+	}
+
+	th := Setup(t).InitBasic()
+	th.Login(apiClient, th.BasicUser)
+	defer th.TearDown()
+
+	th.App.UpdateConfig(func(cfg *model.Config) { *cfg.ServiceSettings.EnableOAuthServiceProvider = true })
+
+	oauthApp, appErr := th.App.CreateOAuthApp(&model.OAuthApp{
+		Name:         "TestApp5" + model.NewId(),
+		Homepage:     "https://nowhere.com",
+		CallbackUrls: []string{"https://nowhere.com"},
+		CreatorId:    th.SystemAdminUser.Id,
+	})
+	require.Nil(t, appErr)
+
+	getJSON := func(t *testing.T, path, token string, v any) {
+		t.Helper()
+		req, err := http.NewRequest(http.MethodGet, apiClient.URL+path, nil)
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set(model.HeaderAuth, model.HeaderBearer+" "+token)
+		}
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		require.Equal(t, http.StatusOK, resp.StatusCode)
+		require.NoError(t, json.NewDecoder(resp.Body).Decode(v))
+	}
+
+	var metadata model.OIDCProviderMetadata
+	getJSON(t, "/.well-known/openid-configuration", "", &metadata)
+	assert.Equal(t, metadata.Issuer+"/oauth/jwks", metadata.JWKSURI)
+	assert.Contains(t, metadata.ScopesSupported, model.OIDCScopeOpenID)
+
+	redirect, _, err := apiClient.AuthorizeOAuthApp(context.Background(), &model.AuthorizeRequest{
+		ResponseType: model.AuthCodeResponseType,
+		ClientId:     oauthApp.Id,
+		RedirectURI:  oauthApp.CallbackUrls[0],
+		State:        "123",
+		Scope:        "openid profile",
+		Nonce:        model.NewId(),
+	})
+	require.NoError(t, err)
+	rurl, err := url.Parse(redirect)
+	require.NoError(t, err)
+
+	data := url.Values{"grant_type": []string{model.AccessTokenGrantType}, "client_id": []string{oauthApp.Id}, "client_secret": []string{oauthApp.ClientSecret}, "code": []string{rurl.Query().Get("code")}, "redirect_uri": []string{oauthApp.CallbackUrls[0]}}
+	rsp, _, err := apiClient.GetOAuthAccessToken(context.Background(), data)
+	require.NoError(t, err)
+	require.NotEmpty(t, rsp.IdToken)
+
+	var keySet model.JSONWebKeySet
+	getJSON(t, "/oauth/jwks", "", &keySet)
+	require.Len(t, keySet.Keys, 1)
+
+	var userInfo model.OIDCUserInfo
+	getJSON(t, "/oauth/userinfo", rsp.AccessToken, &userInfo)
+	assert.Equal(t, th.BasicUser.Id, userInfo.Subject)
+	assert.Equal(t, th.BasicUser.Username, userInfo.PreferredUsername)
+}
+
 func TestMobileLoginWithOAuth(t *testing.T) {
 	th := Setup(t).InitBasic()
 	defer th.TearDown()
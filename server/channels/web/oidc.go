@@ -0,0 +1,60 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// The discovery document and the signing keys are public, and fetched by clients running in
+// browsers on other origins.
+func getOIDCProviderMetadata(c *Context, w http.ResponseWriter, r *http.Request) {
+	metadata, err := c.App.GetOIDCProviderMetadata()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+
+	if err := json.NewEncoder(w).Encode(metadata); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+func getOIDCJSONWebKeySet(c *Context, w http.ResponseWriter, r *http.Request) {
+	keySet, err := c.App.GetOIDCJSONWebKeySet()
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Access-Control-Allow-Origin", "*")
+	// Clients fetch the keys again when an ID token is signed with a key they don't know yet.
+	w.Header().Set("Cache-Control", "public, max-age=3600")
+
+	if err := json.NewEncoder(w).Encode(keySet); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+func getOIDCUserInfo(c *Context, w http.ResponseWriter, r *http.Request) {
+	userInfo, err := c.App.GetOIDCUserInfo(c.AppContext.Session())
+	if err != nil {
+		c.Err = err
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+
+	if err := json.NewEncoder(w).Encode(userInfo); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
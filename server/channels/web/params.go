@@ -273,6 +273,10 @@ func ParamsFromRequest(r *http.Request) *Params {
 		switch val {
 		case "custom":
 			params.GroupSource = model.GroupSourceCustom
+		case "oidc":
+			params.GroupSource = model.GroupSourceOIDC
+		case "scim":
+			params.GroupSource = model.GroupSourceScim
 		default:
 			params.GroupSource = model.GroupSourceLdap
 		}
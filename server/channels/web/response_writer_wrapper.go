@@ -60,3 +60,8 @@ func (rw *responseWriterWrapper) Flush() {
 		rw.flusher.Flush()
 	}
 }
+
+// Unwrap allows http.ResponseController to reach the original ResponseWriter.
+func (rw *responseWriterWrapper) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
@@ -150,6 +150,11 @@ func completeSaml(c *Context, w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Failing to synchronize the attribute groups doesn't prevent logging in.
+	if appErr := c.App.SyncSamlUserAttributeGroups(c.AppContext, user, encodedXML); appErr != nil {
+		c.Logger.Warn("Failed to synchronize the attribute groups of the SAML user", mlog.String("user_id", user.Id), mlog.Err(appErr))
+	}
+
 	switch action {
 	case model.OAuthActionSignup:
 		if teamId := relayProps["team_id"]; teamId != "" {
@@ -0,0 +1,388 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package web
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+	"github.com/mattermost/mattermost/server/v8/channels/audit"
+)
+
+// InitScim registers the SCIM 2.0 endpoints identity providers provision users and groups
+// through. Requests are authenticated with the personal access token of a system admin.
+func (w *Web) InitScim() {
+	scim := w.MainRouter.PathPrefix("/scim/v2").Subrouter()
+
+	scim.Handle("/ServiceProviderConfig", w.APISessionRequired(scimHandler(getScimServiceProviderConfig))).Methods(http.MethodGet)
+	scim.Handle("/ResourceTypes", w.APISessionRequired(scimHandler(getScimResourceTypes))).Methods(http.MethodGet)
+
+	scim.Handle("/Users", w.APISessionRequired(scimHandler(getScimUsers))).Methods(http.MethodGet)
+	scim.Handle("/Users", w.APISessionRequired(scimHandler(createScimUser))).Methods(http.MethodPost)
+	scim.Handle("/Users/{user_id}", w.APISessionRequired(scimHandler(getScimUser))).Methods(http.MethodGet)
+	scim.Handle("/Users/{user_id}", w.APISessionRequired(scimHandler(replaceScimUser))).Methods(http.MethodPut)
+	scim.Handle("/Users/{user_id}", w.APISessionRequired(scimHandler(patchScimUser))).Methods(http.MethodPatch)
+	scim.Handle("/Users/{user_id}", w.APISessionRequired(scimHandler(deleteScimUser))).Methods(http.MethodDelete)
+
+	scim.Handle("/Groups", w.APISessionRequired(scimHandler(getScimGroups))).Methods(http.MethodGet)
+	scim.Handle("/Groups", w.APISessionRequired(scimHandler(createScimGroup))).Methods(http.MethodPost)
+	scim.Handle("/Groups/{group_id}", w.APISessionRequired(scimHandler(getScimGroup))).Methods(http.MethodGet)
+	scim.Handle("/Groups/{group_id}", w.APISessionRequired(scimHandler(replaceScimGroup))).Methods(http.MethodPut)
+	scim.Handle("/Groups/{group_id}", w.APISessionRequired(scimHandler(patchScimGroup))).Methods(http.MethodPatch)
+	scim.Handle("/Groups/{group_id}", w.APISessionRequired(scimHandler(deleteScimGroup))).Methods(http.MethodDelete)
+}
+
+// scimHandler checks the permissions of the requester, and writes the errors returned by the
+// handler in the format of SCIM clients.
+func scimHandler(h func(*Context, http.ResponseWriter, *http.Request) *model.AppError) func(*Context, http.ResponseWriter, *http.Request) {
+	return func(c *Context, w http.ResponseWriter, r *http.Request) {
+		if !c.App.SessionHasPermissionTo(*c.AppContext.Session(), model.PermissionManageSystem) {
+			c.SetPermissionError(model.PermissionManageSystem)
+			return
+		}
+
+		var appErr *model.AppError
+		if !*c.App.Config().ServiceSettings.EnableScimProvisioning {
+			appErr = model.NewAppError("scimHandler", "api.scim.disabled.app_error", nil, "", http.StatusNotImplemented)
+		} else {
+			appErr = h(c, w, r)
+		}
+		if appErr != nil {
+			c.LogErrorByCode(appErr)
+			writeScimError(c, w, appErr)
+		}
+	}
+}
+
+func writeScimError(c *Context, w http.ResponseWriter, appErr *model.AppError) {
+	status := appErr.StatusCode
+	scimType := ""
+	switch {
+	case appErr.Id == "api.scim.invalid_filter.app_error":
+		scimType = model.ScimErrorTypeInvalidFilter
+	case appErr.Id == "api.scim.invalid_body.app_error":
+		scimType = model.ScimErrorTypeInvalidSyntax
+	case appErr.Id == "model.scim_patch.is_valid.path.app_error":
+		scimType = model.ScimErrorTypeInvalidPath
+	case appErr.Id == "app.user.save.username_exists.app_error",
+		appErr.Id == "app.user.save.email_exists.app_error",
+		appErr.Id == "app.user.update_auth_data.email_exists.app_error",
+		appErr.Id == "app.scim.group_exists.app_error":
+		status = http.StatusConflict
+		scimType = model.ScimErrorTypeUniqueness
+	case status == http.StatusBadRequest:
+		scimType = model.ScimErrorTypeInvalidValue
+	}
+
+	appErr.Translate(c.AppContext.T)
+	writeScimResponse(c, w, status, model.NewScimError(status, scimType, appErr.Message))
+}
+
+func writeScimResponse(c *Context, w http.ResponseWriter, status int, response any) {
+	w.Header().Set("Content-Type", model.ScimContentType)
+	w.WriteHeader(status)
+
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		c.Logger.Warn("Error writing response", mlog.Err(err))
+	}
+}
+
+func decodeScimBody(r *http.Request, v any) *model.AppError {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return model.NewAppError("decodeScimBody", "api.scim.invalid_body.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+	return nil
+}
+
+// getScimPage returns the 1-based index of the first resource and the number of resources
+// requested.
+func getScimPage(r *http.Request) (int, int) {
+	query := r.URL.Query()
+
+	startIndex, err := strconv.Atoi(query.Get("startIndex"))
+	if err != nil || startIndex < 1 {
+		startIndex = 1
+	}
+
+	count, err := strconv.Atoi(query.Get("count"))
+	if err != nil {
+		count = model.ScimDefaultCount
+	}
+	count = max(1, min(count, model.ScimMaxCount))
+
+	return startIndex, count
+}
+
+// scimGroupMembersExcluded returns whether the requester asked for groups without their
+// members, which are expensive to look up for large groups.
+func scimGroupMembersExcluded(r *http.Request) bool {
+	for _, attribute := range strings.Split(r.URL.Query().Get("excludedAttributes"), ",") {
+		if strings.EqualFold(strings.TrimSpace(attribute), "members") {
+			return true
+		}
+	}
+	return false
+}
+
+func getScimServiceProviderConfig(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	writeScimResponse(c, w, http.StatusOK, model.NewScimServiceProviderConfig(c.App.GetScimBaseURL()))
+	return nil
+}
+
+func getScimResourceTypes(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	resourceTypes := model.NewScimResourceTypes(c.App.GetScimBaseURL())
+	writeScimResponse(c, w, http.StatusOK, &model.ScimListResponse{
+		Schemas:      []string{model.ScimSchemaListResponse},
+		TotalResults: len(resourceTypes),
+		StartIndex:   1,
+		ItemsPerPage: len(resourceTypes),
+		Resources:    resourceTypes,
+	})
+	return nil
+}
+
+func getScimUsers(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	startIndex, count := getScimPage(r)
+
+	users, appErr := c.App.GetScimUsers(r.URL.Query().Get("filter"), startIndex, count)
+	if appErr != nil {
+		return appErr
+	}
+
+	writeScimResponse(c, w, http.StatusOK, users)
+	return nil
+}
+
+func getScimUser(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	if !model.IsValidId(c.Params.UserId) {
+		return model.NewAppError("getScimUser", "app.scim.user_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	user, appErr := c.App.GetScimUser(c.Params.UserId)
+	if appErr != nil {
+		return appErr
+	}
+
+	writeScimResponse(c, w, http.StatusOK, user)
+	return nil
+}
+
+func createScimUser(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	var scimUser model.ScimUser
+	if appErr := decodeScimBody(r, &scimUser); appErr != nil {
+		return appErr
+	}
+
+	auditRec := c.MakeAuditRecord("createScimUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_name", scimUser.UserName)
+
+	user, appErr := c.App.CreateScimUser(c.AppContext, &scimUser)
+	if appErr != nil {
+		return appErr
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("user_id", user.Id)
+
+	w.Header().Set("Location", user.Meta.Location)
+	writeScimResponse(c, w, http.StatusCreated, user)
+	return nil
+}
+
+func replaceScimUser(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	if !model.IsValidId(c.Params.UserId) {
+		return model.NewAppError("replaceScimUser", "app.scim.user_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	var scimUser model.ScimUser
+	if appErr := decodeScimBody(r, &scimUser); appErr != nil {
+		return appErr
+	}
+
+	auditRec := c.MakeAuditRecord("replaceScimUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	user, appErr := c.App.ReplaceScimUser(c.AppContext, c.Params.UserId, &scimUser)
+	if appErr != nil {
+		return appErr
+	}
+
+	auditRec.Success()
+
+	writeScimResponse(c, w, http.StatusOK, user)
+	return nil
+}
+
+func patchScimUser(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	if !model.IsValidId(c.Params.UserId) {
+		return model.NewAppError("patchScimUser", "app.scim.user_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	var patch model.ScimPatchRequest
+	if appErr := decodeScimBody(r, &patch); appErr != nil {
+		return appErr
+	}
+
+	auditRec := c.MakeAuditRecord("patchScimUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	user, appErr := c.App.PatchScimUser(c.AppContext, c.Params.UserId, &patch)
+	if appErr != nil {
+		return appErr
+	}
+
+	auditRec.Success()
+
+	writeScimResponse(c, w, http.StatusOK, user)
+	return nil
+}
+
+func deleteScimUser(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	if !model.IsValidId(c.Params.UserId) {
+		return model.NewAppError("deleteScimUser", "app.scim.user_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	auditRec := c.MakeAuditRecord("deleteScimUser", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("user_id", c.Params.UserId)
+
+	if appErr := c.App.DeleteScimUser(c.AppContext, c.Params.UserId); appErr != nil {
+		return appErr
+	}
+
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func getScimGroups(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	startIndex, count := getScimPage(r)
+
+	groups, appErr := c.App.GetScimGroups(r.URL.Query().Get("filter"), startIndex, count, !scimGroupMembersExcluded(r))
+	if appErr != nil {
+		return appErr
+	}
+
+	writeScimResponse(c, w, http.StatusOK, groups)
+	return nil
+}
+
+func getScimGroup(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	if !model.IsValidId(c.Params.GroupId) {
+		return model.NewAppError("getScimGroup", "app.scim.group_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	group, appErr := c.App.GetScimGroup(c.Params.GroupId, !scimGroupMembersExcluded(r))
+	if appErr != nil {
+		return appErr
+	}
+
+	writeScimResponse(c, w, http.StatusOK, group)
+	return nil
+}
+
+func createScimGroup(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	var scimGroup model.ScimGroup
+	if appErr := decodeScimBody(r, &scimGroup); appErr != nil {
+		return appErr
+	}
+
+	auditRec := c.MakeAuditRecord("createScimGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("display_name", scimGroup.DisplayName)
+
+	group, appErr := c.App.CreateScimGroup(c.AppContext, &scimGroup)
+	if appErr != nil {
+		return appErr
+	}
+
+	auditRec.Success()
+	auditRec.AddMeta("group_id", group.Id)
+
+	w.Header().Set("Location", group.Meta.Location)
+	writeScimResponse(c, w, http.StatusCreated, group)
+	return nil
+}
+
+func replaceScimGroup(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	if !model.IsValidId(c.Params.GroupId) {
+		return model.NewAppError("replaceScimGroup", "app.scim.group_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	var scimGroup model.ScimGroup
+	if appErr := decodeScimBody(r, &scimGroup); appErr != nil {
+		return appErr
+	}
+
+	auditRec := c.MakeAuditRecord("replaceScimGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	group, appErr := c.App.ReplaceScimGroup(c.AppContext, c.Params.GroupId, &scimGroup)
+	if appErr != nil {
+		return appErr
+	}
+
+	auditRec.Success()
+
+	writeScimResponse(c, w, http.StatusOK, group)
+	return nil
+}
+
+func patchScimGroup(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	if !model.IsValidId(c.Params.GroupId) {
+		return model.NewAppError("patchScimGroup", "app.scim.group_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	var patch model.ScimPatchRequest
+	if appErr := decodeScimBody(r, &patch); appErr != nil {
+		return appErr
+	}
+
+	auditRec := c.MakeAuditRecord("patchScimGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	group, appErr := c.App.PatchScimGroup(c.AppContext, c.Params.GroupId, &patch)
+	if appErr != nil {
+		return appErr
+	}
+
+	auditRec.Success()
+
+	// Identity providers patch the members of large groups, and don't need them back.
+	if scimGroupMembersExcluded(r) {
+		group.Members = nil
+	}
+
+	writeScimResponse(c, w, http.StatusOK, group)
+	return nil
+}
+
+func deleteScimGroup(c *Context, w http.ResponseWriter, r *http.Request) *model.AppError {
+	if !model.IsValidId(c.Params.GroupId) {
+		return model.NewAppError("deleteScimGroup", "app.scim.group_not_found.app_error", nil, "", http.StatusNotFound)
+	}
+
+	auditRec := c.MakeAuditRecord("deleteScimGroup", audit.Fail)
+	defer c.LogAuditRec(auditRec)
+	auditRec.AddMeta("group_id", c.Params.GroupId)
+
+	if appErr := c.App.DeleteScimGroup(c.AppContext, c.Params.GroupId); appErr != nil {
+		return appErr
+	}
+
+	auditRec.Success()
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
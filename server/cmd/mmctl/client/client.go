@@ -59,6 +59,8 @@ type Client interface {
 	RemovePlugin(ctx context.Context, id string) (*model.Response, error)
 	EnablePlugin(ctx context.Context, id string) (*model.Response, error)
 	DisablePlugin(ctx context.Context, id string) (*model.Response, error)
+	ForceDisablePlugin(ctx context.Context, id string) (*model.Response, error)
+	ApprovePluginCapabilities(ctx context.Context, id string, capabilities []string) (*model.PluginInfo, *model.Response, error)
 	GetPlugins(ctx context.Context) (*model.PluginsResponse, *model.Response, error)
 	GetUser(ctx context.Context, userID, etag string) (*model.User, *model.Response, error)
 	GetUserByUsername(ctx context.Context, userName, etag string) (*model.User, *model.Response, error)
@@ -75,9 +77,11 @@ type Client interface {
 	SendPasswordResetEmail(ctx context.Context, email string) (*model.Response, error)
 	UpdateUser(ctx context.Context, user *model.User) (*model.User, *model.Response, error)
 	UpdateUserMfa(ctx context.Context, userID, code string, activate bool) (*model.Response, error)
+	UnlockUser(ctx context.Context, userID string) (*model.Response, error)
 	UpdateUserPassword(ctx context.Context, userID, currentPassword, newPassword string) (*model.Response, error)
 	UpdateUserHashedPassword(ctx context.Context, userID, newHashedPassword string) (*model.Response, error)
 	CreateUserAccessToken(ctx context.Context, userID, description string) (*model.UserAccessToken, *model.Response, error)
+	CreateUserAccessTokenWithOptions(ctx context.Context, userID string, token *model.UserAccessToken) (*model.UserAccessToken, *model.Response, error)
 	RevokeUserAccessToken(ctx context.Context, tokenID string) (*model.Response, error)
 	GetUserAccessTokensForUser(ctx context.Context, userID string, page, perPage int) ([]*model.UserAccessToken, *model.Response, error)
 	ConvertUserToBot(ctx context.Context, userID string) (*model.Bot, *model.Response, error)
@@ -117,6 +121,9 @@ type Client interface {
 	InstallPluginFromURL(context.Context, string, bool) (*model.Manifest, *model.Response, error)
 	InstallMarketplacePlugin(context.Context, *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.Response, error)
 	GetMarketplacePlugins(context.Context, *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.Response, error)
+	ImportMarketplaceCatalog(ctx context.Context, catalog io.Reader, replace bool) ([]*model.BaseMarketplacePlugin, *model.Response, error)
+	GetMarketplaceCatalog(ctx context.Context) ([]*model.BaseMarketplacePlugin, *model.Response, error)
+	RemoveMarketplaceCatalogPlugin(ctx context.Context, pluginID, version string) (*model.Response, error)
 	MigrateAuthToLdap(ctx context.Context, fromAuthService string, matchField string, force bool) (*model.Response, error)
 	MigrateAuthToSaml(ctx context.Context, fromAuthService string, usersMap map[string]string, auto bool) (*model.Response, error)
 	GetPing(ctx context.Context) (string, *model.Response, error)
@@ -103,6 +103,7 @@ func init() {
 	ExportCreateCmd.Flags().Bool("include-archived-channels", false, "Include archived channels in the export file.")
 	ExportCreateCmd.Flags().Bool("include-profile-pictures", false, "Include profile pictures in the export file.")
 	ExportCreateCmd.Flags().Bool("no-roles-and-schemes", false, "Exclude roles and custom permission schemes from the export file.")
+	ExportCreateCmd.Flags().Bool("no-plugin-key-values", false, "Exclude the key value stores of plugins from the export file.")
 
 	ExportDownloadCmd.Flags().Bool("resume", false, "Set to true to resume an export download.")
 	_ = ExportDownloadCmd.Flags().MarkHidden("resume")
@@ -144,6 +145,11 @@ func exportCreateCmdF(c client.Client, command *cobra.Command, args []string) er
 		data["include_roles_and_schemes"] = "true"
 	}
 
+	excludePluginKeyValues, _ := command.Flags().GetBool("no-plugin-key-values")
+	if !excludePluginKeyValues {
+		data["include_plugin_key_values"] = "true"
+	}
+
 	includeArchivedChannels, _ := command.Flags().GetBool("include-archived-channels")
 	if includeArchivedChannels {
 		data["include_archived_channels"] = "true"
@@ -7,6 +7,9 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
+
+	"github.com/mattermost/mattermost/server/public/model"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
@@ -70,6 +73,15 @@ var PluginDisableCmd = &cobra.Command{
 	Args:    cobra.MinimumNArgs(1),
 }
 
+var PluginApproveCapabilitiesCmd = &cobra.Command{
+	Use:     "approve-capabilities [plugins]",
+	Short:   "Approve plugin capabilities",
+	Long:    "Approve the capabilities plugins declare in their manifest. Plugins declaring capabilities can only be enabled once all of them are approved.",
+	Example: `  plugin approve-capabilities hovercardexample pluginexample`,
+	RunE:    withClient(pluginApproveCapabilitiesCmdF),
+	Args:    cobra.MinimumNArgs(1),
+}
+
 var PluginListCmd = &cobra.Command{
 	Use:     "list",
 	Short:   "List plugins",
@@ -80,7 +92,9 @@ var PluginListCmd = &cobra.Command{
 
 func init() {
 	PluginAddCmd.Flags().BoolP("force", "f", false, "overwrite a previously installed plugin with the same ID, if any")
+	PluginAddCmd.Flags().Bool("approve-capabilities", false, "approve the capabilities declared by the added plugins")
 	PluginInstallURLCmd.Flags().BoolP("force", "f", false, "overwrite a previously installed plugin with the same ID, if any")
+	PluginDisableCmd.Flags().BoolP("force", "f", false, "disable the plugins even though other enabled plugins depend on them, disabling those as well")
 
 	PluginCmd.AddCommand(
 		PluginAddCmd,
@@ -88,6 +102,7 @@ func init() {
 		PluginDeleteCmd,
 		PluginEnableCmd,
 		PluginDisableCmd,
+		PluginApproveCapabilitiesCmd,
 		PluginListCmd,
 	)
 	RootCmd.AddCommand(PluginCmd)
@@ -95,6 +110,7 @@ func init() {
 
 func pluginAddCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
+	approve, _ := cmd.Flags().GetBool("approve-capabilities")
 	var multiErr *multierror.Error
 
 	for i, plugin := range args {
@@ -103,24 +119,46 @@ func pluginAddCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 			return err
 		}
 
+		var manifest *model.Manifest
 		if force {
-			_, _, err = c.UploadPluginForced(context.TODO(), fileReader)
+			manifest, _, err = c.UploadPluginForced(context.TODO(), fileReader)
 		} else {
-			_, _, err = c.UploadPlugin(context.TODO(), fileReader)
+			manifest, _, err = c.UploadPlugin(context.TODO(), fileReader)
 		}
+		fileReader.Close()
 
 		if err != nil {
 			printer.PrintError("Unable to add plugin: " + args[i] + ". Error: " + err.Error())
 			multiErr = multierror.Append(multiErr, err)
-		} else {
-			printer.Print("Added plugin: " + plugin)
+			continue
+		}
+		printer.Print("Added plugin: " + plugin)
+
+		if manifest == nil || len(manifest.Capabilities) == 0 {
+			continue
+		}
+		printer.Print("Plugin " + manifest.Id + " requests capabilities: " + strings.Join(manifest.Capabilities, ", "))
+		if !approve {
+			printer.Print("Approve them with: mmctl plugin approve-capabilities " + manifest.Id)
+			continue
+		}
+		if err := approvePluginCapabilities(c, manifest); err != nil {
+			multiErr = multierror.Append(multiErr, err)
 		}
-		fileReader.Close()
 	}
 
 	return multiErr.ErrorOrNil()
 }
 
+func approvePluginCapabilities(c client.Client, manifest *model.Manifest) error {
+	if _, _, err := c.ApprovePluginCapabilities(context.TODO(), manifest.Id, manifest.Capabilities); err != nil {
+		printer.PrintError("Unable to approve capabilities of plugin: " + manifest.Id + ". Error: " + err.Error())
+		return err
+	}
+	printer.Print("Approved capabilities of plugin: " + manifest.Id)
+	return nil
+}
+
 func pluginInstallURLCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	force, _ := cmd.Flags().GetBool("force")
 	var multiErr *multierror.Error
@@ -167,9 +205,15 @@ func pluginEnableCmdF(c client.Client, cmd *cobra.Command, args []string) error
 }
 
 func pluginDisableCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	force, _ := cmd.Flags().GetBool("force")
+	disablePlugin := c.DisablePlugin
+	if force {
+		disablePlugin = c.ForceDisablePlugin
+	}
+
 	var multiErr *multierror.Error
 	for _, plugin := range args {
-		if _, err := c.DisablePlugin(context.TODO(), plugin); err != nil {
+		if _, err := disablePlugin(context.TODO(), plugin); err != nil {
 			printer.PrintError("Unable to disable plugin: " + plugin + ". Error: " + err.Error())
 			multiErr = multierror.Append(multiErr, err)
 		} else {
@@ -180,6 +224,40 @@ func pluginDisableCmdF(c client.Client, cmd *cobra.Command, args []string) error
 	return multiErr.ErrorOrNil()
 }
 
+func pluginApproveCapabilitiesCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	pluginsResp, _, err := c.GetPlugins(context.TODO())
+	if err != nil {
+		return errors.New("Unable to list plugins. Error: " + err.Error())
+	}
+
+	manifests := map[string]*model.Manifest{}
+	for _, plugin := range append(pluginsResp.Active, pluginsResp.Inactive...) {
+		manifests[plugin.Id] = &plugin.Manifest
+	}
+
+	var multiErr *multierror.Error
+	for _, plugin := range args {
+		manifest, ok := manifests[plugin]
+		if !ok {
+			err := fmt.Errorf("plugin %s is not installed", plugin)
+			printer.PrintError("Unable to approve capabilities of plugin: " + plugin + ". Error: " + err.Error())
+			multiErr = multierror.Append(multiErr, err)
+			continue
+		}
+		if len(manifest.Capabilities) == 0 {
+			printer.Print("Plugin " + plugin + " does not declare capabilities")
+			continue
+		}
+
+		printer.Print("Plugin " + plugin + " requests capabilities: " + strings.Join(manifest.Capabilities, ", "))
+		if err := approvePluginCapabilities(c, manifest); err != nil {
+			multiErr = multierror.Append(multiErr, err)
+		}
+	}
+
+	return multiErr.ErrorOrNil()
+}
+
 func pluginListCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	pluginsResp, _, err := c.GetPlugins(context.TODO())
 	if err != nil {
@@ -4,7 +4,14 @@
 package commands
 
 import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
 	"context"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
 
 	"github.com/mattermost/mattermost/server/public/model"
 
@@ -48,7 +55,57 @@ var PluginMarketplaceListCmd = &cobra.Command{
 	RunE: withClient(pluginMarketplaceListCmdF),
 }
 
+var PluginMarketplaceCatalogCmd = &cobra.Command{
+	Use:   "catalog",
+	Short: "Management of the offline marketplace catalog",
+	Long:  "Manages the offline marketplace catalog, listing plugins in the marketplace of servers that can't reach the marketplace server when PluginSettings.EnableMarketplaceCatalog is set",
+}
+
+var PluginMarketplaceCatalogImportCmd = &cobra.Command{
+	Use:   "import <directory|bundle>",
+	Short: "Import plugins into the offline marketplace catalog",
+	Long:  "Imports the signed plugins of a catalog directory or tar.gz bundle into the offline marketplace catalog. Each plugin bundle must be alongside its signature (<bundle>.tar.gz.sig), and a metadata.json file may hold marketplace listings for the plugins",
+	Example: `  # You can import a directory of signed plugins
+  $ mmctl plugin marketplace catalog import ./catalog
+
+  # Or a bundle of them, replacing the plugins already in the catalog
+  $ mmctl plugin marketplace catalog import catalog.tar.gz --replace`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(pluginMarketplaceCatalogImportCmdF),
+}
+
+var PluginMarketplaceCatalogListCmd = &cobra.Command{
+	Use:     "list",
+	Short:   "List the plugins of the offline marketplace catalog",
+	Long:    "Lists every version of every plugin of the offline marketplace catalog",
+	Example: `  plugin marketplace catalog list`,
+	Args:    cobra.NoArgs,
+	RunE:    withClient(pluginMarketplaceCatalogListCmdF),
+}
+
+var PluginMarketplaceCatalogRemoveCmd = &cobra.Command{
+	Use:   "remove <id>",
+	Short: "Remove a plugin from the offline marketplace catalog",
+	Long:  "Removes every version of a plugin from the offline marketplace catalog, or only the given version",
+	Example: `  # You can remove every version of a plugin
+  $ mmctl plugin marketplace catalog remove jitsi
+
+  # Or only one of them
+  $ mmctl plugin marketplace catalog remove jitsi --version 2.0.0`,
+	Args: cobra.ExactArgs(1),
+	RunE: withClient(pluginMarketplaceCatalogRemoveCmdF),
+}
+
 func init() {
+	PluginMarketplaceCatalogImportCmd.Flags().Bool("replace", false, "Replace the plugins already in the catalog instead of adding to them")
+	PluginMarketplaceCatalogRemoveCmd.Flags().String("version", "", "Only remove this version of the plugin")
+
+	PluginMarketplaceCatalogCmd.AddCommand(
+		PluginMarketplaceCatalogImportCmd,
+		PluginMarketplaceCatalogListCmd,
+		PluginMarketplaceCatalogRemoveCmd,
+	)
+
 	PluginMarketplaceListCmd.Flags().Int("page", 0, "Page number to fetch for the list of users")
 	PluginMarketplaceListCmd.Flags().Int("per-page", DefaultPageSize, "Number of users to be fetched")
 	PluginMarketplaceListCmd.Flags().Bool("all", false, "Fetch all plugins. --page flag will be ignore if provided")
@@ -58,6 +115,7 @@ func init() {
 	PluginMarketplaceCmd.AddCommand(
 		PluginMarketplaceInstallCmd,
 		PluginMarketplaceListCmd,
+		PluginMarketplaceCatalogCmd,
 	)
 
 	PluginCmd.AddCommand(
@@ -118,3 +176,114 @@ func pluginMarketplaceListCmdF(c client.Client, cmd *cobra.Command, _ []string)
 
 	return nil
 }
+
+func pluginMarketplaceCatalogImportCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	replace, _ := cmd.Flags().GetBool("replace")
+
+	info, err := os.Stat(args[0])
+	if err != nil {
+		return err
+	}
+
+	var catalog io.Reader
+	if info.IsDir() {
+		buf, err := packCatalogDirectory(args[0])
+		if err != nil {
+			return errors.Wrap(err, "couldn't pack the catalog directory")
+		}
+		catalog = buf
+	} else {
+		file, err := os.Open(args[0])
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		catalog = file
+	}
+
+	plugins, _, err := c.ImportMarketplaceCatalog(context.TODO(), catalog, replace)
+	if err != nil {
+		return errors.Wrap(err, "couldn't import the marketplace catalog")
+	}
+
+	for _, plugin := range plugins {
+		printer.PrintT("Imported plugin {{.Manifest.Id}}: {{.Manifest.Name}}, Version: {{.Manifest.Version}}", plugin)
+	}
+
+	return nil
+}
+
+// packCatalogDirectory archives the files of a catalog directory into a catalog bundle.
+func packCatalogDirectory(dir string) (*bytes.Buffer, error) {
+	buf := &bytes.Buffer{}
+	gzw := gzip.NewWriter(buf)
+	tw := tar.NewWriter(gzw)
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || !d.Type().IsRegular() {
+			return err
+		}
+
+		name, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		header, err := tar.FileInfoHeader(info, "")
+		if err != nil {
+			return err
+		}
+		header.Name = filepath.ToSlash(name)
+		if err = tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gzw.Close(); err != nil {
+		return nil, err
+	}
+
+	return buf, nil
+}
+
+func pluginMarketplaceCatalogListCmdF(c client.Client, _ *cobra.Command, _ []string) error {
+	plugins, _, err := c.GetMarketplaceCatalog(context.TODO())
+	if err != nil {
+		return errors.Wrap(err, "Failed to fetch the marketplace catalog")
+	}
+
+	for _, plugin := range plugins {
+		printer.PrintT("{{.Manifest.Id}}: {{.Manifest.Name}}, Version: {{.Manifest.Version}}", plugin)
+	}
+
+	return nil
+}
+
+func pluginMarketplaceCatalogRemoveCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	version, _ := cmd.Flags().GetString("version")
+
+	if _, err := c.RemoveMarketplaceCatalogPlugin(context.TODO(), args[0], version); err != nil {
+		return errors.Wrap(err, "couldn't remove the plugin from the marketplace catalog")
+	}
+
+	printer.Print("Removed plugin " + args[0] + " from the marketplace catalog")
+
+	return nil
+}
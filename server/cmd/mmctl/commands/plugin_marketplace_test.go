@@ -4,8 +4,14 @@
 package commands
 
 import (
+	"archive/tar"
+	"compress/gzip"
 	"context"
+	"io"
+	"os"
+	"path/filepath"
 
+	"github.com/golang/mock/gomock"
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/pkg/errors"
 
@@ -166,3 +172,152 @@ func (s *MmctlUnitTestSuite) TestPluginMarketplaceListCmd() {
 		s.Require().Equal(mockPlugin, printer.GetLines()[0])
 	})
 }
+
+func (s *MmctlUnitTestSuite) TestPluginMarketplaceCatalogImportCmd() {
+	s.Run("Import a catalog directory", func() {
+		printer.Clean()
+
+		dir := s.T().TempDir()
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "jitsi.tar.gz"), []byte("bundle"), 0600))
+		s.Require().NoError(os.WriteFile(filepath.Join(dir, "jitsi.tar.gz.sig"), []byte("signature"), 0600))
+		plugin := &model.BaseMarketplacePlugin{Manifest: &model.Manifest{Id: "jitsi", Name: "Jitsi", Version: "2.0.0"}}
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("replace", true, "")
+
+		s.client.
+			EXPECT().
+			ImportMarketplaceCatalog(context.TODO(), gomock.Any(), true).
+			DoAndReturn(func(_ context.Context, catalog io.Reader, _ bool) ([]*model.BaseMarketplacePlugin, *model.Response, error) {
+				gzr, err := gzip.NewReader(catalog)
+				s.Require().NoError(err)
+				tr := tar.NewReader(gzr)
+				files := map[string]string{}
+				for {
+					header, err := tr.Next()
+					if err == io.EOF {
+						break
+					}
+					s.Require().NoError(err)
+					data, err := io.ReadAll(tr)
+					s.Require().NoError(err)
+					files[header.Name] = string(data)
+				}
+				s.Require().Equal(map[string]string{"jitsi.tar.gz": "bundle", "jitsi.tar.gz.sig": "signature"}, files)
+				return []*model.BaseMarketplacePlugin{plugin}, &model.Response{}, nil
+			}).
+			Times(1)
+
+		err := pluginMarketplaceCatalogImportCmdF(s.client, cmd, []string{dir})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(plugin, printer.GetLines()[0])
+	})
+
+	s.Run("Import a missing catalog", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("replace", false, "")
+
+		err := pluginMarketplaceCatalogImportCmdF(s.client, cmd, []string{filepath.Join(s.T().TempDir(), "missing.tar.gz")})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
+	s.Run("Import fails on the server", func() {
+		printer.Clean()
+
+		bundle := filepath.Join(s.T().TempDir(), "catalog.tar.gz")
+		s.Require().NoError(os.WriteFile(bundle, []byte("catalog"), 0600))
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("replace", false, "")
+
+		s.client.
+			EXPECT().
+			ImportMarketplaceCatalog(context.TODO(), gomock.Any(), false).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := pluginMarketplaceCatalogImportCmdF(s.client, cmd, []string{bundle})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestPluginMarketplaceCatalogListCmd() {
+	s.Run("List the catalog", func() {
+		printer.Clean()
+
+		plugins := []*model.BaseMarketplacePlugin{
+			{Manifest: &model.Manifest{Id: "jitsi", Name: "Jitsi", Version: "2.0.0"}},
+			{Manifest: &model.Manifest{Id: "jitsi", Name: "Jitsi", Version: "1.0.0"}},
+		}
+
+		s.client.
+			EXPECT().
+			GetMarketplaceCatalog(context.TODO()).
+			Return(plugins, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginMarketplaceCatalogListCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 2)
+		s.Require().Equal(plugins[0], printer.GetLines()[0])
+		s.Require().Equal(plugins[1], printer.GetLines()[1])
+	})
+
+	s.Run("List with an error", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetMarketplaceCatalog(context.TODO()).
+			Return(nil, &model.Response{}, errors.New("mock error")).
+			Times(1)
+
+		err := pluginMarketplaceCatalogListCmdF(s.client, &cobra.Command{}, []string{})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestPluginMarketplaceCatalogRemoveCmd() {
+	s.Run("Remove a version of a plugin", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("version", "1.0.0", "")
+
+		s.client.
+			EXPECT().
+			RemoveMarketplaceCatalogPlugin(context.TODO(), "jitsi", "1.0.0").
+			Return(&model.Response{}, nil).
+			Times(1)
+
+		err := pluginMarketplaceCatalogRemoveCmdF(s.client, cmd, []string{"jitsi"})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Len(printer.GetLines(), 1)
+	})
+
+	s.Run("Remove with an error", func() {
+		printer.Clean()
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("version", "", "")
+
+		s.client.
+			EXPECT().
+			RemoveMarketplaceCatalogPlugin(context.TODO(), "jitsi", "").
+			Return(nil, errors.New("mock error")).
+			Times(1)
+
+		err := pluginMarketplaceCatalogRemoveCmdF(s.client, cmd, []string{"jitsi"})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
@@ -125,6 +125,106 @@ func (s *MmctlUnitTestSuite) TestPluginAddCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestPluginAddCmdCapabilities() {
+	manifest := &model.Manifest{Id: "capabilities-plugin", Capabilities: []string{model.PluginCapabilityReadPosts, model.PluginCapabilityManagePosts}}
+
+	s.Run("Add plugin declaring capabilities", func() {
+		printer.Clean()
+		tmpFile, err := os.CreateTemp("", "tmpPlugin")
+		s.Require().Nil(err)
+		defer os.Remove(tmpFile.Name())
+
+		s.client.
+			EXPECT().
+			UploadPlugin(context.TODO(), gomock.AssignableToTypeOf(tmpFile)).
+			Return(manifest, &model.Response{}, nil).
+			Times(1)
+
+		err = pluginAddCmdF(s.client, &cobra.Command{}, []string{tmpFile.Name()})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 3)
+		s.Require().Equal("Plugin capabilities-plugin requests capabilities: read_posts, manage_posts", printer.GetLines()[1])
+		s.Require().Equal("Approve them with: mmctl plugin approve-capabilities capabilities-plugin", printer.GetLines()[2])
+	})
+
+	s.Run("Add plugin and approve its capabilities", func() {
+		printer.Clean()
+		tmpFile, err := os.CreateTemp("", "tmpPlugin")
+		s.Require().Nil(err)
+		defer os.Remove(tmpFile.Name())
+
+		s.client.
+			EXPECT().
+			UploadPlugin(context.TODO(), gomock.AssignableToTypeOf(tmpFile)).
+			Return(manifest, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			ApprovePluginCapabilities(context.TODO(), manifest.Id, manifest.Capabilities).
+			Return(&model.PluginInfo{Manifest: *manifest, ApprovedCapabilities: manifest.Capabilities}, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("approve-capabilities", true, "")
+
+		err = pluginAddCmdF(s.client, cmd, []string{tmpFile.Name()})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetLines(), 3)
+		s.Require().Equal("Approved capabilities of plugin: capabilities-plugin", printer.GetLines()[2])
+	})
+}
+
+func (s *MmctlUnitTestSuite) TestPluginApproveCapabilitiesCmd() {
+	pluginsResp := &model.PluginsResponse{
+		Active: []*model.PluginInfo{{Manifest: model.Manifest{Id: "legacy-plugin"}}},
+		Inactive: []*model.PluginInfo{{Manifest: model.Manifest{
+			Id:           "capabilities-plugin",
+			Capabilities: []string{model.PluginCapabilityDBAccess},
+		}}},
+	}
+
+	s.Run("Approve capabilities", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetPlugins(context.TODO()).
+			Return(pluginsResp, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			ApprovePluginCapabilities(context.TODO(), "capabilities-plugin", []string{model.PluginCapabilityDBAccess}).
+			Return(&model.PluginInfo{}, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginApproveCapabilitiesCmdF(s.client, &cobra.Command{}, []string{"capabilities-plugin", "legacy-plugin"})
+		s.Require().NoError(err)
+		s.Require().Len(printer.GetErrorLines(), 0)
+		s.Require().Equal([]any{
+			"Plugin capabilities-plugin requests capabilities: db_access",
+			"Approved capabilities of plugin: capabilities-plugin",
+			"Plugin legacy-plugin does not declare capabilities",
+		}, printer.GetLines())
+	})
+
+	s.Run("Approve capabilities of missing plugin", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetPlugins(context.TODO()).
+			Return(pluginsResp, &model.Response{}, nil).
+			Times(1)
+
+		err := pluginApproveCapabilitiesCmdF(s.client, &cobra.Command{}, []string{"missing-plugin"})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetErrorLines(), 1)
+		s.Require().Equal("Unable to approve capabilities of plugin: missing-plugin. Error: plugin missing-plugin is not installed", printer.GetErrorLines()[0])
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestPluginInstallUrlCmd() {
 	s.Run("Install multiple plugins", func() {
 		printer.Clean()
@@ -224,6 +324,26 @@ func (s *MmctlUnitTestSuite) TestPluginDisableCmd() {
 		s.Require().Len(printer.GetErrorLines(), 0)
 	})
 
+	s.Run("Force disable 1 plugin", func() {
+		printer.Clean()
+		arg := "plug1"
+
+		s.client.
+			EXPECT().
+			ForceDisablePlugin(context.TODO(), arg).
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().Bool("force", true, "")
+
+		err := pluginDisableCmdF(s.client, cmd, []string{arg})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(printer.GetLines()[0], "Disabled plugin: "+arg)
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
 	s.Run("Fail to disable 1 plugin", func() {
 		printer.Clean()
 		arg := "fail1"
@@ -6,6 +6,11 @@ package commands
 import (
 	"context"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mattermost/mattermost/server/public/model"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/client"
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
@@ -20,12 +25,19 @@ var TokenCmd = &cobra.Command{
 }
 
 var GenerateUserTokenCmd = &cobra.Command{
-	Use:     "generate [user] [description]",
-	Short:   "Generate token for a user",
-	Long:    "Generate token for a user",
-	Example: "  generate testuser test-token",
-	RunE:    withClient(generateTokenForAUserCmdF),
-	Args:    cobra.ExactArgs(2),
+	Use:   "generate [user] [description]",
+	Short: "Generate token for a user",
+	Long:  "Generate token for a user, optionally expiring and restricted to some permissions, teams and channels of the user",
+	Example: `  generate testuser test-token
+
+  # The token can expire after a duration or on a date
+  generate testuser test-token --expires 30d
+  generate testuser test-token --expires 2025-12-31
+
+  # The token can be restricted to some permissions, teams and channels
+  generate testuser test-token --scope permission:create_post --scope channel:myteam:town-square`,
+	RunE: withClient(generateTokenForAUserCmdF),
+	Args: cobra.ExactArgs(2),
 }
 
 var RevokeUserTokenCmd = &cobra.Command{
@@ -47,6 +59,9 @@ var ListUserTokensCmd = &cobra.Command{
 }
 
 func init() {
+	GenerateUserTokenCmd.Flags().String("expires", "", "Expire the token after a duration, such as 12h or 30d, or on a date, such as 2025-12-31")
+	GenerateUserTokenCmd.Flags().StringArray("scope", []string{}, "Restrict the token to a permission (permission:<permission>), a team (team:<team>) or a channel (channel:<channel>). Can be repeated")
+
 	ListUserTokensCmd.Flags().Int("page", 0, "Page number to fetch for the list of users")
 	ListUserTokensCmd.Flags().Int("per-page", DefaultPageSize, "Number of users to be fetched")
 	ListUserTokensCmd.Flags().Bool("all", false, "Fetch all tokens. --page flag will be ignore if provided")
@@ -71,7 +86,25 @@ func generateTokenForAUserCmdF(c client.Client, command *cobra.Command, args []s
 		return errors.Errorf("could not retrieve user information of %q", userArg)
 	}
 
-	token, _, err := c.CreateUserAccessToken(context.TODO(), user.Id, args[1])
+	expires, _ := command.Flags().GetString("expires")
+	scopes, _ := command.Flags().GetStringArray("scope")
+
+	var token *model.UserAccessToken
+	var err error
+	if expires == "" && len(scopes) == 0 {
+		token, _, err = c.CreateUserAccessToken(context.TODO(), user.Id, args[1])
+	} else {
+		options := &model.UserAccessToken{Description: args[1]}
+		if expires != "" {
+			if options.ExpiresAt, err = parseTokenExpiry(expires, time.Now()); err != nil {
+				return err
+			}
+		}
+		if options.Scopes, err = resolveTokenScopes(c, scopes); err != nil {
+			return err
+		}
+		token, _, err = c.CreateUserAccessTokenWithOptions(context.TODO(), user.Id, options)
+	}
 	if err != nil {
 		return errors.Errorf("could not create token for %q: %s", userArg, err.Error())
 	}
@@ -80,6 +113,61 @@ func generateTokenForAUserCmdF(c client.Client, command *cobra.Command, args []s
 	return nil
 }
 
+// parseTokenExpiry parses the expiry of a token, given either as a duration from now, which may
+// be in days, or as a date.
+func parseTokenExpiry(expires string, now time.Time) (int64, error) {
+	if days, ok := strings.CutSuffix(expires, "d"); ok {
+		if n, err := strconv.Atoi(days); err == nil && n > 0 {
+			return now.AddDate(0, 0, n).UnixMilli(), nil
+		}
+	}
+
+	if duration, err := time.ParseDuration(expires); err == nil && duration > 0 {
+		return now.Add(duration).UnixMilli(), nil
+	}
+
+	for _, layout := range []string{"2006-01-02", time.RFC3339} {
+		if date, err := time.Parse(layout, expires); err == nil {
+			return date.UnixMilli(), nil
+		}
+	}
+
+	return 0, errors.Errorf("invalid expiry %q, expected a duration such as 12h or 30d, or a date such as 2025-12-31", expires)
+}
+
+// resolveTokenScopes resolves the teams and channels of the given scopes to their ids.
+func resolveTokenScopes(c client.Client, scopes []string) (model.StringArray, error) {
+	var resolved model.StringArray
+	for _, scope := range scopes {
+		kind, value, ok := strings.Cut(scope, ":")
+		if !ok || value == "" {
+			return nil, errors.Errorf("invalid scope %q, expected <kind>:<value>", scope)
+		}
+
+		switch kind {
+		case model.UserAccessTokenScopePermission:
+		case model.UserAccessTokenScopeTeam:
+			team := getTeamFromTeamArg(c, value)
+			if team == nil {
+				return nil, errors.Errorf("could not find team %q of scope %q", value, scope)
+			}
+			value = team.Id
+		case model.UserAccessTokenScopeChannel:
+			channel := getChannelFromChannelArg(c, value)
+			if channel == nil {
+				return nil, errors.Errorf("could not find channel %q of scope %q", value, scope)
+			}
+			value = channel.Id
+		default:
+			return nil, errors.Errorf("invalid scope %q, the kind must be permission, team or channel", scope)
+		}
+
+		resolved = append(resolved, kind+":"+value)
+	}
+
+	return resolved, nil
+}
+
 func listTokensOfAUserCmdF(c client.Client, command *cobra.Command, args []string) error {
 	page, _ := command.Flags().GetInt("page")
 	perPage, _ := command.Flags().GetInt("per-page")
@@ -7,9 +7,13 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"testing"
+	"time"
 
+	"github.com/golang/mock/gomock"
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/pkg/errors"
+	"github.com/stretchr/testify/require"
 
 	"github.com/mattermost/mattermost/server/v8/cmd/mmctl/printer"
 
@@ -54,6 +58,83 @@ func (s *MmctlUnitTestSuite) TestGenerateTokenForAUserCmd() {
 		s.Require().Equal(&mockToken, printer.GetLines()[0])
 	})
 
+	s.Run("Should generate an expiring and scoped token for a user", func() {
+		printer.Clean()
+
+		mockUser := model.User{Id: model.NewId(), Email: "user1@example.com", Username: "user1"}
+		mockTeam := model.Team{Id: model.NewId(), Name: "myteam"}
+		mockToken := model.UserAccessToken{Token: "token-id", Description: "token-desc"}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), mockUser.Id, "").
+			Return(nil, &model.Response{}, errors.New("no user found with the given email")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetUserByUsername(context.TODO(), mockUser.Id, "").
+			Return(nil, &model.Response{}, errors.New("no user found with the given username")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetUser(context.TODO(), mockUser.Id, "").
+			Return(&mockUser, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetTeam(context.TODO(), mockTeam.Name, "").
+			Return(nil, &model.Response{}, errors.New("no team found with the given id")).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			GetTeamByName(context.TODO(), mockTeam.Name, "").
+			Return(&mockTeam, &model.Response{}, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			CreateUserAccessTokenWithOptions(context.TODO(), mockUser.Id, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ string, token *model.UserAccessToken) (*model.UserAccessToken, *model.Response, error) {
+				s.Require().Equal(mockToken.Description, token.Description)
+				s.Require().InDelta(time.Now().Add(12*time.Hour).UnixMilli(), token.ExpiresAt, float64(time.Minute.Milliseconds()))
+				s.Require().Equal(model.StringArray{"permission:create_post", "team:" + mockTeam.Id}, token.Scopes)
+				return &mockToken, &model.Response{}, nil
+			}).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().String("expires", "12h", "")
+		cmd.Flags().StringArray("scope", []string{"permission:create_post", "team:myteam"}, "")
+
+		err := generateTokenForAUserCmdF(s.client, cmd, []string{mockUser.Id, mockToken.Description})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Equal(&mockToken, printer.GetLines()[0])
+	})
+
+	s.Run("Should fail on an invalid scope", func() {
+		printer.Clean()
+
+		mockUser := model.User{Id: model.NewId(), Email: "user1@example.com", Username: "user1"}
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), mockUser.Id, "").
+			Return(&mockUser, &model.Response{}, nil).
+			Times(1)
+
+		cmd := &cobra.Command{}
+		cmd.Flags().StringArray("scope", []string{"system:all"}, "")
+
+		err := generateTokenForAUserCmdF(s.client, cmd, []string{mockUser.Id, "token-desc"})
+		s.Require().Error(err)
+		s.Require().Len(printer.GetLines(), 0)
+	})
+
 	s.Run("Should fail on an invalid username", func() {
 		printer.Clean()
 
@@ -295,3 +376,29 @@ func (s *MmctlUnitTestSuite) TestRevokeTokenForAUserCmdF() {
 		s.Require().Contains(err.Error(), fmt.Sprintf("could not revoke token %q", "token-id"))
 	})
 }
+
+func TestParseTokenExpiry(t *testing.T) {
+	now := time.Date(2025, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	testCases := map[string]struct {
+		expires  string
+		expected time.Time
+	}{
+		"days":     {expires: "30d", expected: now.AddDate(0, 0, 30)},
+		"duration": {expires: "36h", expected: now.Add(36 * time.Hour)},
+		"date":     {expires: "2025-12-31", expected: time.Date(2025, time.December, 31, 0, 0, 0, 0, time.UTC)},
+		"rfc3339":  {expires: "2025-06-01T10:00:00Z", expected: time.Date(2025, time.June, 1, 10, 0, 0, 0, time.UTC)},
+	}
+	for name, tc := range testCases {
+		t.Run(name, func(t *testing.T) {
+			expiresAt, err := parseTokenExpiry(tc.expires, now)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.UnixMilli(), expiresAt)
+		})
+	}
+
+	for _, expires := range []string{"tomorrow", "-1d", "0d", "-5h"} {
+		_, err := parseTokenExpiry(expires, now)
+		require.Error(t, err, expires)
+	}
+}
@@ -138,6 +138,15 @@ If MFA enforcement is enabled, the user will be forced to re-enable MFA as soon
 	RunE:    withClient(resetUserMfaCmdF),
 }
 
+var UnlockUsersCmd = &cobra.Command{
+	Use:     "unlock [users]",
+	Short:   "Unlock users",
+	Long:    "Unlock users locked after too many failed login attempts.",
+	Example: "  user unlock user@example.com",
+	Args:    cobra.MinimumNArgs(1),
+	RunE:    withClient(unlockUsersCmdF),
+}
+
 var DeleteUsersCmd = &cobra.Command{
 	Use:   "delete [users]",
 	Short: "Delete users",
@@ -413,6 +422,7 @@ Global Flags:
 		UpdateUsernameCmd,
 		ChangePasswordUserCmd,
 		ResetUserMfaCmd,
+		UnlockUsersCmd,
 		DeleteUsersCmd,
 		DeleteAllUsersCmd,
 		SearchUserCmd,
@@ -728,6 +738,25 @@ func resetUserMfaCmdF(c client.Client, cmd *cobra.Command, args []string) error
 	return result.ErrorOrNil()
 }
 
+func unlockUsersCmdF(c client.Client, cmd *cobra.Command, args []string) error {
+	var result *multierror.Error
+	users, err := getUsersFromArgs(c, args)
+	if err != nil {
+		result = multierror.Append(result, err)
+	}
+
+	for _, user := range users {
+		if _, err := c.UnlockUser(context.TODO(), user.Id); err != nil {
+			result = multierror.Append(result, fmt.Errorf("unable to unlock user %q. Error: %w", user.Id, err))
+			continue
+		}
+
+		printer.PrintT("User {{.Username}} unlocked", user)
+	}
+
+	return result.ErrorOrNil()
+}
+
 func deleteUsersCmdF(c client.Client, cmd *cobra.Command, args []string) error {
 	confirmFlag, _ := cmd.Flags().GetBool("confirm")
 	if !confirmFlag {
@@ -1864,6 +1864,57 @@ func (s *MmctlUnitTestSuite) TestResetUserMfaCmd() {
 	})
 }
 
+func (s *MmctlUnitTestSuite) TestUnlockUsersCmd() {
+	s.Run("One user without problems", func() {
+		printer.Clean()
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), "user@example.com", "").
+			Return(&model.User{Id: "userId", Username: "user"}, nil, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			UnlockUser(context.TODO(), "userId").
+			Return(&model.Response{StatusCode: http.StatusOK}, nil).
+			Times(1)
+
+		err := unlockUsersCmdF(s.client, &cobra.Command{}, []string{"user@example.com"})
+		s.Require().Nil(err)
+		s.Require().Len(printer.GetLines(), 1)
+		s.Require().Len(printer.GetErrorLines(), 0)
+	})
+
+	s.Run("One user, unable to unlock", func() {
+		printer.Clean()
+		mockError := errors.New("mock error")
+
+		s.client.
+			EXPECT().
+			GetUserByEmail(context.TODO(), "user@example.com", "").
+			Return(&model.User{Id: "userId", Username: "user"}, nil, nil).
+			Times(1)
+
+		s.client.
+			EXPECT().
+			UnlockUser(context.TODO(), "userId").
+			Return(&model.Response{StatusCode: http.StatusForbidden}, mockError).
+			Times(1)
+
+		err := unlockUsersCmdF(s.client, &cobra.Command{}, []string{"user@example.com"})
+
+		var expected error
+
+		expected = multierror.Append(
+			expected, fmt.Errorf("unable to unlock user \"userId\". Error: %w", mockError),
+		)
+
+		s.Require().EqualError(err, expected.Error())
+		s.Require().Len(printer.GetLines(), 0)
+	})
+}
+
 func (s *MmctlUnitTestSuite) TestListUserCmdF() {
 	s.Run("Listing users with paging", func() {
 		printer.Clean()
@@ -72,6 +72,22 @@ func (mr *MockClientMockRecorder) AddTeamMember(arg0, arg1, arg2 interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTeamMember", reflect.TypeOf((*MockClient)(nil).AddTeamMember), arg0, arg1, arg2)
 }
 
+// ApprovePluginCapabilities mocks base method.
+func (m *MockClient) ApprovePluginCapabilities(arg0 context.Context, arg1 string, arg2 []string) (*model.PluginInfo, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ApprovePluginCapabilities", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.PluginInfo)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ApprovePluginCapabilities indicates an expected call of ApprovePluginCapabilities.
+func (mr *MockClientMockRecorder) ApprovePluginCapabilities(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ApprovePluginCapabilities", reflect.TypeOf((*MockClient)(nil).ApprovePluginCapabilities), arg0, arg1, arg2)
+}
+
 // AssignBot mocks base method.
 func (m *MockClient) AssignBot(arg0 context.Context, arg1, arg2 string) (*model.Bot, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -342,6 +358,22 @@ func (mr *MockClientMockRecorder) CreateUserAccessToken(arg0, arg1, arg2 interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserAccessToken", reflect.TypeOf((*MockClient)(nil).CreateUserAccessToken), arg0, arg1, arg2)
 }
 
+// CreateUserAccessTokenWithOptions mocks base method.
+func (m *MockClient) CreateUserAccessTokenWithOptions(arg0 context.Context, arg1 string, arg2 *model.UserAccessToken) (*model.UserAccessToken, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUserAccessTokenWithOptions", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.UserAccessToken)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// CreateUserAccessTokenWithOptions indicates an expected call of CreateUserAccessTokenWithOptions.
+func (mr *MockClientMockRecorder) CreateUserAccessTokenWithOptions(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUserAccessTokenWithOptions", reflect.TypeOf((*MockClient)(nil).CreateUserAccessTokenWithOptions), arg0, arg1, arg2)
+}
+
 // DeleteChannel mocks base method.
 func (m *MockClient) DeleteChannel(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -555,6 +587,21 @@ func (mr *MockClientMockRecorder) EnablePlugin(arg0, arg1 interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "EnablePlugin", reflect.TypeOf((*MockClient)(nil).EnablePlugin), arg0, arg1)
 }
 
+// ForceDisablePlugin mocks base method.
+func (m *MockClient) ForceDisablePlugin(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForceDisablePlugin", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForceDisablePlugin indicates an expected call of ForceDisablePlugin.
+func (mr *MockClientMockRecorder) ForceDisablePlugin(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForceDisablePlugin", reflect.TypeOf((*MockClient)(nil).ForceDisablePlugin), arg0, arg1)
+}
+
 // GeneratePresignedURL mocks base method.
 func (m *MockClient) GeneratePresignedURL(arg0 context.Context, arg1 string) (*model.PresignURLResponse, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -941,6 +988,22 @@ func (mr *MockClientMockRecorder) GetLogs(arg0, arg1, arg2 interface{}) *gomock.
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLogs", reflect.TypeOf((*MockClient)(nil).GetLogs), arg0, arg1, arg2)
 }
 
+// GetMarketplaceCatalog mocks base method.
+func (m *MockClient) GetMarketplaceCatalog(arg0 context.Context) ([]*model.BaseMarketplacePlugin, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetMarketplaceCatalog", arg0)
+	ret0, _ := ret[0].([]*model.BaseMarketplacePlugin)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetMarketplaceCatalog indicates an expected call of GetMarketplaceCatalog.
+func (mr *MockClientMockRecorder) GetMarketplaceCatalog(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMarketplaceCatalog", reflect.TypeOf((*MockClient)(nil).GetMarketplaceCatalog), arg0)
+}
+
 // GetMarketplacePlugins mocks base method.
 func (m *MockClient) GetMarketplacePlugins(arg0 context.Context, arg1 *model.MarketplacePluginFilter) ([]*model.MarketplacePlugin, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1469,6 +1532,22 @@ func (mr *MockClientMockRecorder) GetUsersWithCustomQueryParameters(arg0, arg1,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsersWithCustomQueryParameters", reflect.TypeOf((*MockClient)(nil).GetUsersWithCustomQueryParameters), arg0, arg1, arg2, arg3, arg4)
 }
 
+// ImportMarketplaceCatalog mocks base method.
+func (m *MockClient) ImportMarketplaceCatalog(arg0 context.Context, arg1 io.Reader, arg2 bool) ([]*model.BaseMarketplacePlugin, *model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ImportMarketplaceCatalog", arg0, arg1, arg2)
+	ret0, _ := ret[0].([]*model.BaseMarketplacePlugin)
+	ret1, _ := ret[1].(*model.Response)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ImportMarketplaceCatalog indicates an expected call of ImportMarketplaceCatalog.
+func (mr *MockClientMockRecorder) ImportMarketplaceCatalog(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ImportMarketplaceCatalog", reflect.TypeOf((*MockClient)(nil).ImportMarketplaceCatalog), arg0, arg1, arg2)
+}
+
 // InstallMarketplacePlugin mocks base method.
 func (m *MockClient) InstallMarketplacePlugin(arg0 context.Context, arg1 *model.InstallMarketplacePluginRequest) (*model.Manifest, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -1871,6 +1950,21 @@ func (mr *MockClientMockRecorder) RemoveLicenseFile(arg0 interface{}) *gomock.Ca
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveLicenseFile", reflect.TypeOf((*MockClient)(nil).RemoveLicenseFile), arg0)
 }
 
+// RemoveMarketplaceCatalogPlugin mocks base method.
+func (m *MockClient) RemoveMarketplaceCatalogPlugin(arg0 context.Context, arg1, arg2 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveMarketplaceCatalogPlugin", arg0, arg1, arg2)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RemoveMarketplaceCatalogPlugin indicates an expected call of RemoveMarketplaceCatalogPlugin.
+func (mr *MockClientMockRecorder) RemoveMarketplaceCatalogPlugin(arg0, arg1, arg2 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveMarketplaceCatalogPlugin", reflect.TypeOf((*MockClient)(nil).RemoveMarketplaceCatalogPlugin), arg0, arg1, arg2)
+}
+
 // RemovePlugin mocks base method.
 func (m *MockClient) RemovePlugin(arg0 context.Context, arg1 string) (*model.Response, error) {
 	m.ctrl.T.Helper()
@@ -2071,6 +2165,21 @@ func (mr *MockClientMockRecorder) SyncLdap(arg0, arg1 interface{}) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncLdap", reflect.TypeOf((*MockClient)(nil).SyncLdap), arg0, arg1)
 }
 
+// UnlockUser mocks base method.
+func (m *MockClient) UnlockUser(arg0 context.Context, arg1 string) (*model.Response, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UnlockUser", arg0, arg1)
+	ret0, _ := ret[0].(*model.Response)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UnlockUser indicates an expected call of UnlockUser.
+func (mr *MockClientMockRecorder) UnlockUser(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UnlockUser", reflect.TypeOf((*MockClient)(nil).UnlockUser), arg0, arg1)
+}
+
 // UpdateChannelPrivacy mocks base method.
 func (m *MockClient) UpdateChannelPrivacy(arg0 context.Context, arg1 string, arg2 model.ChannelType) (*model.Channel, *model.Response, error) {
 	m.ctrl.T.Helper()
@@ -72,6 +72,8 @@ type MetricsInterface interface {
 	ObservePluginMultiHookIterationDuration(pluginID string, elapsed float64)
 	ObservePluginMultiHookDuration(elapsed float64)
 	ObservePluginAPIDuration(pluginID, apiName string, success bool, elapsed float64)
+	IncrementPluginHookTimeout(pluginID, hookName string)
+	IncrementPluginCircuitBreakerTrip(pluginID string)
 
 	ObserveEnabledUsers(users int64)
 	GetLoggerMetricsCollector() mlog.MetricsCollector
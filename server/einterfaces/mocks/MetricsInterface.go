@@ -203,6 +203,16 @@ func (_m *MetricsInterface) IncrementNotificationUnsupportedCounter(notification
 	_m.Called(notificationType, notSentReason, platform)
 }
 
+// IncrementPluginCircuitBreakerTrip provides a mock function with given fields: pluginID
+func (_m *MetricsInterface) IncrementPluginCircuitBreakerTrip(pluginID string) {
+	_m.Called(pluginID)
+}
+
+// IncrementPluginHookTimeout provides a mock function with given fields: pluginID, hookName
+func (_m *MetricsInterface) IncrementPluginHookTimeout(pluginID string, hookName string) {
+	_m.Called(pluginID, hookName)
+}
+
 // IncrementPostBroadcast provides a mock function with given fields:
 func (_m *MetricsInterface) IncrementPostBroadcast() {
 	_m.Called()
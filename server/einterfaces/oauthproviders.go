@@ -5,6 +5,7 @@ package einterfaces
 
 import (
 	"io"
+	"net/http"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/request"
@@ -17,6 +18,29 @@ type OAuthProvider interface {
 	IsSameUser(c request.CTX, dbUser, oAuthUser *model.User) bool
 }
 
+// OAuthHTTPClientProvider is implemented by the OAuth providers making requests to the service
+// themselves. The app binds them to its HTTP client, which applies the outgoing connection
+// settings, before using them.
+type OAuthHTTPClientProvider interface {
+	OAuthProvider
+	WithHTTPClient(client *http.Client) OAuthProvider
+}
+
+// OAuthClaimsProvider is implemented by the OAuth providers reading users from claims mapped
+// by the settings of the service logged in with, such as the generic OpenID Connect provider.
+// The app uses these methods instead of the ones of OAuthProvider when they are implemented.
+type OAuthClaimsProvider interface {
+	OAuthProvider
+	GetUserFromJSONWithSettings(c request.CTX, settings *model.SSOSettings, data io.Reader, tokenUser *model.User) (*model.User, error)
+	GetUserFromIdTokenWithSettings(c request.CTX, settings *model.SSOSettings, idToken string) (*model.User, error)
+	// GetUserGroups returns the remote ids of the groups of the user, or false if the groups
+	// of the service aren't synchronized.
+	GetUserGroups(c request.CTX, settings *model.SSOSettings, data io.Reader, tokenUser *model.User) ([]string, bool, error)
+	// GetUserAttributes returns the values of the claims of the user, which are matched against
+	// the rules of attribute groups.
+	GetUserAttributes(c request.CTX, settings *model.SSOSettings, data io.Reader) (map[string][]string, error)
+}
+
 var oauthProviders = make(map[string]OAuthProvider)
 
 func RegisterOAuthProvider(name string, newProvider OAuthProvider) {
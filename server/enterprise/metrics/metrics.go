@@ -168,6 +168,8 @@ type MetricsInterfaceImpl struct {
 	PluginMultiHookTimeHistogram       *prometheus.HistogramVec
 	PluginMultiHookServerTimeHistogram prometheus.Histogram
 	PluginAPITimeHistogram             *prometheus.HistogramVec
+	PluginHookTimeoutCounter           *prometheus.CounterVec
+	PluginCircuitBreakerTripCounter    *prometheus.CounterVec
 
 	LoggerQueueGauge      *DynamicGauge
 	LoggerLoggedCounters  *DynamicCounter
@@ -870,6 +872,30 @@ func New(ps *platform.PlatformService, driver, dataSource string) *MetricsInterf
 	)
 	m.Registry.MustRegister(m.PluginAPITimeHistogram)
 
+	m.PluginHookTimeoutCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "hook_timeouts_total",
+			Help:        "The total number of plugin hook handlers given up on for exceeding their deadline.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id", "hook_name"},
+	)
+	m.Registry.MustRegister(m.PluginHookTimeoutCounter)
+
+	m.PluginCircuitBreakerTripCounter = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace:   MetricsNamespace,
+			Subsystem:   MetricsSubsystemPlugin,
+			Name:        "circuit_breaker_trips_total",
+			Help:        "The total number of times the hooks of a plugin were suspended for timing out repeatedly.",
+			ConstLabels: additionalLabels,
+		},
+		[]string{"plugin_id"},
+	)
+	m.Registry.MustRegister(m.PluginCircuitBreakerTripCounter)
+
 	// Logging subsystem
 
 	m.LoggerQueueGauge = NewDynamicGauge(
@@ -1734,6 +1760,14 @@ func (mi *MetricsInterfaceImpl) ObservePluginAPIDuration(pluginID, apiName strin
 	mi.PluginAPITimeHistogram.With(prometheus.Labels{"plugin_id": pluginID, "api_name": apiName, "success": strconv.FormatBool(success)}).Observe(elapsed)
 }
 
+func (mi *MetricsInterfaceImpl) IncrementPluginHookTimeout(pluginID, hookName string) {
+	mi.PluginHookTimeoutCounter.With(prometheus.Labels{"plugin_id": pluginID, "hook_name": hookName}).Inc()
+}
+
+func (mi *MetricsInterfaceImpl) IncrementPluginCircuitBreakerTrip(pluginID string) {
+	mi.PluginCircuitBreakerTripCounter.With(prometheus.Labels{"plugin_id": pluginID}).Inc()
+}
+
 func (mi *MetricsInterfaceImpl) GetLoggerMetricsCollector() mlog.MetricsCollector {
 	return &LoggerMetricsCollector{
 		queueGauge:      mi.LoggerQueueGauge,
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package geoip locates IP addresses with a local database mapping networks to regions, so
+// that no address is sent to a third party.
+package geoip
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/netip"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const earthRadiusKm = 6371
+
+// Location is the region of a network, located by the coordinates of its center.
+type Location struct {
+	Region    string
+	Latitude  float64
+	Longitude float64
+}
+
+type addrRange struct {
+	first    netip.Addr
+	last     netip.Addr
+	location Location
+}
+
+// Database maps networks to the location of their region.
+type Database struct {
+	ranges []addrRange
+}
+
+// Parse reads a database from CSV records made of a network in CIDR notation or a single
+// address, the name of its region, and its latitude and longitude. Lines starting with # are
+// ignored. Networks can't overlap.
+func Parse(r io.Reader) (*Database, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	db := &Database{}
+	for {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read the database: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		addrRange, err := parseRecord(record)
+		if err != nil {
+			return nil, fmt.Errorf("invalid record on line %d: %w", line, err)
+		}
+		db.ranges = append(db.ranges, addrRange)
+	}
+
+	sort.Slice(db.ranges, func(i, j int) bool {
+		return db.ranges[i].first.Less(db.ranges[j].first)
+	})
+	for i := 1; i < len(db.ranges); i++ {
+		if db.ranges[i].first.Compare(db.ranges[i-1].last) <= 0 {
+			return nil, fmt.Errorf("network of %s overlaps the network of %s", db.ranges[i].location.Region, db.ranges[i-1].location.Region)
+		}
+	}
+
+	return db, nil
+}
+
+func parseRecord(record []string) (addrRange, error) {
+	network := strings.TrimSpace(record[0])
+	var prefix netip.Prefix
+	var err error
+	if strings.Contains(network, "/") {
+		prefix, err = netip.ParsePrefix(network)
+	} else {
+		var addr netip.Addr
+		if addr, err = netip.ParseAddr(network); err == nil {
+			prefix = netip.PrefixFrom(addr, addr.BitLen())
+		}
+	}
+	if err != nil {
+		return addrRange{}, fmt.Errorf("invalid network %q: %w", network, err)
+	}
+
+	region := strings.TrimSpace(record[1])
+	if region == "" {
+		return addrRange{}, errors.New("missing region")
+	}
+
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(record[2]), 64)
+	if err != nil || latitude < -90 || latitude > 90 {
+		return addrRange{}, fmt.Errorf("invalid latitude %q", record[2])
+	}
+
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+	if err != nil || longitude < -180 || longitude > 180 {
+		return addrRange{}, fmt.Errorf("invalid longitude %q", record[3])
+	}
+
+	prefix = prefix.Masked()
+	return addrRange{
+		first:    prefix.Addr(),
+		last:     lastAddr(prefix),
+		location: Location{Region: region, Latitude: latitude, Longitude: longitude},
+	}, nil
+}
+
+func lastAddr(prefix netip.Prefix) netip.Addr {
+	bytes := prefix.Addr().AsSlice()
+	for bit := prefix.Bits(); bit < len(bytes)*8; bit++ {
+		bytes[bit/8] |= 1 << (7 - bit%8)
+	}
+	addr, _ := netip.AddrFromSlice(bytes)
+	return addr
+}
+
+// Lookup returns the location of the address, if it belongs to a network of the database.
+func (db *Database) Lookup(address string) (Location, bool) {
+	addr, err := netip.ParseAddr(strings.TrimSpace(address))
+	if err != nil {
+		return Location{}, false
+	}
+	addr = addr.Unmap().WithZone("")
+
+	// Find the last network starting at or before the address.
+	i := sort.Search(len(db.ranges), func(i int) bool {
+		return addr.Less(db.ranges[i].first)
+	}) - 1
+	if i < 0 || db.ranges[i].last.Less(addr) || db.ranges[i].first.BitLen() != addr.BitLen() {
+		return Location{}, false
+	}
+
+	return db.ranges[i].location, true
+}
+
+// Distance returns the great-circle distance between both locations, in kilometers.
+func Distance(a, b Location) float64 {
+	toRadians := func(degrees float64) float64 { return degrees * math.Pi / 180 }
+
+	latitudeDelta := toRadians(b.Latitude - a.Latitude)
+	longitudeDelta := toRadians(b.Longitude - a.Longitude)
+	h := math.Pow(math.Sin(latitudeDelta/2), 2) +
+		math.Cos(toRadians(a.Latitude))*math.Cos(toRadians(b.Latitude))*math.Pow(math.Sin(longitudeDelta/2), 2)
+
+	return 2 * earthRadiusKm * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// Loader loads a database from a file, and loads it again when the file changes.
+type Loader struct {
+	mu      sync.Mutex
+	path    string
+	modTime time.Time
+	db      *Database
+}
+
+// Get returns the database of the file at the given path.
+func (l *Loader) Get(path string) (*Database, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the database: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.db != nil && l.path == path && l.modTime.Equal(info.ModTime()) {
+		return l.db, nil
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the database: %w", err)
+	}
+	defer file.Close()
+
+	db, err := Parse(file)
+	if err != nil {
+		return nil, err
+	}
+
+	l.path = path
+	l.modTime = info.ModTime()
+	l.db = db
+
+	return db, nil
+}
@@ -0,0 +1,108 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package geoip
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDatabase = `# network,region,latitude,longitude
+10.0.0.0/8,Paris,48.8566,2.3522
+192.168.1.0/24, Tokyo, 35.6762, 139.6503
+203.0.113.7,New York,40.7128,-74.0060
+2001:db8::/32,Sydney,-33.8688,151.2093
+`
+
+func TestParse(t *testing.T) {
+	db, err := Parse(strings.NewReader(testDatabase))
+	require.NoError(t, err)
+
+	for address, region := range map[string]string{
+		"10.0.0.0":            "Paris",
+		"10.255.255.255":      "Paris",
+		"192.168.1.42":        "Tokyo",
+		"203.0.113.7":         "New York",
+		"::ffff:10.1.2.3":     "Paris",
+		"2001:db8:1234::1":    "Sydney",
+		"11.0.0.0":            "",
+		"192.168.2.1":         "",
+		"203.0.113.8":         "",
+		"2001:db9::1":         "",
+		"not an address":      "",
+		"fe80::1%eth0":        "",
+		"2001:db8::1%eth0":    "Sydney",
+		"0.0.0.0":             "",
+		"255.255.255.255":     "",
+		"::":                  "",
+		"ffff:ffff:ffff::1":   "",
+		" 192.168.1.1 ":       "Tokyo",
+		"9.255.255.255":       "",
+		"192.168.0.255":       "",
+		"2001:db8:ffff::ffff": "Sydney",
+	} {
+		t.Run(address, func(t *testing.T) {
+			location, ok := db.Lookup(address)
+			assert.Equal(t, region != "", ok)
+			assert.Equal(t, region, location.Region)
+		})
+	}
+
+	location, ok := db.Lookup("192.168.1.1")
+	require.True(t, ok)
+	assert.InDelta(t, 35.6762, location.Latitude, 0.0001)
+	assert.InDelta(t, 139.6503, location.Longitude, 0.0001)
+
+	for name, database := range map[string]string{
+		"invalid network":   "10.0.0.0/33,Paris,0,0\n",
+		"missing region":    "10.0.0.0/8,,0,0\n",
+		"invalid latitude":  "10.0.0.0/8,Paris,91,0\n",
+		"invalid longitude": "10.0.0.0/8,Paris,0,east\n",
+		"missing field":     "10.0.0.0/8,Paris,0\n",
+		"overlap":           "10.0.0.0/8,Paris,0,0\n10.1.0.0/16,Lyon,0,0\n",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Parse(strings.NewReader(database))
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestDistance(t *testing.T) {
+	paris := Location{Latitude: 48.8566, Longitude: 2.3522}
+	tokyo := Location{Latitude: 35.6762, Longitude: 139.6503}
+
+	assert.InDelta(t, 9712, Distance(paris, tokyo), 20)
+	assert.InDelta(t, Distance(paris, tokyo), Distance(tokyo, paris), 0.001)
+	assert.Zero(t, Distance(paris, paris))
+}
+
+func TestLoader(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "geoip.csv")
+	require.NoError(t, os.WriteFile(path, []byte(testDatabase), 0600))
+
+	var loader Loader
+	db, err := loader.Get(path)
+	require.NoError(t, err)
+	cached, err := loader.Get(path)
+	require.NoError(t, err)
+	assert.Same(t, db, cached)
+
+	require.NoError(t, os.WriteFile(path, []byte("10.0.0.0/8,Lyon,45.764,4.8357\n"), 0600))
+	require.NoError(t, os.Chtimes(path, time.Now().Add(time.Minute), time.Now().Add(time.Minute)))
+	db, err = loader.Get(path)
+	require.NoError(t, err)
+	location, ok := db.Lookup("10.0.0.1")
+	require.True(t, ok)
+	assert.Equal(t, "Lyon", location.Region)
+
+	_, err = loader.Get(filepath.Join(t.TempDir(), "missing.csv"))
+	assert.Error(t, err)
+}
@@ -0,0 +1,138 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package marketplace
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/blang/semver/v4"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+const (
+	hostingCloud  = "cloud"
+	hostingOnPrem = "on-prem"
+)
+
+// Catalog is an offline catalog of plugins, listed in place of or alongside the marketplace
+// server for deployments that can't reach it. It filters its plugins the way the marketplace
+// server does.
+type Catalog struct {
+	plugins []*model.BaseMarketplacePlugin
+}
+
+// NewCatalog creates a catalog listing the given plugins, which may include several versions of
+// the same plugin.
+func NewCatalog(plugins []*model.BaseMarketplacePlugin) *Catalog {
+	return &Catalog{plugins: plugins}
+}
+
+// GetPlugins lists the plugins of the catalog matching the given filter, keeping only the latest
+// version of each plugin unless all versions are requested.
+func (c *Catalog) GetPlugins(filter *model.MarketplacePluginFilter) ([]*model.BaseMarketplacePlugin, error) {
+	latest := map[string]*model.BaseMarketplacePlugin{}
+	var result []*model.BaseMarketplacePlugin
+	for _, plugin := range c.plugins {
+		if plugin.Manifest == nil || !catalogPluginMatchesFilter(plugin, filter) {
+			continue
+		}
+
+		if filter.ReturnAllVersions {
+			result = append(result, plugin)
+			continue
+		}
+
+		if current, ok := latest[plugin.Manifest.Id]; !ok || compareVersions(plugin.Manifest.Version, current.Manifest.Version) > 0 {
+			latest[plugin.Manifest.Id] = plugin
+		}
+	}
+	for _, plugin := range latest {
+		result = append(result, plugin)
+	}
+
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].Manifest.Id != result[j].Manifest.Id {
+			return result[i].Manifest.Id < result[j].Manifest.Id
+		}
+		return compareVersions(result[i].Manifest.Version, result[j].Manifest.Version) > 0
+	})
+
+	if filter.PerPage > 0 {
+		start := min(filter.Page*filter.PerPage, len(result))
+		end := min(start+filter.PerPage, len(result))
+		result = result[start:end]
+	}
+
+	return result, nil
+}
+
+// GetPlugin returns the given version of a plugin of the catalog.
+func (c *Catalog) GetPlugin(filter *model.MarketplacePluginFilter, pluginVersion string) (*model.BaseMarketplacePlugin, error) {
+	return getPlugin(c, filter, pluginVersion)
+}
+
+// GetLatestPlugin returns the latest version of a plugin of the catalog compatible with the
+// server described by the filter.
+func (c *Catalog) GetLatestPlugin(filter *model.MarketplacePluginFilter) (*model.BaseMarketplacePlugin, error) {
+	return getLatestPlugin(c, filter)
+}
+
+func catalogPluginMatchesFilter(plugin *model.BaseMarketplacePlugin, filter *model.MarketplacePluginFilter) bool {
+	manifest := plugin.Manifest
+
+	if filter.PluginId != "" && manifest.Id != filter.PluginId {
+		return false
+	}
+
+	if text := strings.ToLower(strings.TrimSpace(filter.Filter)); text != "" &&
+		strings.ToLower(manifest.Id) != text &&
+		!strings.Contains(strings.ToLower(manifest.Name), text) &&
+		!strings.Contains(strings.ToLower(manifest.Description), text) {
+		return false
+	}
+
+	if filter.ServerVersion != "" && manifest.MinServerVersion != "" {
+		if _, err := semver.Parse(filter.ServerVersion); err != nil {
+			return false
+		}
+		if fulfilled, err := manifest.MeetMinServerVersion(filter.ServerVersion); err != nil || !fulfilled {
+			return false
+		}
+	}
+
+	// Plugins with a server built for other platforms only can't run on this server.
+	if goOS, goArch, ok := strings.Cut(filter.Platform, "-"); ok && manifest.HasServer() && !manifest.HasWasmServer() {
+		if manifest.GetExecutableForRuntime(goOS, goArch) == "" {
+			return false
+		}
+	}
+
+	if plugin.Enterprise && !filter.EnterprisePlugins {
+		return false
+	}
+
+	if (filter.Cloud && plugin.Hosting == hostingOnPrem) || (!filter.Cloud && plugin.Hosting == hostingCloud) {
+		return false
+	}
+
+	return true
+}
+
+// compareVersions compares two plugin versions, ordering invalid versions first.
+func compareVersions(a, b string) int {
+	versionA, errA := semver.Parse(a)
+	versionB, errB := semver.Parse(b)
+	switch {
+	case errA != nil && errB != nil:
+		return strings.Compare(a, b)
+	case errA != nil:
+		return -1
+	case errB != nil:
+		return 1
+	default:
+		return versionA.Compare(versionB)
+	}
+}
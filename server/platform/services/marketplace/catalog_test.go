@@ -0,0 +1,120 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package marketplace
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newCatalogPlugin(id, version, minServerVersion string) *model.BaseMarketplacePlugin {
+	return &model.BaseMarketplacePlugin{
+		Manifest: &model.Manifest{
+			Id:               id,
+			Name:             id + " plugin",
+			Version:          version,
+			MinServerVersion: minServerVersion,
+		},
+	}
+}
+
+func catalogPluginVersions(plugins []*model.BaseMarketplacePlugin) []string {
+	var versions []string
+	for _, plugin := range plugins {
+		versions = append(versions, plugin.Manifest.Id+"-"+plugin.Manifest.Version)
+	}
+	return versions
+}
+
+func TestCatalogGetPlugins(t *testing.T) {
+	enterprise := newCatalogPlugin("enterprise", "1.0.0", "")
+	enterprise.Enterprise = true
+	cloud := newCatalogPlugin("cloud", "1.0.0", "")
+	cloud.Hosting = hostingCloud
+
+	catalog := NewCatalog([]*model.BaseMarketplacePlugin{
+		newCatalogPlugin("jitsi", "1.0.0", ""),
+		newCatalogPlugin("jitsi", "2.0.0", "9.0.0"),
+		newCatalogPlugin("jitsi", "1.10.0", ""),
+		newCatalogPlugin("github", "2.1.0", ""),
+		enterprise,
+		cloud,
+	})
+
+	testCases := map[string]struct {
+		filter   *model.MarketplacePluginFilter
+		expected []string
+	}{
+		"latest versions": {
+			filter:   &model.MarketplacePluginFilter{},
+			expected: []string{"github-2.1.0", "jitsi-2.0.0"},
+		},
+		"all versions": {
+			filter:   &model.MarketplacePluginFilter{ReturnAllVersions: true},
+			expected: []string{"github-2.1.0", "jitsi-2.0.0", "jitsi-1.10.0", "jitsi-1.0.0"},
+		},
+		"server version": {
+			filter:   &model.MarketplacePluginFilter{ServerVersion: "8.1.0"},
+			expected: []string{"github-2.1.0", "jitsi-1.10.0"},
+		},
+		"plugin id": {
+			filter:   &model.MarketplacePluginFilter{PluginId: "jitsi", ReturnAllVersions: true},
+			expected: []string{"jitsi-2.0.0", "jitsi-1.10.0", "jitsi-1.0.0"},
+		},
+		"filter text": {
+			filter:   &model.MarketplacePluginFilter{Filter: "GIT"},
+			expected: []string{"github-2.1.0"},
+		},
+		"enterprise and cloud": {
+			filter:   &model.MarketplacePluginFilter{EnterprisePlugins: true, Cloud: true},
+			expected: []string{"cloud-1.0.0", "enterprise-1.0.0", "github-2.1.0", "jitsi-2.0.0"},
+		},
+		"pagination": {
+			filter:   &model.MarketplacePluginFilter{Page: 1, PerPage: 1},
+			expected: []string{"jitsi-2.0.0"},
+		},
+		"page out of range": {
+			filter:   &model.MarketplacePluginFilter{Page: 5, PerPage: 10},
+			expected: nil,
+		},
+	}
+
+	for name, tt := range testCases {
+		t.Run(name, func(t *testing.T) {
+			plugins, err := catalog.GetPlugins(tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, catalogPluginVersions(plugins))
+		})
+	}
+}
+
+func TestCatalogGetPlugin(t *testing.T) {
+	catalog := NewCatalog([]*model.BaseMarketplacePlugin{
+		newCatalogPlugin("jitsi", "1.0.0", ""),
+		newCatalogPlugin("jitsi", "2.0.0", "9.0.0"),
+	})
+
+	t.Run("given version", func(t *testing.T) {
+		plugin, err := catalog.GetPlugin(&model.MarketplacePluginFilter{PluginId: "jitsi"}, "1.0.0")
+		require.NoError(t, err)
+		require.NotNil(t, plugin)
+		assert.Equal(t, "1.0.0", plugin.Manifest.Version)
+	})
+
+	t.Run("latest compatible version", func(t *testing.T) {
+		plugin, err := catalog.GetLatestPlugin(&model.MarketplacePluginFilter{PluginId: "jitsi", ServerVersion: "8.1.0"})
+		require.NoError(t, err)
+		require.NotNil(t, plugin)
+		assert.Equal(t, "1.0.0", plugin.Manifest.Version)
+	})
+
+	t.Run("missing plugin", func(t *testing.T) {
+		_, err := catalog.GetLatestPlugin(&model.MarketplacePluginFilter{PluginId: "github"})
+		assert.Error(t, err)
+	})
+}
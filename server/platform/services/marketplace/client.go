@@ -65,6 +65,19 @@ func (c *Client) GetPlugins(request *model.MarketplacePluginFilter) ([]*model.Ba
 }
 
 func (c *Client) GetPlugin(filter *model.MarketplacePluginFilter, pluginVersion string) (*model.BaseMarketplacePlugin, error) {
+	return getPlugin(c, filter, pluginVersion)
+}
+
+func (c *Client) GetLatestPlugin(filter *model.MarketplacePluginFilter) (*model.BaseMarketplacePlugin, error) {
+	return getLatestPlugin(c, filter)
+}
+
+// pluginLister lists the plugins of a marketplace matching a filter.
+type pluginLister interface {
+	GetPlugins(filter *model.MarketplacePluginFilter) ([]*model.BaseMarketplacePlugin, error)
+}
+
+func getPlugin(lister pluginLister, filter *model.MarketplacePluginFilter, pluginVersion string) (*model.BaseMarketplacePlugin, error) {
 	filter.ReturnAllVersions = true
 
 	if filter.PluginId == "" {
@@ -75,7 +88,7 @@ func (c *Client) GetPlugin(filter *model.MarketplacePluginFilter, pluginVersion
 		return nil, errors.New("missing pluginVersion")
 	}
 
-	plugins, err := c.GetPlugins(filter)
+	plugins, err := lister.GetPlugins(filter)
 	if err != nil {
 		return nil, err
 	}
@@ -87,14 +100,14 @@ func (c *Client) GetPlugin(filter *model.MarketplacePluginFilter, pluginVersion
 	return nil, errors.New("plugin not found")
 }
 
-func (c *Client) GetLatestPlugin(filter *model.MarketplacePluginFilter) (*model.BaseMarketplacePlugin, error) {
+func getLatestPlugin(lister pluginLister, filter *model.MarketplacePluginFilter) (*model.BaseMarketplacePlugin, error) {
 	filter.ReturnAllVersions = false
 
 	if filter.PluginId == "" {
 		return nil, errors.New("no pluginID provided")
 	}
 
-	plugins, err := c.GetPlugins(filter)
+	plugins, err := lister.GetPlugins(filter)
 	if err != nil {
 		return nil, err
 	}
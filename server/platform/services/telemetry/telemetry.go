@@ -565,6 +565,7 @@ func (ts *TelemetryService) trackConfig() {
 		"persistent_notification_max_count":                       *cfg.ServiceSettings.PersistentNotificationMaxCount,
 		"persistent_notification_max_recipients":                  *cfg.ServiceSettings.PersistentNotificationMaxRecipients,
 		"allow_synced_drafts":                                     *cfg.ServiceSettings.AllowSyncedDrafts,
+		"enable_calendar_status_sync":                             *cfg.ServiceSettings.EnableCalendarStatusSync,
 		"refresh_post_stats_run_time":                             *cfg.ServiceSettings.RefreshPostStatsRunTime,
 		"maximum_payload_size":                                    *cfg.ServiceSettings.MaximumPayloadSizeBytes,
 		"maximum_url_length":                                      *cfg.ServiceSettings.MaximumURLLength,
@@ -1477,18 +1478,29 @@ func (ts *TelemetryService) Shutdown() error {
 
 func (ts *TelemetryService) trackPluginConfig(configs map[string]any, cfg *model.Config, marketplaceURL string) {
 	pluginConfigData := map[string]any{
-		"enable_nps_survey":             pluginSetting(&cfg.PluginSettings, model.PluginIdNPS, "enablesurvey", true),
-		"enable":                        *cfg.PluginSettings.Enable,
-		"enable_uploads":                *cfg.PluginSettings.EnableUploads,
-		"allow_insecure_download_url":   *cfg.PluginSettings.AllowInsecureDownloadURL,
-		"enable_health_check":           *cfg.PluginSettings.EnableHealthCheck,
-		"enable_marketplace":            *cfg.PluginSettings.EnableMarketplace,
-		"require_pluginSignature":       *cfg.PluginSettings.RequirePluginSignature,
-		"enable_remote_marketplace":     *cfg.PluginSettings.EnableRemoteMarketplace,
-		"automatic_prepackaged_plugins": *cfg.PluginSettings.AutomaticPrepackagedPlugins,
-		"is_default_marketplace_url":    isDefault(*cfg.PluginSettings.MarketplaceURL, model.PluginSettingsDefaultMarketplaceURL),
-		"signature_public_key_files":    len(cfg.PluginSettings.SignaturePublicKeyFiles),
-		"chimera_oauth_proxy_url":       *cfg.PluginSettings.ChimeraOAuthProxyURL,
+		"enable_nps_survey":                     pluginSetting(&cfg.PluginSettings, model.PluginIdNPS, "enablesurvey", true),
+		"enable":                                *cfg.PluginSettings.Enable,
+		"enable_uploads":                        *cfg.PluginSettings.EnableUploads,
+		"allow_insecure_download_url":           *cfg.PluginSettings.AllowInsecureDownloadURL,
+		"enable_health_check":                   *cfg.PluginSettings.EnableHealthCheck,
+		"enable_marketplace":                    *cfg.PluginSettings.EnableMarketplace,
+		"require_pluginSignature":               *cfg.PluginSettings.RequirePluginSignature,
+		"require_plugin_capabilities":           *cfg.PluginSettings.RequirePluginCapabilities,
+		"wasm_max_memory_mb":                    *cfg.PluginSettings.WasmMaxMemoryMB,
+		"wasm_max_call_duration_ms":             *cfg.PluginSettings.WasmMaxCallDurationMs,
+		"hook_timeouts":                         len(cfg.PluginSettings.HookTimeoutsMs),
+		"hook_circuit_breaker_threshold":        *cfg.PluginSettings.HookCircuitBreakerThreshold,
+		"hook_circuit_breaker_cooldown_seconds": *cfg.PluginSettings.HookCircuitBreakerCooldownSeconds,
+		"process_max_memory_mb":                 *cfg.PluginSettings.ProcessMaxMemoryMB,
+		"process_max_cpu_percent":               *cfg.PluginSettings.ProcessMaxCPUPercent,
+		"process_max_open_files":                *cfg.PluginSettings.ProcessMaxOpenFiles,
+		"enable_remote_marketplace":             *cfg.PluginSettings.EnableRemoteMarketplace,
+		"enable_marketplace_catalog":            *cfg.PluginSettings.EnableMarketplaceCatalog,
+		"automatic_prepackaged_plugins":         *cfg.PluginSettings.AutomaticPrepackagedPlugins,
+		"is_default_marketplace_url":            isDefault(*cfg.PluginSettings.MarketplaceURL, model.PluginSettingsDefaultMarketplaceURL),
+		"signature_public_key_files":            len(cfg.PluginSettings.SignaturePublicKeyFiles),
+		"hot_reload_directories":                len(cfg.PluginSettings.HotReloadDirectories),
+		"chimera_oauth_proxy_url":               *cfg.PluginSettings.ChimeraOAuthProxyURL,
 	}
 
 	// knownPluginIDs lists all known plugin IDs in the Marketplace
@@ -0,0 +1,358 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+// Package ics implements a minimal parser for iCalendar (RFC 5545) feeds,
+// sufficient to find out when the owner of a calendar is busy.
+package ics
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	dateLayout            = "20060102"
+	dateTimeLayout        = "20060102T150405"
+	utcDateTimeLayout     = "20060102T150405Z"
+	maxLineLength         = 64 * 1024
+	statusCancelled       = "CANCELLED"
+	transpTransparent     = "TRANSPARENT"
+	busyStatusOutOfOffice = "OOF"
+)
+
+// Event is a single VEVENT of a calendar.
+type Event struct {
+	UID     string
+	Summary string
+	Start   time.Time
+	End     time.Time
+	// AllDay is set for events whose start is a date rather than a date-time.
+	AllDay bool
+	// Transparent events do not block time on the calendar.
+	Transparent bool
+	Cancelled   bool
+	// OutOfOffice is set when the event is flagged as such by the calendar
+	// software (e.g. X-MICROSOFT-CDO-BUSYSTATUS:OOF).
+	OutOfOffice bool
+
+	recurrence   *recurrence
+	exceptions   map[int64]struct{}
+	recurrenceID time.Time
+}
+
+// Occurrence is a single instance of a possibly recurring event.
+type Occurrence struct {
+	Event *Event
+	Start time.Time
+	End   time.Time
+}
+
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Parse reads all the events of a calendar. Floating times and dates are
+// interpreted in the given location, as are times referencing a time
+// zone which is unknown to the system.
+func Parse(r io.Reader, loc *time.Location) ([]*Event, error) {
+	if loc == nil {
+		loc = time.UTC
+	}
+
+	props, err := readProperties(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*Event
+	var current *Event
+	var duration time.Duration
+	var hasEnd bool
+	depth := 0
+	for _, prop := range props {
+		switch prop.name {
+		case "BEGIN":
+			if current != nil {
+				// Nested components such as VALARM are skipped.
+				depth++
+				continue
+			}
+			if strings.EqualFold(prop.value, "VEVENT") {
+				current = &Event{exceptions: map[int64]struct{}{}}
+				duration = 0
+				hasEnd = false
+			}
+			continue
+		case "END":
+			if current == nil {
+				continue
+			}
+			if depth > 0 {
+				depth--
+				continue
+			}
+			if current.Start.IsZero() {
+				return nil, errors.New("event without a start")
+			}
+			if !hasEnd {
+				switch {
+				case duration > 0:
+					current.End = current.Start.Add(duration)
+				case current.AllDay:
+					current.End = current.Start.AddDate(0, 0, 1)
+				default:
+					current.End = current.Start
+				}
+			}
+			events = append(events, current)
+			current = nil
+			continue
+		}
+
+		if current == nil || depth > 0 {
+			continue
+		}
+
+		switch prop.name {
+		case "UID":
+			current.UID = prop.value
+		case "SUMMARY":
+			current.Summary = unescapeText(prop.value)
+		case "STATUS":
+			current.Cancelled = strings.EqualFold(prop.value, statusCancelled)
+		case "TRANSP":
+			current.Transparent = strings.EqualFold(prop.value, transpTransparent)
+		case "X-MICROSOFT-CDO-BUSYSTATUS":
+			current.OutOfOffice = strings.EqualFold(prop.value, busyStatusOutOfOffice)
+		case "DTSTART":
+			current.Start, current.AllDay, err = parseTime(prop, loc)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid DTSTART")
+			}
+		case "DTEND":
+			current.End, _, err = parseTime(prop, loc)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid DTEND")
+			}
+			hasEnd = true
+		case "DURATION":
+			duration, err = parseDuration(prop.value)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid DURATION")
+			}
+		case "RRULE":
+			// Rules which are not supported are ignored rather than failing
+			// the whole calendar, leaving only the first occurrence.
+			if rule, ruleErr := parseRecurrence(prop.value, loc); ruleErr == nil {
+				current.recurrence = rule
+			}
+		case "EXDATE":
+			for _, value := range strings.Split(prop.value, ",") {
+				t, _, err := parseTime(property{name: prop.name, params: prop.params, value: value}, loc)
+				if err != nil {
+					return nil, errors.Wrap(err, "invalid EXDATE")
+				}
+				current.exceptions[t.Unix()] = struct{}{}
+			}
+		case "RECURRENCE-ID":
+			current.recurrenceID, _, err = parseTime(prop, loc)
+			if err != nil {
+				return nil, errors.Wrap(err, "invalid RECURRENCE-ID")
+			}
+		}
+	}
+
+	// Modified instances of a recurring event replace the original occurrence.
+	byUID := make(map[string]*Event, len(events))
+	for _, event := range events {
+		if event.recurrenceID.IsZero() && event.UID != "" {
+			byUID[event.UID] = event
+		}
+	}
+	for _, event := range events {
+		if event.recurrenceID.IsZero() {
+			continue
+		}
+		if master, ok := byUID[event.UID]; ok {
+			master.exceptions[event.recurrenceID.Unix()] = struct{}{}
+		}
+	}
+
+	return events, nil
+}
+
+// Occurrences returns the instances of the event overlapping the given
+// time range, ordered by start time.
+func (e *Event) Occurrences(from, to time.Time) []Occurrence {
+	length := e.End.Sub(e.Start)
+	overlaps := func(start time.Time) bool {
+		return start.Before(to) && start.Add(length).After(from)
+	}
+
+	var occurrences []Occurrence
+	if e.recurrence == nil {
+		if overlaps(e.Start) {
+			occurrences = append(occurrences, Occurrence{Event: e, Start: e.Start, End: e.End})
+		}
+		return occurrences
+	}
+
+	e.recurrence.iterate(e.Start, func(start time.Time) bool {
+		if !start.Before(to) {
+			return false
+		}
+		if _, excluded := e.exceptions[start.Unix()]; !excluded && overlaps(start) {
+			occurrences = append(occurrences, Occurrence{Event: e, Start: start, End: start.Add(length)})
+		}
+		return true
+	})
+	return occurrences
+}
+
+func readProperties(r io.Reader) ([]property, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineLength)
+
+	// Long lines are folded by inserting a line break followed by
+	// a single whitespace character.
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if len(lines) == 0 {
+			line = strings.TrimPrefix(line, "\ufeff")
+		}
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.Wrap(err, "failed to read calendar")
+	}
+
+	if len(lines) == 0 || !strings.EqualFold(lines[0], "BEGIN:VCALENDAR") {
+		return nil, errors.New("not an iCalendar file")
+	}
+
+	props := make([]property, 0, len(lines))
+	for _, line := range lines {
+		prop, err := parseProperty(line)
+		if err != nil {
+			return nil, err
+		}
+		props = append(props, prop)
+	}
+	return props, nil
+}
+
+func parseProperty(line string) (property, error) {
+	// The value starts at the first colon which is not inside a quoted parameter.
+	inQuotes := false
+	sep := -1
+	for i, c := range line {
+		if c == '"' {
+			inQuotes = !inQuotes
+		} else if c == ':' && !inQuotes {
+			sep = i
+			break
+		}
+	}
+	if sep == -1 {
+		return property{}, fmt.Errorf("invalid content line %q", line)
+	}
+
+	parts := strings.Split(line[:sep], ";")
+	prop := property{
+		name:   strings.ToUpper(parts[0]),
+		params: make(map[string]string, len(parts)-1),
+		value:  line[sep+1:],
+	}
+	for _, param := range parts[1:] {
+		key, value, _ := strings.Cut(param, "=")
+		prop.params[strings.ToUpper(key)] = strings.Trim(value, `"`)
+	}
+	return prop, nil
+}
+
+func parseTime(prop property, loc *time.Location) (time.Time, bool, error) {
+	value := strings.TrimSpace(prop.value)
+	if strings.EqualFold(prop.params["VALUE"], "DATE") || len(value) == len(dateLayout) {
+		t, err := time.ParseInLocation(dateLayout, value, loc)
+		return t, true, err
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcDateTimeLayout, value)
+		return t, false, err
+	}
+
+	if tzid := prop.params["TZID"]; tzid != "" {
+		if tzLoc, err := time.LoadLocation(tzid); err == nil {
+			loc = tzLoc
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeLayout, value, loc)
+	return t, false, err
+}
+
+// parseDuration parses a duration value such as "PT1H30M" or "P1D".
+func parseDuration(value string) (time.Duration, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "+")
+	if !strings.HasPrefix(value, "P") {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+
+	var total time.Duration
+	inTime := false
+	number := ""
+	for _, c := range value[1:] {
+		switch {
+		case c >= '0' && c <= '9':
+			number += string(c)
+			continue
+		case c == 'T':
+			inTime = true
+			continue
+		}
+
+		n, err := strconv.Atoi(number)
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+		number = ""
+
+		switch {
+		case c == 'W':
+			total += time.Duration(n) * 7 * 24 * time.Hour
+		case c == 'D':
+			total += time.Duration(n) * 24 * time.Hour
+		case c == 'H' && inTime:
+			total += time.Duration(n) * time.Hour
+		case c == 'M' && inTime:
+			total += time.Duration(n) * time.Minute
+		case c == 'S' && inTime:
+			total += time.Duration(n) * time.Second
+		default:
+			return 0, fmt.Errorf("invalid duration %q", value)
+		}
+	}
+	if number != "" {
+		return 0, fmt.Errorf("invalid duration %q", value)
+	}
+	return total, nil
+}
+
+func unescapeText(value string) string {
+	return strings.NewReplacer(`\\`, `\`, `\;`, `;`, `\,`, `,`, `\n`, "\n", `\N`, "\n").Replace(value)
+}
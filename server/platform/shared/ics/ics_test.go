@@ -0,0 +1,191 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package ics
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func calendar(lines ...string) string {
+	return "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" + strings.Join(lines, "\r\n") + "\r\nEND:VCALENDAR\r\n"
+}
+
+func TestParse(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	require.NoError(t, err)
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	t.Run("not a calendar", func(t *testing.T) {
+		_, err := Parse(strings.NewReader("hello"), time.UTC)
+		require.Error(t, err)
+	})
+
+	t.Run("event without start", func(t *testing.T) {
+		_, err := Parse(strings.NewReader(calendar("BEGIN:VEVENT", "SUMMARY:Nothing", "END:VEVENT")), time.UTC)
+		require.Error(t, err)
+	})
+
+	t.Run("times, dates and properties", func(t *testing.T) {
+		events, err := Parse(strings.NewReader(calendar(
+			"BEGIN:VEVENT",
+			"UID:1",
+			"SUMMARY:Standup\\, daily",
+			"DTSTART:20240102T150000Z",
+			"DTEND:20240102T151500Z",
+			"BEGIN:VALARM",
+			"TRIGGER:-PT15M",
+			"END:VALARM",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:2",
+			"SUMMARY:Planning",
+			"DTSTART;TZID=Europe/Berlin:20240102T100000",
+			"DURATION:PT1H30M",
+			"TRANSP:TRANSPARENT",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:3",
+			"SUMMARY:Out of",
+			"  office",
+			"DTSTART;VALUE=DATE:20240103",
+			"X-MICROSOFT-CDO-BUSYSTATUS:OOF",
+			"STATUS:CANCELLED",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:4",
+			"DTSTART:20240104T090000",
+			"DTEND;TZID=Unknown Zone:20240104T100000",
+			"END:VEVENT",
+		)), newYork)
+		require.NoError(t, err)
+		require.Len(t, events, 4)
+
+		assert.Equal(t, "Standup, daily", events[0].Summary)
+		assert.Equal(t, time.Date(2024, 1, 2, 15, 0, 0, 0, time.UTC), events[0].Start)
+		assert.Equal(t, time.Date(2024, 1, 2, 15, 15, 0, 0, time.UTC), events[0].End)
+		assert.False(t, events[0].AllDay)
+
+		assert.True(t, events[1].Start.Equal(time.Date(2024, 1, 2, 10, 0, 0, 0, berlin)))
+		assert.Equal(t, 90*time.Minute, events[1].End.Sub(events[1].Start))
+		assert.True(t, events[1].Transparent)
+
+		assert.Equal(t, "Out of office", events[2].Summary)
+		assert.True(t, events[2].AllDay)
+		assert.True(t, events[2].OutOfOffice)
+		assert.True(t, events[2].Cancelled)
+		assert.True(t, events[2].Start.Equal(time.Date(2024, 1, 3, 0, 0, 0, 0, newYork)))
+		assert.True(t, events[2].End.Equal(time.Date(2024, 1, 4, 0, 0, 0, 0, newYork)))
+
+		// Floating times and unknown time zones use the given location.
+		assert.True(t, events[3].Start.Equal(time.Date(2024, 1, 4, 9, 0, 0, 0, newYork)))
+		assert.True(t, events[3].End.Equal(time.Date(2024, 1, 4, 10, 0, 0, 0, newYork)))
+	})
+}
+
+func TestOccurrences(t *testing.T) {
+	parse := func(t *testing.T, lines ...string) *Event {
+		t.Helper()
+		events, err := Parse(strings.NewReader(calendar(lines...)), time.UTC)
+		require.NoError(t, err)
+		require.NotEmpty(t, events)
+		return events[0]
+	}
+	starts := func(occurrences []Occurrence) []string {
+		var result []string
+		for _, o := range occurrences {
+			result = append(result, o.Start.Format("2006-01-02 15:04 Mon"))
+		}
+		return result
+	}
+	jan := func(day, hour int) time.Time {
+		return time.Date(2024, 1, day, hour, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("single event", func(t *testing.T) {
+		event := parse(t, "BEGIN:VEVENT", "DTSTART:20240102T090000Z", "DTEND:20240102T100000Z", "END:VEVENT")
+		assert.Len(t, event.Occurrences(jan(2, 9), jan(2, 10)), 1)
+		assert.Len(t, event.Occurrences(jan(2, 8), jan(2, 9)), 0)
+		assert.Len(t, event.Occurrences(jan(2, 10), jan(2, 11)), 0)
+	})
+
+	t.Run("daily with count", func(t *testing.T) {
+		event := parse(t, "BEGIN:VEVENT", "DTSTART:20240101T090000Z", "DTEND:20240101T093000Z", "RRULE:FREQ=DAILY;COUNT=3", "END:VEVENT")
+		assert.Equal(t, []string{"2024-01-01 09:00 Mon", "2024-01-02 09:00 Tue", "2024-01-03 09:00 Wed"}, starts(event.Occurrences(jan(1, 0), jan(31, 0))))
+	})
+
+	t.Run("weekly by day with until and exceptions", func(t *testing.T) {
+		event := parse(t,
+			"BEGIN:VEVENT",
+			"UID:weekly",
+			"DTSTART:20240102T090000Z",
+			"DTEND:20240102T100000Z",
+			"RRULE:FREQ=WEEKLY;BYDAY=MO,TU,TH;UNTIL=20240112T000000Z",
+			"EXDATE:20240104T090000Z",
+			"END:VEVENT",
+			"BEGIN:VEVENT",
+			"UID:weekly",
+			"RECURRENCE-ID:20240109T090000Z",
+			"DTSTART:20240109T130000Z",
+			"DTEND:20240109T140000Z",
+			"END:VEVENT",
+		)
+		assert.Equal(t, []string{
+			"2024-01-02 09:00 Tue",
+			"2024-01-08 09:00 Mon",
+			"2024-01-11 09:00 Thu",
+		}, starts(event.Occurrences(jan(1, 0), jan(31, 0))))
+	})
+
+	t.Run("monthly skips short months", func(t *testing.T) {
+		event := parse(t, "BEGIN:VEVENT", "DTSTART:20240131T090000Z", "DTEND:20240131T100000Z", "RRULE:FREQ=MONTHLY", "END:VEVENT")
+		occurrences := event.Occurrences(jan(1, 0), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))
+		assert.Equal(t, []string{"2024-01-31 09:00 Wed", "2024-03-31 09:00 Sun", "2024-05-31 09:00 Fri"}, starts(occurrences))
+	})
+
+	t.Run("every other week", func(t *testing.T) {
+		event := parse(t, "BEGIN:VEVENT", "DTSTART:20240101T090000Z", "DTEND:20240101T100000Z", "RRULE:FREQ=WEEKLY;INTERVAL=2", "END:VEVENT")
+		assert.Equal(t, []string{"2024-01-01 09:00 Mon", "2024-01-15 09:00 Mon", "2024-01-29 09:00 Mon"}, starts(event.Occurrences(jan(1, 0), jan(31, 0))))
+	})
+
+	t.Run("long running occurrence overlapping the range", func(t *testing.T) {
+		event := parse(t, "BEGIN:VEVENT", "DTSTART;VALUE=DATE:20200101", "DTEND;VALUE=DATE:20200103", "RRULE:FREQ=YEARLY", "END:VEVENT")
+		occurrences := event.Occurrences(jan(2, 12), jan(2, 13))
+		require.Len(t, occurrences, 1)
+		assert.Equal(t, jan(1, 0), occurrences[0].Start)
+		assert.Equal(t, jan(3, 0), occurrences[0].End)
+	})
+
+	t.Run("unsupported rule", func(t *testing.T) {
+		event := parse(t, "BEGIN:VEVENT", "DTSTART:20240101T090000Z", "DTEND:20240101T100000Z", "RRULE:FREQ=MONTHLY;BYDAY=1MO", "END:VEVENT")
+		assert.Equal(t, []string{"2024-01-01 09:00 Mon"}, starts(event.Occurrences(jan(1, 0), time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC))))
+	})
+}
+
+func TestParseDuration(t *testing.T) {
+	for value, expected := range map[string]time.Duration{
+		"PT15M":    15 * time.Minute,
+		"PT1H30M":  90 * time.Minute,
+		"P1D":      24 * time.Hour,
+		"P1W":      7 * 24 * time.Hour,
+		"P1DT2H":   26 * time.Hour,
+		"+PT10S":   10 * time.Second,
+		"P0D":      0,
+		"PT1H0M0S": time.Hour,
+	} {
+		d, err := parseDuration(value)
+		require.NoError(t, err, value)
+		assert.Equal(t, expected, d, value)
+	}
+
+	for _, value := range []string{"", "1H", "PT1", "P1H", "PTXM"} {
+		_, err := parseDuration(value)
+		assert.Error(t, err, value)
+	}
+}
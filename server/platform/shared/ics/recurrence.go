@@ -0,0 +1,163 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package ics
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxIterations bounds the expansion of recurrence rules without an end.
+const maxIterations = 100000
+
+type frequency int
+
+const (
+	daily frequency = iota
+	weekly
+	monthly
+	yearly
+)
+
+var weekdays = map[string]time.Weekday{
+	"SU": time.Sunday,
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+}
+
+// recurrence is the subset of RRULE supported: FREQ, INTERVAL, COUNT,
+// UNTIL, and BYDAY with plain week days for weekly events.
+type recurrence struct {
+	freq     frequency
+	interval int
+	count    int
+	until    time.Time
+	byDay    []time.Weekday
+}
+
+func parseRecurrence(value string, loc *time.Location) (*recurrence, error) {
+	rule := &recurrence{interval: 1}
+	hasFreq := false
+	for _, part := range strings.Split(value, ";") {
+		key, val, _ := strings.Cut(part, "=")
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			hasFreq = true
+			switch strings.ToUpper(val) {
+			case "DAILY":
+				rule.freq = daily
+			case "WEEKLY":
+				rule.freq = weekly
+			case "MONTHLY":
+				rule.freq = monthly
+			case "YEARLY":
+				rule.freq = yearly
+			default:
+				return nil, fmt.Errorf("unsupported frequency %q", val)
+			}
+		case "INTERVAL":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid interval %q", val)
+			}
+			rule.interval = n
+		case "COUNT":
+			n, err := strconv.Atoi(val)
+			if err != nil || n < 1 {
+				return nil, fmt.Errorf("invalid count %q", val)
+			}
+			rule.count = n
+		case "UNTIL":
+			until, _, err := parseTime(property{value: val, params: map[string]string{}}, loc)
+			if err != nil {
+				return nil, fmt.Errorf("invalid until %q", val)
+			}
+			rule.until = until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				weekday, ok := weekdays[strings.ToUpper(day)]
+				if !ok {
+					return nil, fmt.Errorf("unsupported day %q", day)
+				}
+				rule.byDay = append(rule.byDay, weekday)
+			}
+		}
+	}
+	if !hasFreq {
+		return nil, fmt.Errorf("missing frequency")
+	}
+	return rule, nil
+}
+
+// iterate calls fn with the start of each occurrence, in order, until fn
+// returns false or the rule ends.
+func (r *recurrence) iterate(start time.Time, fn func(time.Time) bool) {
+	emitted := 0
+	emit := func(t time.Time) bool {
+		if !r.until.IsZero() && t.After(r.until) {
+			return false
+		}
+		if r.count > 0 && emitted >= r.count {
+			return false
+		}
+		emitted++
+		return fn(t)
+	}
+
+	for i := 0; i < maxIterations; i++ {
+		var period time.Time
+		switch r.freq {
+		case daily:
+			period = start.AddDate(0, 0, i*r.interval)
+		case weekly:
+			period = start.AddDate(0, 0, 7*i*r.interval)
+		case monthly:
+			period = start.AddDate(0, i*r.interval, 0)
+			// Months without the given day are skipped.
+			if period.Day() != start.Day() {
+				continue
+			}
+		case yearly:
+			period = start.AddDate(i*r.interval, 0, 0)
+			if period.Day() != start.Day() {
+				continue
+			}
+		}
+
+		if r.freq != weekly || len(r.byDay) == 0 {
+			if !emit(period) {
+				return
+			}
+			continue
+		}
+
+		// Weeks start on Monday, and each listed day of the
+		// week falls at the time of day of the first occurrence.
+		weekStart := period.AddDate(0, 0, -((int(period.Weekday()) + 6) % 7))
+		for offset := 0; offset < 7; offset++ {
+			day := weekStart.AddDate(0, 0, offset)
+			if !containsWeekday(r.byDay, day.Weekday()) || day.Before(start) {
+				continue
+			}
+			if !emit(day) {
+				return
+			}
+		}
+	}
+}
+
+func containsWeekday(days []time.Weekday, day time.Weekday) bool {
+	for _, d := range days {
+		if d == day {
+			return true
+		}
+	}
+	return false
+}
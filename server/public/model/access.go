@@ -8,9 +8,11 @@ import (
 )
 
 const (
-	AccessTokenGrantType  = "authorization_code"
-	AccessTokenType       = "bearer"
-	RefreshTokenGrantType = "refresh_token"
+	AccessTokenGrantType       = "authorization_code"
+	AccessTokenType            = "bearer"
+	RefreshTokenGrantType      = "refresh_token"
+	ClientCredentialsGrantType = "client_credentials"
+	DeviceCodeGrantType        = "urn:ietf:params:oauth:grant-type:device_code"
 )
 
 type AccessData struct {
@@ -21,6 +23,9 @@ type AccessData struct {
 	RedirectUri  string `json:"redirect_uri"`
 	ExpiresAt    int64  `json:"expires_at"`
 	Scope        string `json:"scope"`
+	// GrantType is the grant the token was issued for when issued without a redirection, by
+	// the client credentials and device flows, and empty otherwise.
+	GrantType string `json:"grant_type"`
 }
 
 type AccessResponse struct {
@@ -51,6 +56,16 @@ func (ad *AccessData) IsValid() *AppError {
 		return NewAppError("AccessData.IsValid", "model.access.is_valid.refresh_token.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if ad.GrantType != "" && ad.GrantType != ClientCredentialsGrantType && ad.GrantType != DeviceCodeGrantType {
+		return NewAppError("AccessData.IsValid", "model.access.is_valid.grant_type.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// Tokens issued without a redirection, by the client credentials and device flows, don't
+	// have a redirect URI.
+	if ad.GrantType != "" && ad.RedirectUri == "" {
+		return nil
+	}
+
 	if ad.RedirectUri == "" || len(ad.RedirectUri) > 256 || !IsValidHTTPURL(ad.RedirectUri) {
 		return NewAppError("AccessData.IsValid", "model.access.is_valid.redirect_uri.app_error", nil, "", http.StatusBadRequest)
 	}
@@ -0,0 +1,473 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	AccessPolicyOperandUsername    = "user.username"
+	AccessPolicyOperandEmail       = "user.email"
+	AccessPolicyOperandEmailDomain = "user.email_domain"
+	AccessPolicyOperandPosition    = "user.position"
+	AccessPolicyOperandAuthService = "user.auth_service"
+	AccessPolicyOperandLocale      = "user.locale"
+	AccessPolicyOperandRoles       = "user.roles"
+
+	// AccessPolicyOperandAttributesPrefix prefixes the name of a SAML or OpenID Connect
+	// attribute of the user, e.g. user.attributes.department or user.attributes["urn:oid:2.5.4.11"].
+	AccessPolicyOperandAttributesPrefix = "user.attributes."
+)
+
+// AccessPolicyExpression is a parsed expression of a channel access policy. Expressions compare
+// operands describing the user to string literals, e.g.
+//
+//	user.email_domain == "example.com" && (user.attributes.department in ["Sales", "Support"] || user.position != "Contractor")
+//
+// Operands having several values, like user.roles or multi-valued attributes, are equal to a
+// literal when any of their values is. Custom profile fields aren't operands, since users don't
+// have any in this version.
+type AccessPolicyExpression struct {
+	root           accessPolicyNode
+	attributeNames []string
+}
+
+// ParseAccessPolicyExpression parses the expression of a channel access policy.
+func ParseAccessPolicyExpression(expression string) (*AccessPolicyExpression, error) {
+	tokens, err := lexAccessPolicyExpression(expression)
+	if err != nil {
+		return nil, err
+	}
+
+	p := &accessPolicyParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if tok := p.peek(); tok.kind != accessPolicyTokenEOF {
+		return nil, fmt.Errorf("unexpected %q at position %d", tok.text, tok.pos)
+	}
+
+	return &AccessPolicyExpression{root: root, attributeNames: p.attributeNames}, nil
+}
+
+// AttributeNames returns the names of the SAML or OpenID Connect attributes the expression
+// refers to.
+func (e *AccessPolicyExpression) AttributeNames() []string {
+	return e.attributeNames
+}
+
+// Evaluate returns whether the user, having the given SAML or OpenID Connect attributes,
+// satisfies the expression.
+func (e *AccessPolicyExpression) Evaluate(user *User, attributes map[string][]string) bool {
+	return e.root.evaluate(user, attributes)
+}
+
+// EvaluateWithoutAttributes evaluates the expression for a user whose SAML or OpenID Connect
+// attributes aren't known. It returns whether the user satisfies the expression, and whether the
+// result holds whatever the attributes of the user are.
+func (e *AccessPolicyExpression) EvaluateWithoutAttributes(user *User) (satisfied bool, decided bool) {
+	switch e.root.evaluateWithoutAttributes(user) {
+	case accessPolicyTrue:
+		return true, true
+	case accessPolicyFalse:
+		return false, true
+	}
+	return false, false
+}
+
+// accessPolicyTruth is the result of an expression evaluated without the attributes of the user,
+// which is unknown when it depends on them.
+type accessPolicyTruth int
+
+const (
+	accessPolicyFalse accessPolicyTruth = iota
+	accessPolicyTrue
+	accessPolicyUnknown
+)
+
+func accessPolicyTruthOf(value bool) accessPolicyTruth {
+	if value {
+		return accessPolicyTrue
+	}
+	return accessPolicyFalse
+}
+
+type accessPolicyNode interface {
+	evaluate(user *User, attributes map[string][]string) bool
+	evaluateWithoutAttributes(user *User) accessPolicyTruth
+}
+
+type accessPolicyOr struct{ left, right accessPolicyNode }
+
+func (n *accessPolicyOr) evaluate(user *User, attributes map[string][]string) bool {
+	return n.left.evaluate(user, attributes) || n.right.evaluate(user, attributes)
+}
+
+func (n *accessPolicyOr) evaluateWithoutAttributes(user *User) accessPolicyTruth {
+	left, right := n.left.evaluateWithoutAttributes(user), n.right.evaluateWithoutAttributes(user)
+	switch {
+	case left == accessPolicyTrue || right == accessPolicyTrue:
+		return accessPolicyTrue
+	case left == accessPolicyFalse && right == accessPolicyFalse:
+		return accessPolicyFalse
+	}
+	return accessPolicyUnknown
+}
+
+type accessPolicyAnd struct{ left, right accessPolicyNode }
+
+func (n *accessPolicyAnd) evaluate(user *User, attributes map[string][]string) bool {
+	return n.left.evaluate(user, attributes) && n.right.evaluate(user, attributes)
+}
+
+func (n *accessPolicyAnd) evaluateWithoutAttributes(user *User) accessPolicyTruth {
+	left, right := n.left.evaluateWithoutAttributes(user), n.right.evaluateWithoutAttributes(user)
+	switch {
+	case left == accessPolicyFalse || right == accessPolicyFalse:
+		return accessPolicyFalse
+	case left == accessPolicyTrue && right == accessPolicyTrue:
+		return accessPolicyTrue
+	}
+	return accessPolicyUnknown
+}
+
+type accessPolicyNot struct{ operand accessPolicyNode }
+
+func (n *accessPolicyNot) evaluate(user *User, attributes map[string][]string) bool {
+	return !n.operand.evaluate(user, attributes)
+}
+
+func (n *accessPolicyNot) evaluateWithoutAttributes(user *User) accessPolicyTruth {
+	switch n.operand.evaluateWithoutAttributes(user) {
+	case accessPolicyTrue:
+		return accessPolicyFalse
+	case accessPolicyFalse:
+		return accessPolicyTrue
+	}
+	return accessPolicyUnknown
+}
+
+// accessPolicyComparison is true when any value of the operand is among the literals, or,
+// when negated, when none is.
+type accessPolicyComparison struct {
+	operand  string
+	literals []string
+	negated  bool
+}
+
+func (n *accessPolicyComparison) evaluate(user *User, attributes map[string][]string) bool {
+	found := false
+	for _, value := range accessPolicyOperandValues(n.operand, user, attributes) {
+		for _, literal := range n.literals {
+			if value == literal {
+				found = true
+				break
+			}
+		}
+		if found {
+			break
+		}
+	}
+	return found != n.negated
+}
+
+func (n *accessPolicyComparison) evaluateWithoutAttributes(user *User) accessPolicyTruth {
+	if strings.HasPrefix(n.operand, AccessPolicyOperandAttributesPrefix) {
+		return accessPolicyUnknown
+	}
+	return accessPolicyTruthOf(n.evaluate(user, nil))
+}
+
+func accessPolicyOperandValues(operand string, user *User, attributes map[string][]string) []string {
+	switch operand {
+	case AccessPolicyOperandUsername:
+		return []string{user.Username}
+	case AccessPolicyOperandEmail:
+		return []string{user.Email}
+	case AccessPolicyOperandEmailDomain:
+		if i := strings.LastIndex(user.Email, "@"); i >= 0 {
+			return []string{strings.ToLower(user.Email[i+1:])}
+		}
+		return nil
+	case AccessPolicyOperandPosition:
+		return []string{user.Position}
+	case AccessPolicyOperandAuthService:
+		return []string{user.AuthService}
+	case AccessPolicyOperandLocale:
+		return []string{user.Locale}
+	case AccessPolicyOperandRoles:
+		return strings.Fields(user.Roles)
+	}
+	return attributes[strings.TrimPrefix(operand, AccessPolicyOperandAttributesPrefix)]
+}
+
+func isValidAccessPolicyOperand(operand string) bool {
+	switch operand {
+	case AccessPolicyOperandUsername, AccessPolicyOperandEmail, AccessPolicyOperandEmailDomain,
+		AccessPolicyOperandPosition, AccessPolicyOperandAuthService, AccessPolicyOperandLocale,
+		AccessPolicyOperandRoles:
+		return true
+	}
+	return strings.HasPrefix(operand, AccessPolicyOperandAttributesPrefix) && len(operand) > len(AccessPolicyOperandAttributesPrefix)
+}
+
+type accessPolicyTokenKind int
+
+const (
+	accessPolicyTokenEOF accessPolicyTokenKind = iota
+	accessPolicyTokenIdentifier
+	accessPolicyTokenString
+	accessPolicyTokenOr
+	accessPolicyTokenAnd
+	accessPolicyTokenNot
+	accessPolicyTokenEqual
+	accessPolicyTokenNotEqual
+	accessPolicyTokenIn
+	accessPolicyTokenLeftParen
+	accessPolicyTokenRightParen
+	accessPolicyTokenLeftBracket
+	accessPolicyTokenRightBracket
+	accessPolicyTokenComma
+)
+
+type accessPolicyToken struct {
+	kind accessPolicyTokenKind
+	text string
+	pos  int
+}
+
+var accessPolicyPunctuation = map[rune]accessPolicyTokenKind{
+	'(': accessPolicyTokenLeftParen,
+	')': accessPolicyTokenRightParen,
+	'[': accessPolicyTokenLeftBracket,
+	']': accessPolicyTokenRightBracket,
+	',': accessPolicyTokenComma,
+}
+
+// accessPolicyOperators are the operators written as a doubled character.
+var accessPolicyOperators = map[rune]accessPolicyTokenKind{
+	'|': accessPolicyTokenOr,
+	'&': accessPolicyTokenAnd,
+	'=': accessPolicyTokenEqual,
+}
+
+func lexAccessPolicyExpression(expression string) ([]accessPolicyToken, error) {
+	var tokens []accessPolicyToken
+	runes := []rune(expression)
+
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case accessPolicyPunctuation[r] != accessPolicyTokenEOF:
+			tokens = append(tokens, accessPolicyToken{kind: accessPolicyPunctuation[r], text: string(r), pos: i})
+			i++
+		case accessPolicyOperators[r] != accessPolicyTokenEOF:
+			if i+1 >= len(runes) || runes[i+1] != r {
+				return nil, fmt.Errorf("unexpected %q at position %d", string(r), i)
+			}
+			tokens = append(tokens, accessPolicyToken{kind: accessPolicyOperators[r], text: string(runes[i : i+2]), pos: i})
+			i += 2
+		case r == '!':
+			if i+1 < len(runes) && runes[i+1] == '=' {
+				tokens = append(tokens, accessPolicyToken{kind: accessPolicyTokenNotEqual, text: "!=", pos: i})
+				i += 2
+			} else {
+				tokens = append(tokens, accessPolicyToken{kind: accessPolicyTokenNot, text: "!", pos: i})
+				i++
+			}
+		case r == '"':
+			start := i
+			var value strings.Builder
+			i++
+			for ; i < len(runes) && runes[i] != '"'; i++ {
+				if runes[i] == '\\' && i+1 < len(runes) {
+					i++
+				}
+				value.WriteRune(runes[i])
+			}
+			if i >= len(runes) {
+				return nil, fmt.Errorf("unterminated string at position %d", start)
+			}
+			i++
+			tokens = append(tokens, accessPolicyToken{kind: accessPolicyTokenString, text: value.String(), pos: start})
+		case unicode.IsLetter(r) || r == '_':
+			start := i
+			for i < len(runes) && (unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i]) || runes[i] == '_' || runes[i] == '.' || runes[i] == '-') {
+				i++
+			}
+			text := string(runes[start:i])
+			kind := accessPolicyTokenIdentifier
+			if text == "in" {
+				kind = accessPolicyTokenIn
+			}
+			tokens = append(tokens, accessPolicyToken{kind: kind, text: text, pos: start})
+		default:
+			return nil, fmt.Errorf("unexpected %q at position %d", string(r), i)
+		}
+	}
+
+	return append(tokens, accessPolicyToken{kind: accessPolicyTokenEOF, text: "end of expression", pos: len(runes)}), nil
+}
+
+type accessPolicyParser struct {
+	tokens         []accessPolicyToken
+	pos            int
+	attributeNames []string
+}
+
+func (p *accessPolicyParser) peek() accessPolicyToken {
+	return p.tokens[p.pos]
+}
+
+func (p *accessPolicyParser) next() accessPolicyToken {
+	tok := p.tokens[p.pos]
+	if tok.kind != accessPolicyTokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+func (p *accessPolicyParser) expect(kind accessPolicyTokenKind, what string) (accessPolicyToken, error) {
+	tok := p.next()
+	if tok.kind != kind {
+		return tok, fmt.Errorf("expected %s at position %d, got %q", what, tok.pos, tok.text)
+	}
+	return tok, nil
+}
+
+func (p *accessPolicyParser) parseOr() (accessPolicyNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == accessPolicyTokenOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &accessPolicyOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *accessPolicyParser) parseAnd() (accessPolicyNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == accessPolicyTokenAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &accessPolicyAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *accessPolicyParser) parseUnary() (accessPolicyNode, error) {
+	switch p.peek().kind {
+	case accessPolicyTokenNot:
+		p.next()
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &accessPolicyNot{operand: operand}, nil
+	case accessPolicyTokenLeftParen:
+		p.next()
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := p.expect(accessPolicyTokenRightParen, "\")\""); err != nil {
+			return nil, err
+		}
+		return node, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *accessPolicyParser) parseComparison() (accessPolicyNode, error) {
+	operand, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	tok := p.next()
+	switch tok.kind {
+	case accessPolicyTokenEqual, accessPolicyTokenNotEqual:
+		literal, err := p.expect(accessPolicyTokenString, "a string")
+		if err != nil {
+			return nil, err
+		}
+		return &accessPolicyComparison{
+			operand:  operand,
+			literals: []string{literal.text},
+			negated:  tok.kind == accessPolicyTokenNotEqual,
+		}, nil
+	case accessPolicyTokenIn:
+		if _, err := p.expect(accessPolicyTokenLeftBracket, "\"[\""); err != nil {
+			return nil, err
+		}
+		var literals []string
+		for {
+			literal, err := p.expect(accessPolicyTokenString, "a string")
+			if err != nil {
+				return nil, err
+			}
+			literals = append(literals, literal.text)
+			if p.peek().kind != accessPolicyTokenComma {
+				break
+			}
+			p.next()
+		}
+		if _, err := p.expect(accessPolicyTokenRightBracket, "\"]\""); err != nil {
+			return nil, err
+		}
+		return &accessPolicyComparison{operand: operand, literals: literals}, nil
+	}
+
+	return nil, fmt.Errorf("expected \"==\", \"!=\" or \"in\" at position %d, got %q", tok.pos, tok.text)
+}
+
+// parseOperand parses an operand, where attribute names that aren't identifiers are quoted
+// between brackets, e.g. user.attributes["urn:oid:2.5.4.11"].
+func (p *accessPolicyParser) parseOperand() (string, error) {
+	tok, err := p.expect(accessPolicyTokenIdentifier, "an operand")
+	if err != nil {
+		return "", err
+	}
+
+	operand := tok.text
+	if operand+"." == AccessPolicyOperandAttributesPrefix && p.peek().kind == accessPolicyTokenLeftBracket {
+		p.next()
+		name, err := p.expect(accessPolicyTokenString, "an attribute name")
+		if err != nil {
+			return "", err
+		}
+		if _, err := p.expect(accessPolicyTokenRightBracket, "\"]\""); err != nil {
+			return "", err
+		}
+		operand = AccessPolicyOperandAttributesPrefix + name.text
+	}
+
+	if !isValidAccessPolicyOperand(operand) {
+		return "", fmt.Errorf("unknown operand %q at position %d", operand, tok.pos)
+	}
+
+	if name, ok := strings.CutPrefix(operand, AccessPolicyOperandAttributesPrefix); ok && !slices.Contains(p.attributeNames, name) {
+		p.attributeNames = append(p.attributeNames, name)
+	}
+	return operand, nil
+}
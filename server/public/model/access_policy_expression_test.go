@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseAccessPolicyExpression(t *testing.T) {
+	for _, expression := range []string{
+		`user.position == "Engineer"`,
+		`user.email_domain != "example.com"`,
+		`user.roles in ["system_admin", "system_user"]`,
+		`!(user.auth_service == "saml") && user.locale == "en" || user.username == "alice"`,
+		`user.attributes.department == "Sales"`,
+		`user.attributes["urn:oid:2.5.4.11"] in ["Sales"]`,
+		`user.attributes.name == "with \"quotes\""`,
+	} {
+		_, err := ParseAccessPolicyExpression(expression)
+		assert.NoError(t, err, expression)
+	}
+
+	for _, expression := range []string{
+		``,
+		`user.position`,
+		`user.position = "Engineer"`,
+		`user.position == Engineer`,
+		`user.position == "Engineer`,
+		`user.unknown == "value"`,
+		`user.attributes. == "value"`,
+		`user.roles in []`,
+		`user.roles in ["a", ]`,
+		`(user.position == "Engineer"`,
+		`user.position == "Engineer")`,
+		`user.position == "Engineer" &&`,
+		`user.position == "Engineer" & user.locale == "en"`,
+		`user.position == "Engineer" user.locale == "en"`,
+	} {
+		_, err := ParseAccessPolicyExpression(expression)
+		assert.Error(t, err, expression)
+	}
+}
+
+func TestAccessPolicyExpressionEvaluate(t *testing.T) {
+	user := &User{
+		Username:    "alice",
+		Email:       "alice@Example.com",
+		Position:    "Engineer",
+		AuthService: UserAuthServiceSaml,
+		Locale:      "en",
+		Roles:       "system_user system_admin",
+	}
+	attributes := map[string][]string{
+		"department":       {"Sales", "Support"},
+		"urn:oid:2.5.4.11": {"Marketing"},
+	}
+
+	for expression, expected := range map[string]bool{
+		`user.username == "alice"`:                                               true,
+		`user.email == "alice@Example.com"`:                                      true,
+		`user.email_domain == "example.com"`:                                     true,
+		`user.email_domain != "example.com"`:                                     false,
+		`user.position == "Engineer"`:                                            true,
+		`user.position == "engineer"`:                                            false,
+		`user.auth_service == "saml"`:                                            true,
+		`user.locale in ["fr", "en"]`:                                            true,
+		`user.roles == "system_admin"`:                                           true,
+		`user.roles != "system_guest"`:                                           true,
+		`user.attributes.department == "Support"`:                                true,
+		`user.attributes.department != "Sales"`:                                  false,
+		`user.attributes.department in ["Finance", "Legal"]`:                     false,
+		`user.attributes["urn:oid:2.5.4.11"] == "Marketing"`:                     true,
+		`user.attributes.missing == "value"`:                                     false,
+		`user.attributes.missing != "value"`:                                     true,
+		`!(user.position == "Engineer")`:                                         false,
+		`user.position == "Manager" || user.locale == "en"`:                      true,
+		`user.position == "Engineer" && user.locale == "fr"`:                     false,
+		`user.locale == "fr" && user.locale == "de" || user.username == "alice"`: true,
+	} {
+		parsed, err := ParseAccessPolicyExpression(expression)
+		require.NoError(t, err, expression)
+		assert.Equal(t, expected, parsed.Evaluate(user, attributes), expression)
+	}
+}
+
+func TestAccessPolicyExpressionEvaluateWithoutAttributes(t *testing.T) {
+	user := &User{Username: "alice", Position: "Engineer", Locale: "en"}
+
+	for expression, expected := range map[string]struct{ satisfied, decided bool }{
+		`user.position == "Engineer"`:                                          {true, true},
+		`user.attributes.department == "Sales"`:                                {false, false},
+		`!(user.attributes.department == "Sales")`:                             {false, false},
+		`user.position == "Manager" && user.attributes.department == "Sales"`:  {false, true},
+		`user.position == "Engineer" && user.attributes.department == "Sales"`: {false, false},
+		`user.position == "Engineer" || user.attributes.department == "Sales"`: {true, true},
+		`user.position == "Manager" || user.attributes.department == "Sales"`:  {false, false},
+		`!(user.locale == "en" || user.attributes.department == "Sales")`:      {false, true},
+		`user.attributes.department == "Sales" && !(user.username == "alice")`: {false, true},
+	} {
+		parsed, err := ParseAccessPolicyExpression(expression)
+		require.NoError(t, err, expression)
+		satisfied, decided := parsed.EvaluateWithoutAttributes(user)
+		assert.Equal(t, expected.satisfied, satisfied, expression)
+		assert.Equal(t, expected.decided, decided, expression)
+	}
+}
+
+func TestAccessPolicyExpressionAttributeNames(t *testing.T) {
+	parsed, err := ParseAccessPolicyExpression(`user.attributes.department == "Sales" || user.position == "Engineer" && user.attributes["urn:oid:2.5.4.11"] != "Marketing" || user.attributes.department == "Support"`)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"department", "urn:oid:2.5.4.11"}, parsed.AttributeNames())
+}
@@ -52,4 +52,15 @@ func TestAccessIsValid(t *testing.T) {
 
 	ad.RedirectUri = "http://example.com"
 	require.Nil(t, ad.IsValid())
+
+	// Tokens issued by the client credentials and device flows don't have a redirect URI.
+	ad.RedirectUri = ""
+	ad.GrantType = DeviceCodeGrantType
+	require.Nil(t, ad.IsValid())
+
+	ad.GrantType = ClientCredentialsGrantType
+	require.Nil(t, ad.IsValid())
+
+	ad.GrantType = RefreshTokenGrantType
+	require.NotNil(t, ad.IsValid())
 }
@@ -4,7 +4,11 @@
 package model
 
 import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
 	"net/http"
+	"regexp"
 )
 
 const (
@@ -12,8 +16,14 @@ const (
 	AuthCodeResponseType = "code"
 	ImplicitResponseType = "token"
 	DefaultScope         = "user"
+
+	PKCECodeChallengeMethodPlain = "plain"
+	PKCECodeChallengeMethodS256  = "S256"
 )
 
+// pkceCodePattern matches the code verifiers and challenges allowed by RFC 7636.
+var pkceCodePattern = regexp.MustCompile(`^[A-Za-z0-9\-._~]{43,128}$`)
+
 type AuthData struct {
 	ClientId    string `json:"client_id"`
 	UserId      string `json:"user_id"`
@@ -23,14 +33,23 @@ type AuthData struct {
 	RedirectUri string `json:"redirect_uri"`
 	State       string `json:"state"`
 	Scope       string `json:"scope"`
+	// CodeChallenge and CodeChallengeMethod are set when the client uses PKCE, in which case
+	// the code must be exchanged along with the matching code verifier.
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+	// Nonce is given by OpenID Connect clients to be returned in the ID token.
+	Nonce string `json:"nonce"`
 }
 
 type AuthorizeRequest struct {
-	ResponseType string `json:"response_type"`
-	ClientId     string `json:"client_id"`
-	RedirectURI  string `json:"redirect_uri"`
-	Scope        string `json:"scope"`
-	State        string `json:"state"`
+	ResponseType        string `json:"response_type"`
+	ClientId            string `json:"client_id"`
+	RedirectURI         string `json:"redirect_uri"`
+	Scope               string `json:"scope"`
+	State               string `json:"state"`
+	CodeChallenge       string `json:"code_challenge"`
+	CodeChallengeMethod string `json:"code_challenge_method"`
+	Nonce               string `json:"nonce"`
 }
 
 // IsValid validates the AuthData and returns an error if it isn't configured
@@ -64,11 +83,15 @@ func (ad *AuthData) IsValid() *AppError {
 		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.state.app_error", nil, "client_id="+ad.ClientId, http.StatusBadRequest)
 	}
 
-	if len(ad.Scope) > 128 {
+	if len(ad.Scope) > 1024 {
 		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.scope.app_error", nil, "client_id="+ad.ClientId, http.StatusBadRequest)
 	}
 
-	return nil
+	if len(ad.Nonce) > OIDCNonceMaxLength {
+		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.nonce.app_error", nil, "client_id="+ad.ClientId, http.StatusBadRequest)
+	}
+
+	return isValidCodeChallenge(ad.CodeChallenge, ad.CodeChallengeMethod, ad.ClientId)
 }
 
 // IsValid validates the AuthorizeRequest and returns an error if it isn't configured
@@ -94,6 +117,29 @@ func (ar *AuthorizeRequest) IsValid() *AppError {
 		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.scope.app_error", nil, "client_id="+ar.ClientId, http.StatusBadRequest)
 	}
 
+	if len(ar.Nonce) > OIDCNonceMaxLength {
+		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.nonce.app_error", nil, "client_id="+ar.ClientId, http.StatusBadRequest)
+	}
+
+	return isValidCodeChallenge(ar.CodeChallenge, ar.CodeChallengeMethod, ar.ClientId)
+}
+
+func isValidCodeChallenge(challenge, method, clientId string) *AppError {
+	if challenge == "" {
+		if method != "" {
+			return NewAppError("AuthData.IsValid", "model.authorize.is_valid.code_challenge.app_error", nil, "client_id="+clientId, http.StatusBadRequest)
+		}
+		return nil
+	}
+
+	if !pkceCodePattern.MatchString(challenge) {
+		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.code_challenge.app_error", nil, "client_id="+clientId, http.StatusBadRequest)
+	}
+
+	if method != "" && method != PKCECodeChallengeMethodPlain && method != PKCECodeChallengeMethodS256 {
+		return NewAppError("AuthData.IsValid", "model.authorize.is_valid.code_challenge_method.app_error", nil, "client_id="+clientId, http.StatusBadRequest)
+	}
+
 	return nil
 }
 
@@ -109,8 +155,33 @@ func (ad *AuthData) PreSave() {
 	if ad.Scope == "" {
 		ad.Scope = DefaultScope
 	}
+
+	// The plain method is the default one of RFC 7636.
+	if ad.CodeChallenge != "" && ad.CodeChallengeMethod == "" {
+		ad.CodeChallengeMethod = PKCECodeChallengeMethodPlain
+	}
 }
 
 func (ad *AuthData) IsExpired() bool {
 	return GetMillis() > ad.CreateAt+int64(ad.ExpiresIn*1000)
 }
+
+// VerifyCodeVerifier returns true if the code verifier matches the code challenge the code was
+// requested with, or if the code was requested without PKCE and no verifier is given.
+func (ad *AuthData) VerifyCodeVerifier(verifier string) bool {
+	if ad.CodeChallenge == "" {
+		return verifier == ""
+	}
+
+	if !pkceCodePattern.MatchString(verifier) {
+		return false
+	}
+
+	challenge := verifier
+	if ad.CodeChallengeMethod == PKCECodeChallengeMethodS256 {
+		hash := sha256.Sum256([]byte(verifier))
+		challenge = base64.RawURLEncoding.EncodeToString(hash[:])
+	}
+
+	return subtle.ConstantTimeCompare([]byte(challenge), []byte(ad.CodeChallenge)) == 1
+}
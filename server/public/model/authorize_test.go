@@ -79,4 +79,67 @@ func TestAuthIsValid(t *testing.T) {
 
 	ad.RedirectUri = "http://example.com"
 	require.Nil(t, ad.IsValid())
+
+	ad.Nonce = NewRandomString(OIDCNonceMaxLength + 1)
+	require.NotNil(t, ad.IsValid(), "Should have failed invalid Nonce")
+
+	ad.Nonce = NewRandomString(OIDCNonceMaxLength)
+	require.Nil(t, ad.IsValid())
+}
+
+func TestAuthIsValidCodeChallenge(t *testing.T) {
+	ad := AuthData{
+		ClientId:    NewId(),
+		UserId:      NewId(),
+		Code:        NewId(),
+		ExpiresIn:   1,
+		CreateAt:    1,
+		RedirectUri: "http://example.com",
+	}
+	require.Nil(t, ad.IsValid())
+
+	ad.CodeChallengeMethod = PKCECodeChallengeMethodS256
+	appErr := ad.IsValid()
+	require.NotNil(t, appErr, "Should have failed method without challenge")
+	require.Equal(t, "model.authorize.is_valid.code_challenge.app_error", appErr.Id)
+
+	ad.CodeChallenge = "short"
+	appErr = ad.IsValid()
+	require.NotNil(t, appErr, "Should have failed challenge too short")
+	require.Equal(t, "model.authorize.is_valid.code_challenge.app_error", appErr.Id)
+
+	ad.CodeChallenge = NewRandomString(43)
+	require.Nil(t, ad.IsValid())
+
+	ad.CodeChallengeMethod = "junk"
+	appErr = ad.IsValid()
+	require.NotNil(t, appErr, "Should have failed invalid method")
+	require.Equal(t, "model.authorize.is_valid.code_challenge_method.app_error", appErr.Id)
+}
+
+func TestAuthVerifyCodeVerifier(t *testing.T) {
+	// The example of RFC 7636 appendix B.
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	challenge := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+
+	t.Run("without PKCE", func(t *testing.T) {
+		ad := AuthData{}
+		require.True(t, ad.VerifyCodeVerifier(""))
+		require.False(t, ad.VerifyCodeVerifier(verifier))
+	})
+
+	t.Run("S256", func(t *testing.T) {
+		ad := AuthData{CodeChallenge: challenge, CodeChallengeMethod: PKCECodeChallengeMethodS256}
+		require.True(t, ad.VerifyCodeVerifier(verifier))
+		require.False(t, ad.VerifyCodeVerifier(""))
+		require.False(t, ad.VerifyCodeVerifier(challenge))
+	})
+
+	t.Run("plain", func(t *testing.T) {
+		ad := AuthData{CodeChallenge: verifier}
+		ad.PreSave()
+		require.Equal(t, PKCECodeChallengeMethodPlain, ad.CodeChallengeMethod)
+		require.True(t, ad.VerifyCodeVerifier(verifier))
+		require.False(t, ad.VerifyCodeVerifier(challenge))
+	})
 }
@@ -0,0 +1,56 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+const ChannelAccessPolicyExpressionMaxLength = 4096
+
+// ChannelAccessPolicy restricts the members of a channel to the users satisfying its expression.
+// Users who don't can't join or be added to the channel, and are removed from it by the
+// channel access policy enforcement job.
+type ChannelAccessPolicy struct {
+	ChannelId  string `json:"channel_id"`
+	Expression string `json:"expression"`
+	CreateAt   int64  `json:"create_at"`
+	UpdateAt   int64  `json:"update_at"`
+}
+
+func (p *ChannelAccessPolicy) PreSave() {
+	p.Expression = strings.TrimSpace(p.Expression)
+	if p.CreateAt == 0 {
+		p.CreateAt = GetMillis()
+	}
+	p.UpdateAt = GetMillis()
+}
+
+func (p *ChannelAccessPolicy) IsValid() *AppError {
+	if !IsValidId(p.ChannelId) {
+		return NewAppError("ChannelAccessPolicy.IsValid", "model.channel_access_policy.channel_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if p.Expression == "" || utf8.RuneCountInString(p.Expression) > ChannelAccessPolicyExpressionMaxLength {
+		return NewAppError("ChannelAccessPolicy.IsValid", "model.channel_access_policy.expression_length.app_error", map[string]any{"MaxLength": ChannelAccessPolicyExpressionMaxLength}, "", http.StatusBadRequest)
+	}
+
+	if _, err := ParseAccessPolicyExpression(p.Expression); err != nil {
+		return NewAppError("ChannelAccessPolicy.IsValid", "model.channel_access_policy.expression.app_error", map[string]any{"Error": err.Error()}, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return nil
+}
+
+// Allows returns whether the user, having the given SAML or OpenID Connect attributes, may be a
+// member of the channel. Policies failing to parse allow no one.
+func (p *ChannelAccessPolicy) Allows(user *User, attributes map[string][]string) bool {
+	expression, err := ParseAccessPolicyExpression(p.Expression)
+	if err != nil {
+		return false
+	}
+	return expression.Evaluate(user, attributes)
+}
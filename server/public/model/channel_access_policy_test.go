@@ -0,0 +1,43 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChannelAccessPolicyIsValid(t *testing.T) {
+	policy := &ChannelAccessPolicy{ChannelId: NewId(), Expression: `user.position == "Engineer"`}
+	assert.Nil(t, policy.IsValid())
+
+	invalid := *policy
+	invalid.ChannelId = "invalid"
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *policy
+	invalid.Expression = ""
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *policy
+	invalid.Expression = `user.position == "` + strings.Repeat("a", ChannelAccessPolicyExpressionMaxLength) + `"`
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *policy
+	invalid.Expression = `user.position ==`
+	assert.NotNil(t, invalid.IsValid())
+}
+
+func TestChannelAccessPolicyAllows(t *testing.T) {
+	user := &User{Position: "Engineer"}
+
+	policy := &ChannelAccessPolicy{ChannelId: NewId(), Expression: `user.attributes.department == "Sales"`}
+	assert.True(t, policy.Allows(user, map[string][]string{"department": {"Sales"}}))
+	assert.False(t, policy.Allows(user, nil))
+
+	policy.Expression = `user.position ==`
+	assert.False(t, policy.Allows(user, nil))
+}
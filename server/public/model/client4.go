@@ -432,6 +432,14 @@ func (c *Client4) dataRetentionPolicyRoute(policyID string) string {
 	return fmt.Sprintf(c.dataRetentionRoute()+"/policies/%v", policyID)
 }
 
+func (c *Client4) escalationPoliciesRoute() string {
+	return "/escalation_policies"
+}
+
+func (c *Client4) escalationPolicyRoute(policyID string) string {
+	return fmt.Sprintf(c.escalationPoliciesRoute()+"/%v", policyID)
+}
+
 func (c *Client4) elasticsearchRoute() string {
 	return "/elasticsearch"
 }
@@ -600,6 +608,10 @@ func (c *Client4) limitsRoute() string {
 	return "/limits"
 }
 
+func (c *Client4) eventStreamRoute() string {
+	return "/events"
+}
+
 func (c *Client4) GetServerLimits(ctx context.Context) (*ServerLimits, *Response, error) {
 	r, err := c.DoAPIGet(ctx, c.limitsRoute()+"/users", "")
 	if err != nil {
@@ -1569,6 +1581,16 @@ func (c *Client4) UpdateUserAuth(ctx context.Context, userId string, userAuth *U
 // UpdateUserMfa activates multi-factor authentication for a user if activate
 // is true and a valid code is provided. If activate is false, then code is not
 // required and multi-factor authentication is disabled for the user.
+// UnlockUser unlocks a user account locked after too many failed logins.
+func (c *Client4) UnlockUser(ctx context.Context, userId string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.userRoute(userId)+"/unlock", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 func (c *Client4) UpdateUserMfa(ctx context.Context, userId, code string, activate bool) (*Response, error) {
 	requestBody := make(map[string]any)
 	requestBody["activate"] = activate
@@ -1767,6 +1789,36 @@ func (c *Client4) GetSessions(ctx context.Context, userId, etag string) ([]*Sess
 	return list, BuildResponse(r), nil
 }
 
+// GetSessionActivity returns the activity of the sessions of a user, most recent first.
+func (c *Client4) GetSessionActivity(ctx context.Context, userId string, page, perPage int) ([]*SessionActivity, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.userRoute(userId)+"/sessions/activity"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*SessionActivity
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("GetSessionActivity", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return list, BuildResponse(r), nil
+}
+
+// GetSessionAnomalies returns the anomalous session activity of all users, most recent first.
+func (c *Client4) GetSessionAnomalies(ctx context.Context, page, perPage int) ([]*SessionActivity, *Response, error) {
+	query := fmt.Sprintf("?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.usersRoute()+"/sessions/activity/anomalies"+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*SessionActivity
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("GetSessionAnomalies", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return list, BuildResponse(r), nil
+}
+
 // RevokeSession revokes a user session based on the provided user id and session id strings.
 func (c *Client4) RevokeSession(ctx context.Context, userId, sessionId string) (*Response, error) {
 	requestBody := map[string]string{"session_id": sessionId}
@@ -1959,6 +2011,26 @@ func (c *Client4) CreateUserAccessToken(ctx context.Context, userId, description
 	return &uat, BuildResponse(r), nil
 }
 
+// CreateUserAccessTokenWithOptions will generate a user access token like
+// CreateUserAccessToken, optionally expiring at token.ExpiresAt and restricted to
+// token.Scopes. A non-blank token.Description is required.
+func (c *Client4) CreateUserAccessTokenWithOptions(ctx context.Context, userId string, token *UserAccessToken) (*UserAccessToken, *Response, error) {
+	buf, err := json.Marshal(token)
+	if err != nil {
+		return nil, BuildResponse(nil), NewAppError("CreateUserAccessTokenWithOptions", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.userRoute(userId)+"/tokens", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var uat UserAccessToken
+	if err := json.NewDecoder(r.Body).Decode(&uat); err != nil {
+		return nil, nil, NewAppError("CreateUserAccessTokenWithOptions", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &uat, BuildResponse(r), nil
+}
+
 // GetUserAccessTokens will get a page of access tokens' id, description, is_active
 // and the user_id in the system. The actual token will not be returned. Must have
 // the 'manage_system' permission.
@@ -6203,6 +6275,50 @@ func (c *Client4) DeauthorizeOAuthApp(ctx context.Context, appId string) (*Respo
 	return BuildResponse(r), nil
 }
 
+// CreateOAuthDeviceAuthorization starts the device authorization flow of an OAuth 2.0 client
+// application.
+func (c *Client4) CreateOAuthDeviceAuthorization(ctx context.Context, data url.Values) (*OAuthDeviceAuthorizationResponse, *Response, error) {
+	url := c.URL + "/oauth/device_authorization"
+	rq, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(data.Encode()))
+	if err != nil {
+		return nil, nil, err
+	}
+	rq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	rp, err := c.HTTPClient.Do(rq)
+	if err != nil {
+		return nil, BuildResponse(rp), err
+	}
+	defer closeBody(rp)
+
+	if rp.StatusCode >= 300 {
+		return nil, BuildResponse(rp), AppErrorFromJSON(rp.Body)
+	}
+
+	var dr *OAuthDeviceAuthorizationResponse
+	err = json.NewDecoder(rp.Body).Decode(&dr)
+	if err != nil {
+		return nil, BuildResponse(rp), NewAppError(url, "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+
+	return dr, BuildResponse(rp), nil
+}
+
+// AuthorizeOAuthDevice will approve or deny the authorization request of the device that was
+// given the user code.
+func (c *Client4) AuthorizeOAuthDevice(ctx context.Context, authorizeRequest *OAuthDeviceAuthorizeRequest) (*Response, error) {
+	buf, err := json.Marshal(authorizeRequest)
+	if err != nil {
+		return BuildResponse(nil), NewAppError("AuthorizeOAuthDevice", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIRequestBytes(ctx, http.MethodPost, c.URL+"/oauth/device", buf, "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetOAuthAccessToken is a test helper function for the OAuth access token endpoint.
 func (c *Client4) GetOAuthAccessToken(ctx context.Context, data url.Values) (*AccessResponse, *Response, error) {
 	url := c.URL + "/oauth/access_token"
@@ -6613,6 +6729,89 @@ func (c *Client4) GetChannelPoliciesForUser(ctx context.Context, userID string,
 	return &channels, BuildResponse(r), nil
 }
 
+// Escalation Policies Section
+
+// CreateEscalationPolicy creates an escalation policy for the persistent
+// notifications of a channel, or of every channel when ChannelId is empty.
+func (c *Client4) CreateEscalationPolicy(ctx context.Context, policy *EscalationPolicy) (*EscalationPolicy, *Response, error) {
+	buf, err := json.Marshal(policy)
+	if err != nil {
+		return nil, nil, NewAppError("CreateEscalationPolicy", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.escalationPoliciesRoute(), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var p EscalationPolicy
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		return nil, nil, NewAppError("CreateEscalationPolicy", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &p, BuildResponse(r), nil
+}
+
+// GetEscalationPolicies returns the escalation policies of a channel, or
+// the server wide policies when channelID is empty.
+func (c *Client4) GetEscalationPolicies(ctx context.Context, channelID string) ([]*EscalationPolicy, *Response, error) {
+	query := url.Values{}
+	if channelID != "" {
+		query.Set("channel_id", channelID)
+	}
+	r, err := c.DoAPIGet(ctx, c.escalationPoliciesRoute()+"?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*EscalationPolicy
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("GetEscalationPolicies", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return list, BuildResponse(r), nil
+}
+
+// GetEscalationPolicy returns the escalation policy with the given ID.
+func (c *Client4) GetEscalationPolicy(ctx context.Context, policyID string) (*EscalationPolicy, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.escalationPolicyRoute(policyID), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var p EscalationPolicy
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		return nil, nil, NewAppError("GetEscalationPolicy", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &p, BuildResponse(r), nil
+}
+
+// UpdateEscalationPolicy replaces the steps and quiet hours of an
+// escalation policy. The Id field of policy must be non-empty.
+func (c *Client4) UpdateEscalationPolicy(ctx context.Context, policy *EscalationPolicy) (*EscalationPolicy, *Response, error) {
+	buf, err := json.Marshal(policy)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateEscalationPolicy", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPutBytes(ctx, c.escalationPolicyRoute(policy.Id), buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var p EscalationPolicy
+	if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+		return nil, nil, NewAppError("UpdateEscalationPolicy", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &p, BuildResponse(r), nil
+}
+
+// DeleteEscalationPolicy deletes the escalation policy with the given ID.
+func (c *Client4) DeleteEscalationPolicy(ctx context.Context, policyID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.escalationPolicyRoute(policyID))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // Drafts Sections
 
 // UpsertDraft will create a new draft or update a draft if it already exists
@@ -6848,6 +7047,31 @@ func (c *Client4) RegenCommandToken(ctx context.Context, commandId string) (stri
 	return MapFromJSON(r.Body)["token"], BuildResponse(r), nil
 }
 
+// Event Stream Section
+
+// ConnectEventStream opens a Server-Sent Events stream delivering the same
+// events as the WebSocket. To resume a previous stream without losing
+// events, pass its connection id and next sequence number, otherwise
+// pass an empty connection id.
+func (c *Client4) ConnectEventStream(ctx context.Context, connectionID string, sequenceNumber int64) (*EventStream, *Response, error) {
+	query := ""
+	if connectionID != "" {
+		v := url.Values{}
+		v.Set("connection_id", connectionID)
+		v.Set("sequence_number", strconv.FormatInt(sequenceNumber, 10))
+		query = "?" + v.Encode()
+	}
+
+	r, err := c.DoAPIRequestWithHeaders(ctx, http.MethodGet, c.APIURL+c.eventStreamRoute()+query, "", map[string]string{"Accept": "text/event-stream"})
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+
+	stream := newEventStream(r.Body, connectionID, sequenceNumber)
+	go stream.listen()
+	return stream, BuildResponse(r), nil
+}
+
 // Status Section
 
 // GetUserStatus returns a user based on the provided user id string.
@@ -6938,6 +7162,36 @@ func (c *Client4) RemoveRecentUserCustomStatus(ctx context.Context, userId strin
 	return BuildResponse(r), nil
 }
 
+// SetUserCalendarFeed drives a user's status from the ICS feed at the given URL.
+func (c *Client4) SetUserCalendarFeed(ctx context.Context, userId, feedURL string) (*Response, error) {
+	r, err := c.DoAPIPut(ctx, c.userStatusRoute(userId)+"/calendar", MapToJSON(map[string]string{"url": feedURL}))
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// UploadUserCalendar drives a user's status from the given .ics file.
+func (c *Client4) UploadUserCalendar(ctx context.Context, userId string, data []byte) (*Response, error) {
+	r, err := c.DoAPIPostBytes(ctx, c.userStatusRoute(userId)+"/calendar/file", data)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
+// RemoveUserCalendar stops driving a user's status from their calendar.
+func (c *Client4) RemoveUserCalendar(ctx context.Context, userId string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.userStatusRoute(userId)+"/calendar")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // Emoji Section
 
 // CreateEmoji will save an emoji to the server if the current user has permission
@@ -7663,6 +7917,26 @@ func (c *Client4) EnablePlugin(ctx context.Context, id string) (*Response, error
 	return BuildResponse(r), nil
 }
 
+// ApprovePluginCapabilities approves the given manifest capabilities for an installed plugin,
+// replacing any earlier approval.
+func (c *Client4) ApprovePluginCapabilities(ctx context.Context, id string, capabilities []string) (*PluginInfo, *Response, error) {
+	buf, err := json.Marshal(capabilities)
+	if err != nil {
+		return nil, nil, NewAppError("ApprovePluginCapabilities", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPostBytes(ctx, c.pluginRoute(id)+"/capabilities/approve", buf)
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	var info PluginInfo
+	if err := json.NewDecoder(r.Body).Decode(&info); err != nil {
+		return nil, nil, NewAppError("ApprovePluginCapabilities", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &info, BuildResponse(r), nil
+}
+
 // DisablePlugin will disable an enabled plugin.
 func (c *Client4) DisablePlugin(ctx context.Context, id string) (*Response, error) {
 	r, err := c.DoAPIPost(ctx, c.pluginRoute(id)+"/disable", "")
@@ -7673,6 +7947,17 @@ func (c *Client4) DisablePlugin(ctx context.Context, id string) (*Response, erro
 	return BuildResponse(r), nil
 }
 
+// ForceDisablePlugin will disable an enabled plugin, even though other enabled plugins depend
+// on it.
+func (c *Client4) ForceDisablePlugin(ctx context.Context, id string) (*Response, error) {
+	r, err := c.DoAPIPost(ctx, c.pluginRoute(id)+"/disable?force=true", "")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // GetMarketplacePlugins will return a list of plugins that an admin can install.
 func (c *Client4) GetMarketplacePlugins(ctx context.Context, filter *MarketplacePluginFilter) ([]*MarketplacePlugin, *Response, error) {
 	route := c.pluginsRoute() + "/marketplace"
@@ -7697,6 +7982,76 @@ func (c *Client4) GetMarketplacePlugins(ctx context.Context, filter *Marketplace
 	return plugins, BuildResponse(r), nil
 }
 
+// ImportMarketplaceCatalog adds the plugins of the given catalog bundle to the offline
+// marketplace catalog, removing the plugins previously listed if replace is true. It returns
+// every plugin of the resulting catalog.
+func (c *Client4) ImportMarketplaceCatalog(ctx context.Context, catalog io.Reader, replace bool) ([]*BaseMarketplacePlugin, *Response, error) {
+	body := new(bytes.Buffer)
+	writer := multipart.NewWriter(body)
+
+	if replace {
+		if err := writer.WriteField("replace", c.boolString(true)); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	part, err := writer.CreateFormFile("catalog", "catalog.tar.gz")
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if _, err = io.Copy(part, catalog); err != nil {
+		return nil, nil, err
+	}
+
+	if err = writer.Close(); err != nil {
+		return nil, nil, err
+	}
+
+	r, err := c.DoAPIRequestReader(ctx, http.MethodPost, c.APIURL+c.pluginsRoute()+"/marketplace/catalog", body, map[string]string{"Content-Type": writer.FormDataContentType()})
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	plugins, err := BaseMarketplacePluginsFromReader(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("ImportMarketplaceCatalog", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return plugins, BuildResponse(r), nil
+}
+
+// GetMarketplaceCatalog returns every plugin of the offline marketplace catalog, including
+// every version of each.
+func (c *Client4) GetMarketplaceCatalog(ctx context.Context) ([]*BaseMarketplacePlugin, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.pluginsRoute()+"/marketplace/catalog", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+
+	plugins, err := BaseMarketplacePluginsFromReader(r.Body)
+	if err != nil {
+		return nil, BuildResponse(r), NewAppError("GetMarketplaceCatalog", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return plugins, BuildResponse(r), nil
+}
+
+// RemoveMarketplaceCatalogPlugin removes the given version of a plugin from the offline
+// marketplace catalog, or every version of it if version is empty.
+func (c *Client4) RemoveMarketplaceCatalogPlugin(ctx context.Context, pluginID, version string) (*Response, error) {
+	route := c.pluginsRoute() + "/marketplace/catalog/" + pluginID
+	if version != "" {
+		route += "?version=" + url.QueryEscape(version)
+	}
+	r, err := c.DoAPIDelete(ctx, route)
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 // UpdateChannelScheme will update a channel's scheme.
 func (c *Client4) UpdateChannelScheme(ctx context.Context, channelId, schemeId string) (*Response, error) {
 	sip := &SchemeIDPatch{SchemeID: &schemeId}
@@ -8190,6 +8545,118 @@ func (c *Client4) GetGroupStats(ctx context.Context, groupID string) (*GroupStat
 	return &gs, BuildResponse(r), nil
 }
 
+// GetGroupAttributeRule returns the attribute rule of an attribute group.
+func (c *Client4) GetGroupAttributeRule(ctx context.Context, groupID string) (*GroupAttributeRule, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.groupRoute(groupID)+"/attribute_rule", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var rule GroupAttributeRule
+	if err := json.NewDecoder(r.Body).Decode(&rule); err != nil {
+		return nil, nil, NewAppError("GetGroupAttributeRule", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &rule, BuildResponse(r), nil
+}
+
+// UpdateGroupAttributeRule sets the attribute rule of an attribute group.
+func (c *Client4) UpdateGroupAttributeRule(ctx context.Context, groupID string, rule *GroupAttributeRule) (*GroupAttributeRule, *Response, error) {
+	payload, err := json.Marshal(rule)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateGroupAttributeRule", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPut(ctx, c.groupRoute(groupID)+"/attribute_rule", string(payload))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var savedRule GroupAttributeRule
+	if err := json.NewDecoder(r.Body).Decode(&savedRule); err != nil {
+		return nil, nil, NewAppError("UpdateGroupAttributeRule", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &savedRule, BuildResponse(r), nil
+}
+
+// PreviewAttributeGroupSync returns the memberships synchronizing the attribute groups would
+// change, or only the given group when its id isn't empty.
+func (c *Client4) PreviewAttributeGroupSync(ctx context.Context, groupID string) ([]*MembershipChange, *Response, error) {
+	query := url.Values{}
+	if groupID != "" {
+		query.Set("group_id", groupID)
+	}
+	r, err := c.DoAPIGet(ctx, c.groupsRoute()+"/attribute_rules/preview?"+query.Encode(), "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var changes []*MembershipChange
+	if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+		return nil, nil, NewAppError("PreviewAttributeGroupSync", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return changes, BuildResponse(r), nil
+}
+
+// GetChannelAccessPolicy returns the access policy of a channel.
+func (c *Client4) GetChannelAccessPolicy(ctx context.Context, channelID string) (*ChannelAccessPolicy, *Response, error) {
+	r, err := c.DoAPIGet(ctx, c.channelRoute(channelID)+"/access_policy", "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var policy ChannelAccessPolicy
+	if err := json.NewDecoder(r.Body).Decode(&policy); err != nil {
+		return nil, nil, NewAppError("GetChannelAccessPolicy", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &policy, BuildResponse(r), nil
+}
+
+// UpdateChannelAccessPolicy sets the access policy of a channel.
+func (c *Client4) UpdateChannelAccessPolicy(ctx context.Context, channelID string, policy *ChannelAccessPolicy) (*ChannelAccessPolicy, *Response, error) {
+	payload, err := json.Marshal(policy)
+	if err != nil {
+		return nil, nil, NewAppError("UpdateChannelAccessPolicy", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPut(ctx, c.channelRoute(channelID)+"/access_policy", string(payload))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var savedPolicy ChannelAccessPolicy
+	if err := json.NewDecoder(r.Body).Decode(&savedPolicy); err != nil {
+		return nil, nil, NewAppError("UpdateChannelAccessPolicy", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return &savedPolicy, BuildResponse(r), nil
+}
+
+// PreviewChannelAccessPolicy returns the members of a channel saving the access policy would
+// remove, without saving it.
+func (c *Client4) PreviewChannelAccessPolicy(ctx context.Context, channelID string, policy *ChannelAccessPolicy) ([]*MembershipChange, *Response, error) {
+	payload, err := json.Marshal(policy)
+	if err != nil {
+		return nil, nil, NewAppError("PreviewChannelAccessPolicy", "api.marshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	r, err := c.DoAPIPost(ctx, c.channelRoute(channelID)+"/access_policy/preview", string(payload))
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var changes []*MembershipChange
+	if err := json.NewDecoder(r.Body).Decode(&changes); err != nil {
+		return nil, nil, NewAppError("PreviewChannelAccessPolicy", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return changes, BuildResponse(r), nil
+}
+
+// DeleteChannelAccessPolicy removes the access policy of a channel.
+func (c *Client4) DeleteChannelAccessPolicy(ctx context.Context, channelID string) (*Response, error) {
+	r, err := c.DoAPIDelete(ctx, c.channelRoute(channelID)+"/access_policy")
+	if err != nil {
+		return BuildResponse(r), err
+	}
+	defer closeBody(r)
+	return BuildResponse(r), nil
+}
+
 func (c *Client4) GetSidebarCategoriesForTeamForUser(ctx context.Context, userID, teamID, etag string) (*OrderedSidebarCategories, *Response, error) {
 	route := c.userCategoryRoute(userID, teamID)
 	r, err := c.DoAPIGet(ctx, route, etag)
@@ -9097,6 +9564,22 @@ func (c *Client4) GetUsersWithInvalidEmails(ctx context.Context, page, perPage i
 	return list, BuildResponse(r), nil
 }
 
+// GetUsersWithExpiredPasswords returns the users who have to reset their password the next
+// time they log in.
+func (c *Client4) GetUsersWithExpiredPasswords(ctx context.Context, page, perPage int) ([]*User, *Response, error) {
+	query := fmt.Sprintf("/expired_passwords?page=%v&per_page=%v", page, perPage)
+	r, err := c.DoAPIGet(ctx, c.usersRoute()+query, "")
+	if err != nil {
+		return nil, BuildResponse(r), err
+	}
+	defer closeBody(r)
+	var list []*User
+	if err := json.NewDecoder(r.Body).Decode(&list); err != nil {
+		return nil, nil, NewAppError("GetUsersWithExpiredPasswords", "api.unmarshal_error", nil, "", http.StatusInternalServerError).Wrap(err)
+	}
+	return list, BuildResponse(r), nil
+}
+
 func (c *Client4) GetAppliedSchemaMigrations(ctx context.Context) ([]AppliedMigration, *Response, error) {
 	r, err := c.DoAPIGet(ctx, c.systemRoute()+"/schema/version", "")
 	if err != nil {
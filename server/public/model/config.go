@@ -44,8 +44,9 @@ const (
 	MinioSecretKey = "miniosecretkey"
 	MinioBucket    = "mattermost-test"
 
-	PasswordMaximumLength = 72
-	PasswordMinimumLength = 5
+	PasswordMaximumLength       = 72
+	PasswordMinimumLength       = 5
+	PasswordHistoryMaximumCount = 24
 
 	ServiceGitlab    = "gitlab"
 	ServiceGoogle    = "google"
@@ -228,11 +229,14 @@ const (
 
 	OutgoingIntegrationRequestsDefaultTimeout = 30
 
-	PluginSettingsDefaultDirectory         = "./plugins"
-	PluginSettingsDefaultClientDirectory   = "./client/plugins"
-	PluginSettingsDefaultEnableMarketplace = true
-	PluginSettingsDefaultMarketplaceURL    = "https://api.integrations.mattermost.com"
-	PluginSettingsOldMarketplaceURL        = "https://marketplace.integrations.mattermost.com"
+	PluginSettingsDefaultDirectory                         = "./plugins"
+	PluginSettingsDefaultClientDirectory                   = "./client/plugins"
+	PluginSettingsDefaultEnableMarketplace                 = true
+	PluginSettingsDefaultMarketplaceURL                    = "https://api.integrations.mattermost.com"
+	PluginSettingsDefaultWasmMaxMemoryMB                   = 256
+	PluginSettingsDefaultWasmMaxCallDurationMs             = 10000
+	PluginSettingsDefaultHookCircuitBreakerCooldownSeconds = 300
+	PluginSettingsOldMarketplaceURL                        = "https://marketplace.integrations.mattermost.com"
 
 	ComplianceExportTypeCsv            = "csv"
 	ComplianceExportTypeActiance       = "actiance"
@@ -263,7 +267,12 @@ const (
 	CloudSettingsDefaultCwsURLTest    = "https://portal.test.cloud.mattermost.com"
 	CloudSettingsDefaultCwsAPIURLTest = "https://api.internal.test.cloud.mattermost.com"
 
-	OpenidSettingsDefaultScope = "profile openid email"
+	OpenidSettingsDefaultScope          = "profile openid email"
+	OpenidSettingsDefaultUsernameClaim  = "preferred_username"
+	OpenidSettingsDefaultEmailClaim     = "email"
+	OpenidSettingsDefaultFirstNameClaim = "given_name"
+	OpenidSettingsDefaultLastNameClaim  = "family_name"
+	OpenidSettingsDefaultAuthDataClaim  = "sub"
 
 	LocalModeSocketPath = "/var/tmp/mattermost_local.socket"
 
@@ -434,6 +443,29 @@ type ServiceSettings struct {
 	MaximumPayloadSizeBytes                           *int64  `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
 	MaximumURLLength                                  *int    `access:"environment_file_storage,write_restrictable,cloud_restrictable"`
 	ScheduledPosts                                    *bool   `access:"site_posts"`
+	EnableCalendarStatusSync                          *bool   `access:"site_users_and_teams"`
+	EnableScimProvisioning                            *bool   `access:"site_users_and_teams,write_restrictable,cloud_restrictable"`
+	// ScimAuthService is the auth service users provisioned through SCIM log in with. Their
+	// externalId is their auth data then, and they log in with a password otherwise.
+	ScimAuthService *string `access:"site_users_and_teams,write_restrictable,cloud_restrictable"`
+	// EnableSessionActivityLog records the IP addresses and devices sessions are used from.
+	EnableSessionActivityLog     *bool `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	SessionActivityRetentionDays *int  `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	// SessionGeoIPDatabasePath is a CSV file mapping networks in CIDR notation to the name,
+	// latitude and longitude of their region, used to locate the sessions.
+	SessionGeoIPDatabasePath *string `access:"environment_session_lengths,write_restrictable,cloud_restrictable"` // telemetry: none
+	// SessionImpossibleTravelSpeedKmh flags the sessions used from a place the user couldn't
+	// have reached at this speed since they were last seen. 0 disables the check.
+	SessionImpossibleTravelSpeedKmh  *int  `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	RevokeSessionsOnImpossibleTravel *bool `access:"environment_session_lengths,write_restrictable,cloud_restrictable"`
+	// LoginBackoffBaseSeconds delays the next login after a failed one, for the account and for
+	// the IP address, doubling the delay on each further failure up to LoginBackoffMaxSeconds.
+	// 0 disables the delays.
+	LoginBackoffBaseSeconds *int `access:"authentication_password,write_restrictable,cloud_restrictable"`
+	LoginBackoffMaxSeconds  *int `access:"authentication_password,write_restrictable,cloud_restrictable"`
+	// LoginLockoutWindowMinutes unlocks the accounts locked after MaximumLoginAttempts once no
+	// login failed for this long. 0 keeps them locked until their password is reset.
+	LoginLockoutWindowMinutes *int `access:"authentication_password,write_restrictable,cloud_restrictable"`
 }
 
 var MattermostGiphySdkKey string
@@ -604,6 +636,18 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 		s.MaximumLoginAttempts = NewPointer(ServiceSettingsDefaultMaxLoginAttempts)
 	}
 
+	if s.LoginBackoffBaseSeconds == nil {
+		s.LoginBackoffBaseSeconds = NewPointer(0)
+	}
+
+	if s.LoginBackoffMaxSeconds == nil {
+		s.LoginBackoffMaxSeconds = NewPointer(300)
+	}
+
+	if s.LoginLockoutWindowMinutes == nil {
+		s.LoginLockoutWindowMinutes = NewPointer(0)
+	}
+
 	if s.Forward80To443 == nil {
 		s.Forward80To443 = NewPointer(false)
 	}
@@ -961,6 +1005,38 @@ func (s *ServiceSettings) SetDefaults(isUpdate bool) {
 	if s.ScheduledPosts == nil {
 		s.ScheduledPosts = NewPointer(true)
 	}
+
+	if s.EnableCalendarStatusSync == nil {
+		s.EnableCalendarStatusSync = NewPointer(false)
+	}
+
+	if s.EnableScimProvisioning == nil {
+		s.EnableScimProvisioning = NewPointer(false)
+	}
+
+	if s.ScimAuthService == nil {
+		s.ScimAuthService = NewPointer("")
+	}
+
+	if s.EnableSessionActivityLog == nil {
+		s.EnableSessionActivityLog = NewPointer(false)
+	}
+
+	if s.SessionActivityRetentionDays == nil {
+		s.SessionActivityRetentionDays = NewPointer(90)
+	}
+
+	if s.SessionGeoIPDatabasePath == nil {
+		s.SessionGeoIPDatabasePath = NewPointer("")
+	}
+
+	if s.SessionImpossibleTravelSpeedKmh == nil {
+		s.SessionImpossibleTravelSpeedKmh = NewPointer(1000)
+	}
+
+	if s.RevokeSessionsOnImpossibleTravel == nil {
+		s.RevokeSessionsOnImpossibleTravel = NewPointer(false)
+	}
 }
 
 type CacheSettings struct {
@@ -1185,6 +1261,15 @@ type SSOSettings struct {
 	DiscoveryEndpoint *string `access:"authentication_openid"` // telemetry: none
 	ButtonText        *string `access:"authentication_openid"` // telemetry: none
 	ButtonColor       *string `access:"authentication_openid"` // telemetry: none
+
+	// The claims the fields of users are read from when logging in with OpenID Connect. The
+	// groups of users are only synchronized when GroupsClaim is set.
+	UsernameClaim  *string `access:"authentication_openid"` // telemetry: none
+	EmailClaim     *string `access:"authentication_openid"` // telemetry: none
+	FirstNameClaim *string `access:"authentication_openid"` // telemetry: none
+	LastNameClaim  *string `access:"authentication_openid"` // telemetry: none
+	AuthDataClaim  *string `access:"authentication_openid"` // telemetry: none
+	GroupsClaim    *string `access:"authentication_openid"` // telemetry: none
 }
 
 func (s *SSOSettings) setDefaults(scope, authEndpoint, tokenEndpoint, userAPIEndpoint, buttonColor string) {
@@ -1227,6 +1312,30 @@ func (s *SSOSettings) setDefaults(scope, authEndpoint, tokenEndpoint, userAPIEnd
 	if s.ButtonColor == nil {
 		s.ButtonColor = NewPointer(buttonColor)
 	}
+
+	if s.UsernameClaim == nil {
+		s.UsernameClaim = NewPointer(OpenidSettingsDefaultUsernameClaim)
+	}
+
+	if s.EmailClaim == nil {
+		s.EmailClaim = NewPointer(OpenidSettingsDefaultEmailClaim)
+	}
+
+	if s.FirstNameClaim == nil {
+		s.FirstNameClaim = NewPointer(OpenidSettingsDefaultFirstNameClaim)
+	}
+
+	if s.LastNameClaim == nil {
+		s.LastNameClaim = NewPointer(OpenidSettingsDefaultLastNameClaim)
+	}
+
+	if s.AuthDataClaim == nil {
+		s.AuthDataClaim = NewPointer(OpenidSettingsDefaultAuthDataClaim)
+	}
+
+	if s.GroupsClaim == nil {
+		s.GroupsClaim = NewPointer("")
+	}
 }
 
 type Office365Settings struct {
@@ -1609,6 +1718,16 @@ type PasswordSettings struct {
 	Uppercase        *bool `access:"authentication_password"`
 	Symbol           *bool `access:"authentication_password"`
 	EnableForgotLink *bool `access:"authentication_password"`
+	// HistoryCount prevents the reuse of the given number of last passwords, including the current one.
+	HistoryCount *int `access:"authentication_password"`
+	// MaximumAgeDays is the number of days after which passwords expire and have to be reset. 0
+	// disables the expiry.
+	MaximumAgeDays *int `access:"authentication_password"`
+	// BreachedPasswordsFile lists the SHA-1 hashes of breached passwords in hexadecimal, one per
+	// line and optionally followed by a colon and a count, which can't be used as passwords. It
+	// can also be a directory of k-anonymity range files named after the first 5 characters of
+	// the hashes and listing the remaining 35, of which only one is read per check.
+	BreachedPasswordsFile *string `access:"authentication_password"` // telemetry: none
 }
 
 func (s *PasswordSettings) SetDefaults() {
@@ -1635,6 +1754,34 @@ func (s *PasswordSettings) SetDefaults() {
 	if s.EnableForgotLink == nil {
 		s.EnableForgotLink = NewPointer(true)
 	}
+
+	if s.HistoryCount == nil {
+		s.HistoryCount = NewPointer(0)
+	}
+
+	if s.MaximumAgeDays == nil {
+		s.MaximumAgeDays = NewPointer(0)
+	}
+
+	if s.BreachedPasswordsFile == nil {
+		s.BreachedPasswordsFile = NewPointer("")
+	}
+}
+
+func (s *PasswordSettings) isValid() *AppError {
+	if *s.MinimumLength < PasswordMinimumLength || *s.MinimumLength > PasswordMaximumLength {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_length.app_error", map[string]any{"MinLength": PasswordMinimumLength, "MaxLength": PasswordMaximumLength}, "", http.StatusBadRequest)
+	}
+
+	if *s.HistoryCount < 0 || *s.HistoryCount > PasswordHistoryMaximumCount {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_history_count.app_error", map[string]any{"MaxCount": PasswordHistoryMaximumCount}, "", http.StatusBadRequest)
+	}
+
+	if *s.MaximumAgeDays < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.password_maximum_age.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 type FileSettings struct {
@@ -1900,6 +2047,8 @@ type EmailSettings struct {
 	LoginButtonColor                  *string `access:"experimental_features"`
 	LoginButtonBorderColor            *string `access:"experimental_features"`
 	LoginButtonTextColor              *string `access:"experimental_features"`
+	// SendNewSignInEmails emails users when they sign in from a device they never used before.
+	SendNewSignInEmails *bool `access:"authentication_email"`
 }
 
 func (s *EmailSettings) SetDefaults(isUpdate bool) {
@@ -2042,6 +2191,10 @@ func (s *EmailSettings) SetDefaults(isUpdate bool) {
 	if s.LoginButtonTextColor == nil {
 		s.LoginButtonTextColor = NewPointer("#2389D7")
 	}
+
+	if s.SendNewSignInEmails == nil {
+		s.SendNewSignInEmails = NewPointer(false)
+	}
 }
 
 type RateLimitSettings struct {
@@ -3156,24 +3309,37 @@ func (s *CloudSettings) SetDefaults() {
 
 type PluginState struct {
 	Enable bool
+	// ApprovedCapabilities are the manifest capabilities an administrator approved for the plugin.
+	ApprovedCapabilities []string `json:",omitempty"`
 }
 
 type PluginSettings struct {
-	Enable                      *bool                     `access:"plugins,write_restrictable"`
-	EnableUploads               *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	AllowInsecureDownloadURL    *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	EnableHealthCheck           *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	Directory                   *string                   `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
-	ClientDirectory             *string                   `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
-	Plugins                     map[string]map[string]any `access:"plugins"`                                       // telemetry: none
-	PluginStates                map[string]*PluginState   `access:"plugins"`                                       // telemetry: none
-	EnableMarketplace           *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	EnableRemoteMarketplace     *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	AutomaticPrepackagedPlugins *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	RequirePluginSignature      *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
-	MarketplaceURL              *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
-	SignaturePublicKeyFiles     []string                  `access:"plugins,write_restrictable,cloud_restrictable"`
-	ChimeraOAuthProxyURL        *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
+	Enable                            *bool                     `access:"plugins,write_restrictable"`
+	EnableUploads                     *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	AllowInsecureDownloadURL          *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	EnableHealthCheck                 *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	Directory                         *string                   `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	ClientDirectory                   *string                   `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	Plugins                           map[string]map[string]any `access:"plugins"`                                       // telemetry: none
+	PluginStates                      map[string]*PluginState   `access:"plugins"`                                       // telemetry: none
+	EnableMarketplace                 *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	EnableRemoteMarketplace           *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	EnableMarketplaceCatalog          *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	AutomaticPrepackagedPlugins       *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	RequirePluginSignature            *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	RequirePluginCapabilities         *bool                     `access:"plugins,write_restrictable,cloud_restrictable"`
+	MarketplaceURL                    *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
+	SignaturePublicKeyFiles           []string                  `access:"plugins,write_restrictable,cloud_restrictable"`
+	ChimeraOAuthProxyURL              *string                   `access:"plugins,write_restrictable,cloud_restrictable"`
+	WasmMaxMemoryMB                   *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	WasmMaxCallDurationMs             *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	HookTimeoutsMs                    map[string]int            `access:"plugins,write_restrictable,cloud_restrictable"` // telemetry: none
+	HookCircuitBreakerThreshold       *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	HookCircuitBreakerCooldownSeconds *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	ProcessMaxMemoryMB                *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	ProcessMaxCPUPercent              *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	ProcessMaxOpenFiles               *int                      `access:"plugins,write_restrictable,cloud_restrictable"`
+	HotReloadDirectories              []string                  `access:"plugins,write_restrictable,cloud_restrictable"`
 }
 
 func (s *PluginSettings) SetDefaults(ls LogSettings) {
@@ -3237,6 +3403,10 @@ func (s *PluginSettings) SetDefaults(ls LogSettings) {
 		s.EnableRemoteMarketplace = NewPointer(true)
 	}
 
+	if s.EnableMarketplaceCatalog == nil {
+		s.EnableMarketplaceCatalog = NewPointer(false)
+	}
+
 	if s.AutomaticPrepackagedPlugins == nil {
 		s.AutomaticPrepackagedPlugins = NewPointer(true)
 	}
@@ -3249,6 +3419,10 @@ func (s *PluginSettings) SetDefaults(ls LogSettings) {
 		s.RequirePluginSignature = NewPointer(false)
 	}
 
+	if s.RequirePluginCapabilities == nil {
+		s.RequirePluginCapabilities = NewPointer(false)
+	}
+
 	if s.SignaturePublicKeyFiles == nil {
 		s.SignaturePublicKeyFiles = []string{}
 	}
@@ -3256,6 +3430,65 @@ func (s *PluginSettings) SetDefaults(ls LogSettings) {
 	if s.ChimeraOAuthProxyURL == nil {
 		s.ChimeraOAuthProxyURL = NewPointer("")
 	}
+
+	if s.WasmMaxMemoryMB == nil {
+		s.WasmMaxMemoryMB = NewPointer(PluginSettingsDefaultWasmMaxMemoryMB)
+	}
+
+	if s.WasmMaxCallDurationMs == nil {
+		s.WasmMaxCallDurationMs = NewPointer(PluginSettingsDefaultWasmMaxCallDurationMs)
+	}
+
+	// Hook timeouts and the circuit breaker are opt-in: hooks have no deadline by default.
+	if s.HookTimeoutsMs == nil {
+		s.HookTimeoutsMs = map[string]int{}
+	}
+
+	if s.HookCircuitBreakerThreshold == nil {
+		s.HookCircuitBreakerThreshold = NewPointer(0)
+	}
+
+	if s.HookCircuitBreakerCooldownSeconds == nil {
+		s.HookCircuitBreakerCooldownSeconds = NewPointer(PluginSettingsDefaultHookCircuitBreakerCooldownSeconds)
+	}
+
+	if s.ProcessMaxMemoryMB == nil {
+		s.ProcessMaxMemoryMB = NewPointer(0)
+	}
+
+	if s.ProcessMaxCPUPercent == nil {
+		s.ProcessMaxCPUPercent = NewPointer(0)
+	}
+
+	if s.ProcessMaxOpenFiles == nil {
+		s.ProcessMaxOpenFiles = NewPointer(0)
+	}
+
+	if s.HotReloadDirectories == nil {
+		s.HotReloadDirectories = []string{}
+	}
+}
+
+func (s *PluginSettings) isValid() *AppError {
+	for hookName, timeout := range s.HookTimeoutsMs {
+		if timeout < 0 {
+			return NewAppError("Config.IsValid", "model.config.is_valid.plugin_hook_timeout.app_error", map[string]any{"HookName": hookName}, "", http.StatusBadRequest)
+		}
+	}
+
+	if *s.HookCircuitBreakerThreshold < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_hook_circuit_breaker_threshold.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.HookCircuitBreakerCooldownSeconds <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_hook_circuit_breaker_cooldown.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.ProcessMaxMemoryMB < 0 || *s.ProcessMaxCPUPercent < 0 || *s.ProcessMaxOpenFiles < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.plugin_process_limits.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
 }
 
 // Sanitize cleans up the plugin settings by removing any sensitive information.
@@ -3831,8 +4064,8 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
-	if *o.PasswordSettings.MinimumLength < PasswordMinimumLength || *o.PasswordSettings.MinimumLength > PasswordMaximumLength {
-		return NewAppError("Config.IsValid", "model.config.is_valid.password_length.app_error", map[string]any{"MinLength": PasswordMinimumLength, "MaxLength": PasswordMaximumLength}, "", http.StatusBadRequest)
+	if appErr := o.PasswordSettings.isValid(); appErr != nil {
+		return appErr
 	}
 
 	if appErr := o.RateLimitSettings.isValid(); appErr != nil {
@@ -3879,6 +4112,10 @@ func (o *Config) IsValid() *AppError {
 		return appErr
 	}
 
+	if appErr := o.PluginSettings.isValid(); appErr != nil {
+		return appErr
+	}
+
 	if appErr := o.WranglerSettings.IsValid(); appErr != nil {
 		return appErr
 	}
@@ -4224,6 +4461,28 @@ func (s *ServiceSettings) isValid() *AppError {
 		return NewAppError("Config.IsValid", "model.config.is_valid.login_attempts.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if *s.LoginBackoffBaseSeconds < 0 || *s.LoginBackoffMaxSeconds < *s.LoginBackoffBaseSeconds {
+		return NewAppError("Config.IsValid", "model.config.is_valid.login_backoff.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.LoginLockoutWindowMinutes < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.login_lockout_window.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	switch *s.ScimAuthService {
+	case "", UserAuthServiceSaml, UserAuthServiceLdap, ServiceGitlab, ServiceGoogle, ServiceOffice365, ServiceOpenid:
+	default:
+		return NewAppError("Config.IsValid", "model.config.is_valid.scim_auth_service.app_error", map[string]any{"AuthService": *s.ScimAuthService}, "", http.StatusBadRequest)
+	}
+
+	if *s.SessionActivityRetentionDays <= 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.session_activity_retention_days.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if *s.SessionImpossibleTravelSpeedKmh < 0 {
+		return NewAppError("Config.IsValid", "model.config.is_valid.session_impossible_travel_speed.app_error", nil, "", http.StatusBadRequest)
+	}
+
 	if *s.SiteURL != "" {
 		if _, err := url.ParseRequestURI(*s.SiteURL); err != nil {
 			return NewAppError("Config.IsValid", "model.config.is_valid.site_url.app_error", nil, "", http.StatusBadRequest).Wrap(err)
@@ -101,6 +101,25 @@ func TestServiceSettingsIsValid(t *testing.T) {
 			},
 			ExpectError: false,
 		},
+		"LoginBackoffBaseSeconds is negative": {
+			ServiceSettings: ServiceSettings{
+				LoginBackoffBaseSeconds: NewPointer(-1),
+			},
+			ExpectError: true,
+		},
+		"LoginBackoffMaxSeconds is lower than LoginBackoffBaseSeconds": {
+			ServiceSettings: ServiceSettings{
+				LoginBackoffBaseSeconds: NewPointer(60),
+				LoginBackoffMaxSeconds:  NewPointer(30),
+			},
+			ExpectError: true,
+		},
+		"LoginLockoutWindowMinutes is negative": {
+			ServiceSettings: ServiceSettings{
+				LoginLockoutWindowMinutes: NewPointer(-1),
+			},
+			ExpectError: true,
+		},
 	} {
 		t.Run(name, func(t *testing.T) {
 			test.ServiceSettings.SetDefaults(false)
@@ -374,6 +393,15 @@ func TestConfigDefaultNPSPluginState(t *testing.T) {
 	})
 }
 
+func TestConfigDefaultPluginHookLimits(t *testing.T) {
+	c1 := Config{}
+	c1.SetDefaults()
+
+	assert.Empty(t, c1.PluginSettings.HookTimeoutsMs)
+	assert.Equal(t, 0, *c1.PluginSettings.HookCircuitBreakerThreshold)
+	require.Nil(t, c1.PluginSettings.isValid())
+}
+
 func TestConfigDefaultChannelExportPluginState(t *testing.T) {
 	t.Run("should not enable ChannelExport plugin by default", func(t *testing.T) {
 		BuildEnterpriseReady = "true"
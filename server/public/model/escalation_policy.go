@@ -0,0 +1,217 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"sort"
+	"time"
+)
+
+const (
+	EscalationPolicyMaxSteps       = 10
+	EscalationPolicyMaxStepTargets = 50
+	escalationQuietHoursTimeLayout = "15:04"
+)
+
+// EscalationPolicy describes who else to notify when the mentioned users
+// do not acknowledge a post with persistent notifications. A policy applies
+// to the posts of a channel, or to the posts of every channel when ChannelId
+// is empty. It can be restricted to posts of a given priority.
+type EscalationPolicy struct {
+	Id        string `json:"id"`
+	ChannelId string `json:"channel_id"`
+	// Priority is the post priority the policy applies to, or empty
+	// for any priority.
+	Priority   string                `json:"priority"`
+	Steps      []*EscalationStep     `json:"steps"`
+	QuietHours *EscalationQuietHours `json:"quiet_hours,omitempty"`
+	CreatorId  string                `json:"creator_id"`
+	CreateAt   int64                 `json:"create_at"`
+	UpdateAt   int64                 `json:"update_at"`
+}
+
+// EscalationStep is run once the post has been notified AfterCount times
+// without being acknowledged. The given users, and members of the given
+// groups, are sent a direct message and a message is posted to the given
+// channel.
+type EscalationStep struct {
+	AfterCount int16    `json:"after_count"`
+	UserIds    []string `json:"user_ids,omitempty"`
+	GroupIds   []string `json:"group_ids,omitempty"`
+	ChannelId  string   `json:"channel_id,omitempty"`
+}
+
+// EscalationQuietHours is a daily time range, such as 22:00 to 07:00,
+// during which escalation steps are held back until the range ends.
+type EscalationQuietHours struct {
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Timezone string `json:"timezone"`
+}
+
+func (p *EscalationPolicy) Auditable() map[string]any {
+	return map[string]any{
+		"id":          p.Id,
+		"channel_id":  p.ChannelId,
+		"priority":    p.Priority,
+		"steps":       p.Steps,
+		"quiet_hours": p.QuietHours,
+		"creator_id":  p.CreatorId,
+		"create_at":   p.CreateAt,
+		"update_at":   p.UpdateAt,
+	}
+}
+
+func (p *EscalationPolicy) PreSave() {
+	if p.Id == "" {
+		p.Id = NewId()
+	}
+
+	p.CreateAt = GetMillis()
+	p.UpdateAt = p.CreateAt
+	p.sortSteps()
+}
+
+func (p *EscalationPolicy) PreUpdate() {
+	p.UpdateAt = GetMillis()
+	p.sortSteps()
+}
+
+// sortSteps orders the steps by count. Policies with nil steps are left as they are, for IsValid
+// to reject them.
+func (p *EscalationPolicy) sortSteps() {
+	for _, step := range p.Steps {
+		if step == nil {
+			return
+		}
+	}
+	sort.SliceStable(p.Steps, func(i, j int) bool {
+		return p.Steps[i].AfterCount < p.Steps[j].AfterCount
+	})
+}
+
+func (p *EscalationPolicy) IsValid() *AppError {
+	if !IsValidId(p.Id) {
+		return NewAppError("EscalationPolicy.IsValid", "model.escalation_policy.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if p.ChannelId != "" && !IsValidId(p.ChannelId) {
+		return NewAppError("EscalationPolicy.IsValid", "model.escalation_policy.is_valid.channel_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.Priority != "" && p.Priority != PostPriorityUrgent {
+		return NewAppError("EscalationPolicy.IsValid", "model.escalation_policy.is_valid.priority.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if len(p.Steps) == 0 || len(p.Steps) > EscalationPolicyMaxSteps {
+		return NewAppError("EscalationPolicy.IsValid", "model.escalation_policy.is_valid.steps.app_error", map[string]any{"MaxSteps": EscalationPolicyMaxSteps}, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	for _, step := range p.Steps {
+		if step == nil {
+			return NewAppError("EscalationPolicy.IsValid", "model.escalation_policy.is_valid.step_targets.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+		}
+		if appErr := step.IsValid(); appErr != nil {
+			return appErr
+		}
+	}
+
+	if p.QuietHours != nil {
+		if appErr := p.QuietHours.IsValid(); appErr != nil {
+			return appErr
+		}
+	}
+
+	if !IsValidId(p.CreatorId) {
+		return NewAppError("EscalationPolicy.IsValid", "model.escalation_policy.is_valid.creator_id.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.CreateAt == 0 {
+		return NewAppError("EscalationPolicy.IsValid", "model.escalation_policy.is_valid.create_at.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	if p.UpdateAt == 0 {
+		return NewAppError("EscalationPolicy.IsValid", "model.escalation_policy.is_valid.update_at.app_error", nil, "id="+p.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (s *EscalationStep) IsValid() *AppError {
+	if s.AfterCount < 1 {
+		return NewAppError("EscalationStep.IsValid", "model.escalation_policy.is_valid.after_count.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(s.UserIds) == 0 && len(s.GroupIds) == 0 && s.ChannelId == "" {
+		return NewAppError("EscalationStep.IsValid", "model.escalation_policy.is_valid.step_targets.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(s.UserIds)+len(s.GroupIds) > EscalationPolicyMaxStepTargets {
+		return NewAppError("EscalationStep.IsValid", "model.escalation_policy.is_valid.too_many_targets.app_error", map[string]any{"Max": EscalationPolicyMaxStepTargets}, "", http.StatusBadRequest)
+	}
+
+	for _, id := range append(append([]string{}, s.UserIds...), s.GroupIds...) {
+		if !IsValidId(id) {
+			return NewAppError("EscalationStep.IsValid", "model.escalation_policy.is_valid.step_targets.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
+	if s.ChannelId != "" && !IsValidId(s.ChannelId) {
+		return NewAppError("EscalationStep.IsValid", "model.escalation_policy.is_valid.step_targets.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func (q *EscalationQuietHours) IsValid() *AppError {
+	_, startErr := time.Parse(escalationQuietHoursTimeLayout, q.Start)
+	_, endErr := time.Parse(escalationQuietHoursTimeLayout, q.End)
+	if startErr != nil || endErr != nil || q.Start == q.End {
+		return NewAppError("EscalationQuietHours.IsValid", "model.escalation_policy.is_valid.quiet_hours.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if _, err := time.LoadLocation(q.Timezone); err != nil {
+		return NewAppError("EscalationQuietHours.IsValid", "model.escalation_policy.is_valid.quiet_hours_timezone.app_error", nil, "", http.StatusBadRequest).Wrap(err)
+	}
+
+	return nil
+}
+
+// Contains returns whether the given time falls within the quiet hours.
+// Ranges ending before they start span midnight.
+func (q *EscalationQuietHours) Contains(t time.Time) bool {
+	loc, err := time.LoadLocation(q.Timezone)
+	if err != nil {
+		return false
+	}
+	start, startErr := time.Parse(escalationQuietHoursTimeLayout, q.Start)
+	end, endErr := time.Parse(escalationQuietHoursTimeLayout, q.End)
+	if startErr != nil || endErr != nil {
+		return false
+	}
+
+	t = t.In(loc)
+	minutes := t.Hour()*60 + t.Minute()
+	startMinutes := start.Hour()*60 + start.Minute()
+	endMinutes := end.Hour()*60 + end.Minute()
+
+	if startMinutes < endMinutes {
+		return minutes >= startMinutes && minutes < endMinutes
+	}
+	return minutes >= startMinutes || minutes < endMinutes
+}
+
+// StepsDue returns the steps which are due once a post has been notified
+// sentCount times, given that the first level steps have already run.
+func (p *EscalationPolicy) StepsDue(level int16, sentCount int16) []*EscalationStep {
+	var due []*EscalationStep
+	for i := int(level); i < len(p.Steps); i++ {
+		if p.Steps[i].AfterCount > sentCount {
+			break
+		}
+		due = append(due, p.Steps[i])
+	}
+	return due
+}
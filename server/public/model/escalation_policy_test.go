@@ -0,0 +1,110 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEscalationPolicyIsValid(t *testing.T) {
+	newPolicy := func() *EscalationPolicy {
+		p := &EscalationPolicy{
+			ChannelId: NewId(),
+			Priority:  PostPriorityUrgent,
+			CreatorId: NewId(),
+			Steps: []*EscalationStep{
+				{AfterCount: 2, ChannelId: NewId()},
+				{AfterCount: 1, UserIds: []string{NewId()}},
+			},
+		}
+		p.PreSave()
+		return p
+	}
+
+	p := newPolicy()
+	require.Nil(t, p.IsValid())
+	assert.Equal(t, int16(1), p.Steps[0].AfterCount, "steps should be sorted")
+
+	p = newPolicy()
+	p.ChannelId = ""
+	p.Priority = ""
+	assert.Nil(t, p.IsValid())
+
+	p = newPolicy()
+	p.Priority = "important"
+	assert.NotNil(t, p.IsValid())
+
+	p = newPolicy()
+	p.Steps = nil
+	assert.NotNil(t, p.IsValid())
+
+	p = newPolicy()
+	p.Steps = append(p.Steps, nil)
+	require.NotPanics(t, p.PreUpdate)
+	assert.NotNil(t, p.IsValid())
+
+	p = newPolicy()
+	p.Steps[0].AfterCount = 0
+	assert.NotNil(t, p.IsValid())
+
+	p = newPolicy()
+	p.Steps[0] = &EscalationStep{AfterCount: 1}
+	assert.NotNil(t, p.IsValid())
+
+	p = newPolicy()
+	p.Steps[0].GroupIds = []string{"invalid"}
+	assert.NotNil(t, p.IsValid())
+
+	p = newPolicy()
+	p.QuietHours = &EscalationQuietHours{Start: "22:00", End: "07:00", Timezone: "America/New_York"}
+	assert.Nil(t, p.IsValid())
+
+	p.QuietHours.Start = "25:00"
+	assert.NotNil(t, p.IsValid())
+
+	p.QuietHours = &EscalationQuietHours{Start: "22:00", End: "07:00", Timezone: "Nowhere/Invalid"}
+	assert.NotNil(t, p.IsValid())
+}
+
+func TestEscalationQuietHoursContains(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2024, 3, 4, hour, minute, 0, 0, time.UTC)
+	}
+
+	overnight := &EscalationQuietHours{Start: "22:00", End: "07:00", Timezone: "UTC"}
+	assert.True(t, overnight.Contains(at(23, 30)))
+	assert.True(t, overnight.Contains(at(6, 59)))
+	assert.False(t, overnight.Contains(at(7, 0)))
+	assert.False(t, overnight.Contains(at(12, 0)))
+
+	daytime := &EscalationQuietHours{Start: "12:00", End: "13:00", Timezone: "UTC"}
+	assert.True(t, daytime.Contains(at(12, 30)))
+	assert.False(t, daytime.Contains(at(13, 0)))
+
+	berlin := &EscalationQuietHours{Start: "12:00", End: "13:00", Timezone: "Europe/Berlin"}
+	assert.True(t, berlin.Contains(at(11, 30)))
+	assert.False(t, berlin.Contains(at(12, 30)))
+}
+
+func TestEscalationPolicyStepsDue(t *testing.T) {
+	p := &EscalationPolicy{
+		Steps: []*EscalationStep{
+			{AfterCount: 2},
+			{AfterCount: 2},
+			{AfterCount: 4},
+		},
+	}
+
+	assert.Empty(t, p.StepsDue(0, 1))
+	assert.Len(t, p.StepsDue(0, 2), 2)
+	assert.Empty(t, p.StepsDue(2, 3))
+	assert.Len(t, p.StepsDue(2, 4), 1)
+	// Steps held back by quiet hours run together once they're over.
+	assert.Len(t, p.StepsDue(0, 5), 3)
+	assert.Empty(t, p.StepsDue(3, 6))
+}
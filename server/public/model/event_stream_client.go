@@ -0,0 +1,116 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"sync"
+)
+
+const eventStreamMaxLineSize = 1024 * 1024 // 1MB
+
+// EventStream stores the necessary information required to consume the
+// Server-Sent Events endpoint, an alternative to the WebSocket for clients
+// which cannot upgrade their connection.
+// A client must read from EventChannel to prevent deadlocks from occurring in the program.
+type EventStream struct {
+	EventChannel chan *WebSocketEvent // The channel used to receive events pushed from the server. It is closed when the stream ends.
+	ListenError  error                // A field that is set if the stream ended abnormally
+
+	body         io.ReadCloser
+	mut          sync.RWMutex
+	connectionID string
+	sequence     int64
+	closeOnce    sync.Once
+}
+
+func newEventStream(body io.ReadCloser, connectionID string, sequence int64) *EventStream {
+	return &EventStream{
+		EventChannel: make(chan *WebSocketEvent, 100),
+		body:         body,
+		connectionID: connectionID,
+		sequence:     sequence,
+	}
+}
+
+// ConnectionID returns the id of the server side connection. Along with
+// NextSequence, it can be used to resume the stream without losing events.
+func (es *EventStream) ConnectionID() string {
+	es.mut.RLock()
+	defer es.mut.RUnlock()
+	return es.connectionID
+}
+
+// NextSequence returns the sequence number of the next expected event.
+func (es *EventStream) NextSequence() int64 {
+	es.mut.RLock()
+	defer es.mut.RUnlock()
+	return es.sequence
+}
+
+// Close ends the stream. EventChannel is closed once all buffered
+// events have been read.
+func (es *EventStream) Close() {
+	es.closeOnce.Do(func() {
+		es.body.Close()
+	})
+}
+
+// listen reads the stream until it ends, decoding each event and
+// pushing it to EventChannel.
+func (es *EventStream) listen() {
+	defer func() {
+		es.Close()
+		close(es.EventChannel)
+	}()
+
+	scanner := bufio.NewScanner(es.body)
+	scanner.Buffer(make([]byte, 0, avgReadMsgSizeBytes), eventStreamMaxLineSize)
+
+	var data bytes.Buffer
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		switch {
+		case len(line) == 0:
+			// An empty line dispatches the event.
+			if data.Len() > 0 {
+				es.dispatch(data.Bytes())
+				data.Reset()
+			}
+		case line[0] == ':':
+			// Comments are only used as keep-alives.
+		default:
+			field, value, _ := bytes.Cut(line, []byte(":"))
+			value = bytes.TrimPrefix(value, []byte(" "))
+			if string(field) == "data" {
+				if data.Len() > 0 {
+					data.WriteByte('\n')
+				}
+				data.Write(value)
+			}
+		}
+	}
+
+	es.ListenError = scanner.Err()
+}
+
+func (es *EventStream) dispatch(data []byte) {
+	event, err := WebSocketEventFromJSON(bytes.NewReader(data))
+	if err != nil {
+		return
+	}
+
+	es.mut.Lock()
+	if event.EventType() == WebsocketEventHello {
+		if connID, ok := event.GetData()["connection_id"].(string); ok {
+			es.connectionID = connID
+		}
+	}
+	es.sequence = event.GetSequence() + 1
+	es.mut.Unlock()
+
+	es.EventChannel <- event
+}
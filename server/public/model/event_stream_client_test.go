@@ -0,0 +1,46 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEventStreamListen(t *testing.T) {
+	body := strings.Join([]string{
+		`: ping`,
+		``,
+		`id: conn1:0`,
+		`data: {"event": "hello", "data": {"connection_id": "conn1"}, "broadcast": {}, "seq": 0}`,
+		``,
+		`id: conn1:1`,
+		`data: {"event": "typing", "data": {"user_id": "user1"},`,
+		`data:  "broadcast": {"channel_id": "channel1"}, "seq": 1}`,
+		``,
+		`data: not json`,
+		``,
+	}, "\n")
+
+	stream := newEventStream(io.NopCloser(strings.NewReader(body)), "", 0)
+	go stream.listen()
+
+	var events []*WebSocketEvent
+	for evt := range stream.EventChannel {
+		events = append(events, evt)
+	}
+
+	require.Len(t, events, 2)
+	assert.Equal(t, WebsocketEventHello, events[0].EventType())
+	assert.Equal(t, WebsocketEventTyping, events[1].EventType())
+	assert.Equal(t, "user1", events[1].GetData()["user_id"])
+	assert.Equal(t, "channel1", events[1].GetBroadcast().ChannelId)
+	assert.Equal(t, "conn1", stream.ConnectionID())
+	assert.Equal(t, int64(2), stream.NextSequence())
+	assert.NoError(t, stream.ListenError)
+}
@@ -15,6 +15,16 @@ const (
 	FileinfoSortBySize    = "Size"
 )
 
+// FileDownloadType identifies which representation of a file is being downloaded.
+type FileDownloadType string
+
+const (
+	FileDownloadTypeFile      FileDownloadType = "file"
+	FileDownloadTypeThumbnail FileDownloadType = "thumbnail"
+	FileDownloadTypePreview   FileDownloadType = "preview"
+	FileDownloadTypePublic    FileDownloadType = "public"
+)
+
 // GetFileInfosOptions contains options for getting FileInfos
 type GetFileInfosOptions struct {
 	// UserIds optionally limits the FileInfos to those created by the given users.
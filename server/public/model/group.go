@@ -11,6 +11,13 @@ import (
 const (
 	GroupSourceLdap   GroupSource = "ldap"
 	GroupSourceCustom GroupSource = "custom"
+	// GroupSourceOIDC groups are synchronized from the groups claim of OpenID Connect logins.
+	GroupSourceOIDC GroupSource = "oidc"
+	// GroupSourceScim groups are provisioned by an identity provider through SCIM.
+	GroupSourceScim GroupSource = "scim"
+	// GroupSourceAttribute groups have the users whose SAML or OpenID Connect attributes match
+	// the attribute rule of the group as members.
+	GroupSourceAttribute GroupSource = "attribute"
 
 	GroupNameMaxLength        = 64
 	GroupSourceMaxLength      = 64
@@ -24,10 +31,14 @@ type GroupSource string
 var allGroupSources = []GroupSource{
 	GroupSourceLdap,
 	GroupSourceCustom,
+	GroupSourceOIDC,
+	GroupSourceScim,
+	GroupSourceAttribute,
 }
 
 var groupSourcesRequiringRemoteID = []GroupSource{
 	GroupSourceLdap,
+	GroupSourceOIDC,
 }
 
 type Group struct {
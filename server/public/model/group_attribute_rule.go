@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	GroupAttributeRuleAttributeMaxLength = 255
+	GroupAttributeRuleValueMaxLength     = 255
+
+	// UserAttributesMaxValues is the maximum number of values of an attribute kept for a user.
+	UserAttributesMaxValues = 100
+
+	MembershipChangeActionAdd    = "add"
+	MembershipChangeActionRemove = "remove"
+
+	MembershipChangeTypeGroup   = "group"
+	MembershipChangeTypeTeam    = "team"
+	MembershipChangeTypeChannel = "channel"
+)
+
+// GroupAttributeRule makes the users whose SAML or OpenID Connect attribute has the given value
+// when logging in the members of an attribute group. Teams and channels are linked to the
+// group like to any other synchronized group.
+type GroupAttributeRule struct {
+	GroupId   string `json:"group_id"`
+	Attribute string `json:"attribute"`
+	Value     string `json:"value"`
+	CreateAt  int64  `json:"create_at"`
+	UpdateAt  int64  `json:"update_at"`
+}
+
+func (r *GroupAttributeRule) PreSave() {
+	if r.CreateAt == 0 {
+		r.CreateAt = GetMillis()
+	}
+	r.UpdateAt = GetMillis()
+}
+
+func (r *GroupAttributeRule) IsValid() *AppError {
+	if !IsValidId(r.GroupId) {
+		return NewAppError("GroupAttributeRule.IsValid", "model.group_attribute_rule.group_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if r.Attribute == "" || utf8.RuneCountInString(r.Attribute) > GroupAttributeRuleAttributeMaxLength {
+		return NewAppError("GroupAttributeRule.IsValid", "model.group_attribute_rule.attribute.app_error", map[string]any{"MaxLength": GroupAttributeRuleAttributeMaxLength}, "", http.StatusBadRequest)
+	}
+
+	if r.Value == "" || utf8.RuneCountInString(r.Value) > GroupAttributeRuleValueMaxLength {
+		return NewAppError("GroupAttributeRule.IsValid", "model.group_attribute_rule.value.app_error", map[string]any{"MaxLength": GroupAttributeRuleValueMaxLength}, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// Matches returns whether the attribute of the rule has its value among the given attributes.
+func (r *GroupAttributeRule) Matches(attributes map[string][]string) bool {
+	for _, value := range attributes[r.Attribute] {
+		if value == r.Value {
+			return true
+		}
+	}
+	return false
+}
+
+// MembershipChange is a group, team or channel membership that synchronizing attribute groups
+// adds or removes.
+type MembershipChange struct {
+	UserId   string `json:"user_id"`
+	Type     string `json:"type"`
+	TargetId string `json:"target_id"`
+	Action   string `json:"action"`
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGroupAttributeRuleIsValid(t *testing.T) {
+	rule := &GroupAttributeRule{GroupId: NewId(), Attribute: "department", Value: "Engineering"}
+	assert.Nil(t, rule.IsValid())
+
+	invalid := *rule
+	invalid.GroupId = "invalid"
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *rule
+	invalid.Attribute = ""
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *rule
+	invalid.Attribute = strings.Repeat("a", GroupAttributeRuleAttributeMaxLength+1)
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *rule
+	invalid.Value = ""
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *rule
+	invalid.Value = strings.Repeat("a", GroupAttributeRuleValueMaxLength+1)
+	assert.NotNil(t, invalid.IsValid())
+}
+
+func TestGroupAttributeRuleMatches(t *testing.T) {
+	rule := &GroupAttributeRule{GroupId: NewId(), Attribute: "department", Value: "Engineering"}
+
+	assert.True(t, rule.Matches(map[string][]string{"department": {"Sales", "Engineering"}}))
+	assert.False(t, rule.Matches(map[string][]string{"department": {"engineering"}}))
+	assert.False(t, rule.Matches(map[string][]string{"team": {"Engineering"}}))
+	assert.False(t, rule.Matches(nil))
+}
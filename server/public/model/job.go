@@ -41,6 +41,11 @@ const (
 	JobTypeExportUsersToCSV              = "export_users_to_csv"
 	JobTypeDeleteDmsPreferencesMigration = "delete_dms_preferences_migration"
 	JobTypeMobileSessionMetadata         = "mobile_session_metadata"
+	JobTypeCalendarStatusSync            = "calendar_status_sync"
+	JobTypeUserAccessTokenExpiryNotify   = "user_access_token_expiry_notify"
+	JobTypeOIDCSigningKeyRotation        = "oidc_signing_key_rotation"
+	JobTypeAttributeGroupSync            = "attribute_group_sync"
+	JobTypeEnforceChannelAccessPolicies  = "enforce_channel_access_policies"
 
 	JobStatusPending         = "pending"
 	JobStatusInProgress      = "in_progress"
@@ -171,6 +171,10 @@ type PluginSettingsSchema struct {
 //	  },
 //	  "props": {
 //	    "someKey": "someData"
+//	  },
+//	  "capabilities": ["read_posts", "manage_posts"],
+//	  "dependencies": {
+//	    "com.mattermost.other-plugin": ">=2.1.0 <3.0.0"
 //	  }
 //	}
 type Manifest struct {
@@ -218,6 +222,23 @@ type Manifest struct {
 
 	// Plugins can store any kind of data in Props to allow other plugins to use it.
 	Props map[string]any `json:"props,omitempty" yaml:"props,omitempty"`
+
+	// Capabilities lists the groups of plugin API methods the server side of the plugin
+	// calls, such as "read_posts" or "db_access". When declared, calls outside of them are
+	// refused and an administrator has to approve them before the plugin can be enabled.
+	// Plugins which don't declare capabilities keep unrestricted access to the plugin API,
+	// unless PluginSettings.RequirePluginCapabilities is set.
+	//
+	// Minimum server version: 10.5
+	Capabilities []string `json:"capabilities,omitempty" yaml:"capabilities,omitempty"`
+
+	// Dependencies maps the ids of the plugins this plugin requires to the range of their
+	// versions it supports, such as ">=2.1.0 <3.0.0". The plugin is only activated once each
+	// of them is active in a supported version, and they can't be disabled while this plugin
+	// is enabled, short of forcing it.
+	//
+	// Minimum server version: 10.5
+	Dependencies map[string]string `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
 }
 
 type ManifestServer struct {
@@ -233,6 +254,16 @@ type ManifestServer struct {
 	// If your plugin is compiled for multiple platforms, consider bundling them together
 	// and using the Executables field instead.
 	Executable string `json:"executable" yaml:"executable"`
+
+	// Wasm is the path to a WebAssembly module built for the wasip1 target, relative to the root
+	// of your bundle. When set, the server runs the plugin in-process within a sandboxed runtime
+	// instead of launching a native executable, and Executables and Executable are ignored.
+	//
+	// Build the module with GOOS=wasip1 GOARCH=wasm and -buildmode=c-shared, and register the
+	// plugin by calling plugin.WasmMain from an init function.
+	//
+	// Minimum server version: 10.5
+	Wasm string `json:"wasm,omitempty" yaml:"wasm,omitempty"`
 }
 
 type ManifestWebapp struct {
@@ -291,6 +322,11 @@ func (m *Manifest) HasServer() bool {
 	return m.Server != nil
 }
 
+// HasWasmServer reports whether the server side of the plugin is a WebAssembly module.
+func (m *Manifest) HasWasmServer() bool {
+	return m.Server != nil && m.Server.Wasm != ""
+}
+
 func (m *Manifest) HasWebapp() bool {
 	return m.Webapp != nil
 }
@@ -307,6 +343,22 @@ func (m *Manifest) MeetMinServerVersion(serverVersion string) (bool, error) {
 	return true, nil
 }
 
+// SatisfiesDependency reports whether the given plugin is in a version within the range this
+// plugin depends on.
+func (m *Manifest) SatisfiesDependency(dependency *Manifest) (bool, error) {
+	versionRange, err := semver.ParseRange(m.Dependencies[dependency.Id])
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse version range of dependency %q", dependency.Id)
+	}
+
+	version, err := semver.Parse(dependency.Version)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse version of dependency %q", dependency.Id)
+	}
+
+	return versionRange(version), nil
+}
+
 func (m *Manifest) IsValid() error {
 	if !IsValidPluginId(m.Id) {
 		return errors.New("invalid plugin ID")
@@ -342,6 +394,21 @@ func (m *Manifest) IsValid() error {
 		}
 	}
 
+	for _, capability := range m.Capabilities {
+		if !IsValidPluginCapability(capability) {
+			return errors.Errorf("invalid capability %q", capability)
+		}
+	}
+
+	for id, versionRange := range m.Dependencies {
+		if !IsValidPluginId(id) || id == m.Id {
+			return errors.Errorf("invalid dependency %q", id)
+		}
+		if _, err := semver.ParseRange(versionRange); err != nil {
+			return errors.Wrapf(err, "failed to parse version range of dependency %q", id)
+		}
+	}
+
 	if m.SettingsSchema != nil {
 		err := m.SettingsSchema.isValid()
 		if err != nil {
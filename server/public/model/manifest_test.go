@@ -31,7 +31,13 @@ func TestIsValid(t *testing.T) {
 		{"SettingSchema error", &Manifest{Id: "com.company.test", Name: "some name", HomepageURL: "http://someurl.com", SupportURL: "http://someotherurl.com", Version: "5.10.0", MinServerVersion: "5.10.8", SettingsSchema: &PluginSettingsSchema{
 			Settings: []*PluginSetting{{Type: "Invalid"}},
 		}}, true},
+		{"Invalid capability", &Manifest{Id: "com.company.test", Name: "some name", Capabilities: []string{PluginCapabilityReadPosts, "read_everything"}}, true},
+		{"Invalid dependency id", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: map[string]string{"some id": ">=1.0.0"}}, true},
+		{"Dependency on itself", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: map[string]string{"com.company.test": ">=1.0.0"}}, true},
+		{"Invalid dependency range", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: map[string]string{"com.company.other": "at least 1"}}, true},
 		{"Minimal valid manifest", &Manifest{Id: "com.company.test", Name: "some name"}, false},
+		{"Valid dependencies", &Manifest{Id: "com.company.test", Name: "some name", Dependencies: map[string]string{"com.company.other": ">=2.1.0 <3.0.0"}}, false},
+		{"Valid capabilities", &Manifest{Id: "com.company.test", Name: "some name", Capabilities: []string{PluginCapabilityReadPosts, PluginCapabilityDBAccess}}, false},
 		{"Happy case", &Manifest{
 			Id:               "com.company.test",
 			Name:             "thename",
@@ -112,6 +118,49 @@ func TestIsValid(t *testing.T) {
 	}
 }
 
+func TestManifestSatisfiesDependency(t *testing.T) {
+	m := &Manifest{Id: "com.company.test", Dependencies: map[string]string{"com.company.other": ">=2.1.0 <3.0.0"}}
+
+	for version, expected := range map[string]bool{
+		"2.0.9": false,
+		"2.1.0": true,
+		"2.9.3": true,
+		"3.0.0": false,
+	} {
+		satisfied, err := m.SatisfiesDependency(&Manifest{Id: "com.company.other", Version: version})
+		require.NoError(t, err)
+		assert.Equal(t, expected, satisfied, version)
+	}
+
+	_, err := m.SatisfiesDependency(&Manifest{Id: "com.company.other", Version: "latest"})
+	assert.Error(t, err)
+
+	m.Dependencies["com.company.other"] = "2.1.0"
+	satisfied, err := m.SatisfiesDependency(&Manifest{Id: "com.company.other", Version: "2.1.0"})
+	require.NoError(t, err)
+	assert.True(t, satisfied)
+
+	m.Dependencies["com.company.other"] = ">2.1.0 <=2.10.0"
+	satisfied, err = m.SatisfiesDependency(&Manifest{Id: "com.company.other", Version: "2.10.0"})
+	require.NoError(t, err)
+	assert.True(t, satisfied)
+	satisfied, err = m.SatisfiesDependency(&Manifest{Id: "com.company.other", Version: "2.1.0"})
+	require.NoError(t, err)
+	assert.False(t, satisfied)
+}
+
+func TestManifestUnapprovedCapabilities(t *testing.T) {
+	m := &Manifest{Id: "com.company.test"}
+	assert.False(t, m.DeclaresCapabilities())
+	assert.Empty(t, m.UnapprovedCapabilities(nil))
+
+	m.Capabilities = []string{PluginCapabilityReadPosts, PluginCapabilityManagePosts}
+	assert.True(t, m.DeclaresCapabilities())
+	assert.Equal(t, []string{PluginCapabilityReadPosts, PluginCapabilityManagePosts}, m.UnapprovedCapabilities(nil))
+	assert.Equal(t, []string{PluginCapabilityManagePosts}, m.UnapprovedCapabilities([]string{PluginCapabilityReadPosts}))
+	assert.Empty(t, m.UnapprovedCapabilities([]string{PluginCapabilityManagePosts, PluginCapabilityReadPosts, PluginCapabilityDBAccess}))
+}
+
 func TestIsValidSettingsSchema(t *testing.T) {
 	testCases := []struct {
 		Title          string
@@ -811,6 +860,12 @@ func TestManifestHasServer(t *testing.T) {
 	}
 }
 
+func TestManifestHasWasmServer(t *testing.T) {
+	assert.False(t, (&Manifest{}).HasWasmServer())
+	assert.False(t, (&Manifest{Server: &ManifestServer{Executable: "plugin.exe"}}).HasWasmServer())
+	assert.True(t, (&Manifest{Server: &ManifestServer{Wasm: "server/dist/plugin.wasm"}}).HasWasmServer())
+}
+
 func TestManifestHasWebapp(t *testing.T) {
 	testCases := []struct {
 		Description string
@@ -4,8 +4,10 @@
 package model
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"unicode/utf8"
 )
 
@@ -15,6 +17,8 @@ const (
 	OAuthActionEmailToSSO = "email_to_sso"
 	OAuthActionSSOToEmail = "sso_to_email"
 	OAuthActionMobile     = "mobile"
+
+	OAuthAppScopesMaxLength = 1024
 )
 
 type OAuthApp struct {
@@ -30,6 +34,16 @@ type OAuthApp struct {
 	Homepage        string      `json:"homepage"`
 	IsTrusted       bool        `json:"is_trusted"`
 	MattermostAppID string      `json:"mattermost_app_id"`
+	// IsPublic is set for clients that can't keep their secret confidential, such as native
+	// or command line applications. They don't authenticate with their client secret and must
+	// use PKCE with the authorization code flow.
+	IsPublic bool `json:"is_public"`
+	// Scopes restricts the sessions of the app to a subset of the permissions, teams and
+	// channels of its users, in the same format as the scopes of user access tokens. An app
+	// without scopes isn't restricted.
+	Scopes StringArray `json:"scopes"`
+	// BotUserId is the bot the app acts as when using the client credentials grant.
+	BotUserId string `json:"bot_user_id"`
 }
 
 func (a *OAuthApp) Auditable() map[string]interface{} {
@@ -45,6 +59,9 @@ func (a *OAuthApp) Auditable() map[string]interface{} {
 		"homepage":          a.Homepage,
 		"is_trusted":        a.IsTrusted,
 		"mattermost_app_id": a.MattermostAppID,
+		"is_public":         a.IsPublic,
+		"scopes":            a.Scopes,
+		"bot_user_id":       a.BotUserId,
 	}
 }
 
@@ -103,6 +120,22 @@ func (a *OAuthApp) IsValid() *AppError {
 		return NewAppError("OAuthApp.IsValid", "model.oauth.is_valid.mattermost_app_id.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
 	}
 
+	// Public clients can't authenticate as a bot since they don't keep their secret.
+	if a.BotUserId != "" && (!IsValidId(a.BotUserId) || a.IsPublic) {
+		return NewAppError("OAuthApp.IsValid", "model.oauth.is_valid.bot_user_id.app_error", nil, "app_id="+a.Id, http.StatusBadRequest)
+	}
+
+	// The scopes are stored encoded as JSON.
+	if scopes, _ := json.Marshal(a.Scopes); len(scopes) > OAuthAppScopesMaxLength {
+		return NewAppError("OAuthApp.IsValid", "model.oauth.is_valid.scopes_length.app_error", map[string]any{"Limit": OAuthAppScopesMaxLength}, "app_id="+a.Id, http.StatusBadRequest)
+	}
+
+	for _, scope := range a.Scopes {
+		if !isValidUserAccessTokenScope(scope) {
+			return NewAppError("OAuthApp.IsValid", "model.oauth.is_valid.scope.app_error", map[string]any{"Scope": scope}, "app_id="+a.Id, http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
@@ -145,3 +178,50 @@ func (a *OAuthApp) IsValidRedirectURL(url string) bool {
 
 	return false
 }
+
+// GetGrantedScope returns the scope granted to the app for the requested one, or false if the
+// app isn't allowed the requested scope. The requested scopes of each kind the app is
+// restricted to must be a subset of the app's, and the app's scopes are granted for the kinds
+// that weren't requested, so that the sessions of the app are never less restricted than the
+// app itself.
+func (a *OAuthApp) GetGrantedScope(requested string) (string, bool) {
+	if requested == "" {
+		requested = DefaultScope
+	}
+
+	if len(a.Scopes) == 0 {
+		return requested, true
+	}
+
+	allowed := ParseUserAccessTokenScopes(a.Scopes)
+	requestedKinds := map[string]bool{}
+	granted := []string{}
+	for _, scope := range strings.Fields(requested) {
+		if scope == DefaultScope {
+			continue
+		}
+
+		// The scopes of OpenID Connect select claims rather than restrict the token.
+		if IsOIDCScope(scope) {
+			granted = append(granted, scope)
+			continue
+		}
+
+		kind, id, _ := strings.Cut(scope, ":")
+		if !allowed[kind][id] {
+			return "", false
+		}
+
+		requestedKinds[kind] = true
+		granted = append(granted, scope)
+	}
+
+	for _, scope := range a.Scopes {
+		kind, _, _ := strings.Cut(scope, ":")
+		if !requestedKinds[kind] {
+			granted = append(granted, scope)
+		}
+	}
+
+	return strings.Join(granted, " "), true
+}
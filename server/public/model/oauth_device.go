@@ -0,0 +1,93 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"math/big"
+	"strings"
+)
+
+const (
+	TokenTypeOAuthDeviceCode = "oauth_device_code"
+	TokenTypeOAuthUserCode   = "oauth_user_code"
+
+	OAuthDeviceCodeExpireTime = 60 * 10 // 10 minutes
+	OAuthDevicePollInterval   = 5       // 5 seconds
+
+	OAuthDeviceAuthorizationStatusPending  = "pending"
+	OAuthDeviceAuthorizationStatusApproved = "approved"
+	OAuthDeviceAuthorizationStatusDenied   = "denied"
+
+	// The error codes of RFC 8628 returned while polling for the token of a device.
+	OAuthErrorAuthorizationPending = "authorization_pending"
+	OAuthErrorSlowDown             = "slow_down"
+	OAuthErrorAccessDenied         = "access_denied"
+	OAuthErrorExpiredToken         = "expired_token"
+
+	// The user codes avoid vowels and ambiguous characters, as advised by RFC 8628.
+	oauthUserCodeCharset = "BCDFGHJKLMNPQRSTVWXZ"
+	oauthUserCodeLength  = 8
+)
+
+// OAuthDeviceAuthorization is a pending authorization request of the device flow, stored as
+// the extra data of the token of its device code.
+type OAuthDeviceAuthorization struct {
+	ClientId     string `json:"client_id"`
+	Scope        string `json:"scope"`
+	Status       string `json:"status"`
+	UserId       string `json:"user_id,omitempty"`
+	ExpiresAt    int64  `json:"expires_at"`
+	Interval     int    `json:"interval"`
+	LastPolledAt int64  `json:"last_polled_at,omitempty"`
+}
+
+func (da *OAuthDeviceAuthorization) IsExpired() bool {
+	return GetMillis() > da.ExpiresAt
+}
+
+type OAuthDeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresInSeconds        int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// NewOAuthUserCode generates the code a user enters to approve the authorization request of a
+// device, formatted as XXXX-XXXX.
+func NewOAuthUserCode() string {
+	var code strings.Builder
+	max := big.NewInt(int64(len(oauthUserCodeCharset)))
+	for i := 0; i < oauthUserCodeLength; i++ {
+		if i == oauthUserCodeLength/2 {
+			code.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			panic(err)
+		}
+		code.WriteByte(oauthUserCodeCharset[n.Int64()])
+	}
+	return code.String()
+}
+
+// OAuthUserCodeTokenKey returns the key of the token storing a user code, which is hashed
+// so that it has the size of tokens. The code is normalized first, since users may type it
+// in lowercase or without its separator.
+func OAuthUserCodeTokenKey(userCode string) string {
+	normalized := strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(userCode))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
+
+// OAuthDeviceAuthorizeRequest is sent by a user to approve or deny the authorization request
+// of a device.
+type OAuthDeviceAuthorizeRequest struct {
+	UserCode string `json:"user_code"`
+	Approve  bool   `json:"approve"`
+}
@@ -0,0 +1,30 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewOAuthUserCode(t *testing.T) {
+	code := NewOAuthUserCode()
+	require.Regexp(t, regexp.MustCompile(`^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`), code)
+	require.NotEqual(t, code, NewOAuthUserCode())
+}
+
+func TestOAuthUserCodeTokenKey(t *testing.T) {
+	key := OAuthUserCodeTokenKey("BCDF-GHJK")
+	require.Len(t, key, TokenSize)
+	require.Equal(t, key, OAuthUserCodeTokenKey("bcdfghjk"))
+	require.Equal(t, key, OAuthUserCodeTokenKey("bcdf ghjk"))
+	require.NotEqual(t, key, OAuthUserCodeTokenKey("BCDF-GHJL"))
+}
+
+func TestOAuthDeviceAuthorizationIsExpired(t *testing.T) {
+	require.True(t, (&OAuthDeviceAuthorization{ExpiresAt: GetMillis() - 1}).IsExpired())
+	require.False(t, (&OAuthDeviceAuthorization{ExpiresAt: GetMillis() + 60000}).IsExpired())
+}
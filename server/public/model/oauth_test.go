@@ -68,3 +68,87 @@ func TestOAuthAppIsValid(t *testing.T) {
 	app.IconURL = "https://nowhere.com/icon_image.png"
 	require.Nil(t, app.IsValid())
 }
+
+func TestOAuthAppIsValidPublicClientAndScopes(t *testing.T) {
+	app := OAuthApp{
+		Id:           NewId(),
+		CreateAt:     1,
+		UpdateAt:     1,
+		CreatorId:    NewId(),
+		ClientSecret: NewId(),
+		Name:         "TestOAuthApp",
+		CallbackUrls: []string{"https://nowhere.com"},
+		Homepage:     "https://nowhere.com",
+	}
+	require.Nil(t, app.IsValid())
+
+	app.BotUserId = "junk"
+	appErr := app.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.oauth.is_valid.bot_user_id.app_error", appErr.Id)
+
+	app.BotUserId = NewId()
+	require.Nil(t, app.IsValid())
+
+	app.IsPublic = true
+	appErr = app.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.oauth.is_valid.bot_user_id.app_error", appErr.Id)
+
+	app.BotUserId = ""
+	require.Nil(t, app.IsValid())
+
+	app.Scopes = StringArray{"permission:" + PermissionCreatePost.Id, "channel:" + NewId()}
+	require.Nil(t, app.IsValid())
+
+	app.Scopes = StringArray{"permission:junk"}
+	appErr = app.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.oauth.is_valid.scope.app_error", appErr.Id)
+}
+
+func TestOAuthAppGetGrantedScope(t *testing.T) {
+	channelID := NewId()
+	otherChannelID := NewId()
+	permission := "permission:" + PermissionCreatePost.Id
+	channel := "channel:" + channelID
+	otherChannel := "channel:" + otherChannelID
+
+	t.Run("app without scopes", func(t *testing.T) {
+		app := OAuthApp{}
+
+		scope, ok := app.GetGrantedScope("")
+		require.True(t, ok)
+		require.Equal(t, DefaultScope, scope)
+
+		scope, ok = app.GetGrantedScope("all")
+		require.True(t, ok)
+		require.Equal(t, "all", scope)
+	})
+
+	t.Run("app with scopes", func(t *testing.T) {
+		app := OAuthApp{Scopes: StringArray{permission, channel, otherChannel}}
+
+		scope, ok := app.GetGrantedScope("")
+		require.True(t, ok)
+		require.Equal(t, permission+" "+channel+" "+otherChannel, scope)
+
+		scope, ok = app.GetGrantedScope(DefaultScope + " " + channel)
+		require.True(t, ok)
+		require.Equal(t, channel+" "+permission, scope)
+
+		_, ok = app.GetGrantedScope("channel:" + NewId())
+		require.False(t, ok)
+
+		_, ok = app.GetGrantedScope("all")
+		require.False(t, ok)
+	})
+
+	t.Run("app with scopes granted openid connect scopes", func(t *testing.T) {
+		app := OAuthApp{Scopes: StringArray{permission, channel}}
+
+		scope, ok := app.GetGrantedScope(OIDCScopeOpenID + " " + OIDCScopeEmail + " " + channel)
+		require.True(t, ok)
+		require.Equal(t, OIDCScopeOpenID+" "+OIDCScopeEmail+" "+channel+" "+permission, scope)
+	})
+}
@@ -0,0 +1,283 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+)
+
+const (
+	// The scopes of OpenID Connect. They select the claims of the ID token and the user info
+	// returned to an app, and don't restrict the sessions of the tokens issued to it.
+	OIDCScopeOpenID  = "openid"
+	OIDCScopeProfile = "profile"
+	OIDCScopeEmail   = "email"
+	OIDCScopeGroups  = "groups"
+
+	OIDCSigningAlgorithm  = "RS256"
+	OIDCIdTokenExpireTime = 60 * 60 // 1 hour
+	OIDCNonceMaxLength    = 256
+
+	// OIDCSigningKeyRotationPeriod is how long a signing key is used before a new one replaces it.
+	OIDCSigningKeyRotationPeriod = 90 * 24 * time.Hour
+	// OIDCSigningKeyRetirementPeriod is how long a replaced signing key is still published, so
+	// that the ID tokens it signed can be verified until they expire, even by clients caching
+	// the published keys.
+	OIDCSigningKeyRetirementPeriod = 7 * 24 * time.Hour
+
+	oidcSigningKeyBits = 2048
+)
+
+// IsOIDCScope returns whether the scope is one of the scopes of OpenID Connect.
+func IsOIDCScope(scope string) bool {
+	switch scope {
+	case OIDCScopeOpenID, OIDCScopeProfile, OIDCScopeEmail, OIDCScopeGroups:
+		return true
+	}
+	return false
+}
+
+// HasOIDCScope returns whether the space separated scopes include the given one.
+func HasOIDCScope(scopes, scope string) bool {
+	return slices.Contains(strings.Fields(scopes), scope)
+}
+
+// OIDCProviderMetadata is the discovery document of the OpenID Connect provider.
+type OIDCProviderMetadata struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	DeviceAuthorizationEndpoint       string   `json:"device_authorization_endpoint"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IdTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+// NewOIDCProviderMetadata returns the discovery document of the provider served at the given
+// site URL, which is also its issuer.
+func NewOIDCProviderMetadata(siteURL string) *OIDCProviderMetadata {
+	return &OIDCProviderMetadata{
+		Issuer:                      siteURL,
+		AuthorizationEndpoint:       siteURL + "/oauth/authorize",
+		TokenEndpoint:               siteURL + "/oauth/access_token",
+		UserinfoEndpoint:            siteURL + "/oauth/userinfo",
+		JWKSURI:                     siteURL + "/oauth/jwks",
+		DeviceAuthorizationEndpoint: siteURL + "/oauth/device_authorization",
+		ScopesSupported:             []string{OIDCScopeOpenID, OIDCScopeProfile, OIDCScopeEmail, OIDCScopeGroups, DefaultScope},
+		ResponseTypesSupported:      []string{AuthCodeResponseType, ImplicitResponseType},
+		GrantTypesSupported: []string{
+			AccessTokenGrantType,
+			RefreshTokenGrantType,
+			ClientCredentialsGrantType,
+			DeviceCodeGrantType,
+		},
+		SubjectTypesSupported:             []string{"public"},
+		IdTokenSigningAlgValuesSupported:  []string{OIDCSigningAlgorithm},
+		TokenEndpointAuthMethodsSupported: []string{"client_secret_post", "client_secret_basic", "none"},
+		CodeChallengeMethodsSupported:     []string{PKCECodeChallengeMethodS256, PKCECodeChallengeMethodPlain},
+		ClaimsSupported: []string{
+			"iss", "sub", "aud", "exp", "iat", "nonce", "azp",
+			"email", "email_verified",
+			"name", "given_name", "family_name", "nickname", "preferred_username", "locale", "updated_at",
+			"groups",
+		},
+	}
+}
+
+// OIDCUserInfo holds the claims about a user released to an app, depending on the scopes it
+// was granted. They are returned by the user info endpoint and included in ID tokens.
+type OIDCUserInfo struct {
+	Subject           string   `json:"sub"`
+	Email             string   `json:"email,omitempty"`
+	EmailVerified     *bool    `json:"email_verified,omitempty"`
+	Name              string   `json:"name,omitempty"`
+	GivenName         string   `json:"given_name,omitempty"`
+	FamilyName        string   `json:"family_name,omitempty"`
+	Nickname          string   `json:"nickname,omitempty"`
+	PreferredUsername string   `json:"preferred_username,omitempty"`
+	Locale            string   `json:"locale,omitempty"`
+	UpdatedAt         int64    `json:"updated_at,omitempty"`
+	Groups            []string `json:"groups,omitempty"`
+}
+
+// NewOIDCUserInfo returns the claims about the user allowed by the space separated scopes. The
+// groups are only included along with the groups scope.
+func NewOIDCUserInfo(user *User, scopes string, groups []string) *OIDCUserInfo {
+	info := &OIDCUserInfo{Subject: user.Id}
+
+	if HasOIDCScope(scopes, OIDCScopeEmail) {
+		info.Email = user.Email
+		info.EmailVerified = NewPointer(user.EmailVerified)
+	}
+
+	if HasOIDCScope(scopes, OIDCScopeProfile) {
+		info.Name = strings.TrimSpace(user.FirstName + " " + user.LastName)
+		info.GivenName = user.FirstName
+		info.FamilyName = user.LastName
+		info.Nickname = user.Nickname
+		info.PreferredUsername = user.Username
+		info.Locale = user.Locale
+		info.UpdatedAt = user.UpdateAt / 1000
+	}
+
+	if HasOIDCScope(scopes, OIDCScopeGroups) {
+		info.Groups = groups
+		if info.Groups == nil {
+			info.Groups = []string{}
+		}
+	}
+
+	return info
+}
+
+// JSONWebKey is the public part of a signing key, as published in a JSON Web Key Set. RSA keys
+// have a modulus and an exponent, and elliptic curve keys a curve and coordinates.
+type JSONWebKey struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyId     string `json:"kid"`
+	Modulus   string `json:"n,omitempty"`
+	Exponent  string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+	Y         string `json:"y,omitempty"`
+}
+
+// PublicKey returns the RSA or elliptic curve public key to verify signatures with.
+func (k *JSONWebKey) PublicKey() (crypto.PublicKey, error) {
+	switch k.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.Modulus)
+		if err != nil {
+			return nil, fmt.Errorf("invalid modulus: %w", err)
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.Exponent)
+		if err != nil {
+			return nil, fmt.Errorf("invalid exponent: %w", err)
+		}
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 2 || exponent.Int64() > math.MaxInt32 {
+			return nil, errors.New("invalid rsa key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Curve {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Curve)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, fmt.Errorf("invalid x coordinate: %w", err)
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("invalid y coordinate: %w", err)
+		}
+		key := &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}
+		if !curve.IsOnCurve(key.X, key.Y) {
+			return nil, errors.New("invalid elliptic curve key")
+		}
+		return key, nil
+	}
+
+	return nil, fmt.Errorf("unsupported key type %q", k.KeyType)
+}
+
+type JSONWebKeySet struct {
+	Keys []JSONWebKey `json:"keys"`
+}
+
+// OIDCSigningKey is a key signing ID tokens. The signing keys are stored together in the
+// systems table.
+type OIDCSigningKey struct {
+	Id         string `json:"id"`
+	PrivateKey string `json:"private_key"`
+	CreateAt   int64  `json:"create_at"`
+	// RetireAt is set when the key is replaced by a new one. It's no longer used to sign, but
+	// still published for OIDCSigningKeyRetirementPeriod.
+	RetireAt int64 `json:"retire_at,omitempty"`
+}
+
+// NewOIDCSigningKey generates a new RSA signing key.
+func NewOIDCSigningKey() (*OIDCSigningKey, error) {
+	key, err := rsa.GenerateKey(rand.Reader, oidcSigningKeyBits)
+	if err != nil {
+		return nil, err
+	}
+
+	der := x509.MarshalPKCS1PrivateKey(key)
+	return &OIDCSigningKey{
+		Id:         NewId(),
+		PrivateKey: string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: der})),
+		CreateAt:   GetMillis(),
+	}, nil
+}
+
+func (k *OIDCSigningKey) RSAPrivateKey() (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(k.PrivateKey))
+	if block == nil {
+		return nil, errors.New("failed to decode the pem block of the signing key")
+	}
+	return x509.ParsePKCS1PrivateKey(block.Bytes)
+}
+
+// PublicJSONWebKey returns the public part of the key to publish.
+func (k *OIDCSigningKey) PublicJSONWebKey() (*JSONWebKey, error) {
+	key, err := k.RSAPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+
+	return &JSONWebKey{
+		KeyType:   "RSA",
+		Use:       "sig",
+		Algorithm: OIDCSigningAlgorithm,
+		KeyId:     k.Id,
+		Modulus:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		Exponent:  base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}, nil
+}
+
+func (k *OIDCSigningKey) IsRetired() bool {
+	return k.RetireAt != 0
+}
+
+// IsExpired returns whether a retired key no longer needs to be published.
+func (k *OIDCSigningKey) IsExpired() bool {
+	return k.IsRetired() && GetMillis() > k.RetireAt+OIDCSigningKeyRetirementPeriod.Milliseconds()
+}
+
+// NeedsRotation returns whether the key has been used for long enough to be replaced.
+func (k *OIDCSigningKey) NeedsRotation() bool {
+	return GetMillis() > k.CreateAt+OIDCSigningKeyRotationPeriod.Milliseconds()
+}
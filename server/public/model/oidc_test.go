@@ -0,0 +1,164 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsOIDCScope(t *testing.T) {
+	for _, scope := range []string{OIDCScopeOpenID, OIDCScopeProfile, OIDCScopeEmail, OIDCScopeGroups} {
+		assert.True(t, IsOIDCScope(scope), scope)
+	}
+
+	assert.False(t, IsOIDCScope(DefaultScope))
+	assert.False(t, IsOIDCScope("channel:"+NewId()))
+
+	assert.True(t, HasOIDCScope("user openid email", OIDCScopeOpenID))
+	assert.False(t, HasOIDCScope("user openidx", OIDCScopeOpenID))
+	assert.False(t, HasOIDCScope("", OIDCScopeOpenID))
+}
+
+func TestNewOIDCUserInfo(t *testing.T) {
+	user := &User{
+		Id:            NewId(),
+		Username:      "jdoe",
+		Email:         "jdoe@example.com",
+		EmailVerified: true,
+		FirstName:     "Jane",
+		LastName:      "Doe",
+		Nickname:      "jd",
+		Locale:        "en",
+		UpdateAt:      1700000000123,
+	}
+	groups := []string{"developers"}
+
+	t.Run("openid only", func(t *testing.T) {
+		info := NewOIDCUserInfo(user, OIDCScopeOpenID, groups)
+		assert.Equal(t, &OIDCUserInfo{Subject: user.Id}, info)
+	})
+
+	t.Run("email", func(t *testing.T) {
+		info := NewOIDCUserInfo(user, "openid email", groups)
+		assert.Equal(t, user.Email, info.Email)
+		require.NotNil(t, info.EmailVerified)
+		assert.True(t, *info.EmailVerified)
+		assert.Empty(t, info.PreferredUsername)
+	})
+
+	t.Run("profile", func(t *testing.T) {
+		info := NewOIDCUserInfo(user, "openid profile", groups)
+		assert.Equal(t, "Jane Doe", info.Name)
+		assert.Equal(t, "Jane", info.GivenName)
+		assert.Equal(t, "Doe", info.FamilyName)
+		assert.Equal(t, "jd", info.Nickname)
+		assert.Equal(t, "jdoe", info.PreferredUsername)
+		assert.Equal(t, int64(1700000000), info.UpdatedAt)
+		assert.Empty(t, info.Email)
+		assert.Nil(t, info.Groups)
+	})
+
+	t.Run("groups", func(t *testing.T) {
+		info := NewOIDCUserInfo(user, "openid groups", groups)
+		assert.Equal(t, groups, info.Groups)
+
+		info = NewOIDCUserInfo(user, "openid groups", nil)
+		assert.NotNil(t, info.Groups)
+		assert.Empty(t, info.Groups)
+	})
+}
+
+func TestOIDCSigningKey(t *testing.T) {
+	key, err := NewOIDCSigningKey()
+	require.NoError(t, err)
+	require.True(t, IsValidId(key.Id))
+
+	privateKey, err := key.RSAPrivateKey()
+	require.NoError(t, err)
+
+	jwk, err := key.PublicJSONWebKey()
+	require.NoError(t, err)
+	assert.Equal(t, "RSA", jwk.KeyType)
+	assert.Equal(t, OIDCSigningAlgorithm, jwk.Algorithm)
+	assert.Equal(t, key.Id, jwk.KeyId)
+
+	modulus, err := base64.RawURLEncoding.DecodeString(jwk.Modulus)
+	require.NoError(t, err)
+	assert.Equal(t, 0, new(big.Int).SetBytes(modulus).Cmp(privateKey.N))
+	assert.Equal(t, "AQAB", jwk.Exponent)
+
+	t.Run("rotation", func(t *testing.T) {
+		assert.False(t, key.NeedsRotation())
+		assert.False(t, key.IsRetired())
+		assert.False(t, key.IsExpired())
+
+		key.CreateAt = GetMillis() - OIDCSigningKeyRotationPeriod.Milliseconds() - 1
+		assert.True(t, key.NeedsRotation())
+
+		key.RetireAt = GetMillis()
+		assert.True(t, key.IsRetired())
+		assert.False(t, key.IsExpired())
+
+		key.RetireAt = GetMillis() - OIDCSigningKeyRetirementPeriod.Milliseconds() - 1
+		assert.True(t, key.IsExpired())
+	})
+
+	t.Run("invalid private key", func(t *testing.T) {
+		_, err := (&OIDCSigningKey{Id: NewId(), PrivateKey: "invalid"}).RSAPrivateKey()
+		require.Error(t, err)
+	})
+}
+
+func TestJSONWebKeyPublicKey(t *testing.T) {
+	t.Run("rsa", func(t *testing.T) {
+		key, err := NewOIDCSigningKey()
+		require.NoError(t, err)
+		privateKey, err := key.RSAPrivateKey()
+		require.NoError(t, err)
+
+		jwk, err := key.PublicJSONWebKey()
+		require.NoError(t, err)
+		publicKey, err := jwk.PublicKey()
+		require.NoError(t, err)
+		assert.True(t, privateKey.PublicKey.Equal(publicKey))
+	})
+
+	t.Run("elliptic curve", func(t *testing.T) {
+		privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+
+		jwk := &JSONWebKey{
+			KeyType: "EC",
+			Curve:   "P-256",
+			X:       base64.RawURLEncoding.EncodeToString(privateKey.X.Bytes()),
+			Y:       base64.RawURLEncoding.EncodeToString(privateKey.Y.Bytes()),
+		}
+		publicKey, err := jwk.PublicKey()
+		require.NoError(t, err)
+		assert.True(t, privateKey.PublicKey.Equal(publicKey))
+
+		jwk.Y = jwk.X
+		_, err = jwk.PublicKey()
+		assert.Error(t, err)
+	})
+
+	t.Run("unsupported", func(t *testing.T) {
+		_, err := (&JSONWebKey{KeyType: "oct"}).PublicKey()
+		assert.Error(t, err)
+
+		_, err = (&JSONWebKey{KeyType: "EC", Curve: "P-224"}).PublicKey()
+		assert.Error(t, err)
+
+		_, err = (&JSONWebKey{KeyType: "RSA", Modulus: "AQAB", Exponent: "AQ"}).PublicKey()
+		assert.Error(t, err)
+	})
+}
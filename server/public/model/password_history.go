@@ -0,0 +1,22 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+// PasswordHistory is a previous password of a user, kept to prevent its reuse.
+type PasswordHistory struct {
+	Id           string
+	UserId       string
+	PasswordHash string
+	CreateAt     int64
+}
+
+func (h *PasswordHistory) PreSave() {
+	if h.Id == "" {
+		h.Id = NewId()
+	}
+
+	if h.CreateAt == 0 {
+		h.CreateAt = GetMillis()
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"slices"
+)
+
+// Plugin capabilities group the plugin API methods a plugin may declare in its manifest.
+// PluginCapabilityInterPluginHTTP covers the HTTP requests a plugin makes to other plugins and to
+// the server through the plugin API. It doesn't cover outbound requests, which plugins make
+// directly from their process and are left to the network policy of the host.
+const (
+	PluginCapabilityReadConfig         = "read_config"
+	PluginCapabilityManageConfig       = "manage_config"
+	PluginCapabilityReadUsers          = "read_users"
+	PluginCapabilityManageUsers        = "manage_users"
+	PluginCapabilityManageBots         = "manage_bots"
+	PluginCapabilityManageSessions     = "manage_sessions"
+	PluginCapabilityReadTeams          = "read_teams"
+	PluginCapabilityManageTeams        = "manage_teams"
+	PluginCapabilityReadChannels       = "read_channels"
+	PluginCapabilityManageChannels     = "manage_channels"
+	PluginCapabilityReadPosts          = "read_posts"
+	PluginCapabilityManagePosts        = "manage_posts"
+	PluginCapabilityReadFiles          = "read_files"
+	PluginCapabilityManageFiles        = "manage_files"
+	PluginCapabilityManagePlugins      = "manage_plugins"
+	PluginCapabilityManageIntegrations = "manage_integrations"
+	PluginCapabilitySendNotifications  = "send_notifications"
+	PluginCapabilityDBAccess           = "db_access"
+	PluginCapabilityInterPluginHTTP    = "inter_plugin_http"
+)
+
+var AllPluginCapabilities = []string{
+	PluginCapabilityReadConfig,
+	PluginCapabilityManageConfig,
+	PluginCapabilityReadUsers,
+	PluginCapabilityManageUsers,
+	PluginCapabilityManageBots,
+	PluginCapabilityManageSessions,
+	PluginCapabilityReadTeams,
+	PluginCapabilityManageTeams,
+	PluginCapabilityReadChannels,
+	PluginCapabilityManageChannels,
+	PluginCapabilityReadPosts,
+	PluginCapabilityManagePosts,
+	PluginCapabilityReadFiles,
+	PluginCapabilityManageFiles,
+	PluginCapabilityManagePlugins,
+	PluginCapabilityManageIntegrations,
+	PluginCapabilitySendNotifications,
+	PluginCapabilityDBAccess,
+	PluginCapabilityInterPluginHTTP,
+}
+
+func IsValidPluginCapability(capability string) bool {
+	return slices.Contains(AllPluginCapabilities, capability)
+}
+
+// DeclaresCapabilities reports whether the plugin restricts itself to the capabilities
+// listed in its manifest. An empty, but present, list declares no capabilities at all.
+func (m *Manifest) DeclaresCapabilities() bool {
+	return m.Capabilities != nil
+}
+
+// UnapprovedCapabilities returns the declared capabilities missing from approved.
+func (m *Manifest) UnapprovedCapabilities(approved []string) []string {
+	var unapproved []string
+	for _, capability := range m.Capabilities {
+		if !slices.Contains(approved, capability) {
+			unapproved = append(unapproved, capability)
+		}
+	}
+	return unapproved
+}
@@ -0,0 +1,26 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import "unicode/utf8"
+
+const PluginEventTopicMaxRunes = 256
+
+// PluginEvent is published by a plugin to the other plugins of the server subscribed to its
+// topic.
+type PluginEvent struct {
+	// Topic identifies the kind of event. By convention, topics are prefixed by the id of the
+	// publishing plugin and suffixed by the version of the payload, such as
+	// "com.mattermost.calls/call_started/v1", so that subscribers rely on a stable schema.
+	Topic string
+	// SourcePluginId is the id of the plugin which published the event.
+	SourcePluginId string
+	// Payload is the JSON encoded payload of the event.
+	Payload []byte
+}
+
+// IsValidPluginEventTopic reports whether plugins may publish and subscribe to the given topic.
+func IsValidPluginEventTopic(topic string) bool {
+	return topic != "" && utf8.RuneCountInString(topic) <= PluginEventTopicMaxRunes
+}
@@ -11,6 +11,10 @@ import (
 const (
 	KeyValuePluginIdMaxRunes = 190
 	KeyValueKeyMaxRunes      = 150
+
+	// PluginKVBatchMaxOperations is the maximum number of operations of a single batch of writes
+	// to the plugin KV store.
+	PluginKVBatchMaxOperations = 1000
 )
 
 type PluginKeyValue struct {
@@ -45,3 +45,11 @@ func NewPluginKeyValueFromOptions(pluginId, key string, value []byte, opt Plugin
 
 	return kv, nil
 }
+
+// PluginKVBatchOperation is a single write of a batch applied atomically to the plugin KV store.
+// A nil Value removes the key.
+type PluginKVBatchOperation struct {
+	Key     string             `json:"key"`
+	Value   []byte             `json:"value"`
+	Options PluginKVSetOptions `json:"options"`
+}
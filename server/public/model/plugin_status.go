@@ -22,6 +22,25 @@ type PluginStatus struct {
 	Name        string `json:"name"`
 	Description string `json:"description"`
 	Version     string `json:"version"`
+
+	// Resources reports the resources used by the plugin process, if the plugin is running as
+	// a separate process on a platform where they can be measured.
+	Resources *PluginResourceUsage `json:"resources,omitempty"`
+}
+
+// PluginResourceUsage reports the resources used by a plugin process along with its limits, as
+// last measured by the plugin health check. Zero limits are unbounded.
+type PluginResourceUsage struct {
+	MemoryMB      int     `json:"memory_mb"`
+	MaxMemoryMB   int     `json:"max_memory_mb"`
+	CPUPercent    float64 `json:"cpu_percent"`
+	MaxCPUPercent int     `json:"max_cpu_percent"`
+	OpenFiles     int     `json:"open_files"`
+	MaxOpenFiles  int     `json:"max_open_files"`
+
+	// Cgroup is whether the memory and CPU limits are enforced by the kernel through a cgroup,
+	// rather than by restarting the plugin once it exceeds them.
+	Cgroup bool `json:"cgroup"`
 }
 
 type PluginStatuses []*PluginStatus
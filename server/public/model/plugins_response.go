@@ -5,6 +5,8 @@ package model
 
 type PluginInfo struct {
 	Manifest
+	// ApprovedCapabilities are the manifest capabilities an administrator approved.
+	ApprovedCapabilities []string `json:"approved_capabilities,omitempty"`
 }
 
 type PluginsResponse struct {
@@ -186,6 +186,8 @@ type PostPersistentNotifications struct {
 	LastSentAt int64
 	DeleteAt   int64
 	SentCount  int16
+	// EscalationLevel is the number of escalation policy steps already run.
+	EscalationLevel int16
 }
 
 type GetPersistentNotificationsPostsParams struct {
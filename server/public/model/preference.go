@@ -66,6 +66,11 @@ const (
 	// Possible Name values are:
 	// - PreferenceNameEmailInterval
 	PreferenceCategoryNotifications = "notifications"
+	// PreferenceCategoryCalendar is used to store the calendar which drives the user's status.
+	// Possible Name values are:
+	// - PreferenceNameCalendarFeed
+	// - PreferenceNameCalendarAppliedStatus
+	PreferenceCategoryCalendar = "calendar"
 
 	// Deprecated: PreferenceRecommendedNextSteps is not used anymore.
 	// Use PreferenceCategoryRecommendedNextSteps instead.
@@ -106,6 +111,14 @@ const (
 
 	PreferenceNameEmailInterval = "email_interval"
 
+	// PreferenceNameCalendarFeed is the URL of the user's ICS feed, or
+	// PreferenceCalendarFeedFile if the user uploaded a calendar file instead.
+	PreferenceNameCalendarFeed = "feed"
+	// PreferenceNameCalendarAppliedStatus is the status last set on behalf
+	// of the user by the calendar sync, so that it can be reverted later.
+	PreferenceNameCalendarAppliedStatus = "applied_status"
+	PreferenceCalendarFeedFile          = "file"
+
 	PreferenceEmailIntervalNoBatchingSeconds = "30"  // the "immediate" setting is actually 30s
 	PreferenceEmailIntervalBatchingSeconds   = "900" // fifteen minutes is 900 seconds
 	PreferenceEmailIntervalImmediately       = "immediately"
@@ -158,6 +171,12 @@ func (o *Preference) IsValid() *AppError {
 		}
 	}
 
+	if o.Category == PreferenceCategoryCalendar && o.Name == PreferenceNameCalendarFeed {
+		if o.Value != PreferenceCalendarFeedFile && !IsValidHTTPURL(o.Value) {
+			return NewAppError("Preference.IsValid", "model.preference.is_valid.calendar_feed.app_error", nil, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
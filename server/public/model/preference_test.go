@@ -98,6 +98,22 @@ func TestPreferenceIsValid(t *testing.T) {
 		preference.Value = "-10"
 		require.NotNil(t, preference.IsValid())
 	})
+
+	t.Run("calendar feed is a URL or an uploaded file", func(t *testing.T) {
+		preference.Category = PreferenceCategoryCalendar
+		preference.Name = PreferenceNameCalendarFeed
+		preference.Value = "https://calendar.example.com/feed.ics"
+		require.Nil(t, preference.IsValid())
+
+		preference.Value = PreferenceCalendarFeedFile
+		require.Nil(t, preference.IsValid())
+
+		preference.Value = "file:///etc/passwd"
+		require.NotNil(t, preference.IsValid())
+
+		preference.Value = "not a url"
+		require.NotNil(t, preference.IsValid())
+	})
 }
 
 func TestPreferencePreUpdate(t *testing.T) {
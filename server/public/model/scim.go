@@ -0,0 +1,781 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	ScimSchemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	ScimSchemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	ScimSchemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	ScimSchemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	ScimSchemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	ScimSchemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	ScimSchemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+
+	ScimContentType = "application/scim+json"
+
+	ScimResourceTypeUser  = "User"
+	ScimResourceTypeGroup = "Group"
+
+	ScimDefaultCount = 100
+	ScimMaxCount     = 200
+
+	ScimPatchOpAdd     = "add"
+	ScimPatchOpReplace = "replace"
+	ScimPatchOpRemove  = "remove"
+
+	// The scimType of errors, telling the client what was wrong with its request.
+	ScimErrorTypeInvalidFilter = "invalidFilter"
+	ScimErrorTypeInvalidValue  = "invalidValue"
+	ScimErrorTypeInvalidPath   = "invalidPath"
+	ScimErrorTypeInvalidSyntax = "invalidSyntax"
+	ScimErrorTypeUniqueness    = "uniqueness"
+
+	// UserPropsKeyScimExternalId holds the id of a user provisioned through SCIM in the
+	// identity provider.
+	UserPropsKeyScimExternalId = "scim_external_id"
+)
+
+type ScimMeta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+}
+
+type ScimName struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// ScimMultiValue is a value of a multi-valued attribute, such as an email of a user.
+type ScimMultiValue struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+// ScimReference references a resource, such as a member of a group.
+type ScimReference struct {
+	Value   string `json:"value"`
+	Ref     string `json:"$ref,omitempty"`
+	Display string `json:"display,omitempty"`
+}
+
+type ScimUser struct {
+	Schemas     []string         `json:"schemas"`
+	Id          string           `json:"id,omitempty"`
+	ExternalId  string           `json:"externalId,omitempty"`
+	UserName    string           `json:"userName"`
+	Name        *ScimName        `json:"name,omitempty"`
+	DisplayName string           `json:"displayName,omitempty"`
+	NickName    string           `json:"nickName,omitempty"`
+	Locale      string           `json:"locale,omitempty"`
+	Emails      []ScimMultiValue `json:"emails,omitempty"`
+	Active      *bool            `json:"active,omitempty"`
+	// Password is only ever received, to set the password of users logging in with one.
+	Password string `json:"password,omitempty"`
+	// Groups is read only, the members of groups are changed through the groups.
+	Groups []ScimReference `json:"groups,omitempty"`
+	Meta   *ScimMeta       `json:"meta,omitempty"`
+}
+
+type ScimGroup struct {
+	Schemas     []string        `json:"schemas"`
+	Id          string          `json:"id,omitempty"`
+	ExternalId  string          `json:"externalId,omitempty"`
+	DisplayName string          `json:"displayName"`
+	Members     []ScimReference `json:"members,omitempty"`
+	Meta        *ScimMeta       `json:"meta,omitempty"`
+}
+
+type ScimListResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    any      `json:"Resources"`
+}
+
+type ScimError struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func NewScimError(status int, scimType, detail string) *ScimError {
+	return &ScimError{
+		Schemas:  []string{ScimSchemaError},
+		Status:   strconv.Itoa(status),
+		ScimType: scimType,
+		Detail:   detail,
+	}
+}
+
+type ScimPatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+type ScimPatchRequest struct {
+	Schemas    []string             `json:"schemas"`
+	Operations []ScimPatchOperation `json:"Operations"`
+}
+
+type ScimSupported struct {
+	Supported bool `json:"supported"`
+}
+
+type ScimAuthenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type ScimServiceProviderConfig struct {
+	Schemas []string      `json:"schemas"`
+	Patch   ScimSupported `json:"patch"`
+	Bulk    struct {
+		Supported      bool `json:"supported"`
+		MaxOperations  int  `json:"maxOperations"`
+		MaxPayloadSize int  `json:"maxPayloadSize"`
+	} `json:"bulk"`
+	Filter struct {
+		Supported  bool `json:"supported"`
+		MaxResults int  `json:"maxResults"`
+	} `json:"filter"`
+	ChangePassword        ScimSupported              `json:"changePassword"`
+	Sort                  ScimSupported              `json:"sort"`
+	Etag                  ScimSupported              `json:"etag"`
+	AuthenticationSchemes []ScimAuthenticationScheme `json:"authenticationSchemes"`
+	Meta                  *ScimMeta                  `json:"meta"`
+}
+
+// NewScimServiceProviderConfig returns the features of the SCIM service served at the given
+// base URL.
+func NewScimServiceProviderConfig(baseURL string) *ScimServiceProviderConfig {
+	config := &ScimServiceProviderConfig{
+		Schemas:        []string{ScimSchemaServiceProviderConfig},
+		Patch:          ScimSupported{Supported: true},
+		ChangePassword: ScimSupported{Supported: true},
+		AuthenticationSchemes: []ScimAuthenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "OAuth Bearer Token",
+			Description: "A personal access token of a system admin.",
+			Primary:     true,
+		}},
+		Meta: &ScimMeta{ResourceType: "ServiceProviderConfig", Location: baseURL + "/ServiceProviderConfig"},
+	}
+	config.Filter.Supported = true
+	config.Filter.MaxResults = ScimMaxCount
+
+	return config
+}
+
+type ScimResourceType struct {
+	Schemas  []string  `json:"schemas"`
+	Id       string    `json:"id"`
+	Name     string    `json:"name"`
+	Endpoint string    `json:"endpoint"`
+	Schema   string    `json:"schema"`
+	Meta     *ScimMeta `json:"meta"`
+}
+
+// NewScimResourceTypes returns the types of the resources of the SCIM service served at the
+// given base URL.
+func NewScimResourceTypes(baseURL string) []*ScimResourceType {
+	newResourceType := func(name, endpoint, schema string) *ScimResourceType {
+		return &ScimResourceType{
+			Schemas:  []string{ScimSchemaResourceType},
+			Id:       name,
+			Name:     name,
+			Endpoint: endpoint,
+			Schema:   schema,
+			Meta:     &ScimMeta{ResourceType: "ResourceType", Location: baseURL + "/ResourceTypes/" + name},
+		}
+	}
+
+	return []*ScimResourceType{
+		newResourceType(ScimResourceTypeUser, "/Users", ScimSchemaUser),
+		newResourceType(ScimResourceTypeGroup, "/Groups", ScimSchemaGroup),
+	}
+}
+
+func newScimMeta(resourceType string, createAt, updateAt int64, location string) *ScimMeta {
+	return &ScimMeta{
+		ResourceType: resourceType,
+		Created:      time.UnixMilli(createAt).UTC().Format(time.RFC3339),
+		LastModified: time.UnixMilli(updateAt).UTC().Format(time.RFC3339),
+		Location:     location,
+	}
+}
+
+// NewScimUser returns the SCIM representation of the user, a member of the given groups.
+func NewScimUser(user *User, groups []*Group, baseURL string) *ScimUser {
+	scimUser := &ScimUser{
+		Schemas:    []string{ScimSchemaUser},
+		Id:         user.Id,
+		ExternalId: user.Props[UserPropsKeyScimExternalId],
+		UserName:   user.Username,
+		Name: &ScimName{
+			Formatted:  strings.TrimSpace(user.FirstName + " " + user.LastName),
+			GivenName:  user.FirstName,
+			FamilyName: user.LastName,
+		},
+		DisplayName: user.GetDisplayName(ShowFullName),
+		NickName:    user.Nickname,
+		Locale:      user.Locale,
+		Emails:      []ScimMultiValue{{Value: user.Email, Type: "work", Primary: true}},
+		Active:      NewPointer(user.DeleteAt == 0),
+		Meta:        newScimMeta(ScimResourceTypeUser, user.CreateAt, user.UpdateAt, baseURL+"/Users/"+user.Id),
+	}
+
+	for _, group := range groups {
+		scimUser.Groups = append(scimUser.Groups, ScimReference{
+			Value:   group.Id,
+			Ref:     baseURL + "/Groups/" + group.Id,
+			Display: group.DisplayName,
+		})
+	}
+
+	return scimUser
+}
+
+func (u *ScimUser) IsValid() *AppError {
+	if u.UserName == "" {
+		return NewAppError("ScimUser.IsValid", "model.scim_user.is_valid.user_name.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if u.PrimaryEmail() == "" {
+		return NewAppError("ScimUser.IsValid", "model.scim_user.is_valid.email.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if len(u.ExternalId) > UserAuthDataMaxLength {
+		return NewAppError("ScimUser.IsValid", "model.scim_user.is_valid.external_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+// PrimaryEmail returns the email marked as primary, or else the first one.
+func (u *ScimUser) PrimaryEmail() string {
+	for _, email := range u.Emails {
+		if email.Primary {
+			return email.Value
+		}
+	}
+
+	if len(u.Emails) > 0 {
+		return u.Emails[0].Value
+	}
+
+	return ""
+}
+
+// ApplyTo sets the fields of the user from the SCIM user. The fields of the user the SCIM
+// user doesn't have are cleared, as the SCIM user replaces it.
+func (u *ScimUser) ApplyTo(user *User) {
+	user.Username = strings.ToLower(u.UserName)
+	user.Email = strings.ToLower(strings.TrimSpace(u.PrimaryEmail()))
+	user.Nickname = u.NickName
+
+	switch {
+	case u.Name != nil && (u.Name.GivenName != "" || u.Name.FamilyName != ""):
+		user.FirstName = u.Name.GivenName
+		user.LastName = u.Name.FamilyName
+	case u.DisplayName != "":
+		user.FirstName, user.LastName, _ = strings.Cut(strings.TrimSpace(u.DisplayName), " ")
+	default:
+		user.FirstName = ""
+		user.LastName = ""
+	}
+
+	// Identity providers send locales with a region, such as en_US, which are only kept when
+	// short enough to be valid, such as pt-BR.
+	if locale := strings.ReplaceAll(u.Locale, "_", "-"); locale != "" {
+		if !IsValidLocale(locale) {
+			locale, _, _ = strings.Cut(locale, "-")
+		}
+		if IsValidLocale(locale) {
+			user.Locale = locale
+		}
+	}
+
+	if u.ExternalId != "" {
+		user.SetProp(UserPropsKeyScimExternalId, u.ExternalId)
+	} else {
+		delete(user.Props, UserPropsKeyScimExternalId)
+	}
+}
+
+// NewScimGroup returns the SCIM representation of the group with the given members.
+func NewScimGroup(group *Group, members []*User, baseURL string) *ScimGroup {
+	scimGroup := &ScimGroup{
+		Schemas:     []string{ScimSchemaGroup},
+		Id:          group.Id,
+		ExternalId:  group.GetRemoteId(),
+		DisplayName: group.DisplayName,
+		Meta:        newScimMeta(ScimResourceTypeGroup, group.CreateAt, group.UpdateAt, baseURL+"/Groups/"+group.Id),
+	}
+
+	for _, member := range members {
+		scimGroup.Members = append(scimGroup.Members, ScimReference{
+			Value:   member.Id,
+			Ref:     baseURL + "/Users/" + member.Id,
+			Display: member.Username,
+		})
+	}
+
+	return scimGroup
+}
+
+func (g *ScimGroup) IsValid() *AppError {
+	if l := len(g.DisplayName); l == 0 || l > GroupDisplayNameMaxLength {
+		return NewAppError("ScimGroup.IsValid", "model.group.display_name.app_error", map[string]any{"GroupDisplayNameMaxLength": GroupDisplayNameMaxLength}, "", http.StatusBadRequest)
+	}
+
+	if len(g.ExternalId) > GroupRemoteIDMaxLength {
+		return NewAppError("ScimGroup.IsValid", "model.group.remote_id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, member := range g.Members {
+		if !IsValidId(member.Value) {
+			return NewAppError("ScimGroup.IsValid", "model.scim_group.is_valid.member.app_error", map[string]any{"Member": member.Value}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// MemberIds returns the ids of the members of the group, without duplicates.
+func (g *ScimGroup) MemberIds() []string {
+	seen := make(map[string]bool, len(g.Members))
+	ids := make([]string, 0, len(g.Members))
+	for _, member := range g.Members {
+		if !seen[member.Value] {
+			seen[member.Value] = true
+			ids = append(ids, member.Value)
+		}
+	}
+	return ids
+}
+
+func (r *ScimPatchRequest) IsValid() *AppError {
+	if len(r.Operations) == 0 {
+		return NewAppError("ScimPatchRequest.IsValid", "model.scim_patch.is_valid.operations.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	for _, op := range r.Operations {
+		switch strings.ToLower(op.Op) {
+		case ScimPatchOpAdd, ScimPatchOpReplace:
+			if len(op.Value) == 0 {
+				return NewAppError("ScimPatchRequest.IsValid", "model.scim_patch.is_valid.value.app_error", map[string]any{"Op": op.Op}, "", http.StatusBadRequest)
+			}
+		case ScimPatchOpRemove:
+			if op.Path == "" {
+				return NewAppError("ScimPatchRequest.IsValid", "model.scim_patch.is_valid.path.app_error", nil, "", http.StatusBadRequest)
+			}
+		default:
+			return NewAppError("ScimPatchRequest.IsValid", "model.scim_patch.is_valid.op.app_error", map[string]any{"Op": op.Op}, "", http.StatusBadRequest)
+		}
+	}
+
+	return nil
+}
+
+// ScimFilterExpression compares an attribute with a value. Attributes of complex attributes
+// are named by their path, e.g. emails.value or members.value.
+type ScimFilterExpression struct {
+	Attribute string
+	Operator  string
+	Value     string
+}
+
+// ScimFilter is a filter of resources made of expressions which must all match.
+type ScimFilter []ScimFilterExpression
+
+// ParseScimFilter parses the subset of the SCIM filter syntax identity providers use to look
+// resources up: comparisons with the eq, ne, co, sw, ew and pr operators joined with "and",
+// including comparisons of a value of a multi-valued attribute, such as members[value eq "id"].
+func ParseScimFilter(filter string) (ScimFilter, error) {
+	tokens, err := tokenizeScimFilter(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	var parsed ScimFilter
+	for len(tokens) > 0 {
+		if len(parsed) > 0 {
+			if !strings.EqualFold(tokens[0], "and") {
+				return nil, fmt.Errorf("unsupported logical operator %q", tokens[0])
+			}
+			tokens = tokens[1:]
+		}
+
+		if len(tokens) < 2 {
+			return nil, errors.New("incomplete filter expression")
+		}
+
+		attribute := tokens[0]
+		operator := strings.ToLower(tokens[1])
+		tokens = tokens[2:]
+
+		// A comparison of a value of a multi-valued attribute, e.g. members[value eq "id"],
+		// compares the sub-attribute of its values.
+		name, subAttribute, inValueFilter := strings.Cut(attribute, "[")
+		if inValueFilter {
+			attribute = name + "." + subAttribute
+		}
+
+		expression := ScimFilterExpression{Attribute: attribute, Operator: operator}
+		switch operator {
+		case "pr":
+			if inValueFilter {
+				return nil, fmt.Errorf("unsupported value filter in %q", filter)
+			}
+		case "eq", "ne", "co", "sw", "ew":
+			if len(tokens) == 0 {
+				return nil, errors.New("missing value of filter expression")
+			}
+			token := tokens[0]
+			if inValueFilter {
+				if !strings.HasSuffix(token, "]") {
+					return nil, fmt.Errorf("unsupported value filter in %q", filter)
+				}
+				token = strings.TrimSuffix(token, "]")
+			}
+			value, err := parseScimFilterValue(token)
+			if err != nil {
+				return nil, err
+			}
+			expression.Value = value
+			tokens = tokens[1:]
+		default:
+			return nil, fmt.Errorf("unsupported operator %q", operator)
+		}
+
+		parsed = append(parsed, expression)
+	}
+
+	if len(parsed) == 0 {
+		return nil, errors.New("empty filter")
+	}
+
+	return parsed, nil
+}
+
+// tokenizeScimFilter splits a filter on spaces outside of quoted strings.
+func tokenizeScimFilter(filter string) ([]string, error) {
+	var tokens []string
+	var token strings.Builder
+	inString := false
+	escaped := false
+
+	for _, r := range filter {
+		switch {
+		case escaped:
+			escaped = false
+		case inString && r == '\\':
+			escaped = true
+		case r == '"':
+			inString = !inString
+		case !inString && r == ' ':
+			if token.Len() > 0 {
+				tokens = append(tokens, token.String())
+				token.Reset()
+			}
+			continue
+		}
+		token.WriteRune(r)
+	}
+
+	if inString {
+		return nil, errors.New("unterminated string in filter")
+	}
+	if token.Len() > 0 {
+		tokens = append(tokens, token.String())
+	}
+
+	return tokens, nil
+}
+
+func parseScimFilterValue(token string) (string, error) {
+	if strings.HasPrefix(token, `"`) {
+		var value string
+		if err := json.Unmarshal([]byte(token), &value); err != nil {
+			return "", fmt.Errorf("invalid string %s in filter", token)
+		}
+		return value, nil
+	}
+
+	switch strings.ToLower(token) {
+	case "true", "false", "null":
+		return strings.ToLower(token), nil
+	}
+
+	if _, err := strconv.ParseFloat(token, 64); err != nil {
+		return "", fmt.Errorf("invalid value %s in filter", token)
+	}
+	return token, nil
+}
+
+// Lookup returns the value an attribute must be equal to for the filter to match.
+func (f ScimFilter) Lookup(attribute string) (string, bool) {
+	for _, expression := range f {
+		if expression.Operator == "eq" && strings.EqualFold(expression.Attribute, attribute) {
+			return expression.Value, true
+		}
+	}
+	return "", false
+}
+
+// Matches returns whether the resource whose attributes have the given values matches the
+// filter. Values are compared case insensitively.
+func (f ScimFilter) Matches(values func(attribute string) []string) bool {
+	for _, expression := range f {
+		if !expression.matches(values(strings.ToLower(expression.Attribute))) {
+			return false
+		}
+	}
+	return true
+}
+
+func (e ScimFilterExpression) matches(values []string) bool {
+	if e.Operator == "pr" {
+		for _, value := range values {
+			if value != "" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if e.Operator == "ne" {
+		for _, value := range values {
+			if strings.EqualFold(value, e.Value) {
+				return false
+			}
+		}
+		return true
+	}
+
+	expected := strings.ToLower(e.Value)
+	for _, value := range values {
+		value = strings.ToLower(value)
+		switch e.Operator {
+		case "eq":
+			if value == expected {
+				return true
+			}
+		case "co":
+			if strings.Contains(value, expected) {
+				return true
+			}
+		case "sw":
+			if strings.HasPrefix(value, expected) {
+				return true
+			}
+		case "ew":
+			if strings.HasSuffix(value, expected) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ApplyScimPatch applies the operation to the JSON representation of a resource, decoded as
+// a map. Paths name an attribute, a sub-attribute such as name.givenName, or the values of a
+// multi-valued attribute matching a filter such as emails[type eq "work"].value.
+func ApplyScimPatch(resource map[string]any, operation ScimPatchOperation, schema string) error {
+	op := strings.ToLower(operation.Op)
+
+	var value any
+	if len(operation.Value) > 0 {
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return fmt.Errorf("invalid value: %w", err)
+		}
+	}
+
+	if operation.Path == "" {
+		// Without a path, the value holds the attributes to set.
+		attributes, ok := value.(map[string]any)
+		if !ok {
+			return errors.New("the value of an operation without a path must be an object")
+		}
+		for name, attributeValue := range attributes {
+			if err := applyScimPatchPath(resource, op, name, attributeValue, schema); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	return applyScimPatchPath(resource, op, operation.Path, value, schema)
+}
+
+func applyScimPatchPath(resource map[string]any, op, path string, value any, schema string) error {
+	// Attributes can be prefixed with the urn of their schema.
+	if len(path) > len(schema) && strings.EqualFold(path[:len(schema)+1], schema+":") {
+		path = path[len(schema)+1:]
+	}
+
+	var valueFilter ScimFilter
+	attribute, subAttribute := path, ""
+	if name, rest, ok := strings.Cut(path, "["); ok {
+		filter, after, ok := strings.Cut(rest, "]")
+		if !ok {
+			return fmt.Errorf("invalid path %q", path)
+		}
+		parsed, err := ParseScimFilter(filter)
+		if err != nil {
+			return fmt.Errorf("invalid path %q: %w", path, err)
+		}
+		valueFilter = parsed
+		attribute = name
+		subAttribute = strings.TrimPrefix(after, ".")
+	} else if name, sub, ok := strings.Cut(path, "."); ok {
+		attribute, subAttribute = name, sub
+	}
+
+	key := findScimAttribute(resource, attribute)
+	value = normalizeScimValue(attribute, value)
+
+	if valueFilter != nil {
+		values, _ := resource[key].([]any)
+		matched := false
+		kept := values[:0:0]
+		for _, item := range values {
+			object, ok := item.(map[string]any)
+			if !ok || !valueFilter.Matches(scimObjectValues(object)) {
+				kept = append(kept, item)
+				continue
+			}
+			matched = true
+
+			switch {
+			case op == ScimPatchOpRemove && subAttribute == "":
+				continue
+			case op == ScimPatchOpRemove:
+				delete(object, findScimAttribute(object, subAttribute))
+			case subAttribute == "":
+				replacement, ok := value.(map[string]any)
+				if !ok {
+					return fmt.Errorf("the value of %q must be an object", path)
+				}
+				item = replacement
+			default:
+				object[findScimAttribute(object, subAttribute)] = value
+			}
+			kept = append(kept, item)
+		}
+
+		// Setting a sub-attribute of a value that doesn't exist yet adds the value, e.g.
+		// emails[type eq "work"].value adds a work email.
+		if !matched && op != ScimPatchOpRemove && subAttribute != "" && len(valueFilter) == 1 && valueFilter[0].Operator == "eq" {
+			kept = append(kept, map[string]any{valueFilter[0].Attribute: valueFilter[0].Value, subAttribute: value})
+		}
+		resource[key] = kept
+		return nil
+	}
+
+	if subAttribute != "" {
+		object, ok := resource[key].(map[string]any)
+		if !ok {
+			if op == ScimPatchOpRemove {
+				return nil
+			}
+			object = map[string]any{}
+			resource[key] = object
+		}
+		if op == ScimPatchOpRemove {
+			delete(object, findScimAttribute(object, subAttribute))
+		} else {
+			object[findScimAttribute(object, subAttribute)] = value
+		}
+		return nil
+	}
+
+	if op == ScimPatchOpRemove {
+		delete(resource, key)
+		return nil
+	}
+
+	switch existing := resource[key].(type) {
+	case []any:
+		// Values are added to multi-valued attributes.
+		if added, ok := value.([]any); ok && op == ScimPatchOpAdd {
+			resource[key] = append(existing, added...)
+			return nil
+		}
+	case map[string]any:
+		// The sub-attributes of complex attributes left out of the value are left unchanged.
+		if object, ok := value.(map[string]any); ok {
+			for name, subValue := range object {
+				existing[findScimAttribute(existing, name)] = subValue
+			}
+			return nil
+		}
+	}
+	resource[key] = value
+
+	return nil
+}
+
+// findScimAttribute returns the key of the attribute, whose name is case insensitive, or the
+// name itself if the attribute isn't set.
+func findScimAttribute(object map[string]any, name string) string {
+	if _, ok := object[name]; ok {
+		return name
+	}
+	for key := range object {
+		if strings.EqualFold(key, name) {
+			return key
+		}
+	}
+	return name
+}
+
+// normalizeScimValue converts the booleans some identity providers send as strings.
+func normalizeScimValue(attribute string, value any) any {
+	if s, ok := value.(string); ok && (strings.EqualFold(attribute, "active") || strings.EqualFold(attribute, "primary")) {
+		if b, err := strconv.ParseBool(strings.ToLower(s)); err == nil {
+			return b
+		}
+	}
+	if object, ok := value.(map[string]any); ok {
+		for key, v := range object {
+			object[key] = normalizeScimValue(key, v)
+		}
+	}
+	return value
+}
+
+func scimObjectValues(object map[string]any) func(attribute string) []string {
+	return func(attribute string) []string {
+		switch v := object[findScimAttribute(object, attribute)].(type) {
+		case string:
+			return []string{v}
+		case bool:
+			return []string{strconv.FormatBool(v)}
+		case float64:
+			return []string{strconv.FormatFloat(v, 'f', -1, 64)}
+		}
+		return nil
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseScimFilter(t *testing.T) {
+	t.Run("equality", func(t *testing.T) {
+		filter, err := ParseScimFilter(`userName eq "Jane.Doe"`)
+		require.NoError(t, err)
+		assert.Equal(t, ScimFilter{{Attribute: "userName", Operator: "eq", Value: "Jane.Doe"}}, filter)
+
+		value, ok := filter.Lookup("username")
+		assert.True(t, ok)
+		assert.Equal(t, "Jane.Doe", value)
+
+		_, ok = filter.Lookup("externalId")
+		assert.False(t, ok)
+	})
+
+	t.Run("conjunction", func(t *testing.T) {
+		filter, err := ParseScimFilter(`externalId eq "42" and active eq true and displayName pr`)
+		require.NoError(t, err)
+		assert.Equal(t, ScimFilter{
+			{Attribute: "externalId", Operator: "eq", Value: "42"},
+			{Attribute: "active", Operator: "eq", Value: "true"},
+			{Attribute: "displayName", Operator: "pr"},
+		}, filter)
+	})
+
+	t.Run("value filter", func(t *testing.T) {
+		filter, err := ParseScimFilter(`members[value eq "abc"]`)
+		require.NoError(t, err)
+		assert.Equal(t, ScimFilter{{Attribute: "members.value", Operator: "eq", Value: "abc"}}, filter)
+	})
+
+	t.Run("escaped quotes", func(t *testing.T) {
+		filter, err := ParseScimFilter(`displayName eq "The \"A\" team"`)
+		require.NoError(t, err)
+		assert.Equal(t, `The "A" team`, filter[0].Value)
+	})
+
+	for name, filter := range map[string]string{
+		"empty":                "",
+		"unsupported operator": `userName gt "a"`,
+		"disjunction":          `userName eq "a" or userName eq "b"`,
+		"missing value":        `userName eq`,
+		"unterminated string":  `userName eq "a`,
+		"unterminated filter":  `members[value eq "a"`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := ParseScimFilter(filter)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestScimFilterMatches(t *testing.T) {
+	values := func(attribute string) []string {
+		switch attribute {
+		case "username":
+			return []string{"jane.doe"}
+		case "emails.value":
+			return []string{"jane@example.com", "jane.doe@example.org"}
+		}
+		return nil
+	}
+
+	for filter, expected := range map[string]bool{
+		`userName eq "Jane.Doe"`:                     true,
+		`userName ne "jane.doe"`:                     false,
+		`userName sw "jane"`:                         true,
+		`userName ew "doe"`:                          true,
+		`userName co "e.d"`:                          true,
+		`emails[value eq "jane.doe@example.org"]`:    true,
+		`emails.value ew "example.net"`:              false,
+		`userName pr and emails pr`:                  false,
+		`userName eq "jane.doe" and nickName pr`:     false,
+		`userName eq "jane.doe" and emails.value pr`: true,
+	} {
+		t.Run(filter, func(t *testing.T) {
+			parsed, err := ParseScimFilter(filter)
+			require.NoError(t, err)
+			assert.Equal(t, expected, parsed.Matches(values))
+		})
+	}
+}
+
+func TestScimUser(t *testing.T) {
+	user := &User{
+		Id:        NewId(),
+		Username:  "jane.doe",
+		Email:     "jane@example.com",
+		FirstName: "Jane",
+		LastName:  "Doe",
+		Locale:    "en",
+		Props:     StringMap{UserPropsKeyScimExternalId: "42"},
+		CreateAt:  1,
+		UpdateAt:  2,
+	}
+	group := &Group{Id: NewId(), DisplayName: "Engineering"}
+
+	scimUser := NewScimUser(user, []*Group{group}, "https://example.com/scim/v2")
+	assert.Equal(t, "42", scimUser.ExternalId)
+	assert.Equal(t, "jane.doe", scimUser.UserName)
+	assert.Equal(t, "jane@example.com", scimUser.PrimaryEmail())
+	assert.True(t, *scimUser.Active)
+	assert.Equal(t, "https://example.com/scim/v2/Users/"+user.Id, scimUser.Meta.Location)
+	require.Len(t, scimUser.Groups, 1)
+	assert.Equal(t, group.Id, scimUser.Groups[0].Value)
+	require.Nil(t, scimUser.IsValid())
+
+	t.Run("apply", func(t *testing.T) {
+		updated := &User{}
+		(&ScimUser{
+			UserName:    "John.Doe",
+			DisplayName: "John Doe",
+			Locale:      "fr_FR",
+			Emails:      []ScimMultiValue{{Value: "home@example.com"}, {Value: "John@Example.com", Primary: true}},
+			ExternalId:  "43",
+		}).ApplyTo(updated)
+
+		assert.Equal(t, "john.doe", updated.Username)
+		assert.Equal(t, "john@example.com", updated.Email)
+		assert.Equal(t, "John", updated.FirstName)
+		assert.Equal(t, "Doe", updated.LastName)
+		assert.Equal(t, "fr-FR", updated.Locale)
+		assert.Equal(t, "43", updated.Props[UserPropsKeyScimExternalId])
+
+		(&ScimUser{UserName: "john.doe", Emails: []ScimMultiValue{{Value: "john@example.com"}}}).ApplyTo(updated)
+		assert.Empty(t, updated.FirstName)
+		assert.NotContains(t, updated.Props, UserPropsKeyScimExternalId)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.NotNil(t, (&ScimUser{Emails: []ScimMultiValue{{Value: "a@example.com"}}}).IsValid())
+		assert.NotNil(t, (&ScimUser{UserName: "a"}).IsValid())
+		assert.NotNil(t, (&ScimUser{UserName: "a", Emails: []ScimMultiValue{{Value: "a@example.com"}}, ExternalId: strings.Repeat("a", UserAuthDataMaxLength+1)}).IsValid())
+	})
+}
+
+func TestScimGroup(t *testing.T) {
+	memberID := NewId()
+	scimGroup := &ScimGroup{
+		DisplayName: "Engineering",
+		Members:     []ScimReference{{Value: memberID}, {Value: memberID}},
+	}
+	require.Nil(t, scimGroup.IsValid())
+	assert.Equal(t, []string{memberID}, scimGroup.MemberIds())
+
+	scimGroup.Members = append(scimGroup.Members, ScimReference{Value: "invalid"})
+	assert.NotNil(t, scimGroup.IsValid())
+
+	assert.NotNil(t, (&ScimGroup{}).IsValid())
+}
+
+func TestScimPatchRequestIsValid(t *testing.T) {
+	for name, tc := range map[string]struct {
+		operations []ScimPatchOperation
+		valid      bool
+	}{
+		"no operations":         {nil, false},
+		"replace":               {[]ScimPatchOperation{{Op: "Replace", Path: "active", Value: json.RawMessage(`false`)}}, true},
+		"replace without value": {[]ScimPatchOperation{{Op: "replace", Path: "active"}}, false},
+		"remove":                {[]ScimPatchOperation{{Op: "remove", Path: "members"}}, true},
+		"remove without path":   {[]ScimPatchOperation{{Op: "remove"}}, false},
+		"unknown operation":     {[]ScimPatchOperation{{Op: "move", Path: "a", Value: json.RawMessage(`1`)}}, false},
+	} {
+		t.Run(name, func(t *testing.T) {
+			appErr := (&ScimPatchRequest{Operations: tc.operations}).IsValid()
+			if tc.valid {
+				assert.Nil(t, appErr)
+			} else {
+				assert.NotNil(t, appErr)
+			}
+		})
+	}
+}
+
+func TestApplyScimPatch(t *testing.T) {
+	newResource := func() map[string]any {
+		return map[string]any{
+			"userName": "jane.doe",
+			"active":   true,
+			"name":     map[string]any{"givenName": "Jane", "familyName": "Doe"},
+			"emails": []any{
+				map[string]any{"value": "jane@example.com", "type": "work", "primary": true},
+			},
+			"members": []any{
+				map[string]any{"value": "a"},
+				map[string]any{"value": "b"},
+			},
+		}
+	}
+
+	apply := func(t *testing.T, resource map[string]any, op, path, value string) error {
+		t.Helper()
+		operation := ScimPatchOperation{Op: op, Path: path}
+		if value != "" {
+			operation.Value = json.RawMessage(value)
+		}
+		return ApplyScimPatch(resource, operation, ScimSchemaUser)
+	}
+
+	t.Run("replace an attribute", func(t *testing.T) {
+		resource := newResource()
+		require.NoError(t, apply(t, resource, "Replace", "active", `"False"`))
+		assert.Equal(t, false, resource["active"])
+	})
+
+	t.Run("replace without a path", func(t *testing.T) {
+		resource := newResource()
+		require.NoError(t, apply(t, resource, "replace", "", `{"Active": false, "name": {"givenName": "Janet"}}`))
+		assert.Equal(t, false, resource["active"])
+		assert.Equal(t, map[string]any{"givenName": "Janet", "familyName": "Doe"}, resource["name"])
+	})
+
+	t.Run("replace a sub-attribute", func(t *testing.T) {
+		resource := newResource()
+		require.NoError(t, apply(t, resource, "replace", "name.familyName", `"Smith"`))
+		assert.Equal(t, "Smith", resource["name"].(map[string]any)["familyName"])
+	})
+
+	t.Run("path with the schema", func(t *testing.T) {
+		resource := newResource()
+		require.NoError(t, apply(t, resource, "replace", ScimSchemaUser+":userName", `"john.doe"`))
+		assert.Equal(t, "john.doe", resource["userName"])
+	})
+
+	t.Run("replace a filtered value", func(t *testing.T) {
+		resource := newResource()
+		require.NoError(t, apply(t, resource, "replace", `emails[type eq "work"].value`, `"jane@example.org"`))
+		emails := resource["emails"].([]any)
+		require.Len(t, emails, 1)
+		assert.Equal(t, "jane@example.org", emails[0].(map[string]any)["value"])
+	})
+
+	t.Run("add members", func(t *testing.T) {
+		resource := newResource()
+		require.NoError(t, apply(t, resource, "add", "members", `[{"value": "c"}]`))
+		assert.Len(t, resource["members"], 3)
+	})
+
+	t.Run("remove a member", func(t *testing.T) {
+		resource := newResource()
+		require.NoError(t, apply(t, resource, "remove", `members[value eq "a"]`, ""))
+		assert.Equal(t, []any{map[string]any{"value": "b"}}, resource["members"])
+	})
+
+	t.Run("remove an attribute", func(t *testing.T) {
+		resource := newResource()
+		require.NoError(t, apply(t, resource, "remove", "members", ""))
+		assert.NotContains(t, resource, "members")
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		assert.Error(t, apply(t, newResource(), "replace", "", `"not an object"`))
+		assert.Error(t, apply(t, newResource(), "replace", `members[value gt "a"]`, `"b"`))
+	})
+}
@@ -33,6 +33,7 @@ const (
 	SessionTypeCloudKey                   = "CloudKey"
 	SessionTypeRemoteclusterToken         = "RemoteClusterToken"
 	SessionPropIsGuest                    = "is_guest"
+	SessionPropUserAccessTokenScopes      = "user_access_token_scopes"
 	SessionActivityTimeout                = 1000 * 60 * 5  // 5 minutes
 	SessionUserAccessTokenExpiryHours     = 100 * 365 * 24 // 100 years
 )
@@ -244,6 +245,30 @@ func (s *Session) IsUserAccessToken() bool {
 	return false
 }
 
+// GetUserAccessTokenScopes returns the scopes restricting the session of a user access token or
+// of an OAuth app, which are empty for any other session.
+func (s *Session) GetUserAccessTokenScopes() UserAccessTokenScopes {
+	return ParseUserAccessTokenScopes(strings.Fields(s.Props[SessionPropUserAccessTokenScopes]))
+}
+
+// CanActAsUser returns true if the session belongs to the user and isn't the session of a user
+// access token restricted by scopes, which don't cover the account of the user.
+func (s *Session) CanActAsUser(userID string) bool {
+	return s.UserId == userID && len(s.GetUserAccessTokenScopes()) == 0
+}
+
+// CanActAsUserInTeam returns true if the session can act as the user, or belongs to the user and
+// its scopes cover the team, such as for leaving it.
+func (s *Session) CanActAsUserInTeam(userID, teamID string) bool {
+	return s.CanActAsUser(userID) || (s.UserId == userID && s.GetUserAccessTokenScopes().AllowsTeam(teamID))
+}
+
+// CanActAsUserInChannel returns true if the session can act as the user, or belongs to the user
+// and its scopes cover the channel, such as for leaving it.
+func (s *Session) CanActAsUserInChannel(userID, channelID, teamID string) bool {
+	return s.CanActAsUser(userID) || (s.UserId == userID && s.GetUserAccessTokenScopes().AllowsChannel(channelID, teamID))
+}
+
 // Returns true when session is authenticated as a bot, by personal access token, or is an OAuth app.
 // Does not indicate other forms of integrations e.g. webhooks, slash commands, etc.
 func (s *Session) IsIntegration() bool {
@@ -0,0 +1,145 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"net/http"
+	"unicode/utf8"
+)
+
+const (
+	SessionActivityIpAddressMaxLength = 64
+	SessionActivityUserAgentMaxLength = 512
+	SessionActivityFieldMaxLength     = 64
+	SessionActivityRegionMaxLength    = 128
+	SessionActivityDeviceIdMaxLength  = 512
+
+	SessionAnomalyImpossibleTravel = "impossible_travel"
+)
+
+// SessionActivity records the use of a session from an IP address, from the first to the last
+// time it was seen.
+type SessionActivity struct {
+	Id             string  `json:"id"`
+	SessionId      string  `json:"session_id"`
+	UserId         string  `json:"user_id"`
+	IpAddress      string  `json:"ip_address"`
+	UserAgent      string  `json:"user_agent"`
+	Platform       string  `json:"platform"`
+	Os             string  `json:"os"`
+	Browser        string  `json:"browser"`
+	BrowserVersion string  `json:"browser_version"`
+	DeviceId       string  `json:"device_id,omitempty"`
+	Region         string  `json:"region,omitempty"`
+	Latitude       float64 `json:"latitude,omitempty"`
+	Longitude      float64 `json:"longitude,omitempty"`
+	// Anomaly is the rule the activity broke, such as impossible_travel, if any.
+	Anomaly     string `json:"anomaly,omitempty"`
+	FirstSeenAt int64  `json:"first_seen_at"`
+	LastSeenAt  int64  `json:"last_seen_at"`
+}
+
+type SessionActivityGetOptions struct {
+	UserId        string
+	AnomaliesOnly bool
+	Page          int
+	PerPage       int
+}
+
+func (a *SessionActivity) Auditable() map[string]any {
+	return map[string]any{
+		"id":            a.Id,
+		"session_id":    a.SessionId,
+		"user_id":       a.UserId,
+		"ip_address":    a.IpAddress,
+		"platform":      a.Platform,
+		"os":            a.Os,
+		"browser":       a.Browser,
+		"region":        a.Region,
+		"anomaly":       a.Anomaly,
+		"first_seen_at": a.FirstSeenAt,
+		"last_seen_at":  a.LastSeenAt,
+	}
+}
+
+// IsLocated returns whether the region of the IP address of the activity is known.
+func (a *SessionActivity) IsLocated() bool {
+	return a.Region != ""
+}
+
+// IsSameDevice returns whether both activities come from the same device, identified by the id
+// of mobile devices or else by the platform, operating system and browser.
+func (a *SessionActivity) IsSameDevice(other *SessionActivity) bool {
+	if a.DeviceId != "" || other.DeviceId != "" {
+		return a.DeviceId == other.DeviceId
+	}
+	return a.Platform == other.Platform && a.Os == other.Os && a.Browser == other.Browser
+}
+
+func (a *SessionActivity) PreSave() {
+	if a.Id == "" {
+		a.Id = NewId()
+	}
+
+	if a.FirstSeenAt == 0 {
+		a.FirstSeenAt = GetMillis()
+	}
+
+	if a.LastSeenAt == 0 {
+		a.LastSeenAt = a.FirstSeenAt
+	}
+
+	a.UserAgent = truncateString(a.UserAgent, SessionActivityUserAgentMaxLength)
+	a.Platform = truncateString(a.Platform, SessionActivityFieldMaxLength)
+	a.Os = truncateString(a.Os, SessionActivityFieldMaxLength)
+	a.Browser = truncateString(a.Browser, SessionActivityFieldMaxLength)
+	a.BrowserVersion = truncateString(a.BrowserVersion, SessionActivityFieldMaxLength)
+	a.Region = truncateString(a.Region, SessionActivityRegionMaxLength)
+}
+
+func (a *SessionActivity) IsValid() *AppError {
+	if !IsValidId(a.Id) {
+		return NewAppError("SessionActivity.IsValid", "model.session_activity.is_valid.id.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	if !IsValidId(a.SessionId) {
+		return NewAppError("SessionActivity.IsValid", "model.session_activity.is_valid.session_id.app_error", nil, "id="+a.Id, http.StatusBadRequest)
+	}
+
+	if !IsValidId(a.UserId) {
+		return NewAppError("SessionActivity.IsValid", "model.session_activity.is_valid.user_id.app_error", nil, "id="+a.Id, http.StatusBadRequest)
+	}
+
+	if a.IpAddress == "" || len(a.IpAddress) > SessionActivityIpAddressMaxLength {
+		return NewAppError("SessionActivity.IsValid", "model.session_activity.is_valid.ip_address.app_error", nil, "id="+a.Id, http.StatusBadRequest)
+	}
+
+	if len(a.DeviceId) > SessionActivityDeviceIdMaxLength {
+		return NewAppError("SessionActivity.IsValid", "model.session_activity.is_valid.device_id.app_error", nil, "id="+a.Id, http.StatusBadRequest)
+	}
+
+	if a.FirstSeenAt == 0 || a.LastSeenAt < a.FirstSeenAt {
+		return NewAppError("SessionActivity.IsValid", "model.session_activity.is_valid.seen_at.app_error", nil, "id="+a.Id, http.StatusBadRequest)
+	}
+
+	return nil
+}
+
+func truncateString(s string, maxLength int) string {
+	if len(s) <= maxLength {
+		return s
+	}
+
+	// Avoid cutting the last character in half.
+	s = s[:maxLength]
+	for i := len(s) - 1; i >= 0 && i >= len(s)-utf8.UTFMax; i-- {
+		if utf8.RuneStart(s[i]) {
+			if !utf8.FullRuneInString(s[i:]) {
+				s = s[:i]
+			}
+			break
+		}
+	}
+	return s
+}
@@ -0,0 +1,50 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package model
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionActivityIsValid(t *testing.T) {
+	activity := &SessionActivity{
+		SessionId: NewId(),
+		UserId:    NewId(),
+		IpAddress: "10.0.0.1",
+		UserAgent: strings.Repeat("é", SessionActivityUserAgentMaxLength),
+	}
+	activity.PreSave()
+	require.Nil(t, activity.IsValid())
+	assert.NotEmpty(t, activity.Id)
+	assert.Equal(t, activity.FirstSeenAt, activity.LastSeenAt)
+	assert.LessOrEqual(t, len(activity.UserAgent), SessionActivityUserAgentMaxLength)
+	assert.True(t, utf8.ValidString(activity.UserAgent))
+
+	invalid := *activity
+	invalid.SessionId = ""
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *activity
+	invalid.IpAddress = ""
+	assert.NotNil(t, invalid.IsValid())
+
+	invalid = *activity
+	invalid.LastSeenAt = invalid.FirstSeenAt - 1
+	assert.NotNil(t, invalid.IsValid())
+}
+
+func TestSessionActivityIsSameDevice(t *testing.T) {
+	desktop := &SessionActivity{Platform: "Linux", Os: "Linux", Browser: "Firefox"}
+	assert.True(t, desktop.IsSameDevice(&SessionActivity{Platform: "Linux", Os: "Linux", Browser: "Firefox"}))
+	assert.False(t, desktop.IsSameDevice(&SessionActivity{Platform: "Linux", Os: "Linux", Browser: "Chrome"}))
+
+	mobile := &SessionActivity{Platform: "iPhone", Os: "iOS", Browser: "Mattermost", DeviceId: "apple:1"}
+	assert.True(t, mobile.IsSameDevice(&SessionActivity{DeviceId: "apple:1"}))
+	assert.False(t, mobile.IsSameDevice(&SessionActivity{Platform: "iPhone", Os: "iOS", Browser: "Mattermost"}))
+}
@@ -14,6 +14,7 @@ const (
 	SystemActiveLicenseId                  = "ActiveLicenseId"
 	SystemLastComplianceTime               = "LastComplianceTime"
 	SystemAsymmetricSigningKeyKey          = "AsymmetricSigningKey"
+	SystemOIDCSigningKeysKey               = "OIDCSigningKeys"
 	SystemPostActionCookieSecretKey        = "PostActionCookieSecret"
 	SystemInstallationDateKey              = "InstallationDate"
 	SystemOrganizationName                 = "OrganizationName"
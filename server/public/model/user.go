@@ -96,6 +96,7 @@ type User struct {
 	LastPasswordUpdate     int64       `json:"last_password_update,omitempty"`
 	LastPictureUpdate      int64       `json:"last_picture_update,omitempty"`
 	FailedAttempts         int         `json:"failed_attempts,omitempty"`
+	LastFailedAttemptAt    int64       `json:"last_failed_attempt_at,omitempty"`
 	Locale                 string      `json:"locale"`
 	Timezone               StringMap   `json:"timezone"`
 	MfaActive              bool        `json:"mfa_active,omitempty"`
@@ -694,6 +695,7 @@ func (u *User) SanitizeInput(isAdmin bool) {
 	u.LastPasswordUpdate = 0
 	u.LastPictureUpdate = 0
 	u.FailedAttempts = 0
+	u.LastFailedAttemptAt = 0
 	u.MfaActive = false
 	u.MfaSecret = ""
 	u.MfaUsedTimestamps = StringArray{}
@@ -710,6 +712,7 @@ func (u *User) ClearNonProfileFields(asAdmin bool) {
 	u.AllowMarketing = false
 	u.LastPasswordUpdate = 0
 	u.FailedAttempts = 0
+	u.LastFailedAttemptAt = 0
 
 	if !asAdmin {
 		u.NotifyProps = StringMap{}
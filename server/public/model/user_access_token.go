@@ -4,7 +4,21 @@
 package model
 
 import (
+	"encoding/json"
 	"net/http"
+	"strings"
+)
+
+const (
+	// UserAccessTokenScopePermission restricts a token to the given permission. A token scoped to
+	// permissions is only granted those of its user's permissions.
+	UserAccessTokenScopePermission = "permission"
+	// UserAccessTokenScopeTeam restricts a token to the given team and its channels.
+	UserAccessTokenScopeTeam = "team"
+	// UserAccessTokenScopeChannel restricts a token to the given channel.
+	UserAccessTokenScopeChannel = "channel"
+
+	UserAccessTokenScopesMaxLength = 1024
 )
 
 type UserAccessToken struct {
@@ -13,6 +27,14 @@ type UserAccessToken struct {
 	UserId      string `json:"user_id"`
 	Description string `json:"description"`
 	IsActive    bool   `json:"is_active"`
+	// ExpiresAt is when the token stops being accepted, or zero if it never expires.
+	ExpiresAt int64 `json:"expires_at,omitempty"`
+	// Scopes restricts the token to a subset of the permissions, teams and channels of its user,
+	// each scope being formatted as <kind>:<id>. A token without scopes isn't restricted.
+	Scopes     StringArray `json:"scopes,omitempty"`
+	LastUsedAt int64       `json:"last_used_at,omitempty"`
+	// ExpiryNotified is set once the owner of the token has been notified of its upcoming expiry.
+	ExpiryNotified bool `json:"-"`
 }
 
 func (t *UserAccessToken) IsValid() *AppError {
@@ -32,10 +54,112 @@ func (t *UserAccessToken) IsValid() *AppError {
 		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.description.app_error", nil, "", http.StatusBadRequest)
 	}
 
+	if t.ExpiresAt < 0 {
+		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.expires_at.app_error", nil, "", http.StatusBadRequest)
+	}
+
+	// The scopes are stored encoded as JSON.
+	if scopes, _ := json.Marshal(t.Scopes); len(scopes) > UserAccessTokenScopesMaxLength {
+		return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.scopes_length.app_error", map[string]any{"Limit": UserAccessTokenScopesMaxLength}, "", http.StatusBadRequest)
+	}
+
+	for _, scope := range t.Scopes {
+		if !isValidUserAccessTokenScope(scope) {
+			return NewAppError("UserAccessToken.IsValid", "model.user_access_token.is_valid.scope.app_error", map[string]any{"Scope": scope}, "", http.StatusBadRequest)
+		}
+	}
+
 	return nil
 }
 
 func (t *UserAccessToken) PreSave() {
 	t.Id = NewId()
 	t.IsActive = true
+	t.LastUsedAt = 0
+	t.ExpiryNotified = false
+}
+
+// IsExpired returns true if the token has an expiry date that has passed.
+func (t *UserAccessToken) IsExpired() bool {
+	return t.ExpiresAt > 0 && GetMillis() > t.ExpiresAt
+}
+
+func isValidUserAccessTokenScope(scope string) bool {
+	kind, id, ok := strings.Cut(scope, ":")
+	if !ok {
+		return false
+	}
+
+	switch kind {
+	case UserAccessTokenScopePermission:
+		for _, permission := range AllPermissions {
+			if permission.Id == id {
+				return true
+			}
+		}
+		return false
+	case UserAccessTokenScopeTeam, UserAccessTokenScopeChannel:
+		return IsValidId(id)
+	default:
+		return false
+	}
+}
+
+// UserAccessTokenScopes are the parsed scopes of a user access token, keyed by kind.
+type UserAccessTokenScopes map[string]map[string]bool
+
+// ParseUserAccessTokenScopes parses the scopes of a user access token, ignoring invalid ones.
+func ParseUserAccessTokenScopes(scopes []string) UserAccessTokenScopes {
+	parsed := UserAccessTokenScopes{}
+	for _, scope := range scopes {
+		kind, id, ok := strings.Cut(scope, ":")
+		if !ok || id == "" {
+			continue
+		}
+		if parsed[kind] == nil {
+			parsed[kind] = map[string]bool{}
+		}
+		parsed[kind][id] = true
+	}
+	return parsed
+}
+
+// AllowsPermission returns true if the scopes don't restrict the permissions of the token, or
+// include the given permission.
+func (s UserAccessTokenScopes) AllowsPermission(permissionID string) bool {
+	permissions := s[UserAccessTokenScopePermission]
+	return len(permissions) == 0 || permissions[permissionID]
+}
+
+// AllowsSystemPermission returns true if the scopes allow the permission system-wide. A token
+// restricted to some teams or channels is denied the permissions which aren't scoped to a team or
+// channel, such as manage_system.
+func (s UserAccessTokenScopes) AllowsSystemPermission(permission *Permission) bool {
+	if !s.AllowsPermission(permission.Id) {
+		return false
+	}
+	if !s.RestrictsResources() {
+		return true
+	}
+	return permission.Scope == PermissionScopeTeam || permission.Scope == PermissionScopeChannel
+}
+
+// RestrictsResources returns true if the scopes restrict the token to some teams or channels.
+func (s UserAccessTokenScopes) RestrictsResources() bool {
+	return len(s[UserAccessTokenScopeTeam]) > 0 || len(s[UserAccessTokenScopeChannel]) > 0
+}
+
+// AllowsTeam returns true if the scopes don't restrict the token to some teams or channels, or
+// include the given team. A token restricted to some channels only can't act on their teams.
+func (s UserAccessTokenScopes) AllowsTeam(teamID string) bool {
+	return !s.RestrictsResources() || s[UserAccessTokenScopeTeam][teamID]
+}
+
+// AllowsChannel returns true if the scopes don't restrict the token to some teams or channels,
+// or include either the given channel or its team.
+func (s UserAccessTokenScopes) AllowsChannel(channelID, teamID string) bool {
+	if !s.RestrictsResources() || s[UserAccessTokenScopeChannel][channelID] {
+		return true
+	}
+	return teamID != "" && s[UserAccessTokenScopeTeam][teamID]
 }
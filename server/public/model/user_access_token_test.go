@@ -30,3 +30,106 @@ func TestUserAccessTokenIsValid(t *testing.T) {
 	appErr = ad.IsValid()
 	require.False(t, appErr == nil || appErr.Id != "model.user_access_token.is_valid.description.app_error")
 }
+
+func TestUserAccessTokenIsValidExpiryAndScopes(t *testing.T) {
+	token := UserAccessToken{Id: NewId(), Token: NewId(), UserId: NewId()}
+
+	token.ExpiresAt = -1
+	appErr := token.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.user_access_token.is_valid.expires_at.app_error", appErr.Id)
+
+	token.ExpiresAt = GetMillis() + 1000
+	token.Scopes = StringArray{"permission:" + PermissionCreatePost.Id, "team:" + NewId(), "channel:" + NewId()}
+	require.Nil(t, token.IsValid())
+
+	for _, scope := range []string{"permission:unknown", "team:myteam", "system:" + NewId(), "channel"} {
+		token.Scopes = StringArray{scope}
+		appErr = token.IsValid()
+		require.NotNil(t, appErr, scope)
+		require.Equal(t, "model.user_access_token.is_valid.scope.app_error", appErr.Id)
+	}
+
+	token.Scopes = nil
+	for i := 0; i < 40; i++ {
+		token.Scopes = append(token.Scopes, "channel:"+NewId())
+	}
+	appErr = token.IsValid()
+	require.NotNil(t, appErr)
+	require.Equal(t, "model.user_access_token.is_valid.scopes_length.app_error", appErr.Id)
+}
+
+func TestUserAccessTokenIsExpired(t *testing.T) {
+	require.False(t, (&UserAccessToken{}).IsExpired())
+	require.False(t, (&UserAccessToken{ExpiresAt: GetMillis() + 60000}).IsExpired())
+	require.True(t, (&UserAccessToken{ExpiresAt: GetMillis() - 1}).IsExpired())
+}
+
+func TestUserAccessTokenScopes(t *testing.T) {
+	teamID := NewId()
+	channelID := NewId()
+	otherID := NewId()
+
+	t.Run("no scopes", func(t *testing.T) {
+		scopes := ParseUserAccessTokenScopes(nil)
+		require.True(t, scopes.AllowsPermission(PermissionManageSystem.Id))
+		require.True(t, scopes.AllowsTeam(otherID))
+		require.True(t, scopes.AllowsChannel(otherID, otherID))
+	})
+
+	t.Run("permission scopes", func(t *testing.T) {
+		scopes := ParseUserAccessTokenScopes([]string{"permission:" + PermissionCreatePost.Id})
+		require.True(t, scopes.AllowsPermission(PermissionCreatePost.Id))
+		require.False(t, scopes.AllowsPermission(PermissionManageSystem.Id))
+		require.True(t, scopes.AllowsTeam(otherID))
+	})
+
+	t.Run("team scopes", func(t *testing.T) {
+		scopes := ParseUserAccessTokenScopes([]string{"team:" + teamID})
+		require.True(t, scopes.AllowsPermission(PermissionManageSystem.Id))
+		require.True(t, scopes.AllowsTeam(teamID))
+		require.False(t, scopes.AllowsTeam(otherID))
+		require.True(t, scopes.AllowsChannel(otherID, teamID))
+		require.False(t, scopes.AllowsChannel(otherID, otherID))
+		require.False(t, scopes.AllowsChannel(otherID, ""))
+		require.False(t, scopes.AllowsSystemPermission(PermissionManageSystem))
+		require.True(t, scopes.AllowsSystemPermission(PermissionCreatePost))
+	})
+
+	t.Run("channel scopes", func(t *testing.T) {
+		scopes := ParseUserAccessTokenScopes([]string{"channel:" + channelID})
+		require.False(t, scopes.AllowsTeam(teamID))
+		require.True(t, scopes.AllowsChannel(channelID, teamID))
+		require.False(t, scopes.AllowsChannel(otherID, teamID))
+	})
+
+	t.Run("session", func(t *testing.T) {
+		session := &Session{}
+		require.False(t, session.GetUserAccessTokenScopes().RestrictsResources())
+
+		session.AddProp(SessionPropUserAccessTokenScopes, "permission:"+PermissionCreatePost.Id+" channel:"+channelID)
+		scopes := session.GetUserAccessTokenScopes()
+		require.True(t, scopes.RestrictsResources())
+		require.True(t, scopes.AllowsChannel(channelID, ""))
+		require.False(t, scopes.AllowsPermission(PermissionReadChannel.Id))
+	})
+
+	t.Run("acting as the user", func(t *testing.T) {
+		session := &Session{UserId: otherID}
+		require.True(t, session.CanActAsUser(otherID))
+		require.False(t, session.CanActAsUser(teamID))
+
+		session.AddProp(SessionPropUserAccessTokenScopes, "permission:"+PermissionCreatePost.Id)
+		require.False(t, session.CanActAsUser(otherID))
+	})
+
+	t.Run("acting as the user within a team or channel", func(t *testing.T) {
+		session := &Session{UserId: otherID}
+		session.AddProp(SessionPropUserAccessTokenScopes, "team:"+teamID)
+		require.True(t, session.CanActAsUserInTeam(otherID, teamID))
+		require.False(t, session.CanActAsUserInTeam(otherID, channelID))
+		require.False(t, session.CanActAsUserInTeam(teamID, teamID))
+		require.True(t, session.CanActAsUserInChannel(otherID, channelID, teamID))
+		require.False(t, session.CanActAsUserInChannel(otherID, channelID, ""))
+	})
+}
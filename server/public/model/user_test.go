@@ -353,6 +353,7 @@ func TestUserSanitizeInput(t *testing.T) {
 	user.AuthService = "saml"
 	user.EmailVerified = true
 	user.FailedAttempts = 10
+	user.LastFailedAttemptAt = GetMillis()
 	user.LastActivityAt = GetMillis()
 	user.MfaUsedTimestamps = StringArray{"1234", "4566"}
 
@@ -370,6 +371,7 @@ func TestUserSanitizeInput(t *testing.T) {
 	require.Equal(t, int64(0), user.LastPictureUpdate)
 	require.Equal(t, int64(0), user.LastActivityAt)
 	require.Equal(t, 0, user.FailedAttempts)
+	require.Equal(t, int64(0), user.LastFailedAttemptAt)
 	require.Equal(t, StringArray{}, user.MfaUsedTimestamps)
 
 	// these fields should remain intact
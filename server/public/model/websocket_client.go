@@ -351,6 +351,22 @@ func (wsc *WebSocketClient) UpdateActiveThread(isThreadView bool, channelID stri
 	wsc.SendMessage(string(WebsocketPresenceIndicator), data)
 }
 
+// Subscribe restricts the events sent by the server on this connection to
+// the given event types and channels. When channels are given, events which
+// are not scoped to one of them are not sent. Calling it with empty slices
+// removes the restriction.
+func (wsc *WebSocketClient) Subscribe(events []WebsocketEventType, channelIDs []string) {
+	eventNames := make([]string, len(events))
+	for i, event := range events {
+		eventNames[i] = string(event)
+	}
+	data := map[string]any{
+		"events":      eventNames,
+		"channel_ids": channelIDs,
+	}
+	wsc.SendMessage(string(WebsocketSubscribe), data)
+}
+
 func (wsc *WebSocketClient) configurePingHandling() {
 	wsc.Conn.SetPingHandler(wsc.pingHandler)
 	wsc.pingTimeoutTimer = time.NewTimer(time.Second * (60 + PingTimeoutBufferSeconds))
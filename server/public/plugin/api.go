@@ -962,6 +962,29 @@ type API interface {
 	// Minimum server version: 5.6
 	KVList(page, perPage int) ([]string, *model.AppError)
 
+	// KVListWithPrefix lists the keys of a plugin starting with the given prefix, in ascending order.
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 10.5
+	KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError)
+
+	// KVGetMany retrieves the values of the given keys, unique per plugin. Non-existent keys are
+	// omitted from the returned map.
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 10.5
+	KVGetMany(keys []string) (map[string][]byte, *model.AppError)
+
+	// KVBatch applies the given operations, each storing a key-value pair according to its options
+	// or removing it when its value is nil, in a single transaction.
+	// Returns (false, err) if DB error occurred
+	// Returns (false, nil) if an atomic operation was not applied, in which case none were
+	// Returns (true, nil) if all operations were applied
+	//
+	// @tag KeyValueStore
+	// Minimum server version: 10.5
+	KVBatch(operations []*model.PluginKVBatchOperation) (bool, *model.AppError)
+
 	// PublishWebSocketEvent sends an event to WebSocket connections.
 	// event is the type and will be prepended with "custom_<pluginid>_".
 	// payload is the data sent with the event. Interface values must be primitive Go types or mattermost-server/model types.
@@ -1312,6 +1335,28 @@ type API interface {
 	// @tag Plugin
 	// Minimum server version: 10.1
 	GetPluginID() string
+
+	// PublishPluginEvent publishes an event with the given JSON encoded payload to the other
+	// plugins subscribed to the topic. The event is delivered asynchronously through their
+	// OnPluginEvent hook, and only to the plugins of this server.
+	//
+	// @tag Plugin
+	// Minimum server version: 10.5
+	PublishPluginEvent(topic string, payload []byte) *model.AppError
+
+	// SubscribeToPluginEvents subscribes the plugin to the events other plugins publish on the
+	// given topic, delivered through its OnPluginEvent hook until the plugin is deactivated.
+	//
+	// @tag Plugin
+	// Minimum server version: 10.5
+	SubscribeToPluginEvents(topic string) *model.AppError
+
+	// UnsubscribeFromPluginEvents stops the delivery of the events published on the given topic
+	// to the plugin.
+	//
+	// @tag Plugin
+	// Minimum server version: 10.5
+	UnsubscribeFromPluginEvents(topic string) *model.AppError
 }
 
 var handshake = plugin.HandshakeConfig{
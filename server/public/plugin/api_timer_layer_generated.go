@@ -1034,6 +1034,27 @@ func (api *apiTimerLayer) KVList(page, perPage int) ([]string, *model.AppError)
 	return _returnsA, _returnsB
 }
 
+func (api *apiTimerLayer) KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVListWithPrefix(prefix, page, perPage)
+	api.recordTime(startTime, "KVListWithPrefix", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVGetMany(keys)
+	api.recordTime(startTime, "KVGetMany", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
+func (api *apiTimerLayer) KVBatch(operations []*model.PluginKVBatchOperation) (bool, *model.AppError) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := api.apiImpl.KVBatch(operations)
+	api.recordTime(startTime, "KVBatch", _returnsB == nil)
+	return _returnsA, _returnsB
+}
+
 func (api *apiTimerLayer) PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast) {
 	startTime := timePkg.Now()
 	api.apiImpl.PublishWebSocketEvent(event, payload, broadcast)
@@ -1385,3 +1406,24 @@ func (api *apiTimerLayer) GetPluginID() string {
 	api.recordTime(startTime, "GetPluginID", true)
 	return _returnsA
 }
+
+func (api *apiTimerLayer) PublishPluginEvent(topic string, payload []byte) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.PublishPluginEvent(topic, payload)
+	api.recordTime(startTime, "PublishPluginEvent", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) SubscribeToPluginEvents(topic string) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.SubscribeToPluginEvents(topic)
+	api.recordTime(startTime, "SubscribeToPluginEvents", _returnsA == nil)
+	return _returnsA
+}
+
+func (api *apiTimerLayer) UnsubscribeFromPluginEvents(topic string) *model.AppError {
+	startTime := timePkg.Now()
+	_returnsA := api.apiImpl.UnsubscribeFromPluginEvents(topic)
+	api.recordTime(startTime, "UnsubscribeFromPluginEvents", _returnsA == nil)
+	return _returnsA
+}
@@ -0,0 +1,273 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"net/http"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// unrestrictedAPIMethods are the plugin API methods available to every plugin: those for the
+// plugin's own configuration, key value store, commands, events and logging, and those describing
+// the server.
+var unrestrictedAPIMethods = map[string]bool{
+	"LoadPluginConfiguration":     true,
+	"GetPluginConfig":             true,
+	"SavePluginConfig":            true,
+	"GetBundlePath":               true,
+	"GetPluginID":                 true,
+	"RegisterCommand":             true,
+	"UnregisterCommand":           true,
+	"KVSet":                       true,
+	"KVCompareAndSet":             true,
+	"KVCompareAndDelete":          true,
+	"KVSetWithOptions":            true,
+	"KVSetWithExpiry":             true,
+	"KVGet":                       true,
+	"KVGetMany":                   true,
+	"KVDelete":                    true,
+	"KVDeleteAll":                 true,
+	"KVList":                      true,
+	"KVListWithPrefix":            true,
+	"KVBatch":                     true,
+	"PublishPluginClusterEvent":   true,
+	"PublishPluginEvent":          true,
+	"SubscribeToPluginEvents":     true,
+	"UnsubscribeFromPluginEvents": true,
+	"RegisterCollectionAndTopic":  true,
+	"LogDebug":                    true,
+	"LogInfo":                     true,
+	"LogWarn":                     true,
+	"LogError":                    true,
+
+	"GetLicense":           true,
+	"IsEnterpriseReady":    true,
+	"GetServerVersion":     true,
+	"GetSystemInstallDate": true,
+	"GetDiagnosticId":      true,
+	"GetTelemetryId":       true,
+	"GetCloudLimits":       true,
+}
+
+// apiMethodCapabilities maps the other plugin API methods to the capability a plugin has to
+// declare in its manifest to call them. Plugins declaring capabilities can't call methods missing
+// from both maps.
+var apiMethodCapabilities = map[string]string{
+	"ExecuteSlashCommand":   model.PluginCapabilityManageIntegrations,
+	"GetConfig":             model.PluginCapabilityReadConfig,
+	"GetUnsanitizedConfig":  model.PluginCapabilityReadConfig,
+	"SaveConfig":            model.PluginCapabilityManageConfig,
+	"GetLDAPUserAttributes": model.PluginCapabilityReadUsers,
+	"RequestTrialLicense":   model.PluginCapabilityManageConfig,
+
+	"CreateUser":               model.PluginCapabilityManageUsers,
+	"DeleteUser":               model.PluginCapabilityManageUsers,
+	"GetUsers":                 model.PluginCapabilityReadUsers,
+	"GetUsersByIds":            model.PluginCapabilityReadUsers,
+	"GetUser":                  model.PluginCapabilityReadUsers,
+	"GetUserByEmail":           model.PluginCapabilityReadUsers,
+	"GetUserByUsername":        model.PluginCapabilityReadUsers,
+	"GetUsersByUsernames":      model.PluginCapabilityReadUsers,
+	"GetUsersInTeam":           model.PluginCapabilityReadUsers,
+	"GetUsersInChannel":        model.PluginCapabilityReadUsers,
+	"SearchUsers":              model.PluginCapabilityReadUsers,
+	"GetPreferenceForUser":     model.PluginCapabilityReadUsers,
+	"GetPreferencesForUser":    model.PluginCapabilityReadUsers,
+	"UpdatePreferencesForUser": model.PluginCapabilityManageUsers,
+	"DeletePreferencesForUser": model.PluginCapabilityManageUsers,
+	"GetUserStatus":            model.PluginCapabilityReadUsers,
+	"GetUserStatusesByIds":     model.PluginCapabilityReadUsers,
+	"UpdateUserStatus":         model.PluginCapabilityManageUsers,
+	"SetUserStatusTimedDND":    model.PluginCapabilityManageUsers,
+	"UpdateUser":               model.PluginCapabilityManageUsers,
+	"UpdateUserActive":         model.PluginCapabilityManageUsers,
+	"UpdateUserCustomStatus":   model.PluginCapabilityManageUsers,
+	"RemoveUserCustomStatus":   model.PluginCapabilityManageUsers,
+	"UpdateUserAuth":           model.PluginCapabilityManageUsers,
+	"UpdateUserRoles":          model.PluginCapabilityManageUsers,
+	"HasPermissionTo":          model.PluginCapabilityReadUsers,
+	"HasPermissionToTeam":      model.PluginCapabilityReadUsers,
+	"HasPermissionToChannel":   model.PluginCapabilityReadUsers,
+	"RolesGrantPermission":     model.PluginCapabilityReadUsers,
+	"GetProfileImage":          model.PluginCapabilityReadUsers,
+	"SetProfileImage":          model.PluginCapabilityManageUsers,
+	"GetGroup":                 model.PluginCapabilityReadUsers,
+	"GetGroupByName":           model.PluginCapabilityReadUsers,
+	"GetGroupMemberUsers":      model.PluginCapabilityReadUsers,
+	"GetGroupsBySource":        model.PluginCapabilityReadUsers,
+	"GetGroupsForUser":         model.PluginCapabilityReadUsers,
+
+	"GetSession":            model.PluginCapabilityManageSessions,
+	"CreateSession":         model.PluginCapabilityManageSessions,
+	"ExtendSessionExpiry":   model.PluginCapabilityManageSessions,
+	"RevokeSession":         model.PluginCapabilityManageSessions,
+	"CreateUserAccessToken": model.PluginCapabilityManageSessions,
+	"RevokeUserAccessToken": model.PluginCapabilityManageSessions,
+
+	"EnsureBotUser":      model.PluginCapabilityManageBots,
+	"CreateBot":          model.PluginCapabilityManageBots,
+	"PatchBot":           model.PluginCapabilityManageBots,
+	"GetBot":             model.PluginCapabilityManageBots,
+	"GetBots":            model.PluginCapabilityManageBots,
+	"UpdateBotActive":    model.PluginCapabilityManageBots,
+	"PermanentDeleteBot": model.PluginCapabilityManageBots,
+
+	"SendMail":              model.PluginCapabilitySendNotifications,
+	"SendPushNotification":  model.PluginCapabilitySendNotifications,
+	"PublishUserTyping":     model.PluginCapabilitySendNotifications,
+	"PublishWebSocketEvent": model.PluginCapabilitySendNotifications,
+	"SendEphemeralPost":     model.PluginCapabilitySendNotifications,
+	"UpdateEphemeralPost":   model.PluginCapabilitySendNotifications,
+	"DeleteEphemeralPost":   model.PluginCapabilitySendNotifications,
+	"OpenInteractiveDialog": model.PluginCapabilitySendNotifications,
+
+	"CreateTeam":                  model.PluginCapabilityManageTeams,
+	"DeleteTeam":                  model.PluginCapabilityManageTeams,
+	"GetTeams":                    model.PluginCapabilityReadTeams,
+	"GetTeam":                     model.PluginCapabilityReadTeams,
+	"GetTeamByName":               model.PluginCapabilityReadTeams,
+	"GetTeamsUnreadForUser":       model.PluginCapabilityReadTeams,
+	"UpdateTeam":                  model.PluginCapabilityManageTeams,
+	"SearchTeams":                 model.PluginCapabilityReadTeams,
+	"GetTeamsForUser":             model.PluginCapabilityReadTeams,
+	"GetTeamStats":                model.PluginCapabilityReadTeams,
+	"GetTeamIcon":                 model.PluginCapabilityReadTeams,
+	"SetTeamIcon":                 model.PluginCapabilityManageTeams,
+	"RemoveTeamIcon":              model.PluginCapabilityManageTeams,
+	"CreateTeamMember":            model.PluginCapabilityManageTeams,
+	"CreateTeamMembers":           model.PluginCapabilityManageTeams,
+	"CreateTeamMembersGracefully": model.PluginCapabilityManageTeams,
+	"DeleteTeamMember":            model.PluginCapabilityManageTeams,
+	"GetTeamMembers":              model.PluginCapabilityReadTeams,
+	"GetTeamMember":               model.PluginCapabilityReadTeams,
+	"GetTeamMembersForUser":       model.PluginCapabilityReadTeams,
+	"UpdateTeamMemberRoles":       model.PluginCapabilityManageTeams,
+
+	"CreateChannel":                     model.PluginCapabilityManageChannels,
+	"DeleteChannel":                     model.PluginCapabilityManageChannels,
+	"GetPublicChannelsForTeam":          model.PluginCapabilityReadChannels,
+	"GetChannel":                        model.PluginCapabilityReadChannels,
+	"GetChannelByName":                  model.PluginCapabilityReadChannels,
+	"GetChannelByNameForTeamName":       model.PluginCapabilityReadChannels,
+	"GetChannelsForTeamForUser":         model.PluginCapabilityReadChannels,
+	"GetChannelStats":                   model.PluginCapabilityReadChannels,
+	"GetDirectChannel":                  model.PluginCapabilityManageChannels,
+	"GetGroupChannel":                   model.PluginCapabilityManageChannels,
+	"UpdateChannel":                     model.PluginCapabilityManageChannels,
+	"SearchChannels":                    model.PluginCapabilityReadChannels,
+	"CreateChannelSidebarCategory":      model.PluginCapabilityManageChannels,
+	"GetChannelSidebarCategories":       model.PluginCapabilityReadChannels,
+	"UpdateChannelSidebarCategories":    model.PluginCapabilityManageChannels,
+	"AddChannelMember":                  model.PluginCapabilityManageChannels,
+	"AddUserToChannel":                  model.PluginCapabilityManageChannels,
+	"GetChannelMember":                  model.PluginCapabilityReadChannels,
+	"GetChannelMembers":                 model.PluginCapabilityReadChannels,
+	"GetChannelMembersByIds":            model.PluginCapabilityReadChannels,
+	"GetChannelMembersForUser":          model.PluginCapabilityReadChannels,
+	"UpdateChannelMemberRoles":          model.PluginCapabilityManageChannels,
+	"UpdateChannelMemberNotifications":  model.PluginCapabilityManageChannels,
+	"PatchChannelMembersNotifications":  model.PluginCapabilityManageChannels,
+	"DeleteChannelMember":               model.PluginCapabilityManageChannels,
+	"ShareChannel":                      model.PluginCapabilityManageChannels,
+	"UpdateSharedChannel":               model.PluginCapabilityManageChannels,
+	"UnshareChannel":                    model.PluginCapabilityManageChannels,
+	"UpdateSharedChannelCursor":         model.PluginCapabilityManageChannels,
+	"SyncSharedChannel":                 model.PluginCapabilityManageChannels,
+	"InviteRemoteToChannel":             model.PluginCapabilityManageChannels,
+	"UninviteRemoteFromChannel":         model.PluginCapabilityManageChannels,
+	"RegisterPluginForSharedChannels":   model.PluginCapabilityManageChannels,
+	"UnregisterPluginForSharedChannels": model.PluginCapabilityManageChannels,
+
+	"SearchPostsInTeam":        model.PluginCapabilityReadPosts,
+	"SearchPostsInTeamForUser": model.PluginCapabilityReadPosts,
+	"CreatePost":               model.PluginCapabilityManagePosts,
+	"UpdatePost":               model.PluginCapabilityManagePosts,
+	"DeletePost":               model.PluginCapabilityManagePosts,
+	"GetPostThread":            model.PluginCapabilityReadPosts,
+	"GetPost":                  model.PluginCapabilityReadPosts,
+	"GetPostsSince":            model.PluginCapabilityReadPosts,
+	"GetPostsAfter":            model.PluginCapabilityReadPosts,
+	"GetPostsBefore":           model.PluginCapabilityReadPosts,
+	"GetPostsForChannel":       model.PluginCapabilityReadPosts,
+	"AddReaction":              model.PluginCapabilityManagePosts,
+	"RemoveReaction":           model.PluginCapabilityManagePosts,
+	"GetReactions":             model.PluginCapabilityReadPosts,
+	"GetEmojiList":             model.PluginCapabilityReadPosts,
+	"GetEmojiByName":           model.PluginCapabilityReadPosts,
+	"GetEmoji":                 model.PluginCapabilityReadPosts,
+	"GetEmojiImage":            model.PluginCapabilityReadPosts,
+
+	"CopyFileInfos":            model.PluginCapabilityManageFiles,
+	"GetFileInfo":              model.PluginCapabilityReadFiles,
+	"GetFileInfos":             model.PluginCapabilityReadFiles,
+	"SetFileSearchableContent": model.PluginCapabilityManageFiles,
+	"GetFile":                  model.PluginCapabilityReadFiles,
+	"GetFileLink":              model.PluginCapabilityReadFiles,
+	"ReadFile":                 model.PluginCapabilityReadFiles,
+	"UploadFile":               model.PluginCapabilityManageFiles,
+	"CreateUploadSession":      model.PluginCapabilityManageFiles,
+	"UploadData":               model.PluginCapabilityManageFiles,
+	"GetUploadSession":         model.PluginCapabilityManageFiles,
+
+	"GetPlugins":      model.PluginCapabilityManagePlugins,
+	"EnablePlugin":    model.PluginCapabilityManagePlugins,
+	"DisablePlugin":   model.PluginCapabilityManagePlugins,
+	"RemovePlugin":    model.PluginCapabilityManagePlugins,
+	"GetPluginStatus": model.PluginCapabilityManagePlugins,
+	"InstallPlugin":   model.PluginCapabilityManagePlugins,
+
+	"CreateCommand":       model.PluginCapabilityManageIntegrations,
+	"ListCommands":        model.PluginCapabilityManageIntegrations,
+	"ListCustomCommands":  model.PluginCapabilityManageIntegrations,
+	"ListPluginCommands":  model.PluginCapabilityManageIntegrations,
+	"ListBuiltInCommands": model.PluginCapabilityManageIntegrations,
+	"GetCommand":          model.PluginCapabilityManageIntegrations,
+	"UpdateCommand":       model.PluginCapabilityManageIntegrations,
+	"DeleteCommand":       model.PluginCapabilityManageIntegrations,
+	"CreateOAuthApp":      model.PluginCapabilityManageIntegrations,
+	"GetOAuthApp":         model.PluginCapabilityManageIntegrations,
+	"UpdateOAuthApp":      model.PluginCapabilityManageIntegrations,
+	"DeleteOAuthApp":      model.PluginCapabilityManageIntegrations,
+
+	"PluginHTTP": model.PluginCapabilityInterPluginHTTP,
+}
+
+// capabilitySet holds the capabilities declared by a plugin. A nil set places no restrictions,
+// which is the case for plugins that don't declare capabilities.
+type capabilitySet map[string]bool
+
+func newCapabilitySet(manifest *model.Manifest) capabilitySet {
+	if manifest == nil || !manifest.DeclaresCapabilities() {
+		return nil
+	}
+
+	set := capabilitySet{}
+	for _, capability := range manifest.Capabilities {
+		set[capability] = true
+	}
+	return set
+}
+
+func (s capabilitySet) allows(capability string) bool {
+	return s == nil || s[capability]
+}
+
+// checkAPIMethod returns a permission error when the method needs a capability missing from
+// the set, or isn't covered by any capability.
+func (s capabilitySet) checkAPIMethod(method string) *model.AppError {
+	if s == nil || unrestrictedAPIMethods[method] {
+		return nil
+	}
+
+	capability, ok := apiMethodCapabilities[method]
+	if !ok {
+		return model.NewAppError("checkAPIMethod", "plugin.api.capability_unknown_method.app_error", map[string]any{"Method": method}, "method="+method, http.StatusForbidden)
+	}
+	if s.allows(capability) {
+		return nil
+	}
+
+	return model.NewAppError("checkAPIMethod", "plugin.api.capability_not_declared.app_error", map[string]any{"Method": method, "Capability": capability}, "method="+method+", capability="+capability, http.StatusForbidden)
+}
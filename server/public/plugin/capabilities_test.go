@@ -0,0 +1,145 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+type capabilitiesTestAPI struct {
+	API
+}
+
+func (api *capabilitiesTestAPI) GetUser(userID string) (*model.User, *model.AppError) {
+	return &model.User{Id: userID}, nil
+}
+
+func (api *capabilitiesTestAPI) KVGet(key string) ([]byte, *model.AppError) {
+	return []byte("value"), nil
+}
+
+func (api *capabilitiesTestAPI) GetPluginID() string {
+	return "capabilities"
+}
+
+func TestAPIMethodCapabilities(t *testing.T) {
+	apiType := reflect.TypeOf((*API)(nil)).Elem()
+	for method, capability := range apiMethodCapabilities {
+		_, ok := apiType.MethodByName(method)
+		assert.True(t, ok, "%s is not a plugin API method", method)
+		assert.True(t, model.IsValidPluginCapability(capability), "%s maps to unknown capability %s", method, capability)
+		assert.False(t, unrestrictedAPIMethods[method], "%s is both unrestricted and mapped to a capability", method)
+	}
+	for method := range unrestrictedAPIMethods {
+		_, ok := apiType.MethodByName(method)
+		assert.True(t, ok, "%s is not a plugin API method", method)
+	}
+
+	// New methods have to be classified explicitly.
+	for i := 0; i < apiType.NumMethod(); i++ {
+		method := apiType.Method(i).Name
+		_, mapped := apiMethodCapabilities[method]
+		assert.True(t, mapped || unrestrictedAPIMethods[method], "%s is neither unrestricted nor mapped to a capability", method)
+	}
+}
+
+func TestCapabilitySet(t *testing.T) {
+	t.Run("undeclared capabilities place no restrictions", func(t *testing.T) {
+		set := newCapabilitySet(&model.Manifest{Id: "foo"})
+		assert.Nil(t, set)
+		assert.Nil(t, set.checkAPIMethod("CreatePost"))
+		assert.True(t, set.allows(model.PluginCapabilityDBAccess))
+	})
+
+	t.Run("declared capabilities", func(t *testing.T) {
+		set := newCapabilitySet(&model.Manifest{Id: "foo", Capabilities: []string{model.PluginCapabilityReadPosts}})
+		assert.Nil(t, set.checkAPIMethod("GetPost"))
+		assert.Nil(t, set.checkAPIMethod("KVSet"))
+		assert.False(t, set.allows(model.PluginCapabilityDBAccess))
+
+		appErr := set.checkAPIMethod("CreatePost")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "plugin.api.capability_not_declared.app_error", appErr.Id)
+		assert.Equal(t, http.StatusForbidden, appErr.StatusCode)
+	})
+
+	t.Run("unknown methods are denied", func(t *testing.T) {
+		set := newCapabilitySet(&model.Manifest{Id: "foo", Capabilities: model.AllPluginCapabilities})
+		appErr := set.checkAPIMethod("NotAnAPIMethod")
+		require.NotNil(t, appErr)
+		assert.Equal(t, "plugin.api.capability_unknown_method.app_error", appErr.Id)
+		assert.Nil(t, newCapabilitySet(&model.Manifest{Id: "foo"}).checkAPIMethod("NotAnAPIMethod"))
+	})
+
+	t.Run("empty list declares no capabilities", func(t *testing.T) {
+		set := newCapabilitySet(&model.Manifest{Id: "foo", Capabilities: []string{}})
+		assert.NotNil(t, set)
+		assert.NotNil(t, set.checkAPIMethod("GetPost"))
+	})
+}
+
+func TestAPIRPCServerCapabilities(t *testing.T) {
+	t.Run("unrestricted", func(t *testing.T) {
+		s := &apiRPCServer{impl: &capabilitiesTestAPI{}}
+
+		returns := &Z_GetUserReturns{}
+		require.NoError(t, s.GetUser(&Z_GetUserArgs{A: "user"}, returns))
+		assert.Nil(t, returns.B)
+		assert.Equal(t, "user", returns.A.Id)
+	})
+
+	t.Run("capability declared", func(t *testing.T) {
+		s := &apiRPCServer{impl: &capabilitiesTestAPI{}, capabilities: capabilitySet{model.PluginCapabilityReadUsers: true}}
+
+		returns := &Z_GetUserReturns{}
+		require.NoError(t, s.GetUser(&Z_GetUserArgs{A: "user"}, returns))
+		assert.Nil(t, returns.B)
+		assert.Equal(t, "user", returns.A.Id)
+	})
+
+	t.Run("capability not declared", func(t *testing.T) {
+		s := &apiRPCServer{impl: &capabilitiesTestAPI{}, capabilities: capabilitySet{}}
+
+		returns := &Z_GetUserReturns{}
+		require.NoError(t, s.GetUser(&Z_GetUserArgs{A: "user"}, returns))
+		assert.Nil(t, returns.A)
+		require.NotNil(t, returns.B)
+		assert.Equal(t, "plugin.api.capability_not_declared.app_error", returns.B.Id)
+
+		kvReturns := &Z_KVGetReturns{}
+		require.NoError(t, s.KVGet(&Z_KVGetArgs{A: "key"}, kvReturns))
+		assert.Nil(t, kvReturns.B)
+		assert.Equal(t, []byte("value"), kvReturns.A)
+
+		idReturns := &Z_GetPluginIDReturns{}
+		require.NoError(t, s.GetPluginID(&Z_GetPluginIDArgs{}, idReturns))
+		assert.Equal(t, "capabilities", idReturns.A)
+	})
+
+	t.Run("http outbound not declared", func(t *testing.T) {
+		s := &apiRPCServer{impl: &capabilitiesTestAPI{}, capabilities: capabilitySet{}}
+
+		returns := &Z_PluginHTTPReturns{}
+		require.NoError(t, s.PluginHTTP(&Z_PluginHTTPArgs{Request: &http.Request{}}, returns))
+		require.NotNil(t, returns.Response)
+		assert.Equal(t, http.StatusForbidden, returns.Response.StatusCode)
+		assert.Contains(t, string(returns.ResponseBody), "plugin.api.capability_not_declared.app_error")
+	})
+
+	t.Run("db access not declared", func(t *testing.T) {
+		db := &dbRPCServer{capabilities: capabilitySet{}}
+
+		ret := &Z_DbStrErrReturn{}
+		require.NoError(t, db.Conn(true, ret))
+		require.Error(t, ret.B)
+		assert.Equal(t, errDBAccessNotDeclared.Error(), ret.B.Error())
+	})
+}
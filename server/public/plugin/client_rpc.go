@@ -22,7 +22,7 @@ import (
 
 	"github.com/go-sql-driver/mysql"
 	"github.com/hashicorp/go-plugin"
-	"github.com/lib/pq"
+	"github.com/pkg/errors"
 
 	"github.com/mattermost/mattermost/server/public/model"
 	"github.com/mattermost/mattermost/server/public/shared/mlog"
@@ -31,13 +31,15 @@ import (
 var hookNameToId = make(map[string]int)
 
 type hooksRPCClient struct {
-	client      *rpc.Client
-	log         *mlog.Logger
-	muxBroker   *plugin.MuxBroker
-	apiImpl     API
-	driver      Driver
-	implemented [TotalHooksID]bool
-	doneWg      sync.WaitGroup
+	client       *rpc.Client
+	log          *mlog.Logger
+	muxBroker    *plugin.MuxBroker
+	apiImpl      API
+	driver       Driver
+	capabilities capabilitySet
+	guard        *hookGuard
+	implemented  [TotalHooksID]bool
+	doneWg       sync.WaitGroup
 }
 
 type hooksRPCServer struct {
@@ -48,10 +50,12 @@ type hooksRPCServer struct {
 
 // Implements hashicorp/go-plugin/plugin.Plugin interface to connect the hooks of a plugin
 type hooksPlugin struct {
-	hooks      any
-	apiImpl    API
-	driverImpl Driver
-	log        *mlog.Logger
+	hooks        any
+	apiImpl      API
+	driverImpl   Driver
+	capabilities capabilitySet
+	guard        *hookGuard
+	log          *mlog.Logger
 }
 
 func (p *hooksPlugin) Server(b *plugin.MuxBroker) (any, error) {
@@ -60,21 +64,29 @@ func (p *hooksPlugin) Server(b *plugin.MuxBroker) (any, error) {
 
 func (p *hooksPlugin) Client(b *plugin.MuxBroker, client *rpc.Client) (any, error) {
 	return &hooksRPCClient{client: client,
-		log:       p.log,
-		muxBroker: b,
-		apiImpl:   p.apiImpl,
-		driver:    p.driverImpl,
+		log:          p.log,
+		muxBroker:    b,
+		apiImpl:      p.apiImpl,
+		driver:       p.driverImpl,
+		capabilities: p.capabilities,
+		guard:        p.guard,
 	}, nil
 }
 
+// call runs the given hook of the plugin, subject to the deadline configured for it.
+func (g *hooksRPCClient) call(hookName string, args, reply any) error {
+	return g.guard.call(g.client, hookName, args, reply)
+}
+
 type apiRPCClient struct {
 	client    *rpc.Client
 	muxBroker *plugin.MuxBroker
 }
 
 type apiRPCServer struct {
-	impl      API
-	muxBroker *plugin.MuxBroker
+	impl         API
+	muxBroker    *plugin.MuxBroker
+	capabilities capabilitySet
 }
 
 // ErrorString is a fallback for sending unregistered implementations of the error interface across
@@ -99,7 +111,7 @@ func encodableError(err error) error {
 		return err
 	}
 
-	if _, ok := err.(*pq.Error); ok {
+	if isPqError(err) {
 		return err
 	}
 
@@ -159,7 +171,6 @@ func init() {
 	gob.Register([]any{})
 	gob.Register(map[string]any{})
 	gob.Register(&model.AppError{})
-	gob.Register(&pq.Error{})
 	gob.Register(&mysql.MySQLError{})
 	gob.Register(&ErrorString{})
 	gob.Register(&model.AutocompleteDynamicListArg{})
@@ -244,8 +255,9 @@ func (g *hooksRPCClient) OnActivate() error {
 	go func() {
 		defer g.doneWg.Done()
 		g.muxBroker.AcceptAndServe(muxId, &apiRPCServer{
-			impl:      g.apiImpl,
-			muxBroker: g.muxBroker,
+			impl:         g.apiImpl,
+			muxBroker:    g.muxBroker,
+			capabilities: g.capabilities,
 		})
 	}()
 
@@ -254,7 +266,8 @@ func (g *hooksRPCClient) OnActivate() error {
 	go func() {
 		defer g.doneWg.Done()
 		g.muxBroker.AcceptAndServe(nextID, &dbRPCServer{
-			dbImpl: g.driver,
+			dbImpl:       g.driver,
+			capabilities: g.capabilities,
 		})
 	}()
 
@@ -290,12 +303,18 @@ func (s *hooksRPCServer) OnActivate(args *Z_OnActivateArgs, returns *Z_OnActivat
 		client: rpc.NewClient(conn2),
 	}
 
+	returns.A = encodableError(s.activate(s.apiRPCClient, dbClient))
+	return nil
+}
+
+// activate hands the API and database driver to the plugin before running its OnActivate hook.
+func (s *hooksRPCServer) activate(api API, driver Driver) error {
 	if mmplugin, ok := s.impl.(interface {
 		SetAPI(api API)
 		SetDriver(driver Driver)
 	}); ok {
-		mmplugin.SetAPI(s.apiRPCClient)
-		mmplugin.SetDriver(dbClient)
+		mmplugin.SetAPI(api)
+		mmplugin.SetDriver(driver)
 	}
 
 	if mmplugin, ok := s.impl.(interface {
@@ -313,7 +332,7 @@ func (s *hooksRPCServer) OnActivate(args *Z_OnActivateArgs, returns *Z_OnActivat
 	if hook, ok := s.impl.(interface {
 		OnActivate() error
 	}); ok {
-		returns.A = encodableError(hook.OnActivate())
+		return hook.OnActivate()
 	}
 	return nil
 }
@@ -370,6 +389,10 @@ func (g *hooksRPCClient) ServeHTTP(c *Context, w http.ResponseWriter, r *http.Re
 		http.NotFound(w, r)
 		return
 	}
+	if g.guard.skip("ServeHTTP") {
+		http.Error(w, "503 service unavailable", http.StatusServiceUnavailable)
+		return
+	}
 
 	serveHTTPStreamId := g.muxBroker.NextId()
 	go func() {
@@ -509,6 +532,15 @@ func (g *apiRPCClient) PluginHTTP(request *http.Request) *http.Response {
 }
 
 func (s *apiRPCServer) PluginHTTP(args *Z_PluginHTTPArgs, returns *Z_PluginHTTPReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("PluginHTTP"); appErr != nil {
+		returns.Response = &http.Response{
+			StatusCode: appErr.StatusCode,
+			Header:     http.Header{},
+		}
+		returns.ResponseBody = []byte(appErr.Error())
+		return nil
+	}
+
 	args.Request.Body = io.NopCloser(bytes.NewBuffer(args.RequestBody))
 
 	if hook, ok := s.impl.(interface {
@@ -551,7 +583,22 @@ func (g *hooksRPCClient) FileWillBeUploaded(c *Context, info *model.FileInfo, fi
 	if !g.implemented[FileWillBeUploadedID] {
 		return info, ""
 	}
+	if g.guard.skip("FileWillBeUploaded") {
+		return nil, hookUnavailableRejection
+	}
+
+	// A hook given up on may still stream a replacement, which must not reach the caller, so the
+	// replacement of a hook with a deadline is buffered until the hook returns.
+	replacement := output
+	var bufferedReplacement *bytes.Buffer
+	if g.guard.timeout("FileWillBeUploaded") > 0 {
+		bufferedReplacement = &bytes.Buffer{}
+		replacement = bufferedReplacement
+	}
 
+	// The file may be shared with the next plugin, so it mustn't be read anymore once the hook is
+	// given up on.
+	source := &stoppableReader{r: file}
 	uploadedFileStreamId := g.muxBroker.NextId()
 	go func() {
 		uploadedFileConnection, err := g.muxBroker.Accept(uploadedFileStreamId)
@@ -560,7 +607,7 @@ func (g *hooksRPCClient) FileWillBeUploaded(c *Context, info *model.FileInfo, fi
 			return
 		}
 		defer uploadedFileConnection.Close()
-		serveIOReader(file, uploadedFileConnection)
+		serveIOReader(source, uploadedFileConnection)
 	}()
 
 	replacementDone := make(chan bool)
@@ -574,20 +621,31 @@ func (g *hooksRPCClient) FileWillBeUploaded(c *Context, info *model.FileInfo, fi
 			return
 		}
 		defer replacementFileConnection.Close()
-		if _, err := io.Copy(output, replacementFileConnection); err != nil {
+		if _, err := io.Copy(replacement, replacementFileConnection); err != nil {
 			g.log.Error("Error reading replacement file.", mlog.Err(err))
 		}
 	}()
 
 	_args := &Z_FileWillBeUploadedArgs{c, info, uploadedFileStreamId, replacementFileStreamId}
 	_returns := &Z_FileWillBeUploadedReturns{A: _args.B}
-	if err := g.client.Call("Plugin.FileWillBeUploaded", _args, _returns); err != nil {
+	err := g.call("FileWillBeUploaded", _args, _returns)
+	if err != nil {
 		g.log.Error("RPC call FileWillBeUploaded to plugin failed.", mlog.Err(err))
 	}
+	if errors.Is(err, errHookTimeout) {
+		source.stop()
+		return nil, hookUnavailableRejection
+	}
 
 	// Ensure the io.Copy from the replacementFileConnection above completes.
 	<-replacementDone
 
+	if bufferedReplacement != nil && bufferedReplacement.Len() > 0 {
+		if _, err := output.Write(bufferedReplacement.Bytes()); err != nil {
+			g.log.Error("Error writing replacement file.", mlog.Err(err))
+		}
+	}
+
 	return _returns.A, _returns.B
 }
 
@@ -619,6 +677,119 @@ func (s *hooksRPCServer) FileWillBeUploaded(args *Z_FileWillBeUploadedArgs, retu
 	return nil
 }
 
+func init() {
+	hookNameToId["FileWillBeDownloaded"] = FileWillBeDownloadedID
+}
+
+type Z_FileWillBeDownloadedArgs struct {
+	A                     *Context
+	B                     *model.FileInfo
+	C                     string
+	D                     model.FileDownloadType
+	DownloadedFileStream  uint32
+	ReplacementFileStream uint32
+}
+
+type Z_FileWillBeDownloadedReturns struct {
+	A string
+}
+
+func (g *hooksRPCClient) FileWillBeDownloaded(c *Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string {
+	if !g.implemented[FileWillBeDownloadedID] {
+		return ""
+	}
+	if g.guard.skip("FileWillBeDownloaded") {
+		return hookUnavailableRejection
+	}
+
+	// See FileWillBeUploaded for why the replacement of a hook with a deadline is buffered.
+	replacement := output
+	var bufferedReplacement *bytes.Buffer
+	if g.guard.timeout("FileWillBeDownloaded") > 0 {
+		bufferedReplacement = &bytes.Buffer{}
+		replacement = bufferedReplacement
+	}
+
+	// The caller seeks the file back for the next plugin, so it mustn't be read anymore once the
+	// hook is given up on.
+	source := &stoppableReader{r: file}
+	downloadedFileStreamId := g.muxBroker.NextId()
+	go func() {
+		downloadedFileConnection, err := g.muxBroker.Accept(downloadedFileStreamId)
+		if err != nil {
+			g.log.Error("Plugin failed to serve download file stream. MuxBroker could not Accept connection", mlog.Err(err))
+			return
+		}
+		defer downloadedFileConnection.Close()
+		serveIOReader(source, downloadedFileConnection)
+	}()
+
+	replacementDone := make(chan bool)
+	replacementFileStreamId := g.muxBroker.NextId()
+	go func() {
+		defer close(replacementDone)
+
+		replacementFileConnection, err := g.muxBroker.Accept(replacementFileStreamId)
+		if err != nil {
+			g.log.Error("Plugin failed to serve replacement file stream. MuxBroker could not Accept connection", mlog.Err(err))
+			return
+		}
+		defer replacementFileConnection.Close()
+		if _, err := io.Copy(replacement, replacementFileConnection); err != nil {
+			g.log.Error("Error reading replacement file.", mlog.Err(err))
+		}
+	}()
+
+	_args := &Z_FileWillBeDownloadedArgs{c, info, userID, downloadType, downloadedFileStreamId, replacementFileStreamId}
+	_returns := &Z_FileWillBeDownloadedReturns{}
+	err := g.call("FileWillBeDownloaded", _args, _returns)
+	if err != nil {
+		g.log.Error("RPC call FileWillBeDownloaded to plugin failed.", mlog.Err(err))
+	}
+	if errors.Is(err, errHookTimeout) {
+		source.stop()
+		return hookUnavailableRejection
+	}
+
+	// Ensure the io.Copy from the replacementFileConnection above completes.
+	<-replacementDone
+
+	if bufferedReplacement != nil && bufferedReplacement.Len() > 0 {
+		if _, err := output.Write(bufferedReplacement.Bytes()); err != nil {
+			g.log.Error("Error writing replacement file.", mlog.Err(err))
+		}
+	}
+
+	return _returns.A
+}
+
+func (s *hooksRPCServer) FileWillBeDownloaded(args *Z_FileWillBeDownloadedArgs, returns *Z_FileWillBeDownloadedReturns) error {
+	downloadFileConnection, err := s.muxBroker.Dial(args.DownloadedFileStream)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] Can't connect to remote download file stream, error: %v", err.Error())
+		return err
+	}
+	defer downloadFileConnection.Close()
+	fileReader := connectIOReader(downloadFileConnection)
+	defer fileReader.Close()
+
+	replacementFileConnection, err := s.muxBroker.Dial(args.ReplacementFileStream)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "[ERROR] Can't connect to remote replacement file stream, error: %v", err.Error())
+		return err
+	}
+	defer replacementFileConnection.Close()
+
+	if hook, ok := s.impl.(interface {
+		FileWillBeDownloaded(c *Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string
+	}); ok {
+		returns.A = hook.FileWillBeDownloaded(args.A, args.B, args.C, args.D, fileReader, replacementFileConnection)
+	} else {
+		return fmt.Errorf("hook FileWillBeDownloaded called but not implemented")
+	}
+	return nil
+}
+
 // MessageWillBePosted is in this file because of the difficulty of identifying which fields need special behaviour.
 // The special behaviour needed is decoding the returned post into the original one to avoid the unintentional removal
 // of fields by older plugins.
@@ -640,8 +811,14 @@ func (g *hooksRPCClient) MessageWillBePosted(c *Context, post *model.Post) (*mod
 	_args := &Z_MessageWillBePostedArgs{c, post}
 	_returns := &Z_MessageWillBePostedReturns{A: _args.B}
 	if g.implemented[MessageWillBePostedID] {
-		if err := g.client.Call("Plugin.MessageWillBePosted", _args, _returns); err != nil {
+		if g.guard.skip("MessageWillBePosted") {
+			return nil, hookUnavailableRejection
+		}
+		if err := g.call("MessageWillBePosted", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageWillBePosted to plugin failed.", mlog.Err(err))
+			if errors.Is(err, errHookTimeout) {
+				return nil, hookUnavailableRejection
+			}
 		}
 	}
 	return _returns.A, _returns.B
@@ -680,9 +857,15 @@ func (g *hooksRPCClient) MessageWillBeUpdated(c *Context, newPost, oldPost *mode
 	_args := &Z_MessageWillBeUpdatedArgs{c, newPost, oldPost}
 	_default_returns := &Z_MessageWillBeUpdatedReturns{A: _args.B}
 	if g.implemented[MessageWillBeUpdatedID] {
+		if g.guard.skip("MessageWillBeUpdated") {
+			return nil, hookUnavailableRejection
+		}
 		_returns := &Z_MessageWillBeUpdatedReturns{}
-		if err := g.client.Call("Plugin.MessageWillBeUpdated", _args, _returns); err != nil {
+		if err := g.call("MessageWillBeUpdated", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageWillBeUpdated to plugin failed.", mlog.Err(err))
+			if errors.Is(err, errHookTimeout) {
+				return nil, hookUnavailableRejection
+			}
 			return _default_returns.A, _default_returns.B
 		}
 		return _returns.A, _returns.B
@@ -719,8 +902,8 @@ type Z_MessagesWillBeConsumedReturns struct {
 func (g *hooksRPCClient) MessagesWillBeConsumed(posts []*model.Post) []*model.Post {
 	_args := &Z_MessagesWillBeConsumedArgs{posts}
 	_returns := &Z_MessagesWillBeConsumedReturns{}
-	if g.implemented[MessagesWillBeConsumedID] {
-		if err := g.client.Call("Plugin.MessagesWillBeConsumed", _args, _returns); err != nil {
+	if g.implemented[MessagesWillBeConsumedID] && !g.guard.skip("MessagesWillBeConsumed") {
+		if err := g.call("MessagesWillBeConsumed", _args, _returns); err != nil {
 			g.log.Error("RPC call MessagesWillBeConsumed to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -883,6 +1066,11 @@ func (g *apiRPCClient) InstallPlugin(file io.Reader, replace bool) (*model.Manif
 }
 
 func (s *apiRPCServer) InstallPlugin(args *Z_InstallPluginArgs, returns *Z_InstallPluginReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("InstallPlugin"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
+
 	hook, ok := s.impl.(interface {
 		InstallPlugin(file io.Reader, replace bool) (*model.Manifest, *model.AppError)
 	})
@@ -935,6 +1123,11 @@ func (g *apiRPCClient) UploadData(us *model.UploadSession, rd io.Reader) (*model
 }
 
 func (s *apiRPCServer) UploadData(args *Z_UploadDataArgs, returns *Z_UploadDataReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UploadData"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
+
 	hook, ok := s.impl.(interface {
 		UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo, error)
 	})
@@ -970,6 +1163,10 @@ func (g *hooksRPCClient) ServeMetrics(c *Context, w http.ResponseWriter, r *http
 		http.NotFound(w, r)
 		return
 	}
+	if g.guard.skip("ServeMetrics") {
+		http.Error(w, "503 service unavailable", http.StatusServiceUnavailable)
+		return
+	}
 
 	serveMetricsStreamId := g.muxBroker.NextId()
 	go func() {
@@ -28,8 +28,8 @@ type Z_OnDeactivateReturns struct {
 func (g *hooksRPCClient) OnDeactivate() error {
 	_args := &Z_OnDeactivateArgs{}
 	_returns := &Z_OnDeactivateReturns{}
-	if g.implemented[OnDeactivateID] {
-		if err := g.client.Call("Plugin.OnDeactivate", _args, _returns); err != nil {
+	if g.implemented[OnDeactivateID] && !g.guard.skip("OnDeactivate") {
+		if err := g.call("OnDeactivate", _args, _returns); err != nil {
 			g.log.Error("RPC call OnDeactivate to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -62,8 +62,8 @@ type Z_OnConfigurationChangeReturns struct {
 func (g *hooksRPCClient) OnConfigurationChange() error {
 	_args := &Z_OnConfigurationChangeArgs{}
 	_returns := &Z_OnConfigurationChangeReturns{}
-	if g.implemented[OnConfigurationChangeID] {
-		if err := g.client.Call("Plugin.OnConfigurationChange", _args, _returns); err != nil {
+	if g.implemented[OnConfigurationChangeID] && !g.guard.skip("OnConfigurationChange") {
+		if err := g.call("OnConfigurationChange", _args, _returns); err != nil {
 			g.log.Error("RPC call OnConfigurationChange to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -99,8 +99,8 @@ type Z_ExecuteCommandReturns struct {
 func (g *hooksRPCClient) ExecuteCommand(c *Context, args *model.CommandArgs) (*model.CommandResponse, *model.AppError) {
 	_args := &Z_ExecuteCommandArgs{c, args}
 	_returns := &Z_ExecuteCommandReturns{}
-	if g.implemented[ExecuteCommandID] {
-		if err := g.client.Call("Plugin.ExecuteCommand", _args, _returns); err != nil {
+	if g.implemented[ExecuteCommandID] && !g.guard.skip("ExecuteCommand") {
+		if err := g.call("ExecuteCommand", _args, _returns); err != nil {
 			g.log.Error("RPC call ExecuteCommand to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -133,8 +133,8 @@ type Z_UserHasBeenCreatedReturns struct {
 func (g *hooksRPCClient) UserHasBeenCreated(c *Context, user *model.User) {
 	_args := &Z_UserHasBeenCreatedArgs{c, user}
 	_returns := &Z_UserHasBeenCreatedReturns{}
-	if g.implemented[UserHasBeenCreatedID] {
-		if err := g.client.Call("Plugin.UserHasBeenCreated", _args, _returns); err != nil {
+	if g.implemented[UserHasBeenCreatedID] && !g.guard.skip("UserHasBeenCreated") {
+		if err := g.call("UserHasBeenCreated", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasBeenCreated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -168,8 +168,8 @@ type Z_UserWillLogInReturns struct {
 func (g *hooksRPCClient) UserWillLogIn(c *Context, user *model.User) string {
 	_args := &Z_UserWillLogInArgs{c, user}
 	_returns := &Z_UserWillLogInReturns{}
-	if g.implemented[UserWillLogInID] {
-		if err := g.client.Call("Plugin.UserWillLogIn", _args, _returns); err != nil {
+	if g.implemented[UserWillLogInID] && !g.guard.skip("UserWillLogIn") {
+		if err := g.call("UserWillLogIn", _args, _returns); err != nil {
 			g.log.Error("RPC call UserWillLogIn to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -202,8 +202,8 @@ type Z_UserHasLoggedInReturns struct {
 func (g *hooksRPCClient) UserHasLoggedIn(c *Context, user *model.User) {
 	_args := &Z_UserHasLoggedInArgs{c, user}
 	_returns := &Z_UserHasLoggedInReturns{}
-	if g.implemented[UserHasLoggedInID] {
-		if err := g.client.Call("Plugin.UserHasLoggedIn", _args, _returns); err != nil {
+	if g.implemented[UserHasLoggedInID] && !g.guard.skip("UserHasLoggedIn") {
+		if err := g.call("UserHasLoggedIn", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLoggedIn to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -236,8 +236,8 @@ type Z_MessageHasBeenPostedReturns struct {
 func (g *hooksRPCClient) MessageHasBeenPosted(c *Context, post *model.Post) {
 	_args := &Z_MessageHasBeenPostedArgs{c, post}
 	_returns := &Z_MessageHasBeenPostedReturns{}
-	if g.implemented[MessageHasBeenPostedID] {
-		if err := g.client.Call("Plugin.MessageHasBeenPosted", _args, _returns); err != nil {
+	if g.implemented[MessageHasBeenPostedID] && !g.guard.skip("MessageHasBeenPosted") {
+		if err := g.call("MessageHasBeenPosted", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageHasBeenPosted to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -271,8 +271,8 @@ type Z_MessageHasBeenUpdatedReturns struct {
 func (g *hooksRPCClient) MessageHasBeenUpdated(c *Context, newPost, oldPost *model.Post) {
 	_args := &Z_MessageHasBeenUpdatedArgs{c, newPost, oldPost}
 	_returns := &Z_MessageHasBeenUpdatedReturns{}
-	if g.implemented[MessageHasBeenUpdatedID] {
-		if err := g.client.Call("Plugin.MessageHasBeenUpdated", _args, _returns); err != nil {
+	if g.implemented[MessageHasBeenUpdatedID] && !g.guard.skip("MessageHasBeenUpdated") {
+		if err := g.call("MessageHasBeenUpdated", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageHasBeenUpdated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -305,8 +305,8 @@ type Z_MessageHasBeenDeletedReturns struct {
 func (g *hooksRPCClient) MessageHasBeenDeleted(c *Context, post *model.Post) {
 	_args := &Z_MessageHasBeenDeletedArgs{c, post}
 	_returns := &Z_MessageHasBeenDeletedReturns{}
-	if g.implemented[MessageHasBeenDeletedID] {
-		if err := g.client.Call("Plugin.MessageHasBeenDeleted", _args, _returns); err != nil {
+	if g.implemented[MessageHasBeenDeletedID] && !g.guard.skip("MessageHasBeenDeleted") {
+		if err := g.call("MessageHasBeenDeleted", _args, _returns); err != nil {
 			g.log.Error("RPC call MessageHasBeenDeleted to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -339,8 +339,8 @@ type Z_ChannelHasBeenCreatedReturns struct {
 func (g *hooksRPCClient) ChannelHasBeenCreated(c *Context, channel *model.Channel) {
 	_args := &Z_ChannelHasBeenCreatedArgs{c, channel}
 	_returns := &Z_ChannelHasBeenCreatedReturns{}
-	if g.implemented[ChannelHasBeenCreatedID] {
-		if err := g.client.Call("Plugin.ChannelHasBeenCreated", _args, _returns); err != nil {
+	if g.implemented[ChannelHasBeenCreatedID] && !g.guard.skip("ChannelHasBeenCreated") {
+		if err := g.call("ChannelHasBeenCreated", _args, _returns); err != nil {
 			g.log.Error("RPC call ChannelHasBeenCreated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -374,8 +374,8 @@ type Z_UserHasJoinedChannelReturns struct {
 func (g *hooksRPCClient) UserHasJoinedChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
 	_args := &Z_UserHasJoinedChannelArgs{c, channelMember, actor}
 	_returns := &Z_UserHasJoinedChannelReturns{}
-	if g.implemented[UserHasJoinedChannelID] {
-		if err := g.client.Call("Plugin.UserHasJoinedChannel", _args, _returns); err != nil {
+	if g.implemented[UserHasJoinedChannelID] && !g.guard.skip("UserHasJoinedChannel") {
+		if err := g.call("UserHasJoinedChannel", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasJoinedChannel to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -409,8 +409,8 @@ type Z_UserHasLeftChannelReturns struct {
 func (g *hooksRPCClient) UserHasLeftChannel(c *Context, channelMember *model.ChannelMember, actor *model.User) {
 	_args := &Z_UserHasLeftChannelArgs{c, channelMember, actor}
 	_returns := &Z_UserHasLeftChannelReturns{}
-	if g.implemented[UserHasLeftChannelID] {
-		if err := g.client.Call("Plugin.UserHasLeftChannel", _args, _returns); err != nil {
+	if g.implemented[UserHasLeftChannelID] && !g.guard.skip("UserHasLeftChannel") {
+		if err := g.call("UserHasLeftChannel", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLeftChannel to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -444,8 +444,8 @@ type Z_UserHasJoinedTeamReturns struct {
 func (g *hooksRPCClient) UserHasJoinedTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
 	_args := &Z_UserHasJoinedTeamArgs{c, teamMember, actor}
 	_returns := &Z_UserHasJoinedTeamReturns{}
-	if g.implemented[UserHasJoinedTeamID] {
-		if err := g.client.Call("Plugin.UserHasJoinedTeam", _args, _returns); err != nil {
+	if g.implemented[UserHasJoinedTeamID] && !g.guard.skip("UserHasJoinedTeam") {
+		if err := g.call("UserHasJoinedTeam", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasJoinedTeam to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -479,8 +479,8 @@ type Z_UserHasLeftTeamReturns struct {
 func (g *hooksRPCClient) UserHasLeftTeam(c *Context, teamMember *model.TeamMember, actor *model.User) {
 	_args := &Z_UserHasLeftTeamArgs{c, teamMember, actor}
 	_returns := &Z_UserHasLeftTeamReturns{}
-	if g.implemented[UserHasLeftTeamID] {
-		if err := g.client.Call("Plugin.UserHasLeftTeam", _args, _returns); err != nil {
+	if g.implemented[UserHasLeftTeamID] && !g.guard.skip("UserHasLeftTeam") {
+		if err := g.call("UserHasLeftTeam", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasLeftTeam to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -513,8 +513,8 @@ type Z_ReactionHasBeenAddedReturns struct {
 func (g *hooksRPCClient) ReactionHasBeenAdded(c *Context, reaction *model.Reaction) {
 	_args := &Z_ReactionHasBeenAddedArgs{c, reaction}
 	_returns := &Z_ReactionHasBeenAddedReturns{}
-	if g.implemented[ReactionHasBeenAddedID] {
-		if err := g.client.Call("Plugin.ReactionHasBeenAdded", _args, _returns); err != nil {
+	if g.implemented[ReactionHasBeenAddedID] && !g.guard.skip("ReactionHasBeenAdded") {
+		if err := g.call("ReactionHasBeenAdded", _args, _returns); err != nil {
 			g.log.Error("RPC call ReactionHasBeenAdded to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -547,8 +547,8 @@ type Z_ReactionHasBeenRemovedReturns struct {
 func (g *hooksRPCClient) ReactionHasBeenRemoved(c *Context, reaction *model.Reaction) {
 	_args := &Z_ReactionHasBeenRemovedArgs{c, reaction}
 	_returns := &Z_ReactionHasBeenRemovedReturns{}
-	if g.implemented[ReactionHasBeenRemovedID] {
-		if err := g.client.Call("Plugin.ReactionHasBeenRemoved", _args, _returns); err != nil {
+	if g.implemented[ReactionHasBeenRemovedID] && !g.guard.skip("ReactionHasBeenRemoved") {
+		if err := g.call("ReactionHasBeenRemoved", _args, _returns); err != nil {
 			g.log.Error("RPC call ReactionHasBeenRemoved to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -581,8 +581,8 @@ type Z_OnPluginClusterEventReturns struct {
 func (g *hooksRPCClient) OnPluginClusterEvent(c *Context, ev model.PluginClusterEvent) {
 	_args := &Z_OnPluginClusterEventArgs{c, ev}
 	_returns := &Z_OnPluginClusterEventReturns{}
-	if g.implemented[OnPluginClusterEventID] {
-		if err := g.client.Call("Plugin.OnPluginClusterEvent", _args, _returns); err != nil {
+	if g.implemented[OnPluginClusterEventID] && !g.guard.skip("OnPluginClusterEvent") {
+		if err := g.call("OnPluginClusterEvent", _args, _returns); err != nil {
 			g.log.Error("RPC call OnPluginClusterEvent to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -615,8 +615,8 @@ type Z_OnWebSocketConnectReturns struct {
 func (g *hooksRPCClient) OnWebSocketConnect(webConnID, userID string) {
 	_args := &Z_OnWebSocketConnectArgs{webConnID, userID}
 	_returns := &Z_OnWebSocketConnectReturns{}
-	if g.implemented[OnWebSocketConnectID] {
-		if err := g.client.Call("Plugin.OnWebSocketConnect", _args, _returns); err != nil {
+	if g.implemented[OnWebSocketConnectID] && !g.guard.skip("OnWebSocketConnect") {
+		if err := g.call("OnWebSocketConnect", _args, _returns); err != nil {
 			g.log.Error("RPC call OnWebSocketConnect to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -649,8 +649,8 @@ type Z_OnWebSocketDisconnectReturns struct {
 func (g *hooksRPCClient) OnWebSocketDisconnect(webConnID, userID string) {
 	_args := &Z_OnWebSocketDisconnectArgs{webConnID, userID}
 	_returns := &Z_OnWebSocketDisconnectReturns{}
-	if g.implemented[OnWebSocketDisconnectID] {
-		if err := g.client.Call("Plugin.OnWebSocketDisconnect", _args, _returns); err != nil {
+	if g.implemented[OnWebSocketDisconnectID] && !g.guard.skip("OnWebSocketDisconnect") {
+		if err := g.call("OnWebSocketDisconnect", _args, _returns); err != nil {
 			g.log.Error("RPC call OnWebSocketDisconnect to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -684,8 +684,8 @@ type Z_WebSocketMessageHasBeenPostedReturns struct {
 func (g *hooksRPCClient) WebSocketMessageHasBeenPosted(webConnID, userID string, req *model.WebSocketRequest) {
 	_args := &Z_WebSocketMessageHasBeenPostedArgs{webConnID, userID, req}
 	_returns := &Z_WebSocketMessageHasBeenPostedReturns{}
-	if g.implemented[WebSocketMessageHasBeenPostedID] {
-		if err := g.client.Call("Plugin.WebSocketMessageHasBeenPosted", _args, _returns); err != nil {
+	if g.implemented[WebSocketMessageHasBeenPostedID] && !g.guard.skip("WebSocketMessageHasBeenPosted") {
+		if err := g.call("WebSocketMessageHasBeenPosted", _args, _returns); err != nil {
 			g.log.Error("RPC call WebSocketMessageHasBeenPosted to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -720,8 +720,8 @@ type Z_RunDataRetentionReturns struct {
 func (g *hooksRPCClient) RunDataRetention(nowTime, batchSize int64) (int64, error) {
 	_args := &Z_RunDataRetentionArgs{nowTime, batchSize}
 	_returns := &Z_RunDataRetentionReturns{}
-	if g.implemented[RunDataRetentionID] {
-		if err := g.client.Call("Plugin.RunDataRetention", _args, _returns); err != nil {
+	if g.implemented[RunDataRetentionID] && !g.guard.skip("RunDataRetention") {
+		if err := g.call("RunDataRetention", _args, _returns); err != nil {
 			g.log.Error("RPC call RunDataRetention to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -756,8 +756,8 @@ type Z_OnInstallReturns struct {
 func (g *hooksRPCClient) OnInstall(c *Context, event model.OnInstallEvent) error {
 	_args := &Z_OnInstallArgs{c, event}
 	_returns := &Z_OnInstallReturns{}
-	if g.implemented[OnInstallID] {
-		if err := g.client.Call("Plugin.OnInstall", _args, _returns); err != nil {
+	if g.implemented[OnInstallID] && !g.guard.skip("OnInstall") {
+		if err := g.call("OnInstall", _args, _returns); err != nil {
 			g.log.Error("RPC call OnInstall to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -789,8 +789,8 @@ type Z_OnSendDailyTelemetryReturns struct {
 func (g *hooksRPCClient) OnSendDailyTelemetry() {
 	_args := &Z_OnSendDailyTelemetryArgs{}
 	_returns := &Z_OnSendDailyTelemetryReturns{}
-	if g.implemented[OnSendDailyTelemetryID] {
-		if err := g.client.Call("Plugin.OnSendDailyTelemetry", _args, _returns); err != nil {
+	if g.implemented[OnSendDailyTelemetryID] && !g.guard.skip("OnSendDailyTelemetry") {
+		if err := g.call("OnSendDailyTelemetry", _args, _returns); err != nil {
 			g.log.Error("RPC call OnSendDailyTelemetry to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -822,8 +822,8 @@ type Z_OnCloudLimitsUpdatedReturns struct {
 func (g *hooksRPCClient) OnCloudLimitsUpdated(limits *model.ProductLimits) {
 	_args := &Z_OnCloudLimitsUpdatedArgs{limits}
 	_returns := &Z_OnCloudLimitsUpdatedReturns{}
-	if g.implemented[OnCloudLimitsUpdatedID] {
-		if err := g.client.Call("Plugin.OnCloudLimitsUpdated", _args, _returns); err != nil {
+	if g.implemented[OnCloudLimitsUpdatedID] && !g.guard.skip("OnCloudLimitsUpdated") {
+		if err := g.call("OnCloudLimitsUpdated", _args, _returns); err != nil {
 			g.log.Error("RPC call OnCloudLimitsUpdated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -857,8 +857,8 @@ type Z_ConfigurationWillBeSavedReturns struct {
 func (g *hooksRPCClient) ConfigurationWillBeSaved(newCfg *model.Config) (*model.Config, error) {
 	_args := &Z_ConfigurationWillBeSavedArgs{newCfg}
 	_returns := &Z_ConfigurationWillBeSavedReturns{}
-	if g.implemented[ConfigurationWillBeSavedID] {
-		if err := g.client.Call("Plugin.ConfigurationWillBeSaved", _args, _returns); err != nil {
+	if g.implemented[ConfigurationWillBeSavedID] && !g.guard.skip("ConfigurationWillBeSaved") {
+		if err := g.call("ConfigurationWillBeSaved", _args, _returns); err != nil {
 			g.log.Error("RPC call ConfigurationWillBeSaved to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -894,8 +894,8 @@ type Z_NotificationWillBePushedReturns struct {
 func (g *hooksRPCClient) NotificationWillBePushed(pushNotification *model.PushNotification, userID string) (*model.PushNotification, string) {
 	_args := &Z_NotificationWillBePushedArgs{pushNotification, userID}
 	_returns := &Z_NotificationWillBePushedReturns{}
-	if g.implemented[NotificationWillBePushedID] {
-		if err := g.client.Call("Plugin.NotificationWillBePushed", _args, _returns); err != nil {
+	if g.implemented[NotificationWillBePushedID] && !g.guard.skip("NotificationWillBePushed") {
+		if err := g.call("NotificationWillBePushed", _args, _returns); err != nil {
 			g.log.Error("RPC call NotificationWillBePushed to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -928,8 +928,8 @@ type Z_UserHasBeenDeactivatedReturns struct {
 func (g *hooksRPCClient) UserHasBeenDeactivated(c *Context, user *model.User) {
 	_args := &Z_UserHasBeenDeactivatedArgs{c, user}
 	_returns := &Z_UserHasBeenDeactivatedReturns{}
-	if g.implemented[UserHasBeenDeactivatedID] {
-		if err := g.client.Call("Plugin.UserHasBeenDeactivated", _args, _returns); err != nil {
+	if g.implemented[UserHasBeenDeactivatedID] && !g.guard.skip("UserHasBeenDeactivated") {
+		if err := g.call("UserHasBeenDeactivated", _args, _returns); err != nil {
 			g.log.Error("RPC call UserHasBeenDeactivated to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -964,8 +964,8 @@ type Z_OnSharedChannelsSyncMsgReturns struct {
 func (g *hooksRPCClient) OnSharedChannelsSyncMsg(msg *model.SyncMsg, rc *model.RemoteCluster) (model.SyncResponse, error) {
 	_args := &Z_OnSharedChannelsSyncMsgArgs{msg, rc}
 	_returns := &Z_OnSharedChannelsSyncMsgReturns{}
-	if g.implemented[OnSharedChannelsSyncMsgID] {
-		if err := g.client.Call("Plugin.OnSharedChannelsSyncMsg", _args, _returns); err != nil {
+	if g.implemented[OnSharedChannelsSyncMsgID] && !g.guard.skip("OnSharedChannelsSyncMsg") {
+		if err := g.call("OnSharedChannelsSyncMsg", _args, _returns); err != nil {
 			g.log.Error("RPC call OnSharedChannelsSyncMsg to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -999,8 +999,8 @@ type Z_OnSharedChannelsPingReturns struct {
 func (g *hooksRPCClient) OnSharedChannelsPing(rc *model.RemoteCluster) bool {
 	_args := &Z_OnSharedChannelsPingArgs{rc}
 	_returns := &Z_OnSharedChannelsPingReturns{}
-	if g.implemented[OnSharedChannelsPingID] {
-		if err := g.client.Call("Plugin.OnSharedChannelsPing", _args, _returns); err != nil {
+	if g.implemented[OnSharedChannelsPingID] && !g.guard.skip("OnSharedChannelsPing") {
+		if err := g.call("OnSharedChannelsPing", _args, _returns); err != nil {
 			g.log.Error("RPC call OnSharedChannelsPing to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -1033,8 +1033,8 @@ type Z_PreferencesHaveChangedReturns struct {
 func (g *hooksRPCClient) PreferencesHaveChanged(c *Context, preferences []model.Preference) {
 	_args := &Z_PreferencesHaveChangedArgs{c, preferences}
 	_returns := &Z_PreferencesHaveChangedReturns{}
-	if g.implemented[PreferencesHaveChangedID] {
-		if err := g.client.Call("Plugin.PreferencesHaveChanged", _args, _returns); err != nil {
+	if g.implemented[PreferencesHaveChangedID] && !g.guard.skip("PreferencesHaveChanged") {
+		if err := g.call("PreferencesHaveChanged", _args, _returns); err != nil {
 			g.log.Error("RPC call PreferencesHaveChanged to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -1069,8 +1069,8 @@ type Z_OnSharedChannelsAttachmentSyncMsgReturns struct {
 func (g *hooksRPCClient) OnSharedChannelsAttachmentSyncMsg(fi *model.FileInfo, post *model.Post, rc *model.RemoteCluster) error {
 	_args := &Z_OnSharedChannelsAttachmentSyncMsgArgs{fi, post, rc}
 	_returns := &Z_OnSharedChannelsAttachmentSyncMsgReturns{}
-	if g.implemented[OnSharedChannelsAttachmentSyncMsgID] {
-		if err := g.client.Call("Plugin.OnSharedChannelsAttachmentSyncMsg", _args, _returns); err != nil {
+	if g.implemented[OnSharedChannelsAttachmentSyncMsgID] && !g.guard.skip("OnSharedChannelsAttachmentSyncMsg") {
+		if err := g.call("OnSharedChannelsAttachmentSyncMsg", _args, _returns); err != nil {
 			g.log.Error("RPC call OnSharedChannelsAttachmentSyncMsg to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -1105,8 +1105,8 @@ type Z_OnSharedChannelsProfileImageSyncMsgReturns struct {
 func (g *hooksRPCClient) OnSharedChannelsProfileImageSyncMsg(user *model.User, rc *model.RemoteCluster) error {
 	_args := &Z_OnSharedChannelsProfileImageSyncMsgArgs{user, rc}
 	_returns := &Z_OnSharedChannelsProfileImageSyncMsgReturns{}
-	if g.implemented[OnSharedChannelsProfileImageSyncMsgID] {
-		if err := g.client.Call("Plugin.OnSharedChannelsProfileImageSyncMsg", _args, _returns); err != nil {
+	if g.implemented[OnSharedChannelsProfileImageSyncMsgID] && !g.guard.skip("OnSharedChannelsProfileImageSyncMsg") {
+		if err := g.call("OnSharedChannelsProfileImageSyncMsg", _args, _returns); err != nil {
 			g.log.Error("RPC call OnSharedChannelsProfileImageSyncMsg to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -1141,8 +1141,8 @@ type Z_GenerateSupportDataReturns struct {
 func (g *hooksRPCClient) GenerateSupportData(c *Context) ([]*model.FileData, error) {
 	_args := &Z_GenerateSupportDataArgs{c}
 	_returns := &Z_GenerateSupportDataReturns{}
-	if g.implemented[GenerateSupportDataID] {
-		if err := g.client.Call("Plugin.GenerateSupportData", _args, _returns); err != nil {
+	if g.implemented[GenerateSupportDataID] && !g.guard.skip("GenerateSupportData") {
+		if err := g.call("GenerateSupportData", _args, _returns); err != nil {
 			g.log.Error("RPC call GenerateSupportData to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -1161,6 +1161,217 @@ func (s *hooksRPCServer) GenerateSupportData(args *Z_GenerateSupportDataArgs, re
 	return nil
 }
 
+func init() {
+	hookNameToId["OnPluginEvent"] = OnPluginEventID
+}
+
+type Z_OnPluginEventArgs struct {
+	A *Context
+	B model.PluginEvent
+}
+
+type Z_OnPluginEventReturns struct {
+}
+
+func (g *hooksRPCClient) OnPluginEvent(c *Context, event model.PluginEvent) {
+	_args := &Z_OnPluginEventArgs{c, event}
+	_returns := &Z_OnPluginEventReturns{}
+	if g.implemented[OnPluginEventID] && !g.guard.skip("OnPluginEvent") {
+		if err := g.call("OnPluginEvent", _args, _returns); err != nil {
+			g.log.Error("RPC call OnPluginEvent to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) OnPluginEvent(args *Z_OnPluginEventArgs, returns *Z_OnPluginEventReturns) error {
+	if hook, ok := s.impl.(interface {
+		OnPluginEvent(c *Context, event model.PluginEvent)
+	}); ok {
+		hook.OnPluginEvent(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook OnPluginEvent called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SearchResultsWillBeReturned"] = SearchResultsWillBeReturnedID
+}
+
+type Z_SearchResultsWillBeReturnedArgs struct {
+	A *Context
+	B string
+	C *model.PostList
+	D *model.FileInfoList
+}
+
+type Z_SearchResultsWillBeReturnedReturns struct {
+	A *model.PostList
+	B *model.FileInfoList
+}
+
+func (g *hooksRPCClient) SearchResultsWillBeReturned(c *Context, userID string, posts *model.PostList, files *model.FileInfoList) (*model.PostList, *model.FileInfoList) {
+	_args := &Z_SearchResultsWillBeReturnedArgs{c, userID, posts, files}
+	_returns := &Z_SearchResultsWillBeReturnedReturns{}
+	if g.implemented[SearchResultsWillBeReturnedID] && !g.guard.skip("SearchResultsWillBeReturned") {
+		if err := g.call("SearchResultsWillBeReturned", _args, _returns); err != nil {
+			g.log.Error("RPC call SearchResultsWillBeReturned to plugin failed.", mlog.Err(err))
+		}
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *hooksRPCServer) SearchResultsWillBeReturned(args *Z_SearchResultsWillBeReturnedArgs, returns *Z_SearchResultsWillBeReturnedReturns) error {
+	if hook, ok := s.impl.(interface {
+		SearchResultsWillBeReturned(c *Context, userID string, posts *model.PostList, files *model.FileInfoList) (*model.PostList, *model.FileInfoList)
+	}); ok {
+		returns.A, returns.B = hook.SearchResultsWillBeReturned(args.A, args.B, args.C, args.D)
+	} else {
+		return encodableError(fmt.Errorf("Hook SearchResultsWillBeReturned called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserHasBeenUpdated"] = UserHasBeenUpdatedID
+}
+
+type Z_UserHasBeenUpdatedArgs struct {
+	A *Context
+	B *model.User
+	C *model.User
+}
+
+type Z_UserHasBeenUpdatedReturns struct {
+}
+
+func (g *hooksRPCClient) UserHasBeenUpdated(c *Context, newUser, oldUser *model.User) {
+	_args := &Z_UserHasBeenUpdatedArgs{c, newUser, oldUser}
+	_returns := &Z_UserHasBeenUpdatedReturns{}
+	if g.implemented[UserHasBeenUpdatedID] && !g.guard.skip("UserHasBeenUpdated") {
+		if err := g.call("UserHasBeenUpdated", _args, _returns); err != nil {
+			g.log.Error("RPC call UserHasBeenUpdated to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) UserHasBeenUpdated(args *Z_UserHasBeenUpdatedArgs, returns *Z_UserHasBeenUpdatedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserHasBeenUpdated(c *Context, newUser, oldUser *model.User)
+	}); ok {
+		hook.UserHasBeenUpdated(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserHasBeenUpdated called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserRolesHaveChanged"] = UserRolesHaveChangedID
+}
+
+type Z_UserRolesHaveChangedArgs struct {
+	A *Context
+	B *model.User
+	C string
+	D string
+}
+
+type Z_UserRolesHaveChangedReturns struct {
+}
+
+func (g *hooksRPCClient) UserRolesHaveChanged(c *Context, user *model.User, oldRoles, newRoles string) {
+	_args := &Z_UserRolesHaveChangedArgs{c, user, oldRoles, newRoles}
+	_returns := &Z_UserRolesHaveChangedReturns{}
+	if g.implemented[UserRolesHaveChangedID] && !g.guard.skip("UserRolesHaveChanged") {
+		if err := g.call("UserRolesHaveChanged", _args, _returns); err != nil {
+			g.log.Error("RPC call UserRolesHaveChanged to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) UserRolesHaveChanged(args *Z_UserRolesHaveChangedArgs, returns *Z_UserRolesHaveChangedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserRolesHaveChanged(c *Context, user *model.User, oldRoles, newRoles string)
+	}); ok {
+		hook.UserRolesHaveChanged(args.A, args.B, args.C, args.D)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserRolesHaveChanged called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["UserPasswordHasChanged"] = UserPasswordHasChangedID
+}
+
+type Z_UserPasswordHasChangedArgs struct {
+	A *Context
+	B *model.User
+}
+
+type Z_UserPasswordHasChangedReturns struct {
+}
+
+func (g *hooksRPCClient) UserPasswordHasChanged(c *Context, user *model.User) {
+	_args := &Z_UserPasswordHasChangedArgs{c, user}
+	_returns := &Z_UserPasswordHasChangedReturns{}
+	if g.implemented[UserPasswordHasChangedID] && !g.guard.skip("UserPasswordHasChanged") {
+		if err := g.call("UserPasswordHasChanged", _args, _returns); err != nil {
+			g.log.Error("RPC call UserPasswordHasChanged to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) UserPasswordHasChanged(args *Z_UserPasswordHasChangedArgs, returns *Z_UserPasswordHasChangedReturns) error {
+	if hook, ok := s.impl.(interface {
+		UserPasswordHasChanged(c *Context, user *model.User)
+	}); ok {
+		hook.UserPasswordHasChanged(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook UserPasswordHasChanged called but not implemented."))
+	}
+	return nil
+}
+
+func init() {
+	hookNameToId["SessionHasBeenRevoked"] = SessionHasBeenRevokedID
+}
+
+type Z_SessionHasBeenRevokedArgs struct {
+	A *Context
+	B *model.Session
+}
+
+type Z_SessionHasBeenRevokedReturns struct {
+}
+
+func (g *hooksRPCClient) SessionHasBeenRevoked(c *Context, session *model.Session) {
+	_args := &Z_SessionHasBeenRevokedArgs{c, session}
+	_returns := &Z_SessionHasBeenRevokedReturns{}
+	if g.implemented[SessionHasBeenRevokedID] && !g.guard.skip("SessionHasBeenRevoked") {
+		if err := g.call("SessionHasBeenRevoked", _args, _returns); err != nil {
+			g.log.Error("RPC call SessionHasBeenRevoked to plugin failed.", mlog.Err(err))
+		}
+	}
+
+}
+
+func (s *hooksRPCServer) SessionHasBeenRevoked(args *Z_SessionHasBeenRevokedArgs, returns *Z_SessionHasBeenRevokedReturns) error {
+	if hook, ok := s.impl.(interface {
+		SessionHasBeenRevoked(c *Context, session *model.Session)
+	}); ok {
+		hook.SessionHasBeenRevoked(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("Hook SessionHasBeenRevoked called but not implemented."))
+	}
+	return nil
+}
+
 type Z_RegisterCommandArgs struct {
 	A *model.Command
 }
@@ -1179,6 +1390,10 @@ func (g *apiRPCClient) RegisterCommand(command *model.Command) error {
 }
 
 func (s *apiRPCServer) RegisterCommand(args *Z_RegisterCommandArgs, returns *Z_RegisterCommandReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RegisterCommand"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RegisterCommand(command *model.Command) error
 	}); ok {
@@ -1209,6 +1424,10 @@ func (g *apiRPCClient) UnregisterCommand(teamID, trigger string) error {
 }
 
 func (s *apiRPCServer) UnregisterCommand(args *Z_UnregisterCommandArgs, returns *Z_UnregisterCommandReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UnregisterCommand"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UnregisterCommand(teamID, trigger string) error
 	}); ok {
@@ -1239,6 +1458,10 @@ func (g *apiRPCClient) ExecuteSlashCommand(commandArgs *model.CommandArgs) (*mod
 }
 
 func (s *apiRPCServer) ExecuteSlashCommand(args *Z_ExecuteSlashCommandArgs, returns *Z_ExecuteSlashCommandReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("ExecuteSlashCommand"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ExecuteSlashCommand(commandArgs *model.CommandArgs) (*model.CommandResponse, error)
 	}); ok {
@@ -1267,6 +1490,9 @@ func (g *apiRPCClient) GetConfig() *model.Config {
 }
 
 func (s *apiRPCServer) GetConfig(args *Z_GetConfigArgs, returns *Z_GetConfigReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetConfig"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetConfig() *model.Config
 	}); ok {
@@ -1294,6 +1520,9 @@ func (g *apiRPCClient) GetUnsanitizedConfig() *model.Config {
 }
 
 func (s *apiRPCServer) GetUnsanitizedConfig(args *Z_GetUnsanitizedConfigArgs, returns *Z_GetUnsanitizedConfigReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUnsanitizedConfig"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetUnsanitizedConfig() *model.Config
 	}); ok {
@@ -1322,6 +1551,10 @@ func (g *apiRPCClient) SaveConfig(config *model.Config) *model.AppError {
 }
 
 func (s *apiRPCServer) SaveConfig(args *Z_SaveConfigArgs, returns *Z_SaveConfigReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SaveConfig"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SaveConfig(config *model.Config) *model.AppError
 	}); ok {
@@ -1349,6 +1582,9 @@ func (g *apiRPCClient) GetPluginConfig() map[string]any {
 }
 
 func (s *apiRPCServer) GetPluginConfig(args *Z_GetPluginConfigArgs, returns *Z_GetPluginConfigReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPluginConfig"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetPluginConfig() map[string]any
 	}); ok {
@@ -1377,6 +1613,10 @@ func (g *apiRPCClient) SavePluginConfig(config map[string]any) *model.AppError {
 }
 
 func (s *apiRPCServer) SavePluginConfig(args *Z_SavePluginConfigArgs, returns *Z_SavePluginConfigReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SavePluginConfig"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SavePluginConfig(config map[string]any) *model.AppError
 	}); ok {
@@ -1405,6 +1645,10 @@ func (g *apiRPCClient) GetBundlePath() (string, error) {
 }
 
 func (s *apiRPCServer) GetBundlePath(args *Z_GetBundlePathArgs, returns *Z_GetBundlePathReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetBundlePath"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetBundlePath() (string, error)
 	}); ok {
@@ -1433,6 +1677,9 @@ func (g *apiRPCClient) GetLicense() *model.License {
 }
 
 func (s *apiRPCServer) GetLicense(args *Z_GetLicenseArgs, returns *Z_GetLicenseReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetLicense"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetLicense() *model.License
 	}); ok {
@@ -1460,6 +1707,9 @@ func (g *apiRPCClient) IsEnterpriseReady() bool {
 }
 
 func (s *apiRPCServer) IsEnterpriseReady(args *Z_IsEnterpriseReadyArgs, returns *Z_IsEnterpriseReadyReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("IsEnterpriseReady"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		IsEnterpriseReady() bool
 	}); ok {
@@ -1487,6 +1737,9 @@ func (g *apiRPCClient) GetServerVersion() string {
 }
 
 func (s *apiRPCServer) GetServerVersion(args *Z_GetServerVersionArgs, returns *Z_GetServerVersionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetServerVersion"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetServerVersion() string
 	}); ok {
@@ -1515,6 +1768,10 @@ func (g *apiRPCClient) GetSystemInstallDate() (int64, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetSystemInstallDate(args *Z_GetSystemInstallDateArgs, returns *Z_GetSystemInstallDateReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetSystemInstallDate"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetSystemInstallDate() (int64, *model.AppError)
 	}); ok {
@@ -1542,6 +1799,9 @@ func (g *apiRPCClient) GetDiagnosticId() string {
 }
 
 func (s *apiRPCServer) GetDiagnosticId(args *Z_GetDiagnosticIdArgs, returns *Z_GetDiagnosticIdReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetDiagnosticId"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetDiagnosticId() string
 	}); ok {
@@ -1569,6 +1829,9 @@ func (g *apiRPCClient) GetTelemetryId() string {
 }
 
 func (s *apiRPCServer) GetTelemetryId(args *Z_GetTelemetryIdArgs, returns *Z_GetTelemetryIdReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTelemetryId"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetTelemetryId() string
 	}); ok {
@@ -1598,6 +1861,10 @@ func (g *apiRPCClient) CreateUser(user *model.User) (*model.User, *model.AppErro
 }
 
 func (s *apiRPCServer) CreateUser(args *Z_CreateUserArgs, returns *Z_CreateUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateUser(user *model.User) (*model.User, *model.AppError)
 	}); ok {
@@ -1626,6 +1893,10 @@ func (g *apiRPCClient) DeleteUser(userID string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteUser(args *Z_DeleteUserArgs, returns *Z_DeleteUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeleteUser"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteUser(userID string) *model.AppError
 	}); ok {
@@ -1655,6 +1926,10 @@ func (g *apiRPCClient) GetUsers(options *model.UserGetOptions) ([]*model.User, *
 }
 
 func (s *apiRPCServer) GetUsers(args *Z_GetUsersArgs, returns *Z_GetUsersReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUsers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsers(options *model.UserGetOptions) ([]*model.User, *model.AppError)
 	}); ok {
@@ -1684,6 +1959,10 @@ func (g *apiRPCClient) GetUsersByIds(userIDs []string) ([]*model.User, *model.Ap
 }
 
 func (s *apiRPCServer) GetUsersByIds(args *Z_GetUsersByIdsArgs, returns *Z_GetUsersByIdsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUsersByIds"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsersByIds(userIDs []string) ([]*model.User, *model.AppError)
 	}); ok {
@@ -1713,6 +1992,10 @@ func (g *apiRPCClient) GetUser(userID string) (*model.User, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetUser(args *Z_GetUserArgs, returns *Z_GetUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUser(userID string) (*model.User, *model.AppError)
 	}); ok {
@@ -1742,6 +2025,10 @@ func (g *apiRPCClient) GetUserByEmail(email string) (*model.User, *model.AppErro
 }
 
 func (s *apiRPCServer) GetUserByEmail(args *Z_GetUserByEmailArgs, returns *Z_GetUserByEmailReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUserByEmail"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUserByEmail(email string) (*model.User, *model.AppError)
 	}); ok {
@@ -1771,6 +2058,10 @@ func (g *apiRPCClient) GetUserByUsername(name string) (*model.User, *model.AppEr
 }
 
 func (s *apiRPCServer) GetUserByUsername(args *Z_GetUserByUsernameArgs, returns *Z_GetUserByUsernameReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUserByUsername"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUserByUsername(name string) (*model.User, *model.AppError)
 	}); ok {
@@ -1800,6 +2091,10 @@ func (g *apiRPCClient) GetUsersByUsernames(usernames []string) ([]*model.User, *
 }
 
 func (s *apiRPCServer) GetUsersByUsernames(args *Z_GetUsersByUsernamesArgs, returns *Z_GetUsersByUsernamesReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUsersByUsernames"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsersByUsernames(usernames []string) ([]*model.User, *model.AppError)
 	}); ok {
@@ -1831,6 +2126,10 @@ func (g *apiRPCClient) GetUsersInTeam(teamID string, page int, perPage int) ([]*
 }
 
 func (s *apiRPCServer) GetUsersInTeam(args *Z_GetUsersInTeamArgs, returns *Z_GetUsersInTeamReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUsersInTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsersInTeam(teamID string, page int, perPage int) ([]*model.User, *model.AppError)
 	}); ok {
@@ -1862,6 +2161,10 @@ func (g *apiRPCClient) GetPreferenceForUser(userID, category, name string) (mode
 }
 
 func (s *apiRPCServer) GetPreferenceForUser(args *Z_GetPreferenceForUserArgs, returns *Z_GetPreferenceForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPreferenceForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPreferenceForUser(userID, category, name string) (model.Preference, *model.AppError)
 	}); ok {
@@ -1891,6 +2194,10 @@ func (g *apiRPCClient) GetPreferencesForUser(userID string) ([]model.Preference,
 }
 
 func (s *apiRPCServer) GetPreferencesForUser(args *Z_GetPreferencesForUserArgs, returns *Z_GetPreferencesForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPreferencesForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPreferencesForUser(userID string) ([]model.Preference, *model.AppError)
 	}); ok {
@@ -1920,6 +2227,10 @@ func (g *apiRPCClient) UpdatePreferencesForUser(userID string, preferences []mod
 }
 
 func (s *apiRPCServer) UpdatePreferencesForUser(args *Z_UpdatePreferencesForUserArgs, returns *Z_UpdatePreferencesForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdatePreferencesForUser"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdatePreferencesForUser(userID string, preferences []model.Preference) *model.AppError
 	}); ok {
@@ -1949,6 +2260,10 @@ func (g *apiRPCClient) DeletePreferencesForUser(userID string, preferences []mod
 }
 
 func (s *apiRPCServer) DeletePreferencesForUser(args *Z_DeletePreferencesForUserArgs, returns *Z_DeletePreferencesForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeletePreferencesForUser"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeletePreferencesForUser(userID string, preferences []model.Preference) *model.AppError
 	}); ok {
@@ -1978,6 +2293,10 @@ func (g *apiRPCClient) GetSession(sessionID string) (*model.Session, *model.AppE
 }
 
 func (s *apiRPCServer) GetSession(args *Z_GetSessionArgs, returns *Z_GetSessionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetSession"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetSession(sessionID string) (*model.Session, *model.AppError)
 	}); ok {
@@ -2007,6 +2326,10 @@ func (g *apiRPCClient) CreateSession(session *model.Session) (*model.Session, *m
 }
 
 func (s *apiRPCServer) CreateSession(args *Z_CreateSessionArgs, returns *Z_CreateSessionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateSession"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateSession(session *model.Session) (*model.Session, *model.AppError)
 	}); ok {
@@ -2036,6 +2359,10 @@ func (g *apiRPCClient) ExtendSessionExpiry(sessionID string, newExpiry int64) *m
 }
 
 func (s *apiRPCServer) ExtendSessionExpiry(args *Z_ExtendSessionExpiryArgs, returns *Z_ExtendSessionExpiryReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("ExtendSessionExpiry"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ExtendSessionExpiry(sessionID string, newExpiry int64) *model.AppError
 	}); ok {
@@ -2064,6 +2391,10 @@ func (g *apiRPCClient) RevokeSession(sessionID string) *model.AppError {
 }
 
 func (s *apiRPCServer) RevokeSession(args *Z_RevokeSessionArgs, returns *Z_RevokeSessionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RevokeSession"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RevokeSession(sessionID string) *model.AppError
 	}); ok {
@@ -2093,6 +2424,10 @@ func (g *apiRPCClient) CreateUserAccessToken(token *model.UserAccessToken) (*mod
 }
 
 func (s *apiRPCServer) CreateUserAccessToken(args *Z_CreateUserAccessTokenArgs, returns *Z_CreateUserAccessTokenReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateUserAccessToken"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateUserAccessToken(token *model.UserAccessToken) (*model.UserAccessToken, *model.AppError)
 	}); ok {
@@ -2121,6 +2456,10 @@ func (g *apiRPCClient) RevokeUserAccessToken(tokenID string) *model.AppError {
 }
 
 func (s *apiRPCServer) RevokeUserAccessToken(args *Z_RevokeUserAccessTokenArgs, returns *Z_RevokeUserAccessTokenReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RevokeUserAccessToken"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RevokeUserAccessToken(tokenID string) *model.AppError
 	}); ok {
@@ -2150,6 +2489,10 @@ func (g *apiRPCClient) GetTeamIcon(teamID string) ([]byte, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetTeamIcon(args *Z_GetTeamIconArgs, returns *Z_GetTeamIconReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeamIcon"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamIcon(teamID string) ([]byte, *model.AppError)
 	}); ok {
@@ -2179,6 +2522,10 @@ func (g *apiRPCClient) SetTeamIcon(teamID string, data []byte) *model.AppError {
 }
 
 func (s *apiRPCServer) SetTeamIcon(args *Z_SetTeamIconArgs, returns *Z_SetTeamIconReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SetTeamIcon"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SetTeamIcon(teamID string, data []byte) *model.AppError
 	}); ok {
@@ -2207,6 +2554,10 @@ func (g *apiRPCClient) RemoveTeamIcon(teamID string) *model.AppError {
 }
 
 func (s *apiRPCServer) RemoveTeamIcon(args *Z_RemoveTeamIconArgs, returns *Z_RemoveTeamIconReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RemoveTeamIcon"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RemoveTeamIcon(teamID string) *model.AppError
 	}); ok {
@@ -2236,6 +2587,10 @@ func (g *apiRPCClient) UpdateUser(user *model.User) (*model.User, *model.AppErro
 }
 
 func (s *apiRPCServer) UpdateUser(args *Z_UpdateUserArgs, returns *Z_UpdateUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUser(user *model.User) (*model.User, *model.AppError)
 	}); ok {
@@ -2265,6 +2620,10 @@ func (g *apiRPCClient) GetUserStatus(userID string) (*model.Status, *model.AppEr
 }
 
 func (s *apiRPCServer) GetUserStatus(args *Z_GetUserStatusArgs, returns *Z_GetUserStatusReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUserStatus"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUserStatus(userID string) (*model.Status, *model.AppError)
 	}); ok {
@@ -2294,6 +2653,10 @@ func (g *apiRPCClient) GetUserStatusesByIds(userIds []string) ([]*model.Status,
 }
 
 func (s *apiRPCServer) GetUserStatusesByIds(args *Z_GetUserStatusesByIdsArgs, returns *Z_GetUserStatusesByIdsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUserStatusesByIds"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUserStatusesByIds(userIds []string) ([]*model.Status, *model.AppError)
 	}); ok {
@@ -2324,6 +2687,10 @@ func (g *apiRPCClient) UpdateUserStatus(userID, status string) (*model.Status, *
 }
 
 func (s *apiRPCServer) UpdateUserStatus(args *Z_UpdateUserStatusArgs, returns *Z_UpdateUserStatusReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateUserStatus"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserStatus(userID, status string) (*model.Status, *model.AppError)
 	}); ok {
@@ -2354,6 +2721,10 @@ func (g *apiRPCClient) SetUserStatusTimedDND(userId string, endtime int64) (*mod
 }
 
 func (s *apiRPCServer) SetUserStatusTimedDND(args *Z_SetUserStatusTimedDNDArgs, returns *Z_SetUserStatusTimedDNDReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SetUserStatusTimedDND"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SetUserStatusTimedDND(userId string, endtime int64) (*model.Status, *model.AppError)
 	}); ok {
@@ -2383,6 +2754,10 @@ func (g *apiRPCClient) UpdateUserActive(userID string, active bool) *model.AppEr
 }
 
 func (s *apiRPCServer) UpdateUserActive(args *Z_UpdateUserActiveArgs, returns *Z_UpdateUserActiveReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateUserActive"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserActive(userID string, active bool) *model.AppError
 	}); ok {
@@ -2412,6 +2787,10 @@ func (g *apiRPCClient) UpdateUserCustomStatus(userID string, customStatus *model
 }
 
 func (s *apiRPCServer) UpdateUserCustomStatus(args *Z_UpdateUserCustomStatusArgs, returns *Z_UpdateUserCustomStatusReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateUserCustomStatus"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserCustomStatus(userID string, customStatus *model.CustomStatus) *model.AppError
 	}); ok {
@@ -2440,6 +2819,10 @@ func (g *apiRPCClient) RemoveUserCustomStatus(userID string) *model.AppError {
 }
 
 func (s *apiRPCServer) RemoveUserCustomStatus(args *Z_RemoveUserCustomStatusArgs, returns *Z_RemoveUserCustomStatusReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RemoveUserCustomStatus"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RemoveUserCustomStatus(userID string) *model.AppError
 	}); ok {
@@ -2472,6 +2855,10 @@ func (g *apiRPCClient) GetUsersInChannel(channelID, sortBy string, page, perPage
 }
 
 func (s *apiRPCServer) GetUsersInChannel(args *Z_GetUsersInChannelArgs, returns *Z_GetUsersInChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUsersInChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUsersInChannel(channelID, sortBy string, page, perPage int) ([]*model.User, *model.AppError)
 	}); ok {
@@ -2502,6 +2889,10 @@ func (g *apiRPCClient) GetLDAPUserAttributes(userID string, attributes []string)
 }
 
 func (s *apiRPCServer) GetLDAPUserAttributes(args *Z_GetLDAPUserAttributesArgs, returns *Z_GetLDAPUserAttributesReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetLDAPUserAttributes"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetLDAPUserAttributes(userID string, attributes []string) (map[string]string, *model.AppError)
 	}); ok {
@@ -2531,6 +2922,10 @@ func (g *apiRPCClient) CreateTeam(team *model.Team) (*model.Team, *model.AppErro
 }
 
 func (s *apiRPCServer) CreateTeam(args *Z_CreateTeamArgs, returns *Z_CreateTeamReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateTeam(team *model.Team) (*model.Team, *model.AppError)
 	}); ok {
@@ -2559,6 +2954,10 @@ func (g *apiRPCClient) DeleteTeam(teamID string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteTeam(args *Z_DeleteTeamArgs, returns *Z_DeleteTeamReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeleteTeam"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteTeam(teamID string) *model.AppError
 	}); ok {
@@ -2587,6 +2986,10 @@ func (g *apiRPCClient) GetTeams() ([]*model.Team, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetTeams(args *Z_GetTeamsArgs, returns *Z_GetTeamsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeams"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeams() ([]*model.Team, *model.AppError)
 	}); ok {
@@ -2616,6 +3019,10 @@ func (g *apiRPCClient) GetTeam(teamID string) (*model.Team, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetTeam(args *Z_GetTeamArgs, returns *Z_GetTeamReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeam(teamID string) (*model.Team, *model.AppError)
 	}); ok {
@@ -2645,6 +3052,10 @@ func (g *apiRPCClient) GetTeamByName(name string) (*model.Team, *model.AppError)
 }
 
 func (s *apiRPCServer) GetTeamByName(args *Z_GetTeamByNameArgs, returns *Z_GetTeamByNameReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeamByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamByName(name string) (*model.Team, *model.AppError)
 	}); ok {
@@ -2674,6 +3085,10 @@ func (g *apiRPCClient) GetTeamsUnreadForUser(userID string) ([]*model.TeamUnread
 }
 
 func (s *apiRPCServer) GetTeamsUnreadForUser(args *Z_GetTeamsUnreadForUserArgs, returns *Z_GetTeamsUnreadForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeamsUnreadForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamsUnreadForUser(userID string) ([]*model.TeamUnread, *model.AppError)
 	}); ok {
@@ -2703,6 +3118,10 @@ func (g *apiRPCClient) UpdateTeam(team *model.Team) (*model.Team, *model.AppErro
 }
 
 func (s *apiRPCServer) UpdateTeam(args *Z_UpdateTeamArgs, returns *Z_UpdateTeamReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateTeam(team *model.Team) (*model.Team, *model.AppError)
 	}); ok {
@@ -2732,6 +3151,10 @@ func (g *apiRPCClient) SearchTeams(term string) ([]*model.Team, *model.AppError)
 }
 
 func (s *apiRPCServer) SearchTeams(args *Z_SearchTeamsArgs, returns *Z_SearchTeamsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SearchTeams"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchTeams(term string) ([]*model.Team, *model.AppError)
 	}); ok {
@@ -2761,6 +3184,10 @@ func (g *apiRPCClient) GetTeamsForUser(userID string) ([]*model.Team, *model.App
 }
 
 func (s *apiRPCServer) GetTeamsForUser(args *Z_GetTeamsForUserArgs, returns *Z_GetTeamsForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeamsForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamsForUser(userID string) ([]*model.Team, *model.AppError)
 	}); ok {
@@ -2791,6 +3218,10 @@ func (g *apiRPCClient) CreateTeamMember(teamID, userID string) (*model.TeamMembe
 }
 
 func (s *apiRPCServer) CreateTeamMember(args *Z_CreateTeamMemberArgs, returns *Z_CreateTeamMemberReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateTeamMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateTeamMember(teamID, userID string) (*model.TeamMember, *model.AppError)
 	}); ok {
@@ -2822,6 +3253,10 @@ func (g *apiRPCClient) CreateTeamMembers(teamID string, userIds []string, reques
 }
 
 func (s *apiRPCServer) CreateTeamMembers(args *Z_CreateTeamMembersArgs, returns *Z_CreateTeamMembersReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateTeamMembers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateTeamMembers(teamID string, userIds []string, requestorId string) ([]*model.TeamMember, *model.AppError)
 	}); ok {
@@ -2853,6 +3288,10 @@ func (g *apiRPCClient) CreateTeamMembersGracefully(teamID string, userIds []stri
 }
 
 func (s *apiRPCServer) CreateTeamMembersGracefully(args *Z_CreateTeamMembersGracefullyArgs, returns *Z_CreateTeamMembersGracefullyReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateTeamMembersGracefully"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateTeamMembersGracefully(teamID string, userIds []string, requestorId string) ([]*model.TeamMemberWithError, *model.AppError)
 	}); ok {
@@ -2883,6 +3322,10 @@ func (g *apiRPCClient) DeleteTeamMember(teamID, userID, requestorId string) *mod
 }
 
 func (s *apiRPCServer) DeleteTeamMember(args *Z_DeleteTeamMemberArgs, returns *Z_DeleteTeamMemberReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeleteTeamMember"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteTeamMember(teamID, userID, requestorId string) *model.AppError
 	}); ok {
@@ -2914,6 +3357,10 @@ func (g *apiRPCClient) GetTeamMembers(teamID string, page, perPage int) ([]*mode
 }
 
 func (s *apiRPCServer) GetTeamMembers(args *Z_GetTeamMembersArgs, returns *Z_GetTeamMembersReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeamMembers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamMembers(teamID string, page, perPage int) ([]*model.TeamMember, *model.AppError)
 	}); ok {
@@ -2944,6 +3391,10 @@ func (g *apiRPCClient) GetTeamMember(teamID, userID string) (*model.TeamMember,
 }
 
 func (s *apiRPCServer) GetTeamMember(args *Z_GetTeamMemberArgs, returns *Z_GetTeamMemberReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeamMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamMember(teamID, userID string) (*model.TeamMember, *model.AppError)
 	}); ok {
@@ -2975,6 +3426,10 @@ func (g *apiRPCClient) GetTeamMembersForUser(userID string, page int, perPage in
 }
 
 func (s *apiRPCServer) GetTeamMembersForUser(args *Z_GetTeamMembersForUserArgs, returns *Z_GetTeamMembersForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeamMembersForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamMembersForUser(userID string, page int, perPage int) ([]*model.TeamMember, *model.AppError)
 	}); ok {
@@ -3006,6 +3461,10 @@ func (g *apiRPCClient) UpdateTeamMemberRoles(teamID, userID, newRoles string) (*
 }
 
 func (s *apiRPCServer) UpdateTeamMemberRoles(args *Z_UpdateTeamMemberRolesArgs, returns *Z_UpdateTeamMemberRolesReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateTeamMemberRoles"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateTeamMemberRoles(teamID, userID, newRoles string) (*model.TeamMember, *model.AppError)
 	}); ok {
@@ -3035,6 +3494,10 @@ func (g *apiRPCClient) CreateChannel(channel *model.Channel) (*model.Channel, *m
 }
 
 func (s *apiRPCServer) CreateChannel(args *Z_CreateChannelArgs, returns *Z_CreateChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateChannel(channel *model.Channel) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3063,6 +3526,10 @@ func (g *apiRPCClient) DeleteChannel(channelId string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteChannel(args *Z_DeleteChannelArgs, returns *Z_DeleteChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeleteChannel"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteChannel(channelId string) *model.AppError
 	}); ok {
@@ -3094,6 +3561,10 @@ func (g *apiRPCClient) GetPublicChannelsForTeam(teamID string, page, perPage int
 }
 
 func (s *apiRPCServer) GetPublicChannelsForTeam(args *Z_GetPublicChannelsForTeamArgs, returns *Z_GetPublicChannelsForTeamReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPublicChannelsForTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPublicChannelsForTeam(teamID string, page, perPage int) ([]*model.Channel, *model.AppError)
 	}); ok {
@@ -3123,6 +3594,10 @@ func (g *apiRPCClient) GetChannel(channelId string) (*model.Channel, *model.AppE
 }
 
 func (s *apiRPCServer) GetChannel(args *Z_GetChannelArgs, returns *Z_GetChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannel(channelId string) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3154,6 +3629,10 @@ func (g *apiRPCClient) GetChannelByName(teamID, name string, includeDeleted bool
 }
 
 func (s *apiRPCServer) GetChannelByName(args *Z_GetChannelByNameArgs, returns *Z_GetChannelByNameReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelByName(teamID, name string, includeDeleted bool) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3185,6 +3664,10 @@ func (g *apiRPCClient) GetChannelByNameForTeamName(teamName, channelName string,
 }
 
 func (s *apiRPCServer) GetChannelByNameForTeamName(args *Z_GetChannelByNameForTeamNameArgs, returns *Z_GetChannelByNameForTeamNameReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelByNameForTeamName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelByNameForTeamName(teamName, channelName string, includeDeleted bool) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3216,6 +3699,10 @@ func (g *apiRPCClient) GetChannelsForTeamForUser(teamID, userID string, includeD
 }
 
 func (s *apiRPCServer) GetChannelsForTeamForUser(args *Z_GetChannelsForTeamForUserArgs, returns *Z_GetChannelsForTeamForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelsForTeamForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelsForTeamForUser(teamID, userID string, includeDeleted bool) ([]*model.Channel, *model.AppError)
 	}); ok {
@@ -3245,6 +3732,10 @@ func (g *apiRPCClient) GetChannelStats(channelId string) (*model.ChannelStats, *
 }
 
 func (s *apiRPCServer) GetChannelStats(args *Z_GetChannelStatsArgs, returns *Z_GetChannelStatsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelStats"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelStats(channelId string) (*model.ChannelStats, *model.AppError)
 	}); ok {
@@ -3275,6 +3766,10 @@ func (g *apiRPCClient) GetDirectChannel(userId1, userId2 string) (*model.Channel
 }
 
 func (s *apiRPCServer) GetDirectChannel(args *Z_GetDirectChannelArgs, returns *Z_GetDirectChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetDirectChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetDirectChannel(userId1, userId2 string) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3304,6 +3799,10 @@ func (g *apiRPCClient) GetGroupChannel(userIds []string) (*model.Channel, *model
 }
 
 func (s *apiRPCServer) GetGroupChannel(args *Z_GetGroupChannelArgs, returns *Z_GetGroupChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetGroupChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupChannel(userIds []string) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3333,6 +3832,10 @@ func (g *apiRPCClient) UpdateChannel(channel *model.Channel) (*model.Channel, *m
 }
 
 func (s *apiRPCServer) UpdateChannel(args *Z_UpdateChannelArgs, returns *Z_UpdateChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateChannel(channel *model.Channel) (*model.Channel, *model.AppError)
 	}); ok {
@@ -3363,6 +3866,10 @@ func (g *apiRPCClient) SearchChannels(teamID string, term string) ([]*model.Chan
 }
 
 func (s *apiRPCServer) SearchChannels(args *Z_SearchChannelsArgs, returns *Z_SearchChannelsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SearchChannels"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchChannels(teamID string, term string) ([]*model.Channel, *model.AppError)
 	}); ok {
@@ -3394,6 +3901,10 @@ func (g *apiRPCClient) CreateChannelSidebarCategory(userID, teamID string, newCa
 }
 
 func (s *apiRPCServer) CreateChannelSidebarCategory(args *Z_CreateChannelSidebarCategoryArgs, returns *Z_CreateChannelSidebarCategoryReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateChannelSidebarCategory"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateChannelSidebarCategory(userID, teamID string, newCategory *model.SidebarCategoryWithChannels) (*model.SidebarCategoryWithChannels, *model.AppError)
 	}); ok {
@@ -3424,6 +3935,10 @@ func (g *apiRPCClient) GetChannelSidebarCategories(userID, teamID string) (*mode
 }
 
 func (s *apiRPCServer) GetChannelSidebarCategories(args *Z_GetChannelSidebarCategoriesArgs, returns *Z_GetChannelSidebarCategoriesReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelSidebarCategories"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelSidebarCategories(userID, teamID string) (*model.OrderedSidebarCategories, *model.AppError)
 	}); ok {
@@ -3455,6 +3970,10 @@ func (g *apiRPCClient) UpdateChannelSidebarCategories(userID, teamID string, cat
 }
 
 func (s *apiRPCServer) UpdateChannelSidebarCategories(args *Z_UpdateChannelSidebarCategoriesArgs, returns *Z_UpdateChannelSidebarCategoriesReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateChannelSidebarCategories"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateChannelSidebarCategories(userID, teamID string, categories []*model.SidebarCategoryWithChannels) ([]*model.SidebarCategoryWithChannels, *model.AppError)
 	}); ok {
@@ -3484,6 +4003,10 @@ func (g *apiRPCClient) SearchUsers(search *model.UserSearch) ([]*model.User, *mo
 }
 
 func (s *apiRPCServer) SearchUsers(args *Z_SearchUsersArgs, returns *Z_SearchUsersReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SearchUsers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchUsers(search *model.UserSearch) ([]*model.User, *model.AppError)
 	}); ok {
@@ -3514,6 +4037,10 @@ func (g *apiRPCClient) SearchPostsInTeam(teamID string, paramsList []*model.Sear
 }
 
 func (s *apiRPCServer) SearchPostsInTeam(args *Z_SearchPostsInTeamArgs, returns *Z_SearchPostsInTeamReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SearchPostsInTeam"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchPostsInTeam(teamID string, paramsList []*model.SearchParams) ([]*model.Post, *model.AppError)
 	}); ok {
@@ -3545,6 +4072,10 @@ func (g *apiRPCClient) SearchPostsInTeamForUser(teamID string, userID string, se
 }
 
 func (s *apiRPCServer) SearchPostsInTeamForUser(args *Z_SearchPostsInTeamForUserArgs, returns *Z_SearchPostsInTeamForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SearchPostsInTeamForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SearchPostsInTeamForUser(teamID string, userID string, searchParams model.SearchParameter) (*model.PostSearchResults, *model.AppError)
 	}); ok {
@@ -3575,6 +4106,10 @@ func (g *apiRPCClient) AddChannelMember(channelId, userID string) (*model.Channe
 }
 
 func (s *apiRPCServer) AddChannelMember(args *Z_AddChannelMemberArgs, returns *Z_AddChannelMemberReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("AddChannelMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		AddChannelMember(channelId, userID string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3606,6 +4141,10 @@ func (g *apiRPCClient) AddUserToChannel(channelId, userID, asUserId string) (*mo
 }
 
 func (s *apiRPCServer) AddUserToChannel(args *Z_AddUserToChannelArgs, returns *Z_AddUserToChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("AddUserToChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		AddUserToChannel(channelId, userID, asUserId string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3636,6 +4175,10 @@ func (g *apiRPCClient) GetChannelMember(channelId, userID string) (*model.Channe
 }
 
 func (s *apiRPCServer) GetChannelMember(args *Z_GetChannelMemberArgs, returns *Z_GetChannelMemberReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelMember"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelMember(channelId, userID string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3667,6 +4210,10 @@ func (g *apiRPCClient) GetChannelMembers(channelId string, page, perPage int) (m
 }
 
 func (s *apiRPCServer) GetChannelMembers(args *Z_GetChannelMembersArgs, returns *Z_GetChannelMembersReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelMembers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelMembers(channelId string, page, perPage int) (model.ChannelMembers, *model.AppError)
 	}); ok {
@@ -3697,6 +4244,10 @@ func (g *apiRPCClient) GetChannelMembersByIds(channelId string, userIds []string
 }
 
 func (s *apiRPCServer) GetChannelMembersByIds(args *Z_GetChannelMembersByIdsArgs, returns *Z_GetChannelMembersByIdsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelMembersByIds"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelMembersByIds(channelId string, userIds []string) (model.ChannelMembers, *model.AppError)
 	}); ok {
@@ -3729,6 +4280,10 @@ func (g *apiRPCClient) GetChannelMembersForUser(teamID, userID string, page, per
 }
 
 func (s *apiRPCServer) GetChannelMembersForUser(args *Z_GetChannelMembersForUserArgs, returns *Z_GetChannelMembersForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetChannelMembersForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetChannelMembersForUser(teamID, userID string, page, perPage int) ([]*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3760,6 +4315,10 @@ func (g *apiRPCClient) UpdateChannelMemberRoles(channelId, userID, newRoles stri
 }
 
 func (s *apiRPCServer) UpdateChannelMemberRoles(args *Z_UpdateChannelMemberRolesArgs, returns *Z_UpdateChannelMemberRolesReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateChannelMemberRoles"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateChannelMemberRoles(channelId, userID, newRoles string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3791,6 +4350,10 @@ func (g *apiRPCClient) UpdateChannelMemberNotifications(channelId, userID string
 }
 
 func (s *apiRPCServer) UpdateChannelMemberNotifications(args *Z_UpdateChannelMemberNotificationsArgs, returns *Z_UpdateChannelMemberNotificationsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateChannelMemberNotifications"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateChannelMemberNotifications(channelId, userID string, notifications map[string]string) (*model.ChannelMember, *model.AppError)
 	}); ok {
@@ -3820,6 +4383,10 @@ func (g *apiRPCClient) PatchChannelMembersNotifications(members []*model.Channel
 }
 
 func (s *apiRPCServer) PatchChannelMembersNotifications(args *Z_PatchChannelMembersNotificationsArgs, returns *Z_PatchChannelMembersNotificationsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("PatchChannelMembersNotifications"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PatchChannelMembersNotifications(members []*model.ChannelMemberIdentifier, notifyProps map[string]string) *model.AppError
 	}); ok {
@@ -3849,6 +4416,10 @@ func (g *apiRPCClient) GetGroup(groupId string) (*model.Group, *model.AppError)
 }
 
 func (s *apiRPCServer) GetGroup(args *Z_GetGroupArgs, returns *Z_GetGroupReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetGroup"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroup(groupId string) (*model.Group, *model.AppError)
 	}); ok {
@@ -3878,6 +4449,10 @@ func (g *apiRPCClient) GetGroupByName(name string) (*model.Group, *model.AppErro
 }
 
 func (s *apiRPCServer) GetGroupByName(args *Z_GetGroupByNameArgs, returns *Z_GetGroupByNameReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetGroupByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupByName(name string) (*model.Group, *model.AppError)
 	}); ok {
@@ -3909,6 +4484,10 @@ func (g *apiRPCClient) GetGroupMemberUsers(groupID string, page, perPage int) ([
 }
 
 func (s *apiRPCServer) GetGroupMemberUsers(args *Z_GetGroupMemberUsersArgs, returns *Z_GetGroupMemberUsersReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetGroupMemberUsers"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupMemberUsers(groupID string, page, perPage int) ([]*model.User, *model.AppError)
 	}); ok {
@@ -3938,6 +4517,10 @@ func (g *apiRPCClient) GetGroupsBySource(groupSource model.GroupSource) ([]*mode
 }
 
 func (s *apiRPCServer) GetGroupsBySource(args *Z_GetGroupsBySourceArgs, returns *Z_GetGroupsBySourceReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetGroupsBySource"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupsBySource(groupSource model.GroupSource) ([]*model.Group, *model.AppError)
 	}); ok {
@@ -3967,6 +4550,10 @@ func (g *apiRPCClient) GetGroupsForUser(userID string) ([]*model.Group, *model.A
 }
 
 func (s *apiRPCServer) GetGroupsForUser(args *Z_GetGroupsForUserArgs, returns *Z_GetGroupsForUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetGroupsForUser"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetGroupsForUser(userID string) ([]*model.Group, *model.AppError)
 	}); ok {
@@ -3996,6 +4583,10 @@ func (g *apiRPCClient) DeleteChannelMember(channelId, userID string) *model.AppE
 }
 
 func (s *apiRPCServer) DeleteChannelMember(args *Z_DeleteChannelMemberArgs, returns *Z_DeleteChannelMemberReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeleteChannelMember"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteChannelMember(channelId, userID string) *model.AppError
 	}); ok {
@@ -4025,6 +4616,10 @@ func (g *apiRPCClient) CreatePost(post *model.Post) (*model.Post, *model.AppErro
 }
 
 func (s *apiRPCServer) CreatePost(args *Z_CreatePostArgs, returns *Z_CreatePostReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreatePost"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreatePost(post *model.Post) (*model.Post, *model.AppError)
 	}); ok {
@@ -4054,6 +4649,10 @@ func (g *apiRPCClient) AddReaction(reaction *model.Reaction) (*model.Reaction, *
 }
 
 func (s *apiRPCServer) AddReaction(args *Z_AddReactionArgs, returns *Z_AddReactionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("AddReaction"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		AddReaction(reaction *model.Reaction) (*model.Reaction, *model.AppError)
 	}); ok {
@@ -4082,6 +4681,10 @@ func (g *apiRPCClient) RemoveReaction(reaction *model.Reaction) *model.AppError
 }
 
 func (s *apiRPCServer) RemoveReaction(args *Z_RemoveReactionArgs, returns *Z_RemoveReactionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RemoveReaction"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RemoveReaction(reaction *model.Reaction) *model.AppError
 	}); ok {
@@ -4111,6 +4714,10 @@ func (g *apiRPCClient) GetReactions(postId string) ([]*model.Reaction, *model.Ap
 }
 
 func (s *apiRPCServer) GetReactions(args *Z_GetReactionsArgs, returns *Z_GetReactionsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetReactions"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetReactions(postId string) ([]*model.Reaction, *model.AppError)
 	}); ok {
@@ -4140,6 +4747,9 @@ func (g *apiRPCClient) SendEphemeralPost(userID string, post *model.Post) *model
 }
 
 func (s *apiRPCServer) SendEphemeralPost(args *Z_SendEphemeralPostArgs, returns *Z_SendEphemeralPostReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SendEphemeralPost"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		SendEphemeralPost(userID string, post *model.Post) *model.Post
 	}); ok {
@@ -4169,6 +4779,9 @@ func (g *apiRPCClient) UpdateEphemeralPost(userID string, post *model.Post) *mod
 }
 
 func (s *apiRPCServer) UpdateEphemeralPost(args *Z_UpdateEphemeralPostArgs, returns *Z_UpdateEphemeralPostReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateEphemeralPost"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateEphemeralPost(userID string, post *model.Post) *model.Post
 	}); ok {
@@ -4197,6 +4810,9 @@ func (g *apiRPCClient) DeleteEphemeralPost(userID, postId string) {
 }
 
 func (s *apiRPCServer) DeleteEphemeralPost(args *Z_DeleteEphemeralPostArgs, returns *Z_DeleteEphemeralPostReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeleteEphemeralPost"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteEphemeralPost(userID, postId string)
 	}); ok {
@@ -4225,6 +4841,10 @@ func (g *apiRPCClient) DeletePost(postId string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeletePost(args *Z_DeletePostArgs, returns *Z_DeletePostReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeletePost"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeletePost(postId string) *model.AppError
 	}); ok {
@@ -4254,6 +4874,10 @@ func (g *apiRPCClient) GetPostThread(postId string) (*model.PostList, *model.App
 }
 
 func (s *apiRPCServer) GetPostThread(args *Z_GetPostThreadArgs, returns *Z_GetPostThreadReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPostThread"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostThread(postId string) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4283,6 +4907,10 @@ func (g *apiRPCClient) GetPost(postId string) (*model.Post, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetPost(args *Z_GetPostArgs, returns *Z_GetPostReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPost"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPost(postId string) (*model.Post, *model.AppError)
 	}); ok {
@@ -4313,6 +4941,10 @@ func (g *apiRPCClient) GetPostsSince(channelId string, time int64) (*model.PostL
 }
 
 func (s *apiRPCServer) GetPostsSince(args *Z_GetPostsSinceArgs, returns *Z_GetPostsSinceReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPostsSince"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostsSince(channelId string, time int64) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4345,6 +4977,10 @@ func (g *apiRPCClient) GetPostsAfter(channelId, postId string, page, perPage int
 }
 
 func (s *apiRPCServer) GetPostsAfter(args *Z_GetPostsAfterArgs, returns *Z_GetPostsAfterReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPostsAfter"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostsAfter(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4377,6 +5013,10 @@ func (g *apiRPCClient) GetPostsBefore(channelId, postId string, page, perPage in
 }
 
 func (s *apiRPCServer) GetPostsBefore(args *Z_GetPostsBeforeArgs, returns *Z_GetPostsBeforeReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPostsBefore"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostsBefore(channelId, postId string, page, perPage int) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4408,6 +5048,10 @@ func (g *apiRPCClient) GetPostsForChannel(channelId string, page, perPage int) (
 }
 
 func (s *apiRPCServer) GetPostsForChannel(args *Z_GetPostsForChannelArgs, returns *Z_GetPostsForChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPostsForChannel"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPostsForChannel(channelId string, page, perPage int) (*model.PostList, *model.AppError)
 	}); ok {
@@ -4437,6 +5081,10 @@ func (g *apiRPCClient) GetTeamStats(teamID string) (*model.TeamStats, *model.App
 }
 
 func (s *apiRPCServer) GetTeamStats(args *Z_GetTeamStatsArgs, returns *Z_GetTeamStatsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetTeamStats"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetTeamStats(teamID string) (*model.TeamStats, *model.AppError)
 	}); ok {
@@ -4466,6 +5114,10 @@ func (g *apiRPCClient) UpdatePost(post *model.Post) (*model.Post, *model.AppErro
 }
 
 func (s *apiRPCServer) UpdatePost(args *Z_UpdatePostArgs, returns *Z_UpdatePostReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdatePost"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdatePost(post *model.Post) (*model.Post, *model.AppError)
 	}); ok {
@@ -4495,6 +5147,10 @@ func (g *apiRPCClient) GetProfileImage(userID string) ([]byte, *model.AppError)
 }
 
 func (s *apiRPCServer) GetProfileImage(args *Z_GetProfileImageArgs, returns *Z_GetProfileImageReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetProfileImage"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetProfileImage(userID string) ([]byte, *model.AppError)
 	}); ok {
@@ -4524,6 +5180,10 @@ func (g *apiRPCClient) SetProfileImage(userID string, data []byte) *model.AppErr
 }
 
 func (s *apiRPCServer) SetProfileImage(args *Z_SetProfileImageArgs, returns *Z_SetProfileImageReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SetProfileImage"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SetProfileImage(userID string, data []byte) *model.AppError
 	}); ok {
@@ -4555,6 +5215,10 @@ func (g *apiRPCClient) GetEmojiList(sortBy string, page, perPage int) ([]*model.
 }
 
 func (s *apiRPCServer) GetEmojiList(args *Z_GetEmojiListArgs, returns *Z_GetEmojiListReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetEmojiList"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetEmojiList(sortBy string, page, perPage int) ([]*model.Emoji, *model.AppError)
 	}); ok {
@@ -4584,6 +5248,10 @@ func (g *apiRPCClient) GetEmojiByName(name string) (*model.Emoji, *model.AppErro
 }
 
 func (s *apiRPCServer) GetEmojiByName(args *Z_GetEmojiByNameArgs, returns *Z_GetEmojiByNameReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetEmojiByName"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetEmojiByName(name string) (*model.Emoji, *model.AppError)
 	}); ok {
@@ -4613,6 +5281,10 @@ func (g *apiRPCClient) GetEmoji(emojiId string) (*model.Emoji, *model.AppError)
 }
 
 func (s *apiRPCServer) GetEmoji(args *Z_GetEmojiArgs, returns *Z_GetEmojiReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetEmoji"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetEmoji(emojiId string) (*model.Emoji, *model.AppError)
 	}); ok {
@@ -4643,6 +5315,10 @@ func (g *apiRPCClient) CopyFileInfos(userID string, fileIds []string) ([]string,
 }
 
 func (s *apiRPCServer) CopyFileInfos(args *Z_CopyFileInfosArgs, returns *Z_CopyFileInfosReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CopyFileInfos"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CopyFileInfos(userID string, fileIds []string) ([]string, *model.AppError)
 	}); ok {
@@ -4672,6 +5348,10 @@ func (g *apiRPCClient) GetFileInfo(fileId string) (*model.FileInfo, *model.AppEr
 }
 
 func (s *apiRPCServer) GetFileInfo(args *Z_GetFileInfoArgs, returns *Z_GetFileInfoReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetFileInfo"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetFileInfo(fileId string) (*model.FileInfo, *model.AppError)
 	}); ok {
@@ -4701,6 +5381,10 @@ func (g *apiRPCClient) SetFileSearchableContent(fileID string, content string) *
 }
 
 func (s *apiRPCServer) SetFileSearchableContent(args *Z_SetFileSearchableContentArgs, returns *Z_SetFileSearchableContentReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SetFileSearchableContent"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SetFileSearchableContent(fileID string, content string) *model.AppError
 	}); ok {
@@ -4732,6 +5416,10 @@ func (g *apiRPCClient) GetFileInfos(page, perPage int, opt *model.GetFileInfosOp
 }
 
 func (s *apiRPCServer) GetFileInfos(args *Z_GetFileInfosArgs, returns *Z_GetFileInfosReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetFileInfos"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetFileInfos(page, perPage int, opt *model.GetFileInfosOptions) ([]*model.FileInfo, *model.AppError)
 	}); ok {
@@ -4761,6 +5449,10 @@ func (g *apiRPCClient) GetFile(fileId string) ([]byte, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetFile(args *Z_GetFileArgs, returns *Z_GetFileReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetFile"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetFile(fileId string) ([]byte, *model.AppError)
 	}); ok {
@@ -4790,6 +5482,10 @@ func (g *apiRPCClient) GetFileLink(fileId string) (string, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetFileLink(args *Z_GetFileLinkArgs, returns *Z_GetFileLinkReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetFileLink"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetFileLink(fileId string) (string, *model.AppError)
 	}); ok {
@@ -4819,6 +5515,10 @@ func (g *apiRPCClient) ReadFile(path string) ([]byte, *model.AppError) {
 }
 
 func (s *apiRPCServer) ReadFile(args *Z_ReadFileArgs, returns *Z_ReadFileReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("ReadFile"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ReadFile(path string) ([]byte, *model.AppError)
 	}); ok {
@@ -4849,6 +5549,10 @@ func (g *apiRPCClient) GetEmojiImage(emojiId string) ([]byte, string, *model.App
 }
 
 func (s *apiRPCServer) GetEmojiImage(args *Z_GetEmojiImageArgs, returns *Z_GetEmojiImageReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetEmojiImage"); appErr != nil {
+		returns.C = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetEmojiImage(emojiId string) ([]byte, string, *model.AppError)
 	}); ok {
@@ -4880,6 +5584,10 @@ func (g *apiRPCClient) UploadFile(data []byte, channelId string, filename string
 }
 
 func (s *apiRPCServer) UploadFile(args *Z_UploadFileArgs, returns *Z_UploadFileReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UploadFile"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UploadFile(data []byte, channelId string, filename string) (*model.FileInfo, *model.AppError)
 	}); ok {
@@ -4908,6 +5616,10 @@ func (g *apiRPCClient) OpenInteractiveDialog(dialog model.OpenDialogRequest) *mo
 }
 
 func (s *apiRPCServer) OpenInteractiveDialog(args *Z_OpenInteractiveDialogArgs, returns *Z_OpenInteractiveDialogReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("OpenInteractiveDialog"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		OpenInteractiveDialog(dialog model.OpenDialogRequest) *model.AppError
 	}); ok {
@@ -4936,6 +5648,10 @@ func (g *apiRPCClient) GetPlugins() ([]*model.Manifest, *model.AppError) {
 }
 
 func (s *apiRPCServer) GetPlugins(args *Z_GetPluginsArgs, returns *Z_GetPluginsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPlugins"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPlugins() ([]*model.Manifest, *model.AppError)
 	}); ok {
@@ -4964,6 +5680,10 @@ func (g *apiRPCClient) EnablePlugin(id string) *model.AppError {
 }
 
 func (s *apiRPCServer) EnablePlugin(args *Z_EnablePluginArgs, returns *Z_EnablePluginReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("EnablePlugin"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		EnablePlugin(id string) *model.AppError
 	}); ok {
@@ -4992,6 +5712,10 @@ func (g *apiRPCClient) DisablePlugin(id string) *model.AppError {
 }
 
 func (s *apiRPCServer) DisablePlugin(args *Z_DisablePluginArgs, returns *Z_DisablePluginReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DisablePlugin"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DisablePlugin(id string) *model.AppError
 	}); ok {
@@ -5020,6 +5744,10 @@ func (g *apiRPCClient) RemovePlugin(id string) *model.AppError {
 }
 
 func (s *apiRPCServer) RemovePlugin(args *Z_RemovePluginArgs, returns *Z_RemovePluginReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RemovePlugin"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RemovePlugin(id string) *model.AppError
 	}); ok {
@@ -5049,6 +5777,10 @@ func (g *apiRPCClient) GetPluginStatus(id string) (*model.PluginStatus, *model.A
 }
 
 func (s *apiRPCServer) GetPluginStatus(args *Z_GetPluginStatusArgs, returns *Z_GetPluginStatusReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPluginStatus"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetPluginStatus(id string) (*model.PluginStatus, *model.AppError)
 	}); ok {
@@ -5078,6 +5810,10 @@ func (g *apiRPCClient) KVSet(key string, value []byte) *model.AppError {
 }
 
 func (s *apiRPCServer) KVSet(args *Z_KVSetArgs, returns *Z_KVSetReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVSet"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVSet(key string, value []byte) *model.AppError
 	}); ok {
@@ -5109,6 +5845,10 @@ func (g *apiRPCClient) KVCompareAndSet(key string, oldValue, newValue []byte) (b
 }
 
 func (s *apiRPCServer) KVCompareAndSet(args *Z_KVCompareAndSetArgs, returns *Z_KVCompareAndSetReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVCompareAndSet"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVCompareAndSet(key string, oldValue, newValue []byte) (bool, *model.AppError)
 	}); ok {
@@ -5139,6 +5879,10 @@ func (g *apiRPCClient) KVCompareAndDelete(key string, oldValue []byte) (bool, *m
 }
 
 func (s *apiRPCServer) KVCompareAndDelete(args *Z_KVCompareAndDeleteArgs, returns *Z_KVCompareAndDeleteReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVCompareAndDelete"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVCompareAndDelete(key string, oldValue []byte) (bool, *model.AppError)
 	}); ok {
@@ -5170,6 +5914,10 @@ func (g *apiRPCClient) KVSetWithOptions(key string, value []byte, options model.
 }
 
 func (s *apiRPCServer) KVSetWithOptions(args *Z_KVSetWithOptionsArgs, returns *Z_KVSetWithOptionsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVSetWithOptions"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVSetWithOptions(key string, value []byte, options model.PluginKVSetOptions) (bool, *model.AppError)
 	}); ok {
@@ -5200,6 +5948,10 @@ func (g *apiRPCClient) KVSetWithExpiry(key string, value []byte, expireInSeconds
 }
 
 func (s *apiRPCServer) KVSetWithExpiry(args *Z_KVSetWithExpiryArgs, returns *Z_KVSetWithExpiryReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVSetWithExpiry"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVSetWithExpiry(key string, value []byte, expireInSeconds int64) *model.AppError
 	}); ok {
@@ -5229,6 +5981,10 @@ func (g *apiRPCClient) KVGet(key string) ([]byte, *model.AppError) {
 }
 
 func (s *apiRPCServer) KVGet(args *Z_KVGetArgs, returns *Z_KVGetReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVGet"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVGet(key string) ([]byte, *model.AppError)
 	}); ok {
@@ -5257,6 +6013,10 @@ func (g *apiRPCClient) KVDelete(key string) *model.AppError {
 }
 
 func (s *apiRPCServer) KVDelete(args *Z_KVDeleteArgs, returns *Z_KVDeleteReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVDelete"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVDelete(key string) *model.AppError
 	}); ok {
@@ -5284,6 +6044,10 @@ func (g *apiRPCClient) KVDeleteAll() *model.AppError {
 }
 
 func (s *apiRPCServer) KVDeleteAll(args *Z_KVDeleteAllArgs, returns *Z_KVDeleteAllReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVDeleteAll"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVDeleteAll() *model.AppError
 	}); ok {
@@ -5314,6 +6078,10 @@ func (g *apiRPCClient) KVList(page, perPage int) ([]string, *model.AppError) {
 }
 
 func (s *apiRPCServer) KVList(args *Z_KVListArgs, returns *Z_KVListReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVList"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		KVList(page, perPage int) ([]string, *model.AppError)
 	}); ok {
@@ -5324,6 +6092,107 @@ func (s *apiRPCServer) KVList(args *Z_KVListArgs, returns *Z_KVListReturns) erro
 	return nil
 }
 
+type Z_KVListWithPrefixArgs struct {
+	A string
+	B int
+	C int
+}
+
+type Z_KVListWithPrefixReturns struct {
+	A []string
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError) {
+	_args := &Z_KVListWithPrefixArgs{prefix, page, perPage}
+	_returns := &Z_KVListWithPrefixReturns{}
+	if err := g.client.Call("Plugin.KVListWithPrefix", _args, _returns); err != nil {
+		log.Printf("RPC call to KVListWithPrefix API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVListWithPrefix(args *Z_KVListWithPrefixArgs, returns *Z_KVListWithPrefixReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVListWithPrefix"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		KVListWithPrefix(prefix string, page, perPage int) ([]string, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVListWithPrefix(args.A, args.B, args.C)
+	} else {
+		return encodableError(fmt.Errorf("API KVListWithPrefix called but not implemented."))
+	}
+	return nil
+}
+
+type Z_KVGetManyArgs struct {
+	A []string
+}
+
+type Z_KVGetManyReturns struct {
+	A map[string][]byte
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	_args := &Z_KVGetManyArgs{keys}
+	_returns := &Z_KVGetManyReturns{}
+	if err := g.client.Call("Plugin.KVGetMany", _args, _returns); err != nil {
+		log.Printf("RPC call to KVGetMany API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVGetMany(args *Z_KVGetManyArgs, returns *Z_KVGetManyReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVGetMany"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		KVGetMany(keys []string) (map[string][]byte, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVGetMany(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVGetMany called but not implemented."))
+	}
+	return nil
+}
+
+type Z_KVBatchArgs struct {
+	A []*model.PluginKVBatchOperation
+}
+
+type Z_KVBatchReturns struct {
+	A bool
+	B *model.AppError
+}
+
+func (g *apiRPCClient) KVBatch(operations []*model.PluginKVBatchOperation) (bool, *model.AppError) {
+	_args := &Z_KVBatchArgs{operations}
+	_returns := &Z_KVBatchReturns{}
+	if err := g.client.Call("Plugin.KVBatch", _args, _returns); err != nil {
+		log.Printf("RPC call to KVBatch API failed: %s", err.Error())
+	}
+	return _returns.A, _returns.B
+}
+
+func (s *apiRPCServer) KVBatch(args *Z_KVBatchArgs, returns *Z_KVBatchReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("KVBatch"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		KVBatch(operations []*model.PluginKVBatchOperation) (bool, *model.AppError)
+	}); ok {
+		returns.A, returns.B = hook.KVBatch(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API KVBatch called but not implemented."))
+	}
+	return nil
+}
+
 type Z_PublishWebSocketEventArgs struct {
 	A string
 	B map[string]any
@@ -5343,6 +6212,9 @@ func (g *apiRPCClient) PublishWebSocketEvent(event string, payload map[string]an
 }
 
 func (s *apiRPCServer) PublishWebSocketEvent(args *Z_PublishWebSocketEventArgs, returns *Z_PublishWebSocketEventReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("PublishWebSocketEvent"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		PublishWebSocketEvent(event string, payload map[string]any, broadcast *model.WebsocketBroadcast)
 	}); ok {
@@ -5372,6 +6244,9 @@ func (g *apiRPCClient) HasPermissionTo(userID string, permission *model.Permissi
 }
 
 func (s *apiRPCServer) HasPermissionTo(args *Z_HasPermissionToArgs, returns *Z_HasPermissionToReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("HasPermissionTo"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		HasPermissionTo(userID string, permission *model.Permission) bool
 	}); ok {
@@ -5402,6 +6277,9 @@ func (g *apiRPCClient) HasPermissionToTeam(userID, teamID string, permission *mo
 }
 
 func (s *apiRPCServer) HasPermissionToTeam(args *Z_HasPermissionToTeamArgs, returns *Z_HasPermissionToTeamReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("HasPermissionToTeam"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		HasPermissionToTeam(userID, teamID string, permission *model.Permission) bool
 	}); ok {
@@ -5432,6 +6310,9 @@ func (g *apiRPCClient) HasPermissionToChannel(userID, channelId string, permissi
 }
 
 func (s *apiRPCServer) HasPermissionToChannel(args *Z_HasPermissionToChannelArgs, returns *Z_HasPermissionToChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("HasPermissionToChannel"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		HasPermissionToChannel(userID, channelId string, permission *model.Permission) bool
 	}); ok {
@@ -5461,6 +6342,9 @@ func (g *apiRPCClient) RolesGrantPermission(roleNames []string, permissionId str
 }
 
 func (s *apiRPCServer) RolesGrantPermission(args *Z_RolesGrantPermissionArgs, returns *Z_RolesGrantPermissionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RolesGrantPermission"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		RolesGrantPermission(roleNames []string, permissionId string) bool
 	}); ok {
@@ -5491,6 +6375,10 @@ func (g *apiRPCClient) SendMail(to, subject, htmlBody string) *model.AppError {
 }
 
 func (s *apiRPCServer) SendMail(args *Z_SendMailArgs, returns *Z_SendMailReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SendMail"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SendMail(to, subject, htmlBody string) *model.AppError
 	}); ok {
@@ -5520,6 +6408,10 @@ func (g *apiRPCClient) CreateBot(bot *model.Bot) (*model.Bot, *model.AppError) {
 }
 
 func (s *apiRPCServer) CreateBot(args *Z_CreateBotArgs, returns *Z_CreateBotReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateBot"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateBot(bot *model.Bot) (*model.Bot, *model.AppError)
 	}); ok {
@@ -5550,6 +6442,10 @@ func (g *apiRPCClient) PatchBot(botUserId string, botPatch *model.BotPatch) (*mo
 }
 
 func (s *apiRPCServer) PatchBot(args *Z_PatchBotArgs, returns *Z_PatchBotReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("PatchBot"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PatchBot(botUserId string, botPatch *model.BotPatch) (*model.Bot, *model.AppError)
 	}); ok {
@@ -5580,6 +6476,10 @@ func (g *apiRPCClient) GetBot(botUserId string, includeDeleted bool) (*model.Bot
 }
 
 func (s *apiRPCServer) GetBot(args *Z_GetBotArgs, returns *Z_GetBotReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetBot"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetBot(botUserId string, includeDeleted bool) (*model.Bot, *model.AppError)
 	}); ok {
@@ -5609,6 +6509,10 @@ func (g *apiRPCClient) GetBots(options *model.BotGetOptions) ([]*model.Bot, *mod
 }
 
 func (s *apiRPCServer) GetBots(args *Z_GetBotsArgs, returns *Z_GetBotsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetBots"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetBots(options *model.BotGetOptions) ([]*model.Bot, *model.AppError)
 	}); ok {
@@ -5639,6 +6543,10 @@ func (g *apiRPCClient) UpdateBotActive(botUserId string, active bool) (*model.Bo
 }
 
 func (s *apiRPCServer) UpdateBotActive(args *Z_UpdateBotActiveArgs, returns *Z_UpdateBotActiveReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateBotActive"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateBotActive(botUserId string, active bool) (*model.Bot, *model.AppError)
 	}); ok {
@@ -5667,6 +6575,10 @@ func (g *apiRPCClient) PermanentDeleteBot(botUserId string) *model.AppError {
 }
 
 func (s *apiRPCServer) PermanentDeleteBot(args *Z_PermanentDeleteBotArgs, returns *Z_PermanentDeleteBotReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("PermanentDeleteBot"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PermanentDeleteBot(botUserId string) *model.AppError
 	}); ok {
@@ -5697,6 +6609,10 @@ func (g *apiRPCClient) PublishUserTyping(userID, channelId, parentId string) *mo
 }
 
 func (s *apiRPCServer) PublishUserTyping(args *Z_PublishUserTypingArgs, returns *Z_PublishUserTypingReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("PublishUserTyping"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PublishUserTyping(userID, channelId, parentId string) *model.AppError
 	}); ok {
@@ -5726,6 +6642,10 @@ func (g *apiRPCClient) CreateCommand(cmd *model.Command) (*model.Command, error)
 }
 
 func (s *apiRPCServer) CreateCommand(args *Z_CreateCommandArgs, returns *Z_CreateCommandReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateCommand"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateCommand(cmd *model.Command) (*model.Command, error)
 	}); ok {
@@ -5756,6 +6676,10 @@ func (g *apiRPCClient) ListCommands(teamID string) ([]*model.Command, error) {
 }
 
 func (s *apiRPCServer) ListCommands(args *Z_ListCommandsArgs, returns *Z_ListCommandsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("ListCommands"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ListCommands(teamID string) ([]*model.Command, error)
 	}); ok {
@@ -5786,6 +6710,10 @@ func (g *apiRPCClient) ListCustomCommands(teamID string) ([]*model.Command, erro
 }
 
 func (s *apiRPCServer) ListCustomCommands(args *Z_ListCustomCommandsArgs, returns *Z_ListCustomCommandsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("ListCustomCommands"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ListCustomCommands(teamID string) ([]*model.Command, error)
 	}); ok {
@@ -5816,6 +6744,10 @@ func (g *apiRPCClient) ListPluginCommands(teamID string) ([]*model.Command, erro
 }
 
 func (s *apiRPCServer) ListPluginCommands(args *Z_ListPluginCommandsArgs, returns *Z_ListPluginCommandsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("ListPluginCommands"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ListPluginCommands(teamID string) ([]*model.Command, error)
 	}); ok {
@@ -5845,6 +6777,10 @@ func (g *apiRPCClient) ListBuiltInCommands() ([]*model.Command, error) {
 }
 
 func (s *apiRPCServer) ListBuiltInCommands(args *Z_ListBuiltInCommandsArgs, returns *Z_ListBuiltInCommandsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("ListBuiltInCommands"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ListBuiltInCommands() ([]*model.Command, error)
 	}); ok {
@@ -5875,6 +6811,10 @@ func (g *apiRPCClient) GetCommand(commandID string) (*model.Command, error) {
 }
 
 func (s *apiRPCServer) GetCommand(args *Z_GetCommandArgs, returns *Z_GetCommandReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetCommand"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetCommand(commandID string) (*model.Command, error)
 	}); ok {
@@ -5906,6 +6846,10 @@ func (g *apiRPCClient) UpdateCommand(commandID string, updatedCmd *model.Command
 }
 
 func (s *apiRPCServer) UpdateCommand(args *Z_UpdateCommandArgs, returns *Z_UpdateCommandReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateCommand"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateCommand(commandID string, updatedCmd *model.Command) (*model.Command, error)
 	}); ok {
@@ -5935,6 +6879,10 @@ func (g *apiRPCClient) DeleteCommand(commandID string) error {
 }
 
 func (s *apiRPCServer) DeleteCommand(args *Z_DeleteCommandArgs, returns *Z_DeleteCommandReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeleteCommand"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteCommand(commandID string) error
 	}); ok {
@@ -5965,6 +6913,10 @@ func (g *apiRPCClient) CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *mo
 }
 
 func (s *apiRPCServer) CreateOAuthApp(args *Z_CreateOAuthAppArgs, returns *Z_CreateOAuthAppReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateOAuthApp"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	}); ok {
@@ -5994,6 +6946,10 @@ func (g *apiRPCClient) GetOAuthApp(appID string) (*model.OAuthApp, *model.AppErr
 }
 
 func (s *apiRPCServer) GetOAuthApp(args *Z_GetOAuthAppArgs, returns *Z_GetOAuthAppReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetOAuthApp"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetOAuthApp(appID string) (*model.OAuthApp, *model.AppError)
 	}); ok {
@@ -6023,6 +6979,10 @@ func (g *apiRPCClient) UpdateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *mo
 }
 
 func (s *apiRPCServer) UpdateOAuthApp(args *Z_UpdateOAuthAppArgs, returns *Z_UpdateOAuthAppReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateOAuthApp"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateOAuthApp(app *model.OAuthApp) (*model.OAuthApp, *model.AppError)
 	}); ok {
@@ -6051,6 +7011,10 @@ func (g *apiRPCClient) DeleteOAuthApp(appID string) *model.AppError {
 }
 
 func (s *apiRPCServer) DeleteOAuthApp(args *Z_DeleteOAuthAppArgs, returns *Z_DeleteOAuthAppReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("DeleteOAuthApp"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		DeleteOAuthApp(appID string) *model.AppError
 	}); ok {
@@ -6080,6 +7044,10 @@ func (g *apiRPCClient) PublishPluginClusterEvent(ev model.PluginClusterEvent, op
 }
 
 func (s *apiRPCServer) PublishPluginClusterEvent(args *Z_PublishPluginClusterEventArgs, returns *Z_PublishPluginClusterEventReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("PublishPluginClusterEvent"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		PublishPluginClusterEvent(ev model.PluginClusterEvent, opts model.PluginClusterEventSendOptions) error
 	}); ok {
@@ -6112,6 +7080,10 @@ func (g *apiRPCClient) RequestTrialLicense(requesterID string, users int, termsA
 }
 
 func (s *apiRPCServer) RequestTrialLicense(args *Z_RequestTrialLicenseArgs, returns *Z_RequestTrialLicenseReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RequestTrialLicense"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RequestTrialLicense(requesterID string, users int, termsAccepted bool, receiveEmailsAccepted bool) *model.AppError
 	}); ok {
@@ -6140,6 +7112,10 @@ func (g *apiRPCClient) GetCloudLimits() (*model.ProductLimits, error) {
 }
 
 func (s *apiRPCServer) GetCloudLimits(args *Z_GetCloudLimitsArgs, returns *Z_GetCloudLimitsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetCloudLimits"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetCloudLimits() (*model.ProductLimits, error)
 	}); ok {
@@ -6170,6 +7146,10 @@ func (g *apiRPCClient) EnsureBotUser(bot *model.Bot) (string, error) {
 }
 
 func (s *apiRPCServer) EnsureBotUser(args *Z_EnsureBotUserArgs, returns *Z_EnsureBotUserReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("EnsureBotUser"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		EnsureBotUser(bot *model.Bot) (string, error)
 	}); ok {
@@ -6200,6 +7180,10 @@ func (g *apiRPCClient) RegisterCollectionAndTopic(collectionType, topicType stri
 }
 
 func (s *apiRPCServer) RegisterCollectionAndTopic(args *Z_RegisterCollectionAndTopicArgs, returns *Z_RegisterCollectionAndTopicReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RegisterCollectionAndTopic"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RegisterCollectionAndTopic(collectionType, topicType string) error
 	}); ok {
@@ -6230,6 +7214,10 @@ func (g *apiRPCClient) CreateUploadSession(us *model.UploadSession) (*model.Uplo
 }
 
 func (s *apiRPCServer) CreateUploadSession(args *Z_CreateUploadSessionArgs, returns *Z_CreateUploadSessionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("CreateUploadSession"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		CreateUploadSession(us *model.UploadSession) (*model.UploadSession, error)
 	}); ok {
@@ -6260,6 +7248,10 @@ func (g *apiRPCClient) GetUploadSession(uploadID string) (*model.UploadSession,
 }
 
 func (s *apiRPCServer) GetUploadSession(args *Z_GetUploadSessionArgs, returns *Z_GetUploadSessionReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetUploadSession"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		GetUploadSession(uploadID string) (*model.UploadSession, error)
 	}); ok {
@@ -6290,6 +7282,10 @@ func (g *apiRPCClient) SendPushNotification(notification *model.PushNotification
 }
 
 func (s *apiRPCServer) SendPushNotification(args *Z_SendPushNotificationArgs, returns *Z_SendPushNotificationReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SendPushNotification"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SendPushNotification(notification *model.PushNotification, userID string) *model.AppError
 	}); ok {
@@ -6320,6 +7316,10 @@ func (g *apiRPCClient) UpdateUserAuth(userID string, userAuth *model.UserAuth) (
 }
 
 func (s *apiRPCServer) UpdateUserAuth(args *Z_UpdateUserAuthArgs, returns *Z_UpdateUserAuthReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateUserAuth"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserAuth(userID string, userAuth *model.UserAuth) (*model.UserAuth, *model.AppError)
 	}); ok {
@@ -6349,6 +7349,10 @@ func (g *apiRPCClient) RegisterPluginForSharedChannels(opts model.RegisterPlugin
 }
 
 func (s *apiRPCServer) RegisterPluginForSharedChannels(args *Z_RegisterPluginForSharedChannelsArgs, returns *Z_RegisterPluginForSharedChannelsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("RegisterPluginForSharedChannels"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		RegisterPluginForSharedChannels(opts model.RegisterPluginOpts) (remoteID string, err error)
 	}); ok {
@@ -6378,6 +7382,10 @@ func (g *apiRPCClient) UnregisterPluginForSharedChannels(pluginID string) error
 }
 
 func (s *apiRPCServer) UnregisterPluginForSharedChannels(args *Z_UnregisterPluginForSharedChannelsArgs, returns *Z_UnregisterPluginForSharedChannelsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UnregisterPluginForSharedChannels"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UnregisterPluginForSharedChannels(pluginID string) error
 	}); ok {
@@ -6408,6 +7416,10 @@ func (g *apiRPCClient) ShareChannel(sc *model.SharedChannel) (*model.SharedChann
 }
 
 func (s *apiRPCServer) ShareChannel(args *Z_ShareChannelArgs, returns *Z_ShareChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("ShareChannel"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		ShareChannel(sc *model.SharedChannel) (*model.SharedChannel, error)
 	}); ok {
@@ -6438,6 +7450,10 @@ func (g *apiRPCClient) UpdateSharedChannel(sc *model.SharedChannel) (*model.Shar
 }
 
 func (s *apiRPCServer) UpdateSharedChannel(args *Z_UpdateSharedChannelArgs, returns *Z_UpdateSharedChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateSharedChannel"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateSharedChannel(sc *model.SharedChannel) (*model.SharedChannel, error)
 	}); ok {
@@ -6468,6 +7484,10 @@ func (g *apiRPCClient) UnshareChannel(channelID string) (unshared bool, err erro
 }
 
 func (s *apiRPCServer) UnshareChannel(args *Z_UnshareChannelArgs, returns *Z_UnshareChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UnshareChannel"); appErr != nil {
+		returns.B = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UnshareChannel(channelID string) (unshared bool, err error)
 	}); ok {
@@ -6499,6 +7519,10 @@ func (g *apiRPCClient) UpdateSharedChannelCursor(channelID, remoteID string, cus
 }
 
 func (s *apiRPCServer) UpdateSharedChannelCursor(args *Z_UpdateSharedChannelCursorArgs, returns *Z_UpdateSharedChannelCursorReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateSharedChannelCursor"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateSharedChannelCursor(channelID, remoteID string, cusror model.GetPostsSinceForSyncCursor) error
 	}); ok {
@@ -6528,6 +7552,10 @@ func (g *apiRPCClient) SyncSharedChannel(channelID string) error {
 }
 
 func (s *apiRPCServer) SyncSharedChannel(args *Z_SyncSharedChannelArgs, returns *Z_SyncSharedChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SyncSharedChannel"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		SyncSharedChannel(channelID string) error
 	}); ok {
@@ -6560,6 +7588,10 @@ func (g *apiRPCClient) InviteRemoteToChannel(channelID string, remoteID string,
 }
 
 func (s *apiRPCServer) InviteRemoteToChannel(args *Z_InviteRemoteToChannelArgs, returns *Z_InviteRemoteToChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("InviteRemoteToChannel"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		InviteRemoteToChannel(channelID string, remoteID string, userID string, shareIfNotShared bool) error
 	}); ok {
@@ -6590,6 +7622,10 @@ func (g *apiRPCClient) UninviteRemoteFromChannel(channelID string, remoteID stri
 }
 
 func (s *apiRPCServer) UninviteRemoteFromChannel(args *Z_UninviteRemoteFromChannelArgs, returns *Z_UninviteRemoteFromChannelReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UninviteRemoteFromChannel"); appErr != nil {
+		returns.A = encodableError(appErr)
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UninviteRemoteFromChannel(channelID string, remoteID string) error
 	}); ok {
@@ -6621,6 +7657,10 @@ func (g *apiRPCClient) UpdateUserRoles(userID, newRoles string) (*model.User, *m
 }
 
 func (s *apiRPCServer) UpdateUserRoles(args *Z_UpdateUserRolesArgs, returns *Z_UpdateUserRolesReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UpdateUserRoles"); appErr != nil {
+		returns.B = appErr
+		return nil
+	}
 	if hook, ok := s.impl.(interface {
 		UpdateUserRoles(userID, newRoles string) (*model.User, *model.AppError)
 	}); ok {
@@ -6648,6 +7688,9 @@ func (g *apiRPCClient) GetPluginID() string {
 }
 
 func (s *apiRPCServer) GetPluginID(args *Z_GetPluginIDArgs, returns *Z_GetPluginIDReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("GetPluginID"); appErr != nil {
+		return encodableError(appErr)
+	}
 	if hook, ok := s.impl.(interface {
 		GetPluginID() string
 	}); ok {
@@ -6657,3 +7700,100 @@ func (s *apiRPCServer) GetPluginID(args *Z_GetPluginIDArgs, returns *Z_GetPlugin
 	}
 	return nil
 }
+
+type Z_PublishPluginEventArgs struct {
+	A string
+	B []byte
+}
+
+type Z_PublishPluginEventReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) PublishPluginEvent(topic string, payload []byte) *model.AppError {
+	_args := &Z_PublishPluginEventArgs{topic, payload}
+	_returns := &Z_PublishPluginEventReturns{}
+	if err := g.client.Call("Plugin.PublishPluginEvent", _args, _returns); err != nil {
+		log.Printf("RPC call to PublishPluginEvent API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) PublishPluginEvent(args *Z_PublishPluginEventArgs, returns *Z_PublishPluginEventReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("PublishPluginEvent"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		PublishPluginEvent(topic string, payload []byte) *model.AppError
+	}); ok {
+		returns.A = hook.PublishPluginEvent(args.A, args.B)
+	} else {
+		return encodableError(fmt.Errorf("API PublishPluginEvent called but not implemented."))
+	}
+	return nil
+}
+
+type Z_SubscribeToPluginEventsArgs struct {
+	A string
+}
+
+type Z_SubscribeToPluginEventsReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) SubscribeToPluginEvents(topic string) *model.AppError {
+	_args := &Z_SubscribeToPluginEventsArgs{topic}
+	_returns := &Z_SubscribeToPluginEventsReturns{}
+	if err := g.client.Call("Plugin.SubscribeToPluginEvents", _args, _returns); err != nil {
+		log.Printf("RPC call to SubscribeToPluginEvents API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) SubscribeToPluginEvents(args *Z_SubscribeToPluginEventsArgs, returns *Z_SubscribeToPluginEventsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("SubscribeToPluginEvents"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		SubscribeToPluginEvents(topic string) *model.AppError
+	}); ok {
+		returns.A = hook.SubscribeToPluginEvents(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API SubscribeToPluginEvents called but not implemented."))
+	}
+	return nil
+}
+
+type Z_UnsubscribeFromPluginEventsArgs struct {
+	A string
+}
+
+type Z_UnsubscribeFromPluginEventsReturns struct {
+	A *model.AppError
+}
+
+func (g *apiRPCClient) UnsubscribeFromPluginEvents(topic string) *model.AppError {
+	_args := &Z_UnsubscribeFromPluginEventsArgs{topic}
+	_returns := &Z_UnsubscribeFromPluginEventsReturns{}
+	if err := g.client.Call("Plugin.UnsubscribeFromPluginEvents", _args, _returns); err != nil {
+		log.Printf("RPC call to UnsubscribeFromPluginEvents API failed: %s", err.Error())
+	}
+	return _returns.A
+}
+
+func (s *apiRPCServer) UnsubscribeFromPluginEvents(args *Z_UnsubscribeFromPluginEventsArgs, returns *Z_UnsubscribeFromPluginEventsReturns) error {
+	if appErr := s.capabilities.checkAPIMethod("UnsubscribeFromPluginEvents"); appErr != nil {
+		returns.A = appErr
+		return nil
+	}
+	if hook, ok := s.impl.(interface {
+		UnsubscribeFromPluginEvents(topic string) *model.AppError
+	}); ok {
+		returns.A = hook.UnsubscribeFromPluginEvents(args.A)
+	} else {
+		return encodableError(fmt.Errorf("API UnsubscribeFromPluginEvents called but not implemented."))
+	}
+	return nil
+}
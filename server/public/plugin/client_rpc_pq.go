@@ -0,0 +1,21 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build !wasip1
+
+package plugin
+
+import (
+	"encoding/gob"
+
+	"github.com/lib/pq"
+)
+
+func init() {
+	gob.Register(&pq.Error{})
+}
+
+func isPqError(err error) bool {
+	_, ok := err.(*pq.Error)
+	return ok
+}
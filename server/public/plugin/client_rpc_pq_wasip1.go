@@ -0,0 +1,12 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build wasip1
+
+package plugin
+
+// The Postgres driver doesn't build for WebAssembly. Guests have no database access, so its
+// errors never cross the boundary.
+func isPqError(err error) bool {
+	return false
+}
@@ -5,8 +5,11 @@ package plugin
 
 import (
 	"database/sql/driver"
+	"errors"
 	"log"
 	"net/rpc"
+
+	"github.com/mattermost/mattermost/server/public/model"
 )
 
 // dbRPCClient contains the client-side logic to handle the RPC communication
@@ -19,11 +22,15 @@ type dbRPCClient struct {
 // dbRPCServer is the server-side component which is responsible for calling
 // the driver methods and properly encoding the responses back to the RPC client.
 type dbRPCServer struct {
-	dbImpl Driver
+	dbImpl       Driver
+	capabilities capabilitySet
 }
 
 var _ Driver = &dbRPCClient{}
 
+// errDBAccessNotDeclared is returned to plugins which declare capabilities without db_access.
+var errDBAccessNotDeclared = errors.New("plugin did not declare the db_access capability")
+
 type Z_DbStrErrReturn struct {
 	A string
 	B error
@@ -53,6 +60,10 @@ func (db *dbRPCClient) Conn(isMaster bool) (string, error) {
 }
 
 func (db *dbRPCServer) Conn(isMaster bool, ret *Z_DbStrErrReturn) error {
+	if !db.capabilities.allows(model.PluginCapabilityDBAccess) {
+		ret.B = encodableError(errDBAccessNotDeclared)
+		return nil
+	}
 	ret.A, ret.B = db.dbImpl.Conn(isMaster)
 	ret.B = encodableError(ret.B)
 	return nil
@@ -110,6 +121,10 @@ func (db *dbRPCClient) Tx(connID string, opts driver.TxOptions) (string, error)
 }
 
 func (db *dbRPCServer) Tx(args *Z_DbTxArgs, ret *Z_DbStrErrReturn) error {
+	if !db.capabilities.allows(model.PluginCapabilityDBAccess) {
+		ret.B = encodableError(errDBAccessNotDeclared)
+		return nil
+	}
 	ret.A, ret.B = db.dbImpl.Tx(args.A, args.B)
 	ret.B = encodableError(ret.B)
 	return nil
@@ -167,6 +182,10 @@ func (db *dbRPCClient) Stmt(connID, q string) (string, error) {
 }
 
 func (db *dbRPCServer) Stmt(args *Z_DbStmtArgs, ret *Z_DbStrErrReturn) error {
+	if !db.capabilities.allows(model.PluginCapabilityDBAccess) {
+		ret.B = encodableError(errDBAccessNotDeclared)
+		return nil
+	}
 	ret.A, ret.B = db.dbImpl.Stmt(args.A, args.B)
 	ret.B = encodableError(ret.B)
 	return nil
@@ -277,6 +296,10 @@ func (db *dbRPCClient) ConnQuery(connID, q string, argVals []driver.NamedValue)
 }
 
 func (db *dbRPCServer) ConnQuery(args *Z_DbConnArgs, ret *Z_DbStrErrReturn) error {
+	if !db.capabilities.allows(model.PluginCapabilityDBAccess) {
+		ret.B = encodableError(errDBAccessNotDeclared)
+		return nil
+	}
 	ret.A, ret.B = db.dbImpl.ConnQuery(args.A, args.B, args.C)
 	ret.B = encodableError(ret.B)
 	return nil
@@ -305,6 +328,10 @@ func (db *dbRPCClient) ConnExec(connID, q string, argVals []driver.NamedValue) (
 }
 
 func (db *dbRPCServer) ConnExec(args *Z_DbConnArgs, ret *Z_DbResultContErrReturn) error {
+	if !db.capabilities.allows(model.PluginCapabilityDBAccess) {
+		ret.B = encodableError(errDBAccessNotDeclared)
+		return nil
+	}
 	ret.A, ret.B = db.dbImpl.ConnExec(args.A, args.B, args.C)
 	ret.A.LastIDError = encodableError(ret.A.LastIDError)
 	ret.A.RowsAffectedError = encodableError(ret.A.RowsAffectedError)
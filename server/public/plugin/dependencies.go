@@ -0,0 +1,118 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+// GroupByDependencies splits the given plugins into groups to activate in order, each plugin
+// coming after the plugins it depends on. The plugins of a group don't depend on each other and
+// may be activated concurrently. Plugins depending on each other in a cycle come last.
+//
+// Deactivating the groups in reverse order stops plugins before the plugins they depend on.
+func GroupByDependencies(plugins []*model.BundleInfo) [][]*model.BundleInfo {
+	remaining := make(map[string]*model.BundleInfo, len(plugins))
+	for _, p := range plugins {
+		remaining[p.Manifest.Id] = p
+	}
+
+	var groups [][]*model.BundleInfo
+	for len(remaining) > 0 {
+		var group []*model.BundleInfo
+		for _, p := range remaining {
+			ready := true
+			for id := range p.Manifest.Dependencies {
+				if _, ok := remaining[id]; ok {
+					ready = false
+					break
+				}
+			}
+			if ready {
+				group = append(group, p)
+			}
+		}
+
+		if len(group) == 0 {
+			// The remaining plugins depend on each other, so none can ever be activated first.
+			for _, p := range remaining {
+				group = append(group, p)
+			}
+		}
+
+		sort.Slice(group, func(i, j int) bool {
+			return group[i].Manifest.Id < group[j].Manifest.Id
+		})
+		for _, p := range group {
+			delete(remaining, p.Manifest.Id)
+		}
+		groups = append(groups, group)
+	}
+
+	return groups
+}
+
+// checkDependencies ensures every plugin the given plugin depends on is active in a supported
+// version.
+func (env *Environment) checkDependencies(manifest *model.Manifest) error {
+	ids := make([]string, 0, len(manifest.Dependencies))
+	for id := range manifest.Dependencies {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		rp, ok := env.registeredPlugins.Load(id)
+		if !ok || !env.IsActive(id) {
+			return fmt.Errorf("plugin requires plugin %s %s, which is not active", id, manifest.Dependencies[id])
+		}
+		dependency := rp.(registeredPlugin).BundleInfo.Manifest
+
+		satisfied, err := manifest.SatisfiesDependency(dependency)
+		if err != nil {
+			return err
+		}
+		if !satisfied {
+			return fmt.Errorf("plugin requires plugin %s %s, but version %s is active", id, manifest.Dependencies[id], dependency.Version)
+		}
+	}
+
+	return nil
+}
+
+// Dependents returns the ids of the given plugins depending on the plugin with the given id.
+func Dependents(id string, plugins []*model.BundleInfo) []string {
+	var dependents []string
+	for _, p := range plugins {
+		if p.Manifest == nil {
+			continue
+		}
+		if _, ok := p.Manifest.Dependencies[id]; ok {
+			dependents = append(dependents, p.Manifest.Id)
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
+
+// AllDependents returns the ids of the given plugins depending on the plugin with the given id,
+// directly or through other plugins.
+func AllDependents(id string, plugins []*model.BundleInfo) []string {
+	seen := map[string]bool{id: true}
+	var dependents []string
+	for queue := []string{id}; len(queue) > 0; queue = queue[1:] {
+		for _, dependent := range Dependents(queue[0], plugins) {
+			if !seen[dependent] {
+				seen[dependent] = true
+				dependents = append(dependents, dependent)
+				queue = append(queue, dependent)
+			}
+		}
+	}
+	sort.Strings(dependents)
+	return dependents
+}
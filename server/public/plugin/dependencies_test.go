@@ -0,0 +1,101 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func newDependenciesTestBundle(id string, dependencies map[string]string) *model.BundleInfo {
+	return &model.BundleInfo{
+		Manifest: &model.Manifest{
+			Id:           id,
+			Dependencies: dependencies,
+		},
+	}
+}
+
+func bundleIDs(groups [][]*model.BundleInfo) [][]string {
+	ids := make([][]string, 0, len(groups))
+	for _, group := range groups {
+		groupIDs := make([]string, 0, len(group))
+		for _, p := range group {
+			groupIDs = append(groupIDs, p.Manifest.Id)
+		}
+		ids = append(ids, groupIDs)
+	}
+	return ids
+}
+
+func TestGroupByDependencies(t *testing.T) {
+	t.Run("no plugins", func(t *testing.T) {
+		assert.Empty(t, GroupByDependencies(nil))
+	})
+
+	t.Run("independent plugins", func(t *testing.T) {
+		groups := GroupByDependencies([]*model.BundleInfo{
+			newDependenciesTestBundle("c", nil),
+			newDependenciesTestBundle("a", nil),
+			newDependenciesTestBundle("b", nil),
+		})
+		assert.Equal(t, [][]string{{"a", "b", "c"}}, bundleIDs(groups))
+	})
+
+	t.Run("dependencies", func(t *testing.T) {
+		groups := GroupByDependencies([]*model.BundleInfo{
+			newDependenciesTestBundle("d", map[string]string{"b": ">=1.0.0", "c": ">=1.0.0"}),
+			newDependenciesTestBundle("c", map[string]string{"a": ">=1.0.0"}),
+			newDependenciesTestBundle("b", map[string]string{"a": ">=1.0.0"}),
+			newDependenciesTestBundle("a", nil),
+			newDependenciesTestBundle("e", nil),
+		})
+		assert.Equal(t, [][]string{{"a", "e"}, {"b", "c"}, {"d"}}, bundleIDs(groups))
+	})
+
+	t.Run("dependency on a missing plugin", func(t *testing.T) {
+		groups := GroupByDependencies([]*model.BundleInfo{
+			newDependenciesTestBundle("a", map[string]string{"missing": ">=1.0.0"}),
+		})
+		assert.Equal(t, [][]string{{"a"}}, bundleIDs(groups))
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		groups := GroupByDependencies([]*model.BundleInfo{
+			newDependenciesTestBundle("c", map[string]string{"b": ">=1.0.0"}),
+			newDependenciesTestBundle("b", map[string]string{"c": ">=1.0.0"}),
+			newDependenciesTestBundle("a", nil),
+		})
+		assert.Equal(t, [][]string{{"a"}, {"b", "c"}}, bundleIDs(groups))
+	})
+}
+
+func TestDependents(t *testing.T) {
+	plugins := []*model.BundleInfo{
+		newDependenciesTestBundle("c", map[string]string{"a": ">=1.0.0"}),
+		newDependenciesTestBundle("b", map[string]string{"a": ">=1.0.0"}),
+		newDependenciesTestBundle("a", nil),
+		{},
+	}
+
+	assert.Equal(t, []string{"b", "c"}, Dependents("a", plugins))
+	assert.Empty(t, Dependents("b", plugins))
+}
+
+func TestAllDependents(t *testing.T) {
+	plugins := []*model.BundleInfo{
+		newDependenciesTestBundle("d", map[string]string{"c": ">=1.0.0"}),
+		newDependenciesTestBundle("c", map[string]string{"b": ">=1.0.0", "a": ">=1.0.0"}),
+		newDependenciesTestBundle("b", map[string]string{"a": ">=1.0.0"}),
+		newDependenciesTestBundle("a", map[string]string{"d": ">=1.0.0"}),
+		newDependenciesTestBundle("e", nil),
+	}
+
+	assert.Equal(t, []string{"b", "c", "d"}, AllDependents("a", plugins))
+	assert.Equal(t, []string{"a", "b", "c"}, AllDependents("d", plugins))
+	assert.Empty(t, AllDependents("e", plugins))
+}
@@ -60,6 +60,15 @@ type Environment struct {
 	prepackagedPlugins               []*PrepackagedPlugin
 	transitionallyPrepackagedPlugins []*PrepackagedPlugin
 	prepackagedPluginsLock           sync.RWMutex
+	wasmLimits                       WasmLimits
+	wasmLimitsLock                   sync.RWMutex
+	processLimits                    ProcessLimits
+	processLimitsLock                sync.RWMutex
+	hookLimits                       HookLimits
+	hookCircuitBreakerListener       HookCircuitBreakerListener
+	hookLimitsLock                   sync.RWMutex
+	hookGuards                       sync.Map
+	eventBus                         eventBus
 }
 
 func NewEnvironment(
@@ -178,6 +187,20 @@ func (env *Environment) getPluginError(id string) string {
 	return ""
 }
 
+// getPluginResourceUsage returns the resources used by the process of a running plugin, if known.
+func (env *Environment) getPluginResourceUsage(id string) *model.PluginResourceUsage {
+	rp, ok := env.registeredPlugins.Load(id)
+	if !ok {
+		return nil
+	}
+
+	p := rp.(registeredPlugin)
+	if p.State != model.PluginStateRunning || p.supervisor == nil {
+		return nil
+	}
+	return p.supervisor.ResourceUsage()
+}
+
 // GetPluginState returns the current state of a plugin (disabled, running, or error)
 func (env *Environment) GetPluginState(id string) int {
 	rp, ok := env.registeredPlugins.Load(id)
@@ -239,6 +262,7 @@ func (env *Environment) Statuses() (model.PluginStatuses, error) {
 			Name:        plugin.Manifest.Name,
 			Description: plugin.Manifest.Description,
 			Version:     plugin.Manifest.Version,
+			Resources:   env.getPluginResourceUsage(plugin.Manifest.Id),
 		}
 
 		pluginStatuses = append(pluginStatuses, status)
@@ -280,8 +304,85 @@ func checkMinServerVersion(pluginInfo *model.BundleInfo) error {
 	return nil
 }
 
+// SetWasmLimits bounds the resources of plugins running in the WebAssembly runtime. The limits
+// apply to plugins activated afterwards.
+func (env *Environment) SetWasmLimits(limits WasmLimits) {
+	env.wasmLimitsLock.Lock()
+	defer env.wasmLimitsLock.Unlock()
+	env.wasmLimits = limits
+}
+
+func (env *Environment) getWasmLimits() WasmLimits {
+	env.wasmLimitsLock.RLock()
+	defer env.wasmLimitsLock.RUnlock()
+	return env.wasmLimits
+}
+
+// SetProcessLimits bounds the resources of plugins running as separate processes. The limits
+// apply to plugins activated afterwards.
+func (env *Environment) SetProcessLimits(limits ProcessLimits) {
+	env.processLimitsLock.Lock()
+	defer env.processLimitsLock.Unlock()
+	env.processLimits = limits
+}
+
+func (env *Environment) getProcessLimits() ProcessLimits {
+	env.processLimitsLock.RLock()
+	defer env.processLimitsLock.RUnlock()
+	return env.processLimits
+}
+
+// SetHookLimits bounds the time plugins may spend in their hooks. The limits apply immediately
+// to every plugin.
+func (env *Environment) SetHookLimits(limits HookLimits) {
+	env.hookLimitsLock.Lock()
+	defer env.hookLimitsLock.Unlock()
+	env.hookLimits = limits
+}
+
+func (env *Environment) getHookLimits() HookLimits {
+	env.hookLimitsLock.RLock()
+	defer env.hookLimitsLock.RUnlock()
+	return env.hookLimits
+}
+
+// SetHookCircuitBreakerListener sets the listener notified whenever the hooks of a plugin start
+// being skipped for timing out repeatedly.
+func (env *Environment) SetHookCircuitBreakerListener(listener HookCircuitBreakerListener) {
+	env.hookLimitsLock.Lock()
+	defer env.hookLimitsLock.Unlock()
+	env.hookCircuitBreakerListener = listener
+}
+
+func (env *Environment) getHookCircuitBreakerListener() HookCircuitBreakerListener {
+	env.hookLimitsLock.RLock()
+	defer env.hookLimitsLock.RUnlock()
+	return env.hookCircuitBreakerListener
+}
+
+// getHookGuard returns the hook guard of the given plugin, which is kept for the lifetime of the
+// environment so that restarting the plugin doesn't reset its circuit breaker.
+func (env *Environment) getHookGuard(id string) *hookGuard {
+	guard, _ := env.hookGuards.LoadOrStore(id, &hookGuard{
+		pluginID: id,
+		limits:   env.getHookLimits,
+		listener: env.getHookCircuitBreakerListener,
+		metrics:  env.metrics,
+		log:      env.logger.With(mlog.String("plugin_id", id)),
+	})
+	return guard.(*hookGuard)
+}
+
 func (env *Environment) startPluginServer(pluginInfo *model.BundleInfo, opts ...func(*supervisor, *plugin.ClientConfig) error) error {
-	sup, err := newSupervisor(pluginInfo, env.newAPIImpl(pluginInfo.Manifest), env.dbDriver, env.logger, env.metrics, opts...)
+	var sup *supervisor
+	var err error
+	guard := env.getHookGuard(pluginInfo.Manifest.Id)
+	if pluginInfo.Manifest.HasWasmServer() {
+		sup, err = newWasmSupervisor(pluginInfo, env.newAPIImpl(pluginInfo.Manifest), env.dbDriver, env.logger, env.metrics, env.getWasmLimits(), guard)
+	} else {
+		opts = append(opts, withHookGuard(guard), withProcessLimits(env.getProcessLimits()))
+		sup, err = newSupervisor(pluginInfo, env.newAPIImpl(pluginInfo.Manifest), env.dbDriver, env.logger, env.metrics, opts...)
+	}
 	if err != nil {
 		return errors.Wrapf(err, "unable to start plugin: %v", pluginInfo.Manifest.Id)
 	}
@@ -351,6 +452,11 @@ func (env *Environment) Activate(id string) (manifest *model.Manifest, activated
 		return nil, false, err
 	}
 
+	err = env.checkDependencies(pluginInfo.Manifest)
+	if err != nil {
+		return nil, false, err
+	}
+
 	componentActivated := false
 
 	if pluginInfo.Manifest.HasWebapp() {
@@ -455,6 +561,7 @@ func (env *Environment) Deactivate(id string) bool {
 	isActive := env.IsActive(id)
 
 	env.setPluginState(id, model.PluginStateNotRunning)
+	env.unsubscribeFromAllPluginEvents(id)
 
 	if !isActive {
 		return false
@@ -595,6 +702,21 @@ func (env *Environment) HooksForPlugin(id string) (Hooks, error) {
 	return nil, fmt.Errorf("plugin not found: %v", id)
 }
 
+// IsHookImplemented returns whether any active plugin implements the given hookId.
+func (env *Environment) IsHookImplemented(hookId int) bool {
+	implemented := false
+	env.registeredPlugins.Range(func(key, value any) bool {
+		rp := value.(registeredPlugin)
+		if rp.supervisor != nil && rp.supervisor.Implements(hookId) && env.IsActive(rp.BundleInfo.Manifest.Id) {
+			implemented = true
+			return false
+		}
+		return true
+	})
+
+	return implemented
+}
+
 // RunMultiPluginHook invokes hookRunnerFunc for each active plugin that implements the given hookId.
 //
 // If hookRunnerFunc returns false, iteration will not continue. The iteration order among active
@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// eventBus tracks the topics plugins subscribed to.
+type eventBus struct {
+	lock sync.RWMutex
+	// subscribers maps topics to the set of ids of the plugins subscribed to them.
+	subscribers map[string]map[string]bool
+}
+
+// SubscribeToPluginEvents subscribes the given plugin to the events published on topic until
+// the plugin is deactivated.
+func (env *Environment) SubscribeToPluginEvents(pluginID, topic string) {
+	env.eventBus.lock.Lock()
+	defer env.eventBus.lock.Unlock()
+
+	if env.eventBus.subscribers == nil {
+		env.eventBus.subscribers = make(map[string]map[string]bool)
+	}
+	if env.eventBus.subscribers[topic] == nil {
+		env.eventBus.subscribers[topic] = make(map[string]bool)
+	}
+	env.eventBus.subscribers[topic][pluginID] = true
+}
+
+// UnsubscribeFromPluginEvents stops delivering the events published on topic to the given
+// plugin.
+func (env *Environment) UnsubscribeFromPluginEvents(pluginID, topic string) {
+	env.eventBus.lock.Lock()
+	defer env.eventBus.lock.Unlock()
+
+	delete(env.eventBus.subscribers[topic], pluginID)
+	if len(env.eventBus.subscribers[topic]) == 0 {
+		delete(env.eventBus.subscribers, topic)
+	}
+}
+
+func (env *Environment) unsubscribeFromAllPluginEvents(pluginID string) {
+	env.eventBus.lock.Lock()
+	defer env.eventBus.lock.Unlock()
+
+	for topic, subscribers := range env.eventBus.subscribers {
+		delete(subscribers, pluginID)
+		if len(subscribers) == 0 {
+			delete(env.eventBus.subscribers, topic)
+		}
+	}
+}
+
+func (env *Environment) pluginEventSubscribers(topic string) []string {
+	env.eventBus.lock.RLock()
+	defer env.eventBus.lock.RUnlock()
+
+	subscribers := make([]string, 0, len(env.eventBus.subscribers[topic]))
+	for pluginID := range env.eventBus.subscribers[topic] {
+		subscribers = append(subscribers, pluginID)
+	}
+	sort.Strings(subscribers)
+	return subscribers
+}
+
+// PublishPluginEvent delivers the given event to the active plugins subscribed to its topic,
+// other than the plugin which published it, through their OnPluginEvent hook.
+//
+// Events are delivered asynchronously, and only to the plugins of this server.
+func (env *Environment) PublishPluginEvent(event model.PluginEvent) {
+	subscribers := env.pluginEventSubscribers(event.Topic)
+	if len(subscribers) == 0 {
+		return
+	}
+
+	go func() {
+		for _, pluginID := range subscribers {
+			if pluginID == event.SourcePluginId {
+				continue
+			}
+
+			rp, ok := env.registeredPlugins.Load(pluginID)
+			if !ok || !env.IsActive(pluginID) {
+				continue
+			}
+
+			sup := rp.(registeredPlugin).supervisor
+			if sup == nil || !sup.Implements(OnPluginEventID) {
+				env.logger.Debug("Plugin subscribed to events without implementing OnPluginEvent", mlog.String("plugin_id", pluginID), mlog.String("topic", event.Topic))
+				continue
+			}
+
+			sup.Hooks().OnPluginEvent(&Context{}, event)
+		}
+	}()
+}
@@ -0,0 +1,36 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+func TestPluginEventSubscriptions(t *testing.T) {
+	env := &Environment{}
+
+	env.SubscribeToPluginEvents("b", "topic")
+	env.SubscribeToPluginEvents("a", "topic")
+	env.SubscribeToPluginEvents("a", "topic")
+	env.SubscribeToPluginEvents("a", "other")
+	assert.Equal(t, []string{"a", "b"}, env.pluginEventSubscribers("topic"))
+	assert.Equal(t, []string{"a"}, env.pluginEventSubscribers("other"))
+	assert.Empty(t, env.pluginEventSubscribers("unknown"))
+
+	env.UnsubscribeFromPluginEvents("b", "topic")
+	env.UnsubscribeFromPluginEvents("b", "unknown")
+	assert.Equal(t, []string{"a"}, env.pluginEventSubscribers("topic"))
+
+	env.unsubscribeFromAllPluginEvents("a")
+	assert.Empty(t, env.pluginEventSubscribers("topic"))
+	assert.Empty(t, env.pluginEventSubscribers("other"))
+	assert.Empty(t, env.eventBus.subscribers)
+
+	// Publishing without subscribers is a no-op.
+	env.PublishPluginEvent(model.PluginEvent{Topic: "topic"})
+}
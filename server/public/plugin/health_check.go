@@ -68,6 +68,7 @@ func (job *PluginHealthCheckJob) CheckPlugin(id string) {
 		// Reset timestamp state for this plugin
 		job.failureTimestamps.Delete(id)
 		job.env.setPluginState(id, model.PluginStateFailedToStayRunning)
+		job.env.SetPluginError(id, err.Error())
 	} else {
 		mlog.Debug("Restarting plugin due to failed health check", mlog.String("id", id))
 		if err := job.env.RestartPlugin(id); err != nil {
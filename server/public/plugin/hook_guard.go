@@ -0,0 +1,180 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/gob"
+	"net/rpc"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// HookLimits bounds the time plugins may spend in their hooks.
+type HookLimits struct {
+	// Timeouts maps hook names to the time the server waits for them before carrying on as if
+	// the plugin didn't implement the hook, or rejecting the content for the hooks which can
+	// reject it. Hooks without a timeout are waited on indefinitely.
+	Timeouts map[string]time.Duration
+
+	// CircuitBreakerThreshold is the number of consecutive timeouts after which the hooks of
+	// the plugin are skipped altogether. Zero disables the circuit breaker.
+	CircuitBreakerThreshold int
+
+	// CircuitBreakerCooldown is the time the hooks of the plugin are skipped for once the
+	// circuit breaker trips.
+	CircuitBreakerCooldown time.Duration
+}
+
+// HookCircuitBreakerListener is notified when the hooks of a plugin start being skipped, after
+// the given hook timed out once too often.
+type HookCircuitBreakerListener func(pluginID, hookName string, until time.Time)
+
+var errHookTimeout = errors.New("plugin hook timed out")
+
+// hookUnavailableRejection is the reason given for posts and files rejected because the hook
+// checking them timed out or was skipped by the circuit breaker: the hooks able to reject content
+// fail closed, rather than let it through unchecked. UserWillLogIn is the exception, so that a
+// misbehaving plugin can't lock administrators out.
+const hookUnavailableRejection = "The plugin did not check the content in time."
+
+// lifecycleHooks are never timed out nor skipped, since the server relies on them to manage
+// the plugin itself.
+var lifecycleHooks = map[string]bool{
+	"Implemented":           true,
+	"OnActivate":            true,
+	"OnDeactivate":          true,
+	"OnConfigurationChange": true,
+	"OnInstall":             true,
+}
+
+// hookGuard enforces the hook limits of a single plugin. It outlives the processes of the
+// plugin, so that restarting a misbehaving plugin doesn't reset its circuit breaker.
+type hookGuard struct {
+	pluginID string
+	limits   func() HookLimits
+	listener func() HookCircuitBreakerListener
+	metrics  metricsInterface
+	log      *mlog.Logger
+
+	lock      sync.Mutex
+	timeouts  int
+	openUntil time.Time
+	probing   bool
+}
+
+// timeout returns the deadline of the given hook, if any.
+func (g *hookGuard) timeout(hookName string) time.Duration {
+	if g == nil || lifecycleHooks[hookName] {
+		return 0
+	}
+	return g.limits().Timeouts[hookName]
+}
+
+// skip reports whether the circuit breaker of the plugin is open, and so whether the given hook
+// must be skipped.
+func (g *hookGuard) skip(hookName string) bool {
+	if g == nil || lifecycleHooks[hookName] {
+		return false
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	return time.Now().Before(g.openUntil)
+}
+
+func (g *hookGuard) succeeded() {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.timeouts = 0
+	g.probing = false
+}
+
+// timedOut records a timeout of the given hook, tripping the circuit breaker once the plugin
+// reaches the threshold of consecutive timeouts. Once the cool-down is over, a single further
+// timeout trips it again.
+func (g *hookGuard) timedOut(hookName string) {
+	if g.metrics != nil {
+		g.metrics.IncrementPluginHookTimeout(g.pluginID, hookName)
+	}
+
+	limits := g.limits()
+
+	g.lock.Lock()
+	g.timeouts++
+	if limits.CircuitBreakerThreshold <= 0 || (g.timeouts < limits.CircuitBreakerThreshold && !g.probing) {
+		g.lock.Unlock()
+		g.log.Warn("Plugin hook timed out.", mlog.String("hook", hookName), mlog.Int("consecutive_timeouts", g.timeouts))
+		return
+	}
+	g.timeouts = 0
+	g.probing = true
+	g.openUntil = time.Now().Add(limits.CircuitBreakerCooldown)
+	until := g.openUntil
+	g.lock.Unlock()
+
+	g.log.Error("Plugin hook timed out too often, skipping the hooks of the plugin.", mlog.String("hook", hookName), mlog.String("until", until.Format(time.RFC3339)))
+	if g.metrics != nil {
+		g.metrics.IncrementPluginCircuitBreakerTrip(g.pluginID)
+	}
+	if listener := g.listener(); listener != nil {
+		go listener(g.pluginID, hookName, until)
+	}
+}
+
+// call runs the given hook of the plugin through client, giving up once the deadline of the hook
+// expires.
+func (g *hookGuard) call(client *rpc.Client, hookName string, args, reply any) error {
+	timeout := g.timeout(hookName)
+	if timeout <= 0 {
+		return client.Call("Plugin."+hookName, args, reply)
+	}
+
+	// The reply of a call given up on still arrives eventually, so it is decoded into a copy the
+	// caller never sees.
+	isolated, err := cloneReply(reply)
+	if err != nil {
+		return err
+	}
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	call := client.Go("Plugin."+hookName, args, isolated, make(chan *rpc.Call, 1))
+	select {
+	case <-call.Done:
+		g.succeeded()
+		if call.Error != nil {
+			return call.Error
+		}
+		reflect.ValueOf(reply).Elem().Set(reflect.ValueOf(isolated).Elem())
+		return nil
+	case <-timer.C:
+		g.timedOut(hookName)
+		return errHookTimeout
+	}
+}
+
+// cloneReply deep copies the given pointer to a reply, preserving the defaults it holds.
+func cloneReply(reply any) (any, error) {
+	clone := reflect.New(reflect.TypeOf(reply).Elem()).Interface()
+	if reflect.TypeOf(reply).Elem().NumField() == 0 {
+		return clone, nil
+	}
+
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(reply); err != nil {
+		return nil, errors.Wrap(err, "failed to copy hook reply")
+	}
+
+	if err := gob.NewDecoder(&buf).Decode(clone); err != nil {
+		return nil, errors.Wrap(err, "failed to copy hook reply")
+	}
+	return clone, nil
+}
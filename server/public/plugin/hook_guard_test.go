@@ -0,0 +1,211 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func newHookGuardTestSupervisor(t *testing.T, guard *hookGuard) *supervisor {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	backend := filepath.Join(dir, "backend.exe")
+	utils.CompileGo(t, `
+		package main
+
+		import (
+			"io"
+			"strings"
+			"time"
+
+			"github.com/mattermost/mattermost/server/public/model"
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func (p *MyPlugin) MessageWillBePosted(c *plugin.Context, post *model.Post) (*model.Post, string) {
+			if post.Message == "slow" {
+				time.Sleep(time.Second)
+			}
+			post.Message += " from plugin"
+			return post, ""
+		}
+
+		func (p *MyPlugin) FileWillBeUploaded(c *plugin.Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
+			data, _ := io.ReadAll(file)
+			if info.Name == "slow.txt" {
+				time.Sleep(time.Second)
+			}
+			output.Write([]byte(strings.ToUpper(string(data))))
+			info.Name = "replaced.txt"
+			return info, ""
+		}
+
+		func (p *MyPlugin) FileWillBeDownloaded(c *plugin.Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string {
+			if downloadType == model.FileDownloadTypePublic {
+				return "public links are disabled"
+			}
+			data, _ := io.ReadAll(file)
+			output.Write([]byte(strings.ToUpper(string(data)) + " for " + userID))
+			return ""
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`, backend)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "server": {"executable": "backend.exe"}}`), 0600))
+
+	bundle := model.BundleInfoForPath(dir)
+	sup, err := newSupervisor(bundle, nil, nil, mlog.CreateConsoleTestLogger(t), nil, WithExecutableFromManifest(bundle), withHookGuard(guard))
+	require.NoError(t, err)
+	t.Cleanup(sup.Shutdown)
+
+	return sup
+}
+
+type hookGuardTestListener struct {
+	lock  sync.Mutex
+	trips []string
+}
+
+func (l *hookGuardTestListener) listen(pluginID, hookName string, until time.Time) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.trips = append(l.trips, pluginID+"/"+hookName)
+}
+
+func (l *hookGuardTestListener) getTrips() []string {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	return append([]string{}, l.trips...)
+}
+
+func TestHookGuard(t *testing.T) {
+	limits := HookLimits{
+		Timeouts: map[string]time.Duration{
+			"MessageWillBePosted": 100 * time.Millisecond,
+			"FileWillBeUploaded":  100 * time.Millisecond,
+		},
+		CircuitBreakerThreshold: 2,
+		CircuitBreakerCooldown:  500 * time.Millisecond,
+	}
+	listener := &hookGuardTestListener{}
+	guard := &hookGuard{
+		pluginID: "foo",
+		limits:   func() HookLimits { return limits },
+		listener: func() HookCircuitBreakerListener { return listener.listen },
+		log:      mlog.CreateConsoleTestLogger(t),
+	}
+	hooks := newHookGuardTestSupervisor(t, guard).Hooks()
+
+	t.Run("hook within its deadline", func(t *testing.T) {
+		post, rejection := hooks.MessageWillBePosted(&Context{}, &model.Post{Message: "fast"})
+		assert.Empty(t, rejection)
+		assert.Equal(t, "fast from plugin", post.Message)
+
+		var output bytes.Buffer
+		info, rejection := hooks.FileWillBeUploaded(&Context{}, &model.FileInfo{Name: "file.txt"}, strings.NewReader("content"), &output)
+		assert.Empty(t, rejection)
+		assert.Equal(t, "replaced.txt", info.Name)
+		assert.Equal(t, "CONTENT", output.String())
+
+		output.Reset()
+		rejection = hooks.FileWillBeDownloaded(&Context{}, &model.FileInfo{Name: "file.txt"}, "user", model.FileDownloadTypeFile, strings.NewReader("content"), &output)
+		assert.Empty(t, rejection)
+		assert.Equal(t, "CONTENT for user", output.String())
+
+		output.Reset()
+		rejection = hooks.FileWillBeDownloaded(&Context{}, &model.FileInfo{Name: "file.txt"}, "", model.FileDownloadTypePublic, strings.NewReader("content"), &output)
+		assert.Equal(t, "public links are disabled", rejection)
+		assert.Empty(t, output.String())
+	})
+
+	t.Run("hook past its deadline", func(t *testing.T) {
+		post := &model.Post{Message: "slow"}
+		start := time.Now()
+		result, rejection := hooks.MessageWillBePosted(&Context{}, post)
+		assert.Less(t, time.Since(start), time.Second)
+		assert.Equal(t, hookUnavailableRejection, rejection, "the post must not be let through unchecked")
+		assert.Nil(t, result)
+
+		// The late reply of the plugin doesn't alter the post.
+		time.Sleep(time.Second)
+		assert.Equal(t, "slow", post.Message)
+	})
+
+	t.Run("circuit breaker", func(t *testing.T) {
+		var output bytes.Buffer
+		info, rejection := hooks.FileWillBeUploaded(&Context{}, &model.FileInfo{Name: "slow.txt"}, strings.NewReader("content"), &output)
+		assert.Equal(t, hookUnavailableRejection, rejection)
+		assert.Nil(t, info)
+
+		require.Eventually(t, func() bool { return len(listener.getTrips()) == 1 }, time.Second, 10*time.Millisecond)
+		assert.Equal(t, []string{"foo/FileWillBeUploaded"}, listener.getTrips())
+
+		// The hooks of the plugin are skipped during the cool-down, rejecting the content they
+		// would have checked.
+		post, rejection := hooks.MessageWillBePosted(&Context{}, &model.Post{Message: "fast"})
+		assert.Equal(t, hookUnavailableRejection, rejection)
+		assert.Nil(t, post)
+		rejection = hooks.FileWillBeDownloaded(&Context{}, &model.FileInfo{Name: "file.txt"}, "user", model.FileDownloadTypeFile, strings.NewReader("content"), &output)
+		assert.Equal(t, hookUnavailableRejection, rejection)
+
+		time.Sleep(time.Second)
+		assert.Empty(t, output.String(), "the replacement of the hook given up on must be discarded")
+
+		// Once the cool-down is over, a single further timeout trips the circuit breaker again.
+		_, rejection = hooks.MessageWillBePosted(&Context{}, &model.Post{Message: "slow"})
+		assert.Equal(t, hookUnavailableRejection, rejection)
+		require.Eventually(t, func() bool { return len(listener.getTrips()) == 2 }, time.Second, 10*time.Millisecond)
+
+		// A hook returning in time after the cool-down resets the circuit breaker.
+		time.Sleep(time.Second)
+		post, _ = hooks.MessageWillBePosted(&Context{}, &model.Post{Message: "fast"})
+		assert.Equal(t, "fast from plugin", post.Message)
+		_, rejection = hooks.MessageWillBePosted(&Context{}, &model.Post{Message: "slow"})
+		assert.Equal(t, hookUnavailableRejection, rejection)
+		assert.False(t, guard.skip("MessageWillBePosted"))
+		assert.Len(t, listener.getTrips(), 2)
+	})
+
+	t.Run("lifecycle hooks", func(t *testing.T) {
+		assert.Zero(t, guard.timeout("OnActivate"))
+		assert.Zero(t, guard.timeout("OnDeactivate"))
+	})
+}
+
+func TestStoppableReader(t *testing.T) {
+	source := &stoppableReader{r: strings.NewReader("content")}
+
+	b := make([]byte, 3)
+	n, err := source.Read(b)
+	require.NoError(t, err)
+	assert.Equal(t, "con", string(b[:n]))
+
+	source.stop()
+	n, err = source.Read(b)
+	assert.Equal(t, io.EOF, err)
+	assert.Zero(t, n)
+}
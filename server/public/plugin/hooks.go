@@ -61,6 +61,13 @@ const (
 	OnSharedChannelsAttachmentSyncMsgID       = 43
 	OnSharedChannelsProfileImageSyncMsgID     = 44
 	GenerateSupportDataID                     = 45
+	OnPluginEventID                           = 46
+	FileWillBeDownloadedID                    = 47
+	SearchResultsWillBeReturnedID             = 48
+	UserHasBeenUpdatedID                      = 49
+	UserRolesHaveChangedID                    = 50
+	UserPasswordHasChangedID                  = 51
+	SessionHasBeenRevokedID                   = 52
 	TotalHooksID                              = iota
 )
 
@@ -395,4 +402,55 @@ type Hooks interface {
 	//
 	// Minimum server version: 9.8
 	GenerateSupportData(c *Context) ([]*model.FileData, error)
+
+	// OnPluginEvent is invoked for each event another plugin publishes on a topic this plugin
+	// subscribed to through API.SubscribeToPluginEvents. Events are delivered asynchronously and
+	// only to the plugins of the server the event was published on.
+	//
+	// Minimum server version: 10.5
+	OnPluginEvent(c *Context, event model.PluginEvent)
+
+	// FileWillBeDownloaded is invoked when a file, or its thumbnail or preview, is requested by a
+	// user, before it is sent to them. Read from file to retrieve the content about to be sent.
+	// userID is empty for downloads through a public link.
+	//
+	// To reject the download, return a non-empty string describing why the download was rejected.
+	// To modify the content sent to the user, write the replacement to the output and return an empty string.
+	// To allow the download without modification, do not write to the output and return an empty string.
+	//
+	// Minimum server version: 10.5
+	FileWillBeDownloaded(c *Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string
+
+	// SearchResultsWillBeReturned is invoked after a user searched for posts or files, before the
+	// results are returned to them. Exactly one of posts and files is set, depending on what was
+	// searched for.
+	//
+	// To filter or redact the results, return the modified list. To return the results without
+	// modification, return nil.
+	//
+	// Minimum server version: 10.5
+	SearchResultsWillBeReturned(c *Context, userID string, posts *model.PostList, files *model.FileInfoList) (*model.PostList, *model.FileInfoList)
+
+	// UserHasBeenUpdated is invoked after the profile of a user has been updated.
+	//
+	// Minimum server version: 10.5
+	UserHasBeenUpdated(c *Context, newUser, oldUser *model.User)
+
+	// UserRolesHaveChanged is invoked after the system roles of a user have been changed.
+	// oldRoles and newRoles are space separated lists of role names.
+	//
+	// Minimum server version: 10.5
+	UserRolesHaveChanged(c *Context, user *model.User, oldRoles, newRoles string)
+
+	// UserPasswordHasChanged is invoked after the password of a user has been changed, whether
+	// by the user themselves, an administrator or through a password reset.
+	//
+	// Minimum server version: 10.5
+	UserPasswordHasChanged(c *Context, user *model.User)
+
+	// SessionHasBeenRevoked is invoked after a session has been revoked, either individually or
+	// as part of revoking all the sessions of a user.
+	//
+	// Minimum server version: 10.5
+	SessionHasBeenRevoked(c *Context, session *model.Session)
 }
@@ -291,3 +291,47 @@ func (hooks *hooksTimerLayer) GenerateSupportData(c *Context) ([]*model.FileData
 	hooks.recordTime(startTime, "GenerateSupportData", _returnsB == nil)
 	return _returnsA, _returnsB
 }
+
+func (hooks *hooksTimerLayer) OnPluginEvent(c *Context, event model.PluginEvent) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.OnPluginEvent(c, event)
+	hooks.recordTime(startTime, "OnPluginEvent", true)
+}
+
+func (hooks *hooksTimerLayer) FileWillBeDownloaded(c *Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string {
+	startTime := timePkg.Now()
+	_returnsA := hooks.hooksImpl.FileWillBeDownloaded(c, info, userID, downloadType, file, output)
+	hooks.recordTime(startTime, "FileWillBeDownloaded", true)
+	return _returnsA
+}
+
+func (hooks *hooksTimerLayer) SearchResultsWillBeReturned(c *Context, userID string, posts *model.PostList, files *model.FileInfoList) (*model.PostList, *model.FileInfoList) {
+	startTime := timePkg.Now()
+	_returnsA, _returnsB := hooks.hooksImpl.SearchResultsWillBeReturned(c, userID, posts, files)
+	hooks.recordTime(startTime, "SearchResultsWillBeReturned", true)
+	return _returnsA, _returnsB
+}
+
+func (hooks *hooksTimerLayer) UserHasBeenUpdated(c *Context, newUser, oldUser *model.User) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.UserHasBeenUpdated(c, newUser, oldUser)
+	hooks.recordTime(startTime, "UserHasBeenUpdated", true)
+}
+
+func (hooks *hooksTimerLayer) UserRolesHaveChanged(c *Context, user *model.User, oldRoles, newRoles string) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.UserRolesHaveChanged(c, user, oldRoles, newRoles)
+	hooks.recordTime(startTime, "UserRolesHaveChanged", true)
+}
+
+func (hooks *hooksTimerLayer) UserPasswordHasChanged(c *Context, user *model.User) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.UserPasswordHasChanged(c, user)
+	hooks.recordTime(startTime, "UserPasswordHasChanged", true)
+}
+
+func (hooks *hooksTimerLayer) SessionHasBeenRevoked(c *Context, session *model.Session) {
+	startTime := timePkg.Now()
+	hooks.hooksImpl.SessionHasBeenRevoked(c, session)
+	hooks.recordTime(startTime, "SessionHasBeenRevoked", true)
+}
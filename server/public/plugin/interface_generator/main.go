@@ -22,6 +22,7 @@ import (
 )
 
 var excludedPluginHooks = []string{
+	"FileWillBeDownloaded",
 	"FileWillBeUploaded",
 	"Implemented",
 	"LoadPluginConfiguration",
@@ -181,6 +182,28 @@ func FieldListToRecordSuccess(structPrefix string, fieldList *ast.FieldList) str
 	return fmt.Sprintf("%s == nil", result)
 }
 
+// FieldListToCapabilityError returns the statement reporting a denied capability through the
+// error return of an API method, or an empty string if the method doesn't return an error.
+func FieldListToCapabilityError(structPrefix string, fieldList *ast.FieldList) string {
+	if fieldList == nil {
+		return ""
+	}
+
+	nextLetter := 'A'
+	for _, field := range fieldList.List {
+		count := max(len(field.Names), 1)
+		switch baseTypeName(field.Type) {
+		case "AppError":
+			return structPrefix + string(nextLetter) + " = appErr"
+		case "error":
+			return structPrefix + string(nextLetter) + " = encodableError(appErr)"
+		}
+		nextLetter += rune(count)
+	}
+
+	return ""
+}
+
 func FieldListToStructList(fieldList *ast.FieldList, fileset *token.FileSet) string {
 	result := []string{}
 	if fieldList == nil || len(fieldList.List) == 0 {
@@ -324,8 +347,8 @@ type {{.Name | obscure}}Returns struct {
 func (g *hooksRPCClient) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
 	_args := &{{.Name | obscure}}Args{ {{valuesOnly .Params}} }
 	_returns := &{{.Name | obscure}}Returns{}
-	if g.implemented[{{.Name}}ID] {
-		if err := g.client.Call("Plugin.{{.Name}}", _args, _returns); err != nil {
+	if g.implemented[{{.Name}}ID] && !g.guard.skip("{{.Name}}") {
+		if err := g.call("{{.Name}}", _args, _returns); err != nil {
 			g.log.Error("RPC call {{.Name}} to plugin failed.", mlog.Err(err))
 		}
 	}
@@ -365,6 +388,11 @@ func (g *apiRPCClient) {{.Name}}{{funcStyle .Params}} {{funcStyle .Return}} {
 }
 
 func (s *apiRPCServer) {{.Name}}(args *{{.Name | obscure}}Args, returns *{{.Name | obscure}}Returns) error {
+	if appErr := s.capabilities.checkAPIMethod("{{.Name}}"); appErr != nil {
+		{{- $capabilityError := capabilityError "returns." .Return}}
+		{{if $capabilityError}}{{$capabilityError}}
+		return nil{{else}}return encodableError(appErr){{end}}
+	}
 	if hook, ok := s.impl.(interface {
 		{{.Name}}{{funcStyle .Params}} {{funcStyle .Return}}
 	}); ok {
@@ -485,6 +513,9 @@ func generateHooksGlue(info *PluginInterfaceInfo) {
 		"shouldRecordSuccess": func(structPrefix string, fields *ast.FieldList) string {
 			return FieldListToRecordSuccess(structPrefix, fields)
 		},
+		"capabilityError": func(structPrefix string, fields *ast.FieldList) string {
+			return FieldListToCapabilityError(structPrefix, fields)
+		},
 		"obscure": func(name string) string {
 			return "Z_" + name
 		},
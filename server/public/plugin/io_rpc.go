@@ -7,6 +7,7 @@ import (
 	"bufio"
 	"encoding/binary"
 	"io"
+	"sync"
 )
 
 type remoteIOReader struct {
@@ -44,3 +45,27 @@ func serveIOReader(r io.Reader, conn io.ReadWriteCloser) {
 		}
 	}
 }
+
+// stoppableReader guards a reader served to a plugin, so that it can be handed back to the
+// caller when a hook is given up on. Once stop returns, the underlying reader isn't read anymore.
+type stoppableReader struct {
+	mu      sync.Mutex
+	r       io.Reader
+	stopped bool
+}
+
+func (s *stoppableReader) Read(b []byte) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.stopped {
+		return 0, io.EOF
+	}
+	return s.r.Read(b)
+}
+
+// stop waits for any read in progress and makes the following ones return io.EOF.
+func (s *stoppableReader) stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.stopped = true
+}
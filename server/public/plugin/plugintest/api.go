@@ -3332,6 +3332,36 @@ func (_m *API) IsEnterpriseReady() bool {
 	return r0
 }
 
+// KVBatch provides a mock function with given fields: operations
+func (_m *API) KVBatch(operations []*model.PluginKVBatchOperation) (bool, *model.AppError) {
+	ret := _m.Called(operations)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVBatch")
+	}
+
+	var r0 bool
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func([]*model.PluginKVBatchOperation) (bool, *model.AppError)); ok {
+		return rf(operations)
+	}
+	if rf, ok := ret.Get(0).(func([]*model.PluginKVBatchOperation) bool); ok {
+		r0 = rf(operations)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	if rf, ok := ret.Get(1).(func([]*model.PluginKVBatchOperation) *model.AppError); ok {
+		r1 = rf(operations)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVCompareAndDelete provides a mock function with given fields: key, oldValue
 func (_m *API) KVCompareAndDelete(key string, oldValue []byte) (bool, *model.AppError) {
 	ret := _m.Called(key, oldValue)
@@ -3464,6 +3494,38 @@ func (_m *API) KVGet(key string) ([]byte, *model.AppError) {
 	return r0, r1
 }
 
+// KVGetMany provides a mock function with given fields: keys
+func (_m *API) KVGetMany(keys []string) (map[string][]byte, *model.AppError) {
+	ret := _m.Called(keys)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVGetMany")
+	}
+
+	var r0 map[string][]byte
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func([]string) (map[string][]byte, *model.AppError)); ok {
+		return rf(keys)
+	}
+	if rf, ok := ret.Get(0).(func([]string) map[string][]byte); ok {
+		r0 = rf(keys)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(map[string][]byte)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func([]string) *model.AppError); ok {
+		r1 = rf(keys)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVList provides a mock function with given fields: page, perPage
 func (_m *API) KVList(page int, perPage int) ([]string, *model.AppError) {
 	ret := _m.Called(page, perPage)
@@ -3496,6 +3558,38 @@ func (_m *API) KVList(page int, perPage int) ([]string, *model.AppError) {
 	return r0, r1
 }
 
+// KVListWithPrefix provides a mock function with given fields: prefix, page, perPage
+func (_m *API) KVListWithPrefix(prefix string, page int, perPage int) ([]string, *model.AppError) {
+	ret := _m.Called(prefix, page, perPage)
+
+	if len(ret) == 0 {
+		panic("no return value specified for KVListWithPrefix")
+	}
+
+	var r0 []string
+	var r1 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, int, int) ([]string, *model.AppError)); ok {
+		return rf(prefix, page, perPage)
+	}
+	if rf, ok := ret.Get(0).(func(string, int, int) []string); ok {
+		r0 = rf(prefix, page, perPage)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]string)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(string, int, int) *model.AppError); ok {
+		r1 = rf(prefix, page, perPage)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.AppError)
+		}
+	}
+
+	return r0, r1
+}
+
 // KVSet provides a mock function with given fields: key, value
 func (_m *API) KVSet(key string, value []byte) *model.AppError {
 	ret := _m.Called(key, value)
@@ -3866,6 +3960,26 @@ func (_m *API) PublishPluginClusterEvent(ev model.PluginClusterEvent, opts model
 	return r0
 }
 
+// PublishPluginEvent provides a mock function with given fields: topic, payload
+func (_m *API) PublishPluginEvent(topic string, payload []byte) *model.AppError {
+	ret := _m.Called(topic, payload)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PublishPluginEvent")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string, []byte) *model.AppError); ok {
+		r0 = rf(topic, payload)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // PublishUserTyping provides a mock function with given fields: userID, channelId, parentId
 func (_m *API) PublishUserTyping(userID string, channelId string, parentId string) *model.AppError {
 	ret := _m.Called(userID, channelId, parentId)
@@ -4527,6 +4641,26 @@ func (_m *API) ShareChannel(sc *model.SharedChannel) (*model.SharedChannel, erro
 	return r0, r1
 }
 
+// SubscribeToPluginEvents provides a mock function with given fields: topic
+func (_m *API) SubscribeToPluginEvents(topic string) *model.AppError {
+	ret := _m.Called(topic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SubscribeToPluginEvents")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(topic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // SyncSharedChannel provides a mock function with given fields: channelID
 func (_m *API) SyncSharedChannel(channelID string) error {
 	ret := _m.Called(channelID)
@@ -4599,6 +4733,26 @@ func (_m *API) UnregisterPluginForSharedChannels(pluginID string) error {
 	return r0
 }
 
+// UnsubscribeFromPluginEvents provides a mock function with given fields: topic
+func (_m *API) UnsubscribeFromPluginEvents(topic string) *model.AppError {
+	ret := _m.Called(topic)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UnsubscribeFromPluginEvents")
+	}
+
+	var r0 *model.AppError
+	if rf, ok := ret.Get(0).(func(string) *model.AppError); ok {
+		r0 = rf(topic)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.AppError)
+		}
+	}
+
+	return r0
+}
+
 // UnshareChannel provides a mock function with given fields: channelID
 func (_m *API) UnshareChannel(channelID string) (bool, error) {
 	ret := _m.Called(channelID)
@@ -87,6 +87,24 @@ func (_m *Hooks) ExecuteCommand(c *plugin.Context, args *model.CommandArgs) (*mo
 	return r0, r1
 }
 
+// FileWillBeDownloaded provides a mock function with given fields: c, info, userID, downloadType, file, output
+func (_m *Hooks) FileWillBeDownloaded(c *plugin.Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string {
+	ret := _m.Called(c, info, userID, downloadType, file, output)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FileWillBeDownloaded")
+	}
+
+	var r0 string
+	if rf, ok := ret.Get(0).(func(*plugin.Context, *model.FileInfo, string, model.FileDownloadType, io.Reader, io.Writer) string); ok {
+		r0 = rf(c, info, userID, downloadType, file, output)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	return r0
+}
+
 // FileWillBeUploaded provides a mock function with given fields: c, info, file, output
 func (_m *Hooks) FileWillBeUploaded(c *plugin.Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
 	ret := _m.Called(c, info, file, output)
@@ -384,6 +402,11 @@ func (_m *Hooks) OnPluginClusterEvent(c *plugin.Context, ev model.PluginClusterE
 	_m.Called(c, ev)
 }
 
+// OnPluginEvent provides a mock function with given fields: c, event
+func (_m *Hooks) OnPluginEvent(c *plugin.Context, event model.PluginEvent) {
+	_m.Called(c, event)
+}
+
 // OnSendDailyTelemetry provides a mock function with given fields:
 func (_m *Hooks) OnSendDailyTelemetry() {
 	_m.Called()
@@ -524,6 +547,38 @@ func (_m *Hooks) RunDataRetention(nowTime int64, batchSize int64) (int64, error)
 	return r0, r1
 }
 
+// SearchResultsWillBeReturned provides a mock function with given fields: c, userID, posts, files
+func (_m *Hooks) SearchResultsWillBeReturned(c *plugin.Context, userID string, posts *model.PostList, files *model.FileInfoList) (*model.PostList, *model.FileInfoList) {
+	ret := _m.Called(c, userID, posts, files)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchResultsWillBeReturned")
+	}
+
+	var r0 *model.PostList
+	var r1 *model.FileInfoList
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, *model.PostList, *model.FileInfoList) (*model.PostList, *model.FileInfoList)); ok {
+		return rf(c, userID, posts, files)
+	}
+	if rf, ok := ret.Get(0).(func(*plugin.Context, string, *model.PostList, *model.FileInfoList) *model.PostList); ok {
+		r0 = rf(c, userID, posts, files)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.PostList)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(*plugin.Context, string, *model.PostList, *model.FileInfoList) *model.FileInfoList); ok {
+		r1 = rf(c, userID, posts, files)
+	} else {
+		if ret.Get(1) != nil {
+			r1 = ret.Get(1).(*model.FileInfoList)
+		}
+	}
+
+	return r0, r1
+}
+
 // ServeHTTP provides a mock function with given fields: c, w, r
 func (_m *Hooks) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
 	_m.Called(c, w, r)
@@ -534,6 +589,11 @@ func (_m *Hooks) ServeMetrics(c *plugin.Context, w http.ResponseWriter, r *http.
 	_m.Called(c, w, r)
 }
 
+// SessionHasBeenRevoked provides a mock function with given fields: c, session
+func (_m *Hooks) SessionHasBeenRevoked(c *plugin.Context, session *model.Session) {
+	_m.Called(c, session)
+}
+
 // UserHasBeenCreated provides a mock function with given fields: c, user
 func (_m *Hooks) UserHasBeenCreated(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
@@ -544,6 +604,11 @@ func (_m *Hooks) UserHasBeenDeactivated(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserHasBeenUpdated provides a mock function with given fields: c, newUser, oldUser
+func (_m *Hooks) UserHasBeenUpdated(c *plugin.Context, newUser *model.User, oldUser *model.User) {
+	_m.Called(c, newUser, oldUser)
+}
+
 // UserHasJoinedChannel provides a mock function with given fields: c, channelMember, actor
 func (_m *Hooks) UserHasJoinedChannel(c *plugin.Context, channelMember *model.ChannelMember, actor *model.User) {
 	_m.Called(c, channelMember, actor)
@@ -569,6 +634,16 @@ func (_m *Hooks) UserHasLoggedIn(c *plugin.Context, user *model.User) {
 	_m.Called(c, user)
 }
 
+// UserPasswordHasChanged provides a mock function with given fields: c, user
+func (_m *Hooks) UserPasswordHasChanged(c *plugin.Context, user *model.User) {
+	_m.Called(c, user)
+}
+
+// UserRolesHaveChanged provides a mock function with given fields: c, user, oldRoles, newRoles
+func (_m *Hooks) UserRolesHaveChanged(c *plugin.Context, user *model.User, oldRoles string, newRoles string) {
+	_m.Called(c, user, oldRoles, newRoles)
+}
+
 // UserWillLogIn provides a mock function with given fields: c, user
 func (_m *Hooks) UserWillLogIn(c *plugin.Context, user *model.User) string {
 	ret := _m.Called(c, user)
@@ -0,0 +1,148 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// ProcessLimits bounds the resources available to each plugin running as a separate process.
+// Zero values leave the corresponding resource unbounded.
+type ProcessLimits struct {
+	// MaxMemoryMB caps the resident memory of the plugin.
+	MaxMemoryMB int
+
+	// MaxCPUPercent caps the CPU time of the plugin, as a percentage of a single core.
+	MaxCPUPercent int
+
+	// MaxOpenFiles caps the number of files the plugin may have open at once. Opening more
+	// files fails rather than getting the plugin restarted.
+	MaxOpenFiles int
+}
+
+var errProcessLimitsUnsupported = errors.New("plugin process limits are not supported on this platform")
+
+// processUsage is a measurement of the resources used by a process.
+type processUsage struct {
+	memoryBytes uint64
+	cpuTime     time.Duration
+	openFiles   int
+}
+
+// processMonitor measures the resources used by a plugin process and checks them against its
+// limits. Where the platform allows it, the limits are also enforced by the kernel as soon as
+// the process has started.
+type processMonitor struct {
+	pluginID string
+	pid      int
+	limits   ProcessLimits
+
+	// cgroup is the path of the cgroup confining the process, if any, and oomKills the number
+	// of processes the kernel had killed in it for exceeding its memory limit when last checked.
+	cgroup   string
+	oomKills int
+
+	lock      sync.Mutex
+	lastCheck time.Time
+	lastCPU   time.Duration
+	usage     *model.PluginResourceUsage
+}
+
+func newProcessMonitor(pluginID string, pid int, limits ProcessLimits, logger *mlog.Logger) *processMonitor {
+	m := &processMonitor{
+		pluginID: pluginID,
+		pid:      pid,
+		limits:   limits,
+	}
+
+	if err := m.confine(logger); err != nil {
+		logger.Warn("Failed to limit the resources of the plugin process", mlog.Err(err))
+	}
+
+	if usage, err := readProcessUsage(pid); err == nil {
+		m.lastCheck = time.Now()
+		m.lastCPU = usage.cpuTime
+	}
+
+	return m
+}
+
+// check measures the resources used by the process, returning an error describing the limit it
+// exceeded, if any. The CPU usage is averaged since the previous check.
+func (m *processMonitor) check() error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if err := m.checkCgroup(); err != nil {
+		return err
+	}
+
+	usage, err := readProcessUsage(m.pid)
+	if err != nil {
+		// The process has exited or can't be measured on this platform, in which case the
+		// health check relies on pinging the plugin instead.
+		return nil
+	}
+
+	now := time.Now()
+	var cpuPercent float64
+	if !m.lastCheck.IsZero() && now.After(m.lastCheck) {
+		cpuPercent = float64(usage.cpuTime-m.lastCPU) / float64(now.Sub(m.lastCheck)) * 100
+	}
+	m.lastCheck = now
+	m.lastCPU = usage.cpuTime
+
+	memoryMB := int(usage.memoryBytes / 1024 / 1024)
+	m.usage = &model.PluginResourceUsage{
+		MemoryMB:      memoryMB,
+		MaxMemoryMB:   m.limits.MaxMemoryMB,
+		CPUPercent:    cpuPercent,
+		MaxCPUPercent: m.limits.MaxCPUPercent,
+		OpenFiles:     usage.openFiles,
+		MaxOpenFiles:  m.limits.MaxOpenFiles,
+		Cgroup:        m.cgroup != "",
+	}
+
+	// Within a cgroup, the kernel keeps the process from exceeding its limits in the first place.
+	if m.cgroup != "" {
+		return nil
+	}
+
+	if m.limits.MaxMemoryMB > 0 && memoryMB > m.limits.MaxMemoryMB {
+		return fmt.Errorf("plugin exceeded its memory limit: using %d MB out of %d MB", memoryMB, m.limits.MaxMemoryMB)
+	}
+
+	if m.limits.MaxCPUPercent > 0 && cpuPercent > float64(m.limits.MaxCPUPercent) {
+		return fmt.Errorf("plugin exceeded its CPU limit: using %.0f%% out of %d%%", cpuPercent, m.limits.MaxCPUPercent)
+	}
+
+	return nil
+}
+
+// resourceUsage returns the resources used by the process when last checked.
+func (m *processMonitor) resourceUsage() *model.PluginResourceUsage {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	if m.usage == nil {
+		return nil
+	}
+	usage := *m.usage
+	return &usage
+}
+
+// close releases the resources used to confine the process, once it has exited.
+func (m *processMonitor) close() {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	m.removeCgroup()
+}
@@ -0,0 +1,218 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build linux
+
+package plugin
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"golang.org/x/sys/unix"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const (
+	cgroupRoot = "/sys/fs/cgroup"
+
+	// cgroupCPUPeriod is the period, in microseconds, over which the CPU limit of a cgroup is
+	// enforced.
+	cgroupCPUPeriod = 100000
+
+	// clockTicksPerSecond is the unit of the CPU times in /proc, which the kernel fixes at 100
+	// for user space on every architecture we support.
+	clockTicksPerSecond = 100
+)
+
+// confine enforces the limits of the process through the kernel: an rlimit caps its open files,
+// and its memory and CPU are capped by a cgroup v2 if one can be created for it.
+func (m *processMonitor) confine(logger *mlog.Logger) error {
+	if m.limits.MaxOpenFiles > 0 {
+		limit := uint64(m.limits.MaxOpenFiles)
+		if err := unix.Prlimit(m.pid, unix.RLIMIT_NOFILE, &unix.Rlimit{Cur: limit, Max: limit}, nil); err != nil {
+			return errors.Wrap(err, "unable to limit the open files of the plugin process")
+		}
+	}
+
+	if m.limits.MaxMemoryMB == 0 && m.limits.MaxCPUPercent == 0 {
+		return nil
+	}
+
+	cgroup, err := m.createCgroup()
+	if err != nil {
+		logger.Warn("Unable to confine the plugin process to a cgroup, its memory and CPU limits aren't enforced by the kernel. The health check restarts the plugin once it exceeds them instead.", mlog.Err(err))
+		return nil
+	}
+	m.cgroup = cgroup
+
+	return nil
+}
+
+// createCgroup creates a cgroup for the process nested under the cgroup of the server, and moves
+// the process into it. This requires the memory and cpu controllers to be enabled for the
+// children of the cgroup of the server. Since cgroup v2 only allows that for cgroups without
+// processes of their own, other than the root cgroup, this mostly works when the server runs in
+// the root of its cgroup namespace, as in a container. It doesn't work for a systemd service,
+// even with Delegate=yes, since the server process sits in the cgroup of the service. The
+// limits are then only enforced by the health check polling the usage of the process.
+func (m *processMonitor) createCgroup() (string, error) {
+	data, err := os.ReadFile("/proc/self/cgroup")
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read the cgroup of the server")
+	}
+
+	// Under cgroup v2, the single hierarchy is listed as "0::<path>".
+	var parent string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		if path, ok := strings.CutPrefix(line, "0::"); ok {
+			parent = filepath.Join(cgroupRoot, path)
+		}
+	}
+	if parent == "" {
+		return "", errors.New("cgroup v2 is not available")
+	}
+
+	data, err = os.ReadFile(filepath.Join(parent, "cgroup.subtree_control"))
+	if err != nil {
+		return "", errors.Wrap(err, "unable to read the controllers of the cgroup of the server")
+	}
+	controllers := strings.Fields(string(data))
+	if !slices.Contains(controllers, "memory") || !slices.Contains(controllers, "cpu") {
+		return "", errors.Errorf("the memory and cpu controllers aren't enabled for the children of the cgroup %s of the server", parent)
+	}
+
+	cgroup := filepath.Join(parent, "plugin-"+m.pluginID)
+	if err = os.Mkdir(cgroup, 0755); err != nil && !os.IsExist(err) {
+		return "", errors.Wrap(err, "unable to create the cgroup of the plugin")
+	}
+
+	memoryMax := "max"
+	if m.limits.MaxMemoryMB > 0 {
+		memoryMax = strconv.Itoa(m.limits.MaxMemoryMB * 1024 * 1024)
+	}
+	cpuMax := fmt.Sprintf("max %d", cgroupCPUPeriod)
+	if m.limits.MaxCPUPercent > 0 {
+		cpuMax = fmt.Sprintf("%d %d", m.limits.MaxCPUPercent*cgroupCPUPeriod/100, cgroupCPUPeriod)
+	}
+
+	for file, value := range map[string]string{"memory.max": memoryMax, "cpu.max": cpuMax} {
+		if err = os.WriteFile(filepath.Join(cgroup, file), []byte(value), 0644); err != nil {
+			os.Remove(cgroup)
+			return "", errors.Wrapf(err, "unable to write %s of the cgroup of the plugin", file)
+		}
+	}
+
+	// The cgroup outlives the processes it confined until they exit, so it may have recorded
+	// kills of a previous process of the plugin.
+	m.oomKills, _ = readCgroupOOMKills(cgroup)
+
+	if err = os.WriteFile(filepath.Join(cgroup, "cgroup.procs"), []byte(strconv.Itoa(m.pid)), 0644); err != nil {
+		os.Remove(cgroup)
+		return "", errors.Wrap(err, "unable to move the plugin process to its cgroup")
+	}
+
+	return cgroup, nil
+}
+
+// checkCgroup returns an error if the kernel killed the process for exceeding the memory limit
+// of its cgroup.
+func (m *processMonitor) checkCgroup() error {
+	if m.cgroup == "" {
+		return nil
+	}
+
+	oomKills, err := readCgroupOOMKills(m.cgroup)
+	if err != nil || oomKills <= m.oomKills {
+		return nil
+	}
+	m.oomKills = oomKills
+
+	return fmt.Errorf("plugin was killed for exceeding its memory limit of %d MB", m.limits.MaxMemoryMB)
+}
+
+func (m *processMonitor) removeCgroup() {
+	if m.cgroup == "" {
+		return
+	}
+
+	// Removing the cgroup fails while a process remains in it, such as when the plugin is
+	// restarted before its previous process exited. The cgroup is then reused as is.
+	os.Remove(m.cgroup)
+	m.cgroup = ""
+}
+
+func readCgroupOOMKills(cgroup string) (int, error) {
+	file, err := os.Open(filepath.Join(cgroup, "memory.events"))
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if value, ok := strings.CutPrefix(scanner.Text(), "oom_kill "); ok {
+			return strconv.Atoi(value)
+		}
+	}
+
+	return 0, scanner.Err()
+}
+
+// readProcessUsage measures the resources used by the process with the given pid from /proc.
+func readProcessUsage(pid int) (processUsage, error) {
+	procDir := filepath.Join("/proc", strconv.Itoa(pid))
+
+	statm, err := os.ReadFile(filepath.Join(procDir, "statm"))
+	if err != nil {
+		return processUsage{}, err
+	}
+	// The second field of statm is the number of resident pages.
+	fields := strings.Fields(string(statm))
+	if len(fields) < 2 {
+		return processUsage{}, errors.New("unexpected format of statm")
+	}
+	residentPages, err := strconv.ParseUint(fields[1], 10, 64)
+	if err != nil {
+		return processUsage{}, errors.Wrap(err, "unexpected format of statm")
+	}
+
+	stat, err := os.ReadFile(filepath.Join(procDir, "stat"))
+	if err != nil {
+		return processUsage{}, err
+	}
+	// The name of the process may contain spaces and parentheses, so the fields are counted
+	// from its closing parenthesis, after which utime and stime are the 12th and 13th fields.
+	fields = strings.Fields(string(stat[bytes.LastIndexByte(stat, ')')+1:]))
+	if len(fields) < 13 {
+		return processUsage{}, errors.New("unexpected format of stat")
+	}
+	var ticks uint64
+	for _, field := range fields[11:13] {
+		value, err := strconv.ParseUint(field, 10, 64)
+		if err != nil {
+			return processUsage{}, errors.Wrap(err, "unexpected format of stat")
+		}
+		ticks += value
+	}
+
+	fds, err := os.ReadDir(filepath.Join(procDir, "fd"))
+	if err != nil {
+		return processUsage{}, err
+	}
+
+	return processUsage{
+		memoryBytes: residentPages * uint64(os.Getpagesize()),
+		cpuTime:     time.Duration(ticks) * time.Second / clockTicksPerSecond,
+		openFiles:   len(fds),
+	}, nil
+}
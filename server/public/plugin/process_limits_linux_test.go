@@ -0,0 +1,102 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build linux
+
+package plugin
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/sys/unix"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func TestReadProcessUsage(t *testing.T) {
+	usage, err := readProcessUsage(os.Getpid())
+	require.NoError(t, err)
+	assert.NotZero(t, usage.memoryBytes)
+	assert.NotZero(t, usage.openFiles)
+
+	_, err = readProcessUsage(-1)
+	assert.Error(t, err)
+}
+
+func TestProcessMonitorCheck(t *testing.T) {
+	t.Run("within limits", func(t *testing.T) {
+		m := &processMonitor{pid: os.Getpid()}
+		require.NoError(t, m.check())
+
+		usage := m.resourceUsage()
+		require.NotNil(t, usage)
+		assert.NotZero(t, usage.MemoryMB)
+		assert.NotZero(t, usage.OpenFiles)
+		assert.False(t, usage.Cgroup)
+	})
+
+	t.Run("memory limit exceeded", func(t *testing.T) {
+		m := &processMonitor{pid: os.Getpid(), limits: ProcessLimits{MaxMemoryMB: 1}}
+		err := m.check()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "memory limit")
+		assert.Equal(t, 1, m.resourceUsage().MaxMemoryMB)
+	})
+
+	t.Run("exited process", func(t *testing.T) {
+		m := &processMonitor{pid: -1, limits: ProcessLimits{MaxMemoryMB: 1}}
+		assert.NoError(t, m.check())
+		assert.Nil(t, m.resourceUsage())
+	})
+}
+
+func TestSupervisorProcessLimits(t *testing.T) {
+	dir := t.TempDir()
+
+	backend := filepath.Join(dir, "backend.exe")
+	utils.CompileGo(t, `
+		package main
+
+		import (
+			"github.com/mattermost/mattermost/server/public/plugin"
+		)
+
+		type MyPlugin struct {
+			plugin.MattermostPlugin
+		}
+
+		func main() {
+			plugin.ClientMain(&MyPlugin{})
+		}
+	`, backend)
+
+	err := os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "server": {"executable": "backend.exe"}}`), 0600)
+	require.NoError(t, err)
+
+	bundle := model.BundleInfoForPath(dir)
+	logger := mlog.CreateConsoleTestLogger(t)
+	supervisor, err := newSupervisor(bundle, nil, nil, logger, nil, WithExecutableFromManifest(bundle), withProcessLimits(ProcessLimits{MaxOpenFiles: 64}))
+	require.NoError(t, err)
+	require.NotNil(t, supervisor)
+	defer supervisor.Shutdown()
+
+	require.NotNil(t, supervisor.monitor)
+	var limit unix.Rlimit
+	require.NoError(t, unix.Prlimit(supervisor.monitor.pid, unix.RLIMIT_NOFILE, nil, &limit))
+	assert.Equal(t, uint64(64), limit.Cur)
+	assert.Equal(t, uint64(64), limit.Max)
+
+	require.NoError(t, supervisor.PerformHealthCheck())
+
+	usage := supervisor.ResourceUsage()
+	require.NotNil(t, usage)
+	assert.Equal(t, 64, usage.MaxOpenFiles)
+	assert.NotZero(t, usage.OpenFiles)
+	assert.LessOrEqual(t, usage.OpenFiles, 64)
+}
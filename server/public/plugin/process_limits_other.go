@@ -0,0 +1,27 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build !linux
+
+package plugin
+
+import (
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func (m *processMonitor) confine(_ *mlog.Logger) error {
+	if m.limits != (ProcessLimits{}) {
+		return errProcessLimitsUnsupported
+	}
+	return nil
+}
+
+func (m *processMonitor) checkCgroup() error {
+	return nil
+}
+
+func (m *processMonitor) removeCgroup() {}
+
+func readProcessUsage(_ int) (processUsage, error) {
+	return processUsage{}, errProcessLimitsUnsupported
+}
@@ -7,6 +7,7 @@ import (
 	"crypto/sha256"
 	"fmt"
 	"io"
+	"net/rpc"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -31,6 +32,11 @@ type supervisor struct {
 	implemented  [TotalHooksID]bool
 	hooksClient  *hooksRPCClient
 	isReattached bool
+	wasm         *wasmPlugin
+
+	// processLimits bounds the resources of the plugin process, which monitor measures.
+	processLimits ProcessLimits
+	monitor       *processMonitor
 }
 
 type driverForPlugin struct {
@@ -85,6 +91,22 @@ func WithReattachConfig(pluginReattachConfig *model.PluginReattachConfig) func(*
 	}
 }
 
+// withHookGuard subjects the hooks of the plugin to the limits enforced by the given guard.
+func withHookGuard(guard *hookGuard) func(*supervisor, *plugin.ClientConfig) error {
+	return func(_ *supervisor, clientConfig *plugin.ClientConfig) error {
+		clientConfig.Plugins["hooks"].(*hooksPlugin).guard = guard
+		return nil
+	}
+}
+
+// withProcessLimits bounds the resources of the plugin process.
+func withProcessLimits(limits ProcessLimits) func(*supervisor, *plugin.ClientConfig) error {
+	return func(sup *supervisor, _ *plugin.ClientConfig) error {
+		sup.processLimits = limits
+		return nil
+	}
+}
+
 func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver, parentLogger *mlog.Logger, metrics metricsInterface, opts ...func(*supervisor, *plugin.ClientConfig) error) (retSupervisor *supervisor, retErr error) {
 	sup := supervisor{
 		pluginID: pluginInfo.Manifest.Id,
@@ -108,9 +130,10 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 
 	pluginMap := map[string]plugin.Plugin{
 		"hooks": &hooksPlugin{
-			log:        wrappedLogger,
-			driverImpl: sup.appDriver,
-			apiImpl:    &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics},
+			log:          wrappedLogger,
+			driverImpl:   sup.appDriver,
+			apiImpl:      &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics},
+			capabilities: newCapabilitySet(pluginInfo.Manifest),
 		},
 	}
 
@@ -136,6 +159,11 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 		return nil, err
 	}
 
+	// Reattached plugins were launched elsewhere, so their resources aren't ours to manage.
+	if !sup.isReattached && clientConfig.Cmd != nil && clientConfig.Cmd.Process != nil {
+		sup.monitor = newProcessMonitor(pluginInfo.Manifest.Id, clientConfig.Cmd.Process.Pid, sup.processLimits, wrappedLogger)
+	}
+
 	raw, err := rpcClient.Dispense("hooks")
 	if err != nil {
 		return nil, err
@@ -161,9 +189,97 @@ func newSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver,
 	return &sup, nil
 }
 
+// newWasmSupervisor runs the WebAssembly module of the plugin in-process rather than launching
+// its executable.
+func newWasmSupervisor(pluginInfo *model.BundleInfo, apiImpl API, driver AppDriver, parentLogger *mlog.Logger, metrics metricsInterface, limits WasmLimits, guard *hookGuard) (retSupervisor *supervisor, retErr error) {
+	sup := supervisor{
+		pluginID: pluginInfo.Manifest.Id,
+	}
+	if driver != nil {
+		sup.appDriver = &driverForPlugin{AppDriver: driver, pluginID: pluginInfo.Manifest.Id}
+	}
+
+	defer func() {
+		if retErr != nil {
+			sup.Shutdown()
+		}
+	}()
+
+	module := filepath.Clean(filepath.Join(".", pluginInfo.Manifest.Server.Wasm))
+	if strings.HasPrefix(module, "..") {
+		return nil, fmt.Errorf("invalid backend WebAssembly module: %s", module)
+	}
+
+	code, err := os.ReadFile(filepath.Join(pluginInfo.Path, module))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to read backend WebAssembly module")
+	}
+
+	wrappedLogger := pluginInfo.WrapLogger(parentLogger)
+	capabilities := newCapabilitySet(pluginInfo.Manifest)
+
+	services := map[uint32]*rpc.Server{}
+	apiServer := rpc.NewServer()
+	if err = apiServer.RegisterName("Plugin", &wasmAPIRPCServer{&apiRPCServer{
+		impl:         &apiTimerLayer{pluginInfo.Manifest.Id, apiImpl, metrics},
+		capabilities: capabilities,
+	}}); err != nil {
+		return nil, err
+	}
+	services[wasmServiceAPI] = apiServer
+
+	if sup.appDriver != nil {
+		dbServer := rpc.NewServer()
+		if err = dbServer.RegisterName("Plugin", &dbRPCServer{
+			dbImpl:       sup.appDriver,
+			capabilities: capabilities,
+		}); err != nil {
+			return nil, err
+		}
+		services[wasmServiceDB] = dbServer
+	}
+
+	sup.wasm, err = newWasmPlugin(code, limits,
+		wrappedLogger.With(mlog.String("source", "plugin_stdout")).StdLogWriter(),
+		wrappedLogger.With(mlog.String("source", "plugin_stderr")).StdLogWriter(),
+		services,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	hooks := &wasmHooksRPCClient{&hooksRPCClient{
+		client: rpc.NewClientWithCodec(newWasmClientCodec(sup.wasm.call)),
+		log:    wrappedLogger,
+		guard:  guard,
+	}}
+
+	sup.hooks = &hooksTimerLayer{pluginInfo.Manifest.Id, hooks, metrics}
+
+	impl, err := sup.hooks.Implemented()
+	if err != nil {
+		return nil, err
+	}
+	for _, hookName := range impl {
+		if hookId, ok := hookNameToId[hookName]; ok {
+			sup.implemented[hookId] = true
+		}
+	}
+
+	return &sup, nil
+}
+
 func (sup *supervisor) Shutdown() {
 	sup.lock.RLock()
 	defer sup.lock.RUnlock()
+	if sup.wasm != nil {
+		sup.wasm.close()
+		if sup.appDriver != nil {
+			sup.appDriver.ShutdownConns(sup.pluginID)
+		}
+		return
+	}
+
 	if sup.client != nil {
 		// For reattached plugins, Kill() is mostly a no-op, so manually clean up the
 		// underlying rpcClient. This might be something to upstream unless we're doing
@@ -182,6 +298,10 @@ func (sup *supervisor) Shutdown() {
 		sup.client.Kill()
 	}
 
+	if sup.monitor != nil {
+		sup.monitor.close()
+	}
+
 	// Wait for API RPC server and DB RPC server to exit.
 	// And then shutdown conns.
 	if sup.hooksClient != nil {
@@ -198,8 +318,17 @@ func (sup *supervisor) Hooks() Hooks {
 	return sup.hooks
 }
 
-// PerformHealthCheck checks the plugin through an an RPC ping.
+// PerformHealthCheck checks the plugin through an an RPC ping, and checks that the plugin
+// process stays within its resource limits.
 func (sup *supervisor) PerformHealthCheck() error {
+	// The resources are checked first, so that a plugin killed for exceeding its memory limit
+	// is reported as such rather than as not responding.
+	if sup.monitor != nil {
+		if err := sup.monitor.check(); err != nil {
+			return err
+		}
+	}
+
 	// No need for a lock here because Ping is read-locked.
 	if pingErr := sup.Ping(); pingErr != nil {
 		for pingFails := 1; pingFails < HealthCheckPingFailLimit; pingFails++ {
@@ -220,6 +349,10 @@ func (sup *supervisor) PerformHealthCheck() error {
 func (sup *supervisor) Ping() error {
 	sup.lock.RLock()
 	defer sup.lock.RUnlock()
+	if sup.wasm != nil {
+		return sup.wasm.ping()
+	}
+
 	client, err := sup.client.Client()
 	if err != nil {
 		return err
@@ -228,6 +361,14 @@ func (sup *supervisor) Ping() error {
 	return client.Ping()
 }
 
+// ResourceUsage returns the resources used by the plugin process when last checked, if known.
+func (sup *supervisor) ResourceUsage() *model.PluginResourceUsage {
+	if sup.monitor == nil {
+		return nil
+	}
+	return sup.monitor.resourceUsage()
+}
+
 func (sup *supervisor) Implements(hookId int) bool {
 	sup.lock.RLock()
 	defer sup.lock.RUnlock()
@@ -71,3 +71,34 @@ func CompileGoTest(t *testing.T, sourceCode, outputPath string) {
 	}
 	require.NoError(t, err, "failed to compile go")
 }
+
+// CompileWasm compiles a plugin to a WebAssembly module for the wasip1 target.
+func CompileWasm(t *testing.T, sourceCode, outputPath string) {
+	dir, err := os.MkdirTemp(".", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	dir, err = filepath.Abs(dir)
+	require.NoError(t, err)
+
+	// Write out main.go given the source code.
+	main := filepath.Join(dir, "main.go")
+	err = os.WriteFile(main, []byte(sourceCode), 0600)
+	require.NoError(t, err)
+
+	_, sourceFile, _, ok := runtime.Caller(0)
+	require.True(t, ok)
+	serverPath := filepath.Dir(filepath.Dir(sourceFile))
+
+	out := &bytes.Buffer{}
+	cmd := exec.Command("go", "build", "-buildmode=c-shared", "-o", outputPath, main)
+	cmd.Dir = serverPath
+	cmd.Env = append(os.Environ(), "GOOS=wasip1", "GOARCH=wasm")
+	cmd.Stdout = out
+	cmd.Stderr = out
+	err = cmd.Run()
+	if err != nil {
+		t.Log("Go compile errors:\n", out.String())
+	}
+	require.NoError(t, err, "failed to compile go")
+}
@@ -0,0 +1,223 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+//go:build wasip1
+
+package plugin
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"net/http"
+	"net/rpc"
+	"unsafe"
+
+	"github.com/mattermost/mattermost/server/public/model"
+)
+
+var wasmHooksServer *rpc.Server
+
+// wasmBuffers keeps the buffers shared with the server from being garbage collected.
+var wasmBuffers = map[uint32][]byte{}
+
+// WasmMain starts the plugin when compiled to WebAssembly, taking the place of ClientMain.
+//
+// The server never runs the main function of WebAssembly plugins, so WasmMain must be called
+// from an init function of the main package:
+//
+//	func init() {
+//		plugin.WasmMain(&MyPlugin{})
+//	}
+//
+//	func main() {}
+//
+// Goroutines started by the plugin only run while the server is calling one of its hooks.
+func WasmMain(pluginImplementation any) {
+	impl, ok := pluginImplementation.(interface {
+		SetAPI(api API)
+		SetDriver(driver Driver)
+	})
+	if !ok {
+		panic("Plugin implementation given must embed plugin.MattermostPlugin")
+	}
+	impl.SetAPI(nil)
+	impl.SetDriver(nil)
+
+	wasmHooksServer = rpc.NewServer()
+	if err := wasmHooksServer.RegisterName("Plugin", &wasmHooksRPCServer{&hooksRPCServer{impl: pluginImplementation}}); err != nil {
+		panic("failed to register plugin hooks: " + err.Error())
+	}
+}
+
+func wasmPin(b []byte) uint32 {
+	ptr := uint32(uintptr(unsafe.Pointer(unsafe.SliceData(b))))
+	wasmBuffers[ptr] = b
+	return ptr
+}
+
+//go:wasmexport mattermost_alloc
+func wasmExportAlloc(size uint32) uint32 {
+	return wasmPin(make([]byte, size, max(size, 1)))
+}
+
+//go:wasmexport mattermost_free
+func wasmExportFree(ptr uint32) {
+	delete(wasmBuffers, ptr)
+}
+
+//go:wasmexport mattermost_call
+func wasmExportCall(ptr, size uint32) uint64 {
+	request := wasmBuffers[ptr][:size]
+	delete(wasmBuffers, ptr)
+
+	response := serveWasmRequest(wasmHooksServer, request)
+	if len(response) == 0 {
+		return 0
+	}
+	return uint64(wasmPin(response))<<32 | uint64(len(response))
+}
+
+//go:wasmimport mattermost call
+func wasmHostCall(service uint32, request unsafe.Pointer, size uint32) uint32
+
+//go:wasmimport mattermost result
+func wasmHostResult(response unsafe.Pointer)
+
+func newWasmHostClient(service uint32) *rpc.Client {
+	return rpc.NewClientWithCodec(newWasmClientCodec(func(request []byte) ([]byte, error) {
+		size := wasmHostCall(service, unsafe.Pointer(unsafe.SliceData(request)), uint32(len(request)))
+		if size == 0 {
+			return nil, io.ErrUnexpectedEOF
+		}
+		response := make([]byte, size)
+		wasmHostResult(unsafe.Pointer(unsafe.SliceData(response)))
+		return response, nil
+	}))
+}
+
+// wasmHooksRPCServer serves the hooks of the plugin to the server.
+type wasmHooksRPCServer struct {
+	*hooksRPCServer
+}
+
+func (s *wasmHooksRPCServer) OnActivate(args *Z_OnActivateArgs, returns *Z_OnActivateReturns) error {
+	s.apiRPCClient = &apiRPCClient{
+		client: newWasmHostClient(wasmServiceAPI),
+	}
+
+	dbClient := &dbRPCClient{
+		client: newWasmHostClient(wasmServiceDB),
+	}
+
+	returns.A = encodableError(s.activate(&wasmAPIRPCClient{s.apiRPCClient}, dbClient))
+	return nil
+}
+
+func (s *wasmHooksRPCServer) ServeHTTP(args *Z_WasmServeHTTPArgs, returns *Z_WasmServeHTTPReturns) error {
+	w := newWasmResponseWriter()
+	r := args.Request
+	r.Body = io.NopCloser(bytes.NewReader(args.RequestBody))
+
+	if hook, ok := s.impl.(interface {
+		ServeHTTP(c *Context, w http.ResponseWriter, r *http.Request)
+	}); ok {
+		hook.ServeHTTP(args.Context, w, r)
+	} else {
+		http.NotFound(w, r)
+	}
+
+	w.result(returns)
+	return nil
+}
+
+func (s *wasmHooksRPCServer) ServeMetrics(args *Z_WasmServeHTTPArgs, returns *Z_WasmServeHTTPReturns) error {
+	w := newWasmResponseWriter()
+	r := args.Request
+	r.Body = io.NopCloser(bytes.NewReader(args.RequestBody))
+
+	if hook, ok := s.impl.(interface {
+		ServeMetrics(c *Context, w http.ResponseWriter, r *http.Request)
+	}); ok {
+		hook.ServeMetrics(args.Context, w, r)
+	} else {
+		http.NotFound(w, r)
+	}
+
+	w.result(returns)
+	return nil
+}
+
+func (s *wasmHooksRPCServer) FileWillBeUploaded(args *Z_WasmFileWillBeUploadedArgs, returns *Z_WasmFileWillBeUploadedReturns) error {
+	var replacement bytes.Buffer
+	if hook, ok := s.impl.(interface {
+		FileWillBeUploaded(c *Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string)
+	}); ok {
+		returns.A, returns.B = hook.FileWillBeUploaded(args.A, args.B, bytes.NewReader(args.File), &replacement)
+	} else {
+		return fmt.Errorf("hook FileWillBeUploaded called but not implemented")
+	}
+	returns.Replacement = replacement.Bytes()
+	return nil
+}
+
+func (s *wasmHooksRPCServer) FileWillBeDownloaded(args *Z_WasmFileWillBeDownloadedArgs, returns *Z_WasmFileWillBeDownloadedReturns) error {
+	var replacement bytes.Buffer
+	if hook, ok := s.impl.(interface {
+		FileWillBeDownloaded(c *Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string
+	}); ok {
+		returns.A = hook.FileWillBeDownloaded(args.A, args.B, args.C, args.D, bytes.NewReader(args.File), &replacement)
+	} else {
+		return fmt.Errorf("hook FileWillBeDownloaded called but not implemented")
+	}
+	returns.Replacement = replacement.Bytes()
+	return nil
+}
+
+// wasmResponseWriter buffers the response of the plugin to an HTTP request.
+type wasmResponseWriter struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func newWasmResponseWriter() *wasmResponseWriter {
+	return &wasmResponseWriter{header: http.Header{}}
+}
+
+func (w *wasmResponseWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *wasmResponseWriter) Write(b []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.WriteHeader(http.StatusOK)
+	}
+	return w.body.Write(b)
+}
+
+func (w *wasmResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+}
+
+func (w *wasmResponseWriter) result(returns *Z_WasmServeHTTPReturns) {
+	returns.StatusCode = w.statusCode
+	returns.Header = w.header
+	returns.Body = w.body.Bytes()
+}
+
+// wasmAPIRPCClient calls the API of the server, short of the methods that aren't available to
+// WebAssembly plugins.
+type wasmAPIRPCClient struct {
+	*apiRPCClient
+}
+
+func (g *wasmAPIRPCClient) InstallPlugin(file io.Reader, replace bool) (*model.Manifest, *model.AppError) {
+	return nil, newWasmNotSupportedError("InstallPlugin")
+}
+
+func (g *wasmAPIRPCClient) UploadData(us *model.UploadSession, rd io.Reader) (*model.FileInfo, error) {
+	return nil, newWasmNotSupportedError("UploadData")
+}
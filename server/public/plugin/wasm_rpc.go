@@ -0,0 +1,326 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"encoding/gob"
+	"io"
+	"net/http"
+	"net/rpc"
+	"sync"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+// WebAssembly plugins exchange the same net/rpc messages as plugins launched through go-plugin,
+// but each message is handed across the module boundary as a whole rather than streamed over a
+// connection. The guest calls the server through the services below.
+const (
+	wasmServiceAPI uint32 = iota
+	wasmServiceDB
+)
+
+// wasmClientCodec is a net/rpc client codec delivering each request to transport, which returns
+// the encoded response synchronously. Requests are delivered from their own goroutine, since
+// serving a request may well issue another through the same client.
+type wasmClientCodec struct {
+	transport func(request []byte) ([]byte, error)
+	responses chan *gob.Decoder
+	response  *gob.Decoder
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+func newWasmClientCodec(transport func(request []byte) ([]byte, error)) *wasmClientCodec {
+	return &wasmClientCodec{
+		transport: transport,
+		responses: make(chan *gob.Decoder),
+		closed:    make(chan struct{}),
+	}
+}
+
+func (c *wasmClientCodec) WriteRequest(r *rpc.Request, body any) error {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	if err := enc.Encode(r); err != nil {
+		return err
+	}
+	if err := enc.Encode(body); err != nil {
+		return err
+	}
+
+	seq, serviceMethod := r.Seq, r.ServiceMethod
+	go func() {
+		response, err := c.transport(buf.Bytes())
+		if err != nil {
+			response = encodeWasmErrorResponse(&rpc.Response{ServiceMethod: serviceMethod, Seq: seq, Error: err.Error()})
+		}
+
+		select {
+		case c.responses <- gob.NewDecoder(bytes.NewReader(response)):
+		case <-c.closed:
+		}
+	}()
+
+	return nil
+}
+
+// encodeWasmErrorResponse encodes a response failing the call, as net/rpc servers do.
+func encodeWasmErrorResponse(r *rpc.Response) []byte {
+	var buf bytes.Buffer
+	enc := gob.NewEncoder(&buf)
+	_ = enc.Encode(r)
+	_ = enc.Encode(struct{}{})
+	return buf.Bytes()
+}
+
+func (c *wasmClientCodec) ReadResponseHeader(r *rpc.Response) error {
+	select {
+	case c.response = <-c.responses:
+		return c.response.Decode(r)
+	case <-c.closed:
+		return io.EOF
+	}
+}
+
+func (c *wasmClientCodec) ReadResponseBody(body any) error {
+	return c.response.Decode(body)
+}
+
+func (c *wasmClientCodec) Close() error {
+	c.closeOnce.Do(func() { close(c.closed) })
+	return nil
+}
+
+// wasmServerCodec is a net/rpc server codec serving the single request it was created with.
+type wasmServerCodec struct {
+	dec *gob.Decoder
+	enc *gob.Encoder
+	out bytes.Buffer
+}
+
+func newWasmServerCodec(request []byte) *wasmServerCodec {
+	c := &wasmServerCodec{dec: gob.NewDecoder(bytes.NewReader(request))}
+	c.enc = gob.NewEncoder(&c.out)
+	return c
+}
+
+// serveWasmRequest serves a single encoded request and returns the encoded response.
+func serveWasmRequest(server *rpc.Server, request []byte) []byte {
+	c := newWasmServerCodec(request)
+	if err := server.ServeRequest(c); err != nil && c.out.Len() == 0 {
+		// The request couldn't even be decoded, so there is no call to answer.
+		return nil
+	}
+	return c.out.Bytes()
+}
+
+func (c *wasmServerCodec) ReadRequestHeader(r *rpc.Request) error {
+	return c.dec.Decode(r)
+}
+
+func (c *wasmServerCodec) ReadRequestBody(body any) error {
+	return c.dec.Decode(body)
+}
+
+func (c *wasmServerCodec) WriteResponse(r *rpc.Response, body any) error {
+	if err := c.enc.Encode(r); err != nil {
+		return err
+	}
+	return c.enc.Encode(body)
+}
+
+func (c *wasmServerCodec) Close() error {
+	return nil
+}
+
+func newWasmNotSupportedError(where string) *model.AppError {
+	return model.NewAppError(where, "plugin.api.wasm_not_supported.app_error", nil, "", http.StatusNotImplemented)
+}
+
+// The hooks and API methods streaming data over extra go-plugin connections carry the data
+// in full instead when the plugin runs in the WebAssembly runtime.
+
+type Z_WasmServeHTTPArgs struct {
+	Context     *Context
+	Request     *http.Request
+	RequestBody []byte
+}
+
+type Z_WasmServeHTTPReturns struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type Z_WasmFileWillBeUploadedArgs struct {
+	A    *Context
+	B    *model.FileInfo
+	File []byte
+}
+
+type Z_WasmFileWillBeUploadedReturns struct {
+	A           *model.FileInfo
+	B           string
+	Replacement []byte
+}
+
+type Z_WasmFileWillBeDownloadedArgs struct {
+	A    *Context
+	B    *model.FileInfo
+	C    string
+	D    model.FileDownloadType
+	File []byte
+}
+
+type Z_WasmFileWillBeDownloadedReturns struct {
+	A           string
+	Replacement []byte
+}
+
+// wasmHooksRPCClient calls the hooks of a WebAssembly plugin.
+type wasmHooksRPCClient struct {
+	*hooksRPCClient
+}
+
+func (g *wasmHooksRPCClient) OnActivate() error {
+	_returns := &Z_OnActivateReturns{}
+	if err := g.client.Call("Plugin.OnActivate", &Z_OnActivateArgs{}, _returns); err != nil {
+		g.log.Error("RPC call to OnActivate plugin failed.", mlog.Err(err))
+		return err
+	}
+	return _returns.A
+}
+
+func (g *wasmHooksRPCClient) ServeHTTP(c *Context, w http.ResponseWriter, r *http.Request) {
+	g.serveHTTP("ServeHTTP", ServeHTTPID, c, w, r)
+}
+
+func (g *wasmHooksRPCClient) ServeMetrics(c *Context, w http.ResponseWriter, r *http.Request) {
+	g.serveHTTP("ServeMetrics", ServeMetricsID, c, w, r)
+}
+
+func (g *wasmHooksRPCClient) serveHTTP(hookName string, hookID int, c *Context, w http.ResponseWriter, r *http.Request) {
+	if !g.implemented[hookID] {
+		http.NotFound(w, r)
+		return
+	}
+	if g.guard.skip(hookName) {
+		http.Error(w, "503 service unavailable", http.StatusServiceUnavailable)
+		return
+	}
+
+	var body []byte
+	if r.Body != nil {
+		var err error
+		if body, err = io.ReadAll(r.Body); err != nil {
+			g.log.Error("Plugin failed to "+hookName+", couldn't read request body", mlog.Err(err))
+			http.Error(w, "400 bad request", http.StatusBadRequest)
+			return
+		}
+	}
+
+	forwardedRequest := &http.Request{
+		Method:     r.Method,
+		URL:        r.URL,
+		Proto:      r.Proto,
+		ProtoMajor: r.ProtoMajor,
+		ProtoMinor: r.ProtoMinor,
+		Header:     r.Header,
+		Host:       r.Host,
+		RemoteAddr: r.RemoteAddr,
+		RequestURI: r.RequestURI,
+	}
+
+	_returns := &Z_WasmServeHTTPReturns{}
+	if err := g.client.Call("Plugin."+hookName, &Z_WasmServeHTTPArgs{
+		Context:     c,
+		Request:     forwardedRequest,
+		RequestBody: body,
+	}, _returns); err != nil {
+		g.log.Error("Plugin failed to "+hookName+", RPC call failed", mlog.Err(err))
+		http.Error(w, "500 internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	for key, values := range _returns.Header {
+		w.Header()[key] = values
+	}
+	if _returns.StatusCode == 0 {
+		_returns.StatusCode = http.StatusOK
+	}
+	w.WriteHeader(_returns.StatusCode)
+	if _, err := w.Write(_returns.Body); err != nil {
+		g.log.Warn("Plugin failed to "+hookName+", couldn't write response", mlog.Err(err))
+	}
+}
+
+func (g *wasmHooksRPCClient) FileWillBeUploaded(c *Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
+	if !g.implemented[FileWillBeUploadedID] || g.guard.skip("FileWillBeUploaded") {
+		return info, ""
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		g.log.Error("Plugin failed to read uploaded file.", mlog.Err(err))
+		return info, ""
+	}
+
+	_args := &Z_WasmFileWillBeUploadedArgs{c, info, data}
+	_returns := &Z_WasmFileWillBeUploadedReturns{A: info}
+	if err := g.call("FileWillBeUploaded", _args, _returns); err != nil {
+		g.log.Error("RPC call FileWillBeUploaded to plugin failed.", mlog.Err(err))
+	}
+
+	if len(_returns.Replacement) > 0 {
+		if _, err := output.Write(_returns.Replacement); err != nil {
+			g.log.Error("Error writing replacement file.", mlog.Err(err))
+		}
+	}
+
+	return _returns.A, _returns.B
+}
+
+func (g *wasmHooksRPCClient) FileWillBeDownloaded(c *Context, info *model.FileInfo, userID string, downloadType model.FileDownloadType, file io.Reader, output io.Writer) string {
+	if !g.implemented[FileWillBeDownloadedID] || g.guard.skip("FileWillBeDownloaded") {
+		return ""
+	}
+
+	data, err := io.ReadAll(file)
+	if err != nil {
+		g.log.Error("Plugin failed to read downloaded file.", mlog.Err(err))
+		return ""
+	}
+
+	_args := &Z_WasmFileWillBeDownloadedArgs{c, info, userID, downloadType, data}
+	_returns := &Z_WasmFileWillBeDownloadedReturns{}
+	if err := g.call("FileWillBeDownloaded", _args, _returns); err != nil {
+		g.log.Error("RPC call FileWillBeDownloaded to plugin failed.", mlog.Err(err))
+	}
+
+	if len(_returns.Replacement) > 0 {
+		if _, err := output.Write(_returns.Replacement); err != nil {
+			g.log.Error("Error writing replacement file.", mlog.Err(err))
+		}
+	}
+
+	return _returns.A
+}
+
+// wasmAPIRPCServer serves the API calls of a WebAssembly plugin.
+type wasmAPIRPCServer struct {
+	*apiRPCServer
+}
+
+func (s *wasmAPIRPCServer) InstallPlugin(args *Z_InstallPluginArgs, returns *Z_InstallPluginReturns) error {
+	returns.B = newWasmNotSupportedError("InstallPlugin")
+	return nil
+}
+
+func (s *wasmAPIRPCServer) UploadData(args *Z_UploadDataArgs, returns *Z_UploadDataReturns) error {
+	returns.B = encodableError(newWasmNotSupportedError("UploadData"))
+	return nil
+}
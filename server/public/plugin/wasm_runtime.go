@@ -0,0 +1,282 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"net/rpc"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/tetratelabs/wazero"
+	"github.com/tetratelabs/wazero/api"
+	"github.com/tetratelabs/wazero/imports/wasi_snapshot_preview1"
+)
+
+// WasmLimits bounds the resources available to each plugin running in the WebAssembly runtime.
+// Zero values leave the corresponding resource unbounded.
+type WasmLimits struct {
+	// MaxMemoryMB caps the linear memory of the plugin.
+	MaxMemoryMB int
+
+	// MaxCallDuration caps the time the plugin may spend executing a single hook, not counting
+	// the time the server spends serving its API calls. Plugins exceeding it are terminated.
+	MaxCallDuration time.Duration
+}
+
+var errWasmCallDuration = errors.New("plugin exceeded the maximum duration of a call")
+
+// wasmPlugin is a single instance of a WebAssembly plugin.
+//
+// The instance executes one call at a time, but a call suspended in an API call may be
+// interrupted by another, since serving the API call may well invoke a hook of the same plugin.
+// Calls nest like a stack: a suspended call resumes only once every call started on top of it
+// has returned.
+type wasmPlugin struct {
+	runtime  wazero.Runtime
+	module   api.Module
+	limits   WasmLimits
+	services map[uint32]*rpc.Server
+
+	lock    sync.Mutex
+	cond    *sync.Cond
+	depth   int
+	busy    bool
+	pending []byte
+}
+
+type wasmCallKey struct{}
+
+// wasmCall tracks a call into the plugin: its position on the stack of calls and the execution
+// time it has left.
+type wasmCall struct {
+	depth     int
+	remaining time.Duration
+	started   time.Time
+	timer     *time.Timer
+	cancel    context.CancelCauseFunc
+}
+
+func (c *wasmCall) start() {
+	if c.remaining <= 0 {
+		return
+	}
+	c.started = time.Now()
+	c.timer = time.AfterFunc(c.remaining, func() { c.cancel(errWasmCallDuration) })
+}
+
+func (c *wasmCall) pause() {
+	if c.timer == nil {
+		return
+	}
+	c.timer.Stop()
+	c.timer = nil
+	// Keep the remaining time positive so a call suspended right at its deadline stays bounded.
+	c.remaining = max(c.remaining-time.Since(c.started), time.Nanosecond)
+}
+
+// newWasmPlugin instantiates the given WebAssembly module, which serves its hooks and calls the
+// given services of the server.
+func newWasmPlugin(code []byte, limits WasmLimits, stdout, stderr io.Writer, services map[uint32]*rpc.Server) (*wasmPlugin, error) {
+	ctx := context.Background()
+
+	config := wazero.NewRuntimeConfig().WithCloseOnContextDone(true)
+	if limits.MaxMemoryMB > 0 {
+		// Memory is allocated in pages of 64 KiB.
+		config = config.WithMemoryLimitPages(uint32(limits.MaxMemoryMB) * 16)
+	}
+
+	wp := &wasmPlugin{
+		runtime:  wazero.NewRuntimeWithConfig(ctx, config),
+		limits:   limits,
+		services: services,
+	}
+	wp.cond = sync.NewCond(&wp.lock)
+
+	if _, err := wasi_snapshot_preview1.Instantiate(ctx, wp.runtime); err != nil {
+		wp.close()
+		return nil, errors.Wrap(err, "failed to instantiate WASI")
+	}
+
+	_, err := wp.runtime.NewHostModuleBuilder("mattermost").
+		NewFunctionBuilder().WithFunc(wp.hostCall).Export("call").
+		NewFunctionBuilder().WithFunc(wp.hostResult).Export("result").
+		Instantiate(ctx)
+	if err != nil {
+		wp.close()
+		return nil, errors.Wrap(err, "failed to instantiate host functions")
+	}
+
+	compiled, err := wp.runtime.CompileModule(ctx, code)
+	if err != nil {
+		wp.close()
+		return nil, errors.Wrap(err, "failed to compile plugin")
+	}
+
+	// Instantiating the module runs the package initializers of the plugin, which register its
+	// hooks, so it is subject to the same limits as any other call.
+	call, ctx := wp.enter()
+	defer wp.exit(call)
+
+	wp.module, err = wp.runtime.InstantiateModule(ctx, compiled, wazero.NewModuleConfig().
+		WithStartFunctions("_initialize").
+		WithStdout(stdout).
+		WithStderr(stderr).
+		WithSysWalltime().
+		WithSysNanotime().
+		WithRandSource(rand.Reader))
+	if err != nil {
+		wp.close()
+		return nil, wp.callError(ctx, err, "failed to instantiate plugin")
+	}
+
+	return wp, nil
+}
+
+// enter waits until the plugin is idle, or suspended in an API call, and then starts a new call.
+func (wp *wasmPlugin) enter() (*wasmCall, context.Context) {
+	wp.lock.Lock()
+	for wp.busy {
+		wp.cond.Wait()
+	}
+	wp.depth++
+	wp.busy = true
+	call := &wasmCall{depth: wp.depth, remaining: wp.limits.MaxCallDuration}
+	wp.lock.Unlock()
+
+	ctx, cancel := context.WithCancelCause(context.Background())
+	call.cancel = cancel
+	call.start()
+
+	return call, context.WithValue(ctx, wasmCallKey{}, call)
+}
+
+func (wp *wasmPlugin) exit(call *wasmCall) {
+	call.pause()
+	call.cancel(nil)
+
+	wp.lock.Lock()
+	wp.depth--
+	wp.busy = false
+	wp.cond.Broadcast()
+	wp.lock.Unlock()
+}
+
+// suspend lets other calls run while the current one waits for the server.
+func (wp *wasmPlugin) suspend(call *wasmCall) {
+	call.pause()
+
+	wp.lock.Lock()
+	wp.busy = false
+	wp.cond.Broadcast()
+	wp.lock.Unlock()
+}
+
+// resume waits until every call started on top of the given one has returned.
+func (wp *wasmPlugin) resume(call *wasmCall) {
+	wp.lock.Lock()
+	for wp.busy || wp.depth != call.depth {
+		wp.cond.Wait()
+	}
+	wp.busy = true
+	wp.lock.Unlock()
+
+	call.start()
+}
+
+func (wp *wasmPlugin) callError(ctx context.Context, err error, message string) error {
+	if cause := context.Cause(ctx); errors.Is(cause, errWasmCallDuration) {
+		return cause
+	}
+	return errors.Wrap(err, message)
+}
+
+// call delivers an encoded request to the plugin and returns its encoded response.
+func (wp *wasmPlugin) call(request []byte) ([]byte, error) {
+	call, ctx := wp.enter()
+	defer wp.exit(call)
+
+	if wp.module.IsClosed() {
+		return nil, errors.New("plugin is no longer running")
+	}
+
+	results, err := wp.module.ExportedFunction("mattermost_alloc").Call(ctx, uint64(len(request)))
+	if err != nil {
+		return nil, wp.callError(ctx, err, "failed to allocate request")
+	}
+	ptr := uint32(results[0])
+	if !wp.module.Memory().Write(ptr, request) {
+		return nil, errors.New("request allocated out of memory bounds")
+	}
+
+	// The plugin takes ownership of the request and returns a response it keeps until freed.
+	results, err = wp.module.ExportedFunction("mattermost_call").Call(ctx, uint64(ptr), uint64(len(request)))
+	if err != nil {
+		return nil, wp.callError(ctx, err, "call failed")
+	}
+	ptr, size := uint32(results[0]>>32), uint32(results[0])
+	if size == 0 {
+		return nil, errors.New("plugin couldn't decode the request")
+	}
+
+	response, ok := wp.module.Memory().Read(ptr, size)
+	if !ok {
+		return nil, errors.New("response out of memory bounds")
+	}
+	response = bytes.Clone(response)
+
+	if _, err := wp.module.ExportedFunction("mattermost_free").Call(ctx, uint64(ptr)); err != nil {
+		return nil, wp.callError(ctx, err, "failed to free response")
+	}
+
+	return response, nil
+}
+
+// hostCall serves a request of the plugin to one of the services of the server, and returns the
+// size of the response, which the plugin then retrieves through hostResult.
+func (wp *wasmPlugin) hostCall(ctx context.Context, m api.Module, service, ptr, size uint32) uint32 {
+	request, ok := m.Memory().Read(ptr, size)
+	if !ok {
+		panic(errors.New("request out of memory bounds"))
+	}
+	request = bytes.Clone(request)
+
+	server, ok := wp.services[service]
+	if !ok {
+		// Answer with the error of a server without any service registered.
+		server = rpc.NewServer()
+	}
+
+	call := ctx.Value(wasmCallKey{}).(*wasmCall)
+	wp.suspend(call)
+	response := serveWasmRequest(server, request)
+	wp.resume(call)
+
+	wp.pending = response
+	return uint32(len(response))
+}
+
+// hostResult copies the response of the last request into the memory of the plugin.
+func (wp *wasmPlugin) hostResult(ctx context.Context, m api.Module, ptr uint32) {
+	response := wp.pending
+	wp.pending = nil
+	if !m.Memory().Write(ptr, response) {
+		panic(errors.New("response out of memory bounds"))
+	}
+}
+
+func (wp *wasmPlugin) ping() error {
+	if wp.module == nil || wp.module.IsClosed() {
+		return errors.New("plugin is no longer running")
+	}
+	return nil
+}
+
+func (wp *wasmPlugin) close() {
+	wp.runtime.Close(context.Background())
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package plugin
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/utils"
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+const wasmTestPlugin = `
+	package main
+
+	import (
+		"io"
+		"net/http"
+		"strings"
+
+		"github.com/mattermost/mattermost/server/public/model"
+		"github.com/mattermost/mattermost/server/public/plugin"
+	)
+
+	type MyPlugin struct {
+		plugin.MattermostPlugin
+	}
+
+	func (p *MyPlugin) OnActivate() error {
+		if _, appErr := p.API.GetUser("user"); appErr != nil {
+			return appErr
+		}
+		return nil
+	}
+
+	func (p *MyPlugin) ServeHTTP(c *plugin.Context, w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Path", r.URL.Path)
+		w.WriteHeader(http.StatusCreated)
+		w.Write([]byte(strings.ToUpper(string(body))))
+	}
+
+	func (p *MyPlugin) MessageWillBePosted(c *plugin.Context, post *model.Post) (*model.Post, string) {
+		switch post.Message {
+		case "loop":
+			for {
+			}
+		case "nested":
+			created, appErr := p.API.CreatePost(&model.Post{Message: "inner"})
+			if appErr != nil {
+				return nil, appErr.Error()
+			}
+			post.Message = created.Message
+		default:
+			post.Message += " from wasm"
+		}
+		return post, ""
+	}
+
+	func (p *MyPlugin) FileWillBeUploaded(c *plugin.Context, info *model.FileInfo, file io.Reader, output io.Writer) (*model.FileInfo, string) {
+		data, _ := io.ReadAll(file)
+		output.Write([]byte(strings.ToUpper(string(data))))
+		info.Name = "replaced.txt"
+		return info, ""
+	}
+
+	func init() {
+		plugin.WasmMain(&MyPlugin{})
+	}
+
+	func main() {}
+`
+
+type wasmTestAPI struct {
+	API
+	hooks func() Hooks
+}
+
+func (api *wasmTestAPI) GetUser(userID string) (*model.User, *model.AppError) {
+	return &model.User{Id: userID}, nil
+}
+
+// CreatePost runs the MessageWillBePosted hook of the calling plugin, which is suspended in
+// this very call.
+func (api *wasmTestAPI) CreatePost(post *model.Post) (*model.Post, *model.AppError) {
+	post, rejection := api.hooks().MessageWillBePosted(&Context{}, post)
+	if rejection != "" {
+		return nil, model.NewAppError("CreatePost", "rejected", nil, rejection, http.StatusBadRequest)
+	}
+	return post, nil
+}
+
+func newWasmTestSupervisor(t *testing.T, manifest string, limits WasmLimits) (*supervisor, error) {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	utils.CompileWasm(t, wasmTestPlugin, filepath.Join(dir, "plugin.wasm"))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(manifest), 0600))
+
+	api := &wasmTestAPI{}
+	sup, err := newWasmSupervisor(model.BundleInfoForPath(dir), api, nil, mlog.CreateConsoleTestLogger(t), nil, limits, nil)
+	if sup != nil {
+		api.hooks = sup.Hooks
+		t.Cleanup(sup.Shutdown)
+	}
+	return sup, err
+}
+
+func TestWasmSupervisor(t *testing.T) {
+	sup, err := newWasmTestSupervisor(t, `{"id": "foo", "server": {"wasm": "plugin.wasm"}}`, WasmLimits{MaxMemoryMB: 256, MaxCallDuration: time.Second})
+	require.NoError(t, err)
+
+	hooks := sup.Hooks()
+	require.NoError(t, hooks.OnActivate())
+	require.NoError(t, sup.Ping())
+	assert.True(t, sup.Implements(ServeHTTPID))
+	assert.False(t, sup.Implements(OnDeactivateID))
+
+	t.Run("ServeHTTP", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		hooks.ServeHTTP(&Context{}, w, httptest.NewRequest(http.MethodPost, "/plugins/foo/echo", strings.NewReader("hello")))
+		assert.Equal(t, http.StatusCreated, w.Code)
+		assert.Equal(t, "/plugins/foo/echo", w.Header().Get("X-Path"))
+		assert.Equal(t, "HELLO", w.Body.String())
+	})
+
+	t.Run("FileWillBeUploaded", func(t *testing.T) {
+		var output bytes.Buffer
+		info, rejection := hooks.FileWillBeUploaded(&Context{}, &model.FileInfo{Name: "file.txt"}, strings.NewReader("content"), &output)
+		assert.Empty(t, rejection)
+		assert.Equal(t, "replaced.txt", info.Name)
+		assert.Equal(t, "CONTENT", output.String())
+	})
+
+	t.Run("nested calls", func(t *testing.T) {
+		post, rejection := hooks.MessageWillBePosted(&Context{}, &model.Post{Message: "nested"})
+		assert.Empty(t, rejection)
+		assert.Equal(t, "inner from wasm", post.Message)
+	})
+
+	t.Run("call duration limit", func(t *testing.T) {
+		// The plugin is terminated, leaving the post unchanged.
+		post, rejection := hooks.MessageWillBePosted(&Context{}, &model.Post{Message: "loop"})
+		assert.Empty(t, rejection)
+		assert.Equal(t, "loop", post.Message)
+		assert.Error(t, sup.Ping())
+	})
+}
+
+func TestWasmSupervisorCapabilities(t *testing.T) {
+	sup, err := newWasmTestSupervisor(t, `{"id": "foo", "server": {"wasm": "plugin.wasm"}, "capabilities": []}`, WasmLimits{})
+	require.NoError(t, err)
+
+	err = sup.Hooks().OnActivate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "plugin.api.capability_not_declared.app_error")
+}
+
+func TestWasmSupervisorInvalidModule(t *testing.T) {
+	dir, err := os.MkdirTemp("", "")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	logger := mlog.CreateConsoleTestLogger(t)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "server": {"wasm": "../plugin.wasm"}}`), 0600))
+	sup, err := newWasmSupervisor(model.BundleInfoForPath(dir), nil, nil, logger, nil, WasmLimits{}, nil)
+	assert.Nil(t, sup)
+	assert.Error(t, err)
+
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.json"), []byte(`{"id": "foo", "server": {"wasm": "plugin.wasm"}}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "plugin.wasm"), []byte("not a module"), 0600))
+	sup, err = newWasmSupervisor(model.BundleInfoForPath(dir), nil, nil, logger, nil, WasmLimits{}, nil)
+	assert.Nil(t, sup)
+	assert.Error(t, err)
+}
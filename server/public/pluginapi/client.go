@@ -17,6 +17,7 @@ type Client struct {
 	SlashCommand  SlashCommandService
 	OAuth         OAuthService
 	Emoji         EmojiService
+	Event         EventService
 	File          FileService
 	Frontend      FrontendService
 	Group         GroupService
@@ -47,6 +48,7 @@ func NewClient(api plugin.API, driver plugin.Driver) *Client {
 		SlashCommand:  SlashCommandService{api: api},
 		OAuth:         OAuthService{api: api},
 		Emoji:         EmojiService{api: api},
+		Event:         EventService{api: api},
 		File:          FileService{api: api},
 		Frontend:      FrontendService{api: api},
 		Group:         GroupService{api: api},
@@ -0,0 +1,52 @@
+package pluginapi
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin"
+)
+
+// EventService exposes methods to exchange events with the other plugins of the server.
+type EventService struct {
+	api plugin.API
+}
+
+// Publish JSON encodes v and delivers it to the other plugins subscribed to topic, through their
+// OnPluginEvent hook.
+//
+// Minimum server version: 10.5
+func (e *EventService) Publish(topic string, v any) error {
+	payload, err := json.Marshal(v)
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal event payload")
+	}
+
+	return normalizeAppErr(e.api.PublishPluginEvent(topic, payload))
+}
+
+// Subscribe starts delivering the events published on topic to the plugin, until it
+// unsubscribes or is deactivated.
+//
+// Minimum server version: 10.5
+func (e *EventService) Subscribe(topic string) error {
+	return normalizeAppErr(e.api.SubscribeToPluginEvents(topic))
+}
+
+// Unsubscribe stops delivering the events published on topic to the plugin.
+//
+// Minimum server version: 10.5
+func (e *EventService) Unsubscribe(topic string) error {
+	return normalizeAppErr(e.api.UnsubscribeFromPluginEvents(topic))
+}
+
+// DecodeEvent JSON decodes the payload of an event published with EventService.Publish into v.
+func DecodeEvent(event model.PluginEvent, v any) error {
+	if err := json.Unmarshal(event.Payload, v); err != nil {
+		return errors.Wrapf(err, "failed to unmarshal payload of event %s", event.Topic)
+	}
+
+	return nil
+}
@@ -0,0 +1,68 @@
+package pluginapi_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/model"
+	"github.com/mattermost/mattermost/server/public/plugin/plugintest"
+	"github.com/mattermost/mattermost/server/public/pluginapi"
+)
+
+func TestPublishEvent(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("PublishPluginEvent", "topic", []byte(`{"name":"value"}`)).Return(nil)
+
+		err := client.Event.Publish("topic", map[string]string{"name": "value"})
+		require.NoError(t, err)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := newAppError()
+
+		api.On("PublishPluginEvent", "topic", []byte(`"value"`)).Return(appErr)
+
+		err := client.Event.Publish("topic", "value")
+		require.Equal(t, appErr, err)
+	})
+
+	t.Run("unsupported payload", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		err := client.Event.Publish("topic", func() {})
+		require.Error(t, err)
+	})
+}
+
+func TestSubscribeToEvents(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("SubscribeToPluginEvents", "topic").Return(nil)
+	api.On("UnsubscribeFromPluginEvents", "topic").Return(nil)
+
+	require.NoError(t, client.Event.Subscribe("topic"))
+	require.NoError(t, client.Event.Unsubscribe("topic"))
+}
+
+func TestDecodeEvent(t *testing.T) {
+	var payload map[string]string
+	err := pluginapi.DecodeEvent(model.PluginEvent{Topic: "topic", Payload: []byte(`{"name":"value"}`)}, &payload)
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"name": "value"}, payload)
+
+	err = pluginapi.DecodeEvent(model.PluginEvent{Topic: "topic", Payload: []byte(`{`)}, &payload)
+	require.Error(t, err)
+}
@@ -60,6 +60,17 @@ func (k *KVService) Set(key string, value interface{}, options ...KVSetOption) (
 		return false, errors.Errorf("'%s' prefix is not allowed for keys", internalKeyPrefix)
 	}
 
+	valueBytes, downstreamOpts, err := marshalKVSet(value, options)
+	if err != nil {
+		return false, err
+	}
+
+	written, appErr := k.api.KVSetWithOptions(key, valueBytes, downstreamOpts)
+	return written, normalizeAppErr(appErr)
+}
+
+// marshalKVSet encodes the given value and options of a write to the KV store.
+func marshalKVSet(value interface{}, options []KVSetOption) ([]byte, model.PluginKVSetOptions, error) {
 	opts := KVSetOptions{}
 	for _, o := range options {
 		o(&opts)
@@ -74,7 +85,7 @@ func (k *KVService) Set(key string, value interface{}, options ...KVSetOption) (
 			var err error
 			valueBytes, err = json.Marshal(value)
 			if err != nil {
-				return false, errors.Wrapf(err, "failed to marshal value %v", value)
+				return nil, model.PluginKVSetOptions{}, errors.Wrapf(err, "failed to marshal value %v", value)
 			}
 		}
 	}
@@ -91,15 +102,14 @@ func (k *KVService) Set(key string, value interface{}, options ...KVSetOption) (
 		} else {
 			data, err := json.Marshal(opts.oldValue)
 			if err != nil {
-				return false, errors.Wrapf(err, "failed to marshal value %v", opts.oldValue)
+				return nil, model.PluginKVSetOptions{}, errors.Wrapf(err, "failed to marshal value %v", opts.oldValue)
 			}
 
 			downstreamOpts.OldValue = data
 		}
 	}
 
-	written, appErr := k.api.KVSetWithOptions(key, valueBytes, downstreamOpts)
-	return written, normalizeAppErr(appErr)
+	return valueBytes, downstreamOpts, nil
 }
 
 // SetAtomicWithRetries will set a key-value pair atomically using compare and set semantics:
@@ -175,6 +185,53 @@ func (k *KVService) Get(key string, o interface{}) error {
 	return nil
 }
 
+// GetMany gets the values of the given keys, as stored. Non-existent keys are omitted from the
+// returned map.
+//
+// Minimum server version: 10.5
+func (k *KVService) GetMany(keys []string) (map[string][]byte, error) {
+	values, appErr := k.api.KVGetMany(keys)
+	return values, normalizeAppErr(appErr)
+}
+
+// KVBatchOperation is a write applied as part of a batch by Batch. A nil Value deletes the key.
+type KVBatchOperation struct {
+	Key     string
+	Value   interface{}
+	Options []KVSetOption
+}
+
+// Batch applies the given operations in a single transaction, so that either all or none of them
+// are applied. Keys prefixed with `mmi_` are reserved for internal use and will fail to be set.
+//
+// Returns (false, err) if DB error occurred
+// Returns (false, nil) if an atomic operation was not applied, in which case none were
+// Returns (true, nil) if all operations were applied
+//
+// Minimum server version: 10.5
+func (k *KVService) Batch(operations ...KVBatchOperation) (bool, error) {
+	downstreamOperations := make([]*model.PluginKVBatchOperation, 0, len(operations))
+	for _, operation := range operations {
+		if strings.HasPrefix(operation.Key, internalKeyPrefix) {
+			return false, errors.Errorf("'%s' prefix is not allowed for keys", internalKeyPrefix)
+		}
+
+		valueBytes, downstreamOpts, err := marshalKVSet(operation.Value, operation.Options)
+		if err != nil {
+			return false, err
+		}
+
+		downstreamOperations = append(downstreamOperations, &model.PluginKVBatchOperation{
+			Key:     operation.Key,
+			Value:   valueBytes,
+			Options: downstreamOpts,
+		})
+	}
+
+	applied, appErr := k.api.KVBatch(downstreamOperations)
+	return applied, normalizeAppErr(appErr)
+}
+
 // Delete deletes the given key-value pair.
 //
 // An error is returned only if the value failed to be deleted. A non-existent key will return
@@ -276,3 +333,13 @@ func (k *KVService) ListKeys(page, count int, options ...ListKeysOption) ([]stri
 
 	return ret, nil
 }
+
+// ListKeysWithPrefix lists the keys starting with the given prefix, in ascending order. Unlike
+// ListKeys with the WithPrefix option, the keys are filtered by the server, so that every page
+// but the last one is full.
+//
+// Minimum server version: 10.5
+func (k *KVService) ListKeysWithPrefix(prefix string, page, count int) ([]string, error) {
+	keys, appErr := k.api.KVListWithPrefix(prefix, page, count)
+	return keys, normalizeAppErr(appErr)
+}
@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -635,3 +636,72 @@ func getKeys(count int) []string {
 	}
 	return ret
 }
+
+func TestListKeysWithPrefix(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("KVListWithPrefix", "key", 1, 2).Return([]string{"key3", "key4"}, nil)
+
+	keys, err := client.KV.ListKeysWithPrefix("key", 1, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"key3", "key4"}, keys)
+}
+
+func TestGetMany(t *testing.T) {
+	api := &plugintest.API{}
+	defer api.AssertExpectations(t)
+	client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+	api.On("KVGetMany", []string{"key1", "key2"}).Return(map[string][]byte{"key1": []byte("value1")}, nil)
+
+	values, err := client.KV.GetMany([]string{"key1", "key2"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string][]byte{"key1": []byte("value1")}, values)
+}
+
+func TestBatch(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		api.On("KVBatch", []*model.PluginKVBatchOperation{
+			{Key: "key1", Value: []byte(`{"name":"value"}`)},
+			{Key: "key2", Value: []byte("new"), Options: model.PluginKVSetOptions{Atomic: true, OldValue: []byte("old"), ExpireInSeconds: 60}},
+			{Key: "key3", Value: nil},
+		}).Return(true, nil)
+
+		applied, err := client.KV.Batch(
+			pluginapi.KVBatchOperation{Key: "key1", Value: map[string]string{"name": "value"}},
+			pluginapi.KVBatchOperation{Key: "key2", Value: []byte("new"), Options: []pluginapi.KVSetOption{pluginapi.SetAtomic([]byte("old")), pluginapi.SetExpiry(time.Minute)}},
+			pluginapi.KVBatchOperation{Key: "key3"},
+		)
+		assert.NoError(t, err)
+		assert.True(t, applied)
+	})
+
+	t.Run("reserved prefix", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		applied, err := client.KV.Batch(pluginapi.KVBatchOperation{Key: "mmi_key", Value: "value"})
+		assert.Error(t, err)
+		assert.False(t, applied)
+	})
+
+	t.Run("failure", func(t *testing.T) {
+		api := &plugintest.API{}
+		defer api.AssertExpectations(t)
+		client := pluginapi.NewClient(api, &plugintest.Driver{})
+
+		appErr := newAppError()
+		api.On("KVBatch", []*model.PluginKVBatchOperation{{Key: "key", Value: []byte(`"value"`)}}).Return(false, appErr)
+
+		applied, err := client.KV.Batch(pluginapi.KVBatchOperation{Key: "key", Value: "value"})
+		assert.Equal(t, appErr, err)
+		assert.False(t, applied)
+	})
+}
@@ -0,0 +1,176 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	DefaultBatchSize          = 100
+	DefaultFlushIntervalMs    = 1000
+	DefaultMaxRetries         = 3
+	DefaultRetryBackoffMillis = 500
+	maxRetryBackoff           = time.Second * 30
+)
+
+// BatchOptions controls how the network log targets group records before
+// shipping them, and how failed deliveries are retried. Zero values use
+// the defaults, and a negative MaxRetries disables retries.
+type BatchOptions struct {
+	BatchSize          int `json:"batch_size,omitempty"`
+	FlushIntervalMs    int `json:"flush_interval_ms,omitempty"`
+	MaxRetries         int `json:"max_retries,omitempty"`
+	RetryBackoffMillis int `json:"retry_backoff_ms,omitempty"`
+}
+
+func (bo *BatchOptions) setDefaults() {
+	if bo.BatchSize <= 0 {
+		bo.BatchSize = DefaultBatchSize
+	}
+	if bo.FlushIntervalMs <= 0 {
+		bo.FlushIntervalMs = DefaultFlushIntervalMs
+	}
+	if bo.MaxRetries < 0 {
+		bo.MaxRetries = 0
+	} else if bo.MaxRetries == 0 {
+		bo.MaxRetries = DefaultMaxRetries
+	}
+	if bo.RetryBackoffMillis <= 0 {
+		bo.RetryBackoffMillis = DefaultRetryBackoffMillis
+	}
+}
+
+// permanentError wraps delivery errors which retrying can't fix, such as a
+// collector rejecting a malformed request.
+type permanentError struct {
+	err error
+}
+
+func (pe *permanentError) Error() string {
+	return pe.err.Error()
+}
+
+func (pe *permanentError) Unwrap() error {
+	return pe.err
+}
+
+// batcher buffers items and hands them to send in batches, either once
+// BatchSize items are buffered or every FlushIntervalMs. send returns how
+// many items were delivered so that retries only resend the others.
+type batcher[T any] struct {
+	opts BatchOptions
+	send func([]T) (int, error)
+
+	mux     sync.Mutex
+	items   []T
+	lastErr error
+
+	sendMux sync.Mutex
+	stop    chan struct{}
+	done    chan struct{}
+}
+
+func newBatcher[T any](opts BatchOptions, send func([]T) (int, error)) *batcher[T] {
+	opts.setDefaults()
+	return &batcher[T]{
+		opts: opts,
+		send: send,
+	}
+}
+
+func (b *batcher[T]) start() {
+	b.stop = make(chan struct{})
+	b.done = make(chan struct{})
+
+	go func() {
+		defer close(b.done)
+		ticker := time.NewTicker(time.Duration(b.opts.FlushIntervalMs) * time.Millisecond)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				b.flush()
+			case <-b.stop:
+				return
+			}
+		}
+	}()
+}
+
+// add buffers an item, sending the batch when full. Errors from previous
+// background flushes are reported here since they have no other caller.
+func (b *batcher[T]) add(item T) error {
+	b.mux.Lock()
+	b.items = append(b.items, item)
+	full := len(b.items) >= b.opts.BatchSize
+	err := b.lastErr
+	b.lastErr = nil
+	b.mux.Unlock()
+
+	if full {
+		if flushErr := b.flush(); flushErr != nil {
+			return flushErr
+		}
+	}
+	return err
+}
+
+func (b *batcher[T]) flush() error {
+	b.sendMux.Lock()
+	defer b.sendMux.Unlock()
+
+	b.mux.Lock()
+	items := b.items
+	b.items = nil
+	b.mux.Unlock()
+
+	if len(items) == 0 {
+		return nil
+	}
+
+	err := b.sendWithRetry(items)
+	if err != nil {
+		b.mux.Lock()
+		b.lastErr = err
+		b.mux.Unlock()
+	}
+	return err
+}
+
+func (b *batcher[T]) sendWithRetry(items []T) error {
+	backoff := time.Duration(b.opts.RetryBackoffMillis) * time.Millisecond
+	var err error
+	for attempt := 0; attempt <= b.opts.MaxRetries; attempt++ {
+		if attempt > 0 {
+			time.Sleep(backoff)
+			backoff = min(backoff*2, maxRetryBackoff)
+		}
+
+		var sent int
+		sent, err = b.send(items)
+		if err == nil {
+			return nil
+		}
+		items = items[sent:]
+
+		var pErr *permanentError
+		if errors.As(err, &pErr) {
+			return err
+		}
+	}
+	return err
+}
+
+// shutdown stops the background flushes and sends what is still buffered.
+func (b *batcher[T]) shutdown() error {
+	if b.stop != nil {
+		close(b.stop)
+		<-b.done
+		b.stop = nil
+	}
+	return b.flush()
+}
@@ -0,0 +1,114 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/mattermost/logr/v2/targets"
+)
+
+const (
+	dialTimeout  = time.Second * 10
+	writeTimeout = time.Second * 10
+)
+
+// TLSOptions configures TLS for the network log targets. Cert is a path to,
+// or base64 encoding of, a PEM certificate to trust in addition to the
+// system pool.
+type TLSOptions struct {
+	TLS      bool   `json:"tls,omitempty"`
+	Cert     string `json:"cert,omitempty"`
+	Insecure bool   `json:"insecure,omitempty"`
+}
+
+func (to TLSOptions) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{InsecureSkipVerify: to.Insecure}
+	if to.Cert != "" {
+		pool, err := targets.GetCertPool(to.Cert)
+		if err != nil {
+			return nil, err
+		}
+		config.RootCAs = pool
+	}
+	return config, nil
+}
+
+// streamConn is a lazily dialed TCP connection which is redialed after a
+// failed write.
+type streamConn struct {
+	addr string
+	tls  TLSOptions
+	conn net.Conn
+}
+
+func (sc *streamConn) write(p []byte) error {
+	if sc.conn == nil {
+		conn, err := sc.dial()
+		if err != nil {
+			return err
+		}
+		sc.conn = conn
+	}
+
+	if err := sc.conn.SetWriteDeadline(time.Now().Add(writeTimeout)); err != nil {
+		sc.close()
+		return err
+	}
+	if _, err := sc.conn.Write(p); err != nil {
+		sc.close()
+		return err
+	}
+	return nil
+}
+
+func (sc *streamConn) dial() (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	if !sc.tls.TLS {
+		return dialer.Dial("tcp", sc.addr)
+	}
+
+	config, err := sc.tls.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	return tls.DialWithDialer(dialer, "tcp", sc.addr, config)
+}
+
+func (sc *streamConn) close() error {
+	if sc.conn == nil {
+		return nil
+	}
+	err := sc.conn.Close()
+	sc.conn = nil
+	return err
+}
+
+func newHTTPClient(to TLSOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	config, err := to.tlsConfig()
+	if err != nil {
+		return nil, err
+	}
+	transport.TLSClientConfig = config
+	return &http.Client{Transport: transport, Timeout: writeTimeout}, nil
+}
+
+// checkHTTPResponse turns unsuccessful responses into errors. Client
+// errors other than throttling won't succeed when retried.
+func checkHTTPResponse(resp *http.Response) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	err := fmt.Errorf("unexpected response status %s", resp.Status)
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return &permanentError{err: err}
+	}
+	return err
+}
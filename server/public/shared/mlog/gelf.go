@@ -0,0 +1,271 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+)
+
+const (
+	GelfTransportUDP  = "udp"
+	GelfTransportTCP  = "tcp"
+	GelfTransportHTTP = "http"
+
+	gelfChunkSize      = 8192
+	gelfChunkHeaderLen = 12
+	gelfMaxChunks      = 128
+)
+
+var gelfChunkMagic = []byte{0x1e, 0x0f}
+
+// GelfOptions configures a target shipping GELF records to Graylog over
+// UDP, TCP or HTTP. The target should be paired with the "gelf" format.
+type GelfOptions struct {
+	Transport string `json:"transport"` // one of "udp", "tcp", "http"
+	Host      string `json:"host,omitempty"`
+	Port      int    `json:"port,omitempty"`
+	// URL is the endpoint of a GELF HTTP input, such as http://graylog:12201/gelf.
+	URL     string            `json:"url,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	// Compress gzips UDP datagrams and HTTP request bodies. GELF TCP
+	// doesn't support compression.
+	Compress bool `json:"compress,omitempty"`
+	TLSOptions
+	BatchOptions
+}
+
+func (o *GelfOptions) CheckValid() error {
+	switch strings.ToLower(o.Transport) {
+	case GelfTransportUDP, GelfTransportTCP:
+		if o.Host == "" {
+			return errors.New("missing host")
+		}
+		if o.Port == 0 {
+			return errors.New("missing port")
+		}
+	case GelfTransportHTTP:
+		if o.URL == "" {
+			return errors.New("missing url")
+		}
+	default:
+		return fmt.Errorf("invalid transport '%s'", o.Transport)
+	}
+	return nil
+}
+
+// Gelf is a log target shipping GELF records to Graylog.
+type Gelf struct {
+	opts    *GelfOptions
+	batcher *batcher[[]byte]
+
+	stream *streamConn
+	client *http.Client
+}
+
+// NewGelfTarget creates a target shipping GELF records to Graylog.
+func NewGelfTarget(opts *GelfOptions) (*Gelf, error) {
+	if opts == nil {
+		return nil, errors.New("options cannot be nil")
+	}
+	if err := opts.CheckValid(); err != nil {
+		return nil, err
+	}
+
+	g := &Gelf{opts: opts}
+	g.batcher = newBatcher(opts.BatchOptions, g.send)
+	return g, nil
+}
+
+// Init is called once to initialize the target. Connections are made when
+// the first batch is sent.
+func (g *Gelf) Init() error {
+	switch strings.ToLower(g.opts.Transport) {
+	case GelfTransportTCP:
+		g.stream = &streamConn{
+			addr: net.JoinHostPort(g.opts.Host, fmt.Sprint(g.opts.Port)),
+			tls:  g.opts.TLSOptions,
+		}
+	case GelfTransportHTTP:
+		client, err := newHTTPClient(g.opts.TLSOptions)
+		if err != nil {
+			return err
+		}
+		g.client = client
+	}
+
+	g.batcher.start()
+	return nil
+}
+
+// Write buffers a formatted record until the next batch is sent.
+func (g *Gelf) Write(p []byte, rec *LogRec) (int, error) {
+	// The gelf formatter terminates records with a null byte for TCP, which
+	// is added back when framing. Formatters reuse the buffer once Write
+	// returns.
+	msg := bytes.TrimRight(p, "\n\x00")
+	if err := g.batcher.add(append([]byte(nil), msg...)); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Shutdown sends any buffered records and closes connections.
+func (g *Gelf) Shutdown() error {
+	err := g.batcher.shutdown()
+	if g.stream != nil {
+		if cErr := g.stream.close(); err == nil {
+			err = cErr
+		}
+	}
+	return err
+}
+
+func (g *Gelf) send(msgs [][]byte) (int, error) {
+	switch strings.ToLower(g.opts.Transport) {
+	case GelfTransportUDP:
+		return g.sendUDP(msgs)
+	case GelfTransportTCP:
+		return g.sendTCP(msgs)
+	default:
+		return g.sendHTTP(msgs)
+	}
+}
+
+func (g *Gelf) sendUDP(msgs [][]byte) (int, error) {
+	conn, err := net.DialTimeout("udp", net.JoinHostPort(g.opts.Host, fmt.Sprint(g.opts.Port)), dialTimeout)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	for i, msg := range msgs {
+		if g.opts.Compress {
+			if msg, err = gzipBytes(msg); err != nil {
+				return i, &permanentError{err: err}
+			}
+		}
+
+		datagrams, err := gelfChunks(msg)
+		if err != nil {
+			// Too large to send, drop this record but keep the others.
+			continue
+		}
+		for _, datagram := range datagrams {
+			if _, err := conn.Write(datagram); err != nil {
+				return i, err
+			}
+		}
+	}
+	return len(msgs), nil
+}
+
+// gelfChunks splits a message into GELF chunks when it doesn't fit into a
+// single datagram.
+func gelfChunks(msg []byte) ([][]byte, error) {
+	if len(msg) <= gelfChunkSize {
+		return [][]byte{msg}, nil
+	}
+
+	payloadSize := gelfChunkSize - gelfChunkHeaderLen
+	count := (len(msg) + payloadSize - 1) / payloadSize
+	if count > gelfMaxChunks {
+		return nil, fmt.Errorf("message of %d bytes needs more than %d chunks", len(msg), gelfMaxChunks)
+	}
+
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, err
+	}
+
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		end := min((i+1)*payloadSize, len(msg))
+		chunk := make([]byte, 0, gelfChunkHeaderLen+end-i*payloadSize)
+		chunk = append(chunk, gelfChunkMagic...)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunk = append(chunk, msg[i*payloadSize:end]...)
+		chunks = append(chunks, chunk)
+	}
+	return chunks, nil
+}
+
+func (g *Gelf) sendTCP(msgs [][]byte) (int, error) {
+	// GELF TCP frames messages with a null byte.
+	var buf bytes.Buffer
+	for _, msg := range msgs {
+		buf.Write(msg)
+		buf.WriteByte(0)
+	}
+	if err := g.stream.write(buf.Bytes()); err != nil {
+		return 0, err
+	}
+	return len(msgs), nil
+}
+
+func (g *Gelf) sendHTTP(msgs [][]byte) (int, error) {
+	// GELF HTTP inputs accept a single message per request.
+	for i, msg := range msgs {
+		if err := g.postHTTP(msg); err != nil {
+			var pErr *permanentError
+			if errors.As(err, &pErr) {
+				// The input rejected this record, drop it but keep the others.
+				continue
+			}
+			return i, err
+		}
+	}
+	return len(msgs), nil
+}
+
+func (g *Gelf) postHTTP(msg []byte) error {
+	body := msg
+	if g.opts.Compress {
+		var err error
+		if body, err = gzipBytes(msg); err != nil {
+			return &permanentError{err: err}
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, g.opts.URL, bytes.NewReader(body))
+	if err != nil {
+		return &permanentError{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if g.opts.Compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	for k, v := range g.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := g.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	return checkHTTPResponse(resp)
+}
+
+func gzipBytes(p []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(p); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -77,7 +77,7 @@ func (lc LoggerConfiguration) IsValid() error {
 	}
 	defer logger.Shutdown()
 
-	err = logrcfg.ConfigureTargets(logger, lc, nil)
+	err = logrcfg.ConfigureTargets(logger, lc, withBuiltinFactories(nil))
 	if err != nil {
 		return errors.Wrap(err, "logger configuration is invalid")
 	}
@@ -209,7 +209,7 @@ func NewLogger(options ...Option) (*Logger, error) {
 //	cfgFile > cfgEscaped
 //
 // An optional set of factories can be provided which will be called to create any target
-// types or formatters not built-in.
+// types or formatters not built into Logr or mlog.
 func (l *Logger) Configure(cfgFile string, cfgEscaped string, factories *Factories) error {
 	if atomic.LoadInt32(l.lockConfig) != 0 {
 		return ErrConfigurationLock
@@ -244,18 +244,18 @@ func (l *Logger) Configure(cfgFile string, cfgEscaped string, factories *Factori
 		return nil
 	}
 
-	return logrcfg.ConfigureTargets(l.log.Logr(), cfgMap.toTargetCfg(), factories)
+	return logrcfg.ConfigureTargets(l.log.Logr(), cfgMap.toTargetCfg(), withBuiltinFactories(factories))
 }
 
 // ConfigureTargets provides a new configuration for this logger via a `LoggerConfig` map.
 // `Logger.Configure` can be used instead which accepts JSON formatted configuration.
 // An optional set of factories can be provided which will be called to create any target
-// types or formatters not built-in.
+// types or formatters not built into Logr or mlog.
 func (l *Logger) ConfigureTargets(cfg LoggerConfiguration, factories *Factories) error {
 	if atomic.LoadInt32(l.lockConfig) != 0 {
 		return ErrConfigurationLock
 	}
-	return logrcfg.ConfigureTargets(l.log.Logr(), cfg.toTargetCfg(), factories)
+	return logrcfg.ConfigureTargets(l.log.Logr(), cfg.toTargetCfg(), withBuiltinFactories(factories))
 }
 
 // LockConfiguration disallows further configuration changes until `UnlockConfiguration`
@@ -0,0 +1,263 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/mattermost/logr/v2"
+)
+
+const (
+	OTLPLogsPath           = "/v1/logs"
+	DefaultOTLPServiceName = "mattermost"
+)
+
+// OTLPOptions configures a target exporting log records to an OpenTelemetry
+// collector using OTLP/HTTP with JSON encoding. Records are exported with
+// their message and fields, so the target ignores the configured format.
+type OTLPOptions struct {
+	// Endpoint is the base URL of the collector, such as http://localhost:4318.
+	// The logs path is appended unless the URL already ends with it.
+	Endpoint           string            `json:"endpoint"`
+	Headers            map[string]string `json:"headers,omitempty"`
+	ServiceName        string            `json:"service_name,omitempty"`
+	ResourceAttributes map[string]string `json:"resource_attributes,omitempty"`
+	Compress           bool              `json:"compress,omitempty"`
+	TLSOptions
+	BatchOptions
+}
+
+func (o *OTLPOptions) CheckValid() error {
+	if o.Endpoint == "" {
+		return errors.New("missing endpoint")
+	}
+	if !strings.HasPrefix(o.Endpoint, "http://") && !strings.HasPrefix(o.Endpoint, "https://") {
+		return errors.New("endpoint must be an http or https URL")
+	}
+	return nil
+}
+
+func (o *OTLPOptions) url() string {
+	endpoint := strings.TrimRight(o.Endpoint, "/")
+	if strings.HasSuffix(endpoint, OTLPLogsPath) {
+		return endpoint
+	}
+	return endpoint + OTLPLogsPath
+}
+
+// OTLP is a log target exporting records to an OpenTelemetry collector.
+type OTLP struct {
+	opts     *OTLPOptions
+	batcher  *batcher[otlpLogRecord]
+	client   *http.Client
+	resource otlpResource
+}
+
+// The types below are the subset of the OTLP logs data model the target
+// uses, in the protobuf JSON mapping accepted by OTLP/HTTP receivers.
+type otlpLogsRequest struct {
+	ResourceLogs []otlpResourceLogs `json:"resourceLogs"`
+}
+
+type otlpResourceLogs struct {
+	Resource  otlpResource    `json:"resource"`
+	ScopeLogs []otlpScopeLogs `json:"scopeLogs"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeLogs struct {
+	Scope      otlpScope       `json:"scope"`
+	LogRecords []otlpLogRecord `json:"logRecords"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpLogRecord struct {
+	TimeUnixNano   string         `json:"timeUnixNano"`
+	SeverityNumber int            `json:"severityNumber"`
+	SeverityText   string         `json:"severityText"`
+	Body           otlpAnyValue   `json:"body"`
+	Attributes     []otlpKeyValue `json:"attributes,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
+
+// NewOTLPTarget creates a target exporting log records to an OpenTelemetry
+// collector.
+func NewOTLPTarget(opts *OTLPOptions) (*OTLP, error) {
+	if opts == nil {
+		return nil, errors.New("options cannot be nil")
+	}
+	if err := opts.CheckValid(); err != nil {
+		return nil, err
+	}
+
+	o := &OTLP{opts: opts}
+	o.batcher = newBatcher(opts.BatchOptions, o.send)
+	return o, nil
+}
+
+// Init is called once to initialize the target.
+func (o *OTLP) Init() error {
+	client, err := newHTTPClient(o.opts.TLSOptions)
+	if err != nil {
+		return err
+	}
+	o.client = client
+
+	serviceName := o.opts.ServiceName
+	if serviceName == "" {
+		serviceName = DefaultOTLPServiceName
+	}
+	o.resource.Attributes = append(o.resource.Attributes, otlpString("service.name", serviceName))
+	if hostname, err := os.Hostname(); err == nil {
+		o.resource.Attributes = append(o.resource.Attributes, otlpString("host.name", hostname))
+	}
+	for k, v := range o.opts.ResourceAttributes {
+		o.resource.Attributes = append(o.resource.Attributes, otlpString(k, v))
+	}
+
+	o.batcher.start()
+	return nil
+}
+
+// Write converts the record to the OTLP data model and buffers it until
+// the next batch is sent.
+func (o *OTLP) Write(p []byte, rec *LogRec) (int, error) {
+	level := rec.Level()
+	record := otlpLogRecord{
+		TimeUnixNano:   strconv.FormatInt(rec.Time().UnixNano(), 10),
+		SeverityNumber: otlpSeverity(level),
+		SeverityText:   level.Name,
+		Body:           otlpAnyValue{StringValue: ptr(rec.Msg())},
+	}
+	for _, field := range rec.Fields() {
+		record.Attributes = append(record.Attributes, otlpKeyValue{Key: field.Key, Value: otlpFieldValue(field)})
+	}
+
+	if err := o.batcher.add(record); err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Shutdown sends any buffered records.
+func (o *OTLP) Shutdown() error {
+	return o.batcher.shutdown()
+}
+
+func (o *OTLP) send(records []otlpLogRecord) (int, error) {
+	payload := otlpLogsRequest{
+		ResourceLogs: []otlpResourceLogs{{
+			Resource: o.resource,
+			ScopeLogs: []otlpScopeLogs{{
+				Scope:      otlpScope{Name: "mlog"},
+				LogRecords: records,
+			}},
+		}},
+	}
+
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return 0, &permanentError{err: err}
+	}
+	if o.opts.Compress {
+		if body, err = gzipBytes(body); err != nil {
+			return 0, &permanentError{err: err}
+		}
+	}
+
+	req, err := http.NewRequest(http.MethodPost, o.opts.url(), bytes.NewReader(body))
+	if err != nil {
+		return 0, &permanentError{err: err}
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.opts.Compress {
+		req.Header.Set("Content-Encoding", "gzip")
+	}
+	for k, v := range o.opts.Headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, resp.Body)
+
+	if err := checkHTTPResponse(resp); err != nil {
+		return 0, err
+	}
+	return len(records), nil
+}
+
+// otlpSeverity maps a level to an OpenTelemetry severity number. Custom
+// levels, such as the audit ones, are reported as info.
+func otlpSeverity(level Level) int {
+	switch level.ID {
+	case LvlTrace.ID:
+		return 1
+	case LvlDebug.ID:
+		return 5
+	case LvlWarn.ID:
+		return 13
+	case LvlError.ID, LvlLogError.ID:
+		return 17
+	case LvlCritical.ID, LvlFatal.ID, LvlPanic.ID:
+		return 21
+	default:
+		return 9
+	}
+}
+
+func otlpString(key, value string) otlpKeyValue {
+	return otlpKeyValue{Key: key, Value: otlpAnyValue{StringValue: &value}}
+}
+
+func otlpFieldValue(field Field) otlpAnyValue {
+	switch field.Type {
+	case logr.BoolType:
+		return otlpAnyValue{BoolValue: ptr(field.Integer != 0)}
+	case logr.Int64Type, logr.Int32Type, logr.IntType:
+		return otlpAnyValue{IntValue: ptr(strconv.FormatInt(field.Integer, 10))}
+	case logr.Uint64Type, logr.Uint32Type, logr.UintType:
+		return otlpAnyValue{IntValue: ptr(strconv.FormatUint(uint64(field.Integer), 10))}
+	case logr.Float64Type, logr.Float32Type:
+		return otlpAnyValue{DoubleValue: ptr(field.Float)}
+	}
+
+	var sb strings.Builder
+	if err := field.ValueString(&sb, nil); err != nil {
+		return otlpAnyValue{StringValue: ptr(err.Error())}
+	}
+	return otlpAnyValue{StringValue: ptr(sb.String())}
+}
+
+func ptr[T any](v T) *T {
+	return &v
+}
@@ -0,0 +1,73 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Target types provided by mlog in addition to the ones built into Logr.
+const (
+	TargetTypeGelf       = "gelf"
+	TargetTypeOTLP       = "otlp"
+	TargetTypeSyslog5424 = "syslog5424"
+)
+
+// withBuiltinFactories returns factories which create the mlog target
+// types, deferring to the given factories for any other type.
+func withBuiltinFactories(factories *Factories) *Factories {
+	f := &Factories{}
+	if factories != nil {
+		*f = *factories
+	}
+
+	custom := f.TargetFactory
+	f.TargetFactory = func(targetType string, options json.RawMessage) (Target, error) {
+		target, err := newBuiltinTarget(targetType, options)
+		if target != nil || err != nil {
+			return target, err
+		}
+		if custom == nil {
+			return nil, fmt.Errorf("target type '%s' is unrecognized", targetType)
+		}
+		return custom(targetType, options)
+	}
+	return f
+}
+
+func newBuiltinTarget(targetType string, options json.RawMessage) (Target, error) {
+	switch strings.ToLower(targetType) {
+	case TargetTypeGelf:
+		opts := &GelfOptions{}
+		if err := decodeTargetOptions(targetType, options, opts); err != nil {
+			return nil, err
+		}
+		return NewGelfTarget(opts)
+	case TargetTypeOTLP:
+		opts := &OTLPOptions{}
+		if err := decodeTargetOptions(targetType, options, opts); err != nil {
+			return nil, err
+		}
+		return NewOTLPTarget(opts)
+	case TargetTypeSyslog5424:
+		opts := &Syslog5424Options{}
+		if err := decodeTargetOptions(targetType, options, opts); err != nil {
+			return nil, err
+		}
+		return NewSyslog5424Target(opts)
+	}
+	return nil, nil
+}
+
+func decodeTargetOptions(targetType string, options json.RawMessage, opts any) error {
+	if len(options) == 0 {
+		return fmt.Errorf("missing %s target options", targetType)
+	}
+	if err := json.Unmarshal(options, opts); err != nil {
+		return fmt.Errorf("error decoding %s target options: %w", targetType, err)
+	}
+	return nil
+}
@@ -0,0 +1,399 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+package mlog_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/mattermost/mattermost/server/public/shared/mlog"
+)
+
+func newTestLogger(t *testing.T, cfg mlog.TargetCfg) *mlog.Logger {
+	t.Helper()
+	logger, err := mlog.NewLogger()
+	require.NoError(t, err)
+	require.NoError(t, logger.ConfigureTargets(mlog.LoggerConfiguration{"test": cfg}, nil))
+	return logger
+}
+
+func mustJSON(t *testing.T, v any) json.RawMessage {
+	t.Helper()
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return b
+}
+
+// collector records the request bodies received by a stand-in HTTP collector.
+type collector struct {
+	mux    sync.Mutex
+	bodies [][]byte
+	fail   atomic.Int32
+}
+
+func (c *collector) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if c.fail.Load() > 0 {
+			c.fail.Add(-1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+
+		body := r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			gz, err := gzip.NewReader(r.Body)
+			require.NoError(t, err)
+			body = gz
+		}
+		b, err := io.ReadAll(body)
+		require.NoError(t, err)
+
+		c.mux.Lock()
+		c.bodies = append(c.bodies, b)
+		c.mux.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+func (c *collector) received() [][]byte {
+	c.mux.Lock()
+	defer c.mux.Unlock()
+	return c.bodies
+}
+
+func TestLoggerConfigurationIsValidWithBuiltinTargets(t *testing.T) {
+	valid := mlog.LoggerConfiguration{
+		"otlp": {
+			Type:    "otlp",
+			Format:  "json",
+			Levels:  []mlog.Level{mlog.LvlInfo},
+			Options: json.RawMessage(`{"endpoint": "http://localhost:4318"}`),
+		},
+		"gelf": {
+			Type:    "gelf",
+			Format:  "gelf",
+			Levels:  []mlog.Level{mlog.LvlInfo},
+			Options: json.RawMessage(`{"transport": "udp", "host": "localhost", "port": 12201}`),
+		},
+		"syslog": {
+			Type:    "syslog5424",
+			Format:  "plain",
+			Levels:  []mlog.Level{mlog.LvlInfo},
+			Options: json.RawMessage(`{"transport": "tcp", "host": "localhost", "port": 6514, "tls": true}`),
+		},
+	}
+	require.NoError(t, valid.IsValid())
+
+	for name, options := range map[string]string{
+		"otlp":       `{"endpoint": "localhost:4318"}`,
+		"gelf":       `{"transport": "http"}`,
+		"syslog5424": `{"transport": "udp", "host": "localhost", "port": 514, "tls": true}`,
+	} {
+		invalid := mlog.LoggerConfiguration{
+			name: {Type: name, Format: "plain", Levels: []mlog.Level{mlog.LvlInfo}, Options: json.RawMessage(options)},
+		}
+		assert.Error(t, invalid.IsValid(), name)
+	}
+}
+
+func TestOTLPTarget(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewServer(c.handler(t))
+	defer server.Close()
+
+	// The first delivery fails and is retried.
+	c.fail.Store(1)
+
+	logger := newTestLogger(t, mlog.TargetCfg{
+		Type:   "otlp",
+		Format: "json",
+		Levels: []mlog.Level{mlog.LvlInfo, mlog.LvlError, mlog.LvlAuditAPI},
+		Options: mustJSON(t, mlog.OTLPOptions{
+			Endpoint:           server.URL,
+			Headers:            map[string]string{"Authorization": "Bearer token"},
+			ServiceName:        "test-service",
+			ResourceAttributes: map[string]string{"deployment.environment": "test"},
+			Compress:           true,
+			BatchOptions:       mlog.BatchOptions{BatchSize: 2, RetryBackoffMillis: 10},
+		}),
+	})
+
+	logger.Info("first", mlog.String("user_id", "abc"), mlog.Int("count", 3), mlog.Bool("ok", true))
+	logger.Error("second", mlog.Float("ratio", 0.5))
+	logger.Log(mlog.LvlAuditAPI, "third")
+	require.NoError(t, logger.Shutdown())
+
+	bodies := c.received()
+	require.Len(t, bodies, 2)
+
+	var req struct {
+		ResourceLogs []struct {
+			Resource struct {
+				Attributes []struct {
+					Key   string         `json:"key"`
+					Value map[string]any `json:"value"`
+				} `json:"attributes"`
+			} `json:"resource"`
+			ScopeLogs []struct {
+				LogRecords []struct {
+					TimeUnixNano   string `json:"timeUnixNano"`
+					SeverityNumber int    `json:"severityNumber"`
+					SeverityText   string `json:"severityText"`
+					Body           struct {
+						StringValue string `json:"stringValue"`
+					} `json:"body"`
+					Attributes []struct {
+						Key   string         `json:"key"`
+						Value map[string]any `json:"value"`
+					} `json:"attributes"`
+				} `json:"logRecords"`
+			} `json:"scopeLogs"`
+		} `json:"resourceLogs"`
+	}
+	require.NoError(t, json.Unmarshal(bodies[0], &req))
+	require.Len(t, req.ResourceLogs, 1)
+
+	resource := map[string]any{}
+	for _, attr := range req.ResourceLogs[0].Resource.Attributes {
+		resource[attr.Key] = attr.Value["stringValue"]
+	}
+	assert.Equal(t, "test-service", resource["service.name"])
+	assert.Equal(t, "test", resource["deployment.environment"])
+
+	records := req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, records, 2)
+	assert.Equal(t, "first", records[0].Body.StringValue)
+	assert.Equal(t, 9, records[0].SeverityNumber)
+	assert.NotEmpty(t, records[0].TimeUnixNano)
+	attrs := map[string]map[string]any{}
+	for _, attr := range records[0].Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	assert.Equal(t, "abc", attrs["user_id"]["stringValue"])
+	assert.Equal(t, "3", attrs["count"]["intValue"])
+	assert.Equal(t, true, attrs["ok"]["boolValue"])
+	assert.Equal(t, "second", records[1].Body.StringValue)
+	assert.Equal(t, 17, records[1].SeverityNumber)
+
+	require.NoError(t, json.Unmarshal(bodies[1], &req))
+	records = req.ResourceLogs[0].ScopeLogs[0].LogRecords
+	require.Len(t, records, 1)
+	assert.Equal(t, "audit-api", records[0].SeverityText)
+}
+
+func TestGelfTargetHTTP(t *testing.T) {
+	c := &collector{}
+	server := httptest.NewTLSServer(c.handler(t))
+	defer server.Close()
+
+	logger := newTestLogger(t, mlog.TargetCfg{
+		Type:   "gelf",
+		Format: "gelf",
+		Levels: []mlog.Level{mlog.LvlInfo},
+		Options: mustJSON(t, mlog.GelfOptions{
+			Transport:  mlog.GelfTransportHTTP,
+			URL:        server.URL + "/gelf",
+			TLSOptions: mlog.TLSOptions{Insecure: true},
+		}),
+	})
+
+	logger.Info("first")
+	logger.Info("second")
+	require.NoError(t, logger.Shutdown())
+
+	bodies := c.received()
+	require.Len(t, bodies, 2)
+	var msg map[string]any
+	require.NoError(t, json.Unmarshal(bodies[0], &msg))
+	assert.Equal(t, "1.1", msg["version"])
+	assert.Equal(t, "first", msg["short_message"])
+}
+
+func TestGelfTargetUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	port := conn.LocalAddr().(*net.UDPAddr).Port
+	logger := newTestLogger(t, mlog.TargetCfg{
+		Type:    "gelf",
+		Format:  "gelf",
+		Levels:  []mlog.Level{mlog.LvlInfo},
+		Options: mustJSON(t, mlog.GelfOptions{Transport: mlog.GelfTransportUDP, Host: "127.0.0.1", Port: port}),
+	})
+
+	long := strings.Repeat("x", 20000)
+	logger.Info("short")
+	logger.Info(long)
+	require.NoError(t, logger.Shutdown())
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 65536)
+
+	n, _, err := conn.ReadFrom(buf)
+	require.NoError(t, err)
+	var msg map[string]any
+	require.NoError(t, json.Unmarshal(buf[:n], &msg))
+	assert.Equal(t, "short", msg["short_message"])
+
+	// The long message is split into chunks sharing a message id.
+	var payload []byte
+	var id []byte
+	for {
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		require.Equal(t, []byte{0x1e, 0x0f}, buf[:2])
+		if id == nil {
+			id = append([]byte(nil), buf[2:10]...)
+		}
+		assert.Equal(t, id, buf[2:10])
+		seq, count := int(buf[10]), int(buf[11])
+		payload = append(payload, buf[12:n]...)
+		if seq == count-1 {
+			assert.Equal(t, 3, count)
+			break
+		}
+	}
+	require.NoError(t, json.Unmarshal(payload, &msg))
+	assert.Equal(t, long, msg["short_message"])
+}
+
+func TestSyslog5424Target(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	received := make(chan string, 10)
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		// Messages are framed with their length (RFC 6587 octet counting).
+		r := bufio.NewReader(conn)
+		for {
+			length, err := r.ReadString(' ')
+			if err != nil {
+				return
+			}
+			n, err := strconv.Atoi(strings.TrimSpace(length))
+			if err != nil {
+				return
+			}
+			msg := make([]byte, n)
+			if _, err := io.ReadFull(r, msg); err != nil {
+				return
+			}
+			received <- string(msg)
+		}
+	}()
+
+	port := ln.Addr().(*net.TCPAddr).Port
+	logger := newTestLogger(t, mlog.TargetCfg{
+		Type:   "syslog5424",
+		Format: "plain",
+		Levels: []mlog.Level{mlog.LvlWarn, mlog.LvlAuditAPI},
+		Options: mustJSON(t, mlog.Syslog5424Options{
+			Transport: mlog.Syslog5424TransportTCP,
+			Host:      "127.0.0.1",
+			Port:      port,
+			AppName:   "mm test",
+			Hostname:  "host1",
+			Facility:  16,
+		}),
+	})
+
+	logger.Warn("disk is filling up")
+	logger.Log(mlog.LvlAuditAPI, "login")
+	require.NoError(t, logger.Shutdown())
+
+	for _, expected := range []struct {
+		prefix string
+		msg    string
+	}{
+		{"<132>1 ", "disk is filling up"},
+		{"<134>1 ", "login"},
+	} {
+		select {
+		case msg := <-received:
+			assert.True(t, strings.HasPrefix(msg, expected.prefix), msg)
+			fields := strings.SplitN(msg, " ", 8)
+			require.Len(t, fields, 8)
+			_, err := time.Parse(time.RFC3339Nano, fields[1])
+			assert.NoError(t, err)
+			assert.Equal(t, "host1", fields[2])
+			assert.Equal(t, "mm_test", fields[3])
+			assert.Equal(t, "-", fields[6])
+			assert.Contains(t, fields[7], expected.msg)
+		case <-time.After(5 * time.Second):
+			require.Fail(t, "timed out waiting for syslog message")
+		}
+	}
+}
+
+func TestGelfTargetTCPRetry(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	port := ln.Addr().(*net.TCPAddr).Port
+	// Nothing is listening until the first attempt failed.
+	require.NoError(t, ln.Close())
+
+	logger := newTestLogger(t, mlog.TargetCfg{
+		Type:   "gelf",
+		Format: "gelf",
+		Levels: []mlog.Level{mlog.LvlInfo},
+		Options: mustJSON(t, mlog.GelfOptions{
+			Transport:    mlog.GelfTransportTCP,
+			Host:         "127.0.0.1",
+			Port:         port,
+			BatchOptions: mlog.BatchOptions{MaxRetries: 5, RetryBackoffMillis: 200},
+		}),
+	})
+
+	received := make(chan []byte, 1)
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		ln, err := net.Listen("tcp", "127.0.0.1:"+strconv.Itoa(port))
+		if err != nil {
+			return
+		}
+		defer ln.Close()
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		msg, _ := bufio.NewReader(conn).ReadBytes(0)
+		received <- msg
+	}()
+
+	logger.Info("retried")
+	require.NoError(t, logger.Shutdown())
+
+	select {
+	case msg := <-received:
+		var gelf map[string]any
+		require.NoError(t, json.Unmarshal(bytes.TrimSuffix(msg, []byte{0}), &gelf))
+		assert.Equal(t, "retried", gelf["short_message"])
+	case <-time.After(5 * time.Second):
+		require.Fail(t, "timed out waiting for GELF message")
+	}
+}
@@ -133,10 +133,21 @@ export function getOAuthAppInfo(clientId) {
  * @param {*}
  * @returns {ActionResult<{redirect: string}>}
  */
-export function allowOAuth2({responseType, clientId, redirectUri, state, scope}) {
+export function allowOAuth2({responseType, clientId, redirectUri, state, scope, codeChallenge, codeChallengeMethod, nonce}) {
     return bindClientFunc({
         clientFunc: Client4.authorizeOAuthApp,
-        params: [responseType, clientId, redirectUri, state, scope],
+        params: [responseType, clientId, redirectUri, state, scope, codeChallenge, codeChallengeMethod, nonce],
+    });
+}
+
+/**
+ * @param {{userCode: string, approve: boolean}}
+ * @returns {ActionResult}
+ */
+export function authorizeOAuthDevice({userCode, approve}) {
+    return bindClientFunc({
+        clientFunc: Client4.authorizeOAuthDevice,
+        params: [userCode, approve],
     });
 }
 
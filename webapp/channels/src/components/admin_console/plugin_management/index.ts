@@ -13,6 +13,7 @@ import {
     installPluginFromUrl,
     enablePlugin,
     disablePlugin,
+    approvePluginCapabilities,
 } from 'mattermost-redux/actions/admin';
 import {appsFeatureFlagEnabled} from 'mattermost-redux/selectors/entities/apps';
 
@@ -36,6 +37,7 @@ function mapDispatchToProps(dispatch: Dispatch) {
             getPluginStatuses,
             enablePlugin,
             disablePlugin,
+            approvePluginCapabilities,
         }, dispatch),
     };
 }
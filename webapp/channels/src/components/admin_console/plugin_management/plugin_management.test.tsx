@@ -100,6 +100,7 @@ describe('components/PluginManagement', () => {
             getPluginStatuses: jest.fn().mockResolvedValue([]),
             enablePlugin: jest.fn(),
             disablePlugin: jest.fn(),
+            approvePluginCapabilities: jest.fn(),
         },
     };
 
@@ -243,6 +244,7 @@ describe('components/PluginManagement', () => {
                 getPluginStatuses: jest.fn().mockResolvedValue([]),
                 enablePlugin: jest.fn(),
                 disablePlugin: jest.fn(),
+                approvePluginCapabilities: jest.fn(),
             },
         };
         const wrapper = shallowWithIntl(<PluginManagement {...props}/>);
@@ -333,6 +335,7 @@ describe('components/PluginManagement', () => {
                 getPluginStatuses: jest.fn().mockResolvedValue([]),
                 enablePlugin: jest.fn(),
                 disablePlugin: jest.fn(),
+                approvePluginCapabilities: jest.fn(),
             },
         };
         const wrapper = shallowWithIntl(<PluginManagement {...props}/>);
@@ -390,6 +393,7 @@ describe('components/PluginManagement', () => {
                 getPluginStatuses: jest.fn().mockResolvedValue([]),
                 enablePlugin: jest.fn(),
                 disablePlugin: jest.fn(),
+                approvePluginCapabilities: jest.fn(),
             },
         };
         const wrapper = shallowWithIntl(<PluginManagement {...props}/>);
@@ -448,6 +452,7 @@ describe('components/PluginManagement', () => {
                 getPluginStatuses: jest.fn().mockResolvedValue([]),
                 enablePlugin: jest.fn(),
                 disablePlugin: jest.fn(),
+                approvePluginCapabilities: jest.fn(),
             },
         };
         const wrapper = shallowWithIntl(<PluginManagement {...props}/>);
@@ -507,6 +512,7 @@ describe('components/PluginManagement', () => {
                 getPluginStatuses: jest.fn().mockResolvedValue([]),
                 enablePlugin: jest.fn(),
                 disablePlugin: jest.fn(),
+                approvePluginCapabilities: jest.fn(),
             },
         };
         const wrapper = shallowWithIntl(<PluginManagement {...props}/>);
@@ -177,10 +177,13 @@ type PluginStatus = {
 
 type PluginItemProps = {
     pluginStatus: PluginStatus;
+    capabilities?: string[];
+    approvedCapabilities?: string[];
     removing: boolean;
     handleEnable: (e: any) => any;
     handleDisable: (e: any) => any;
     handleRemove: (e: any) => any;
+    handleApproveCapabilities: (e: any) => any;
     showInstances: boolean;
     hasSettings: boolean;
     appsFeatureFlagEnabled: boolean;
@@ -227,10 +230,13 @@ export const searchableStrings = [
 
 const PluginItem = ({
     pluginStatus,
+    capabilities,
+    approvedCapabilities,
     removing,
     handleEnable,
     handleDisable,
     handleRemove,
+    handleApproveCapabilities,
     showInstances,
     hasSettings,
     appsFeatureFlagEnabled,
@@ -337,7 +343,48 @@ const PluginItem = ({
         );
     }
 
+    let capabilitiesList;
+    if (capabilities) {
+        capabilitiesList = (
+            <div className='pt-2'>
+                <FormattedMessage
+                    id='admin.plugin.capabilities'
+                    defaultMessage='Capabilities: {capabilities}'
+                    values={{capabilities: capabilities.length ? capabilities.join(', ') : '-'}}
+                />
+            </div>
+        );
+    }
+
     const notices = [];
+    const unapprovedCapabilities = (capabilities || []).filter((capability) => !approvedCapabilities?.includes(capability));
+    if (unapprovedCapabilities.length > 0) {
+        notices.push(
+            <div
+                key='unapproved-capabilities'
+                className='alert alert-warning'
+            >
+                <i className='fa fa-warning'/>
+                <FormattedMessage
+                    id='admin.plugin.unapproved_capabilities_warning'
+                    defaultMessage='This plugin can only be enabled once its capabilities are approved: {capabilities}.'
+                    values={{capabilities: unapprovedCapabilities.join(', ')}}
+                />
+                {' '}
+                <a
+                    data-plugin-id={pluginStatus.id}
+                    className={isDisabled ? 'disabled' : ''}
+                    onClick={handleApproveCapabilities}
+                >
+                    <FormattedMessage
+                        id='admin.plugin.approve_capabilities'
+                        defaultMessage='Approve Capabilities'
+                    />
+                </a>
+            </div>,
+        );
+    }
+
     if (pluginStatus.instances.some((instance) => instance.version !== pluginStatus.version)) {
         notices.push(
             <div
@@ -438,6 +485,7 @@ const PluginItem = ({
                 {')'}
             </div>
             {description}
+            {capabilitiesList}
             <div className='pt-2'>
                 {activateButton}
                 {removeButton}
@@ -466,6 +514,7 @@ type Props = BaseProps & {
         getPluginStatuses: () => Promise<ActionResult>;
         enablePlugin: (pluginId: string) => Promise<ActionResult>;
         disablePlugin: (pluginId: string) => Promise<ActionResult>;
+        approvePluginCapabilities: (pluginId: string, capabilities: string[]) => Promise<ActionResult>;
         installPluginFromUrl: (url: string, force: boolean) => Promise<ActionResult>;
     };
 } & WrappedComponentProps;
@@ -485,6 +534,7 @@ type State = BaseState & {
     confirmOverwriteInstallModal: boolean;
     showRemoveModal: boolean;
     resolveRemoveModal: string| null;
+    approving: string | null;
     enable: boolean;
     enableUploads: boolean;
     allowInsecureDownloadUrl: boolean;
@@ -515,6 +565,7 @@ class PluginManagement extends OLDAdminSettings<Props, State> {
             confirmOverwriteInstallModal: false,
             showRemoveModal: false,
             resolveRemoveModal: null,
+            approving: null,
         });
         this.fileInput = React.createRef();
     }
@@ -550,7 +601,10 @@ class PluginManagement extends OLDAdminSettings<Props, State> {
 
     componentDidMount() {
         if (this.state.enable) {
-            this.props.actions.getPluginStatuses().then(
+            Promise.all([
+                this.props.actions.getPluginStatuses(),
+                this.props.actions.getPlugins(),
+            ]).then(
                 () => this.setState({loading: false}),
             );
         }
@@ -798,6 +852,37 @@ class PluginManagement extends OLDAdminSettings<Props, State> {
         }
     };
 
+    showApproveCapabilitiesModal = (e: React.SyntheticEvent) => {
+        if (this.props.isDisabled) {
+            return;
+        }
+        e.preventDefault();
+        const pluginId = e.currentTarget.getAttribute('data-plugin-id');
+        this.setState({approving: pluginId});
+    };
+
+    handleApproveCapabilitiesCancel = () => {
+        this.setState({approving: null});
+    };
+
+    handleApproveCapabilities = async () => {
+        const pluginId = this.state.approving;
+        this.setState({approving: null, lastMessage: null, serverError: null});
+        if (pluginId === null) {
+            return;
+        }
+
+        const plugin = this.props.plugins[pluginId];
+        const {error} = await this.props.actions.approvePluginCapabilities(pluginId, plugin?.capabilities || []);
+        if (error) {
+            this.setState({serverError: error.message});
+            return;
+        }
+
+        // Enabled plugins are activated once their capabilities are approved.
+        await this.props.actions.getPluginStatuses();
+    };
+
     handleDisable = async (e: React.KeyboardEvent) => {
         this.setState({lastMessage: null, serverError: null});
         e.preventDefault();
@@ -892,6 +977,46 @@ class PluginManagement extends OLDAdminSettings<Props, State> {
         );
     };
 
+    renderApproveCapabilitiesModal = (pluginId: string) => {
+        const plugin = this.props.plugins[pluginId];
+
+        const title = (
+            <FormattedMessage
+                id='admin.plugin.approve_capabilities_modal.title'
+                defaultMessage='Approve plugin capabilities?'
+            />
+        );
+
+        const message = (
+            <FormattedMessage
+                id='admin.plugin.approve_capabilities_modal.desc'
+                defaultMessage='The plugin {name} will be allowed to use the following capabilities: {capabilities}.'
+                values={{
+                    name: plugin?.name || pluginId,
+                    capabilities: (plugin?.capabilities || []).join(', '),
+                }}
+            />
+        );
+
+        const approveButton = (
+            <FormattedMessage
+                id='admin.plugin.approve_capabilities_modal.approve'
+                defaultMessage='Approve'
+            />
+        );
+
+        return (
+            <ConfirmModal
+                show={true}
+                title={title}
+                message={message}
+                confirmButtonText={approveButton}
+                onConfirm={this.handleApproveCapabilities}
+                onCancel={this.handleApproveCapabilitiesCancel}
+            />
+        );
+    };
+
     renderEnablePluginsSetting = () => {
         const hideEnablePlugins = this.props.config.ExperimentalSettings && this.props.config.ExperimentalSettings.RestrictSystemAdmin;
         if (!hideEnablePlugins) {
@@ -997,10 +1122,13 @@ class PluginManagement extends OLDAdminSettings<Props, State> {
                     <PluginItem
                         key={pluginStatus.id}
                         pluginStatus={pluginStatus}
+                        capabilities={p?.capabilities}
+                        approvedCapabilities={p?.approved_capabilities}
                         removing={this.state.removing === pluginStatus.id}
                         handleEnable={this.handleEnable}
                         handleDisable={this.handleDisable}
                         handleRemove={this.showRemovePluginModal}
+                        handleApproveCapabilities={this.showApproveCapabilitiesModal}
                         showInstances={showInstances}
                         hasSettings={hasSettings}
                         appsFeatureFlagEnabled={this.props.appsFeatureFlagEnabled}
@@ -1098,6 +1226,8 @@ class PluginManagement extends OLDAdminSettings<Props, State> {
             this.handleRemovePluginCancel,
         );
 
+        const approveCapabilitiesModal = this.state.approving !== null && this.renderApproveCapabilitiesModal(this.state.approving);
+
         return (
             <div className='admin-console__wrapper'>
                 <div className='admin-console__content'>
@@ -1236,6 +1366,7 @@ class PluginManagement extends OLDAdminSettings<Props, State> {
                     </SettingsGroup>
                     {overwriteUploadPluginModal}
                     {removePluginModal}
+                    {approveCapabilitiesModal}
                 </div>
             </div>
         );
@@ -64,6 +64,9 @@ describe('components/user_settings/display/UserSettingsDisplay', () => {
             redirectUri: null,
             state: null,
             scope: null,
+            codeChallenge: null,
+            codeChallengeMethod: null,
+            nonce: null,
         };
         expect(requiredProps.actions.allowOAuth2).toHaveBeenCalled();
         expect(requiredProps.actions.allowOAuth2).toHaveBeenCalledWith(expected);
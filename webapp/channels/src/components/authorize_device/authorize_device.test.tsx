@@ -0,0 +1,75 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+import React from 'react';
+import {act} from 'react-dom/test-utils';
+
+import {mountWithIntl} from 'tests/helpers/intl-test-helper';
+
+import AuthorizeDevice from './authorize_device';
+
+describe('components/AuthorizeDevice', () => {
+    const baseProps = {
+        location: {
+            search: '',
+        },
+        actions: {
+            authorizeOAuthDevice: jest.fn().mockResolvedValue({data: true}),
+        },
+    };
+
+    it('should prefill the user code from the url', () => {
+        const props = {...baseProps, location: {search: '?user_code=BCDF-GHJK'}};
+
+        const wrapper = mountWithIntl(<AuthorizeDevice {...props}/>);
+
+        expect(wrapper.find('input#authorizeDeviceUserCodeInput').prop('value')).toBe('BCDF-GHJK');
+    });
+
+    it('should approve the device on submit', async () => {
+        const props = {
+            ...baseProps,
+            location: {search: '?user_code=BCDF-GHJK'},
+            actions: {authorizeOAuthDevice: jest.fn().mockResolvedValue({data: true})},
+        };
+
+        const wrapper = mountWithIntl(<AuthorizeDevice {...props}/>);
+
+        await act(async () => {
+            wrapper.find('form').simulate('submit', {preventDefault: () => {}});
+        });
+
+        expect(props.actions.authorizeOAuthDevice).toHaveBeenCalledWith({userCode: 'BCDF-GHJK', approve: true});
+    });
+
+    it('should deny the device', async () => {
+        const props = {
+            ...baseProps,
+            location: {search: '?user_code=BCDF-GHJK'},
+            actions: {authorizeOAuthDevice: jest.fn().mockResolvedValue({data: true})},
+        };
+
+        const wrapper = mountWithIntl(<AuthorizeDevice {...props}/>);
+
+        await act(async () => {
+            wrapper.find('button#authorizeDeviceDenyButton').simulate('click');
+        });
+
+        expect(props.actions.authorizeOAuthDevice).toHaveBeenCalledWith({userCode: 'BCDF-GHJK', approve: false});
+    });
+
+    it('should require a user code', async () => {
+        const props = {
+            ...baseProps,
+            actions: {authorizeOAuthDevice: jest.fn().mockResolvedValue({data: true})},
+        };
+
+        const wrapper = mountWithIntl(<AuthorizeDevice {...props}/>);
+
+        await act(async () => {
+            wrapper.find('form').simulate('submit', {preventDefault: () => {}});
+        });
+
+        expect(props.actions.authorizeOAuthDevice).not.toHaveBeenCalled();
+    });
+});
@@ -0,0 +1,144 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+import classNames from 'classnames';
+import React, {useState, memo} from 'react';
+import {FormattedMessage, useIntl} from 'react-intl';
+
+import type {ActionResult} from 'mattermost-redux/types/actions';
+
+export type Params = {
+    userCode: string;
+    approve: boolean;
+}
+
+export interface Props {
+    location: {search: string};
+    actions: {
+        authorizeOAuthDevice: (params: Params) => Promise<ActionResult>;
+    };
+}
+
+const AuthorizeDevice = ({location, actions}: Props) => {
+    const intl = useIntl();
+
+    // The device may link to this page with its user code, for the user not to type it.
+    const [userCode, setUserCode] = useState((new URLSearchParams(location.search)).get('user_code') ?? '');
+    const [approved, setApproved] = useState<boolean | null>(null);
+    const [error, setError] = useState<React.ReactNode>(null);
+
+    const authorize = async (approve: boolean) => {
+        if (!userCode.trim()) {
+            setError(intl.formatMessage({id: 'authorize_device.userCodeRequired', defaultMessage: 'Please enter the code displayed on your device.'}));
+            return;
+        }
+
+        const {data, error: err} = await actions.authorizeOAuthDevice({userCode: userCode.trim(), approve});
+        if (data) {
+            setApproved(approve);
+            setError(null);
+        } else if (err) {
+            setError(err.message);
+        }
+    };
+
+    const handleSubmit = (e: React.FormEvent) => {
+        e.preventDefault();
+        authorize(true);
+    };
+
+    const handleDeny = () => {
+        authorize(false);
+    };
+
+    if (approved !== null) {
+        return (
+            <div className='col-sm-12'>
+                <div className='signup-team__container'>
+                    {approved ? (
+                        <FormattedMessage
+                            id='authorize_device.approved'
+                            tagName='p'
+                            defaultMessage='Your device is connected. You can close this page and return to your device.'
+                        />
+                    ) : (
+                        <FormattedMessage
+                            id='authorize_device.denied'
+                            tagName='p'
+                            defaultMessage='Your device was denied access to your account.'
+                        />
+                    )}
+                </div>
+            </div>
+        );
+    }
+
+    const errorElement = error ? (
+        <div className='form-group has-error'>
+            <label className='control-label'>
+                {error}
+            </label>
+        </div>
+    ) : null;
+
+    return (
+        <div className='col-sm-12'>
+            <div className='signup-team__container'>
+                <FormattedMessage
+                    id='authorize_device.title'
+                    tagName='h1'
+                    defaultMessage='Connect a Device'
+                />
+                <form onSubmit={handleSubmit}>
+                    <p>
+                        <FormattedMessage
+                            id='authorize_device.enterCode'
+                            defaultMessage='Enter the code displayed on your device to allow it to access your account.'
+                        />
+                    </p>
+                    <div className={classNames('form-group', {'has-error': error})}>
+                        <input
+                            id='authorizeDeviceUserCodeInput'
+                            type='text'
+                            className='form-control'
+                            name='user_code'
+                            value={userCode}
+                            onChange={(e) => setUserCode(e.target.value)}
+                            placeholder={intl.formatMessage({
+                                id: 'authorize_device.userCode',
+                                defaultMessage: 'Code',
+                            })}
+                            autoComplete='off'
+                            spellCheck='false'
+                            autoFocus={true}
+                        />
+                    </div>
+                    {errorElement}
+                    <button
+                        id='authorizeDeviceDenyButton'
+                        type='button'
+                        className='btn btn-tertiary authorize-btn'
+                        onClick={handleDeny}
+                    >
+                        <FormattedMessage
+                            id='authorize.deny'
+                            defaultMessage='Deny'
+                        />
+                    </button>
+                    <button
+                        id='authorizeDeviceAllowButton'
+                        type='submit'
+                        className='btn btn-primary authorize-btn'
+                    >
+                        <FormattedMessage
+                            id='authorize.allow'
+                            defaultMessage='Allow'
+                        />
+                    </button>
+                </form>
+            </div>
+        </div>
+    );
+};
+
+export default memo(AuthorizeDevice);
@@ -0,0 +1,18 @@
+// Copyright (c) 2015-present Mattermost, Inc. All Rights Reserved.
+// See LICENSE.txt for license information.
+
+import {connect} from 'react-redux';
+import {bindActionCreators} from 'redux';
+import type {Dispatch} from 'redux';
+
+import {authorizeOAuthDevice} from 'actions/admin_actions.jsx';
+
+import AuthorizeDevice from './authorize_device';
+
+const mapDispatchToProps = (dispatch: Dispatch) => ({
+    actions: bindActionCreators({
+        authorizeOAuthDevice,
+    }, dispatch),
+});
+
+export default connect(null, mapDispatchToProps)(AuthorizeDevice);
@@ -443,6 +443,7 @@ describe('doesRouteBelongToTeamControllerRoutes', () => {
         expect(doesRouteBelongToTeamControllerRoutes('/mfa')).toBe(false);
         expect(doesRouteBelongToTeamControllerRoutes('/create_team')).toBe(false);
         expect(doesRouteBelongToTeamControllerRoutes('/oauth/authorize')).toBe(false);
+        expect(doesRouteBelongToTeamControllerRoutes('/oauth/device')).toBe(false);
         expect(doesRouteBelongToTeamControllerRoutes('/select_team')).toBe(false);
         expect(doesRouteBelongToTeamControllerRoutes('/admin_console')).toBe(false);
         expect(doesRouteBelongToTeamControllerRoutes('/landing')).toBe(false);
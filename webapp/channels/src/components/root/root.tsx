@@ -65,6 +65,7 @@ const LinkingLandingPage = makeAsyncComponent('LinkingLandingPage', lazy(() => i
 const AdminConsole = makeAsyncComponent('AdminConsole', lazy(() => import('components/admin_console')));
 const SelectTeam = makeAsyncComponent('SelectTeam', lazy(() => import('components/select_team')));
 const Authorize = makeAsyncComponent('Authorize', lazy(() => import('components/authorize')));
+const AuthorizeDevice = makeAsyncComponent('AuthorizeDevice', lazy(() => import('components/authorize_device')));
 const CreateTeam = makeAsyncComponent('CreateTeam', lazy(() => import('components/create_team')));
 const Mfa = makeAsyncComponent('Mfa', lazy(() => import('components/mfa/mfa_controller')));
 const PreparingWorkspace = makeAsyncComponent('PreparingWorkspace', lazy(() => import('components/preparing_workspace')));
@@ -474,6 +475,10 @@ export default class Root extends React.PureComponent<Props, State> {
                         path={'/oauth/authorize'}
                         component={Authorize}
                     />
+                    <LoggedInHFTRoute
+                        path={'/oauth/device'}
+                        component={AuthorizeDevice}
+                    />
                     <LoggedInHFTRoute
                         path={'/create_team'}
                         component={CreateTeam}
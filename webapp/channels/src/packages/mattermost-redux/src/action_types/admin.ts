@@ -41,6 +41,7 @@ export default keyMirror({
     REMOVED_PLUGIN: null,
     ENABLED_PLUGIN: null,
     DISABLED_PLUGIN: null,
+    APPROVED_PLUGIN_CAPABILITIES: null,
 
     RECEIVED_SAML_METADATA_RESPONSE: null,
 
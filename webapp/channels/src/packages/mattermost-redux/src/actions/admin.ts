@@ -527,6 +527,17 @@ export function enablePlugin(pluginId: string): ActionFuncAsync {
     };
 }
 
+export function approvePluginCapabilities(pluginId: string, capabilities: string[]) {
+    return bindClientFunc({
+        clientFunc: Client4.approvePluginCapabilities,
+        onSuccess: [AdminTypes.APPROVED_PLUGIN_CAPABILITIES],
+        params: [
+            pluginId,
+            capabilities,
+        ],
+    });
+}
+
 export function disablePlugin(pluginId: string): ActionFuncAsync {
     return async (dispatch, getState) => {
         dispatch({type: AdminTypes.DISABLE_PLUGIN_REQUEST, data: pluginId});
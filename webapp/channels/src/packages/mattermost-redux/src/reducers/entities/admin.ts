@@ -70,14 +70,21 @@ function config(state: Partial<AdminConfig> = {}, action: MMReduxAction) {
     case AdminTypes.ENABLED_PLUGIN: {
         const nextPluginSettings = {...state.PluginSettings!};
         const nextPluginStates = {...nextPluginSettings.PluginStates};
-        nextPluginStates[action.data] = {Enable: true};
+        nextPluginStates[action.data] = {...nextPluginStates[action.data], Enable: true};
         nextPluginSettings.PluginStates = nextPluginStates;
         return {...state, PluginSettings: nextPluginSettings};
     }
     case AdminTypes.DISABLED_PLUGIN: {
         const nextPluginSettings = {...state.PluginSettings!};
         const nextPluginStates = {...nextPluginSettings.PluginStates};
-        nextPluginStates[action.data] = {Enable: false};
+        nextPluginStates[action.data] = {...nextPluginStates[action.data], Enable: false};
+        nextPluginSettings.PluginStates = nextPluginStates;
+        return {...state, PluginSettings: nextPluginSettings};
+    }
+    case AdminTypes.APPROVED_PLUGIN_CAPABILITIES: {
+        const nextPluginSettings = {...state.PluginSettings!};
+        const nextPluginStates = {...nextPluginSettings.PluginStates};
+        nextPluginStates[action.data.id] = {Enable: false, ...nextPluginStates[action.data.id], ApprovedCapabilities: action.data.approved_capabilities};
         nextPluginSettings.PluginStates = nextPluginStates;
         return {...state, PluginSettings: nextPluginSettings};
     }
@@ -418,6 +425,13 @@ function plugins(state: Record<string, PluginRedux> = {}, action: MMReduxAction)
         }
         return state;
     }
+    case AdminTypes.APPROVED_PLUGIN_CAPABILITIES: {
+        const plugin = state[action.data.id];
+        if (!plugin) {
+            return state;
+        }
+        return {...state, [action.data.id]: {...plugin, approved_capabilities: action.data.approved_capabilities}};
+    }
     case UserTypes.LOGOUT_SUCCESS:
         return {};
 
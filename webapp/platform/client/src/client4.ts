@@ -106,6 +106,7 @@ import type {
 import type {MfaSecret} from '@mattermost/types/mfa';
 import type {
     ClientPluginManifest,
+    PluginInfo,
     PluginManifest,
     PluginsResponse,
     PluginStatus,
@@ -1124,10 +1125,17 @@ export default class Client4 {
         );
     };
 
-    authorizeOAuthApp = (responseType: string, clientId: string, redirectUri: string, state: string, scope: string) => {
+    authorizeOAuthApp = (responseType: string, clientId: string, redirectUri: string, state: string, scope: string, codeChallenge = '', codeChallengeMethod = '', nonce = '') => {
         return this.doFetch<void>(
             `${this.url}/oauth/authorize`,
-            {method: 'post', body: JSON.stringify({client_id: clientId, response_type: responseType, redirect_uri: redirectUri, state, scope})},
+            {method: 'post', body: JSON.stringify({client_id: clientId, response_type: responseType, redirect_uri: redirectUri, state, scope, code_challenge: codeChallenge, code_challenge_method: codeChallengeMethod, nonce})},
+        );
+    };
+
+    authorizeOAuthDevice = (userCode: string, approve: boolean) => {
+        return this.doFetch<StatusOK>(
+            `${this.url}/oauth/device`,
+            {method: 'post', body: JSON.stringify({user_code: userCode, approve})},
         );
     };
 
@@ -3595,6 +3603,13 @@ export default class Client4 {
         );
     };
 
+    approvePluginCapabilities = (pluginId: string, capabilities: string[]) => {
+        return this.doFetch<PluginInfo>(
+            `${this.getPluginRoute(pluginId)}/capabilities/approve`,
+            {method: 'post', body: JSON.stringify(capabilities)},
+        );
+    };
+
     // Groups
     linkGroupSyncable = (groupID: string, syncableID: string, syncableType: string, patch: Partial<SyncablePatch>) => {
         return this.doFetch<GroupSyncable>(
@@ -904,7 +904,7 @@ export type PluginSettings = {
     Directory: string;
     ClientDirectory: string;
     Plugins: Record<string, any>;
-    PluginStates: Record<string, { Enable: boolean }>;
+    PluginStates: Record<string, { Enable: boolean; ApprovedCapabilities?: string[] }>;
     EnableMarketplace: boolean;
     EnableRemoteMarketplace: boolean;
     AutomaticPrepackagedPlugins: boolean;
@@ -23,9 +23,14 @@ export type PluginManifest = {
     webapp?: PluginManifestWebapp;
     settings_schema?: PluginSettingsSchema;
     props?: Record<string, any>;
+    capabilities?: string[];
 };
 
-export type PluginRedux = PluginManifest & {active: boolean};
+export type PluginInfo = PluginManifest & {
+    approved_capabilities?: string[];
+};
+
+export type PluginRedux = PluginInfo & {active: boolean};
 
 export type PluginManifestServer = {
     executables?: {
@@ -75,8 +80,8 @@ export type PluginSettingOption = {
 };
 
 export type PluginsResponse = {
-    active: PluginManifest[];
-    inactive: PluginManifest[];
+    active: PluginInfo[];
+    inactive: PluginInfo[];
 };
 
 export type PluginStatus = {